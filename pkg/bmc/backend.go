@@ -0,0 +1,293 @@
+package bmc
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/golang/glog"
+	"github.com/stmcginnis/gofish/redfish"
+)
+
+// Protocol is the protocol used to access the BMC of a host.
+type Protocol string
+
+const (
+	// ProtocolRedfish accesses the BMC through the Redfish API.
+	ProtocolRedfish Protocol = "redfish"
+	// ProtocolIPMI accesses the BMC through IPMI over LAN (lanplus interface).
+	ProtocolIPMI Protocol = "ipmi"
+)
+
+// BootDevice is a protocol agnostic boot device that can be used as boot override.
+type BootDevice string
+
+const (
+	// BootDevicePXE boots from the network using PXE.
+	BootDevicePXE BootDevice = "pxe"
+	// BootDeviceDisk boots from the default hard drive.
+	BootDeviceDisk BootDevice = "disk"
+	// BootDeviceCD boots from the CD/DVD drive, including virtual media.
+	BootDeviceCD BootDevice = "cdrom"
+	// BootDeviceBIOS boots into the BIOS setup.
+	BootDeviceBIOS BootDevice = "bios"
+)
+
+// SensorType is the kind of value measured by a sensor.
+type SensorType string
+
+const (
+	// SensorTypeTemperature is used for temperature sensors.
+	SensorTypeTemperature SensorType = "Temperature"
+	// SensorTypeFan is used for fan speed sensors.
+	SensorTypeFan SensorType = "Fan"
+	// SensorTypeVoltage is used for voltage sensors.
+	SensorTypeVoltage SensorType = "Voltage"
+	// SensorTypePower is used for power consumption sensors.
+	SensorTypePower SensorType = "Power"
+	// SensorTypeOther is used for any sensor not matching the other types.
+	SensorTypeOther SensorType = "Other"
+)
+
+// SensorReading holds the current reading of a single sensor of the system.
+type SensorReading struct {
+	// Name is the name of the sensor as reported by the BMC.
+	Name string
+	// Type is the kind of value measured by the sensor.
+	Type SensorType
+	// Reading is the current value of the sensor.
+	Reading float64
+	// Units are the units of Reading, e.g. "Cel" or "RPM".
+	Units string
+	// Health is the health of the sensor, one of OK/Warning/Critical.
+	Health string
+}
+
+// Backend holds the power, boot and sensor operations that can be performed against the BMC of a host regardless of
+// the protocol used to access it. Both BMC (Redfish) and IPMI implement it, so code using this interface works across
+// vendors without branching on the protocol.
+type Backend interface {
+	// SystemPowerOn powers on the system.
+	SystemPowerOn() error
+	// SystemPowerOff performs a non-graceful power off of the system.
+	SystemPowerOff() error
+	// SystemForceReset performs a non-graceful reset of the system.
+	SystemForceReset() error
+	// SystemGracefulShutdown performs a graceful shutdown of the system.
+	SystemGracefulShutdown() error
+	// SystemPowerCycle powers the system off and on again.
+	SystemPowerCycle() error
+	// SystemPowerState returns the current power state of the system, e.g. On or Off.
+	SystemPowerState() (string, error)
+	// SetSystemBootDevice sets the device to boot from, either only for the next boot or persistently.
+	SetSystemBootDevice(device BootDevice, once bool) error
+	// SystemSensors returns the current readings of the system sensors.
+	SystemSensors() ([]SensorReading, error)
+}
+
+var (
+	_ Backend = (*BMC)(nil)
+	_ Backend = (*IPMI)(nil)
+
+	redfishBootDevices = map[BootDevice]redfish.BootSourceOverrideTarget{
+		BootDevicePXE:  redfish.PxeBootSourceOverrideTarget,
+		BootDeviceDisk: redfish.HddBootSourceOverrideTarget,
+		BootDeviceCD:   redfish.CdBootSourceOverrideTarget,
+		BootDeviceBIOS: redfish.BiosSetupBootSourceOverrideTarget,
+	}
+
+	// bmcAddressSchemes maps the BareMetalHost BMC address schemes, without their transport suffix, to the protocol of
+	// their backend. Vendor schemes that are not based on Redfish or IPMI, like "idrac" or "ilo5", are not supported.
+	bmcAddressSchemes = map[string]Protocol{
+		"ipmi":                 ProtocolIPMI,
+		"redfish":              ProtocolRedfish,
+		"redfish-virtualmedia": ProtocolRedfish,
+		"idrac-redfish":        ProtocolRedfish,
+		"idrac-virtualmedia":   ProtocolRedfish,
+		"ilo5-redfish":         ProtocolRedfish,
+	}
+)
+
+// NewBackend returns the Backend for the given protocol, configured to access host with the provided credentials.
+// The host may include a port, e.g. "10.1.1.1:623" for IPMI.
+func NewBackend(protocol Protocol, host, username, password string) (Backend, error) {
+	glog.V(100).Infof("Creating new BMC backend for host %s using protocol %s", host, protocol)
+
+	switch protocol {
+	case ProtocolRedfish:
+		bmc := New(host).WithRedfishUser(username, password)
+		if valid, err := bmc.validateRedfish(); !valid {
+			return nil, err
+		}
+
+		return bmc, nil
+	case ProtocolIPMI:
+		hostname, port, err := splitHostPort(host)
+		if err != nil {
+			return nil, err
+		}
+
+		ipmi := NewIPMI(hostname).WithIPMIUser(username, password)
+		if port != 0 {
+			ipmi = ipmi.WithIPMIPort(port)
+		}
+
+		if valid, err := ipmi.validateUser(); !valid {
+			return nil, err
+		}
+
+		return ipmi, nil
+	default:
+		glog.V(100).Infof("The BMC protocol %s is not supported", protocol)
+
+		return nil, fmt.Errorf("bmc protocol %s is not supported", protocol)
+	}
+}
+
+// NewBackendFromAddress returns the Backend for a BMC address in the format used by BareMetalHosts, e.g.
+// "ipmi://10.1.1.1:623" or "redfish-virtualmedia+https://10.1.1.1/redfish/v1/Systems/1". For Redfish based schemes
+// the path of the address selects the system. Only the https transport is supported for Redfish.
+func NewBackendFromAddress(address, username, password string) (Backend, error) {
+	glog.V(100).Infof("Creating new BMC backend for address %s", address)
+
+	parsedAddress, err := url.Parse(address)
+	if err != nil {
+		glog.V(100).Infof("Failed to parse BMC address %s: %v", address, err)
+
+		return nil, fmt.Errorf("failed to parse bmc address %s: %w", address, err)
+	}
+
+	if parsedAddress.Host == "" {
+		glog.V(100).Infof("The BMC address %s has no host", address)
+
+		return nil, fmt.Errorf("bmc address %s has no host", address)
+	}
+
+	scheme, transport, _ := strings.Cut(strings.ToLower(parsedAddress.Scheme), "+")
+	protocol, found := bmcAddressSchemes[scheme]
+
+	if !found || (transport != "" && (protocol != ProtocolRedfish || transport != "https")) {
+		glog.V(100).Infof("The BMC address scheme %q is not supported", parsedAddress.Scheme)
+
+		return nil, fmt.Errorf("bmc address scheme %q is not supported", parsedAddress.Scheme)
+	}
+
+	if protocol == ProtocolIPMI {
+		return NewBackend(ProtocolIPMI, parsedAddress.Host, username, password)
+	}
+
+	bmc := New(parsedAddress.Host).WithRedfishUser(username, password)
+
+	if systemPath := strings.TrimSuffix(parsedAddress.Path, "/"); systemPath != "" {
+		bmc = bmc.WithRedfishSystemPath(systemPath)
+	}
+
+	if valid, err := bmc.validateRedfish(); !valid {
+		return nil, err
+	}
+
+	return bmc, nil
+}
+
+// SetSystemBootDevice sets the boot source override of the system to the given device using the Redfish API. When
+// once is true the override is only used in the next boot.
+func (bmc *BMC) SetSystemBootDevice(device BootDevice, once bool) error {
+	target, found := redfishBootDevices[device]
+	if !found {
+		glog.V(100).Infof("The boot device %s is not supported", device)
+
+		return fmt.Errorf("boot device %s is not supported", device)
+	}
+
+	return bmc.SetSystemBootOverride(target, once)
+}
+
+// SystemSensors returns the temperature and fan readings of all the chassis with a thermal resource using the Redfish
+// API.
+func (bmc *BMC) SystemSensors() ([]SensorReading, error) {
+	if valid, err := bmc.validateRedfish(); !valid {
+		return nil, err
+	}
+
+	glog.V(100).Info("Collecting sensor readings from bmc's redfish endpoint")
+
+	redfishClient, cancel, err := redfishConnect(
+		bmc.host,
+		bmc.redfishUser.Name,
+		bmc.redfishUser.Password,
+		bmc.timeOuts.Redfish)
+	if err != nil {
+		glog.V(100).Infof("Redfish connection error: %v", err)
+
+		return nil, fmt.Errorf("redfish connection error: %w", err)
+	}
+
+	defer func() {
+		redfishClient.Logout()
+		cancel()
+	}()
+
+	chassisCollection, err := redfishClient.GetService().Chassis()
+	if err != nil {
+		glog.V(100).Infof("Failed to get redfish chassis collection: %v", err)
+
+		return nil, fmt.Errorf("failed to get redfish chassis collection: %w", err)
+	}
+
+	var readings []SensorReading
+
+	for _, chassis := range chassisCollection {
+		thermal, err := chassis.Thermal()
+		if err != nil {
+			glog.V(100).Infof("Failed to get thermal of redfish chassis %s: %v", chassis.ID, err)
+
+			return nil, fmt.Errorf("failed to get thermal of redfish chassis %s: %w", chassis.ID, err)
+		}
+
+		if thermal == nil {
+			continue
+		}
+
+		for _, temperature := range thermal.Temperatures {
+			readings = append(readings, SensorReading{
+				Name:    temperature.Name,
+				Type:    SensorTypeTemperature,
+				Reading: float64(temperature.ReadingCelsius),
+				Units:   "Cel",
+				Health:  string(temperature.Status.Health),
+			})
+		}
+
+		for _, fan := range thermal.Fans {
+			readings = append(readings, SensorReading{
+				Name:    fan.Name,
+				Type:    SensorTypeFan,
+				Reading: float64(fan.Reading),
+				Units:   string(fan.ReadingUnits),
+				Health:  string(fan.Status.Health),
+			})
+		}
+	}
+
+	return readings, nil
+}
+
+// splitHostPort splits host into its hostname and port. The port is 0 if host does not include one.
+func splitHostPort(host string) (string, uint16, error) {
+	parsedHost, err := url.Parse("//" + host)
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to parse host %s: %w", host, err)
+	}
+
+	if parsedHost.Port() == "" {
+		return parsedHost.Hostname(), 0, nil
+	}
+
+	port, err := strconv.ParseUint(parsedHost.Port(), 10, 16)
+	if err != nil {
+		return "", 0, fmt.Errorf("invalid port in host %s: %w", host, err)
+	}
+
+	return parsedHost.Hostname(), uint16(port), nil
+}
@@ -0,0 +1,226 @@
+package bmc
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewBackend(t *testing.T) {
+	testCases := []struct {
+		protocol      Protocol
+		host          string
+		username      string
+		expectedType  Backend
+		expectedError string
+	}{
+		{
+			protocol:      ProtocolRedfish,
+			host:          defaultHost,
+			username:      defaultUsername,
+			expectedType:  &BMC{},
+			expectedError: "",
+		},
+		{
+			protocol:      ProtocolIPMI,
+			host:          defaultHost + ":6230",
+			username:      defaultUsername,
+			expectedType:  &IPMI{},
+			expectedError: "",
+		},
+		{
+			protocol:      ProtocolRedfish,
+			host:          "",
+			username:      defaultUsername,
+			expectedError: "bmc 'host' cannot be empty",
+		},
+		{
+			protocol:      ProtocolIPMI,
+			host:          defaultHost,
+			username:      "",
+			expectedError: "ipmi 'username' cannot be empty",
+		},
+		{
+			protocol:      "snmp",
+			host:          defaultHost,
+			username:      defaultUsername,
+			expectedError: "bmc protocol snmp is not supported",
+		},
+	}
+
+	for _, testCase := range testCases {
+		backend, err := NewBackend(testCase.protocol, testCase.host, testCase.username, defaultPassword)
+		if testCase.expectedError != "" {
+			assert.EqualError(t, err, testCase.expectedError)
+			assert.Nil(t, backend)
+
+			continue
+		}
+
+		assert.NoError(t, err)
+		assert.IsType(t, testCase.expectedType, backend)
+	}
+
+	backend, err := NewBackend(ProtocolIPMI, defaultHost+":6230", defaultUsername, defaultPassword)
+	assert.NoError(t, err)
+
+	ipmi, ok := backend.(*IPMI)
+	assert.True(t, ok)
+	assert.Equal(t, defaultHost, ipmi.host)
+	assert.Equal(t, uint16(6230), ipmi.port)
+}
+
+func TestNewBackendFromAddress(t *testing.T) {
+	testCases := []struct {
+		address            string
+		expectedType       Backend
+		expectedSystemPath string
+		expectedError      string
+	}{
+		{
+			address:      "ipmi://1.2.3.4:623",
+			expectedType: &IPMI{},
+		},
+		{
+			address:            "redfish://1.2.3.4/redfish/v1/Systems/1",
+			expectedType:       &BMC{},
+			expectedSystemPath: "/redfish/v1/Systems/1",
+		},
+		{
+			address:            "idrac-redfish://1.2.3.4/redfish/v1/Systems/System.Embedded.1",
+			expectedType:       &BMC{},
+			expectedSystemPath: "/redfish/v1/Systems/System.Embedded.1",
+		},
+		{
+			address:            "idrac-virtualmedia+https://1.2.3.4/redfish/v1/Systems/System.Embedded.1/",
+			expectedType:       &BMC{},
+			expectedSystemPath: "/redfish/v1/Systems/System.Embedded.1",
+		},
+		{
+			address:            "redfish-virtualmedia+https://1.2.3.4/redfish/v1/Systems/1",
+			expectedType:       &BMC{},
+			expectedSystemPath: "/redfish/v1/Systems/1",
+		},
+		{
+			address:      "redfish://1.2.3.4",
+			expectedType: &BMC{},
+		},
+		{
+			address:       "redfish+http://1.2.3.4/redfish/v1/Systems/1",
+			expectedError: "bmc address scheme \"redfish+http\" is not supported",
+		},
+		{
+			address:       "idrac://1.2.3.4",
+			expectedError: "bmc address scheme \"idrac\" is not supported",
+		},
+		{
+			address:       "libvirt://1.2.3.4",
+			expectedError: "bmc address scheme \"libvirt\" is not supported",
+		},
+		{
+			address:       "1.2.3.4",
+			expectedError: "bmc address 1.2.3.4 has no host",
+		},
+	}
+
+	for _, testCase := range testCases {
+		backend, err := NewBackendFromAddress(testCase.address, defaultUsername, defaultPassword)
+		if testCase.expectedError != "" {
+			assert.EqualError(t, err, testCase.expectedError)
+
+			continue
+		}
+
+		assert.NoError(t, err)
+		assert.IsType(t, testCase.expectedType, backend)
+
+		if bmc, ok := backend.(*BMC); ok {
+			assert.Equal(t, testCase.expectedSystemPath, bmc.systemPath)
+		}
+	}
+}
+
+func TestBMCSetSystemBootDevice(t *testing.T) {
+	testCases := []struct {
+		device         BootDevice
+		once           bool
+		expectedTarget string
+		expectedMode   string
+		expectedError  string
+	}{
+		{
+			device:         BootDevicePXE,
+			once:           true,
+			expectedTarget: "Pxe",
+			expectedMode:   "Once",
+		},
+		{
+			device:         BootDeviceDisk,
+			once:           false,
+			expectedTarget: "Hdd",
+			expectedMode:   "Continuous",
+		},
+		{
+			device:        "floppy",
+			expectedError: "boot device floppy is not supported",
+		},
+	}
+
+	for _, testCase := range testCases {
+		var patchBody struct {
+			Boot struct {
+				BootSourceOverrideTarget  string
+				BootSourceOverrideEnabled string
+			}
+		}
+
+		// Create fake redfish endpoint.
+		redfishServer := createFakeRedfishLocalServer(false, redfishAPIResponseCallbacks{
+			secureBoot: func(r *http.Request) {
+				if r.Method != http.MethodPatch {
+					return
+				}
+
+				body, _ := io.ReadAll(r.Body)
+				_ = json.Unmarshal(body, &patchBody)
+			},
+		})
+
+		host := strings.Split(redfishServer.URL, "//")[1]
+		bmc := New(host).WithRedfishUser(defaultUsername, defaultPassword)
+
+		err := bmc.SetSystemBootDevice(testCase.device, testCase.once)
+		if testCase.expectedError != "" {
+			assert.EqualError(t, err, testCase.expectedError)
+		} else {
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.expectedTarget, patchBody.Boot.BootSourceOverrideTarget)
+			assert.Equal(t, testCase.expectedMode, patchBody.Boot.BootSourceOverrideEnabled)
+		}
+
+		redfishServer.Close()
+	}
+}
+
+func TestBMCSystemSensors(t *testing.T) {
+	// Create fake redfish endpoint.
+	redfishServer := createFakeRedfishLocalServer(false, redfishAPIResponseCallbacks{})
+	defer redfishServer.Close()
+
+	host := strings.Split(redfishServer.URL, "//")[1]
+	bmc := New(host).WithRedfishUser(defaultUsername, defaultPassword)
+
+	expectedReadings := []SensorReading{
+		{Name: "System Board Inlet Temp", Type: SensorTypeTemperature, Reading: 23, Units: "Cel", Health: "OK"},
+		{Name: "CPU1 Temp", Type: SensorTypeTemperature, Reading: 91, Units: "Cel", Health: "Warning"},
+		{Name: "System Board Fan1A", Type: SensorTypeFan, Reading: 6840, Units: "RPM", Health: "OK"},
+	}
+
+	readings, err := bmc.SystemSensors()
+	assert.NoError(t, err)
+	assert.Equal(t, expectedReadings, readings)
+}
@@ -0,0 +1,222 @@
+package bmc
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/golang/glog"
+	"github.com/stmcginnis/gofish"
+	"github.com/stmcginnis/gofish/common"
+	"github.com/stmcginnis/gofish/redfish"
+)
+
+// BIOSAttributes returns the current BIOS attributes of the system using the Redfish API. Attribute values are
+// strings, numbers or booleans depending on the attribute type defined in the BIOS attribute registry.
+func (bmc *BMC) BIOSAttributes() (map[string]interface{}, error) {
+	if valid, err := bmc.validateRedfish(); !valid {
+		return nil, err
+	}
+
+	glog.V(100).Infof("Getting BIOS attributes from bmc's redfish endpoint")
+
+	redfishClient, cancel, err := redfishConnect(
+		bmc.host,
+		bmc.redfishUser.Name,
+		bmc.redfishUser.Password,
+		bmc.timeOuts.Redfish)
+	if err != nil {
+		glog.V(100).Infof("Redfish connection error: %v", err)
+
+		return nil, fmt.Errorf("redfish connection error: %w", err)
+	}
+
+	defer func() {
+		redfishClient.Logout()
+		cancel()
+	}()
+
+	bios, err := redfishGetSystemBios(redfishClient, bmc.redfishSystemRef())
+	if err != nil {
+		glog.V(100).Infof("Failed to get redfish system's bios: %v", err)
+
+		return nil, fmt.Errorf("failed to get bios: %w", err)
+	}
+
+	return bios.Attributes, nil
+}
+
+// BIOSPendingAttributes returns the BIOS attributes that have been set but not yet applied, which happens once the
+// system is reset. An empty map is returned if there are no pending attributes or the BMC does not expose a separate
+// settings resource for the BIOS.
+func (bmc *BMC) BIOSPendingAttributes() (map[string]interface{}, error) {
+	if valid, err := bmc.validateRedfish(); !valid {
+		return nil, err
+	}
+
+	glog.V(100).Infof("Getting BIOS pending attributes from bmc's redfish endpoint")
+
+	redfishClient, cancel, err := redfishConnect(
+		bmc.host,
+		bmc.redfishUser.Name,
+		bmc.redfishUser.Password,
+		bmc.timeOuts.Redfish)
+	if err != nil {
+		glog.V(100).Infof("Redfish connection error: %v", err)
+
+		return nil, fmt.Errorf("redfish connection error: %w", err)
+	}
+
+	defer func() {
+		redfishClient.Logout()
+		cancel()
+	}()
+
+	bios, err := redfishGetSystemBios(redfishClient, bmc.redfishSystemRef())
+	if err != nil {
+		glog.V(100).Infof("Failed to get redfish system's bios: %v", err)
+
+		return nil, fmt.Errorf("failed to get bios: %w", err)
+	}
+
+	settingsURI, err := redfishGetSettingsObjectURI(redfishClient, bios.ODataID)
+	if err != nil {
+		glog.V(100).Infof("Failed to get bios settings object: %v", err)
+
+		return nil, fmt.Errorf("failed to get bios settings object: %w", err)
+	}
+
+	pendingAttributes := map[string]interface{}{}
+
+	if settingsURI == "" || settingsURI == bios.ODataID {
+		glog.V(100).Infof("No bios settings object found, there are no pending attributes")
+
+		return pendingAttributes, nil
+	}
+
+	pendingBios, err := redfish.GetBios(redfishClient, settingsURI)
+	if err != nil {
+		glog.V(100).Infof("Failed to get bios pending settings: %v", err)
+
+		return nil, fmt.Errorf("failed to get bios pending settings: %w", err)
+	}
+
+	// Some BMCs return the full set of attributes in the settings object, so only report the ones that differ.
+	for name, value := range pendingBios.Attributes {
+		if currentValue, found := bios.Attributes[name]; !found || !reflect.DeepEqual(currentValue, value) {
+			pendingAttributes[name] = value
+		}
+	}
+
+	return pendingAttributes, nil
+}
+
+// SetBIOSAttributes sets the given BIOS attributes using the Redfish API. The attributes are applied when the system is
+// reset, so they will be reported by BIOSPendingAttributes until then.
+func (bmc *BMC) SetBIOSAttributes(attributes map[string]interface{}) error {
+	return bmc.SetBIOSAttributesApplyAt(attributes, common.OnResetApplyTime)
+}
+
+// SetBIOSAttributesApplyAt sets the given BIOS attributes using the Redfish API, requesting the BMC to apply them at
+// the given apply time. If applyTime is empty, the BMC default apply time is used.
+func (bmc *BMC) SetBIOSAttributesApplyAt(attributes map[string]interface{}, applyTime common.ApplyTime) error {
+	if valid, err := bmc.validateRedfish(); !valid {
+		return err
+	}
+
+	glog.V(100).Infof("Setting BIOS attributes %v with apply time %q from bmc's redfish endpoint", attributes, applyTime)
+
+	if len(attributes) == 0 {
+		glog.V(100).Infof("The BIOS attributes are empty")
+
+		return fmt.Errorf("bios 'attributes' cannot be empty")
+	}
+
+	redfishClient, cancel, err := redfishConnect(
+		bmc.host,
+		bmc.redfishUser.Name,
+		bmc.redfishUser.Password,
+		bmc.timeOuts.Redfish)
+	if err != nil {
+		glog.V(100).Infof("Redfish connection error: %v", err)
+
+		return fmt.Errorf("redfish connection error: %w", err)
+	}
+
+	defer func() {
+		redfishClient.Logout()
+		cancel()
+	}()
+
+	bios, err := redfishGetSystemBios(redfishClient, bmc.redfishSystemRef())
+	if err != nil {
+		glog.V(100).Infof("Failed to get redfish system's bios: %v", err)
+
+		return fmt.Errorf("failed to get bios: %w", err)
+	}
+
+	if applyTime != "" && !isApplyTimeSupported(applyTime, bios.AllowedAttributeUpdateApplyTimes()) {
+		glog.V(100).Infof("Apply time %s is not supported (supported apply times: %v)",
+			applyTime, bios.AllowedAttributeUpdateApplyTimes())
+
+		return fmt.Errorf("apply time %s is not supported (supported apply times: %v)",
+			applyTime, bios.AllowedAttributeUpdateApplyTimes())
+	}
+
+	err = bios.UpdateBiosAttributesApplyAt(attributes, applyTime)
+	if err != nil {
+		glog.V(100).Infof("Failed to update bios attributes: %v", err)
+
+		return fmt.Errorf("failed to update bios attributes: %w", err)
+	}
+
+	return nil
+}
+
+// redfishGetSystemBios uses the provided gofish APIClient and the system reference to get the Bios resource for a
+// system.
+func redfishGetSystemBios(redfishClient *gofish.APIClient, systemRef redfishSystemRef) (*redfish.Bios, error) {
+	system, err := redfishGetSystem(redfishClient, systemRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get redfish system: %w", err)
+	}
+
+	bios, err := system.Bios()
+	if err != nil {
+		return nil, err
+	}
+
+	return bios, nil
+}
+
+// redfishGetSettingsObjectURI returns the URI of the @Redfish.Settings object of the resource located at uri. An empty
+// string is returned when the resource has no settings object.
+func redfishGetSettingsObjectURI(redfishClient *gofish.APIClient, uri string) (string, error) {
+	resp, err := redfishClient.Get(uri)
+	if err != nil {
+		return "", err
+	}
+
+	defer resp.Body.Close()
+
+	var resource struct {
+		Settings common.Settings `json:"@Redfish.Settings"`
+	}
+
+	err = json.NewDecoder(resp.Body).Decode(&resource)
+	if err != nil {
+		return "", err
+	}
+
+	return resource.Settings.SettingsObject.String(), nil
+}
+
+func isApplyTimeSupported(applyTime common.ApplyTime, supportedApplyTimes []common.ApplyTime) bool {
+	for _, supportedApplyTime := range supportedApplyTimes {
+		if supportedApplyTime == applyTime {
+			return true
+		}
+	}
+
+	return false
+}
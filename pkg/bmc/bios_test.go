@@ -0,0 +1,98 @@
+package bmc
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stmcginnis/gofish/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBMCBIOSAttributes(t *testing.T) {
+	// Create fake redfish endpoint.
+	redfishServer := createFakeRedfishLocalServer(false, redfishAPIResponseCallbacks{})
+	defer redfishServer.Close()
+
+	host := strings.Split(redfishServer.URL, "//")[1]
+	bmc := New(host).WithRedfishUser(defaultUsername, defaultPassword)
+
+	attributes, err := bmc.BIOSAttributes()
+	assert.NoError(t, err)
+	assert.Len(t, attributes, 5)
+	assert.Equal(t, "Uefi", attributes["BootMode"])
+	assert.Equal(t, "Disabled", attributes["SriovGlobalEnable"])
+}
+
+func TestBMCBIOSPendingAttributes(t *testing.T) {
+	// Create fake redfish endpoint.
+	redfishServer := createFakeRedfishLocalServer(false, redfishAPIResponseCallbacks{})
+	defer redfishServer.Close()
+
+	host := strings.Split(redfishServer.URL, "//")[1]
+	bmc := New(host).WithRedfishUser(defaultUsername, defaultPassword)
+
+	pendingAttributes, err := bmc.BIOSPendingAttributes()
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"SriovGlobalEnable": "Enabled"}, pendingAttributes)
+}
+
+func TestBMCSetBIOSAttributesApplyAt(t *testing.T) {
+	testCases := []struct {
+		attributes    map[string]interface{}
+		applyTime     common.ApplyTime
+		expectedError string
+	}{
+		{
+			attributes:    map[string]interface{}{"SriovGlobalEnable": "Enabled"},
+			applyTime:     common.OnResetApplyTime,
+			expectedError: "",
+		},
+		{
+			attributes: map[string]interface{}{"SriovGlobalEnable": "Enabled"},
+			applyTime:  common.ImmediateApplyTime,
+			expectedError: "apply time Immediate is not supported (supported apply times: " +
+				"[OnReset AtMaintenanceWindowStart InMaintenanceWindowOnReset])",
+		},
+		{
+			attributes:    map[string]interface{}{},
+			applyTime:     common.OnResetApplyTime,
+			expectedError: "bios 'attributes' cannot be empty",
+		},
+	}
+
+	for _, testCase := range testCases {
+		var patchBody map[string]interface{}
+
+		// Create fake redfish endpoint.
+		redfishServer := createFakeRedfishLocalServer(false, redfishAPIResponseCallbacks{
+			bios: func(r *http.Request) {
+				if r.Method != http.MethodPatch {
+					return
+				}
+
+				body, _ := io.ReadAll(r.Body)
+				_ = json.Unmarshal(body, &patchBody)
+			},
+		})
+
+		host := strings.Split(redfishServer.URL, "//")[1]
+		bmc := New(host).WithRedfishUser(defaultUsername, defaultPassword)
+
+		err := bmc.SetBIOSAttributesApplyAt(testCase.attributes, testCase.applyTime)
+		if testCase.expectedError != "" {
+			assert.EqualError(t, err, testCase.expectedError)
+			assert.Nil(t, patchBody)
+		} else {
+			assert.NoError(t, err)
+			assert.Equal(t, map[string]interface{}{
+				"Attributes":                 map[string]interface{}{"SriovGlobalEnable": "Enabled"},
+				"@Redfish.SettingsApplyTime": map[string]interface{}{"ApplyTime": "OnReset"},
+			}, patchBody)
+		}
+
+		redfishServer.Close()
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"strings"
 	"time"
 
 	"github.com/golang/glog"
@@ -62,6 +63,7 @@ type BMC struct {
 	timeOuts    TimeOuts
 
 	systemIndex       int
+	systemPath        string
 	powerControlIndex int
 
 	sshSessionForSerialConsole *ssh.Session
@@ -165,6 +167,26 @@ func (bmc *BMC) WithRedfishSystemIndex(index int) *BMC {
 	return bmc
 }
 
+// WithRedfishSystemPath provides the path of the system to use in the Redfish API, e.g. "/redfish/v1/Systems/1". When
+// set, it takes precedence over the system index.
+func (bmc *BMC) WithRedfishSystemPath(path string) *BMC {
+	if valid, _ := bmc.validate(); !valid {
+		return bmc
+	}
+
+	if !strings.HasPrefix(path, "/") {
+		glog.V(100).Infof("The Redfish System path %q is not an absolute path", path)
+
+		bmc.errorMsg = "redfish 'systemPath' must be an absolute path"
+
+		return bmc
+	}
+
+	bmc.systemPath = path
+
+	return bmc
+}
+
 // WithRedfishPowerControlIndex provides the index of the PowerControl object to use from the Power link on the Chassis
 // service in the Redfish API. The order of the PowerControl objects is deterministic.
 func (bmc *BMC) WithRedfishPowerControlIndex(index int) *BMC {
@@ -282,7 +304,7 @@ func (bmc *BMC) SystemManufacturer() (string, error) {
 		cancel()
 	}()
 
-	system, err := redfishGetSystem(redfishClient, bmc.systemIndex)
+	system, err := redfishGetSystem(redfishClient, bmc.redfishSystemRef())
 	if err != nil {
 		glog.V(100).Infof("Failed to get redfish system: %v", err)
 
@@ -316,7 +338,7 @@ func (bmc *BMC) IsSecureBootEnabled() (bool, error) {
 		cancel()
 	}()
 
-	sboot, err := redfishGetSystemSecureBoot(redfishClient, bmc.systemIndex)
+	sboot, err := redfishGetSystemSecureBoot(redfishClient, bmc.redfishSystemRef())
 	if err != nil {
 		glog.V(100).Infof("Failed to get redfish system's secure boot: %v", err)
 
@@ -350,7 +372,7 @@ func (bmc *BMC) SecureBootEnable() error {
 		cancel()
 	}()
 
-	sboot, err := redfishGetSystemSecureBoot(redfishClient, bmc.systemIndex)
+	sboot, err := redfishGetSystemSecureBoot(redfishClient, bmc.redfishSystemRef())
 	if err != nil {
 		glog.V(100).Infof("Failed to get redfish system's secure boot: %v", err)
 
@@ -399,7 +421,7 @@ func (bmc *BMC) SecureBootDisable() error {
 		cancel()
 	}()
 
-	sboot, err := redfishGetSystemSecureBoot(redfishClient, bmc.systemIndex)
+	sboot, err := redfishGetSystemSecureBoot(redfishClient, bmc.redfishSystemRef())
 	if err != nil {
 		glog.V(100).Infof("Failed to get redfish system's secure boot: %v", err)
 
@@ -448,7 +470,7 @@ func (bmc *BMC) SystemResetAction(action redfish.ResetType) error {
 		cancel()
 	}()
 
-	system, err := redfishGetSystem(redfishClient, bmc.systemIndex)
+	system, err := redfishGetSystem(redfishClient, bmc.redfishSystemRef())
 	if err != nil {
 		glog.V(100).Infof("Failed to get redfish system: %v", err)
 
@@ -576,7 +598,7 @@ func (bmc *BMC) SystemPowerState() (string, error) {
 		cancel()
 	}()
 
-	system, err := redfishGetSystem(redfishClient, bmc.systemIndex)
+	system, err := redfishGetSystem(redfishClient, bmc.redfishSystemRef())
 	if err != nil {
 		glog.V(100).Infof("Failed to get redfish system: %v", err)
 
@@ -644,7 +666,7 @@ func (bmc *BMC) SystemBootOptions() (map[string]string, error) {
 		cancel()
 	}()
 
-	system, err := redfishGetSystem(redfishClient, bmc.systemIndex)
+	system, err := redfishGetSystem(redfishClient, bmc.redfishSystemRef())
 	if err != nil {
 		glog.V(100).Infof("Failed to get redfish system: %v", err)
 
@@ -692,7 +714,7 @@ func (bmc *BMC) SystemBootOrderReferences() ([]string, error) {
 		cancel()
 	}()
 
-	system, err := redfishGetSystem(redfishClient, bmc.systemIndex)
+	system, err := redfishGetSystem(redfishClient, bmc.redfishSystemRef())
 	if err != nil {
 		glog.V(100).Infof("Failed to get redfish system: %v", err)
 
@@ -731,7 +753,7 @@ func (bmc *BMC) SetSystemBootOrderReferences(bootOrderReferences []string) error
 		cancel()
 	}()
 
-	system, err := redfishGetSystem(redfishClient, bmc.systemIndex)
+	system, err := redfishGetSystem(redfishClient, bmc.redfishSystemRef())
 	if err != nil {
 		glog.V(100).Infof("Failed to get redfish system: %v", err)
 
@@ -990,24 +1012,48 @@ func redfishConnect(
 	return client, cancel, nil
 }
 
-// redfishGetSystem uses the provided gofish APIClient and the system index to get a system from the Redfish API.
-func redfishGetSystem(redfishClient *gofish.APIClient, index int) (*redfish.ComputerSystem, error) {
+// redfishSystemRef identifies a system in the Redfish API, either by its path or, when the path is empty, by its
+// index in the systems collection.
+type redfishSystemRef struct {
+	index int
+	path  string
+}
+
+// redfishSystemRef returns the reference to the system configured using WithRedfishSystemIndex or
+// WithRedfishSystemPath.
+func (bmc *BMC) redfishSystemRef() redfishSystemRef {
+	return redfishSystemRef{index: bmc.systemIndex, path: bmc.systemPath}
+}
+
+// redfishGetSystem uses the provided gofish APIClient and the system reference to get a system from the Redfish API.
+func redfishGetSystem(redfishClient *gofish.APIClient, systemRef redfishSystemRef) (*redfish.ComputerSystem, error) {
+	if systemRef.path != "" {
+		system, err := redfish.GetComputerSystem(redfishClient, systemRef.path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get system %s: %w", systemRef.path, err)
+		}
+
+		return system, nil
+	}
+
 	systems, err := redfishClient.GetService().Systems()
 	if err != nil {
 		return nil, fmt.Errorf("failed to get systems: %w", err)
 	}
 
-	if len(systems) < index+1 {
-		return nil, fmt.Errorf("invalid system index %d (base-index=0, num systems=%d)", index, len(systems))
+	if len(systems) < systemRef.index+1 {
+		return nil, fmt.Errorf(
+			"invalid system index %d (base-index=0, num systems=%d)", systemRef.index, len(systems))
 	}
 
-	return systems[index], nil
+	return systems[systemRef.index], nil
 }
 
-// redfishGetSystemSecureBoot uses the provided gofish APIClient and the system index to get the SecureBoot resource for
-// a system.
-func redfishGetSystemSecureBoot(redfishClient *gofish.APIClient, systemIndex int) (*redfish.SecureBoot, error) {
-	system, err := redfishGetSystem(redfishClient, systemIndex)
+// redfishGetSystemSecureBoot uses the provided gofish APIClient and the system reference to get the SecureBoot resource
+// for a system.
+func redfishGetSystemSecureBoot(
+	redfishClient *gofish.APIClient, systemRef redfishSystemRef) (*redfish.SecureBoot, error) {
+	system, err := redfishGetSystem(redfishClient, systemRef)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get redfish system: %w", err)
 	}
@@ -1122,7 +1168,7 @@ func (bmc *BMC) getSupportedResetTypes() ([]redfish.ResetType, error) {
 		cancel()
 	}()
 
-	system, err := redfishGetSystem(redfishClient, bmc.systemIndex)
+	system, err := redfishGetSystem(redfishClient, bmc.redfishSystemRef())
 	if err != nil {
 		glog.V(100).Infof("Failed to get redfish system: %v", err)
 
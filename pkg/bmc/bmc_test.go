@@ -46,6 +46,9 @@ var redfishChassisNoPowerJSONResponse string
 //go:embed testdata/redfish_v1_power.json
 var redfishPowerJSONResponse string
 
+//go:embed testdata/redfish_v1_thermal.json
+var redfishThermalJSONResponse string
+
 //go:embed testdata/redfish_v1_system_boot_options.json
 var redfishSystemBootOptionsJSONResponse string
 
@@ -55,6 +58,54 @@ var redfishSystemBootOption0000JSONResponse string
 //go:embed testdata/redfish_v1_system_boot_option_Boot0003.json
 var redfishSystemBootOption0003JSONResponse string
 
+//go:embed testdata/redfish_v1_system_virtualmedia_collection.json
+var redfishSystemVirtualMediaCollectionJSONResponse string
+
+//go:embed testdata/redfish_v1_system_virtualmedia_1.json
+var redfishSystemVirtualMedia1JSONResponse string
+
+//go:embed testdata/redfish_v1_system_bios.json
+var redfishSystemBiosJSONResponse string
+
+//go:embed testdata/redfish_v1_system_bios_settings.json
+var redfishSystemBiosSettingsJSONResponse string
+
+//go:embed testdata/redfish_v1_system_logservices.json
+var redfishSystemLogServicesJSONResponse string
+
+//go:embed testdata/redfish_v1_system_logservice_sel.json
+var redfishSystemLogServiceSelJSONResponse string
+
+//go:embed testdata/redfish_v1_system_logservice_sel_entries.json
+var redfishSystemLogServiceSelEntriesJSONResponse string
+
+//go:embed testdata/redfish_v1_system_logservice_sel_entry_1.json
+var redfishSystemLogServiceSelEntry1JSONResponse string
+
+//go:embed testdata/redfish_v1_manager.json
+var redfishManagerJSONResponse string
+
+//go:embed testdata/redfish_v1_manager_logservices.json
+var redfishManagerLogServicesJSONResponse string
+
+//go:embed testdata/redfish_v1_manager_logservice_lclog.json
+var redfishManagerLogServiceLclogJSONResponse string
+
+//go:embed testdata/redfish_v1_manager_logservice_lclog_entries.json
+var redfishManagerLogServiceLclogEntriesJSONResponse string
+
+//go:embed testdata/redfish_v1_updateservice.json
+var redfishUpdateServiceJSONResponse string
+
+//go:embed testdata/redfish_v1_firmwareinventory.json
+var redfishFirmwareInventoryJSONResponse string
+
+//go:embed testdata/redfish_v1_firmwareinventory_bios.json
+var redfishFirmwareInventoryBiosJSONResponse string
+
+//go:embed testdata/redfish_v1_firmwareinventory_idrac.json
+var redfishFirmwareInventoryIDRACJSONResponse string
+
 // redfishAuth is used to unmarshall the received login request redfish credentials.
 type redfishAuth struct {
 	UserName string
@@ -62,13 +113,15 @@ type redfishAuth struct {
 }
 
 type redfishAPIResponseCallbacks struct {
-	v1          func(r *http.Request)
-	sessions    func(r *http.Request)
-	system      func(r *http.Request)
-	secureBoot  func(r *http.Request)
-	bootOptions func(r *http.Request)
-	chassis     func(r *http.Request)
-	power       func(r *http.Request)
+	v1           func(r *http.Request)
+	sessions     func(r *http.Request)
+	system       func(r *http.Request)
+	secureBoot   func(r *http.Request)
+	bootOptions  func(r *http.Request)
+	chassis      func(r *http.Request)
+	power        func(r *http.Request)
+	virtualMedia func(r *http.Request)
+	bios         func(r *http.Request)
 }
 
 const (
@@ -226,6 +279,42 @@ func TestBMCWithRedfishSystemIndex(t *testing.T) {
 	}
 }
 
+func TestBMCWithRedfishSystemPath(t *testing.T) {
+	testCases := []struct {
+		name           string
+		path           string
+		expectedErrMsg string
+	}{
+		{
+			name:           "everything alright",
+			path:           "/redfish/v1/Systems/1",
+			expectedErrMsg: "",
+		},
+		{
+			name:           "relative path",
+			path:           "redfish/v1/Systems/1",
+			expectedErrMsg: "redfish 'systemPath' must be an absolute path",
+		},
+		{
+			name:           "empty path",
+			path:           "",
+			expectedErrMsg: "redfish 'systemPath' must be an absolute path",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			bmc := New(defaultHost).WithRedfishSystemPath(testCase.path)
+
+			assert.Equal(t, testCase.expectedErrMsg, bmc.errorMsg)
+
+			if testCase.expectedErrMsg == "" {
+				assert.Equal(t, testCase.path, bmc.systemPath)
+			}
+		})
+	}
+}
+
 func TestBMCWithRedfishPowerControlIndex(t *testing.T) {
 	testCases := []struct {
 		name           string
@@ -401,6 +490,11 @@ func TestBMCSystemManufacturer(t *testing.T) {
 
 	_, err = bmc.WithRedfishSystemIndex(1).SystemManufacturer()
 	assert.EqualError(t, err, expectedErrMsg)
+
+	// The system path takes precedence over the system index.
+	manufacturer, err = bmc.WithRedfishSystemPath("/redfish/v1/Systems/System.Embedded.1").SystemManufacturer()
+	assert.NoError(t, err)
+	assert.Equal(t, expectedManufacturer, manufacturer)
 }
 
 func TestBMCManufacturerTimeout(t *testing.T) {
@@ -846,6 +940,104 @@ func createFakeRedfishLocalServer(secureBootEnabled bool, callbacks redfishAPIRe
 			_, _ = w.Write([]byte(redfishPowerJSONResponse))
 		}))
 
+	mux.HandleFunc("GET /redfish/v1/Chassis/System.Embedded.1/Thermal",
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(redfishThermalJSONResponse))
+		}))
+
+	mux.HandleFunc("GET /redfish/v1/Systems/System.Embedded.1/VirtualMedia",
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(redfishSystemVirtualMediaCollectionJSONResponse))
+		}))
+
+	mux.HandleFunc("GET /redfish/v1/Systems/System.Embedded.1/VirtualMedia/1",
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(redfishSystemVirtualMedia1JSONResponse))
+		}))
+
+	mux.HandleFunc("POST /redfish/v1/Systems/System.Embedded.1/VirtualMedia/1/Actions/",
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if callbacks.virtualMedia != nil {
+				callbacks.virtualMedia(r)
+			}
+
+			w.WriteHeader(http.StatusNoContent)
+		}))
+
+	mux.HandleFunc("GET /redfish/v1/Systems/System.Embedded.1/Bios",
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(redfishSystemBiosJSONResponse))
+		}))
+
+	mux.HandleFunc("/redfish/v1/Systems/System.Embedded.1/Bios/Settings",
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if callbacks.bios != nil {
+				callbacks.bios(r)
+			}
+
+			_, _ = w.Write([]byte(redfishSystemBiosSettingsJSONResponse))
+		}))
+
+	mux.HandleFunc("GET /redfish/v1/Systems/System.Embedded.1/LogServices",
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(redfishSystemLogServicesJSONResponse))
+		}))
+
+	mux.HandleFunc("GET /redfish/v1/Systems/System.Embedded.1/LogServices/Sel",
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(redfishSystemLogServiceSelJSONResponse))
+		}))
+
+	mux.HandleFunc("GET /redfish/v1/Systems/System.Embedded.1/LogServices/Sel/Entries",
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(redfishSystemLogServiceSelEntriesJSONResponse))
+		}))
+
+	mux.HandleFunc("GET /redfish/v1/Systems/System.Embedded.1/LogServices/Sel/Entries/1",
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(redfishSystemLogServiceSelEntry1JSONResponse))
+		}))
+
+	mux.HandleFunc("GET /redfish/v1/Managers/iDRAC.Embedded.1",
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(redfishManagerJSONResponse))
+		}))
+
+	mux.HandleFunc("GET /redfish/v1/Managers/iDRAC.Embedded.1/LogServices",
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(redfishManagerLogServicesJSONResponse))
+		}))
+
+	mux.HandleFunc("GET /redfish/v1/Managers/iDRAC.Embedded.1/LogServices/Lclog",
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(redfishManagerLogServiceLclogJSONResponse))
+		}))
+
+	mux.HandleFunc("GET /redfish/v1/Managers/iDRAC.Embedded.1/LogServices/Lclog/Entries",
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(redfishManagerLogServiceLclogEntriesJSONResponse))
+		}))
+
+	mux.HandleFunc("GET /redfish/v1/UpdateService",
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(redfishUpdateServiceJSONResponse))
+		}))
+
+	mux.HandleFunc("GET /redfish/v1/UpdateService/FirmwareInventory",
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(redfishFirmwareInventoryJSONResponse))
+		}))
+
+	mux.HandleFunc("GET /redfish/v1/UpdateService/FirmwareInventory/Installed-159-1.10.2",
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(redfishFirmwareInventoryBiosJSONResponse))
+		}))
+
+	mux.HandleFunc("GET /redfish/v1/UpdateService/FirmwareInventory/Installed-25227-6.10.30.00",
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(redfishFirmwareInventoryIDRACJSONResponse))
+		}))
+
 	redfishServer := httptest.NewUnstartedServer(mux)
 	redfishServer.EnableHTTP2 = true
 	redfishServer.StartTLS()
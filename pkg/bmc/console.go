@@ -0,0 +1,494 @@
+package bmc
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+)
+
+// maxConsoleBufferSize is the maximum size of the console output kept for the Expect methods. When the output is not
+// consumed fast enough, the oldest output is dropped. The transcript file always holds the full output.
+const maxConsoleBufferSize = 1 << 20
+
+// consoleOpener opens the underlying serial console, returning its output and input.
+type consoleOpener func() (io.Reader, io.WriteCloser, error)
+
+// ConsoleSession is an expect-style session over the serial console of the system. The console output is continuously
+// read in the background so it can be matched against regular expressions using the Expect methods, while lines can be
+// sent to the console using SendLine. Optionally, all the console output is saved to a transcript file and the console
+// is reopened when the underlying connection is lost.
+type ConsoleSession struct {
+	host         string
+	openConsole  consoleOpener
+	closeConsole func() error
+
+	transcriptPath string
+	transcriptFile *os.File
+	maxReconnects  int
+	reconnects     int
+
+	mutex   sync.Mutex
+	writer  io.WriteCloser
+	buffer  []byte
+	readErr error
+	opened  bool
+	closed  bool
+	notify  chan struct{}
+
+	errorMsg string
+}
+
+// NewConsoleSession returns a ConsoleSession for the serial console of the given BMC. The openConsoleCliCmd is passed
+// to OpenSerialConsole so it can be left empty to use the default command for the system manufacturer. Open must be
+// called before using the session.
+func NewConsoleSession(bmc *BMC, openConsoleCliCmd string) *ConsoleSession {
+	glog.V(100).Infof("Creating new console session with the following params: cliCmd: %q", openConsoleCliCmd)
+
+	session := &ConsoleSession{
+		notify: make(chan struct{}, 1),
+	}
+
+	if valid, err := bmc.validate(); !valid {
+		glog.V(100).Infof("The BMC for the console session is invalid: %v", err)
+
+		session.errorMsg = fmt.Sprintf("console session 'bmc' is invalid: %v", err)
+
+		return session
+	}
+
+	session.host = bmc.host
+	session.openConsole = func() (io.Reader, io.WriteCloser, error) {
+		return bmc.OpenSerialConsole(openConsoleCliCmd)
+	}
+	session.closeConsole = bmc.CloseSerialConsole
+
+	return session
+}
+
+// WithTranscriptFile sets the path of the file where all the console output is written, which is useful to attach the
+// console log to a failure report. The file is created, or truncated if it exists, when the session is opened.
+func (session *ConsoleSession) WithTranscriptFile(path string) *ConsoleSession {
+	if valid, _ := session.validate(); !valid {
+		return session
+	}
+
+	glog.V(100).Infof("Setting console session transcript file to %s", path)
+
+	if path == "" {
+		glog.V(100).Info("The console session transcript path is empty")
+
+		session.errorMsg = "console session 'transcriptPath' cannot be empty"
+
+		return session
+	}
+
+	session.transcriptPath = path
+
+	return session
+}
+
+// WithAutoReconnect enables reopening the serial console up to maxReconnects times when the underlying connection is
+// lost, e.g. when the BMC drops the SOL session during a reboot. The output read before reconnecting is kept.
+func (session *ConsoleSession) WithAutoReconnect(maxReconnects int) *ConsoleSession {
+	if valid, _ := session.validate(); !valid {
+		return session
+	}
+
+	glog.V(100).Infof("Setting console session max reconnects to %d", maxReconnects)
+
+	if maxReconnects <= 0 {
+		glog.V(100).Infof("The console session max reconnects %d is less than or equal to zero", maxReconnects)
+
+		session.errorMsg = "console session 'maxReconnects' cannot be less than or equal to zero"
+
+		return session
+	}
+
+	session.maxReconnects = maxReconnects
+
+	return session
+}
+
+// Open opens the serial console and starts reading its output in the background.
+func (session *ConsoleSession) Open() error {
+	if valid, err := session.validate(); !valid {
+		return err
+	}
+
+	glog.V(100).Infof("Opening console session for %s", session.host)
+
+	session.mutex.Lock()
+	defer session.mutex.Unlock()
+
+	if session.opened {
+		glog.V(100).Infof("The console session for %s is already opened", session.host)
+
+		return fmt.Errorf("console session for %s is already opened", session.host)
+	}
+
+	if session.transcriptPath != "" {
+		transcriptFile, err := os.Create(session.transcriptPath)
+		if err != nil {
+			glog.V(100).Infof("Failed to create console transcript file %s: %v", session.transcriptPath, err)
+
+			return fmt.Errorf("failed to create console transcript file %s: %w", session.transcriptPath, err)
+		}
+
+		session.transcriptFile = transcriptFile
+	}
+
+	reader, writer, err := session.openConsole()
+	if err != nil {
+		glog.V(100).Infof("Failed to open serial console for %s: %v", session.host, err)
+
+		session.closeTranscript()
+
+		return fmt.Errorf("failed to open serial console for %s: %w", session.host, err)
+	}
+
+	session.writer = writer
+	session.opened = true
+
+	go session.readConsole(reader)
+
+	return nil
+}
+
+// Close closes the serial console and the transcript file. The session cannot be reopened after being closed.
+func (session *ConsoleSession) Close() error {
+	if valid, err := session.validate(); !valid {
+		return err
+	}
+
+	glog.V(100).Infof("Closing console session for %s", session.host)
+
+	session.mutex.Lock()
+	defer session.mutex.Unlock()
+
+	if !session.opened || session.closed {
+		glog.V(100).Infof("The console session for %s is not opened", session.host)
+
+		return fmt.Errorf("console session for %s is not opened", session.host)
+	}
+
+	session.closed = true
+
+	err := session.closeConsole()
+
+	session.closeTranscript()
+
+	if err != nil {
+		glog.V(100).Infof("Failed to close serial console for %s: %v", session.host, err)
+
+		return fmt.Errorf("failed to close serial console for %s: %w", session.host, err)
+	}
+
+	return nil
+}
+
+// Send writes text to the serial console as is.
+func (session *ConsoleSession) Send(text string) error {
+	if valid, err := session.validateOpened(); !valid {
+		return err
+	}
+
+	glog.V(100).Infof("Sending %q to console session for %s", text, session.host)
+
+	session.mutex.Lock()
+	writer := session.writer
+	session.mutex.Unlock()
+
+	_, err := io.WriteString(writer, text)
+	if err != nil {
+		glog.V(100).Infof("Failed to write to serial console for %s: %v", session.host, err)
+
+		return fmt.Errorf("failed to write to serial console for %s: %w", session.host, err)
+	}
+
+	return nil
+}
+
+// SendLine writes line to the serial console followed by a carriage return, as a terminal would do when pressing
+// enter.
+func (session *ConsoleSession) SendLine(line string) error {
+	return session.Send(line + "\r")
+}
+
+// Expect waits until the console output matches the regular expression pattern or the timeout is reached. The output
+// up to the end of the match is consumed, so subsequent calls only match new output. It returns the match followed by
+// its submatches, as regexp.FindStringSubmatch does.
+func (session *ConsoleSession) Expect(pattern string, timeout time.Duration) ([]string, error) {
+	regex, err := regexp.Compile(pattern)
+	if err != nil {
+		glog.V(100).Infof("Failed to compile console pattern %q: %v", pattern, err)
+
+		return nil, fmt.Errorf("failed to compile console pattern %q: %w", pattern, err)
+	}
+
+	_, match, err := session.ExpectAny([]*regexp.Regexp{regex}, timeout)
+
+	return match, err
+}
+
+// ExpectAny waits until the console output matches any of the regular expressions or the timeout is reached. It
+// returns the index of the expression that matched first in the output along with the match and its submatches. This
+// allows waiting for the expected output while also detecting failures, e.g. a login prompt or a kernel panic.
+func (session *ConsoleSession) ExpectAny(regexes []*regexp.Regexp, timeout time.Duration) (int, []string, error) {
+	if valid, err := session.validateOpened(); !valid {
+		return -1, nil, err
+	}
+
+	glog.V(100).Infof("Waiting up to %s for console output of %s to match %v", timeout, session.host, regexes)
+
+	if len(regexes) == 0 {
+		glog.V(100).Info("The console patterns are empty")
+
+		return -1, nil, fmt.Errorf("console 'regexes' cannot be empty")
+	}
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	for {
+		session.mutex.Lock()
+		index, match := session.consumeMatch(regexes)
+		readErr := session.readErr
+		session.mutex.Unlock()
+
+		if index >= 0 {
+			glog.V(100).Infof("Console output of %s matched %s", session.host, regexes[index])
+
+			return index, match, nil
+		}
+
+		if readErr != nil {
+			glog.V(100).Infof("Console of %s closed before matching %v: %v", session.host, regexes, readErr)
+
+			return -1, nil, fmt.Errorf("console of %s closed before matching %v: %w", session.host, regexes, readErr)
+		}
+
+		select {
+		case <-session.notify:
+		case <-timer.C:
+			glog.V(100).Infof("Timeout waiting for console output of %s to match %v", session.host, regexes)
+
+			return -1, nil, fmt.Errorf("timeout waiting for console output of %s to match %v", session.host, regexes)
+		}
+	}
+}
+
+// Flush discards the console output read so far that has not been consumed by the Expect methods.
+func (session *ConsoleSession) Flush() {
+	if valid, _ := session.validate(); !valid {
+		return
+	}
+
+	session.mutex.Lock()
+	defer session.mutex.Unlock()
+
+	session.buffer = nil
+}
+
+// readConsole reads the console output until the console is closed, appending it to the buffer and the transcript. If
+// auto reconnect is enabled, the console is reopened when reading fails.
+func (session *ConsoleSession) readConsole(reader io.Reader) {
+	readBuffer := make([]byte, 4096)
+
+	for {
+		count, err := reader.Read(readBuffer)
+
+		if count > 0 {
+			session.mutex.Lock()
+			session.appendOutput(readBuffer[:count])
+			session.mutex.Unlock()
+		}
+
+		if err != nil {
+			reader = session.reconnect(err)
+		}
+
+		session.signal()
+
+		if reader == nil {
+			return
+		}
+	}
+}
+
+// appendOutput appends the console output to the buffer, dropping the oldest output above maxConsoleBufferSize, and
+// writes it to the transcript. The mutex must be held when calling it.
+func (session *ConsoleSession) appendOutput(output []byte) {
+	session.buffer = append(session.buffer, output...)
+
+	if overflow := len(session.buffer) - maxConsoleBufferSize; overflow > 0 {
+		glog.V(100).Infof("Console output of %s exceeds %d bytes, dropping the oldest %d bytes",
+			session.host, maxConsoleBufferSize, overflow)
+
+		session.buffer = append([]byte(nil), session.buffer[overflow:]...)
+	}
+
+	if session.transcriptFile != nil {
+		_, _ = session.transcriptFile.Write(output)
+	}
+}
+
+// reconnect reopens the console after a read error if the session is not closed and there are reconnects left. It
+// returns the new reader or nil if the console was not reopened, in which case the error is saved to be returned by
+// the Expect methods. The mutex must not be held when calling it since reopening the console may take a while.
+func (session *ConsoleSession) reconnect(readErr error) io.Reader {
+	session.mutex.Lock()
+
+	if session.closed {
+		session.readErr = fmt.Errorf("console session closed")
+		session.mutex.Unlock()
+
+		return nil
+	}
+
+	if session.reconnects >= session.maxReconnects {
+		glog.V(100).Infof("Serial console of %s closed: %v", session.host, readErr)
+
+		session.readErr = readErr
+		session.mutex.Unlock()
+
+		return nil
+	}
+
+	session.reconnects++
+
+	glog.V(100).Infof("Serial console of %s closed (%v), reconnecting (%d/%d)",
+		session.host, readErr, session.reconnects, session.maxReconnects)
+
+	session.mutex.Unlock()
+
+	_ = session.closeConsole()
+
+	reader, writer, err := session.openConsole()
+
+	session.mutex.Lock()
+
+	if err != nil {
+		glog.V(100).Infof("Failed to reopen serial console for %s: %v", session.host, err)
+
+		session.readErr = fmt.Errorf("failed to reopen serial console after %w: %w", readErr, err)
+		session.mutex.Unlock()
+
+		return nil
+	}
+
+	if session.closed {
+		glog.V(100).Infof("Console session for %s was closed while reconnecting", session.host)
+
+		session.readErr = fmt.Errorf("console session closed")
+		session.mutex.Unlock()
+
+		_ = session.closeConsole()
+
+		return nil
+	}
+
+	session.writer = writer
+	session.mutex.Unlock()
+
+	return reader
+}
+
+// consumeMatch looks for the earliest match of any of the regexes in the buffer. If found, the buffer is consumed up to
+// the end of the match and the index of the regex with the submatches are returned, otherwise the index is -1. The
+// mutex must be held when calling it.
+func (session *ConsoleSession) consumeMatch(regexes []*regexp.Regexp) (int, []string) {
+	matchIndex := -1
+
+	var matchLocation []int
+
+	for index, regex := range regexes {
+		location := regex.FindSubmatchIndex(session.buffer)
+		if location == nil {
+			continue
+		}
+
+		if matchLocation == nil || location[0] < matchLocation[0] {
+			matchIndex = index
+			matchLocation = location
+		}
+	}
+
+	if matchIndex < 0 {
+		return -1, nil
+	}
+
+	match := make([]string, len(matchLocation)/2)
+
+	for index := range match {
+		if matchLocation[2*index] >= 0 {
+			match[index] = string(session.buffer[matchLocation[2*index]:matchLocation[2*index+1]])
+		}
+	}
+
+	// The remaining output is copied so the memory of the consumed output can be released.
+	session.buffer = append([]byte(nil), session.buffer[matchLocation[1]:]...)
+
+	return matchIndex, match
+}
+
+// signal wakes up any Expect call waiting for new console output.
+func (session *ConsoleSession) signal() {
+	select {
+	case session.notify <- struct{}{}:
+	default:
+	}
+}
+
+// closeTranscript closes the transcript file if there is one. The mutex must be held when calling it.
+func (session *ConsoleSession) closeTranscript() {
+	if session.transcriptFile == nil {
+		return
+	}
+
+	err := session.transcriptFile.Close()
+	if err != nil {
+		glog.V(100).Infof("Failed to close console transcript file %s: %v", session.transcriptPath, err)
+	}
+
+	session.transcriptFile = nil
+}
+
+// validateOpened performs the same validations as in validate but also checks that the session is opened.
+func (session *ConsoleSession) validateOpened() (bool, error) {
+	if valid, err := session.validate(); !valid {
+		return false, err
+	}
+
+	session.mutex.Lock()
+	defer session.mutex.Unlock()
+
+	if !session.opened || session.closed {
+		glog.V(100).Infof("The console session for %s is not opened", session.host)
+
+		return false, fmt.Errorf("console session for %s is not opened", session.host)
+	}
+
+	return true, nil
+}
+
+// validate checks that the ConsoleSession is in a valid state with no error message.
+func (session *ConsoleSession) validate() (bool, error) {
+	if session == nil {
+		glog.V(100).Info("The console session is nil")
+
+		return false, fmt.Errorf("error: received nil console session")
+	}
+
+	if session.errorMsg != "" {
+		glog.V(100).Infof("The console session has an error message: %s", session.errorMsg)
+
+		return false, fmt.Errorf("%s", session.errorMsg)
+	}
+
+	return true, nil
+}
@@ -0,0 +1,303 @@
+package bmc
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeConsole provides in-memory serial consoles for ConsoleSession. Each call to open returns a new console whose
+// output can be written using the returned pipe writer.
+type fakeConsole struct {
+	mutex   sync.Mutex
+	outputs []*io.PipeWriter
+	input   bytes.Buffer
+	opens   int
+	closes  int
+	openErr error
+	// reopenGate blocks reopening the console until it is closed, when set.
+	reopenGate chan struct{}
+}
+
+func (console *fakeConsole) open() (io.Reader, io.WriteCloser, error) {
+	console.mutex.Lock()
+	reopenGate := console.reopenGate
+	reopening := console.opens > 0
+	console.mutex.Unlock()
+
+	if reopenGate != nil && reopening {
+		<-reopenGate
+	}
+
+	console.mutex.Lock()
+	defer console.mutex.Unlock()
+
+	if console.openErr != nil {
+		return nil, nil, console.openErr
+	}
+
+	reader, writer := io.Pipe()
+	console.outputs = append(console.outputs, writer)
+	console.opens++
+
+	return reader, nopWriteCloser{&console.input}, nil
+}
+
+func (console *fakeConsole) close() error {
+	console.mutex.Lock()
+	defer console.mutex.Unlock()
+
+	console.closes++
+
+	if len(console.outputs) > 0 {
+		_ = console.outputs[len(console.outputs)-1].Close()
+	}
+
+	return nil
+}
+
+// output returns the writer for the output of the most recently opened console.
+func (console *fakeConsole) output() *io.PipeWriter {
+	console.mutex.Lock()
+	defer console.mutex.Unlock()
+
+	return console.outputs[len(console.outputs)-1]
+}
+
+type nopWriteCloser struct {
+	io.Writer
+}
+
+func (nopWriteCloser) Close() error { return nil }
+
+func newFakeConsoleSession(console *fakeConsole) *ConsoleSession {
+	session := NewConsoleSession(New(defaultHost), "")
+	session.openConsole = console.open
+	session.closeConsole = console.close
+
+	return session
+}
+
+func TestNewConsoleSession(t *testing.T) {
+	session := NewConsoleSession(New(defaultHost), "console com2")
+	assert.Empty(t, session.errorMsg)
+	assert.Equal(t, defaultHost, session.host)
+	assert.NotNil(t, session.openConsole)
+	assert.NotNil(t, session.closeConsole)
+
+	session = NewConsoleSession(New(""), "")
+	assert.Equal(t, "console session 'bmc' is invalid: bmc 'host' cannot be empty", session.errorMsg)
+
+	session = NewConsoleSession(New(defaultHost), "").WithTranscriptFile("")
+	assert.Equal(t, "console session 'transcriptPath' cannot be empty", session.errorMsg)
+
+	session = NewConsoleSession(New(defaultHost), "").WithAutoReconnect(0)
+	assert.Equal(t, "console session 'maxReconnects' cannot be less than or equal to zero", session.errorMsg)
+
+	session = NewConsoleSession(New(defaultHost), "").WithAutoReconnect(3)
+	assert.Empty(t, session.errorMsg)
+	assert.Equal(t, 3, session.maxReconnects)
+}
+
+func TestConsoleSessionExpect(t *testing.T) {
+	console := &fakeConsole{}
+	transcriptPath := filepath.Join(t.TempDir(), "console.log")
+	session := newFakeConsoleSession(console).WithTranscriptFile(transcriptPath)
+
+	_, err := session.Expect("login:", time.Second)
+	assert.EqualError(t, err, "console session for 1.2.3.4 is not opened")
+
+	err = session.Open()
+	assert.NoError(t, err)
+
+	err = session.Open()
+	assert.EqualError(t, err, "console session for 1.2.3.4 is already opened")
+
+	go func() {
+		_, _ = io.WriteString(console.output(), "Red Hat Enterprise Linux CoreOS 416\nKernel 5.14.0 on an x86_64\n")
+		_, _ = io.WriteString(console.output(), "\nworker-0 login: ")
+	}()
+
+	match, err := session.Expect(`Kernel (\S+) on an (\S+)`, time.Second)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Kernel 5.14.0 on an x86_64", "5.14.0", "x86_64"}, match)
+
+	match, err = session.Expect(`(\S+) login: `, time.Second)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"worker-0 login: ", "worker-0"}, match)
+
+	// The output is consumed, so matching it again times out.
+	_, err = session.Expect(`login: `, 100*time.Millisecond)
+	assert.EqualError(t, err, "timeout waiting for console output of 1.2.3.4 to match [login: ]")
+
+	_, err = session.Expect(`(`, time.Second)
+	assert.ErrorContains(t, err, "failed to compile console pattern")
+
+	err = session.SendLine("core")
+	assert.NoError(t, err)
+	assert.Equal(t, "core\r", console.input.String())
+
+	err = session.Close()
+	assert.NoError(t, err)
+	assert.Equal(t, 1, console.closes)
+
+	err = session.Close()
+	assert.EqualError(t, err, "console session for 1.2.3.4 is not opened")
+
+	transcript, err := os.ReadFile(transcriptPath)
+	assert.NoError(t, err)
+	assert.Equal(t, "Red Hat Enterprise Linux CoreOS 416\nKernel 5.14.0 on an x86_64\n\nworker-0 login: ",
+		string(transcript))
+}
+
+func TestConsoleSessionExpectAny(t *testing.T) {
+	console := &fakeConsole{}
+	session := newFakeConsoleSession(console)
+
+	err := session.Open()
+	assert.NoError(t, err)
+
+	defer func() { _ = session.Close() }()
+
+	go func() {
+		_, _ = io.WriteString(console.output(),
+			"Kernel panic - not syncing: VFS: Unable to mount root fs\nworker-0 login: ")
+	}()
+
+	regexes := []*regexp.Regexp{regexp.MustCompile(`login: `), regexp.MustCompile(`Kernel panic - (.*)`)}
+
+	// The earliest match in the output is returned, regardless of the order of the regexes.
+	index, match, err := session.ExpectAny(regexes, time.Second)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, index)
+	assert.Equal(t, "not syncing: VFS: Unable to mount root fs", match[1])
+
+	index, _, err = session.ExpectAny(regexes, time.Second)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, index)
+
+	_, _, err = session.ExpectAny(nil, time.Second)
+	assert.EqualError(t, err, "console 'regexes' cannot be empty")
+}
+
+func TestConsoleSessionReconnect(t *testing.T) {
+	console := &fakeConsole{}
+	session := newFakeConsoleSession(console).WithAutoReconnect(1)
+
+	err := session.Open()
+	assert.NoError(t, err)
+
+	// The first console is dropped after printing the GRUB menu, the session reopens it.
+	go func() {
+		_, _ = io.WriteString(console.output(), "GNU GRUB  version 2.06\n")
+		_ = console.output().Close()
+	}()
+
+	_, err = session.Expect("GNU GRUB", time.Second)
+	assert.NoError(t, err)
+
+	assert.Eventually(t, func() bool {
+		console.mutex.Lock()
+		defer console.mutex.Unlock()
+
+		return console.opens == 2
+	}, time.Second, 10*time.Millisecond)
+
+	go func() {
+		_, _ = io.WriteString(console.output(), "worker-0 login: ")
+		_ = console.output().CloseWithError(fmt.Errorf("connection reset"))
+	}()
+
+	_, err = session.Expect("login: ", time.Second)
+	assert.NoError(t, err)
+
+	// There are no reconnects left, so the error is returned once the output is consumed.
+	_, err = session.Expect("login: ", time.Second)
+	assert.EqualError(t, err, "console of 1.2.3.4 closed before matching [login: ]: connection reset")
+
+	err = session.Close()
+	assert.NoError(t, err)
+}
+
+func TestConsoleSessionReconnectDoesNotBlock(t *testing.T) {
+	console := &fakeConsole{reopenGate: make(chan struct{})}
+	session := newFakeConsoleSession(console).WithAutoReconnect(1)
+
+	err := session.Open()
+	assert.NoError(t, err)
+
+	_ = console.output().Close()
+
+	// Sending and flushing do not wait for the console to be reopened.
+	done := make(chan struct{})
+
+	go func() {
+		_ = session.SendLine("")
+		session.Flush()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("console session is blocked while reconnecting")
+	}
+
+	close(console.reopenGate)
+
+	assert.Eventually(t, func() bool {
+		console.mutex.Lock()
+		defer console.mutex.Unlock()
+
+		return console.opens == 2
+	}, time.Second, 10*time.Millisecond)
+
+	go func() {
+		_, _ = io.WriteString(console.output(), "worker-0 login: ")
+	}()
+
+	_, err = session.Expect("login: ", time.Second)
+	assert.NoError(t, err)
+
+	err = session.Close()
+	assert.NoError(t, err)
+}
+
+func TestConsoleSessionBufferLimit(t *testing.T) {
+	console := &fakeConsole{}
+	session := newFakeConsoleSession(console)
+
+	err := session.Open()
+	assert.NoError(t, err)
+
+	go func() {
+		_, _ = console.output().Write(bytes.Repeat([]byte("a"), maxConsoleBufferSize))
+		_, _ = io.WriteString(console.output(), "worker-0 login: ")
+	}()
+
+	_, err = session.Expect("login: ", 5*time.Second)
+	assert.NoError(t, err)
+
+	session.mutex.Lock()
+	assert.LessOrEqual(t, len(session.buffer), maxConsoleBufferSize)
+	session.mutex.Unlock()
+
+	err = session.Close()
+	assert.NoError(t, err)
+}
+
+func TestConsoleSessionOpenError(t *testing.T) {
+	console := &fakeConsole{openErr: fmt.Errorf("ssh: handshake failed")}
+	session := newFakeConsoleSession(console)
+
+	err := session.Open()
+	assert.EqualError(t, err, "failed to open serial console for 1.2.3.4: ssh: handshake failed")
+}
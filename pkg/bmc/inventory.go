@@ -0,0 +1,120 @@
+package bmc
+
+import (
+	"fmt"
+
+	"github.com/golang/glog"
+	"github.com/stmcginnis/gofish"
+	"github.com/stmcginnis/gofish/redfish"
+)
+
+// SoftwareComponent holds the details of a firmware or software component reported by the Redfish UpdateService.
+type SoftwareComponent struct {
+	// ID is the Redfish identifier of the component.
+	ID string
+	// Name is the display name of the component, e.g. "BIOS" or "Integrated Remote Access Controller".
+	Name string
+	// Version is the version of the component.
+	Version string
+	// Manufacturer is the manufacturer or producer of the component.
+	Manufacturer string
+	// ReleaseDate is the release date of the component, if known.
+	ReleaseDate string
+	// SoftwareID is the implementation-specific identifier of the component.
+	SoftwareID string
+	// Updateable reports whether the component can be updated by the UpdateService.
+	Updateable bool
+	// State is the state of the component, e.g. "Enabled".
+	State string
+	// Health is the health of the component, e.g. "OK".
+	Health string
+}
+
+// FirmwareInventory returns the firmware components of the BMC and the system using the Redfish UpdateService.
+func (bmc *BMC) FirmwareInventory() ([]SoftwareComponent, error) {
+	if valid, err := bmc.validateRedfish(); !valid {
+		return nil, err
+	}
+
+	glog.V(100).Infof("Getting firmware inventory from bmc's redfish endpoint")
+
+	return bmc.getSoftwareComponents(func(updateService *redfish.UpdateService) ([]*redfish.SoftwareInventory, error) {
+		return updateService.FirmwareInventories()
+	})
+}
+
+// SoftwareInventory returns the software components of the BMC and the system using the Redfish UpdateService.
+func (bmc *BMC) SoftwareInventory() ([]SoftwareComponent, error) {
+	if valid, err := bmc.validateRedfish(); !valid {
+		return nil, err
+	}
+
+	glog.V(100).Infof("Getting software inventory from bmc's redfish endpoint")
+
+	return bmc.getSoftwareComponents(func(updateService *redfish.UpdateService) ([]*redfish.SoftwareInventory, error) {
+		return updateService.SoftwareInventories()
+	})
+}
+
+// getSoftwareComponents connects to the Redfish API and converts the inventory returned by listFunc into a slice of
+// SoftwareComponent.
+func (bmc *BMC) getSoftwareComponents(
+	listFunc func(*redfish.UpdateService) ([]*redfish.SoftwareInventory, error)) ([]SoftwareComponent, error) {
+	redfishClient, cancel, err := redfishConnect(
+		bmc.host,
+		bmc.redfishUser.Name,
+		bmc.redfishUser.Password,
+		bmc.timeOuts.Redfish)
+	if err != nil {
+		glog.V(100).Infof("Redfish connection error: %v", err)
+
+		return nil, fmt.Errorf("redfish connection error: %w", err)
+	}
+
+	defer func() {
+		redfishClient.Logout()
+		cancel()
+	}()
+
+	updateService, err := redfishGetUpdateService(redfishClient)
+	if err != nil {
+		glog.V(100).Infof("Failed to get redfish update service: %v", err)
+
+		return nil, fmt.Errorf("failed to get redfish update service: %w", err)
+	}
+
+	inventory, err := listFunc(updateService)
+	if err != nil {
+		glog.V(100).Infof("Failed to get redfish inventory: %v", err)
+
+		return nil, fmt.Errorf("failed to get redfish inventory: %w", err)
+	}
+
+	var components []SoftwareComponent
+
+	for _, item := range inventory {
+		components = append(components, SoftwareComponent{
+			ID:           item.ID,
+			Name:         item.Name,
+			Version:      item.Version,
+			Manufacturer: item.Manufacturer,
+			ReleaseDate:  item.ReleaseDate,
+			SoftwareID:   item.SoftwareID,
+			Updateable:   item.Updateable,
+			State:        string(item.Status.State),
+			Health:       string(item.Status.Health),
+		})
+	}
+
+	return components, nil
+}
+
+// redfishGetUpdateService uses the provided gofish APIClient to get the UpdateService from the Redfish API.
+func redfishGetUpdateService(redfishClient *gofish.APIClient) (*redfish.UpdateService, error) {
+	updateService, err := redfishClient.GetService().UpdateService()
+	if err != nil {
+		return nil, err
+	}
+
+	return updateService, nil
+}
@@ -0,0 +1,60 @@
+package bmc
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBMCFirmwareInventory(t *testing.T) {
+	// Create fake redfish endpoint.
+	redfishServer := createFakeRedfishLocalServer(false, redfishAPIResponseCallbacks{})
+	defer redfishServer.Close()
+
+	host := strings.Split(redfishServer.URL, "//")[1]
+	bmc := New(host).WithRedfishUser(defaultUsername, defaultPassword)
+
+	expectedComponents := []SoftwareComponent{
+		{
+			ID:           "Installed-159-1.10.2",
+			Name:         "BIOS",
+			Version:      "1.10.2",
+			Manufacturer: "Dell Inc.",
+			ReleaseDate:  "2023-05-10T00:00:00Z",
+			SoftwareID:   "159",
+			Updateable:   true,
+			State:        "Enabled",
+			Health:       "OK",
+		},
+		{
+			ID:           "Installed-25227-6.10.30.00",
+			Name:         "Integrated Dell Remote Access Controller",
+			Version:      "6.10.30.00",
+			Manufacturer: "Dell Inc.",
+			ReleaseDate:  "2023-03-27T00:00:00Z",
+			SoftwareID:   "25227",
+			Updateable:   true,
+			State:        "Enabled",
+			Health:       "OK",
+		},
+	}
+
+	components, err := bmc.FirmwareInventory()
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, expectedComponents, components)
+}
+
+func TestBMCSoftwareInventory(t *testing.T) {
+	// Create fake redfish endpoint.
+	redfishServer := createFakeRedfishLocalServer(false, redfishAPIResponseCallbacks{})
+	defer redfishServer.Close()
+
+	host := strings.Split(redfishServer.URL, "//")[1]
+	bmc := New(host).WithRedfishUser(defaultUsername, defaultPassword)
+
+	// The fake UpdateService has no SoftwareInventory collection.
+	components, err := bmc.SoftwareInventory()
+	assert.NoError(t, err)
+	assert.Empty(t, components)
+}
@@ -0,0 +1,367 @@
+package bmc
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/stmcginnis/gofish/common"
+	"github.com/stmcginnis/gofish/redfish"
+)
+
+const (
+	// defaultIPMIPort is the default port that will be used for IPMI over LAN connections.
+	defaultIPMIPort = 623
+	// ipmiToolBinary is the binary used to run IPMI commands. It must be available in the PATH.
+	ipmiToolBinary = "ipmitool"
+	// ipmiInterface is the ipmitool interface used to connect to the BMC, IPMI v2.0 RMCP+ LAN.
+	ipmiInterface = "lanplus"
+)
+
+var (
+	ipmiBootDevices = map[BootDevice]string{
+		BootDevicePXE:  "pxe",
+		BootDeviceDisk: "disk",
+		BootDeviceCD:   "cdrom",
+		BootDeviceBIOS: "bios",
+	}
+
+	ipmiSensorTypes = map[string]SensorType{
+		"degrees C": SensorTypeTemperature,
+		"RPM":       SensorTypeFan,
+		"percent":   SensorTypeFan,
+		"Volts":     SensorTypeVoltage,
+		"Watts":     SensorTypePower,
+	}
+
+	ipmiSensorHealth = map[string]string{
+		"ok": string(common.OKHealth),
+		"nc": string(common.WarningHealth),
+		"cr": string(common.CriticalHealth),
+		"nr": string(common.CriticalHealth),
+	}
+)
+
+// ipmiCommandRunner runs the ipmitool command with the given args, adding env to the environment of the process, and
+// returns its combined output.
+type ipmiCommandRunner func(ctx context.Context, env []string, args ...string) ([]byte, error)
+
+// IPMI is the holder struct for BMC access through IPMI over LAN. Commands are run using ipmitool with the lanplus
+// interface, so it must be installed where the IPMI methods are called.
+type IPMI struct {
+	host    string
+	user    *User
+	port    uint16
+	timeout time.Duration
+
+	runCommand ipmiCommandRunner
+
+	errorMsg string
+}
+
+// NewIPMI returns an IPMI struct with the specified host. The host should be nonempty. WithIPMIUser must be called
+// before running any command. The port and timeout default to 623 and the Redfish default timeout.
+func NewIPMI(host string) *IPMI {
+	glog.V(100).Infof("Creating new IPMI structure with the following params: host: %s", host)
+
+	ipmi := &IPMI{
+		host:       host,
+		port:       defaultIPMIPort,
+		timeout:    defaultTimeOut,
+		runCommand: runIPMITool,
+	}
+
+	if host == "" {
+		glog.V(100).Info("The host of the IPMI BMC is empty")
+
+		ipmi.errorMsg = "ipmi 'host' cannot be empty"
+	}
+
+	return ipmi
+}
+
+// WithIPMIUser provides the credentials to access the BMC over IPMI. Neither the username nor password should be
+// empty.
+func (ipmi *IPMI) WithIPMIUser(username, password string) *IPMI {
+	if valid, _ := ipmi.validate(); !valid {
+		return ipmi
+	}
+
+	glog.V(100).Infof("Setting IPMI username to %s", username)
+
+	if username == "" {
+		glog.V(100).Info("The IPMI username is empty")
+
+		ipmi.errorMsg = "ipmi 'username' cannot be empty"
+
+		return ipmi
+	}
+
+	if password == "" {
+		glog.V(100).Info("The IPMI password is empty")
+
+		ipmi.errorMsg = "ipmi 'password' cannot be empty"
+
+		return ipmi
+	}
+
+	ipmi.user = &User{
+		Name:     username,
+		Password: password,
+	}
+
+	return ipmi
+}
+
+// WithIPMIPort provides the port to use when connecting to the BMC over IPMI. It should not be zero.
+func (ipmi *IPMI) WithIPMIPort(port uint16) *IPMI {
+	if valid, _ := ipmi.validate(); !valid {
+		return ipmi
+	}
+
+	glog.V(100).Infof("Setting IPMI port to %d", port)
+
+	if port == 0 {
+		glog.V(100).Info("The IPMI port is zero")
+
+		ipmi.errorMsg = "ipmi 'port' cannot be zero"
+
+		return ipmi
+	}
+
+	ipmi.port = port
+
+	return ipmi
+}
+
+// WithIPMITimeout provides the timeout for each IPMI command. It should not be zero or negative.
+func (ipmi *IPMI) WithIPMITimeout(timeout time.Duration) *IPMI {
+	if valid, _ := ipmi.validate(); !valid {
+		return ipmi
+	}
+
+	if timeout <= 0 {
+		glog.V(100).Infof("The IPMI timeout %s is less than or equal to zero", timeout)
+
+		ipmi.errorMsg = "ipmi 'timeout' cannot be less than or equal to zero"
+
+		return ipmi
+	}
+
+	ipmi.timeout = timeout
+
+	return ipmi
+}
+
+// SystemPowerOn powers on the system using IPMI.
+func (ipmi *IPMI) SystemPowerOn() error {
+	return ipmi.chassisPower("on")
+}
+
+// SystemPowerOff performs a non-graceful power off of the system using IPMI.
+func (ipmi *IPMI) SystemPowerOff() error {
+	return ipmi.chassisPower("off")
+}
+
+// SystemForceReset performs a (non-graceful) hard reset of the system using IPMI.
+func (ipmi *IPMI) SystemForceReset() error {
+	return ipmi.chassisPower("reset")
+}
+
+// SystemGracefulShutdown performs a graceful shutdown of the system through ACPI using IPMI.
+func (ipmi *IPMI) SystemGracefulShutdown() error {
+	return ipmi.chassisPower("soft")
+}
+
+// SystemPowerCycle performs a power cycle of the system using IPMI. As most BMCs reject a power cycle when the system
+// is off, the system is powered on instead in that case.
+func (ipmi *IPMI) SystemPowerCycle() error {
+	powerState, err := ipmi.SystemPowerState()
+	if err != nil {
+		return err
+	}
+
+	if powerState == string(redfish.OffPowerState) {
+		glog.V(100).Infof("System is powered off, powering it on instead of power cycling it")
+
+		return ipmi.SystemPowerOn()
+	}
+
+	return ipmi.chassisPower("cycle")
+}
+
+// SystemPowerState returns the system's current power state using IPMI. Returned string is either On or Off to match
+// the states returned by the Redfish API.
+func (ipmi *IPMI) SystemPowerState() (string, error) {
+	glog.V(100).Info("Collecting current power state using IPMI")
+
+	output, err := ipmi.run("chassis", "power", "status")
+	if err != nil {
+		return "", err
+	}
+
+	// Expected output is "Chassis Power is on" or "Chassis Power is off".
+	switch {
+	case strings.HasSuffix(output, " on"):
+		return string(redfish.OnPowerState), nil
+	case strings.HasSuffix(output, " off"):
+		return string(redfish.OffPowerState), nil
+	default:
+		glog.V(100).Infof("Unexpected IPMI power status output: %s", output)
+
+		return "", fmt.Errorf("unexpected ipmi power status output: %s", output)
+	}
+}
+
+// SetSystemBootDevice sets the boot device of the system using IPMI. When once is false the boot device is kept for
+// all the following boots.
+func (ipmi *IPMI) SetSystemBootDevice(device BootDevice, once bool) error {
+	glog.V(100).Infof("Setting boot device to %s (once: %t) using IPMI", device, once)
+
+	ipmiDevice, found := ipmiBootDevices[device]
+	if !found {
+		glog.V(100).Infof("The boot device %s is not supported", device)
+
+		return fmt.Errorf("boot device %s is not supported", device)
+	}
+
+	args := []string{"chassis", "bootdev", ipmiDevice}
+	if !once {
+		args = append(args, "options=persistent")
+	}
+
+	_, err := ipmi.run(args...)
+
+	return err
+}
+
+// SystemSensors returns the readings of the threshold based sensors of the system using IPMI. Sensors with no reading
+// available and discrete sensors are skipped.
+func (ipmi *IPMI) SystemSensors() ([]SensorReading, error) {
+	glog.V(100).Info("Collecting sensor readings using IPMI")
+
+	output, err := ipmi.run("sensor", "list")
+	if err != nil {
+		return nil, err
+	}
+
+	var readings []SensorReading
+
+	// Each line has the format: name | reading | units | status | thresholds...
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Split(line, "|")
+		if len(fields) < 4 {
+			continue
+		}
+
+		reading, err := strconv.ParseFloat(strings.TrimSpace(fields[1]), 64)
+		if err != nil {
+			continue
+		}
+
+		units := strings.TrimSpace(fields[2])
+		status := strings.TrimSpace(fields[3])
+
+		sensorType, found := ipmiSensorTypes[units]
+		if !found {
+			sensorType = SensorTypeOther
+		}
+
+		health, found := ipmiSensorHealth[status]
+		if !found {
+			health = status
+		}
+
+		readings = append(readings, SensorReading{
+			Name:    strings.TrimSpace(fields[0]),
+			Type:    sensorType,
+			Reading: reading,
+			Units:   units,
+			Health:  health,
+		})
+	}
+
+	return readings, nil
+}
+
+// chassisPower runs the chassis power subcommand with the given action.
+func (ipmi *IPMI) chassisPower(action string) error {
+	glog.V(100).Infof("Performing chassis power %s using IPMI", action)
+
+	_, err := ipmi.run("chassis", "power", action)
+
+	return err
+}
+
+// run runs ipmitool against the BMC with the given args and returns its trimmed output. The password is passed through
+// the environment so it is not visible in the process list.
+func (ipmi *IPMI) run(args ...string) (string, error) {
+	if valid, err := ipmi.validateUser(); !valid {
+		return "", err
+	}
+
+	ctx, cancel := context.WithTimeout(context.TODO(), ipmi.timeout)
+	defer cancel()
+
+	fullArgs := append([]string{
+		"-I", ipmiInterface,
+		"-H", ipmi.host,
+		"-p", strconv.Itoa(int(ipmi.port)),
+		"-U", ipmi.user.Name,
+		"-E",
+	}, args...)
+
+	output, err := ipmi.runCommand(ctx, []string{"IPMI_PASSWORD=" + ipmi.user.Password}, fullArgs...)
+	if err != nil {
+		glog.V(100).Infof("Failed to run ipmi command %v: %v: %s", args, err, output)
+
+		return "", fmt.Errorf("failed to run ipmi command %v: %w: %s", args, err, strings.TrimSpace(string(output)))
+	}
+
+	return strings.TrimSpace(string(output)), nil
+}
+
+// validateUser performs the same validations as in validate but also checks for a valid IPMI user.
+func (ipmi *IPMI) validateUser() (bool, error) {
+	if valid, err := ipmi.validate(); !valid {
+		return false, err
+	}
+
+	if ipmi.user == nil {
+		glog.V(100).Info("The IPMI user is nil")
+
+		return false, fmt.Errorf("cannot access ipmi with nil user")
+	}
+
+	return true, nil
+}
+
+// validate checks that the IPMI is in a valid state with no error message.
+func (ipmi *IPMI) validate() (bool, error) {
+	if ipmi == nil {
+		glog.V(100).Info("The IPMI is nil")
+
+		return false, fmt.Errorf("error: received nil ipmi")
+	}
+
+	if ipmi.errorMsg != "" {
+		glog.V(100).Infof("The IPMI has an error message: %s", ipmi.errorMsg)
+
+		return false, fmt.Errorf("%s", ipmi.errorMsg)
+	}
+
+	return true, nil
+}
+
+// runIPMITool is the default ipmiCommandRunner, running the ipmitool binary from the PATH.
+func runIPMITool(ctx context.Context, env []string, args ...string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, ipmiToolBinary, args...)
+	cmd.Env = append(os.Environ(), env...)
+
+	return cmd.CombinedOutput()
+}
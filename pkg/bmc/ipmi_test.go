@@ -0,0 +1,262 @@
+package bmc
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const defaultIPMISensorList = `Inlet Temp       | 23.000     | degrees C  | ok    | na        | na
+Fan1A            | 6840.000   | RPM        | ok    | na        | 360.000
+PS1 Voltage 1    | 230.000    | Volts      | nc    | na        | na
+Pwr Consumption  | 168.000    | Watts      | ok    | na        | na
+Intrusion        | 0x0        | discrete   | 0x0080| na        | na
+Fan2A            | na         | RPM        | na    | na        | 360.000`
+
+// fakeIPMIRunner records the ipmitool invocations and replies with the configured output for each subcommand.
+type fakeIPMIRunner struct {
+	outputs  map[string]string
+	failures map[string]bool
+	calls    [][]string
+	env      []string
+}
+
+func (runner *fakeIPMIRunner) run(_ context.Context, env []string, args ...string) ([]byte, error) {
+	// Skip the connection args: -I lanplus -H host -p port -U user -E.
+	subcommand := args[9:]
+	runner.calls = append(runner.calls, subcommand)
+	runner.env = env
+
+	key := fmt.Sprint(subcommand)
+	if runner.failures[key] {
+		return []byte("Error: Unable to establish IPMI v2 / RMCP+ session"), fmt.Errorf("exit status 1")
+	}
+
+	return []byte(runner.outputs[key]), nil
+}
+
+func newFakeIPMI(runner *fakeIPMIRunner) *IPMI {
+	ipmi := NewIPMI(defaultHost).WithIPMIUser(defaultUsername, defaultPassword)
+	ipmi.runCommand = runner.run
+
+	return ipmi
+}
+
+func TestIPMINew(t *testing.T) {
+	testCases := []struct {
+		host          string
+		expectedError string
+	}{
+		{
+			host:          defaultHost,
+			expectedError: "",
+		},
+		{
+			host:          "",
+			expectedError: "ipmi 'host' cannot be empty",
+		},
+	}
+
+	for _, testCase := range testCases {
+		ipmi := NewIPMI(testCase.host)
+		assert.Equal(t, testCase.expectedError, ipmi.errorMsg)
+		assert.Equal(t, uint16(defaultIPMIPort), ipmi.port)
+		assert.Equal(t, defaultTimeOut, ipmi.timeout)
+	}
+}
+
+func TestIPMIWithIPMIUser(t *testing.T) {
+	testCases := []struct {
+		username      string
+		password      string
+		expectedError string
+	}{
+		{
+			username:      defaultUsername,
+			password:      defaultPassword,
+			expectedError: "",
+		},
+		{
+			username:      "",
+			password:      defaultPassword,
+			expectedError: "ipmi 'username' cannot be empty",
+		},
+		{
+			username:      defaultUsername,
+			password:      "",
+			expectedError: "ipmi 'password' cannot be empty",
+		},
+	}
+
+	for _, testCase := range testCases {
+		ipmi := NewIPMI(defaultHost).WithIPMIUser(testCase.username, testCase.password)
+		assert.Equal(t, testCase.expectedError, ipmi.errorMsg)
+
+		if testCase.expectedError == "" {
+			assert.Equal(t, &User{Name: testCase.username, Password: testCase.password}, ipmi.user)
+		}
+	}
+}
+
+func TestIPMIWithIPMIPortAndTimeout(t *testing.T) {
+	ipmi := NewIPMI(defaultHost).WithIPMIPort(6230).WithIPMITimeout(time.Minute)
+	assert.Empty(t, ipmi.errorMsg)
+	assert.Equal(t, uint16(6230), ipmi.port)
+	assert.Equal(t, time.Minute, ipmi.timeout)
+
+	ipmi = NewIPMI(defaultHost).WithIPMIPort(0)
+	assert.Equal(t, "ipmi 'port' cannot be zero", ipmi.errorMsg)
+
+	ipmi = NewIPMI(defaultHost).WithIPMITimeout(0)
+	assert.Equal(t, "ipmi 'timeout' cannot be less than or equal to zero", ipmi.errorMsg)
+}
+
+func TestIPMIPowerActions(t *testing.T) {
+	testCases := []struct {
+		action        func(ipmi *IPMI) error
+		expectedCalls [][]string
+	}{
+		{
+			action:        (*IPMI).SystemPowerOn,
+			expectedCalls: [][]string{{"chassis", "power", "on"}},
+		},
+		{
+			action:        (*IPMI).SystemPowerOff,
+			expectedCalls: [][]string{{"chassis", "power", "off"}},
+		},
+		{
+			action:        (*IPMI).SystemForceReset,
+			expectedCalls: [][]string{{"chassis", "power", "reset"}},
+		},
+		{
+			action:        (*IPMI).SystemGracefulShutdown,
+			expectedCalls: [][]string{{"chassis", "power", "soft"}},
+		},
+		{
+			action:        (*IPMI).SystemPowerCycle,
+			expectedCalls: [][]string{{"chassis", "power", "status"}, {"chassis", "power", "cycle"}},
+		},
+	}
+
+	for _, testCase := range testCases {
+		runner := &fakeIPMIRunner{outputs: map[string]string{
+			"[chassis power status]": "Chassis Power is on\n",
+		}}
+
+		err := testCase.action(newFakeIPMI(runner))
+		assert.NoError(t, err)
+		assert.Equal(t, testCase.expectedCalls, runner.calls)
+		assert.Equal(t, []string{"IPMI_PASSWORD=" + defaultPassword}, runner.env)
+	}
+
+	// Power cycling a system that is off powers it on.
+	runner := &fakeIPMIRunner{outputs: map[string]string{"[chassis power status]": "Chassis Power is off"}}
+
+	err := newFakeIPMI(runner).SystemPowerCycle()
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"chassis", "power", "status"}, {"chassis", "power", "on"}}, runner.calls)
+
+	// Errors include the ipmitool output.
+	runner = &fakeIPMIRunner{failures: map[string]bool{"[chassis power on]": true}}
+
+	err = newFakeIPMI(runner).SystemPowerOn()
+	assert.EqualError(t, err, "failed to run ipmi command [chassis power on]: exit status 1: "+
+		"Error: Unable to establish IPMI v2 / RMCP+ session")
+
+	// Commands are not run without a user.
+	ipmi := NewIPMI(defaultHost)
+	ipmi.runCommand = runner.run
+
+	err = ipmi.SystemPowerOff()
+	assert.EqualError(t, err, "cannot access ipmi with nil user")
+}
+
+func TestIPMISystemPowerState(t *testing.T) {
+	testCases := []struct {
+		output        string
+		expectedState string
+		expectedError string
+	}{
+		{
+			output:        "Chassis Power is on",
+			expectedState: "On",
+		},
+		{
+			output:        "Chassis Power is off",
+			expectedState: "Off",
+		},
+		{
+			output:        "Unknown",
+			expectedError: "unexpected ipmi power status output: Unknown",
+		},
+	}
+
+	for _, testCase := range testCases {
+		runner := &fakeIPMIRunner{outputs: map[string]string{"[chassis power status]": testCase.output}}
+
+		powerState, err := newFakeIPMI(runner).SystemPowerState()
+		if testCase.expectedError != "" {
+			assert.EqualError(t, err, testCase.expectedError)
+		} else {
+			assert.NoError(t, err)
+		}
+
+		assert.Equal(t, testCase.expectedState, powerState)
+	}
+}
+
+func TestIPMISetSystemBootDevice(t *testing.T) {
+	testCases := []struct {
+		device        BootDevice
+		once          bool
+		expectedCalls [][]string
+		expectedError string
+	}{
+		{
+			device:        BootDevicePXE,
+			once:          true,
+			expectedCalls: [][]string{{"chassis", "bootdev", "pxe"}},
+		},
+		{
+			device:        BootDeviceCD,
+			once:          false,
+			expectedCalls: [][]string{{"chassis", "bootdev", "cdrom", "options=persistent"}},
+		},
+		{
+			device:        "floppy",
+			once:          true,
+			expectedError: "boot device floppy is not supported",
+		},
+	}
+
+	for _, testCase := range testCases {
+		runner := &fakeIPMIRunner{}
+
+		err := newFakeIPMI(runner).SetSystemBootDevice(testCase.device, testCase.once)
+		if testCase.expectedError != "" {
+			assert.EqualError(t, err, testCase.expectedError)
+		} else {
+			assert.NoError(t, err)
+		}
+
+		assert.Equal(t, testCase.expectedCalls, runner.calls)
+	}
+}
+
+func TestIPMISystemSensors(t *testing.T) {
+	runner := &fakeIPMIRunner{outputs: map[string]string{"[sensor list]": defaultIPMISensorList}}
+
+	expectedReadings := []SensorReading{
+		{Name: "Inlet Temp", Type: SensorTypeTemperature, Reading: 23, Units: "degrees C", Health: "OK"},
+		{Name: "Fan1A", Type: SensorTypeFan, Reading: 6840, Units: "RPM", Health: "OK"},
+		{Name: "PS1 Voltage 1", Type: SensorTypeVoltage, Reading: 230, Units: "Volts", Health: "Warning"},
+		{Name: "Pwr Consumption", Type: SensorTypePower, Reading: 168, Units: "Watts", Health: "OK"},
+	}
+
+	readings, err := newFakeIPMI(runner).SystemSensors()
+	assert.NoError(t, err)
+	assert.Equal(t, expectedReadings, readings)
+}
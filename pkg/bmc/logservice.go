@@ -0,0 +1,195 @@
+package bmc
+
+import (
+	"fmt"
+
+	"github.com/golang/glog"
+	"github.com/stmcginnis/gofish"
+	"github.com/stmcginnis/gofish/redfish"
+)
+
+// LogEntry holds a single entry of a Redfish log service.
+type LogEntry struct {
+	// ID is the Redfish identifier of the entry.
+	ID string
+	// Created is the time the entry was created.
+	Created string
+	// EntryType is the type of the entry, e.g. "Event" or "SEL".
+	EntryType redfish.LogEntryType
+	// Severity is the severity of the entry, one of OK/Warning/Critical.
+	Severity redfish.EventSeverity
+	// MessageID is the registry message identifier of the entry.
+	MessageID string
+	// Message is the human readable message of the entry.
+	Message string
+}
+
+// SystemLogServices returns the IDs of the log services of the system using the Redfish API, e.g. "Sel".
+func (bmc *BMC) SystemLogServices() ([]string, error) {
+	if valid, err := bmc.validateRedfish(); !valid {
+		return nil, err
+	}
+
+	glog.V(100).Infof("Getting system log services from bmc's redfish endpoint")
+
+	return bmc.getLogServiceIDs(redfishGetSystemLogServices)
+}
+
+// SystemLogEntries returns the entries of the system log service with the given ID using the Redfish API.
+func (bmc *BMC) SystemLogEntries(logServiceID string) ([]LogEntry, error) {
+	if valid, err := bmc.validateRedfish(); !valid {
+		return nil, err
+	}
+
+	glog.V(100).Infof("Getting entries of system log service %s from bmc's redfish endpoint", logServiceID)
+
+	return bmc.getLogEntries(logServiceID, redfishGetSystemLogServices)
+}
+
+// ManagerLogServices returns the IDs of the log services of the manager (BMC) responsible for the system using the
+// Redfish API, e.g. "Lclog".
+func (bmc *BMC) ManagerLogServices() ([]string, error) {
+	if valid, err := bmc.validateRedfish(); !valid {
+		return nil, err
+	}
+
+	glog.V(100).Infof("Getting manager log services from bmc's redfish endpoint")
+
+	return bmc.getLogServiceIDs(redfishGetManagerLogServices)
+}
+
+// ManagerLogEntries returns the entries of the manager log service with the given ID using the Redfish API.
+func (bmc *BMC) ManagerLogEntries(logServiceID string) ([]LogEntry, error) {
+	if valid, err := bmc.validateRedfish(); !valid {
+		return nil, err
+	}
+
+	glog.V(100).Infof("Getting entries of manager log service %s from bmc's redfish endpoint", logServiceID)
+
+	return bmc.getLogEntries(logServiceID, redfishGetManagerLogServices)
+}
+
+// getLogServiceIDs connects to the Redfish API and returns the IDs of the log services returned by listFunc.
+func (bmc *BMC) getLogServiceIDs(
+	listFunc func(*gofish.APIClient, redfishSystemRef) ([]*redfish.LogService, error)) ([]string, error) {
+	redfishClient, cancel, err := redfishConnect(
+		bmc.host,
+		bmc.redfishUser.Name,
+		bmc.redfishUser.Password,
+		bmc.timeOuts.Redfish)
+	if err != nil {
+		glog.V(100).Infof("Redfish connection error: %v", err)
+
+		return nil, fmt.Errorf("redfish connection error: %w", err)
+	}
+
+	defer func() {
+		redfishClient.Logout()
+		cancel()
+	}()
+
+	logServices, err := listFunc(redfishClient, bmc.redfishSystemRef())
+	if err != nil {
+		glog.V(100).Infof("Failed to get redfish log services: %v", err)
+
+		return nil, fmt.Errorf("failed to get redfish log services: %w", err)
+	}
+
+	var logServiceIDs []string
+	for _, logService := range logServices {
+		logServiceIDs = append(logServiceIDs, logService.ID)
+	}
+
+	return logServiceIDs, nil
+}
+
+// getLogEntries connects to the Redfish API and returns the entries of the log service with the given ID among the
+// ones returned by listFunc.
+func (bmc *BMC) getLogEntries(
+	logServiceID string,
+	listFunc func(*gofish.APIClient, redfishSystemRef) ([]*redfish.LogService, error)) ([]LogEntry, error) {
+	if logServiceID == "" {
+		glog.V(100).Infof("The log service ID is empty")
+
+		return nil, fmt.Errorf("log service 'logServiceID' cannot be empty")
+	}
+
+	redfishClient, cancel, err := redfishConnect(
+		bmc.host,
+		bmc.redfishUser.Name,
+		bmc.redfishUser.Password,
+		bmc.timeOuts.Redfish)
+	if err != nil {
+		glog.V(100).Infof("Redfish connection error: %v", err)
+
+		return nil, fmt.Errorf("redfish connection error: %w", err)
+	}
+
+	defer func() {
+		redfishClient.Logout()
+		cancel()
+	}()
+
+	logServices, err := listFunc(redfishClient, bmc.redfishSystemRef())
+	if err != nil {
+		glog.V(100).Infof("Failed to get redfish log services: %v", err)
+
+		return nil, fmt.Errorf("failed to get redfish log services: %w", err)
+	}
+
+	for _, logService := range logServices {
+		if logService.ID != logServiceID {
+			continue
+		}
+
+		entries, err := logService.Entries()
+		if err != nil {
+			glog.V(100).Infof("Failed to get redfish log service %s entries: %v", logServiceID, err)
+
+			return nil, fmt.Errorf("failed to get log service %s entries: %w", logServiceID, err)
+		}
+
+		var logEntries []LogEntry
+
+		for _, entry := range entries {
+			logEntries = append(logEntries, LogEntry{
+				ID:        entry.ID,
+				Created:   entry.Created,
+				EntryType: entry.EntryType,
+				Severity:  entry.Severity,
+				MessageID: entry.MessageID,
+				Message:   entry.Message,
+			})
+		}
+
+		return logEntries, nil
+	}
+
+	glog.V(100).Infof("Log service %s not found", logServiceID)
+
+	return nil, fmt.Errorf("log service %s not found", logServiceID)
+}
+
+// redfishGetSystemLogServices uses the provided gofish APIClient and the system reference to get the log services of a
+// system.
+func redfishGetSystemLogServices(
+	redfishClient *gofish.APIClient, systemRef redfishSystemRef) ([]*redfish.LogService, error) {
+	system, err := redfishGetSystem(redfishClient, systemRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get redfish system: %w", err)
+	}
+
+	return system.LogServices()
+}
+
+// redfishGetManagerLogServices uses the provided gofish APIClient and the system reference to get the log services of
+// the manager responsible for a system.
+func redfishGetManagerLogServices(
+	redfishClient *gofish.APIClient, systemRef redfishSystemRef) ([]*redfish.LogService, error) {
+	manager, err := redfishGetSystemManager(redfishClient, systemRef)
+	if err != nil {
+		return nil, err
+	}
+
+	return manager.LogServices()
+}
@@ -0,0 +1,86 @@
+package bmc
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stmcginnis/gofish/redfish"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBMCSystemLogServices(t *testing.T) {
+	// Create fake redfish endpoint.
+	redfishServer := createFakeRedfishLocalServer(false, redfishAPIResponseCallbacks{})
+	defer redfishServer.Close()
+
+	host := strings.Split(redfishServer.URL, "//")[1]
+	bmc := New(host).WithRedfishUser(defaultUsername, defaultPassword)
+
+	logServices, err := bmc.SystemLogServices()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Sel"}, logServices)
+}
+
+func TestBMCSystemLogEntries(t *testing.T) {
+	testCases := []struct {
+		logServiceID    string
+		expectedEntries []LogEntry
+		expectedError   string
+	}{
+		{
+			logServiceID: "Sel",
+			expectedEntries: []LogEntry{{
+				ID:        "1",
+				Created:   "2024-06-10T12:03:41-05:00",
+				EntryType: redfish.SELLogEntryType,
+				Severity:  redfish.CriticalEventSeverity,
+				MessageID: "IDRAC.2.9.PWR2263",
+				Message:   "The system board BIOS has stopped responding.",
+			}},
+			expectedError: "",
+		},
+		{
+			logServiceID:  "",
+			expectedError: "log service 'logServiceID' cannot be empty",
+		},
+		{
+			logServiceID:  "Lclog",
+			expectedError: "log service Lclog not found",
+		},
+	}
+
+	// Create fake redfish endpoint.
+	redfishServer := createFakeRedfishLocalServer(false, redfishAPIResponseCallbacks{})
+	defer redfishServer.Close()
+
+	host := strings.Split(redfishServer.URL, "//")[1]
+	bmc := New(host).WithRedfishUser(defaultUsername, defaultPassword)
+
+	for _, testCase := range testCases {
+		entries, err := bmc.SystemLogEntries(testCase.logServiceID)
+		if testCase.expectedError != "" {
+			assert.EqualError(t, err, testCase.expectedError)
+		} else {
+			assert.NoError(t, err)
+		}
+
+		assert.Equal(t, testCase.expectedEntries, entries)
+	}
+}
+
+func TestBMCManagerLogServices(t *testing.T) {
+	// Create fake redfish endpoint.
+	redfishServer := createFakeRedfishLocalServer(false, redfishAPIResponseCallbacks{})
+	defer redfishServer.Close()
+
+	host := strings.Split(redfishServer.URL, "//")[1]
+	bmc := New(host).WithRedfishUser(defaultUsername, defaultPassword)
+
+	logServices, err := bmc.ManagerLogServices()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Lclog"}, logServices)
+
+	entries, err := bmc.ManagerLogEntries("Lclog")
+	assert.NoError(t, err)
+	assert.Empty(t, entries)
+}
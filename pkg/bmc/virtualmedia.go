@@ -0,0 +1,400 @@
+package bmc
+
+import (
+	"fmt"
+
+	"github.com/golang/glog"
+	"github.com/stmcginnis/gofish"
+	"github.com/stmcginnis/gofish/redfish"
+)
+
+// VirtualMediaStatus holds the state of a virtual media device as reported by the Redfish API.
+type VirtualMediaStatus struct {
+	// ID is the Redfish identifier of the virtual media device, e.g. "CD" or "1".
+	ID string
+	// Name is the display name of the virtual media device.
+	Name string
+	// MediaTypes holds the media types supported by the device.
+	MediaTypes []redfish.VirtualMediaType
+	// Image is the URI of the inserted image, empty if no image is connected.
+	Image string
+	// ImageName is the name of the inserted image.
+	ImageName string
+	// Inserted reports whether a media is present in the device.
+	Inserted bool
+	// WriteProtected reports whether the media is write protected.
+	WriteProtected bool
+	// ConnectedVia is the current connection method of the device.
+	ConnectedVia redfish.ConnectedVia
+}
+
+// VirtualMediaList returns the status of all the virtual media devices of the system using the Redfish API. Devices
+// are looked up in the system first and, if the system exposes none, in the first manager of the system.
+func (bmc *BMC) VirtualMediaList() ([]VirtualMediaStatus, error) {
+	if valid, err := bmc.validateRedfish(); !valid {
+		return nil, err
+	}
+
+	glog.V(100).Infof("Getting virtual media list from bmc's redfish endpoint")
+
+	redfishClient, cancel, err := redfishConnect(
+		bmc.host,
+		bmc.redfishUser.Name,
+		bmc.redfishUser.Password,
+		bmc.timeOuts.Redfish)
+	if err != nil {
+		glog.V(100).Infof("Redfish connection error: %v", err)
+
+		return nil, fmt.Errorf("redfish connection error: %w", err)
+	}
+
+	defer func() {
+		redfishClient.Logout()
+		cancel()
+	}()
+
+	virtualMedias, err := redfishGetVirtualMedias(redfishClient, bmc.redfishSystemRef())
+	if err != nil {
+		glog.V(100).Infof("Failed to get redfish virtual media: %v", err)
+
+		return nil, fmt.Errorf("failed to get redfish virtual media: %w", err)
+	}
+
+	var statuses []VirtualMediaStatus
+	for _, virtualMedia := range virtualMedias {
+		statuses = append(statuses, newVirtualMediaStatus(virtualMedia))
+	}
+
+	return statuses, nil
+}
+
+// VirtualMediaStatus returns the status of the virtual media device with the given ID using the Redfish API.
+func (bmc *BMC) VirtualMediaStatus(mediaID string) (*VirtualMediaStatus, error) {
+	if valid, err := bmc.validateRedfish(); !valid {
+		return nil, err
+	}
+
+	glog.V(100).Infof("Getting virtual media %s status from bmc's redfish endpoint", mediaID)
+
+	redfishClient, cancel, err := redfishConnect(
+		bmc.host,
+		bmc.redfishUser.Name,
+		bmc.redfishUser.Password,
+		bmc.timeOuts.Redfish)
+	if err != nil {
+		glog.V(100).Infof("Redfish connection error: %v", err)
+
+		return nil, fmt.Errorf("redfish connection error: %w", err)
+	}
+
+	defer func() {
+		redfishClient.Logout()
+		cancel()
+	}()
+
+	virtualMedia, err := redfishGetVirtualMedia(redfishClient, bmc.redfishSystemRef(), mediaID)
+	if err != nil {
+		glog.V(100).Infof("Failed to get redfish virtual media %s: %v", mediaID, err)
+
+		return nil, fmt.Errorf("failed to get redfish virtual media %s: %w", mediaID, err)
+	}
+
+	status := newVirtualMediaStatus(virtualMedia)
+
+	return &status, nil
+}
+
+// VirtualMediaInsert inserts the image located at imageURL in the virtual media device with the given ID using the
+// Redfish API. The image is inserted write protected. It fails if there is already a media inserted in the device.
+func (bmc *BMC) VirtualMediaInsert(mediaID, imageURL string) error {
+	if valid, err := bmc.validateRedfish(); !valid {
+		return err
+	}
+
+	glog.V(100).Infof("Inserting image %s in virtual media %s from bmc's redfish endpoint", imageURL, mediaID)
+
+	if imageURL == "" {
+		glog.V(100).Infof("The virtual media image URL is empty")
+
+		return fmt.Errorf("virtual media 'imageURL' cannot be empty")
+	}
+
+	redfishClient, cancel, err := redfishConnect(
+		bmc.host,
+		bmc.redfishUser.Name,
+		bmc.redfishUser.Password,
+		bmc.timeOuts.Redfish)
+	if err != nil {
+		glog.V(100).Infof("Redfish connection error: %v", err)
+
+		return fmt.Errorf("redfish connection error: %w", err)
+	}
+
+	defer func() {
+		redfishClient.Logout()
+		cancel()
+	}()
+
+	virtualMedia, err := redfishGetVirtualMedia(redfishClient, bmc.redfishSystemRef(), mediaID)
+	if err != nil {
+		glog.V(100).Infof("Failed to get redfish virtual media %s: %v", mediaID, err)
+
+		return fmt.Errorf("failed to get redfish virtual media %s: %w", mediaID, err)
+	}
+
+	if virtualMedia.Inserted {
+		glog.V(100).Infof("Failed to insert virtual media: image %s is already inserted", virtualMedia.Image)
+
+		return fmt.Errorf("virtual media %s already has image %s inserted", mediaID, virtualMedia.Image)
+	}
+
+	err = virtualMedia.InsertMedia(imageURL, true, true)
+	if err != nil {
+		glog.V(100).Infof("Failed to insert virtual media: %v", err)
+
+		return fmt.Errorf("failed to insert virtual media %s: %w", mediaID, err)
+	}
+
+	return nil
+}
+
+// VirtualMediaEject ejects the media inserted in the virtual media device with the given ID using the Redfish API.
+// Ejecting a device with no media inserted is a no-op.
+func (bmc *BMC) VirtualMediaEject(mediaID string) error {
+	if valid, err := bmc.validateRedfish(); !valid {
+		return err
+	}
+
+	glog.V(100).Infof("Ejecting virtual media %s from bmc's redfish endpoint", mediaID)
+
+	redfishClient, cancel, err := redfishConnect(
+		bmc.host,
+		bmc.redfishUser.Name,
+		bmc.redfishUser.Password,
+		bmc.timeOuts.Redfish)
+	if err != nil {
+		glog.V(100).Infof("Redfish connection error: %v", err)
+
+		return fmt.Errorf("redfish connection error: %w", err)
+	}
+
+	defer func() {
+		redfishClient.Logout()
+		cancel()
+	}()
+
+	virtualMedia, err := redfishGetVirtualMedia(redfishClient, bmc.redfishSystemRef(), mediaID)
+	if err != nil {
+		glog.V(100).Infof("Failed to get redfish virtual media %s: %v", mediaID, err)
+
+		return fmt.Errorf("failed to get redfish virtual media %s: %w", mediaID, err)
+	}
+
+	if !virtualMedia.Inserted {
+		glog.V(100).Infof("Virtual media %s has no media inserted, nothing to eject", mediaID)
+
+		return nil
+	}
+
+	err = virtualMedia.EjectMedia()
+	if err != nil {
+		glog.V(100).Infof("Failed to eject virtual media: %v", err)
+
+		return fmt.Errorf("failed to eject virtual media %s: %w", mediaID, err)
+	}
+
+	return nil
+}
+
+// SetSystemBootOverride sets the boot source override target of the system using the Redfish API. When once is true
+// the override is only used in the next boot, otherwise it is used until it is disabled. As for the boot order, the
+// override is only applied when the system is reset.
+func (bmc *BMC) SetSystemBootOverride(target redfish.BootSourceOverrideTarget, once bool) error {
+	if valid, err := bmc.validateRedfish(); !valid {
+		return err
+	}
+
+	glog.V(100).Infof("Setting boot source override target to %s (once: %t) from redfish endpoint", target, once)
+
+	if target == "" {
+		glog.V(100).Infof("The boot override target is empty")
+
+		return fmt.Errorf("boot override 'target' cannot be empty")
+	}
+
+	redfishClient, cancel, err := redfishConnect(
+		bmc.host,
+		bmc.redfishUser.Name,
+		bmc.redfishUser.Password,
+		bmc.timeOuts.Redfish)
+	if err != nil {
+		glog.V(100).Infof("Redfish connection error: %v", err)
+
+		return fmt.Errorf("redfish connection error: %w", err)
+	}
+
+	defer func() {
+		redfishClient.Logout()
+		cancel()
+	}()
+
+	system, err := redfishGetSystem(redfishClient, bmc.redfishSystemRef())
+	if err != nil {
+		glog.V(100).Infof("Failed to get redfish system: %v", err)
+
+		return fmt.Errorf("failed to get redfish system: %w", err)
+	}
+
+	newBoot := redfish.Boot{
+		BootSourceOverrideTarget:  target,
+		BootSourceOverrideEnabled: redfish.ContinuousBootSourceOverrideEnabled,
+	}
+
+	if once {
+		newBoot.BootSourceOverrideEnabled = redfish.OnceBootSourceOverrideEnabled
+	}
+
+	glog.V(100).Infof("Setting new Boot value: %+v", newBoot)
+
+	return system.SetBoot(newBoot)
+}
+
+// VirtualMediaBootISO prepares the system to boot once from the ISO located at isoURL. Any media inserted in the
+// first CD or DVD virtual media device is ejected, the ISO is inserted in its place and the boot source override is
+// set to Cd for the next boot. The system must be reset afterwards, e.g. using SystemPowerCycle.
+func (bmc *BMC) VirtualMediaBootISO(isoURL string) error {
+	if valid, err := bmc.validateRedfish(); !valid {
+		return err
+	}
+
+	glog.V(100).Infof("Setting up system to boot from ISO %s using virtual media", isoURL)
+
+	virtualMedias, err := bmc.VirtualMediaList()
+	if err != nil {
+		return err
+	}
+
+	mediaID := ""
+
+	for _, virtualMedia := range virtualMedias {
+		if isVirtualMediaTypeSupported(redfish.CDMediaType, virtualMedia.MediaTypes) ||
+			isVirtualMediaTypeSupported(redfish.DVDMediaType, virtualMedia.MediaTypes) {
+			mediaID = virtualMedia.ID
+
+			break
+		}
+	}
+
+	if mediaID == "" {
+		glog.V(100).Infof("No CD or DVD virtual media device found for %v", bmc.host)
+
+		return fmt.Errorf("no CD or DVD virtual media device found for %v", bmc.host)
+	}
+
+	err = bmc.VirtualMediaEject(mediaID)
+	if err != nil {
+		return err
+	}
+
+	err = bmc.VirtualMediaInsert(mediaID, isoURL)
+	if err != nil {
+		return err
+	}
+
+	return bmc.SetSystemBootOverride(redfish.CdBootSourceOverrideTarget, true)
+}
+
+// redfishGetVirtualMedias uses the provided gofish APIClient and the system reference to get the virtual media devices
+// of a system. If the system does not expose any, the virtual media devices of the first manager of the system are
+// used.
+func redfishGetVirtualMedias(
+	redfishClient *gofish.APIClient, systemRef redfishSystemRef) ([]*redfish.VirtualMedia, error) {
+	system, err := redfishGetSystem(redfishClient, systemRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get redfish system: %w", err)
+	}
+
+	virtualMedias, err := system.VirtualMedia()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get system virtual media: %w", err)
+	}
+
+	if len(virtualMedias) > 0 {
+		return virtualMedias, nil
+	}
+
+	manager, err := redfishGetSystemManager(redfishClient, systemRef)
+	if err != nil {
+		return nil, err
+	}
+
+	virtualMedias, err = manager.VirtualMedia()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get manager virtual media: %w", err)
+	}
+
+	return virtualMedias, nil
+}
+
+// redfishGetVirtualMedia returns the virtual media device of the system with the given ID.
+func redfishGetVirtualMedia(
+	redfishClient *gofish.APIClient, systemRef redfishSystemRef, mediaID string) (*redfish.VirtualMedia, error) {
+	if mediaID == "" {
+		return nil, fmt.Errorf("virtual media 'mediaID' cannot be empty")
+	}
+
+	virtualMedias, err := redfishGetVirtualMedias(redfishClient, systemRef)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, virtualMedia := range virtualMedias {
+		if virtualMedia.ID == mediaID {
+			return virtualMedia, nil
+		}
+	}
+
+	return nil, fmt.Errorf("virtual media %s not found", mediaID)
+}
+
+// redfishGetSystemManager returns the first manager responsible for the system with the given index.
+func redfishGetSystemManager(redfishClient *gofish.APIClient, systemRef redfishSystemRef) (*redfish.Manager, error) {
+	system, err := redfishGetSystem(redfishClient, systemRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get redfish system: %w", err)
+	}
+
+	if len(system.ManagedBy) == 0 {
+		return nil, fmt.Errorf("no manager found for redfish system %s", system.ID)
+	}
+
+	manager, err := redfish.GetManager(redfishClient, system.ManagedBy[0])
+	if err != nil {
+		return nil, fmt.Errorf("failed to get redfish manager: %w", err)
+	}
+
+	return manager, nil
+}
+
+func newVirtualMediaStatus(virtualMedia *redfish.VirtualMedia) VirtualMediaStatus {
+	return VirtualMediaStatus{
+		ID:             virtualMedia.ID,
+		Name:           virtualMedia.Name,
+		MediaTypes:     virtualMedia.MediaTypes,
+		Image:          virtualMedia.Image,
+		ImageName:      virtualMedia.ImageName,
+		Inserted:       virtualMedia.Inserted,
+		WriteProtected: virtualMedia.WriteProtected,
+		ConnectedVia:   virtualMedia.ConnectedVia,
+	}
+}
+
+func isVirtualMediaTypeSupported(mediaType redfish.VirtualMediaType, supportedTypes []redfish.VirtualMediaType) bool {
+	for _, supportedType := range supportedTypes {
+		if supportedType == mediaType {
+			return true
+		}
+	}
+
+	return false
+}
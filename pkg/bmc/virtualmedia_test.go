@@ -0,0 +1,165 @@
+package bmc
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stmcginnis/gofish/redfish"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBMCVirtualMediaList(t *testing.T) {
+	// Create fake redfish endpoint.
+	redfishServer := createFakeRedfishLocalServer(false, redfishAPIResponseCallbacks{})
+	defer redfishServer.Close()
+
+	host := strings.Split(redfishServer.URL, "//")[1]
+	bmc := New(host).WithRedfishUser(defaultUsername, defaultPassword)
+
+	virtualMedias, err := bmc.VirtualMediaList()
+	assert.NoError(t, err)
+	assert.Len(t, virtualMedias, 1)
+	assert.Equal(t, "1", virtualMedias[0].ID)
+	assert.Equal(t, []redfish.VirtualMediaType{redfish.CDMediaType, redfish.DVDMediaType, redfish.USBStickMediaType},
+		virtualMedias[0].MediaTypes)
+	assert.False(t, virtualMedias[0].Inserted)
+}
+
+func TestBMCVirtualMediaStatus(t *testing.T) {
+	testCases := []struct {
+		mediaID       string
+		expectedError string
+	}{
+		{
+			mediaID:       "1",
+			expectedError: "",
+		},
+		{
+			mediaID:       "",
+			expectedError: "failed to get redfish virtual media : virtual media 'mediaID' cannot be empty",
+		},
+		{
+			mediaID:       "2",
+			expectedError: "failed to get redfish virtual media 2: virtual media 2 not found",
+		},
+	}
+
+	// Create fake redfish endpoint.
+	redfishServer := createFakeRedfishLocalServer(false, redfishAPIResponseCallbacks{})
+	defer redfishServer.Close()
+
+	host := strings.Split(redfishServer.URL, "//")[1]
+	bmc := New(host).WithRedfishUser(defaultUsername, defaultPassword)
+
+	for _, testCase := range testCases {
+		status, err := bmc.VirtualMediaStatus(testCase.mediaID)
+		if testCase.expectedError != "" {
+			assert.EqualError(t, err, testCase.expectedError)
+
+			continue
+		}
+
+		assert.NoError(t, err)
+		assert.Equal(t, testCase.mediaID, status.ID)
+		assert.Equal(t, redfish.NotConnectedConnectedVia, status.ConnectedVia)
+	}
+}
+
+func TestBMCVirtualMediaInsert(t *testing.T) {
+	testCases := []struct {
+		mediaID       string
+		imageURL      string
+		expectedError string
+	}{
+		{
+			mediaID:       "1",
+			imageURL:      "http://example.com/boot.iso",
+			expectedError: "",
+		},
+		{
+			mediaID:       "1",
+			imageURL:      "",
+			expectedError: "virtual media 'imageURL' cannot be empty",
+		},
+		{
+			mediaID:       "2",
+			imageURL:      "http://example.com/boot.iso",
+			expectedError: "failed to get redfish virtual media 2: virtual media 2 not found",
+		},
+	}
+
+	for _, testCase := range testCases {
+		var requestPath string
+
+		// Create fake redfish endpoint.
+		redfishServer := createFakeRedfishLocalServer(false, redfishAPIResponseCallbacks{
+			virtualMedia: func(r *http.Request) { requestPath = r.URL.Path },
+		})
+
+		host := strings.Split(redfishServer.URL, "//")[1]
+		bmc := New(host).WithRedfishUser(defaultUsername, defaultPassword)
+
+		err := bmc.VirtualMediaInsert(testCase.mediaID, testCase.imageURL)
+		if testCase.expectedError != "" {
+			assert.EqualError(t, err, testCase.expectedError)
+			assert.Empty(t, requestPath)
+		} else {
+			assert.NoError(t, err)
+			assert.True(t, strings.HasSuffix(requestPath, "VirtualMedia.InsertMedia"))
+		}
+
+		redfishServer.Close()
+	}
+}
+
+func TestBMCVirtualMediaEject(t *testing.T) {
+	var requestPath string
+
+	// Create fake redfish endpoint.
+	redfishServer := createFakeRedfishLocalServer(false, redfishAPIResponseCallbacks{
+		virtualMedia: func(r *http.Request) { requestPath = r.URL.Path },
+	})
+	defer redfishServer.Close()
+
+	host := strings.Split(redfishServer.URL, "//")[1]
+	bmc := New(host).WithRedfishUser(defaultUsername, defaultPassword)
+
+	// There is no media inserted, so no eject request should be sent.
+	err := bmc.VirtualMediaEject("1")
+	assert.NoError(t, err)
+	assert.Empty(t, requestPath)
+}
+
+func TestBMCSetSystemBootOverride(t *testing.T) {
+	// Create fake redfish endpoint.
+	redfishServer := createFakeRedfishLocalServer(false, redfishAPIResponseCallbacks{})
+	defer redfishServer.Close()
+
+	host := strings.Split(redfishServer.URL, "//")[1]
+	bmc := New(host).WithRedfishUser(defaultUsername, defaultPassword)
+
+	err := bmc.SetSystemBootOverride(redfish.CdBootSourceOverrideTarget, true)
+	assert.NoError(t, err)
+
+	err = bmc.SetSystemBootOverride("", true)
+	assert.EqualError(t, err, "boot override 'target' cannot be empty")
+}
+
+func TestBMCVirtualMediaBootISO(t *testing.T) {
+	var requestPaths []string
+
+	// Create fake redfish endpoint.
+	redfishServer := createFakeRedfishLocalServer(false, redfishAPIResponseCallbacks{
+		virtualMedia: func(r *http.Request) { requestPaths = append(requestPaths, r.URL.Path) },
+	})
+	defer redfishServer.Close()
+
+	host := strings.Split(redfishServer.URL, "//")[1]
+	bmc := New(host).WithRedfishUser(defaultUsername, defaultPassword)
+
+	err := bmc.VirtualMediaBootISO("http://example.com/boot.iso")
+	assert.NoError(t, err)
+	assert.Equal(t,
+		[]string{"/redfish/v1/Systems/System.Embedded.1/VirtualMedia/1/Actions/VirtualMedia.InsertMedia"}, requestPaths)
+}
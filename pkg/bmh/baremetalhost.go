@@ -2,6 +2,7 @@ package bmh
 
 import (
 	"context"
+	"encoding/json"
 	"time"
 
 	goclient "sigs.k8s.io/controller-runtime/pkg/client"
@@ -15,6 +16,7 @@ import (
 	"github.com/openshift-kni/eco-goinfra/pkg/clients"
 	"github.com/openshift-kni/eco-goinfra/pkg/msg"
 	"golang.org/x/exp/slices"
+	corev1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -629,6 +631,421 @@ func (builder *BmhBuilder) WaitUntilDeleted(timeout time.Duration) error {
 	return err
 }
 
+// WithImage sets the image to provision on the bmh. The checksum is optional for images in formats that do not need
+// to be checked, such as live-iso. Setting an image on an available bmh starts provisioning once the bmh is updated.
+func (builder *BmhBuilder) WithImage(url, checksum string, checksumType bmhv1alpha1.ChecksumType) *BmhBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Setting baremetalhost %s image to %s", builder.Definition.Name, url)
+
+	if url == "" {
+		glog.V(100).Infof("The baremetalhost image url is empty")
+
+		builder.errorMsg = "the baremetalhost image url cannot be empty"
+
+		return builder
+	}
+
+	builder.Definition.Spec.Image = &bmhv1alpha1.Image{
+		URL:          url,
+		Checksum:     checksum,
+		ChecksumType: checksumType,
+	}
+
+	return builder
+}
+
+// WithImageFormat sets the disk format of the image to provision on the bmh, e.g. raw, qcow2 or live-iso. WithImage
+// must be called before.
+func (builder *BmhBuilder) WithImageFormat(format string) *BmhBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Setting baremetalhost %s image format to %s", builder.Definition.Name, format)
+
+	if builder.Definition.Spec.Image == nil {
+		glog.V(100).Infof("The baremetalhost image is not set")
+
+		builder.errorMsg = "the baremetalhost image must be set before setting its format"
+
+		return builder
+	}
+
+	if format == "" {
+		glog.V(100).Infof("The baremetalhost image format is empty")
+
+		builder.errorMsg = "the baremetalhost image format cannot be empty"
+
+		return builder
+	}
+
+	builder.Definition.Spec.Image.DiskFormat = &format
+
+	return builder
+}
+
+// WithUserData sets the secret, in the bmh namespace, holding the user data passed to the provisioned image.
+func (builder *BmhBuilder) WithUserData(secretName string) *BmhBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Setting baremetalhost %s userData secret to %s", builder.Definition.Name, secretName)
+
+	if secretName == "" {
+		glog.V(100).Infof("The baremetalhost userData secret name is empty")
+
+		builder.errorMsg = "the baremetalhost userData secret name cannot be empty"
+
+		return builder
+	}
+
+	builder.Definition.Spec.UserData = &corev1.SecretReference{
+		Name:      secretName,
+		Namespace: builder.Definition.Namespace,
+	}
+
+	return builder
+}
+
+// WithNetworkData sets the secret, in the bmh namespace, holding the network data passed to the provisioned image.
+func (builder *BmhBuilder) WithNetworkData(secretName string) *BmhBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Setting baremetalhost %s networkData secret to %s", builder.Definition.Name, secretName)
+
+	if secretName == "" {
+		glog.V(100).Infof("The baremetalhost networkData secret name is empty")
+
+		builder.errorMsg = "the baremetalhost networkData secret name cannot be empty"
+
+		return builder
+	}
+
+	builder.Definition.Spec.NetworkData = &corev1.SecretReference{
+		Name:      secretName,
+		Namespace: builder.Definition.Namespace,
+	}
+
+	return builder
+}
+
+// WithCustomDeploy sets the custom deploy method used to provision the bmh instead of writing an image, e.g.
+// install_coreos. The method must be supported by the deploy ramdisk.
+func (builder *BmhBuilder) WithCustomDeploy(method string) *BmhBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Setting baremetalhost %s customDeploy method to %s", builder.Definition.Name, method)
+
+	if method == "" {
+		glog.V(100).Infof("The baremetalhost customDeploy method is empty")
+
+		builder.errorMsg = "the baremetalhost customDeploy method cannot be empty"
+
+		return builder
+	}
+
+	builder.Definition.Spec.CustomDeploy = &bmhv1alpha1.CustomDeploy{Method: method}
+
+	return builder
+}
+
+// WithOnline sets whether the bmh should be powered on. NewBuilder defaults it to true.
+func (builder *BmhBuilder) WithOnline(online bool) *BmhBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Setting baremetalhost %s online to %t", builder.Definition.Name, online)
+
+	builder.Definition.Spec.Online = online
+
+	return builder
+}
+
+// Update renews the bmh in the cluster using the builder definition.
+func (builder *BmhBuilder) Update() (*BmhBuilder, error) {
+	if valid, err := builder.validate(); !valid {
+		return builder, err
+	}
+
+	glog.V(100).Infof("Updating the baremetalhost %s in namespace %s",
+		builder.Definition.Name, builder.Definition.Namespace)
+
+	bmh, err := builder.Get()
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			glog.V(100).Infof("The baremetalhost %s does not exist in namespace %s",
+				builder.Definition.Name, builder.Definition.Namespace)
+
+			return builder, fmt.Errorf("cannot update non-existent baremetalhost")
+		}
+
+		return builder, fmt.Errorf("failed to get baremetalhost %s in namespace %s: %w",
+			builder.Definition.Name, builder.Definition.Namespace, err)
+	}
+
+	builder.Object = bmh
+	builder.Definition.ResourceVersion = builder.Object.ResourceVersion
+
+	err = builder.apiClient.Update(context.TODO(), builder.Definition)
+	if err != nil {
+		glog.V(100).Infof(
+			msg.FailToUpdateError("baremetalhost", builder.Definition.Name, builder.Definition.Namespace))
+
+		return builder, err
+	}
+
+	builder.Object = builder.Definition
+
+	return builder, nil
+}
+
+// PowerOn powers on the bmh by setting spec.online to true.
+func (builder *BmhBuilder) PowerOn() error {
+	return builder.setOnline(true)
+}
+
+// PowerOff powers off the bmh by setting spec.online to false.
+func (builder *BmhBuilder) PowerOff() error {
+	return builder.setOnline(false)
+}
+
+// Reboot requests the bmh to be rebooted using the reboot annotation. The mode can be hard, to power cycle the host
+// right away, or soft, to attempt a graceful shutdown first. The annotation is removed by the operator once the host
+// has been powered on again.
+func (builder *BmhBuilder) Reboot(mode bmhv1alpha1.RebootMode) error {
+	if valid, err := builder.validate(); !valid {
+		return err
+	}
+
+	glog.V(100).Infof("Rebooting baremetalhost %s in namespace %s with mode %s",
+		builder.Definition.Name, builder.Definition.Namespace, mode)
+
+	if mode != bmhv1alpha1.RebootModeHard && mode != bmhv1alpha1.RebootModeSoft {
+		glog.V(100).Infof("The baremetalhost reboot mode %s is not supported", mode)
+
+		return fmt.Errorf("baremetalhost reboot mode %s is not supported", mode)
+	}
+
+	arguments, err := json.Marshal(bmhv1alpha1.RebootAnnotationArguments{Mode: mode})
+	if err != nil {
+		return err
+	}
+
+	return builder.updateObject(func(bmh *bmhv1alpha1.BareMetalHost) {
+		if bmh.Annotations == nil {
+			bmh.Annotations = make(map[string]string)
+		}
+
+		bmh.Annotations[bmhv1alpha1.RebootAnnotationPrefix] = string(arguments)
+	})
+}
+
+// Detach sets the detached annotation on the bmh so it is no longer managed by the operator, without powering it off
+// or deprovisioning it.
+func (builder *BmhBuilder) Detach() error {
+	if valid, err := builder.validate(); !valid {
+		return err
+	}
+
+	glog.V(100).Infof("Detaching baremetalhost %s in namespace %s",
+		builder.Definition.Name, builder.Definition.Namespace)
+
+	return builder.updateObject(func(bmh *bmhv1alpha1.BareMetalHost) {
+		if bmh.Annotations == nil {
+			bmh.Annotations = make(map[string]string)
+		}
+
+		bmh.Annotations[bmhv1alpha1.DetachedAnnotation] = ""
+	})
+}
+
+// Attach removes the detached annotation from the bmh so it is managed by the operator again.
+func (builder *BmhBuilder) Attach() error {
+	if valid, err := builder.validate(); !valid {
+		return err
+	}
+
+	glog.V(100).Infof("Attaching baremetalhost %s in namespace %s",
+		builder.Definition.Name, builder.Definition.Namespace)
+
+	return builder.updateObject(func(bmh *bmhv1alpha1.BareMetalHost) {
+		delete(bmh.Annotations, bmhv1alpha1.DetachedAnnotation)
+	})
+}
+
+// IsDetached returns whether the bmh has the detached annotation.
+func (builder *BmhBuilder) IsDetached() (bool, error) {
+	if valid, err := builder.validate(); !valid {
+		return false, err
+	}
+
+	glog.V(100).Infof("Checking if baremetalhost %s in namespace %s is detached",
+		builder.Definition.Name, builder.Definition.Namespace)
+
+	if err := builder.refreshObject(); err != nil {
+		return false, err
+	}
+
+	_, detached := builder.Object.Annotations[bmhv1alpha1.DetachedAnnotation]
+
+	return detached, nil
+}
+
+// Deprovision removes the image, customDeploy, userData and networkData of the bmh, which makes the operator
+// deprovision it. Use WaitUntilAvailable to wait for the deprovisioning to finish.
+func (builder *BmhBuilder) Deprovision() error {
+	if valid, err := builder.validate(); !valid {
+		return err
+	}
+
+	glog.V(100).Infof("Deprovisioning baremetalhost %s in namespace %s",
+		builder.Definition.Name, builder.Definition.Namespace)
+
+	return builder.updateObject(func(bmh *bmhv1alpha1.BareMetalHost) {
+		bmh.Spec.Image = nil
+		bmh.Spec.CustomDeploy = nil
+		bmh.Spec.UserData = nil
+		bmh.Spec.NetworkData = nil
+	})
+}
+
+// GetHardwareDetails returns the hardware details of the bmh collected during inspection, including its NICs, storage
+// and CPU. An error is returned if the bmh has not been inspected yet.
+func (builder *BmhBuilder) GetHardwareDetails() (*bmhv1alpha1.HardwareDetails, error) {
+	if valid, err := builder.validate(); !valid {
+		return nil, err
+	}
+
+	glog.V(100).Infof("Getting hardware details of baremetalhost %s in namespace %s",
+		builder.Definition.Name, builder.Definition.Namespace)
+
+	if err := builder.refreshObject(); err != nil {
+		return nil, err
+	}
+
+	if builder.Object.Status.HardwareDetails == nil {
+		glog.V(100).Infof("The baremetalhost %s has no hardware details", builder.Definition.Name)
+
+		return nil, fmt.Errorf("baremetalhost %s has no hardware details", builder.Definition.Name)
+	}
+
+	return builder.Object.Status.HardwareDetails, nil
+}
+
+// GetBMCCredentials returns the username and password stored in the BMC credentials secret of the bmh.
+func (builder *BmhBuilder) GetBMCCredentials() (string, string, error) {
+	if valid, err := builder.validate(); !valid {
+		return "", "", err
+	}
+
+	glog.V(100).Infof("Getting BMC credentials of baremetalhost %s in namespace %s",
+		builder.Definition.Name, builder.Definition.Namespace)
+
+	if err := builder.refreshObject(); err != nil {
+		return "", "", err
+	}
+
+	secretName := builder.Object.Spec.BMC.CredentialsName
+	if secretName == "" {
+		glog.V(100).Infof("The baremetalhost %s has no BMC credentials secret", builder.Definition.Name)
+
+		return "", "", fmt.Errorf("baremetalhost %s has no bmc credentials secret", builder.Definition.Name)
+	}
+
+	secret := &corev1.Secret{}
+
+	err := builder.apiClient.Get(context.TODO(), goclient.ObjectKey{
+		Name:      secretName,
+		Namespace: builder.Object.Namespace,
+	}, secret)
+	if err != nil {
+		glog.V(100).Infof("Failed to get BMC credentials secret %s: %v", secretName, err)
+
+		return "", "", fmt.Errorf("failed to get bmc credentials secret %s: %w", secretName, err)
+	}
+
+	username, hasUsername := secret.Data["username"]
+	password, hasPassword := secret.Data["password"]
+
+	if !hasUsername || !hasPassword {
+		glog.V(100).Infof("The BMC credentials secret %s has no username or password", secretName)
+
+		return "", "", fmt.Errorf("bmc credentials secret %s must contain username and password", secretName)
+	}
+
+	return string(username), string(password), nil
+}
+
+// setOnline sets spec.online on the bmh in the cluster.
+func (builder *BmhBuilder) setOnline(online bool) error {
+	if valid, err := builder.validate(); !valid {
+		return err
+	}
+
+	glog.V(100).Infof("Setting baremetalhost %s in namespace %s online to %t",
+		builder.Definition.Name, builder.Definition.Namespace, online)
+
+	return builder.updateObject(func(bmh *bmhv1alpha1.BareMetalHost) {
+		bmh.Spec.Online = online
+	})
+}
+
+// updateObject applies mutate to the current bmh in the cluster and updates it. The builder definition is replaced by
+// the updated object, so any change in the definition not applied with Update is discarded.
+func (builder *BmhBuilder) updateObject(mutate func(bmh *bmhv1alpha1.BareMetalHost)) error {
+	if err := builder.refreshObject(); err != nil {
+		return err
+	}
+
+	mutate(builder.Object)
+
+	err := builder.apiClient.Update(context.TODO(), builder.Object)
+	if err != nil {
+		glog.V(100).Infof(
+			msg.FailToUpdateError("baremetalhost", builder.Definition.Name, builder.Definition.Namespace))
+
+		return fmt.Errorf("failed to update baremetalhost %s: %w", builder.Definition.Name, err)
+	}
+
+	builder.Definition = builder.Object
+
+	return nil
+}
+
+// refreshObject gets the current bmh from the cluster and stores it in the builder object. Unlike Exists, any error
+// getting the bmh is returned, so the builder object is always set when it succeeds.
+func (builder *BmhBuilder) refreshObject() error {
+	bmh, err := builder.Get()
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			glog.V(100).Infof("The baremetalhost %s does not exist in namespace %s",
+				builder.Definition.Name, builder.Definition.Namespace)
+
+			return fmt.Errorf("baremetalhost object %s does not exist in namespace %s",
+				builder.Definition.Name, builder.Definition.Namespace)
+		}
+
+		glog.V(100).Infof("Failed to get baremetalhost %s in namespace %s: %v",
+			builder.Definition.Name, builder.Definition.Namespace, err)
+
+		return fmt.Errorf("failed to get baremetalhost %s in namespace %s: %w",
+			builder.Definition.Name, builder.Definition.Namespace, err)
+	}
+
+	builder.Object = bmh
+
+	return nil
+}
+
 // validate will check that the builder and builder definition are properly initialized before
 // accessing any member fields.
 func (builder *BmhBuilder) validate() (bool, error) {
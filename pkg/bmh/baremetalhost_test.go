@@ -9,8 +9,11 @@ import (
 	bmhv1alpha1 "github.com/metal3-io/baremetal-operator/apis/metal3.io/v1alpha1"
 	"github.com/openshift-kni/eco-goinfra/pkg/clients"
 	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	goclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 )
 
 var (
@@ -1058,3 +1061,349 @@ func buildDummyBmHost(
 		},
 	})
 }
+
+func TestBareMetalHostWithImage(t *testing.T) {
+	testCases := []struct {
+		url           string
+		checksum      string
+		format        string
+		expectedError string
+	}{
+		{
+			url:           "http://example.com/rhcos.qcow2",
+			checksum:      "http://example.com/rhcos.qcow2.sha256sum",
+			format:        "qcow2",
+			expectedError: "",
+		},
+		{
+			url:           "",
+			checksum:      "",
+			format:        "qcow2",
+			expectedError: "the baremetalhost image url cannot be empty",
+		},
+		{
+			url:           "http://example.com/rhcos.iso",
+			checksum:      "",
+			format:        "",
+			expectedError: "the baremetalhost image format cannot be empty",
+		},
+	}
+
+	for _, testCase := range testCases {
+		testBuilder := buildValidBmHostBuilder(clients.GetTestClients(clients.TestClientParams{})).
+			WithImage(testCase.url, testCase.checksum, bmhv1alpha1.AutoChecksum).
+			WithImageFormat(testCase.format)
+		assert.Equal(t, testCase.expectedError, testBuilder.errorMsg)
+
+		if testCase.expectedError == "" {
+			assert.Equal(t, testCase.url, testBuilder.Definition.Spec.Image.URL)
+			assert.Equal(t, testCase.checksum, testBuilder.Definition.Spec.Image.Checksum)
+			assert.Equal(t, bmhv1alpha1.AutoChecksum, testBuilder.Definition.Spec.Image.ChecksumType)
+			assert.Equal(t, testCase.format, *testBuilder.Definition.Spec.Image.DiskFormat)
+		}
+	}
+
+	testBuilder := buildValidBmHostBuilder(clients.GetTestClients(clients.TestClientParams{})).WithImageFormat("raw")
+	assert.Equal(t, "the baremetalhost image must be set before setting its format", testBuilder.errorMsg)
+}
+
+func TestBareMetalHostWithUserDataAndNetworkData(t *testing.T) {
+	testCases := []struct {
+		userData      string
+		networkData   string
+		expectedError string
+	}{
+		{
+			userData:      "worker-user-data",
+			networkData:   "worker-network-data",
+			expectedError: "",
+		},
+		{
+			userData:      "",
+			networkData:   "worker-network-data",
+			expectedError: "the baremetalhost userData secret name cannot be empty",
+		},
+		{
+			userData:      "worker-user-data",
+			networkData:   "",
+			expectedError: "the baremetalhost networkData secret name cannot be empty",
+		},
+	}
+
+	for _, testCase := range testCases {
+		testBuilder := buildValidBmHostBuilder(clients.GetTestClients(clients.TestClientParams{})).
+			WithUserData(testCase.userData).
+			WithNetworkData(testCase.networkData)
+		assert.Equal(t, testCase.expectedError, testBuilder.errorMsg)
+
+		if testCase.expectedError == "" {
+			assert.Equal(t, testCase.userData, testBuilder.Definition.Spec.UserData.Name)
+			assert.Equal(t, defaultBmHostNsName, testBuilder.Definition.Spec.UserData.Namespace)
+			assert.Equal(t, testCase.networkData, testBuilder.Definition.Spec.NetworkData.Name)
+			assert.Equal(t, defaultBmHostNsName, testBuilder.Definition.Spec.NetworkData.Namespace)
+		}
+	}
+}
+
+func TestBareMetalHostWithCustomDeploy(t *testing.T) {
+	testBuilder := buildValidBmHostBuilder(clients.GetTestClients(clients.TestClientParams{})).
+		WithCustomDeploy("install_coreos")
+	assert.Empty(t, testBuilder.errorMsg)
+	assert.Equal(t, "install_coreos", testBuilder.Definition.Spec.CustomDeploy.Method)
+
+	testBuilder = buildValidBmHostBuilder(clients.GetTestClients(clients.TestClientParams{})).WithCustomDeploy("")
+	assert.Equal(t, "the baremetalhost customDeploy method cannot be empty", testBuilder.errorMsg)
+}
+
+func TestBareMetalHostWithOnline(t *testing.T) {
+	testBuilder := buildValidBmHostBuilder(clients.GetTestClients(clients.TestClientParams{})).WithOnline(false)
+	assert.Empty(t, testBuilder.errorMsg)
+	assert.False(t, testBuilder.Definition.Spec.Online)
+}
+
+func TestBareMetalHostUpdate(t *testing.T) {
+	testCases := []struct {
+		testBmHost    *BmhBuilder
+		expectedError error
+	}{
+		{
+			testBmHost:    buildValidBmHostBuilder(buildBareMetalHostTestClientWithDummyObject()),
+			expectedError: nil,
+		},
+		{
+			testBmHost:    buildValidBmHostBuilder(clients.GetTestClients(clients.TestClientParams{})),
+			expectedError: fmt.Errorf("cannot update non-existent baremetalhost"),
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase.testBmHost.WithCustomDeploy("install_coreos")
+
+		bmhBuilder, err := testCase.testBmHost.Update()
+		assert.Equal(t, testCase.expectedError, err)
+
+		if testCase.expectedError == nil {
+			assert.Equal(t, "install_coreos", bmhBuilder.Object.Spec.CustomDeploy.Method)
+
+			bmhObject, err := bmhBuilder.Get()
+			assert.Nil(t, err)
+			assert.Equal(t, "install_coreos", bmhObject.Spec.CustomDeploy.Method)
+		}
+	}
+}
+
+func TestBareMetalHostPowerOnOff(t *testing.T) {
+	testBuilder := buildValidBmHostBuilder(buildBareMetalHostTestClientWithDummyObject())
+
+	err := testBuilder.PowerOff()
+	assert.Nil(t, err)
+
+	bmhObject, err := testBuilder.Get()
+	assert.Nil(t, err)
+	assert.False(t, bmhObject.Spec.Online)
+
+	err = testBuilder.PowerOn()
+	assert.Nil(t, err)
+
+	bmhObject, err = testBuilder.Get()
+	assert.Nil(t, err)
+	assert.True(t, bmhObject.Spec.Online)
+
+	testBuilder = buildValidBmHostBuilder(clients.GetTestClients(clients.TestClientParams{}))
+	err = testBuilder.PowerOn()
+	assert.Equal(t, fmt.Errorf("baremetalhost object metallbio does not exist in namespace test-namespace"), err)
+}
+
+func TestBareMetalHostReboot(t *testing.T) {
+	testCases := []struct {
+		mode               bmhv1alpha1.RebootMode
+		expectedAnnotation string
+		expectedError      error
+	}{
+		{
+			mode:               bmhv1alpha1.RebootModeHard,
+			expectedAnnotation: `{"mode":"hard","force":false}`,
+			expectedError:      nil,
+		},
+		{
+			mode:               bmhv1alpha1.RebootModeSoft,
+			expectedAnnotation: `{"mode":"soft","force":false}`,
+			expectedError:      nil,
+		},
+		{
+			mode:          "warm",
+			expectedError: fmt.Errorf("baremetalhost reboot mode warm is not supported"),
+		},
+	}
+
+	for _, testCase := range testCases {
+		testBuilder := buildValidBmHostBuilder(buildBareMetalHostTestClientWithDummyObject())
+
+		err := testBuilder.Reboot(testCase.mode)
+		assert.Equal(t, testCase.expectedError, err)
+
+		if testCase.expectedError == nil {
+			bmhObject, err := testBuilder.Get()
+			assert.Nil(t, err)
+			assert.Equal(t, testCase.expectedAnnotation, bmhObject.Annotations[bmhv1alpha1.RebootAnnotationPrefix])
+		}
+	}
+}
+
+func TestBareMetalHostDetachAttach(t *testing.T) {
+	testBuilder := buildValidBmHostBuilder(buildBareMetalHostTestClientWithDummyObject())
+
+	detached, err := testBuilder.IsDetached()
+	assert.Nil(t, err)
+	assert.False(t, detached)
+
+	err = testBuilder.Detach()
+	assert.Nil(t, err)
+
+	detached, err = testBuilder.IsDetached()
+	assert.Nil(t, err)
+	assert.True(t, detached)
+
+	err = testBuilder.Attach()
+	assert.Nil(t, err)
+
+	detached, err = testBuilder.IsDetached()
+	assert.Nil(t, err)
+	assert.False(t, detached)
+}
+
+func TestBareMetalHostDeprovision(t *testing.T) {
+	testBuilder := buildValidBmHostBuilder(buildBareMetalHostTestClientWithDummyObject()).
+		WithImage("http://example.com/rhcos.qcow2", "", "").
+		WithUserData("worker-user-data").
+		WithNetworkData("worker-network-data")
+
+	_, err := testBuilder.Update()
+	assert.Nil(t, err)
+
+	err = testBuilder.Deprovision()
+	assert.Nil(t, err)
+
+	bmhObject, err := testBuilder.Get()
+	assert.Nil(t, err)
+	assert.Nil(t, bmhObject.Spec.Image)
+	assert.Nil(t, bmhObject.Spec.CustomDeploy)
+	assert.Nil(t, bmhObject.Spec.UserData)
+	assert.Nil(t, bmhObject.Spec.NetworkData)
+}
+
+func TestBareMetalHostGetHardwareDetails(t *testing.T) {
+	testCases := []struct {
+		hardwareDetails *bmhv1alpha1.HardwareDetails
+		expectedError   error
+	}{
+		{
+			hardwareDetails: &bmhv1alpha1.HardwareDetails{
+				CPU:     bmhv1alpha1.CPU{Arch: "x86_64", Count: 64},
+				NIC:     []bmhv1alpha1.NIC{{Name: "eno1", MAC: defaultBmHostMacAddress}},
+				Storage: []bmhv1alpha1.Storage{{Name: "/dev/sda", SizeBytes: 480 * bmhv1alpha1.GigaByte}},
+			},
+			expectedError: nil,
+		},
+		{
+			hardwareDetails: nil,
+			expectedError:   fmt.Errorf("baremetalhost metallbio has no hardware details"),
+		},
+	}
+
+	for _, testCase := range testCases {
+		dummyBmHost := buildDummyBmHost(bmhv1alpha1.StateAvailable)
+		dummyBmHost[0].(*bmhv1alpha1.BareMetalHost).Status.HardwareDetails = testCase.hardwareDetails
+
+		testBuilder := buildValidBmHostBuilder(clients.GetTestClients(clients.TestClientParams{
+			K8sMockObjects: dummyBmHost,
+		}))
+
+		hardwareDetails, err := testBuilder.GetHardwareDetails()
+		assert.Equal(t, testCase.expectedError, err)
+		assert.Equal(t, testCase.hardwareDetails, hardwareDetails)
+	}
+}
+
+func TestBareMetalHostGetBMCCredentials(t *testing.T) {
+	testCases := []struct {
+		secretData       map[string][]byte
+		addSecret        bool
+		expectedUsername string
+		expectedPassword string
+		expectedError    string
+	}{
+		{
+			secretData:       map[string][]byte{"username": []byte("root"), "password": []byte("calvin")},
+			addSecret:        true,
+			expectedUsername: "root",
+			expectedPassword: "calvin",
+		},
+		{
+			secretData:    map[string][]byte{"username": []byte("root")},
+			addSecret:     true,
+			expectedError: "bmc credentials secret testsecret must contain username and password",
+		},
+		{
+			addSecret:     false,
+			expectedError: "failed to get bmc credentials secret testsecret: secrets \"testsecret\" not found",
+		},
+	}
+
+	for _, testCase := range testCases {
+		testSettings := buildBareMetalHostTestClientWithDummyObject()
+
+		// Secrets in K8sMockObjects are only added to the clientset, so create it using the controller-runtime client.
+		if testCase.addSecret {
+			err := testSettings.Client.Create(context.TODO(), &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      defaultBmHostSecretName,
+					Namespace: defaultBmHostNsName,
+				},
+				Data: testCase.secretData,
+			})
+			assert.Nil(t, err)
+		}
+
+		testBuilder := buildValidBmHostBuilder(testSettings)
+
+		username, password, err := testBuilder.GetBMCCredentials()
+		if testCase.expectedError != "" {
+			assert.EqualError(t, err, testCase.expectedError)
+		} else {
+			assert.Nil(t, err)
+		}
+
+		assert.Equal(t, testCase.expectedUsername, username)
+		assert.Equal(t, testCase.expectedPassword, password)
+	}
+}
+
+func TestBareMetalHostGetError(t *testing.T) {
+	testSettings := buildBareMetalHostTestClientWithDummyObject(bmhv1alpha1.StateAvailable)
+	testSettings.Client = interceptor.NewClient(testSettings.Client.(goclient.WithWatch), interceptor.Funcs{
+		Get: func(
+			ctx context.Context, client goclient.WithWatch, key goclient.ObjectKey, obj goclient.Object,
+			opts ...goclient.GetOption) error {
+			return fmt.Errorf("connection refused")
+		},
+	})
+
+	expectedError := fmt.Errorf(
+		"failed to get baremetalhost metallbio in namespace test-namespace: %w", fmt.Errorf("connection refused"))
+
+	_, err := buildValidBmHostBuilder(testSettings).GetHardwareDetails()
+	assert.Equal(t, expectedError, err)
+
+	_, _, err = buildValidBmHostBuilder(testSettings).GetBMCCredentials()
+	assert.Equal(t, expectedError, err)
+
+	err = buildValidBmHostBuilder(testSettings).PowerOn()
+	assert.Equal(t, expectedError, err)
+
+	_, err = buildValidBmHostBuilder(testSettings).IsDetached()
+	assert.Equal(t, expectedError, err)
+
+	_, err = buildValidBmHostBuilder(testSettings).Update()
+	assert.Equal(t, expectedError, err)
+}
@@ -7,8 +7,13 @@ import (
 
 	"github.com/openshift-kni/eco-goinfra/pkg/argocd/argocdtypes"
 	"github.com/openshift-kni/eco-goinfra/pkg/oadp/oadptypes"
+	"github.com/openshift-kni/eco-goinfra/pkg/schemes/metallb/frrtypes"
 	"github.com/openshift-kni/eco-goinfra/pkg/schemes/metallb/mlboperator"
 	"github.com/openshift-kni/eco-goinfra/pkg/schemes/metallb/mlbtypes"
+	"github.com/openshift-kni/eco-goinfra/pkg/schemes/networkpolicy/anptypes"
+	"github.com/openshift-kni/eco-goinfra/pkg/schemes/ovn/ovntypes"
+	"github.com/openshift-kni/eco-goinfra/pkg/schemes/sriov/sriovtypes"
+	"github.com/openshift-kni/eco-goinfra/pkg/schemes/whereabouts/whereaboutstypes"
 
 	"github.com/golang/glog"
 	"k8s.io/client-go/dynamic"
@@ -28,6 +33,7 @@ import (
 	clientConfigV1 "github.com/openshift/client-go/config/clientset/versioned/typed/config/v1"
 	v1security "github.com/openshift/client-go/security/clientset/versioned/typed/security/v1"
 	mcv1 "github.com/openshift/machine-config-operator/pkg/apis/machineconfiguration.openshift.io/v1"
+	ptpv1 "github.com/openshift/ptp-operator/api/v1"
 	ptpV1 "github.com/openshift/ptp-operator/pkg/client/clientset/versioned/typed/ptp/v1"
 	olm2 "github.com/operator-framework/operator-lifecycle-manager/pkg/api/client/clientset/versioned/scheme"
 
@@ -92,6 +98,7 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	scalingv1 "k8s.io/api/autoscaling/v1"
 	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
 	netv1 "k8s.io/api/networking/v1"
 	policyv1 "k8s.io/api/policy/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
@@ -256,6 +263,26 @@ func SetScheme(crScheme *runtime.Scheme) error {
 		return err
 	}
 
+	if err := sriovtypes.AddToScheme(crScheme); err != nil {
+		return err
+	}
+
+	if err := whereaboutstypes.AddToScheme(crScheme); err != nil {
+		return err
+	}
+
+	if err := anptypes.AddToScheme(crScheme); err != nil {
+		return err
+	}
+
+	if err := ovntypes.AddToScheme(crScheme); err != nil {
+		return err
+	}
+
+	if err := ptpv1.AddToScheme(crScheme); err != nil {
+		return err
+	}
+
 	if err := mcv1.AddToScheme(crScheme); err != nil {
 		return err
 	}
@@ -502,6 +529,8 @@ func GetTestClients(tcp TestClientParams) *Settings {
 			k8sClientObjects = append(k8sClientObjects, v)
 		case *appsv1.DaemonSet:
 			k8sClientObjects = append(k8sClientObjects, v)
+		case *discoveryv1.EndpointSlice:
+			k8sClientObjects = append(k8sClientObjects, v)
 		// Generic Client Objects
 		case *bmhv1alpha1.BareMetalHost:
 			genericClientObjects = append(genericClientObjects, v)
@@ -523,6 +552,12 @@ func GetTestClients(tcp TestClientParams) *Settings {
 			genericClientObjects = append(genericClientObjects, v)
 		case *mlbtypes.L2Advertisement:
 			genericClientObjects = append(genericClientObjects, v)
+		case *mlbtypes.ServiceL2Status:
+			genericClientObjects = append(genericClientObjects, v)
+		case *mlbtypes.ServiceBGPStatus:
+			genericClientObjects = append(genericClientObjects, v)
+		case *frrtypes.FRRConfiguration:
+			genericClientObjects = append(genericClientObjects, v)
 		case *policiesv1.Policy:
 			genericClientObjects = append(genericClientObjects, v)
 		case *policiesv1.PlacementBinding:
@@ -533,6 +568,10 @@ func GetTestClients(tcp TestClientParams) *Settings {
 			genericClientObjects = append(genericClientObjects, v)
 		case *configV1.Node:
 			genericClientObjects = append(genericClientObjects, v)
+		case *configV1.Network:
+			genericClientObjects = append(genericClientObjects, v)
+		case *operatorv1.Network:
+			genericClientObjects = append(genericClientObjects, v)
 		case *operatorv1.IngressController:
 			genericClientObjects = append(genericClientObjects, v)
 		case *operatorv1.Console:
@@ -571,6 +610,8 @@ func GetTestClients(tcp TestClientParams) *Settings {
 			genericClientObjects = append(genericClientObjects, v)
 		case *tunedv1.Tuned:
 			genericClientObjects = append(genericClientObjects, v)
+		case *tunedv1.Profile:
+			genericClientObjects = append(genericClientObjects, v)
 		case *kedav1alpha1.KedaController:
 			genericClientObjects = append(genericClientObjects, v)
 		case *kedav2v1alpha1.TriggerAuthentication:
@@ -585,6 +626,48 @@ func GetTestClients(tcp TestClientParams) *Settings {
 			genericClientObjects = append(genericClientObjects, v)
 		case *oadptypes.DataProtectionApplication:
 			genericClientObjects = append(genericClientObjects, v)
+		// SrIov Generic Client Objects
+		case *srIovV1.SriovIBNetwork:
+			genericClientObjects = append(genericClientObjects, v)
+		case *sriovtypes.OVSNetwork:
+			genericClientObjects = append(genericClientObjects, v)
+		// Whereabouts Client Objects
+		case *whereaboutstypes.IPPool:
+			genericClientObjects = append(genericClientObjects, v)
+		case *whereaboutstypes.OverlappingRangeIPReservation:
+			genericClientObjects = append(genericClientObjects, v)
+		// AdminNetworkPolicy Client Objects
+		case *anptypes.AdminNetworkPolicy:
+			genericClientObjects = append(genericClientObjects, v)
+		case *anptypes.BaselineAdminNetworkPolicy:
+			genericClientObjects = append(genericClientObjects, v)
+		// OVN-Kubernetes Client Objects
+		case *ovntypes.EgressIP:
+			genericClientObjects = append(genericClientObjects, v)
+		case *ovntypes.EgressFirewall:
+			genericClientObjects = append(genericClientObjects, v)
+		case *ovntypes.EgressService:
+			genericClientObjects = append(genericClientObjects, v)
+		case *ovntypes.EgressQoS:
+			genericClientObjects = append(genericClientObjects, v)
+		case *ovntypes.UserDefinedNetwork:
+			genericClientObjects = append(genericClientObjects, v)
+		case *ovntypes.ClusterUserDefinedNetwork:
+			genericClientObjects = append(genericClientObjects, v)
+		// PTP Client Objects
+		case *ptpv1.PtpConfig:
+			genericClientObjects = append(genericClientObjects, v)
+		case *ptpv1.PtpOperatorConfig:
+			genericClientObjects = append(genericClientObjects, v)
+		case *ptpv1.NodePtpDevice:
+			genericClientObjects = append(genericClientObjects, v)
+		// NMState Client Objects
+		case *nmstatev1.NodeNetworkConfigurationPolicy:
+			genericClientObjects = append(genericClientObjects, v)
+		case *nmstateV1alpha1.NodeNetworkState:
+			genericClientObjects = append(genericClientObjects, v)
+		case *nmstateV1alpha1.NodeNetworkConfigurationEnactment:
+			genericClientObjects = append(genericClientObjects, v)
 		// ArgoCD Client Objects
 		case *argocdOperatorv1alpha1.ArgoCD:
 			genericClientObjects = append(genericClientObjects, v)
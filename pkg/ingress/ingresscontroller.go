@@ -3,16 +3,28 @@ package ingress
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/golang/glog"
 	"github.com/openshift-kni/eco-goinfra/pkg/clients"
+	"github.com/openshift-kni/eco-goinfra/pkg/deployment"
 	"github.com/openshift-kni/eco-goinfra/pkg/msg"
+	configv1 "github.com/openshift/api/config/v1"
 	operatorv1 "github.com/openshift/api/operator/v1"
+	corev1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
 	goclient "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+const (
+	// RouterNamespace is the namespace where the router deployments of the ingresscontrollers run.
+	RouterNamespace = "openshift-ingress"
+
+	routerDeploymentPrefix = "router-"
+)
+
 // Builder provides a struct for an ingresscontroller object from the cluster and an ingresscontroller definition.
 type Builder struct {
 	// ingresscontroller definition, used to create the ingresscontroller object.
@@ -21,6 +33,332 @@ type Builder struct {
 	Object *operatorv1.IngressController
 	// api clients to interact with the cluster.
 	apiClient *clients.Settings
+	// Used in functions that define or mutate ingresscontroller definitions. errorMsg is processed before the
+	// ingresscontroller object is created.
+	errorMsg string
+}
+
+// AdditionalOptions additional options for ingresscontroller object.
+type AdditionalOptions func(builder *Builder) (*Builder, error)
+
+// NewBuilder creates a new instance of Builder. IngressControllers are created in the openshift-ingress-operator
+// namespace and, except for the default one, usually require a domain set using WithDomain.
+func NewBuilder(apiClient *clients.Settings, name, nsname string) *Builder {
+	glog.V(100).Infof("Initializing new ingresscontroller structure with the name %s in namespace %s", name, nsname)
+
+	builder := &Builder{
+		apiClient: apiClient,
+		Definition: &operatorv1.IngressController{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: nsname,
+			},
+		},
+	}
+
+	if name == "" {
+		glog.V(100).Infof("The ingresscontroller name is empty")
+
+		builder.errorMsg = "ingresscontroller name cannot be empty"
+
+		return builder
+	}
+
+	if nsname == "" {
+		glog.V(100).Infof("The ingresscontroller namespace is empty")
+
+		builder.errorMsg = "ingresscontroller namespace cannot be empty"
+
+		return builder
+	}
+
+	return builder
+}
+
+// WithDomain sets the DNS name serviced by the ingresscontroller, used to generate the default hostnames of routes.
+func (builder *Builder) WithDomain(domain string) *Builder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Setting domain %s to ingresscontroller %s in namespace %s",
+		domain, builder.Definition.Name, builder.Definition.Namespace)
+
+	if domain == "" {
+		glog.V(100).Infof("The ingresscontroller domain is empty")
+
+		builder.errorMsg = "ingresscontroller domain cannot be empty"
+
+		return builder
+	}
+
+	builder.Definition.Spec.Domain = domain
+
+	return builder
+}
+
+// WithReplicas sets the desired number of router replicas of the ingresscontroller.
+func (builder *Builder) WithReplicas(replicas int32) *Builder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Setting %d replicas to ingresscontroller %s in namespace %s",
+		replicas, builder.Definition.Name, builder.Definition.Namespace)
+
+	if replicas < 0 {
+		glog.V(100).Infof("The ingresscontroller replicas %d is negative", replicas)
+
+		builder.errorMsg = fmt.Sprintf("ingresscontroller replicas %d cannot be negative", replicas)
+
+		return builder
+	}
+
+	builder.Definition.Spec.Replicas = &replicas
+
+	return builder
+}
+
+// WithHostNetworkStrategy publishes the ingresscontroller on the host network of the nodes it runs on. Ports left
+// as zero use the defaults of 80 for HTTP, 443 for HTTPS and 1936 for stats.
+func (builder *Builder) WithHostNetworkStrategy(httpPort, httpsPort, statsPort int32) *Builder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Setting HostNetwork endpoint publishing strategy with ports %d, %d and %d to "+
+		"ingresscontroller %s in namespace %s",
+		httpPort, httpsPort, statsPort, builder.Definition.Name, builder.Definition.Namespace)
+
+	for _, port := range []int32{httpPort, httpsPort, statsPort} {
+		if port < 0 || port > 65535 {
+			glog.V(100).Infof("The ingresscontroller host network port %d is invalid", port)
+
+			builder.errorMsg = fmt.Sprintf(
+				"ingresscontroller host network port %d must be between 1 and 65535 or 0 for the default", port)
+
+			return builder
+		}
+	}
+
+	builder.Definition.Spec.EndpointPublishingStrategy = &operatorv1.EndpointPublishingStrategy{
+		Type: operatorv1.HostNetworkStrategyType,
+		HostNetwork: &operatorv1.HostNetworkStrategy{
+			HTTPPort:  httpPort,
+			HTTPSPort: httpsPort,
+			StatsPort: statsPort,
+		},
+	}
+
+	return builder
+}
+
+// WithLoadBalancerStrategy publishes the ingresscontroller using a LoadBalancer service with the given scope.
+func (builder *Builder) WithLoadBalancerStrategy(scope operatorv1.LoadBalancerScope) *Builder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Setting LoadBalancerService endpoint publishing strategy with scope %s to "+
+		"ingresscontroller %s in namespace %s", scope, builder.Definition.Name, builder.Definition.Namespace)
+
+	if scope != operatorv1.ExternalLoadBalancer && scope != operatorv1.InternalLoadBalancer {
+		glog.V(100).Infof("The ingresscontroller load balancer scope %s is invalid", scope)
+
+		builder.errorMsg = fmt.Sprintf("ingresscontroller load balancer scope %s is invalid, must be one of %s or %s",
+			scope, operatorv1.ExternalLoadBalancer, operatorv1.InternalLoadBalancer)
+
+		return builder
+	}
+
+	builder.Definition.Spec.EndpointPublishingStrategy = &operatorv1.EndpointPublishingStrategy{
+		Type: operatorv1.LoadBalancerServiceStrategyType,
+		LoadBalancer: &operatorv1.LoadBalancerStrategy{
+			Scope: scope,
+		},
+	}
+
+	return builder
+}
+
+// WithNodePortStrategy publishes the ingresscontroller using a NodePort service.
+func (builder *Builder) WithNodePortStrategy() *Builder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Setting NodePortService endpoint publishing strategy to ingresscontroller %s in namespace %s",
+		builder.Definition.Name, builder.Definition.Namespace)
+
+	builder.Definition.Spec.EndpointPublishingStrategy = &operatorv1.EndpointPublishingStrategy{
+		Type:     operatorv1.NodePortServiceStrategyType,
+		NodePort: &operatorv1.NodePortStrategy{},
+	}
+
+	return builder
+}
+
+// WithNodePlacement sets the node selector and tolerations of the router deployment. Only match labels are
+// supported in the node selector.
+func (builder *Builder) WithNodePlacement(nodeSelector map[string]string, tolerations ...corev1.Toleration) *Builder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Setting node placement with node selector %v and tolerations %v to "+
+		"ingresscontroller %s in namespace %s",
+		nodeSelector, tolerations, builder.Definition.Name, builder.Definition.Namespace)
+
+	if len(nodeSelector) == 0 {
+		glog.V(100).Infof("The ingresscontroller node selector is empty")
+
+		builder.errorMsg = "ingresscontroller node selector cannot be empty"
+
+		return builder
+	}
+
+	builder.Definition.Spec.NodePlacement = &operatorv1.NodePlacement{
+		NodeSelector: &metav1.LabelSelector{MatchLabels: nodeSelector},
+		Tolerations:  tolerations,
+	}
+
+	return builder
+}
+
+// WithDefaultCertificate sets the secret in the openshift-ingress namespace holding the default certificate served
+// for routes without their own certificate.
+func (builder *Builder) WithDefaultCertificate(secretName string) *Builder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Setting default certificate secret %s to ingresscontroller %s in namespace %s",
+		secretName, builder.Definition.Name, builder.Definition.Namespace)
+
+	if secretName == "" {
+		glog.V(100).Infof("The ingresscontroller default certificate secret name is empty")
+
+		builder.errorMsg = "ingresscontroller default certificate secret name cannot be empty"
+
+		return builder
+	}
+
+	builder.Definition.Spec.DefaultCertificate = &corev1.LocalObjectReference{Name: secretName}
+
+	return builder
+}
+
+// WithRouteSelector limits the routes serviced by the ingresscontroller to those matching the selector.
+func (builder *Builder) WithRouteSelector(routeSelector metav1.LabelSelector) *Builder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Setting route selector %v to ingresscontroller %s in namespace %s",
+		routeSelector, builder.Definition.Name, builder.Definition.Namespace)
+
+	builder.Definition.Spec.RouteSelector = &routeSelector
+
+	return builder
+}
+
+// WithNamespaceSelector limits the routes serviced by the ingresscontroller to those in namespaces matching the
+// selector.
+func (builder *Builder) WithNamespaceSelector(namespaceSelector metav1.LabelSelector) *Builder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Setting namespace selector %v to ingresscontroller %s in namespace %s",
+		namespaceSelector, builder.Definition.Name, builder.Definition.Namespace)
+
+	builder.Definition.Spec.NamespaceSelector = &namespaceSelector
+
+	return builder
+}
+
+// WithTLSSecurityProfile sets the TLS security profile of the ingresscontroller. The profile settings must be set
+// when the type is Custom.
+func (builder *Builder) WithTLSSecurityProfile(profile configv1.TLSSecurityProfile) *Builder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Setting TLS security profile %s to ingresscontroller %s in namespace %s",
+		profile.Type, builder.Definition.Name, builder.Definition.Namespace)
+
+	switch profile.Type {
+	case configv1.TLSProfileOldType, configv1.TLSProfileIntermediateType, configv1.TLSProfileModernType:
+	case configv1.TLSProfileCustomType:
+		if profile.Custom == nil {
+			glog.V(100).Infof("The ingresscontroller custom TLS security profile is empty")
+
+			builder.errorMsg = "ingresscontroller custom TLS security profile cannot be empty"
+
+			return builder
+		}
+	default:
+		glog.V(100).Infof("The ingresscontroller TLS security profile type %s is invalid", profile.Type)
+
+		builder.errorMsg = fmt.Sprintf("ingresscontroller TLS security profile type %s is invalid, "+
+			"must be one of %s, %s, %s or %s", profile.Type, configv1.TLSProfileOldType,
+			configv1.TLSProfileIntermediateType, configv1.TLSProfileModernType, configv1.TLSProfileCustomType)
+
+		return builder
+	}
+
+	builder.Definition.Spec.TLSSecurityProfile = &profile
+
+	return builder
+}
+
+// WithTuningOptions sets the router tuning options of the ingresscontroller, such as thread count, buffer sizes and
+// timeouts.
+func (builder *Builder) WithTuningOptions(tuningOptions operatorv1.IngressControllerTuningOptions) *Builder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Setting tuning options %v to ingresscontroller %s in namespace %s",
+		tuningOptions, builder.Definition.Name, builder.Definition.Namespace)
+
+	if tuningOptions.ThreadCount < 0 || tuningOptions.ThreadCount > 64 {
+		glog.V(100).Infof("The ingresscontroller thread count %d is invalid", tuningOptions.ThreadCount)
+
+		builder.errorMsg = fmt.Sprintf("ingresscontroller thread count %d must be between 1 and 64 or 0 for the default",
+			tuningOptions.ThreadCount)
+
+		return builder
+	}
+
+	builder.Definition.Spec.TuningOptions = tuningOptions
+
+	return builder
+}
+
+// WithOptions creates ingresscontroller with generic mutation options.
+func (builder *Builder) WithOptions(options ...AdditionalOptions) *Builder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Setting ingresscontroller additional options")
+
+	for _, option := range options {
+		if option != nil {
+			builder, err := option(builder)
+
+			if err != nil {
+				glog.V(100).Infof("Error occurred in mutation function")
+
+				builder.errorMsg = err.Error()
+
+				return builder
+			}
+		}
+	}
+
+	return builder
 }
 
 // Pull loads an existing ingresscontroller into Builder struct.
@@ -168,6 +506,94 @@ func (builder *Builder) Delete() error {
 	return nil
 }
 
+// WaitUntilAvailable waits for the duration of the defined timeout until the ingresscontroller has observed the
+// generation of the builder definition and reports the Available condition as True.
+func (builder *Builder) WaitUntilAvailable(timeout time.Duration) error {
+	if valid, err := builder.validate(); !valid {
+		return err
+	}
+
+	glog.V(100).Infof("Waiting until ingresscontroller %s in namespace %s is available",
+		builder.Definition.Name, builder.Definition.Namespace)
+
+	return wait.PollUntilContextTimeout(
+		context.TODO(), 3*time.Second, timeout, true, func(ctx context.Context) (bool, error) {
+			if !builder.Exists() {
+				glog.V(100).Infof("The ingresscontroller %s in namespace %s does not exist yet",
+					builder.Definition.Name, builder.Definition.Namespace)
+
+				return false, nil
+			}
+
+			if builder.Object.Status.ObservedGeneration < builder.Definition.Generation {
+				glog.V(100).Infof("The ingresscontroller %s in namespace %s has not observed generation %d yet",
+					builder.Definition.Name, builder.Definition.Namespace, builder.Definition.Generation)
+
+				return false, nil
+			}
+
+			for _, condition := range builder.Object.Status.Conditions {
+				if condition.Type == operatorv1.IngressControllerAvailableConditionType {
+					return condition.Status == operatorv1.ConditionTrue, nil
+				}
+			}
+
+			return false, nil
+		})
+}
+
+// WaitUntilRouterRolledOut waits for the duration of the defined timeout until every replica of the router
+// deployment of the ingresscontroller is updated to the latest revision and available.
+func (builder *Builder) WaitUntilRouterRolledOut(timeout time.Duration) error {
+	if valid, err := builder.validate(); !valid {
+		return err
+	}
+
+	routerName := routerDeploymentPrefix + builder.Definition.Name
+
+	glog.V(100).Infof("Waiting until router deployment %s in namespace %s of ingresscontroller %s is rolled out",
+		routerName, RouterNamespace, builder.Definition.Name)
+
+	return wait.PollUntilContextTimeout(
+		context.TODO(), 3*time.Second, timeout, true, func(ctx context.Context) (bool, error) {
+			routerDeployment, err := deployment.Pull(builder.apiClient, routerName, RouterNamespace)
+			if err != nil {
+				glog.V(100).Infof("Failed to pull router deployment %s in namespace %s: %v",
+					routerName, RouterNamespace, err)
+
+				return false, nil
+			}
+
+			return isDeploymentRolledOut(routerDeployment), nil
+		})
+}
+
+// WaitUntilReady waits for the duration of the defined timeout until the ingresscontroller is available and its
+// router deployment is rolled out. Waiting for the ingresscontroller to observe its latest generation first ensures
+// the router deployment is already updated by the operator when its rollout is checked.
+func (builder *Builder) WaitUntilReady(timeout time.Duration) error {
+	if valid, err := builder.validate(); !valid {
+		return err
+	}
+
+	startTime := time.Now()
+
+	err := builder.WaitUntilAvailable(timeout)
+	if err != nil {
+		return err
+	}
+
+	remainingTimeout := timeout - time.Since(startTime)
+	if remainingTimeout <= 0 {
+		glog.V(100).Infof("No time is left to wait for the router deployment of ingresscontroller %s to roll out",
+			builder.Definition.Name)
+
+		return context.DeadlineExceeded
+	}
+
+	return builder.WaitUntilRouterRolledOut(remainingTimeout)
+}
+
 // validate will check that the builder and builder definition are properly initialized before
 // accessing any member fields.
 func (builder *Builder) validate() (bool, error) {
@@ -191,5 +617,29 @@ func (builder *Builder) validate() (bool, error) {
 		return false, fmt.Errorf("%s builder cannot have nil apiClient", resourceCRD)
 	}
 
+	if builder.errorMsg != "" {
+		glog.V(100).Infof("The %s builder has error message: %s", resourceCRD, builder.errorMsg)
+
+		return false, fmt.Errorf(builder.errorMsg)
+	}
+
 	return true, nil
 }
+
+// isDeploymentRolledOut checks whether the latest revision of the deployment is observed and all of its desired
+// replicas are updated and available, with no replicas of older revisions left.
+func isDeploymentRolledOut(deploymentBuilder *deployment.Builder) bool {
+	routerDeployment := deploymentBuilder.Object
+
+	desiredReplicas := int32(1)
+	if routerDeployment.Spec.Replicas != nil {
+		desiredReplicas = *routerDeployment.Spec.Replicas
+	}
+
+	status := routerDeployment.Status
+
+	return status.ObservedGeneration >= routerDeployment.Generation &&
+		status.UpdatedReplicas == desiredReplicas &&
+		status.AvailableReplicas == desiredReplicas &&
+		status.Replicas == desiredReplicas
+}
@@ -1,15 +1,55 @@
 package ingress
 
 import (
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/openshift-kni/eco-goinfra/pkg/clients"
+	configv1 "github.com/openshift/api/config/v1"
 	operatorv1 "github.com/openshift/api/operator/v1"
 	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
 )
 
+func TestIngressNewBuilder(t *testing.T) {
+	testCases := []struct {
+		name          string
+		namespace     string
+		expectedError string
+	}{
+		{
+			name:          "test",
+			namespace:     "test",
+			expectedError: "",
+		},
+		{
+			name:          "",
+			namespace:     "test",
+			expectedError: "ingresscontroller name cannot be empty",
+		},
+		{
+			name:          "test",
+			namespace:     "",
+			expectedError: "ingresscontroller namespace cannot be empty",
+		},
+	}
+
+	for _, testCase := range testCases {
+		testBuilder := NewBuilder(clients.GetTestClients(clients.TestClientParams{}), testCase.name, testCase.namespace)
+		assert.Equal(t, testCase.expectedError, testBuilder.errorMsg)
+
+		if testCase.expectedError == "" {
+			assert.Equal(t, testCase.name, testBuilder.Definition.Name)
+			assert.Equal(t, testCase.namespace, testBuilder.Definition.Namespace)
+		}
+	}
+}
+
 func TestIngressPull(t *testing.T) {
 	testCases := []struct {
 		ingressName         string
@@ -307,6 +347,198 @@ func TestIngressExists(t *testing.T) {
 	}
 }
 
+func TestIngressWithOptions(t *testing.T) {
+	routeSelector := metav1.LabelSelector{MatchLabels: map[string]string{"type": "sharded"}}
+	namespaceSelector := metav1.LabelSelector{MatchLabels: map[string]string{"env": "test"}}
+	toleration := corev1.Toleration{Key: "node-role.kubernetes.io/infra", Effect: corev1.TaintEffectNoSchedule}
+	tlsProfile := configv1.TLSSecurityProfile{Type: configv1.TLSProfileModernType}
+	tuningOptions := operatorv1.IngressControllerTuningOptions{ThreadCount: 8}
+
+	testBuilder := NewBuilder(clients.GetTestClients(clients.TestClientParams{}), "test", "test").
+		WithDomain("apps.example.com").
+		WithReplicas(2).
+		WithHostNetworkStrategy(8080, 8443, 0).
+		WithNodePlacement(map[string]string{"node-role.kubernetes.io/infra": ""}, toleration).
+		WithDefaultCertificate("router-certs").
+		WithRouteSelector(routeSelector).
+		WithNamespaceSelector(namespaceSelector).
+		WithTLSSecurityProfile(tlsProfile).
+		WithTuningOptions(tuningOptions)
+	assert.Equal(t, "", testBuilder.errorMsg)
+	assert.Equal(t, operatorv1.IngressControllerSpec{
+		Domain:   "apps.example.com",
+		Replicas: ptr.To[int32](2),
+		EndpointPublishingStrategy: &operatorv1.EndpointPublishingStrategy{
+			Type:        operatorv1.HostNetworkStrategyType,
+			HostNetwork: &operatorv1.HostNetworkStrategy{HTTPPort: 8080, HTTPSPort: 8443},
+		},
+		NodePlacement: &operatorv1.NodePlacement{
+			NodeSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"node-role.kubernetes.io/infra": ""}},
+			Tolerations:  []corev1.Toleration{toleration},
+		},
+		DefaultCertificate: &corev1.LocalObjectReference{Name: "router-certs"},
+		RouteSelector:      &routeSelector,
+		NamespaceSelector:  &namespaceSelector,
+		TLSSecurityProfile: &tlsProfile,
+		TuningOptions:      tuningOptions,
+	}, testBuilder.Definition.Spec)
+
+	testBuilder = testBuilder.WithLoadBalancerStrategy(operatorv1.InternalLoadBalancer)
+	assert.Equal(t, "", testBuilder.errorMsg)
+	assert.Equal(t, &operatorv1.EndpointPublishingStrategy{
+		Type:         operatorv1.LoadBalancerServiceStrategyType,
+		LoadBalancer: &operatorv1.LoadBalancerStrategy{Scope: operatorv1.InternalLoadBalancer},
+	}, testBuilder.Definition.Spec.EndpointPublishingStrategy)
+
+	testBuilder = testBuilder.WithNodePortStrategy()
+	assert.Equal(t, "", testBuilder.errorMsg)
+	assert.Equal(t, &operatorv1.EndpointPublishingStrategy{
+		Type:     operatorv1.NodePortServiceStrategyType,
+		NodePort: &operatorv1.NodePortStrategy{},
+	}, testBuilder.Definition.Spec.EndpointPublishingStrategy)
+}
+
+func TestIngressWithOptionsErrors(t *testing.T) {
+	testCases := []struct {
+		mutate        func(builder *Builder) *Builder
+		expectedError string
+	}{
+		{
+			mutate:        func(builder *Builder) *Builder { return builder.WithDomain("") },
+			expectedError: "ingresscontroller domain cannot be empty",
+		},
+		{
+			mutate:        func(builder *Builder) *Builder { return builder.WithReplicas(-1) },
+			expectedError: "ingresscontroller replicas -1 cannot be negative",
+		},
+		{
+			mutate: func(builder *Builder) *Builder { return builder.WithHostNetworkStrategy(80, 443, 70000) },
+			expectedError: "ingresscontroller host network port 70000 must be between 1 and 65535 or 0 for the " +
+				"default",
+		},
+		{
+			mutate: func(builder *Builder) *Builder { return builder.WithLoadBalancerStrategy("Public") },
+			expectedError: "ingresscontroller load balancer scope Public is invalid, must be one of External or " +
+				"Internal",
+		},
+		{
+			mutate:        func(builder *Builder) *Builder { return builder.WithNodePlacement(nil) },
+			expectedError: "ingresscontroller node selector cannot be empty",
+		},
+		{
+			mutate:        func(builder *Builder) *Builder { return builder.WithDefaultCertificate("") },
+			expectedError: "ingresscontroller default certificate secret name cannot be empty",
+		},
+		{
+			mutate: func(builder *Builder) *Builder {
+				return builder.WithTLSSecurityProfile(configv1.TLSSecurityProfile{Type: configv1.TLSProfileCustomType})
+			},
+			expectedError: "ingresscontroller custom TLS security profile cannot be empty",
+		},
+		{
+			mutate: func(builder *Builder) *Builder {
+				return builder.WithTLSSecurityProfile(configv1.TLSSecurityProfile{Type: "Ancient"})
+			},
+			expectedError: "ingresscontroller TLS security profile type Ancient is invalid, must be one of Old, " +
+				"Intermediate, Modern or Custom",
+		},
+		{
+			mutate: func(builder *Builder) *Builder {
+				return builder.WithTuningOptions(operatorv1.IngressControllerTuningOptions{ThreadCount: 65})
+			},
+			expectedError: "ingresscontroller thread count 65 must be between 1 and 64 or 0 for the default",
+		},
+		{
+			mutate: func(builder *Builder) *Builder {
+				return builder.WithOptions(func(builder *Builder) (*Builder, error) {
+					return builder, fmt.Errorf("error adding additional option")
+				})
+			},
+			expectedError: "error adding additional option",
+		},
+	}
+
+	for _, testCase := range testCases {
+		testBuilder := testCase.mutate(NewBuilder(clients.GetTestClients(clients.TestClientParams{}), "test", "test"))
+		assert.Equal(t, testCase.expectedError, testBuilder.errorMsg)
+
+		_, err := testBuilder.Create()
+		assert.Equal(t, fmt.Errorf(testCase.expectedError), err)
+	}
+}
+
+func TestIngressWaitUntilAvailable(t *testing.T) {
+	testCases := []struct {
+		status             operatorv1.ConditionStatus
+		observedGeneration int64
+		expectedError      bool
+	}{
+		{
+			status:             operatorv1.ConditionTrue,
+			observedGeneration: 2,
+			expectedError:      false,
+		},
+		{
+			status:             operatorv1.ConditionFalse,
+			observedGeneration: 2,
+			expectedError:      true,
+		},
+		{
+			status:             operatorv1.ConditionTrue,
+			observedGeneration: 1,
+			expectedError:      true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testBuilder, _ := buildTestBuilderWithFakeObjects([]runtime.Object{&operatorv1.IngressController{
+			ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "test"},
+			Status: operatorv1.IngressControllerStatus{
+				ObservedGeneration: testCase.observedGeneration,
+				Conditions: []operatorv1.OperatorCondition{{
+					Type:   operatorv1.IngressControllerAvailableConditionType,
+					Status: testCase.status,
+				}},
+			},
+		}}, "test", "test")
+		testBuilder.Definition.Generation = 2
+
+		err := testBuilder.WaitUntilAvailable(time.Second)
+		assert.Equal(t, testCase.expectedError, err != nil)
+	}
+}
+
+func TestIngressWaitUntilRouterRolledOut(t *testing.T) {
+	testCases := []struct {
+		updatedReplicas int32
+		expectedError   bool
+	}{
+		{
+			updatedReplicas: 2,
+			expectedError:   false,
+		},
+		{
+			updatedReplicas: 1,
+			expectedError:   true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testBuilder, _ := buildTestBuilderWithFakeObjects([]runtime.Object{&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: routerDeploymentPrefix + "test", Namespace: RouterNamespace},
+			Spec:       appsv1.DeploymentSpec{Replicas: ptr.To[int32](2)},
+			Status: appsv1.DeploymentStatus{
+				Replicas:          2,
+				UpdatedReplicas:   testCase.updatedReplicas,
+				AvailableReplicas: 2,
+			},
+		}}, "test", "test")
+
+		err := testBuilder.WaitUntilRouterRolledOut(time.Second)
+		assert.Equal(t, testCase.expectedError, err != nil)
+	}
+}
+
 // func TestIngressUpdate(t *testing.T) {
 // 	testCases := []struct {
 // 		ingressExistsAlready bool
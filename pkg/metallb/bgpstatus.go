@@ -0,0 +1,397 @@
+package metallb
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/openshift-kni/eco-goinfra/pkg/clients"
+	"github.com/openshift-kni/eco-goinfra/pkg/pod"
+	"golang.org/x/exp/slices"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+const (
+	// SpeakerPodsLabelSelector selects the MetalLB speaker pods running the frr container.
+	SpeakerPodsLabelSelector = "component=speaker"
+	// FRRK8sPodsLabelSelector selects the frr-k8s daemon pods running the frr container.
+	FRRK8sPodsLabelSelector = "app=frr-k8s"
+	// BGPStateEstablished represents the BGP FSM state of a fully established session.
+	BGPStateEstablished = "Established"
+	// BFDStatusUp represents the status of a BFD peer with an active session.
+	BFDStatusUp = "up"
+
+	frrContainerName = "frr"
+)
+
+// BGPNeighborStatus represents the state of a BGP session as reported by vtysh show bgp summary.
+type BGPNeighborStatus struct {
+	// Address is the IP address of the neighbor.
+	Address string
+	// RemoteAS is the AS number of the neighbor.
+	RemoteAS uint32
+	// LocalAS is the local AS number used for the session.
+	LocalAS uint32
+	// State is the BGP FSM state of the session, e.g. Established or Active.
+	State string
+	// Uptime is the time the session has been in the current state.
+	Uptime string
+	// PrefixesReceived is the number of prefixes received from the neighbor.
+	PrefixesReceived int
+	// PrefixesSent is the number of prefixes sent to the neighbor.
+	PrefixesSent int
+}
+
+// BFDPeerStatus represents the state of a BFD session as reported by vtysh show bfd peers.
+type BFDPeerStatus struct {
+	// Peer is the IP address of the BFD peer.
+	Peer string
+	// Status is the state of the BFD session, e.g. up, down or init.
+	Status string
+	// Uptime is the number of seconds the session has been up.
+	Uptime int
+}
+
+// NodeBGPStatus holds the BGP and BFD state of the FRR instance running on a node.
+type NodeBGPStatus struct {
+	// NodeName is the name of the node running the FRR instance.
+	NodeName string
+	// Neighbors are the BGP neighbors of the node keyed by neighbor address.
+	Neighbors map[string]BGPNeighborStatus
+	// BFDPeers are the BFD peers of the node keyed by peer address.
+	BFDPeers map[string]BFDPeerStatus
+	// AdvertisedPrefixes are the prefixes advertised to each established neighbor keyed by neighbor address.
+	AdvertisedPrefixes map[string][]string
+}
+
+type bgpSummaryPeer struct {
+	RemoteAs   uint32 `json:"remoteAs"`
+	LocalAs    uint32 `json:"localAs"`
+	State      string `json:"state"`
+	PeerUptime string `json:"peerUptime"`
+	PfxRcd     int    `json:"pfxRcd"`
+	PfxSnt     int    `json:"pfxSnt"`
+}
+
+type bgpSummaryAddressFamily struct {
+	AS    uint32                    `json:"as"`
+	Peers map[string]bgpSummaryPeer `json:"peers"`
+}
+
+type bfdPeer struct {
+	Peer   string `json:"peer"`
+	Status string `json:"status"`
+	Uptime int    `json:"uptime"`
+}
+
+type bgpAdvertisedRoutes struct {
+	AdvertisedRoutes map[string]json.RawMessage `json:"advertisedRoutes"`
+}
+
+// GetBGPStatus returns the BGP status of the given nodes read from the frr container of the pods in the given
+// namespace matching the given label selector, e.g. SpeakerPodsLabelSelector or FRRK8sPodsLabelSelector.
+func GetBGPStatus(
+	apiClient *clients.Settings,
+	frrPodsNsName, frrPodsSelector string,
+	nodeNames []string) (map[string]*NodeBGPStatus, error) {
+	glog.V(100).Infof("Getting BGP status of nodes %v from pods %s in namespace %s",
+		nodeNames, frrPodsSelector, frrPodsNsName)
+
+	if apiClient == nil {
+		glog.V(100).Infof("The apiClient is empty")
+
+		return nil, fmt.Errorf("failed to get BGP status, 'apiClient' parameter is empty")
+	}
+
+	if len(nodeNames) == 0 {
+		glog.V(100).Infof("The nodeNames list is empty")
+
+		return nil, fmt.Errorf("failed to get BGP status, 'nodeNames' parameter is empty")
+	}
+
+	frrPods, err := pod.List(apiClient, frrPodsNsName, metav1.ListOptions{LabelSelector: frrPodsSelector})
+	if err != nil {
+		glog.V(100).Infof("Failed to list FRR pods in namespace %s: %v", frrPodsNsName, err)
+
+		return nil, err
+	}
+
+	nodesStatus := make(map[string]*NodeBGPStatus)
+
+	for _, nodeName := range nodeNames {
+		frrPod := getFRRPodOnNode(frrPods, nodeName)
+		if frrPod == nil {
+			return nil, fmt.Errorf("failed to find FRR pod with selector %s on node %s", frrPodsSelector, nodeName)
+		}
+
+		nodeStatus, err := GetFRRPodBGPStatus(frrPod)
+		if err != nil {
+			return nil, err
+		}
+
+		nodesStatus[nodeName] = nodeStatus
+	}
+
+	return nodesStatus, nil
+}
+
+// GetFRRPodBGPStatus returns the BGP neighbors, BFD peers and advertised prefixes reported by vtysh in the frr
+// container of the given pod.
+func GetFRRPodBGPStatus(frrPod *pod.Builder) (*NodeBGPStatus, error) {
+	if frrPod == nil || frrPod.Object == nil {
+		glog.V(100).Infof("The FRR pod is undefined")
+
+		return nil, fmt.Errorf("failed to get BGP status, 'frrPod' parameter is undefined")
+	}
+
+	glog.V(100).Infof("Getting BGP status from pod %s in namespace %s", frrPod.Object.Name, frrPod.Object.Namespace)
+
+	output, err := runVtyshCommand(frrPod, "show bgp summary json")
+	if err != nil {
+		return nil, err
+	}
+
+	neighbors, err := parseBGPSummary(output)
+	if err != nil {
+		return nil, err
+	}
+
+	output, err = runVtyshCommand(frrPod, "show bfd peers json")
+	if err != nil {
+		return nil, err
+	}
+
+	bfdPeers, err := parseBFDPeers(output)
+	if err != nil {
+		return nil, err
+	}
+
+	advertisedPrefixes := make(map[string][]string)
+
+	for address, neighbor := range neighbors {
+		if neighbor.State != BGPStateEstablished {
+			continue
+		}
+
+		addressFamily := "ipv4"
+		if net.ParseIP(address).To4() == nil {
+			addressFamily = "ipv6"
+		}
+
+		output, err = runVtyshCommand(
+			frrPod, fmt.Sprintf("show bgp %s unicast neighbors %s advertised-routes json", addressFamily, address))
+		if err != nil {
+			return nil, err
+		}
+
+		advertisedPrefixes[address], err = parseAdvertisedRoutes(output)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &NodeBGPStatus{
+		NodeName:           frrPod.Object.Spec.NodeName,
+		Neighbors:          neighbors,
+		BFDPeers:           bfdPeers,
+		AdvertisedPrefixes: advertisedPrefixes,
+	}, nil
+}
+
+// WaitForBGPSessionEstablished waits for the duration of the defined timeout or until the BGP session with the
+// given peer is Established on all the given nodes.
+func WaitForBGPSessionEstablished(
+	apiClient *clients.Settings,
+	frrPodsNsName, frrPodsSelector, peerAddress string,
+	nodeNames []string,
+	timeout time.Duration) error {
+	glog.V(100).Infof("Waiting for BGP session with peer %s to be established on nodes %v", peerAddress, nodeNames)
+
+	if net.ParseIP(peerAddress) == nil {
+		glog.V(100).Infof("The peerAddress %s is not a valid ip address", peerAddress)
+
+		return fmt.Errorf("failed to wait for BGP session, 'peerAddress' %s is not a valid ip address", peerAddress)
+	}
+
+	return waitForNodesBGPStatus(apiClient, frrPodsNsName, frrPodsSelector, nodeNames, timeout,
+		func(nodeStatus *NodeBGPStatus) bool {
+			neighbor, ok := nodeStatus.Neighbors[peerAddress]
+			if !ok || neighbor.State != BGPStateEstablished {
+				glog.V(100).Infof("BGP session with peer %s on node %s is not established", peerAddress, nodeStatus.NodeName)
+
+				return false
+			}
+
+			return true
+		})
+}
+
+// WaitForBFDPeerUp waits for the duration of the defined timeout or until the BFD session with the given peer is
+// up on all the given nodes.
+func WaitForBFDPeerUp(
+	apiClient *clients.Settings,
+	frrPodsNsName, frrPodsSelector, peerAddress string,
+	nodeNames []string,
+	timeout time.Duration) error {
+	glog.V(100).Infof("Waiting for BFD session with peer %s to be up on nodes %v", peerAddress, nodeNames)
+
+	if net.ParseIP(peerAddress) == nil {
+		glog.V(100).Infof("The peerAddress %s is not a valid ip address", peerAddress)
+
+		return fmt.Errorf("failed to wait for BFD session, 'peerAddress' %s is not a valid ip address", peerAddress)
+	}
+
+	return waitForNodesBGPStatus(apiClient, frrPodsNsName, frrPodsSelector, nodeNames, timeout,
+		func(nodeStatus *NodeBGPStatus) bool {
+			bfdPeer, ok := nodeStatus.BFDPeers[peerAddress]
+			if !ok || bfdPeer.Status != BFDStatusUp {
+				glog.V(100).Infof("BFD session with peer %s on node %s is not up", peerAddress, nodeStatus.NodeName)
+
+				return false
+			}
+
+			return true
+		})
+}
+
+func waitForNodesBGPStatus(
+	apiClient *clients.Settings,
+	frrPodsNsName, frrPodsSelector string,
+	nodeNames []string,
+	timeout time.Duration,
+	isInStatus func(nodeStatus *NodeBGPStatus) bool) error {
+	if apiClient == nil {
+		glog.V(100).Infof("The apiClient is empty")
+
+		return fmt.Errorf("failed to wait for BGP status, 'apiClient' parameter is empty")
+	}
+
+	if len(nodeNames) == 0 {
+		glog.V(100).Infof("The nodeNames list is empty")
+
+		return fmt.Errorf("failed to wait for BGP status, 'nodeNames' parameter is empty")
+	}
+
+	return wait.PollUntilContextTimeout(
+		context.TODO(), 3*time.Second, timeout, true, func(ctx context.Context) (bool, error) {
+			nodesStatus, err := GetBGPStatus(apiClient, frrPodsNsName, frrPodsSelector, nodeNames)
+			if err != nil {
+				glog.V(100).Infof("Failed to get BGP status: %v", err)
+
+				return false, nil
+			}
+
+			for _, nodeStatus := range nodesStatus {
+				if !isInStatus(nodeStatus) {
+					return false, nil
+				}
+			}
+
+			return true, nil
+		})
+}
+
+func getFRRPodOnNode(frrPods []*pod.Builder, nodeName string) *pod.Builder {
+	for _, frrPod := range frrPods {
+		if frrPod.Object.Spec.NodeName == nodeName {
+			return frrPod
+		}
+	}
+
+	return nil
+}
+
+func runVtyshCommand(frrPod *pod.Builder, command string) (string, error) {
+	output, err := frrPod.ExecCommand([]string{"vtysh", "-c", command}, frrContainerName)
+	if err != nil {
+		glog.V(100).Infof("Failed to run vtysh command %s in pod %s: %v", command, frrPod.Object.Name, err)
+
+		return "", fmt.Errorf("failed to run vtysh command %s in pod %s: %w", command, frrPod.Object.Name, err)
+	}
+
+	return output.String(), nil
+}
+
+func parseBGPSummary(output string) (map[string]BGPNeighborStatus, error) {
+	var addressFamilies map[string]bgpSummaryAddressFamily
+
+	err := json.Unmarshal([]byte(output), &addressFamilies)
+	if err != nil {
+		glog.V(100).Infof("Failed to unmarshal bgp summary: %v", err)
+
+		return nil, fmt.Errorf("failed to unmarshal bgp summary: %w", err)
+	}
+
+	neighbors := make(map[string]BGPNeighborStatus)
+
+	for _, addressFamily := range addressFamilies {
+		for address, peer := range addressFamily.Peers {
+			neighbor := BGPNeighborStatus{
+				Address:          address,
+				RemoteAS:         peer.RemoteAs,
+				LocalAS:          peer.LocalAs,
+				State:            peer.State,
+				Uptime:           peer.PeerUptime,
+				PrefixesReceived: peer.PfxRcd,
+				PrefixesSent:     peer.PfxSnt,
+			}
+
+			if neighbor.LocalAS == 0 {
+				neighbor.LocalAS = addressFamily.AS
+			}
+
+			if existing, ok := neighbors[address]; ok {
+				neighbor.PrefixesReceived += existing.PrefixesReceived
+				neighbor.PrefixesSent += existing.PrefixesSent
+			}
+
+			neighbors[address] = neighbor
+		}
+	}
+
+	return neighbors, nil
+}
+
+func parseBFDPeers(output string) (map[string]BFDPeerStatus, error) {
+	var peers []bfdPeer
+
+	err := json.Unmarshal([]byte(output), &peers)
+	if err != nil {
+		glog.V(100).Infof("Failed to unmarshal bfd peers: %v", err)
+
+		return nil, fmt.Errorf("failed to unmarshal bfd peers: %w", err)
+	}
+
+	bfdPeers := make(map[string]BFDPeerStatus)
+
+	for _, peer := range peers {
+		bfdPeers[peer.Peer] = BFDPeerStatus{Peer: peer.Peer, Status: peer.Status, Uptime: peer.Uptime}
+	}
+
+	return bfdPeers, nil
+}
+
+func parseAdvertisedRoutes(output string) ([]string, error) {
+	var routes bgpAdvertisedRoutes
+
+	err := json.Unmarshal([]byte(output), &routes)
+	if err != nil {
+		glog.V(100).Infof("Failed to unmarshal advertised routes: %v", err)
+
+		return nil, fmt.Errorf("failed to unmarshal advertised routes: %w", err)
+	}
+
+	var prefixes []string
+
+	for prefix := range routes.AdvertisedRoutes {
+		prefixes = append(prefixes, prefix)
+	}
+
+	slices.Sort(prefixes)
+
+	return prefixes, nil
+}
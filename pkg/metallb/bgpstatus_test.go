@@ -0,0 +1,184 @@
+package metallb
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/openshift-kni/eco-goinfra/pkg/clients"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const (
+	testBGPSummary = `{
+  "ipv4Unicast": {
+    "routerId": "10.0.0.1",
+    "as": 64500,
+    "peers": {
+      "10.0.0.10": {"remoteAs": 64501, "localAs": 64500, "state": "Established", "peerUptime": "00:01:02",
+        "pfxRcd": 2, "pfxSnt": 3},
+      "10.0.0.11": {"remoteAs": 64502, "state": "Active", "peerUptime": "never", "pfxRcd": 0, "pfxSnt": 0}
+    }
+  },
+  "ipv6Unicast": {
+    "routerId": "10.0.0.1",
+    "as": 64500,
+    "peers": {
+      "10.0.0.10": {"remoteAs": 64501, "localAs": 64500, "state": "Established", "peerUptime": "00:01:02",
+        "pfxRcd": 1, "pfxSnt": 1},
+      "2001:db8::10": {"remoteAs": 64501, "localAs": 64500, "state": "Established", "peerUptime": "00:00:30",
+        "pfxRcd": 0, "pfxSnt": 1}
+    }
+  }
+}`
+	testBFDPeers = `[
+  {"multihop": false, "peer": "10.0.0.10", "local": "10.0.0.1", "id": 1, "status": "up", "uptime": 60},
+  {"multihop": false, "peer": "10.0.0.11", "local": "10.0.0.1", "id": 2, "status": "down", "uptime": 0}
+]`
+	testAdvertisedRoutes = `{
+  "bgpTableVersion": 3,
+  "bgpLocalRouterId": "10.0.0.1",
+  "advertisedRoutes": {
+    "192.168.20.0/24": {"addrPrefix": "192.168.20.0", "prefixLen": 24},
+    "192.168.10.0/24": {"addrPrefix": "192.168.10.0", "prefixLen": 24}
+  },
+  "totalPrefixCounter": 2
+}`
+)
+
+func TestParseBGPSummary(t *testing.T) {
+	neighbors, err := parseBGPSummary(testBGPSummary)
+	assert.Nil(t, err)
+	assert.Len(t, neighbors, 3)
+	assert.Equal(t, BGPNeighborStatus{
+		Address:          "10.0.0.10",
+		RemoteAS:         64501,
+		LocalAS:          64500,
+		State:            BGPStateEstablished,
+		Uptime:           "00:01:02",
+		PrefixesReceived: 3,
+		PrefixesSent:     4,
+	}, neighbors["10.0.0.10"])
+	assert.Equal(t, "Active", neighbors["10.0.0.11"].State)
+	assert.Equal(t, uint32(64500), neighbors["10.0.0.11"].LocalAS)
+	assert.Equal(t, BGPStateEstablished, neighbors["2001:db8::10"].State)
+
+	_, err = parseBGPSummary("% Unknown command")
+	assert.NotNil(t, err)
+}
+
+func TestParseBFDPeers(t *testing.T) {
+	bfdPeers, err := parseBFDPeers(testBFDPeers)
+	assert.Nil(t, err)
+	assert.Len(t, bfdPeers, 2)
+	assert.Equal(t, BFDPeerStatus{Peer: "10.0.0.10", Status: BFDStatusUp, Uptime: 60}, bfdPeers["10.0.0.10"])
+	assert.Equal(t, "down", bfdPeers["10.0.0.11"].Status)
+
+	_, err = parseBFDPeers("{}")
+	assert.NotNil(t, err)
+}
+
+func TestParseAdvertisedRoutes(t *testing.T) {
+	prefixes, err := parseAdvertisedRoutes(testAdvertisedRoutes)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"192.168.10.0/24", "192.168.20.0/24"}, prefixes)
+
+	prefixes, err = parseAdvertisedRoutes("{}")
+	assert.Nil(t, err)
+	assert.Empty(t, prefixes)
+
+	_, err = parseAdvertisedRoutes("")
+	assert.NotNil(t, err)
+}
+
+func TestGetBGPStatus(t *testing.T) {
+	testCases := []struct {
+		nodeNames     []string
+		client        bool
+		expectedError error
+	}{
+		{
+			nodeNames:     []string{"worker-1"},
+			client:        true,
+			expectedError: fmt.Errorf("failed to find FRR pod with selector app=frr-k8s on node worker-1"),
+		},
+		{
+			nodeNames:     []string{},
+			client:        true,
+			expectedError: fmt.Errorf("failed to get BGP status, 'nodeNames' parameter is empty"),
+		},
+		{
+			nodeNames:     []string{"worker-0"},
+			client:        false,
+			expectedError: fmt.Errorf("failed to get BGP status, 'apiClient' parameter is empty"),
+		},
+	}
+
+	for _, testCase := range testCases {
+		var testSettings *clients.Settings
+
+		if testCase.client {
+			testSettings = clients.GetTestClients(clients.TestClientParams{K8sMockObjects: buildDummyFRRPods()})
+		}
+
+		_, err := GetBGPStatus(testSettings, "frr-k8s-system", FRRK8sPodsLabelSelector, testCase.nodeNames)
+		assert.Equal(t, testCase.expectedError, err)
+	}
+}
+
+func TestGetFRRPodBGPStatus(t *testing.T) {
+	_, err := GetFRRPodBGPStatus(nil)
+	assert.Equal(t, fmt.Errorf("failed to get BGP status, 'frrPod' parameter is undefined"), err)
+}
+
+func TestWaitForBGPSessionEstablished(t *testing.T) {
+	testSettings := clients.GetTestClients(clients.TestClientParams{K8sMockObjects: buildDummyFRRPods()})
+
+	err := WaitForBGPSessionEstablished(
+		testSettings, "frr-k8s-system", FRRK8sPodsLabelSelector, "10.0.0", []string{"worker-0"}, time.Second)
+	assert.Equal(t, fmt.Errorf("failed to wait for BGP session, 'peerAddress' 10.0.0 is not a valid ip address"), err)
+
+	err = WaitForBGPSessionEstablished(
+		nil, "frr-k8s-system", FRRK8sPodsLabelSelector, "10.0.0.10", []string{"worker-0"}, time.Second)
+	assert.Equal(t, fmt.Errorf("failed to wait for BGP status, 'apiClient' parameter is empty"), err)
+
+	err = WaitForBGPSessionEstablished(
+		testSettings, "frr-k8s-system", FRRK8sPodsLabelSelector, "10.0.0.10", []string{"worker-1"}, time.Second)
+	assert.NotNil(t, err)
+}
+
+func TestWaitForBFDPeerUp(t *testing.T) {
+	testSettings := clients.GetTestClients(clients.TestClientParams{K8sMockObjects: buildDummyFRRPods()})
+
+	err := WaitForBFDPeerUp(
+		testSettings, "frr-k8s-system", FRRK8sPodsLabelSelector, "", []string{"worker-0"}, time.Second)
+	assert.Equal(t, fmt.Errorf("failed to wait for BFD session, 'peerAddress'  is not a valid ip address"), err)
+
+	err = WaitForBFDPeerUp(
+		testSettings, "frr-k8s-system", FRRK8sPodsLabelSelector, "10.0.0.10", []string{}, time.Second)
+	assert.Equal(t, fmt.Errorf("failed to wait for BGP status, 'nodeNames' parameter is empty"), err)
+}
+
+func buildDummyFRRPods() []runtime.Object {
+	return []runtime.Object{
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "frr-k8s-abcde",
+				Namespace: "frr-k8s-system",
+				Labels:    map[string]string{"app": "frr-k8s"},
+			},
+			Spec: corev1.PodSpec{NodeName: "worker-0"},
+		},
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "speaker-abcde",
+				Namespace: "frr-k8s-system",
+				Labels:    map[string]string{"component": "speaker"},
+			},
+			Spec: corev1.PodSpec{NodeName: "worker-1"},
+		},
+	}
+}
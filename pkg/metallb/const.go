@@ -5,6 +5,10 @@ const (
 	APIGroup = "metallb.io"
 	// APIVersion represents version of metallb api.
 	APIVersion = "v1beta1"
+	// FRRAPIGroup represents frr-k8s api group.
+	FRRAPIGroup = "frrk8s.metallb.io"
+	// FRRAPIVersion represents version of frr-k8s api.
+	FRRAPIVersion = "v1beta1"
 	// MetalLBList represents kind of MetalLBList object.
 	MetalLBList = "MetalLBList"
 	// BGPPeerListKind represents kind of BGPPeerList object.
@@ -15,4 +19,6 @@ const (
 	BFDProfileList = "BFDProfileList"
 	// IPAddressPoolList represents kind of IPAddressPool object.
 	IPAddressPoolList = "IPAddressPoolList"
+	// FRRConfigurationListKind represents kind of FRRConfigurationList object.
+	FRRConfigurationListKind = "FRRConfigurationList"
 )
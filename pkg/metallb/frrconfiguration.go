@@ -0,0 +1,644 @@
+package metallb
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	"github.com/golang/glog"
+	"github.com/openshift-kni/eco-goinfra/pkg/clients"
+	"github.com/openshift-kni/eco-goinfra/pkg/msg"
+	"github.com/openshift-kni/eco-goinfra/pkg/schemes/metallb/frrtypes"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const (
+	frrConfigurationKind = "FRRConfiguration"
+)
+
+// FRRConfigurationBuilder provides struct for the FRRConfiguration object containing connection to
+// the cluster and the FRRConfiguration definitions.
+type FRRConfigurationBuilder struct {
+	Definition *frrtypes.FRRConfiguration
+	Object     *frrtypes.FRRConfiguration
+	apiClient  *clients.Settings
+	errorMsg   string
+}
+
+// FRRConfigurationAdditionalOptions additional options for FRRConfiguration object.
+type FRRConfigurationAdditionalOptions func(builder *FRRConfigurationBuilder) (*FRRConfigurationBuilder, error)
+
+// NewFRRConfigurationBuilder creates a new instance of FRRConfigurationBuilder.
+func NewFRRConfigurationBuilder(apiClient *clients.Settings, name, nsname string) *FRRConfigurationBuilder {
+	glog.V(100).Infof(
+		"Initializing new FRRConfigurationBuilder structure with the following params: %s, %s",
+		name, nsname)
+
+	builder := FRRConfigurationBuilder{
+		apiClient: apiClient,
+		Definition: &frrtypes.FRRConfiguration{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       frrConfigurationKind,
+				APIVersion: fmt.Sprintf("%s/%s", FRRAPIGroup, FRRAPIVersion),
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: nsname,
+			},
+		},
+	}
+
+	if name == "" {
+		glog.V(100).Infof("The name of the FRRConfiguration is empty")
+
+		builder.errorMsg = "FRRConfiguration 'name' cannot be empty"
+	}
+
+	if nsname == "" {
+		glog.V(100).Infof("The namespace of the FRRConfiguration is empty")
+
+		builder.errorMsg = "FRRConfiguration 'nsname' cannot be empty"
+	}
+
+	return &builder
+}
+
+// Get returns FRRConfiguration object if found.
+func (builder *FRRConfigurationBuilder) Get() (*frrtypes.FRRConfiguration, error) {
+	if valid, err := builder.validate(); !valid {
+		return nil, err
+	}
+
+	glog.V(100).Infof(
+		"Collecting FRRConfiguration object %s in namespace %s",
+		builder.Definition.Name, builder.Definition.Namespace)
+
+	unsObject, err := builder.apiClient.Resource(
+		GetFRRConfigurationGVR()).Namespace(builder.Definition.Namespace).Get(
+		context.TODO(), builder.Definition.Name, metav1.GetOptions{})
+
+	if err != nil {
+		glog.V(100).Infof(
+			"FRRConfiguration object %s does not exist in namespace %s",
+			builder.Definition.Name, builder.Definition.Namespace)
+
+		return nil, err
+	}
+
+	return builder.convertToStructured(unsObject)
+}
+
+// Exists checks whether the given FRRConfiguration exists.
+func (builder *FRRConfigurationBuilder) Exists() bool {
+	if valid, _ := builder.validate(); !valid {
+		return false
+	}
+
+	glog.V(100).Infof(
+		"Checking if FRRConfiguration %s exists in namespace %s",
+		builder.Definition.Name, builder.Definition.Namespace)
+
+	var err error
+	builder.Object, err = builder.Get()
+
+	return err == nil || !k8serrors.IsNotFound(err)
+}
+
+// PullFRRConfiguration pulls existing frrconfiguration from cluster.
+func PullFRRConfiguration(apiClient *clients.Settings, name, nsname string) (*FRRConfigurationBuilder, error) {
+	glog.V(100).Infof("Pulling existing frrconfiguration name %s under namespace %s from cluster", name, nsname)
+
+	if apiClient == nil {
+		glog.V(100).Infof("The apiClient is empty")
+
+		return nil, fmt.Errorf("frrconfiguration 'apiClient' cannot be empty")
+	}
+
+	builder := FRRConfigurationBuilder{
+		apiClient: apiClient,
+		Definition: &frrtypes.FRRConfiguration{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: nsname,
+			},
+		},
+	}
+
+	if name == "" {
+		glog.V(100).Infof("The name of the frrconfiguration is empty")
+
+		return nil, fmt.Errorf("frrconfiguration 'name' cannot be empty")
+	}
+
+	if nsname == "" {
+		glog.V(100).Infof("The namespace of the frrconfiguration is empty")
+
+		return nil, fmt.Errorf("frrconfiguration 'namespace' cannot be empty")
+	}
+
+	if !builder.Exists() {
+		return nil, fmt.Errorf("frrconfiguration object %s does not exist in namespace %s", name, nsname)
+	}
+
+	builder.Definition = builder.Object
+
+	return &builder, nil
+}
+
+// Create makes a FRRConfiguration in the cluster and stores the created object in struct.
+func (builder *FRRConfigurationBuilder) Create() (*FRRConfigurationBuilder, error) {
+	if valid, err := builder.validate(); !valid {
+		return builder, err
+	}
+
+	glog.V(100).Infof("Creating the FRRConfiguration %s in namespace %s",
+		builder.Definition.Name, builder.Definition.Namespace,
+	)
+
+	var err error
+	if !builder.Exists() {
+		unstructuredFRRConfiguration, err := runtime.DefaultUnstructuredConverter.ToUnstructured(builder.Definition)
+
+		if err != nil {
+			glog.V(100).Infof("Failed to convert structured FRRConfiguration to unstructured object")
+
+			return nil, err
+		}
+
+		unsObject, err := builder.apiClient.Resource(
+			GetFRRConfigurationGVR()).Namespace(builder.Definition.Namespace).Create(
+			context.TODO(), &unstructured.Unstructured{Object: unstructuredFRRConfiguration}, metav1.CreateOptions{})
+
+		if err != nil {
+			glog.V(100).Infof("Failed to create FRRConfiguration")
+
+			return nil, err
+		}
+
+		builder.Object, err = builder.convertToStructured(unsObject)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return builder, err
+}
+
+// Delete removes FRRConfiguration object from a cluster.
+func (builder *FRRConfigurationBuilder) Delete() (*FRRConfigurationBuilder, error) {
+	if valid, err := builder.validate(); !valid {
+		return builder, err
+	}
+
+	glog.V(100).Infof("Deleting the FRRConfiguration object %s in namespace %s",
+		builder.Definition.Name, builder.Definition.Namespace,
+	)
+
+	if !builder.Exists() {
+		return builder, fmt.Errorf("FRRConfiguration cannot be deleted because it does not exist")
+	}
+
+	err := builder.apiClient.Resource(
+		GetFRRConfigurationGVR()).Namespace(builder.Definition.Namespace).Delete(
+		context.TODO(), builder.Definition.Name, metav1.DeleteOptions{})
+
+	if err != nil {
+		return builder, fmt.Errorf("can not delete FRRConfiguration: %w", err)
+	}
+
+	builder.Object = nil
+
+	return builder, nil
+}
+
+// Update renovates the existing FRRConfiguration object with the FRRConfiguration definition in builder.
+func (builder *FRRConfigurationBuilder) Update(force bool) (*FRRConfigurationBuilder, error) {
+	if valid, err := builder.validate(); !valid {
+		return builder, err
+	}
+
+	glog.V(100).Infof("Updating the FRRConfiguration object %s in namespace %s",
+		builder.Definition.Name, builder.Definition.Namespace,
+	)
+
+	unstructuredFRRConfiguration, err := runtime.DefaultUnstructuredConverter.ToUnstructured(builder.Definition)
+
+	if err != nil {
+		glog.V(100).Infof("Failed to convert structured FRRConfiguration to unstructured object")
+
+		return nil, err
+	}
+
+	_, err = builder.apiClient.Resource(
+		GetFRRConfigurationGVR()).Namespace(builder.Definition.Namespace).Update(
+		context.TODO(), &unstructured.Unstructured{Object: unstructuredFRRConfiguration}, metav1.UpdateOptions{})
+
+	if err != nil {
+		if force {
+			glog.V(100).Infof(
+				msg.FailToUpdateNotification("FRRConfiguration", builder.Definition.Name, builder.Definition.Namespace))
+
+			builder, err := builder.Delete()
+
+			if err != nil {
+				glog.V(100).Infof(
+					msg.FailToUpdateError("FRRConfiguration", builder.Definition.Name, builder.Definition.Namespace))
+
+				return nil, err
+			}
+
+			return builder.Create()
+		}
+	}
+
+	return builder, err
+}
+
+// WithBGPRouter appends a BGP router with the given local ASN, router id, vrf and prefixes to advertise to the
+// FRRConfiguration. The router id, vrf and prefixes are optional.
+func (builder *FRRConfigurationBuilder) WithBGPRouter(
+	asn uint32, routerID, vrf string, prefixes []string) *FRRConfigurationBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof(
+		"Creating FRRConfiguration %s in namespace %s with BGP router asn: %d, id: %s, vrf: %s, prefixes: %v",
+		builder.Definition.Name, builder.Definition.Namespace, asn, routerID, vrf, prefixes)
+
+	if asn == 0 {
+		builder.errorMsg = "FRRConfiguration router 'asn' cannot be zero"
+
+		return builder
+	}
+
+	if routerID != "" && net.ParseIP(routerID).To4() == nil {
+		builder.errorMsg = fmt.Sprintf("FRRConfiguration router 'routerID' %s is not a valid IPv4 address", routerID)
+
+		return builder
+	}
+
+	for _, prefix := range prefixes {
+		if _, _, err := net.ParseCIDR(prefix); err != nil {
+			builder.errorMsg = fmt.Sprintf("FRRConfiguration router prefix %s is not a valid CIDR", prefix)
+
+			return builder
+		}
+	}
+
+	builder.Definition.Spec.BGP.Routers = append(builder.Definition.Spec.BGP.Routers, frrtypes.Router{
+		ASN:      asn,
+		ID:       routerID,
+		VRF:      vrf,
+		Prefixes: prefixes,
+	})
+
+	return builder
+}
+
+// WithBGPNeighbor appends a BGP neighbor with the given address and remote ASN to the router with the given index
+// in the FRRConfiguration.
+func (builder *FRRConfigurationBuilder) WithBGPNeighbor(
+	routerIndex uint, address string, remoteASN uint32) *FRRConfigurationBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof(
+		"Creating FRRConfiguration %s in namespace %s with BGP neighbor %s asn %d on router %d",
+		builder.Definition.Name, builder.Definition.Namespace, address, remoteASN, routerIndex)
+
+	if routerIndex >= uint(len(builder.Definition.Spec.BGP.Routers)) {
+		builder.errorMsg = fmt.Sprintf("FRRConfiguration router with index %d does not exist", routerIndex)
+
+		return builder
+	}
+
+	if net.ParseIP(address) == nil {
+		builder.errorMsg = fmt.Sprintf("FRRConfiguration neighbor 'address' %s is not a valid ip address", address)
+
+		return builder
+	}
+
+	if remoteASN == 0 {
+		builder.errorMsg = "FRRConfiguration neighbor 'remoteASN' cannot be zero"
+
+		return builder
+	}
+
+	router := &builder.Definition.Spec.BGP.Routers[routerIndex]
+	router.Neighbors = append(router.Neighbors, frrtypes.Neighbor{
+		Address: address,
+		ASN:     remoteASN,
+	})
+
+	return builder
+}
+
+// WithNeighborBFDProfile sets the BFD profile used by the neighbor with the given index on the router with the
+// given index in the FRRConfiguration. The profile must be defined using WithBFDProfile.
+func (builder *FRRConfigurationBuilder) WithNeighborBFDProfile(
+	routerIndex, neighborIndex uint, bfdProfile string) *FRRConfigurationBuilder {
+	neighbor := builder.getNeighbor(routerIndex, neighborIndex)
+	if neighbor == nil {
+		return builder
+	}
+
+	glog.V(100).Infof(
+		"Creating FRRConfiguration %s in namespace %s with neighbor %s bfdProfile: %s",
+		builder.Definition.Name, builder.Definition.Namespace, neighbor.Address, bfdProfile)
+
+	if bfdProfile == "" {
+		builder.errorMsg = "FRRConfiguration neighbor 'bfdProfile' cannot be empty"
+
+		return builder
+	}
+
+	neighbor.BFDProfile = bfdProfile
+
+	return builder
+}
+
+// WithNeighborTimers sets the hold and keepalive timers of the neighbor with the given index on the router with
+// the given index in the FRRConfiguration.
+func (builder *FRRConfigurationBuilder) WithNeighborTimers(
+	routerIndex, neighborIndex uint, holdTime, keepalive metav1.Duration) *FRRConfigurationBuilder {
+	neighbor := builder.getNeighbor(routerIndex, neighborIndex)
+	if neighbor == nil {
+		return builder
+	}
+
+	glog.V(100).Infof(
+		"Creating FRRConfiguration %s in namespace %s with neighbor %s holdTime: %s, keepalive: %s",
+		builder.Definition.Name, builder.Definition.Namespace, neighbor.Address, holdTime, keepalive)
+
+	if keepalive.Duration > holdTime.Duration {
+		builder.errorMsg = "FRRConfiguration neighbor 'keepalive' cannot be greater than 'holdTime'"
+
+		return builder
+	}
+
+	neighbor.HoldTime = &holdTime
+	neighbor.KeepaliveTime = &keepalive
+
+	return builder
+}
+
+// WithNeighborEBGPMultiHop sets the ebgpMultiHop flag of the neighbor with the given index on the router with the
+// given index in the FRRConfiguration.
+func (builder *FRRConfigurationBuilder) WithNeighborEBGPMultiHop(
+	routerIndex, neighborIndex uint, eBGPMultiHop bool) *FRRConfigurationBuilder {
+	neighbor := builder.getNeighbor(routerIndex, neighborIndex)
+	if neighbor == nil {
+		return builder
+	}
+
+	glog.V(100).Infof(
+		"Creating FRRConfiguration %s in namespace %s with neighbor %s ebgpMultiHop: %t",
+		builder.Definition.Name, builder.Definition.Namespace, neighbor.Address, eBGPMultiHop)
+
+	neighbor.EBGPMultiHop = eBGPMultiHop
+
+	return builder
+}
+
+// WithNeighborToAdvertise sets the prefixes allowed to be advertised to the neighbor with the given index on the
+// router with the given index in the FRRConfiguration. If no prefixes are given, all the router prefixes are
+// advertised.
+func (builder *FRRConfigurationBuilder) WithNeighborToAdvertise(
+	routerIndex, neighborIndex uint, prefixes []string) *FRRConfigurationBuilder {
+	neighbor := builder.getNeighbor(routerIndex, neighborIndex)
+	if neighbor == nil {
+		return builder
+	}
+
+	glog.V(100).Infof(
+		"Creating FRRConfiguration %s in namespace %s with neighbor %s toAdvertise prefixes: %v",
+		builder.Definition.Name, builder.Definition.Namespace, neighbor.Address, prefixes)
+
+	if len(prefixes) == 0 {
+		neighbor.ToAdvertise.Allowed = frrtypes.AllowedOutPrefixes{Mode: frrtypes.AllowAll}
+
+		return builder
+	}
+
+	for _, prefix := range prefixes {
+		if _, _, err := net.ParseCIDR(prefix); err != nil {
+			builder.errorMsg = fmt.Sprintf("FRRConfiguration toAdvertise prefix %s is not a valid CIDR", prefix)
+
+			return builder
+		}
+	}
+
+	neighbor.ToAdvertise.Allowed = frrtypes.AllowedOutPrefixes{Prefixes: prefixes, Mode: frrtypes.AllowRestricted}
+
+	return builder
+}
+
+// WithNeighborToReceive sets the prefixes allowed to be received from the neighbor with the given index on the
+// router with the given index in the FRRConfiguration. If no prefixes are given, all the prefixes are received.
+func (builder *FRRConfigurationBuilder) WithNeighborToReceive(
+	routerIndex, neighborIndex uint, prefixes []string) *FRRConfigurationBuilder {
+	neighbor := builder.getNeighbor(routerIndex, neighborIndex)
+	if neighbor == nil {
+		return builder
+	}
+
+	glog.V(100).Infof(
+		"Creating FRRConfiguration %s in namespace %s with neighbor %s toReceive prefixes: %v",
+		builder.Definition.Name, builder.Definition.Namespace, neighbor.Address, prefixes)
+
+	if len(prefixes) == 0 {
+		neighbor.ToReceive.Allowed = frrtypes.AllowedInPrefixes{Mode: frrtypes.AllowAll}
+
+		return builder
+	}
+
+	var prefixSelectors []frrtypes.PrefixSelector
+
+	for _, prefix := range prefixes {
+		if _, _, err := net.ParseCIDR(prefix); err != nil {
+			builder.errorMsg = fmt.Sprintf("FRRConfiguration toReceive prefix %s is not a valid CIDR", prefix)
+
+			return builder
+		}
+
+		prefixSelectors = append(prefixSelectors, frrtypes.PrefixSelector{Prefix: prefix})
+	}
+
+	neighbor.ToReceive.Allowed = frrtypes.AllowedInPrefixes{Prefixes: prefixSelectors, Mode: frrtypes.AllowRestricted}
+
+	return builder
+}
+
+// WithBFDProfile appends a BFD profile that can be referenced by the FRRConfiguration neighbors.
+func (builder *FRRConfigurationBuilder) WithBFDProfile(bfdProfile frrtypes.BFDProfile) *FRRConfigurationBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof(
+		"Creating FRRConfiguration %s in namespace %s with BFD profile %s",
+		builder.Definition.Name, builder.Definition.Namespace, bfdProfile.Name)
+
+	if bfdProfile.Name == "" {
+		builder.errorMsg = "FRRConfiguration BFD profile 'name' cannot be empty"
+
+		return builder
+	}
+
+	builder.Definition.Spec.BGP.BFDProfiles = append(builder.Definition.Spec.BGP.BFDProfiles, bfdProfile)
+
+	return builder
+}
+
+// WithNodeSelector limits the nodes the FRRConfiguration is applied to.
+func (builder *FRRConfigurationBuilder) WithNodeSelector(nodeSelector map[string]string) *FRRConfigurationBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof(
+		"Creating FRRConfiguration %s in namespace %s with nodeSelector: %v",
+		builder.Definition.Name, builder.Definition.Namespace, nodeSelector)
+
+	if len(nodeSelector) == 0 {
+		builder.errorMsg = "FRRConfiguration 'nodeSelector' cannot be empty map"
+
+		return builder
+	}
+
+	builder.Definition.Spec.NodeSelector = metav1.LabelSelector{MatchLabels: nodeSelector}
+
+	return builder
+}
+
+// WithRawConfig sets a raw FRR configuration snippet appended to the rendered configuration with the given priority.
+func (builder *FRRConfigurationBuilder) WithRawConfig(rawConfig string, priority int) *FRRConfigurationBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof(
+		"Creating FRRConfiguration %s in namespace %s with raw config priority %d",
+		builder.Definition.Name, builder.Definition.Namespace, priority)
+
+	if rawConfig == "" {
+		builder.errorMsg = "FRRConfiguration 'rawConfig' cannot be empty"
+
+		return builder
+	}
+
+	builder.Definition.Spec.Raw = frrtypes.RawConfig{Config: rawConfig, Priority: priority}
+
+	return builder
+}
+
+// WithOptions creates FRRConfiguration with generic mutation options.
+func (builder *FRRConfigurationBuilder) WithOptions(
+	options ...FRRConfigurationAdditionalOptions) *FRRConfigurationBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Setting FRRConfiguration additional options")
+
+	for _, option := range options {
+		if option != nil {
+			builder, err := option(builder)
+
+			if err != nil {
+				glog.V(100).Infof("Error occurred in mutation function")
+
+				builder.errorMsg = err.Error()
+
+				return builder
+			}
+		}
+	}
+
+	return builder
+}
+
+// GetFRRConfigurationGVR returns frrconfiguration's GroupVersionResource which could be used for Clean function.
+func GetFRRConfigurationGVR() schema.GroupVersionResource {
+	return schema.GroupVersionResource{
+		Group: FRRAPIGroup, Version: FRRAPIVersion, Resource: "frrconfigurations",
+	}
+}
+
+func (builder *FRRConfigurationBuilder) getNeighbor(routerIndex, neighborIndex uint) *frrtypes.Neighbor {
+	if valid, _ := builder.validate(); !valid {
+		return nil
+	}
+
+	if routerIndex >= uint(len(builder.Definition.Spec.BGP.Routers)) {
+		builder.errorMsg = fmt.Sprintf("FRRConfiguration router with index %d does not exist", routerIndex)
+
+		return nil
+	}
+
+	router := &builder.Definition.Spec.BGP.Routers[routerIndex]
+
+	if neighborIndex >= uint(len(router.Neighbors)) {
+		builder.errorMsg = fmt.Sprintf(
+			"FRRConfiguration neighbor with index %d does not exist on router %d", neighborIndex, routerIndex)
+
+		return nil
+	}
+
+	return &router.Neighbors[neighborIndex]
+}
+
+// validate will check that the builder and builder definition are properly initialized before
+// accessing any member fields.
+func (builder *FRRConfigurationBuilder) validate() (bool, error) {
+	resourceCRD := "FRRConfiguration"
+
+	if builder == nil {
+		glog.V(100).Infof("The %s builder is uninitialized", resourceCRD)
+
+		return false, fmt.Errorf("error: received nil %s builder", resourceCRD)
+	}
+
+	if builder.Definition == nil {
+		glog.V(100).Infof("The %s is undefined", resourceCRD)
+
+		builder.errorMsg = msg.UndefinedCrdObjectErrString(resourceCRD)
+	}
+
+	if builder.apiClient == nil {
+		glog.V(100).Infof("The %s builder apiclient is nil", resourceCRD)
+
+		builder.errorMsg = fmt.Sprintf("%s builder cannot have nil apiClient", resourceCRD)
+	}
+
+	if builder.errorMsg != "" {
+		glog.V(100).Infof("The %s builder has error message: %s", resourceCRD, builder.errorMsg)
+
+		return false, fmt.Errorf(builder.errorMsg)
+	}
+
+	return true, nil
+}
+
+func (builder *FRRConfigurationBuilder) convertToStructured(
+	unsObject *unstructured.Unstructured) (*frrtypes.FRRConfiguration, error) {
+	frrConfiguration := &frrtypes.FRRConfiguration{}
+
+	err := runtime.DefaultUnstructuredConverter.FromUnstructured(unsObject.Object, frrConfiguration)
+	if err != nil {
+		glog.V(100).Infof(
+			"Failed to convert from unstructured to FRRConfiguration object in namespace %s",
+			builder.Definition.Name, builder.Definition.Namespace)
+
+		return nil, err
+	}
+
+	return frrConfiguration, err
+}
@@ -0,0 +1,609 @@
+package metallb
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/openshift-kni/eco-goinfra/pkg/clients"
+	"github.com/openshift-kni/eco-goinfra/pkg/schemes/metallb/frrtypes"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+var (
+	frrConfigurationGVK = schema.GroupVersionKind{
+		Group:   FRRAPIGroup,
+		Version: FRRAPIVersion,
+		Kind:    frrConfigurationKind,
+	}
+	defaultFRRConfigurationName   = "frrconfiguration"
+	defaultFRRConfigurationNsName = "test-namespace"
+)
+
+func TestNewFRRConfigurationBuilder(t *testing.T) {
+	testCases := []struct {
+		name          string
+		namespace     string
+		expectedError string
+	}{
+		{
+			name:          defaultFRRConfigurationName,
+			namespace:     defaultFRRConfigurationNsName,
+			expectedError: "",
+		},
+		{
+			name:          "",
+			namespace:     defaultFRRConfigurationNsName,
+			expectedError: "FRRConfiguration 'name' cannot be empty",
+		},
+		{
+			name:          defaultFRRConfigurationName,
+			namespace:     "",
+			expectedError: "FRRConfiguration 'nsname' cannot be empty",
+		},
+	}
+
+	for _, testCase := range testCases {
+		testSettings := clients.GetTestClients(clients.TestClientParams{
+			GVK: []schema.GroupVersionKind{frrConfigurationGVK},
+		})
+		testBuilder := NewFRRConfigurationBuilder(testSettings, testCase.name, testCase.namespace)
+		assert.Equal(t, testCase.expectedError, testBuilder.errorMsg)
+		assert.NotNil(t, testBuilder.Definition)
+
+		if testCase.expectedError == "" {
+			assert.Equal(t, testCase.name, testBuilder.Definition.Name)
+			assert.Equal(t, testCase.namespace, testBuilder.Definition.Namespace)
+		}
+	}
+}
+
+func TestPullFRRConfiguration(t *testing.T) {
+	testCases := []struct {
+		name                string
+		namespace           string
+		addToRuntimeObjects bool
+		expectedError       error
+		client              bool
+	}{
+		{
+			name:                defaultFRRConfigurationName,
+			namespace:           defaultFRRConfigurationNsName,
+			addToRuntimeObjects: true,
+			expectedError:       nil,
+			client:              true,
+		},
+		{
+			name:                "",
+			namespace:           defaultFRRConfigurationNsName,
+			addToRuntimeObjects: true,
+			expectedError:       fmt.Errorf("frrconfiguration 'name' cannot be empty"),
+			client:              true,
+		},
+		{
+			name:                defaultFRRConfigurationName,
+			namespace:           "",
+			addToRuntimeObjects: true,
+			expectedError:       fmt.Errorf("frrconfiguration 'namespace' cannot be empty"),
+			client:              true,
+		},
+		{
+			name:                defaultFRRConfigurationName,
+			namespace:           defaultFRRConfigurationNsName,
+			addToRuntimeObjects: false,
+			expectedError: fmt.Errorf(
+				"frrconfiguration object frrconfiguration does not exist in namespace test-namespace"),
+			client: true,
+		},
+		{
+			name:                defaultFRRConfigurationName,
+			namespace:           defaultFRRConfigurationNsName,
+			addToRuntimeObjects: true,
+			expectedError:       fmt.Errorf("frrconfiguration 'apiClient' cannot be empty"),
+			client:              false,
+		},
+	}
+
+	for _, testCase := range testCases {
+		var (
+			runtimeObjects []runtime.Object
+			testSettings   *clients.Settings
+		)
+
+		if testCase.addToRuntimeObjects {
+			runtimeObjects = append(runtimeObjects, &frrtypes.FRRConfiguration{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      testCase.name,
+					Namespace: testCase.namespace,
+				},
+			})
+		}
+
+		if testCase.client {
+			testSettings = clients.GetTestClients(clients.TestClientParams{
+				K8sMockObjects: runtimeObjects,
+				GVK:            []schema.GroupVersionKind{frrConfigurationGVK},
+			})
+		}
+
+		builderResult, err := PullFRRConfiguration(testSettings, testCase.name, testCase.namespace)
+		assert.Equal(t, testCase.expectedError, err)
+
+		if testCase.expectedError == nil {
+			assert.Equal(t, testCase.name, builderResult.Object.Name)
+			assert.Equal(t, testCase.namespace, builderResult.Object.Namespace)
+		}
+	}
+}
+
+func TestFRRConfigurationGet(t *testing.T) {
+	testCases := []struct {
+		testBuilder   *FRRConfigurationBuilder
+		expectedError error
+	}{
+		{
+			testBuilder:   buildValidFRRConfigurationBuilder(buildFRRConfigurationTestClientWithDummyObject()),
+			expectedError: nil,
+		},
+		{
+			testBuilder:   buildInValidFRRConfigurationBuilder(buildFRRConfigurationTestClientWithDummyObject()),
+			expectedError: fmt.Errorf("FRRConfiguration 'nsname' cannot be empty"),
+		},
+	}
+
+	for _, testCase := range testCases {
+		frrConfiguration, err := testCase.testBuilder.Get()
+		assert.Equal(t, testCase.expectedError, err)
+
+		if testCase.expectedError == nil {
+			assert.Equal(t, testCase.testBuilder.Definition.Name, frrConfiguration.Name)
+		}
+	}
+}
+
+func TestFRRConfigurationExist(t *testing.T) {
+	testCases := []struct {
+		testBuilder    *FRRConfigurationBuilder
+		expectedStatus bool
+	}{
+		{
+			testBuilder:    buildValidFRRConfigurationBuilder(buildFRRConfigurationTestClientWithDummyObject()),
+			expectedStatus: true,
+		},
+		{
+			testBuilder:    buildInValidFRRConfigurationBuilder(buildFRRConfigurationTestClientWithDummyObject()),
+			expectedStatus: false,
+		},
+		{
+			testBuilder: buildValidFRRConfigurationBuilder(clients.GetTestClients(clients.TestClientParams{
+				GVK: []schema.GroupVersionKind{frrConfigurationGVK},
+			})),
+			expectedStatus: false,
+		},
+	}
+
+	for _, testCase := range testCases {
+		assert.Equal(t, testCase.expectedStatus, testCase.testBuilder.Exists())
+	}
+}
+
+func TestFRRConfigurationCreate(t *testing.T) {
+	testCases := []struct {
+		testBuilder   *FRRConfigurationBuilder
+		expectedError error
+	}{
+		{
+			testBuilder: buildValidFRRConfigurationBuilder(clients.GetTestClients(clients.TestClientParams{
+				GVK: []schema.GroupVersionKind{frrConfigurationGVK},
+			})).WithRawConfig("router bgp 64500", 5),
+			expectedError: nil,
+		},
+		{
+			testBuilder:   buildValidFRRConfigurationBuilder(buildFRRConfigurationTestClientWithDummyObject()),
+			expectedError: nil,
+		},
+		{
+			testBuilder:   buildInValidFRRConfigurationBuilder(buildFRRConfigurationTestClientWithDummyObject()),
+			expectedError: fmt.Errorf("FRRConfiguration 'nsname' cannot be empty"),
+		},
+	}
+
+	for _, testCase := range testCases {
+		testBuilder, err := testCase.testBuilder.Create()
+		assert.Equal(t, testCase.expectedError, err)
+
+		if testCase.expectedError == nil {
+			assert.Equal(t, testBuilder.Definition.Name, testBuilder.Object.Name)
+			assert.Equal(t, testBuilder.Definition.Spec, testBuilder.Object.Spec)
+		}
+	}
+}
+
+func TestFRRConfigurationDelete(t *testing.T) {
+	testCases := []struct {
+		testBuilder   *FRRConfigurationBuilder
+		expectedError error
+	}{
+		{
+			testBuilder:   buildValidFRRConfigurationBuilder(buildFRRConfigurationTestClientWithDummyObject()),
+			expectedError: nil,
+		},
+		{
+			testBuilder:   buildInValidFRRConfigurationBuilder(buildFRRConfigurationTestClientWithDummyObject()),
+			expectedError: fmt.Errorf("FRRConfiguration 'nsname' cannot be empty"),
+		},
+	}
+
+	for _, testCase := range testCases {
+		_, err := testCase.testBuilder.Delete()
+		assert.Equal(t, testCase.expectedError, err)
+
+		if testCase.expectedError == nil {
+			assert.Nil(t, testCase.testBuilder.Object)
+		}
+	}
+}
+
+func TestFRRConfigurationUpdate(t *testing.T) {
+	testCases := []struct {
+		testBuilder   *FRRConfigurationBuilder
+		expectedError error
+	}{
+		{
+			testBuilder:   buildValidFRRConfigurationBuilder(buildFRRConfigurationTestClientWithDummyObject()),
+			expectedError: nil,
+		},
+		{
+			testBuilder:   buildInValidFRRConfigurationBuilder(buildFRRConfigurationTestClientWithDummyObject()),
+			expectedError: fmt.Errorf("FRRConfiguration 'nsname' cannot be empty"),
+		},
+	}
+
+	for _, testCase := range testCases {
+		assert.Empty(t, testCase.testBuilder.Definition.Spec.NodeSelector.MatchLabels)
+		testCase.testBuilder.WithNodeSelector(map[string]string{"kubernetes.io/hostname": "worker-0"})
+		_, err := testCase.testBuilder.Update(false)
+		assert.Equal(t, testCase.expectedError, err)
+
+		if testCase.expectedError == nil {
+			frrConfiguration, err := testCase.testBuilder.Get()
+			assert.Nil(t, err)
+			assert.Equal(t, map[string]string{"kubernetes.io/hostname": "worker-0"},
+				frrConfiguration.Spec.NodeSelector.MatchLabels)
+		}
+	}
+}
+
+func TestFRRConfigurationWithBGPRouter(t *testing.T) {
+	testCases := []struct {
+		asn           uint32
+		routerID      string
+		prefixes      []string
+		expectedError string
+	}{
+		{
+			asn:           64500,
+			routerID:      "10.0.0.1",
+			prefixes:      []string{"192.168.10.0/24", "2001:db8::/64"},
+			expectedError: "",
+		},
+		{
+			asn:           64500,
+			routerID:      "",
+			prefixes:      nil,
+			expectedError: "",
+		},
+		{
+			asn:           0,
+			routerID:      "10.0.0.1",
+			prefixes:      nil,
+			expectedError: "FRRConfiguration router 'asn' cannot be zero",
+		},
+		{
+			asn:           64500,
+			routerID:      "2001:db8::1",
+			prefixes:      nil,
+			expectedError: "FRRConfiguration router 'routerID' 2001:db8::1 is not a valid IPv4 address",
+		},
+		{
+			asn:           64500,
+			routerID:      "10.0.0.1",
+			prefixes:      []string{"192.168.10.0"},
+			expectedError: "FRRConfiguration router prefix 192.168.10.0 is not a valid CIDR",
+		},
+	}
+
+	for _, testCase := range testCases {
+		testBuilder := buildValidFRRConfigurationBuilder(buildFRRConfigurationTestClientWithDummyObject()).
+			WithBGPRouter(testCase.asn, testCase.routerID, "", testCase.prefixes)
+		assert.Equal(t, testCase.expectedError, testBuilder.errorMsg)
+
+		if testCase.expectedError == "" {
+			assert.Equal(t, testCase.asn, testBuilder.Definition.Spec.BGP.Routers[0].ASN)
+			assert.Equal(t, testCase.routerID, testBuilder.Definition.Spec.BGP.Routers[0].ID)
+			assert.Equal(t, testCase.prefixes, testBuilder.Definition.Spec.BGP.Routers[0].Prefixes)
+		}
+	}
+}
+
+func TestFRRConfigurationWithBGPNeighbor(t *testing.T) {
+	testCases := []struct {
+		routerIndex   uint
+		address       string
+		remoteASN     uint32
+		expectedError string
+	}{
+		{
+			routerIndex:   0,
+			address:       "10.0.0.10",
+			remoteASN:     64501,
+			expectedError: "",
+		},
+		{
+			routerIndex:   1,
+			address:       "10.0.0.10",
+			remoteASN:     64501,
+			expectedError: "FRRConfiguration router with index 1 does not exist",
+		},
+		{
+			routerIndex:   0,
+			address:       "10.0.0",
+			remoteASN:     64501,
+			expectedError: "FRRConfiguration neighbor 'address' 10.0.0 is not a valid ip address",
+		},
+		{
+			routerIndex:   0,
+			address:       "10.0.0.10",
+			remoteASN:     0,
+			expectedError: "FRRConfiguration neighbor 'remoteASN' cannot be zero",
+		},
+	}
+
+	for _, testCase := range testCases {
+		testBuilder := buildValidFRRConfigurationBuilder(buildFRRConfigurationTestClientWithDummyObject()).
+			WithBGPRouter(64500, "", "", nil).
+			WithBGPNeighbor(testCase.routerIndex, testCase.address, testCase.remoteASN)
+		assert.Equal(t, testCase.expectedError, testBuilder.errorMsg)
+
+		if testCase.expectedError == "" {
+			neighbor := testBuilder.Definition.Spec.BGP.Routers[0].Neighbors[0]
+			assert.Equal(t, testCase.address, neighbor.Address)
+			assert.Equal(t, testCase.remoteASN, neighbor.ASN)
+		}
+	}
+}
+
+func TestFRRConfigurationWithNeighborBFDProfile(t *testing.T) {
+	testCases := []struct {
+		neighborIndex uint
+		bfdProfile    string
+		expectedError string
+	}{
+		{
+			neighborIndex: 0,
+			bfdProfile:    "bfd-profile",
+			expectedError: "",
+		},
+		{
+			neighborIndex: 1,
+			bfdProfile:    "bfd-profile",
+			expectedError: "FRRConfiguration neighbor with index 1 does not exist on router 0",
+		},
+		{
+			neighborIndex: 0,
+			bfdProfile:    "",
+			expectedError: "FRRConfiguration neighbor 'bfdProfile' cannot be empty",
+		},
+	}
+
+	for _, testCase := range testCases {
+		testBuilder := buildValidFRRConfigurationBuilderWithNeighbor().
+			WithNeighborBFDProfile(0, testCase.neighborIndex, testCase.bfdProfile)
+		assert.Equal(t, testCase.expectedError, testBuilder.errorMsg)
+
+		if testCase.expectedError == "" {
+			assert.Equal(t, testCase.bfdProfile, testBuilder.Definition.Spec.BGP.Routers[0].Neighbors[0].BFDProfile)
+		}
+	}
+}
+
+func TestFRRConfigurationWithNeighborTimers(t *testing.T) {
+	testCases := []struct {
+		holdTime      metav1.Duration
+		keepalive     metav1.Duration
+		expectedError string
+	}{
+		{
+			holdTime:      metav1.Duration{Duration: 90 * time.Second},
+			keepalive:     metav1.Duration{Duration: 30 * time.Second},
+			expectedError: "",
+		},
+		{
+			holdTime:      metav1.Duration{Duration: 30 * time.Second},
+			keepalive:     metav1.Duration{Duration: 90 * time.Second},
+			expectedError: "FRRConfiguration neighbor 'keepalive' cannot be greater than 'holdTime'",
+		},
+	}
+
+	for _, testCase := range testCases {
+		testBuilder := buildValidFRRConfigurationBuilderWithNeighbor().
+			WithNeighborTimers(0, 0, testCase.holdTime, testCase.keepalive)
+		assert.Equal(t, testCase.expectedError, testBuilder.errorMsg)
+
+		if testCase.expectedError == "" {
+			neighbor := testBuilder.Definition.Spec.BGP.Routers[0].Neighbors[0]
+			assert.Equal(t, &testCase.holdTime, neighbor.HoldTime)
+			assert.Equal(t, &testCase.keepalive, neighbor.KeepaliveTime)
+		}
+	}
+}
+
+func TestFRRConfigurationWithNeighborEBGPMultiHop(t *testing.T) {
+	testBuilder := buildValidFRRConfigurationBuilderWithNeighbor().WithNeighborEBGPMultiHop(0, 0, true)
+	assert.Equal(t, "", testBuilder.errorMsg)
+	assert.True(t, testBuilder.Definition.Spec.BGP.Routers[0].Neighbors[0].EBGPMultiHop)
+
+	testBuilder = buildValidFRRConfigurationBuilder(buildFRRConfigurationTestClientWithDummyObject()).
+		WithNeighborEBGPMultiHop(0, 0, true)
+	assert.Equal(t, "FRRConfiguration router with index 0 does not exist", testBuilder.errorMsg)
+}
+
+func TestFRRConfigurationWithNeighborToAdvertise(t *testing.T) {
+	testCases := []struct {
+		prefixes      []string
+		expectedMode  frrtypes.AllowMode
+		expectedError string
+	}{
+		{
+			prefixes:      nil,
+			expectedMode:  frrtypes.AllowAll,
+			expectedError: "",
+		},
+		{
+			prefixes:      []string{"192.168.10.0/24"},
+			expectedMode:  frrtypes.AllowRestricted,
+			expectedError: "",
+		},
+		{
+			prefixes:      []string{"192.168.10.0"},
+			expectedError: "FRRConfiguration toAdvertise prefix 192.168.10.0 is not a valid CIDR",
+		},
+	}
+
+	for _, testCase := range testCases {
+		testBuilder := buildValidFRRConfigurationBuilderWithNeighbor().
+			WithNeighborToAdvertise(0, 0, testCase.prefixes)
+		assert.Equal(t, testCase.expectedError, testBuilder.errorMsg)
+
+		if testCase.expectedError == "" {
+			allowed := testBuilder.Definition.Spec.BGP.Routers[0].Neighbors[0].ToAdvertise.Allowed
+			assert.Equal(t, testCase.expectedMode, allowed.Mode)
+			assert.Equal(t, testCase.prefixes, allowed.Prefixes)
+		}
+	}
+}
+
+func TestFRRConfigurationWithNeighborToReceive(t *testing.T) {
+	testCases := []struct {
+		prefixes      []string
+		expectedMode  frrtypes.AllowMode
+		expectedError string
+	}{
+		{
+			prefixes:      nil,
+			expectedMode:  frrtypes.AllowAll,
+			expectedError: "",
+		},
+		{
+			prefixes:      []string{"192.168.10.0/24"},
+			expectedMode:  frrtypes.AllowRestricted,
+			expectedError: "",
+		},
+		{
+			prefixes:      []string{"192.168.10.0"},
+			expectedError: "FRRConfiguration toReceive prefix 192.168.10.0 is not a valid CIDR",
+		},
+	}
+
+	for _, testCase := range testCases {
+		testBuilder := buildValidFRRConfigurationBuilderWithNeighbor().
+			WithNeighborToReceive(0, 0, testCase.prefixes)
+		assert.Equal(t, testCase.expectedError, testBuilder.errorMsg)
+
+		if testCase.expectedError == "" {
+			allowed := testBuilder.Definition.Spec.BGP.Routers[0].Neighbors[0].ToReceive.Allowed
+			assert.Equal(t, testCase.expectedMode, allowed.Mode)
+			assert.Len(t, allowed.Prefixes, len(testCase.prefixes))
+		}
+	}
+}
+
+func TestFRRConfigurationWithBFDProfile(t *testing.T) {
+	testBuilder := buildValidFRRConfigurationBuilder(buildFRRConfigurationTestClientWithDummyObject()).
+		WithBFDProfile(frrtypes.BFDProfile{Name: "bfd-profile"})
+	assert.Equal(t, "", testBuilder.errorMsg)
+	assert.Equal(t, "bfd-profile", testBuilder.Definition.Spec.BGP.BFDProfiles[0].Name)
+
+	testBuilder = buildValidFRRConfigurationBuilder(buildFRRConfigurationTestClientWithDummyObject()).
+		WithBFDProfile(frrtypes.BFDProfile{})
+	assert.Equal(t, "FRRConfiguration BFD profile 'name' cannot be empty", testBuilder.errorMsg)
+}
+
+func TestFRRConfigurationWithNodeSelector(t *testing.T) {
+	testBuilder := buildValidFRRConfigurationBuilder(buildFRRConfigurationTestClientWithDummyObject()).
+		WithNodeSelector(map[string]string{"kubernetes.io/hostname": "worker-0"})
+	assert.Equal(t, "", testBuilder.errorMsg)
+	assert.Equal(t, map[string]string{"kubernetes.io/hostname": "worker-0"},
+		testBuilder.Definition.Spec.NodeSelector.MatchLabels)
+
+	testBuilder = buildValidFRRConfigurationBuilder(buildFRRConfigurationTestClientWithDummyObject()).
+		WithNodeSelector(map[string]string{})
+	assert.Equal(t, "FRRConfiguration 'nodeSelector' cannot be empty map", testBuilder.errorMsg)
+}
+
+func TestFRRConfigurationWithRawConfig(t *testing.T) {
+	testBuilder := buildValidFRRConfigurationBuilder(buildFRRConfigurationTestClientWithDummyObject()).
+		WithRawConfig("router bgp 64500", 5)
+	assert.Equal(t, "", testBuilder.errorMsg)
+	assert.Equal(t, frrtypes.RawConfig{Config: "router bgp 64500", Priority: 5}, testBuilder.Definition.Spec.Raw)
+
+	testBuilder = buildValidFRRConfigurationBuilder(buildFRRConfigurationTestClientWithDummyObject()).
+		WithRawConfig("", 5)
+	assert.Equal(t, "FRRConfiguration 'rawConfig' cannot be empty", testBuilder.errorMsg)
+}
+
+func TestFRRConfigurationWithOptions(t *testing.T) {
+	testSettings := buildFRRConfigurationTestClientWithDummyObject()
+	testBuilder := buildValidFRRConfigurationBuilder(testSettings).WithOptions(
+		func(builder *FRRConfigurationBuilder) (*FRRConfigurationBuilder, error) {
+			return builder, nil
+		})
+
+	assert.Equal(t, "", testBuilder.errorMsg)
+	testBuilder = buildValidFRRConfigurationBuilder(testSettings).WithOptions(
+		func(builder *FRRConfigurationBuilder) (*FRRConfigurationBuilder, error) {
+			return builder, fmt.Errorf("error")
+		})
+
+	assert.Equal(t, "error", testBuilder.errorMsg)
+}
+
+func TestFRRConfigurationGVR(t *testing.T) {
+	assert.Equal(t, GetFRRConfigurationGVR(),
+		schema.GroupVersionResource{
+			Group: FRRAPIGroup, Version: FRRAPIVersion, Resource: "frrconfigurations",
+		})
+}
+
+func buildValidFRRConfigurationBuilder(apiClient *clients.Settings) *FRRConfigurationBuilder {
+	return NewFRRConfigurationBuilder(apiClient, defaultFRRConfigurationName, defaultFRRConfigurationNsName)
+}
+
+func buildInValidFRRConfigurationBuilder(apiClient *clients.Settings) *FRRConfigurationBuilder {
+	return NewFRRConfigurationBuilder(apiClient, defaultFRRConfigurationName, "")
+}
+
+func buildValidFRRConfigurationBuilderWithNeighbor() *FRRConfigurationBuilder {
+	return buildValidFRRConfigurationBuilder(buildFRRConfigurationTestClientWithDummyObject()).
+		WithBGPRouter(64500, "", "", nil).
+		WithBGPNeighbor(0, "10.0.0.10", 64501)
+}
+
+func buildFRRConfigurationTestClientWithDummyObject() *clients.Settings {
+	return clients.GetTestClients(clients.TestClientParams{
+		K8sMockObjects: buildDummyFRRConfiguration(),
+		GVK:            []schema.GroupVersionKind{frrConfigurationGVK},
+	})
+}
+
+func buildDummyFRRConfiguration() []runtime.Object {
+	return append([]runtime.Object{}, &frrtypes.FRRConfiguration{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      defaultFRRConfigurationName,
+			Namespace: defaultFRRConfigurationNsName,
+		},
+	})
+}
@@ -0,0 +1,424 @@
+package metallb
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/openshift-kni/eco-goinfra/pkg/clients"
+	"github.com/openshift-kni/eco-goinfra/pkg/schemes/metallb/mlbtypes"
+	"github.com/openshift-kni/eco-goinfra/pkg/service"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+const (
+	// IPAllocatedFromPoolAnnotation is the annotation MetalLB sets on a service with the name of the
+	// IPAddressPool the service IP was allocated from.
+	IPAllocatedFromPoolAnnotation = "metallb.io/ip-allocated-from-pool"
+	// LegacyIPAllocatedFromPoolAnnotation is the annotation older MetalLB releases set on a service with the name
+	// of the IPAddressPool the service IP was allocated from.
+	LegacyIPAllocatedFromPoolAnnotation = "metallb.universe.tf/ip-allocated-from-pool"
+
+	nodeAssignedEventReason = "nodeAssigned"
+)
+
+// nodeAssignedEventRegex matches the message of the nodeAssigned events emitted by the speakers, e.g.
+// announcing from node "worker-0" with protocol "layer2".
+var nodeAssignedEventRegex = regexp.MustCompile(`announcing from node "([^"]+)" with protocol "([^"]+)"`)
+
+// ServiceAnnouncement describes how a LoadBalancer service is announced by MetalLB.
+type ServiceAnnouncement struct {
+	// ServiceName is the name of the service.
+	ServiceName string
+	// ServiceNamespace is the namespace of the service.
+	ServiceNamespace string
+	// IPs are the load balancer ingress IPs assigned to the service.
+	IPs []string
+	// AddressPool is the name of the IPAddressPool the IPs were allocated from.
+	AddressPool string
+	// L2Node is the node announcing the service in layer2 mode. It is empty if the service is not announced in
+	// layer2 mode.
+	L2Node string
+	// L2Interfaces are the interfaces the service is announced from in layer2 mode.
+	L2Interfaces []string
+	// BGPPeers are the names of the BGPPeers the service is advertised to, keyed by announcing node.
+	BGPPeers map[string][]string
+}
+
+// GetServiceAnnouncement resolves the IPs assigned to the given LoadBalancer service, the IPAddressPool they were
+// allocated from, the node announcing it in layer2 mode and the BGP peers it is advertised to from each node. The
+// MetalLB status resources are looked up in metalLbNsName.
+func GetServiceAnnouncement(
+	apiClient *clients.Settings, serviceName, serviceNsName, metalLbNsName string) (*ServiceAnnouncement, error) {
+	glog.V(100).Infof("Getting MetalLB announcement of service %s in namespace %s", serviceName, serviceNsName)
+
+	if err := validateServiceStatusParams(apiClient, serviceName, serviceNsName, metalLbNsName); err != nil {
+		return nil, err
+	}
+
+	serviceBuilder, err := service.Pull(apiClient, serviceName, serviceNsName)
+	if err != nil {
+		glog.V(100).Infof("Failed to pull service %s in namespace %s: %v", serviceName, serviceNsName, err)
+
+		return nil, err
+	}
+
+	announcement := &ServiceAnnouncement{
+		ServiceName:      serviceName,
+		ServiceNamespace: serviceNsName,
+		IPs:              getServiceIngressIPs(serviceBuilder.Object),
+	}
+
+	announcement.AddressPool, err = getServiceAddressPool(apiClient, serviceBuilder.Object, metalLbNsName)
+	if err != nil {
+		return nil, err
+	}
+
+	l2Status, err := getServiceL2Status(apiClient, serviceName, serviceNsName, metalLbNsName)
+	if err != nil {
+		return nil, err
+	}
+
+	if l2Status != nil {
+		announcement.L2Node = l2Status.Node
+
+		for _, l2Interface := range l2Status.Interfaces {
+			announcement.L2Interfaces = append(announcement.L2Interfaces, l2Interface.Name)
+		}
+	}
+
+	announcement.BGPPeers, err = GetServiceBGPPeers(apiClient, serviceName, serviceNsName, metalLbNsName)
+	if err != nil {
+		return nil, err
+	}
+
+	return announcement, nil
+}
+
+// GetServiceL2AnnouncingNode returns the node announcing the given service in layer2 mode. The node is read from
+// the ServiceL2Status resources in metalLbNsName and, when none exist, from the latest nodeAssigned event of the
+// service.
+func GetServiceL2AnnouncingNode(
+	apiClient *clients.Settings, serviceName, serviceNsName, metalLbNsName string) (string, error) {
+	glog.V(100).Infof("Getting layer2 announcing node of service %s in namespace %s", serviceName, serviceNsName)
+
+	if err := validateServiceStatusParams(apiClient, serviceName, serviceNsName, metalLbNsName); err != nil {
+		return "", err
+	}
+
+	l2Status, err := getServiceL2Status(apiClient, serviceName, serviceNsName, metalLbNsName)
+	if err != nil {
+		return "", err
+	}
+
+	if l2Status != nil && l2Status.Node != "" {
+		return l2Status.Node, nil
+	}
+
+	glog.V(100).Infof("No ServiceL2Status found for service %s in namespace %s, falling back to events",
+		serviceName, serviceNsName)
+
+	nodeName, err := getServiceAnnouncingNodeFromEvents(apiClient, serviceName, serviceNsName)
+	if err != nil {
+		return "", err
+	}
+
+	if nodeName == "" {
+		return "", fmt.Errorf("service %s in namespace %s is not announced in layer2 mode", serviceName, serviceNsName)
+	}
+
+	return nodeName, nil
+}
+
+// GetServiceBGPPeers returns the names of the BGPPeers the given service is advertised to, keyed by announcing
+// node, read from the ServiceBGPStatus resources in metalLbNsName.
+func GetServiceBGPPeers(
+	apiClient *clients.Settings, serviceName, serviceNsName, metalLbNsName string) (map[string][]string, error) {
+	glog.V(100).Infof("Getting BGP peers of service %s in namespace %s", serviceName, serviceNsName)
+
+	if err := validateServiceStatusParams(apiClient, serviceName, serviceNsName, metalLbNsName); err != nil {
+		return nil, err
+	}
+
+	bgpPeers := make(map[string][]string)
+
+	err := listMetalLBResources(apiClient, GetServiceBGPStatusGVR(), metalLbNsName, func(object runtime.Object) {
+		bgpStatus, ok := object.(*mlbtypes.ServiceBGPStatus)
+		if !ok || bgpStatus.Status.ServiceName != serviceName || bgpStatus.Status.ServiceNamespace != serviceNsName {
+			return
+		}
+
+		bgpPeers[bgpStatus.Status.Node] = append(bgpPeers[bgpStatus.Status.Node], bgpStatus.Status.Peers...)
+	}, func() runtime.Object { return &mlbtypes.ServiceBGPStatus{} })
+	if err != nil {
+		return nil, err
+	}
+
+	return bgpPeers, nil
+}
+
+// WaitForServiceL2Failover waits for the duration of the defined timeout or until the given service is announced
+// in layer2 mode from a node other than previousNode. It returns the new announcing node.
+func WaitForServiceL2Failover(
+	apiClient *clients.Settings,
+	serviceName, serviceNsName, metalLbNsName, previousNode string,
+	timeout time.Duration) (string, error) {
+	glog.V(100).Infof("Waiting for service %s in namespace %s to fail over from node %s",
+		serviceName, serviceNsName, previousNode)
+
+	if err := validateServiceStatusParams(apiClient, serviceName, serviceNsName, metalLbNsName); err != nil {
+		return "", err
+	}
+
+	if previousNode == "" {
+		glog.V(100).Infof("The previousNode is empty")
+
+		return "", fmt.Errorf("failed to wait for service failover, 'previousNode' parameter is empty")
+	}
+
+	var nodeName string
+
+	err := wait.PollUntilContextTimeout(
+		context.TODO(), 3*time.Second, timeout, true, func(ctx context.Context) (bool, error) {
+			var err error
+
+			nodeName, err = GetServiceL2AnnouncingNode(apiClient, serviceName, serviceNsName, metalLbNsName)
+			if err != nil {
+				glog.V(100).Infof("Failed to get layer2 announcing node of service %s: %v", serviceName, err)
+
+				return false, nil
+			}
+
+			return nodeName != previousNode, nil
+		})
+
+	if err != nil {
+		return "", err
+	}
+
+	return nodeName, nil
+}
+
+// GetServiceL2StatusGVR returns ServiceL2Status's GroupVersionResource which could be used for Clean function.
+func GetServiceL2StatusGVR() schema.GroupVersionResource {
+	return schema.GroupVersionResource{
+		Group: APIGroup, Version: APIVersion, Resource: "servicel2statuses",
+	}
+}
+
+// GetServiceBGPStatusGVR returns ServiceBGPStatus's GroupVersionResource which could be used for Clean function.
+func GetServiceBGPStatusGVR() schema.GroupVersionResource {
+	return schema.GroupVersionResource{
+		Group: APIGroup, Version: APIVersion, Resource: "servicebgpstatuses",
+	}
+}
+
+func validateServiceStatusParams(
+	apiClient *clients.Settings, serviceName, serviceNsName, metalLbNsName string) error {
+	if apiClient == nil {
+		glog.V(100).Infof("The apiClient is empty")
+
+		return fmt.Errorf("failed to get service announcement, 'apiClient' parameter is empty")
+	}
+
+	if serviceName == "" {
+		glog.V(100).Infof("The serviceName is empty")
+
+		return fmt.Errorf("failed to get service announcement, 'serviceName' parameter is empty")
+	}
+
+	if serviceNsName == "" {
+		glog.V(100).Infof("The serviceNsName is empty")
+
+		return fmt.Errorf("failed to get service announcement, 'serviceNsName' parameter is empty")
+	}
+
+	if metalLbNsName == "" {
+		glog.V(100).Infof("The metalLbNsName is empty")
+
+		return fmt.Errorf("failed to get service announcement, 'metalLbNsName' parameter is empty")
+	}
+
+	return nil
+}
+
+func getServiceIngressIPs(serviceObj *corev1.Service) []string {
+	var ips []string
+
+	for _, ingress := range serviceObj.Status.LoadBalancer.Ingress {
+		if ingress.IP != "" {
+			ips = append(ips, ingress.IP)
+		}
+	}
+
+	return ips
+}
+
+// getServiceAddressPool returns the pool set in the allocation annotation of the service and, when the annotation
+// is missing, the first IPAddressPool in metalLbNsName containing the service IP.
+func getServiceAddressPool(
+	apiClient *clients.Settings, serviceObj *corev1.Service, metalLbNsName string) (string, error) {
+	for _, annotation := range []string{IPAllocatedFromPoolAnnotation, LegacyIPAllocatedFromPoolAnnotation} {
+		if pool, ok := serviceObj.Annotations[annotation]; ok && pool != "" {
+			return pool, nil
+		}
+	}
+
+	ips := getServiceIngressIPs(serviceObj)
+	if len(ips) == 0 {
+		return "", nil
+	}
+
+	var poolName string
+
+	err := listMetalLBResources(apiClient, GetIPAddressPoolGVR(), metalLbNsName, func(object runtime.Object) {
+		ipAddressPool, ok := object.(*mlbtypes.IPAddressPool)
+		if !ok || poolName != "" {
+			return
+		}
+
+		for _, addresses := range ipAddressPool.Spec.Addresses {
+			if isIPInAddresses(ips[0], addresses) {
+				poolName = ipAddressPool.Name
+
+				return
+			}
+		}
+	}, func() runtime.Object { return &mlbtypes.IPAddressPool{} })
+
+	return poolName, err
+}
+
+func getServiceL2Status(
+	apiClient *clients.Settings,
+	serviceName, serviceNsName, metalLbNsName string) (*mlbtypes.MetalLBServiceL2Status, error) {
+	var l2Status *mlbtypes.MetalLBServiceL2Status
+
+	err := listMetalLBResources(apiClient, GetServiceL2StatusGVR(), metalLbNsName, func(object runtime.Object) {
+		serviceL2Status, ok := object.(*mlbtypes.ServiceL2Status)
+		if !ok || serviceL2Status.Status.ServiceName != serviceName ||
+			serviceL2Status.Status.ServiceNamespace != serviceNsName {
+			return
+		}
+
+		l2Status = &serviceL2Status.Status
+	}, func() runtime.Object { return &mlbtypes.ServiceL2Status{} })
+
+	return l2Status, err
+}
+
+// listMetalLBResources lists the resources of the given GVR and calls visit on each of them converted to the object
+// returned by newObject. A missing resource type is not an error, since older MetalLB releases do not define it.
+func listMetalLBResources(
+	apiClient *clients.Settings,
+	gvr schema.GroupVersionResource,
+	nsname string,
+	visit func(object runtime.Object),
+	newObject func() runtime.Object) error {
+	unsList, err := apiClient.Resource(gvr).Namespace(nsname).List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			glog.V(100).Infof("Resource %s is not found in namespace %s", gvr.Resource, nsname)
+
+			return nil
+		}
+
+		glog.V(100).Infof("Failed to list %s in namespace %s: %v", gvr.Resource, nsname, err)
+
+		return err
+	}
+
+	for _, unsObject := range unsList.Items {
+		object := newObject()
+
+		err = runtime.DefaultUnstructuredConverter.FromUnstructured(unsObject.Object, object)
+		if err != nil {
+			glog.V(100).Infof("Failed to convert from unstructured to %s object", gvr.Resource)
+
+			return err
+		}
+
+		visit(object)
+	}
+
+	return nil
+}
+
+// getServiceAnnouncingNodeFromEvents returns the node of the latest layer2 nodeAssigned event of the service.
+func getServiceAnnouncingNodeFromEvents(
+	apiClient *clients.Settings, serviceName, serviceNsName string) (string, error) {
+	eventList, err := apiClient.CoreV1Interface.Events(serviceNsName).List(context.TODO(), metav1.ListOptions{
+		FieldSelector: fmt.Sprintf("involvedObject.kind=Service,involvedObject.name=%s", serviceName),
+	})
+	if err != nil {
+		glog.V(100).Infof("Failed to list events of service %s in namespace %s: %v", serviceName, serviceNsName, err)
+
+		return "", err
+	}
+
+	var (
+		nodeName  string
+		latestRef time.Time
+	)
+
+	for _, event := range eventList.Items {
+		if event.InvolvedObject.Kind != "Service" || event.InvolvedObject.Name != serviceName ||
+			event.Reason != nodeAssignedEventReason {
+			continue
+		}
+
+		matches := nodeAssignedEventRegex.FindStringSubmatch(event.Message)
+		if len(matches) != 3 || matches[2] != "layer2" {
+			continue
+		}
+
+		eventTime := event.LastTimestamp.Time
+		if eventTime.IsZero() {
+			eventTime = event.EventTime.Time
+		}
+
+		if nodeName == "" || !eventTime.Before(latestRef) {
+			nodeName = matches[1]
+			latestRef = eventTime
+		}
+	}
+
+	return nodeName, nil
+}
+
+// isIPInAddresses checks whether the ip belongs to an IPAddressPool address entry given either as a CIDR or as an
+// ip range in the form first-last.
+func isIPInAddresses(ipAddress, addresses string) bool {
+	parsedIP := net.ParseIP(ipAddress)
+	if parsedIP == nil {
+		return false
+	}
+
+	if _, ipNet, err := net.ParseCIDR(addresses); err == nil {
+		return ipNet.Contains(parsedIP)
+	}
+
+	bounds := strings.Split(addresses, "-")
+	if len(bounds) != 2 {
+		return false
+	}
+
+	first := net.ParseIP(strings.TrimSpace(bounds[0]))
+	last := net.ParseIP(strings.TrimSpace(bounds[1]))
+
+	if first == nil || last == nil {
+		return false
+	}
+
+	return bytes.Compare(parsedIP.To16(), first.To16()) >= 0 && bytes.Compare(parsedIP.To16(), last.To16()) <= 0
+}
@@ -0,0 +1,334 @@
+package metallb
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/openshift-kni/eco-goinfra/pkg/clients"
+	"github.com/openshift-kni/eco-goinfra/pkg/schemes/metallb/mlbtypes"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+var (
+	serviceL2StatusGVK = schema.GroupVersionKind{
+		Group:   APIGroup,
+		Version: APIVersion,
+		Kind:    "ServiceL2Status",
+	}
+	serviceBGPStatusGVK = schema.GroupVersionKind{
+		Group:   APIGroup,
+		Version: APIVersion,
+		Kind:    "ServiceBGPStatus",
+	}
+	defaultServiceName      = "lb-service"
+	defaultServiceNsName    = "test-namespace"
+	defaultSpeakerNsName    = "metallb-system"
+	defaultServiceIP        = "192.168.100.10"
+	defaultAnnouncingNode   = "worker-0"
+	defaultServiceL2Message = `announcing from node "worker-1" with protocol "layer2"`
+)
+
+func TestGetServiceL2AnnouncingNode(t *testing.T) {
+	testCases := []struct {
+		serviceName   string
+		l2StatusFor   string
+		events        []runtime.Object
+		client        bool
+		expectedNode  string
+		expectedError error
+	}{
+		{
+			serviceName:   defaultServiceName,
+			l2StatusFor:   defaultServiceName,
+			client:        true,
+			expectedNode:  defaultAnnouncingNode,
+			expectedError: nil,
+		},
+		{
+			serviceName:   defaultServiceName,
+			l2StatusFor:   "other-service",
+			events:        []runtime.Object{buildDummyNodeAssignedEvent(defaultServiceL2Message)},
+			client:        true,
+			expectedNode:  "worker-1",
+			expectedError: nil,
+		},
+		{
+			serviceName: defaultServiceName,
+			l2StatusFor: "other-service",
+			events: []runtime.Object{
+				buildDummyNodeAssignedEvent(`announcing from node "worker-1" with protocol "bgp"`)},
+			client:       true,
+			expectedNode: "",
+			expectedError: fmt.Errorf(
+				"service lb-service in namespace test-namespace is not announced in layer2 mode"),
+		},
+		{
+			serviceName:   "",
+			l2StatusFor:   defaultServiceName,
+			client:        true,
+			expectedNode:  "",
+			expectedError: fmt.Errorf("failed to get service announcement, 'serviceName' parameter is empty"),
+		},
+		{
+			serviceName:   defaultServiceName,
+			l2StatusFor:   defaultServiceName,
+			client:        false,
+			expectedNode:  "",
+			expectedError: fmt.Errorf("failed to get service announcement, 'apiClient' parameter is empty"),
+		},
+	}
+
+	for _, testCase := range testCases {
+		var testSettings *clients.Settings
+
+		if testCase.client {
+			testSettings = clients.GetTestClients(clients.TestClientParams{
+				K8sMockObjects: append(testCase.events, buildDummyServiceL2Status(testCase.l2StatusFor)),
+				GVK:            []schema.GroupVersionKind{serviceL2StatusGVK},
+			})
+		}
+
+		nodeName, err := GetServiceL2AnnouncingNode(
+			testSettings, testCase.serviceName, defaultServiceNsName, defaultSpeakerNsName)
+		assert.Equal(t, testCase.expectedError, err)
+		assert.Equal(t, testCase.expectedNode, nodeName)
+	}
+}
+
+func TestGetServiceBGPPeers(t *testing.T) {
+	testCases := []struct {
+		metalLbNsName string
+		expectedPeers map[string][]string
+		expectedError error
+	}{
+		{
+			metalLbNsName: defaultSpeakerNsName,
+			expectedPeers: map[string][]string{
+				"worker-0": {"peer-a", "peer-b"},
+				"worker-1": {"peer-a"},
+			},
+			expectedError: nil,
+		},
+		{
+			metalLbNsName: "",
+			expectedPeers: nil,
+			expectedError: fmt.Errorf("failed to get service announcement, 'metalLbNsName' parameter is empty"),
+		},
+	}
+
+	for _, testCase := range testCases {
+		testSettings := clients.GetTestClients(clients.TestClientParams{
+			K8sMockObjects: []runtime.Object{
+				buildDummyServiceBGPStatus("status-0", defaultServiceName, "worker-0", []string{"peer-a", "peer-b"}),
+				buildDummyServiceBGPStatus("status-1", defaultServiceName, "worker-1", []string{"peer-a"}),
+				buildDummyServiceBGPStatus("status-2", "other-service", "worker-1", []string{"peer-c"}),
+			},
+			GVK: []schema.GroupVersionKind{serviceBGPStatusGVK},
+		})
+
+		bgpPeers, err := GetServiceBGPPeers(testSettings, defaultServiceName, defaultServiceNsName, testCase.metalLbNsName)
+		assert.Equal(t, testCase.expectedError, err)
+		assert.Equal(t, testCase.expectedPeers, bgpPeers)
+	}
+}
+
+func TestGetServiceAddressPool(t *testing.T) {
+	testCases := []struct {
+		annotations  map[string]string
+		ingressIP    string
+		expectedPool string
+	}{
+		{
+			annotations:  map[string]string{IPAllocatedFromPoolAnnotation: "pool-a"},
+			ingressIP:    defaultServiceIP,
+			expectedPool: "pool-a",
+		},
+		{
+			annotations:  map[string]string{LegacyIPAllocatedFromPoolAnnotation: "pool-b"},
+			ingressIP:    defaultServiceIP,
+			expectedPool: "pool-b",
+		},
+		{
+			annotations:  nil,
+			ingressIP:    defaultServiceIP,
+			expectedPool: "pool-range",
+		},
+		{
+			annotations:  nil,
+			ingressIP:    "10.10.10.10",
+			expectedPool: "",
+		},
+		{
+			annotations:  nil,
+			ingressIP:    "",
+			expectedPool: "",
+		},
+	}
+
+	for _, testCase := range testCases {
+		testSettings := clients.GetTestClients(clients.TestClientParams{
+			K8sMockObjects: []runtime.Object{
+				buildDummyAddressPoolWithAddresses("pool-cidr", "192.168.200.0/24"),
+				buildDummyAddressPoolWithAddresses("pool-range", "192.168.100.5-192.168.100.20"),
+			},
+			GVK: []schema.GroupVersionKind{addressPoolGvk},
+		})
+
+		serviceObj := buildDummyLoadBalancerService(testCase.ingressIP)
+		serviceObj.Annotations = testCase.annotations
+
+		poolName, err := getServiceAddressPool(testSettings, serviceObj, defaultSpeakerNsName)
+		assert.Nil(t, err)
+		assert.Equal(t, testCase.expectedPool, poolName)
+	}
+}
+
+func TestGetServiceAnnouncement(t *testing.T) {
+	testSettings := clients.GetTestClients(clients.TestClientParams{})
+
+	_, err := GetServiceAnnouncement(testSettings, defaultServiceName, "", defaultSpeakerNsName)
+	assert.Equal(t, fmt.Errorf("failed to get service announcement, 'serviceNsName' parameter is empty"), err)
+
+	_, err = GetServiceAnnouncement(testSettings, defaultServiceName, defaultServiceNsName, defaultSpeakerNsName)
+	assert.Equal(t, fmt.Errorf("service object lb-service does not exist in namespace test-namespace"), err)
+}
+
+func TestWaitForServiceL2Failover(t *testing.T) {
+	testCases := []struct {
+		previousNode  string
+		expectedNode  string
+		expectedError error
+	}{
+		{
+			previousNode:  "worker-1",
+			expectedNode:  defaultAnnouncingNode,
+			expectedError: nil,
+		},
+		{
+			previousNode:  defaultAnnouncingNode,
+			expectedNode:  "",
+			expectedError: fmt.Errorf("context deadline exceeded"),
+		},
+		{
+			previousNode:  "",
+			expectedNode:  "",
+			expectedError: fmt.Errorf("failed to wait for service failover, 'previousNode' parameter is empty"),
+		},
+	}
+
+	for _, testCase := range testCases {
+		testSettings := clients.GetTestClients(clients.TestClientParams{
+			K8sMockObjects: []runtime.Object{buildDummyServiceL2Status(defaultServiceName)},
+			GVK:            []schema.GroupVersionKind{serviceL2StatusGVK},
+		})
+
+		nodeName, err := WaitForServiceL2Failover(testSettings, defaultServiceName, defaultServiceNsName,
+			defaultSpeakerNsName, testCase.previousNode, time.Second)
+		assert.Equal(t, testCase.expectedNode, nodeName)
+
+		if testCase.expectedError == nil {
+			assert.Nil(t, err)
+		} else {
+			assert.EqualError(t, err, testCase.expectedError.Error())
+		}
+	}
+}
+
+func TestIsIPInAddresses(t *testing.T) {
+	testCases := []struct {
+		ipAddress string
+		addresses string
+		expected  bool
+	}{
+		{ipAddress: "192.168.100.10", addresses: "192.168.100.0/24", expected: true},
+		{ipAddress: "192.168.101.10", addresses: "192.168.100.0/24", expected: false},
+		{ipAddress: "192.168.100.10", addresses: "192.168.100.5-192.168.100.20", expected: true},
+		{ipAddress: "192.168.100.21", addresses: "192.168.100.5-192.168.100.20", expected: false},
+		{ipAddress: "2001:db8::10", addresses: "2001:db8::1 - 2001:db8::ff", expected: true},
+		{ipAddress: "2001:db8::10", addresses: "2001:db8::/120", expected: true},
+		{ipAddress: "192.168.100.10", addresses: "invalid", expected: false},
+		{ipAddress: "invalid", addresses: "192.168.100.0/24", expected: false},
+	}
+
+	for _, testCase := range testCases {
+		assert.Equal(t, testCase.expected, isIPInAddresses(testCase.ipAddress, testCase.addresses))
+	}
+}
+
+func buildDummyServiceL2Status(serviceName string) *mlbtypes.ServiceL2Status {
+	return &mlbtypes.ServiceL2Status{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "l2-status",
+			Namespace: defaultSpeakerNsName,
+		},
+		Status: mlbtypes.MetalLBServiceL2Status{
+			Node:             defaultAnnouncingNode,
+			ServiceName:      serviceName,
+			ServiceNamespace: defaultServiceNsName,
+			Interfaces:       []mlbtypes.InterfaceInfo{{Name: "br-ex"}},
+		},
+	}
+}
+
+func buildDummyServiceBGPStatus(name, serviceName, nodeName string, peers []string) *mlbtypes.ServiceBGPStatus {
+	return &mlbtypes.ServiceBGPStatus{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: defaultSpeakerNsName,
+		},
+		Status: mlbtypes.MetalLBServiceBGPStatus{
+			Node:             nodeName,
+			ServiceName:      serviceName,
+			ServiceNamespace: defaultServiceNsName,
+			Peers:            peers,
+		},
+	}
+}
+
+func buildDummyAddressPoolWithAddresses(name, addresses string) *mlbtypes.IPAddressPool {
+	return &mlbtypes.IPAddressPool{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: defaultSpeakerNsName,
+		},
+		Spec: mlbtypes.IPAddressPoolSpec{Addresses: []string{addresses}},
+	}
+}
+
+func buildDummyLoadBalancerService(ingressIP string) *corev1.Service {
+	serviceObj := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      defaultServiceName,
+			Namespace: defaultServiceNsName,
+		},
+		Spec: corev1.ServiceSpec{Type: corev1.ServiceTypeLoadBalancer},
+	}
+
+	if ingressIP != "" {
+		serviceObj.Status.LoadBalancer.Ingress = []corev1.LoadBalancerIngress{{IP: ingressIP}}
+	}
+
+	return serviceObj
+}
+
+func buildDummyNodeAssignedEvent(message string) *corev1.Event {
+	return &corev1.Event{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "lb-service.17a",
+			Namespace: defaultServiceNsName,
+		},
+		InvolvedObject: corev1.ObjectReference{
+			Kind:      "Service",
+			Name:      defaultServiceName,
+			Namespace: defaultServiceNsName,
+		},
+		Reason:        nodeAssignedEventReason,
+		Message:       message,
+		LastTimestamp: metav1.Now(),
+	}
+}
@@ -0,0 +1,459 @@
+package nad
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/golang/glog"
+	"k8s.io/utils/strings/slices"
+)
+
+// cniSchema describes the constraints a known CNI plugin or IPAM configuration has to satisfy.
+type cniSchema struct {
+	// required are the keys which must be present in the configuration.
+	required []string
+	// exactlyOneOf are the keys of which exactly one must be present in the configuration.
+	exactlyOneOf []string
+	// enums are the allowed values of string keys.
+	enums map[string][]string
+	// ranges are the inclusive minimum and maximum of numeric keys.
+	ranges map[string][2]float64
+	// cidrs are the keys holding a CIDR or a comma separated list of CIDRs.
+	cidrs []string
+	// custom runs additional checks which cannot be expressed declaratively.
+	custom func(config map[string]interface{}) error
+}
+
+var (
+	// knownPluginSchemas represents the schemas of the CNI plugins the configuration is validated against.
+	// Plugins with other types are not validated.
+	knownPluginSchemas = map[string]cniSchema{
+		"macvlan": {
+			enums: map[string][]string{"mode": allowedMacVlanMode},
+		},
+		"ipvlan": {
+			enums: map[string][]string{"mode": {"l2", "l3", "l3s"}},
+		},
+		"bridge": {
+			ranges: map[string][2]float64{"vlan": {0, 4094}},
+		},
+		"vlan": {
+			required: []string{"vlanId"},
+			ranges:   map[string][2]float64{"vlanId": {0, 4094}},
+		},
+		"bond": {
+			required: []string{"mode", "links"},
+			enums: map[string][]string{"mode": {
+				"balance-rr", "active-backup", "balance-xor", "broadcast", "802.3ad", "balance-tlb", "balance-alb"}},
+			ranges: map[string][2]float64{"failOverMac": {0, 2}},
+		},
+		"sriov": {
+			enums: map[string][]string{
+				"spoofchk":   {"on", "off"},
+				"trust":      {"on", "off"},
+				"link_state": {"auto", "enable", "disable"},
+			},
+			ranges: map[string][2]float64{"vlan": {0, 4094}, "vlanQoS": {0, 7}},
+		},
+		"tap": {
+			ranges: map[string][2]float64{"owner": {0, 1<<32 - 1}, "group": {0, 1<<32 - 1}},
+		},
+		"tuning": {
+			custom: validateTuningSysctl,
+		},
+		"host-device": {
+			exactlyOneOf: []string{"device", "hwaddr", "kernelpath", "pciBusID"},
+		},
+		"ovn-k8s-cni-overlay": {
+			required: []string{"topology", "netAttachDefName"},
+			enums: map[string][]string{
+				"topology": {"layer2", "layer3", "localnet"},
+				"role":     {"primary", "secondary"},
+			},
+			ranges: map[string][2]float64{"vlanID": {1, 4094}},
+			cidrs:  []string{"subnets", "excludeSubnets"},
+		},
+	}
+
+	// knownIPAMSchemas represents the schemas of the IPAM plugins the configuration is validated against.
+	knownIPAMSchemas = map[string]cniSchema{
+		"whereabouts": {
+			custom: validateWhereaboutsRanges,
+		},
+		"dhcp":   {},
+		"static": {},
+	}
+)
+
+// ovnOverlayPluginType is the type of the OVN-Kubernetes secondary network plugin. It names its VLAN key vlanID while
+// the vlan plugin names it vlanId, both are held by the VlanID field of the plugin.
+const ovnOverlayPluginType = "ovn-k8s-cni-overlay"
+
+// MarshalJSON encodes a Plugin writing VlanID as vlanID for the ovn-k8s-cni-overlay plugin and as vlanId otherwise.
+func (plugin Plugin) MarshalJSON() ([]byte, error) {
+	type pluginAlias Plugin
+
+	if plugin.Type != ovnOverlayPluginType {
+		return json.Marshal(pluginAlias(plugin))
+	}
+
+	return json.Marshal(struct {
+		pluginAlias
+		VlanID         uint16 `json:"vlanId,omitempty"`
+		LocalnetVlanID uint16 `json:"vlanID,omitempty"`
+	}{pluginAlias: pluginAlias(plugin), LocalnetVlanID: plugin.VlanID})
+}
+
+// UnmarshalJSON decodes a Plugin accepting both numeric and string values for vlan and mtu, since the CNI plugins
+// define them as numbers while the Plugin struct holds them as strings. Both the vlanId and vlanID keys are decoded
+// into VlanID.
+func (plugin *Plugin) UnmarshalJSON(data []byte) error {
+	type pluginAlias Plugin
+
+	aux := struct {
+		*pluginAlias
+		Vlan           json.RawMessage `json:"vlan,omitempty"`
+		Mtu            json.RawMessage `json:"mtu,omitempty"`
+		LocalnetVlanID *uint16         `json:"vlanID,omitempty"`
+	}{pluginAlias: (*pluginAlias)(plugin)}
+
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	plugin.Vlan = strings.Trim(string(aux.Vlan), `"`)
+	plugin.Mtu = strings.Trim(string(aux.Mtu), `"`)
+
+	if aux.LocalnetVlanID != nil {
+		plugin.VlanID = *aux.LocalnetVlanID
+	}
+
+	return nil
+}
+
+// MarshalJSON encodes a MasterPlugin writing VlanID as vlanID for the ovn-k8s-cni-overlay plugin and as vlanId
+// otherwise.
+func (masterPlugin MasterPlugin) MarshalJSON() ([]byte, error) {
+	type masterPluginAlias MasterPlugin
+
+	if masterPlugin.Type != ovnOverlayPluginType {
+		return json.Marshal(masterPluginAlias(masterPlugin))
+	}
+
+	return json.Marshal(struct {
+		masterPluginAlias
+		VlanID         uint16 `json:"vlanId,omitempty"`
+		LocalnetVlanID uint16 `json:"vlanID,omitempty"`
+	}{masterPluginAlias: masterPluginAlias(masterPlugin), LocalnetVlanID: masterPlugin.VlanID})
+}
+
+// UnmarshalJSON decodes a MasterPlugin, reading both the vlanId and vlanID keys into VlanID.
+func (masterPlugin *MasterPlugin) UnmarshalJSON(data []byte) error {
+	type masterPluginAlias MasterPlugin
+
+	aux := struct {
+		*masterPluginAlias
+		LocalnetVlanID *uint16 `json:"vlanID,omitempty"`
+	}{masterPluginAlias: (*masterPluginAlias)(masterPlugin)}
+
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	if aux.LocalnetVlanID != nil {
+		masterPlugin.VlanID = *aux.LocalnetVlanID
+	}
+
+	return nil
+}
+
+// ParseConfig decodes a NetworkAttachmentDefinition spec.config into a MasterPlugin. Single plugin configurations
+// are decoded into the MasterPlugin fields while plugin chains are decoded into MasterPlugin.Plugins.
+func ParseConfig(config string) (*MasterPlugin, error) {
+	glog.V(100).Infof("Parsing NetworkAttachmentDefinition config %s", config)
+
+	if config == "" {
+		glog.V(100).Infof("The NetworkAttachmentDefinition config is empty")
+
+		return nil, fmt.Errorf("failed to parse NAD config, 'config' parameter is empty")
+	}
+
+	masterPlugin := &MasterPlugin{}
+
+	err := json.Unmarshal([]byte(config), masterPlugin)
+	if err != nil {
+		glog.V(100).Infof("Failed to unmarshal NetworkAttachmentDefinition config: %v", err)
+
+		return nil, fmt.Errorf("failed to parse NAD config: %w", err)
+	}
+
+	if masterPlugin.Type == "" && masterPlugin.Plugins == nil {
+		glog.V(100).Infof("The NetworkAttachmentDefinition config has neither type nor plugins")
+
+		return nil, fmt.Errorf("failed to parse NAD config, config has neither 'type' nor 'plugins'")
+	}
+
+	return masterPlugin, nil
+}
+
+// ValidateConfig validates a NetworkAttachmentDefinition spec.config against the schemas of the known CNI plugins:
+// macvlan, ipvlan, bridge, vlan, bond, sriov, tap, tuning, host-device and ovn-k8s-cni-overlay, and the known IPAM
+// plugins: whereabouts, dhcp and static. Plugins of other types are only checked for a type.
+func ValidateConfig(config string) error {
+	glog.V(100).Infof("Validating NetworkAttachmentDefinition config %s", config)
+
+	var rawConfig map[string]interface{}
+
+	err := json.Unmarshal([]byte(config), &rawConfig)
+	if err != nil {
+		glog.V(100).Infof("Failed to unmarshal NetworkAttachmentDefinition config: %v", err)
+
+		return fmt.Errorf("failed to parse NAD config: %w", err)
+	}
+
+	if rawConfig["name"] == nil || rawConfig["name"] == "" {
+		return fmt.Errorf("invalid NAD config: 'name' is missing")
+	}
+
+	rawPlugins, isChain := rawConfig["plugins"]
+	if !isChain {
+		return validatePluginConfig(rawConfig)
+	}
+
+	plugins, ok := rawPlugins.([]interface{})
+	if !ok || len(plugins) == 0 {
+		return fmt.Errorf("invalid NAD config: 'plugins' must be a non-empty list")
+	}
+
+	for index, rawPlugin := range plugins {
+		plugin, ok := rawPlugin.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("invalid NAD config: plugin %d is not an object", index)
+		}
+
+		if err := validatePluginConfig(plugin); err != nil {
+			return fmt.Errorf("plugin %d: %w", index, err)
+		}
+	}
+
+	return nil
+}
+
+// GetConfig returns the typed configuration decoded from the NetworkAttachmentDefinition spec.config.
+func (builder *Builder) GetConfig() (*MasterPlugin, error) {
+	if valid, err := builder.validate(); !valid {
+		return nil, err
+	}
+
+	glog.V(100).Infof("Getting config of NetworkAttachmentDefinition %s in namespace %s",
+		builder.Definition.Name, builder.Definition.Namespace)
+
+	return ParseConfig(builder.Definition.Spec.Config)
+}
+
+// ValidateConfig validates the NetworkAttachmentDefinition spec.config against the known CNI plugin schemas.
+func (builder *Builder) ValidateConfig() error {
+	if valid, err := builder.validate(); !valid {
+		return err
+	}
+
+	glog.V(100).Infof("Validating config of NetworkAttachmentDefinition %s in namespace %s",
+		builder.Definition.Name, builder.Definition.Namespace)
+
+	if builder.Definition.Spec.Config == "" {
+		return fmt.Errorf("NetworkAttachmentDefinition %s has empty config", builder.Definition.Name)
+	}
+
+	return ValidateConfig(builder.Definition.Spec.Config)
+}
+
+func validatePluginConfig(config map[string]interface{}) error {
+	pluginType, _ := config["type"].(string)
+	if pluginType == "" {
+		return fmt.Errorf("invalid NAD config: 'type' is missing")
+	}
+
+	if schema, ok := knownPluginSchemas[pluginType]; ok {
+		if err := schema.validate(config); err != nil {
+			return fmt.Errorf("invalid %s plugin config: %w", pluginType, err)
+		}
+	} else {
+		glog.V(100).Infof("Plugin type %s is not known, skipping schema validation", pluginType)
+	}
+
+	rawIPAM, ok := config["ipam"]
+	if !ok {
+		return nil
+	}
+
+	ipam, ok := rawIPAM.(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("invalid %s plugin config: 'ipam' is not an object", pluginType)
+	}
+
+	// An empty ipam object is valid and means no IPAM.
+	if len(ipam) == 0 {
+		return nil
+	}
+
+	ipamType, _ := ipam["type"].(string)
+	if ipamType == "" {
+		return fmt.Errorf("invalid %s plugin config: ipam 'type' is missing", pluginType)
+	}
+
+	if schema, ok := knownIPAMSchemas[ipamType]; ok {
+		if err := schema.validate(ipam); err != nil {
+			return fmt.Errorf("invalid %s ipam config: %w", ipamType, err)
+		}
+	}
+
+	return nil
+}
+
+func (schema cniSchema) validate(config map[string]interface{}) error {
+	for _, key := range schema.required {
+		if _, ok := config[key]; !ok {
+			return fmt.Errorf("'%s' is missing", key)
+		}
+	}
+
+	if len(schema.exactlyOneOf) > 0 {
+		var found []string
+
+		for _, key := range schema.exactlyOneOf {
+			if _, ok := config[key]; ok {
+				found = append(found, key)
+			}
+		}
+
+		if len(found) != 1 {
+			return fmt.Errorf("exactly one of %v must be set, found %v", schema.exactlyOneOf, found)
+		}
+	}
+
+	for key, allowed := range schema.enums {
+		rawValue, ok := config[key]
+		if !ok {
+			continue
+		}
+
+		value, ok := rawValue.(string)
+		if !ok || !slices.Contains(allowed, value) {
+			return fmt.Errorf("'%s' value %v is invalid, allowed values are %v", key, rawValue, allowed)
+		}
+	}
+
+	for key, bounds := range schema.ranges {
+		rawValue, ok := config[key]
+		if !ok {
+			continue
+		}
+
+		value, ok := rawValue.(float64)
+		if !ok || value < bounds[0] || value > bounds[1] {
+			return fmt.Errorf("'%s' value %v is invalid, it must be a number between %v and %v",
+				key, rawValue, bounds[0], bounds[1])
+		}
+	}
+
+	for _, key := range schema.cidrs {
+		rawValue, ok := config[key]
+		if !ok {
+			continue
+		}
+
+		value, ok := rawValue.(string)
+		if !ok {
+			return fmt.Errorf("'%s' value %v is invalid, it must be a string", key, rawValue)
+		}
+
+		if err := validateCIDRList(value); err != nil {
+			return fmt.Errorf("'%s' value is invalid: %w", key, err)
+		}
+	}
+
+	if schema.custom != nil {
+		return schema.custom(config)
+	}
+
+	return nil
+}
+
+func validateTuningSysctl(config map[string]interface{}) error {
+	rawSysctl, ok := config["sysctl"]
+	if !ok {
+		return nil
+	}
+
+	sysctl, ok := rawSysctl.(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("'sysctl' must be an object")
+	}
+
+	for key := range sysctl {
+		if !strings.HasPrefix(key, "net.") {
+			return fmt.Errorf("sysctl %s is not allowed, only net.* sysctls are supported", key)
+		}
+	}
+
+	return nil
+}
+
+func validateWhereaboutsRanges(config map[string]interface{}) error {
+	var ranges []string
+
+	if rawRange, ok := config["range"].(string); ok {
+		ranges = append(ranges, rawRange)
+	}
+
+	if rawIPRanges, ok := config["ipRanges"].([]interface{}); ok {
+		for _, rawIPRange := range rawIPRanges {
+			ipRange, ok := rawIPRange.(map[string]interface{})
+			if !ok {
+				return fmt.Errorf("'ipRanges' items must be objects")
+			}
+
+			if rawRange, ok := ipRange["range"].(string); ok {
+				ranges = append(ranges, rawRange)
+			}
+		}
+	}
+
+	if len(ranges) == 0 {
+		return fmt.Errorf("either 'range' or 'ipRanges' must be set")
+	}
+
+	for _, ipRange := range ranges {
+		// whereabouts also accepts ranges in the form firstIP-lastIP/prefix.
+		cidr := ipRange
+		if dashIndex, slashIndex := strings.Index(ipRange, "-"), strings.Index(ipRange, "/"); dashIndex != -1 &&
+			slashIndex > dashIndex {
+			cidr = ipRange[:dashIndex] + ipRange[slashIndex:]
+		}
+
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			return fmt.Errorf("range %s is not a valid CIDR", ipRange)
+		}
+	}
+
+	return nil
+}
+
+func validateCIDRList(cidrs string) error {
+	for _, cidr := range strings.Split(cidrs, ",") {
+		cidr = strings.TrimSpace(cidr)
+
+		// ovn-k8s-cni-overlay subnets may define a host prefix length in the form cidr/hostPrefix.
+		if strings.Count(cidr, "/") == 2 {
+			cidr = cidr[:strings.LastIndex(cidr, "/")]
+		}
+
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			return fmt.Errorf("%s is not a valid CIDR", cidr)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,314 @@
+package nad
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/openshift-kni/eco-goinfra/pkg/clients"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	testMacVlanConfig = `{"cniVersion":"0.4.0","name":"macvlan-net","type":"macvlan","master":"ens1f0",` +
+		`"mode":"bridge","ipam":{"type":"whereabouts","range":"192.168.10.0/24"}}`
+	testChainConfig = `{"cniVersion":"0.4.0","name":"bridge-net","plugins":[` +
+		`{"type":"bridge","bridge":"br0","vlan":100,"mtu":1500,"ipam":{"type":"dhcp"}},` +
+		`{"type":"tuning","sysctl":{"net.ipv4.conf.IFNAME.arp_notify":"1"}}]}`
+	testSriovChainConfig = `{"cniVersion":"0.3.1","name":"sriov-net","plugins":[` +
+		`{"type":"sriov","deviceID":"1017","vlan":100,"vlanQoS":3,"spoofchk":"on","trust":"off",` +
+		`"link_state":"enable","min_tx_rate":10,"max_tx_rate":100,"ipam":{"type":"static"}},` +
+		`{"type":"host-device","device":"ens1f1","pciBusID":"0000:3b:00.1"},` +
+		`{"type":"ovn-k8s-cni-overlay","topology":"localnet","netAttachDefName":"test-ns/localnet",` +
+		`"role":"secondary","subnets":"10.100.0.0/24","physicalNetworkName":"physnet","vlanID":200},` +
+		`{"type":"vlan","master":"ens1f0","vlanId":300}]}`
+)
+
+func TestParseConfig(t *testing.T) {
+	testCases := []struct {
+		config        string
+		expectedType  string
+		expectedChain bool
+		expectedError error
+	}{
+		{
+			config:        testMacVlanConfig,
+			expectedType:  "macvlan",
+			expectedChain: false,
+			expectedError: nil,
+		},
+		{
+			config:        testChainConfig,
+			expectedType:  "",
+			expectedChain: true,
+			expectedError: nil,
+		},
+		{
+			config:        "",
+			expectedError: fmt.Errorf("failed to parse NAD config, 'config' parameter is empty"),
+		},
+		{
+			config:        `{"name":"no-type"}`,
+			expectedError: fmt.Errorf("failed to parse NAD config, config has neither 'type' nor 'plugins'"),
+		},
+	}
+
+	for _, testCase := range testCases {
+		masterPlugin, err := ParseConfig(testCase.config)
+		assert.Equal(t, testCase.expectedError, err)
+
+		if testCase.expectedError == nil {
+			assert.Equal(t, testCase.expectedType, masterPlugin.Type)
+			assert.Equal(t, testCase.expectedChain, masterPlugin.Plugins != nil)
+		}
+	}
+
+	masterPlugin, err := ParseConfig(testChainConfig)
+	assert.Nil(t, err)
+	assert.Len(t, *masterPlugin.Plugins, 2)
+	assert.Equal(t, "100", (*masterPlugin.Plugins)[0].Vlan)
+	assert.Equal(t, "1500", (*masterPlugin.Plugins)[0].Mtu)
+	assert.Equal(t, "dhcp", (*masterPlugin.Plugins)[0].Ipam.Type)
+
+	_, err = ParseConfig("{")
+	assert.NotNil(t, err)
+}
+
+func TestParseConfigChainRoundTrip(t *testing.T) {
+	masterPlugin, err := ParseConfig(testSriovChainConfig)
+	assert.Nil(t, err)
+	assert.Len(t, *masterPlugin.Plugins, 4)
+
+	sriovPlugin := (*masterPlugin.Plugins)[0]
+	assert.Equal(t, "1017", sriovPlugin.DeviceID)
+	assert.Equal(t, "100", sriovPlugin.Vlan)
+	assert.Equal(t, 3, sriovPlugin.VlanQoS)
+	assert.Equal(t, "on", sriovPlugin.SpoofChk)
+	assert.Equal(t, 100, *sriovPlugin.MaxTxRate)
+
+	hostDevicePlugin := (*masterPlugin.Plugins)[1]
+	assert.Equal(t, "ens1f1", hostDevicePlugin.Device)
+	assert.Equal(t, "0000:3b:00.1", hostDevicePlugin.PCIBusID)
+
+	ovnOverlayPlugin := (*masterPlugin.Plugins)[2]
+	assert.Equal(t, "localnet", ovnOverlayPlugin.Topology)
+	assert.Equal(t, "physnet", ovnOverlayPlugin.PhysicalNetworkName)
+	assert.Equal(t, uint16(200), ovnOverlayPlugin.VlanID)
+
+	assert.Equal(t, uint16(300), (*masterPlugin.Plugins)[3].VlanID)
+
+	marshaledConfig, err := json.Marshal(masterPlugin)
+	assert.Nil(t, err)
+	assert.Contains(t, string(marshaledConfig), `"vlanID":200`)
+	assert.Contains(t, string(marshaledConfig), `"vlanId":300`)
+
+	roundTripPlugin, err := ParseConfig(string(marshaledConfig))
+	assert.Nil(t, err)
+	assert.Equal(t, masterPlugin, roundTripPlugin)
+}
+
+func TestMasterPluginVlanIDKey(t *testing.T) {
+	testCases := []struct {
+		pluginType  string
+		expectedKey string
+		absentKey   string
+	}{
+		{
+			pluginType:  "vlan",
+			expectedKey: `"vlanId":100`,
+			absentKey:   `"vlanID"`,
+		},
+		{
+			pluginType:  "ovn-k8s-cni-overlay",
+			expectedKey: `"vlanID":100`,
+			absentKey:   `"vlanId"`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		marshaledConfig, err := json.Marshal(MasterPlugin{Type: testCase.pluginType, VlanID: 100})
+		assert.Nil(t, err)
+		assert.Contains(t, string(marshaledConfig), testCase.expectedKey)
+		assert.NotContains(t, string(marshaledConfig), testCase.absentKey)
+
+		masterPlugin, err := ParseConfig(string(marshaledConfig))
+		assert.Nil(t, err)
+		assert.Equal(t, uint16(100), masterPlugin.VlanID)
+	}
+}
+
+func TestValidateConfig(t *testing.T) {
+	testCases := []struct {
+		config        string
+		expectedError error
+	}{
+		{
+			config:        testMacVlanConfig,
+			expectedError: nil,
+		},
+		{
+			config:        testChainConfig,
+			expectedError: nil,
+		},
+		{
+			config:        `{"name":"custom","type":"custom-plugin","anything":1}`,
+			expectedError: nil,
+		},
+		{
+			config:        `{"type":"macvlan"}`,
+			expectedError: fmt.Errorf("invalid NAD config: 'name' is missing"),
+		},
+		{
+			config:        `{"name":"net"}`,
+			expectedError: fmt.Errorf("invalid NAD config: 'type' is missing"),
+		},
+		{
+			config: `{"name":"net","type":"macvlan","mode":"invalid"}`,
+			expectedError: fmt.Errorf("invalid macvlan plugin config: 'mode' value invalid is invalid, " +
+				"allowed values are [bridge passthru private vepa]"),
+		},
+		{
+			config:        `{"name":"net","type":"vlan","master":"ens1f0"}`,
+			expectedError: fmt.Errorf("invalid vlan plugin config: 'vlanId' is missing"),
+		},
+		{
+			config: `{"name":"net","type":"sriov","vlan":4095}`,
+			expectedError: fmt.Errorf(
+				"invalid sriov plugin config: 'vlan' value 4095 is invalid, it must be a number between 0 and 4094"),
+		},
+		{
+			config: `{"name":"net","type":"host-device","device":"ens1f0","pciBusID":"0000:3b:00.0"}`,
+			expectedError: fmt.Errorf("invalid host-device plugin config: exactly one of " +
+				"[device hwaddr kernelpath pciBusID] must be set, found [device pciBusID]"),
+		},
+		{
+			config: `{"name":"net","plugins":[{"type":"tuning","sysctl":{"kernel.shmmax":"1"}}]}`,
+			expectedError: fmt.Errorf("plugin 0: invalid tuning plugin config: " +
+				"sysctl kernel.shmmax is not allowed, only net.* sysctls are supported"),
+		},
+		{
+			config:        `{"name":"net","plugins":[]}`,
+			expectedError: fmt.Errorf("invalid NAD config: 'plugins' must be a non-empty list"),
+		},
+		{
+			config:        `{"name":"net","type":"macvlan","ipam":{"type":"whereabouts","range":"192.168.10.0/33"}}`,
+			expectedError: fmt.Errorf("invalid whereabouts ipam config: range 192.168.10.0/33 is not a valid CIDR"),
+		},
+		{
+			config:        `{"name":"net","type":"macvlan","ipam":{"type":"whereabouts"}}`,
+			expectedError: fmt.Errorf("invalid whereabouts ipam config: either 'range' or 'ipRanges' must be set"),
+		},
+		{
+			config: `{"name":"net","type":"ipvlan","ipam":{"type":"whereabouts",` +
+				`"ipRanges":[{"range":"192.168.10.10-192.168.10.20/24"},{"range":"2001:db8::/64"}]}}`,
+			expectedError: nil,
+		},
+		{
+			config:        `{"name":"net","type":"macvlan","ipam":{"range":"192.168.10.0/24"}}`,
+			expectedError: fmt.Errorf("invalid macvlan plugin config: ipam 'type' is missing"),
+		},
+		{
+			config: `{"name":"net","type":"ovn-k8s-cni-overlay","topology":"layer2",` +
+				`"netAttachDefName":"ns/net","subnets":"10.100.0.0/16/24,fd00::/64"}`,
+			expectedError: nil,
+		},
+		{
+			config: `{"name":"net","type":"ovn-k8s-cni-overlay","topology":"layer2",` +
+				`"netAttachDefName":"ns/net","subnets":"10.100.0.0"}`,
+			expectedError: fmt.Errorf(
+				"invalid ovn-k8s-cni-overlay plugin config: 'subnets' value is invalid: 10.100.0.0 is not a valid CIDR"),
+		},
+	}
+
+	for _, testCase := range testCases {
+		err := ValidateConfig(testCase.config)
+
+		if testCase.expectedError == nil {
+			assert.Nil(t, err)
+		} else {
+			assert.EqualError(t, err, testCase.expectedError.Error())
+		}
+	}
+
+	assert.NotNil(t, ValidateConfig("not-json"))
+}
+
+func TestNADGetConfig(t *testing.T) {
+	testCases := []struct {
+		config        string
+		client        bool
+		expectedError error
+	}{
+		{
+			config:        testMacVlanConfig,
+			client:        true,
+			expectedError: nil,
+		},
+		{
+			config:        "",
+			client:        true,
+			expectedError: fmt.Errorf("failed to parse NAD config, 'config' parameter is empty"),
+		},
+		{
+			config:        testMacVlanConfig,
+			client:        false,
+			expectedError: fmt.Errorf("NetworkAttachmentDefinition builder cannot have nil apiClient"),
+		},
+	}
+
+	for _, testCase := range testCases {
+		testBuilder := buildTestBuilderWithConfig(testCase.client, testCase.config)
+
+		masterPlugin, err := testBuilder.GetConfig()
+		assert.Equal(t, testCase.expectedError, err)
+
+		if testCase.expectedError == nil {
+			assert.Equal(t, "macvlan-net", masterPlugin.Name)
+			assert.Equal(t, "ens1f0", masterPlugin.Master)
+		}
+	}
+}
+
+func TestNADValidateConfig(t *testing.T) {
+	testCases := []struct {
+		config        string
+		client        bool
+		expectedError error
+	}{
+		{
+			config:        testChainConfig,
+			client:        true,
+			expectedError: nil,
+		},
+		{
+			config:        "",
+			client:        true,
+			expectedError: fmt.Errorf("NetworkAttachmentDefinition test-nad has empty config"),
+		},
+		{
+			config:        testChainConfig,
+			client:        false,
+			expectedError: fmt.Errorf("NetworkAttachmentDefinition builder cannot have nil apiClient"),
+		},
+	}
+
+	for _, testCase := range testCases {
+		testBuilder := buildTestBuilderWithConfig(testCase.client, testCase.config)
+
+		err := testBuilder.ValidateConfig()
+		assert.Equal(t, testCase.expectedError, err)
+	}
+}
+
+func buildTestBuilderWithConfig(client bool, config string) *Builder {
+	var testSettings *clients.Settings
+
+	if client {
+		testSettings = clients.GetTestClients(clients.TestClientParams{})
+	}
+
+	testBuilder := NewBuilder(testSettings, "test-nad", "test-namespace")
+	testBuilder.Definition.Spec.Config = config
+
+	return testBuilder
+}
@@ -5,6 +5,11 @@ func IPAMStatic() *IPAM {
 	return &IPAM{Type: "static"}
 }
 
+// IPAMDhcp returns dhcp ipam type. It requires the dhcp daemon to run on the nodes.
+func IPAMDhcp() *IPAM {
+	return &IPAM{Type: "dhcp"}
+}
+
 // IPAMWhereAbouts returns WhereAbout ipam type.
 func IPAMWhereAbouts(ipRange, gateway string) *IPAM {
 	if ipRange == "" {
@@ -2,6 +2,8 @@ package nad
 
 import (
 	"fmt"
+	"net"
+	"strings"
 
 	"github.com/golang/glog"
 	"github.com/openshift-kni/eco-goinfra/pkg/msg"
@@ -541,3 +543,468 @@ func (plugin *MasterBondPlugin) GetMasterPluginConfig() (*MasterPlugin, error) {
 
 	return plugin.masterPlugin, nil
 }
+
+// MasterSriovPlugin provides struct for MasterPlugin set to sriov in NetworkAttachmentDefinition.
+type MasterSriovPlugin struct {
+	masterPlugin *MasterPlugin
+	errorMsg     string
+}
+
+// NewMasterSriovPlugin creates new instance of MasterSriovPlugin. The VF is allocated through the
+// k8s.v1.cni.cncf.io/resourceName annotation of the NetworkAttachmentDefinition.
+func NewMasterSriovPlugin(name string) *MasterSriovPlugin {
+	glog.V(100).Infof("Initializing new MasterSriovPlugin structure %s", name)
+
+	builder := MasterSriovPlugin{
+		masterPlugin: &MasterPlugin{
+			CniVersion: "0.3.1",
+			Name:       name,
+			Type:       "sriov",
+		},
+	}
+
+	if builder.masterPlugin.Name == "" {
+		glog.V(100).Infof("error MasterSriovPlugin name can not be empty")
+
+		builder.errorMsg = "MasterSriovPlugin name is empty"
+	}
+
+	return &builder
+}
+
+// WithVlan defines the VF vlan and vlan QoS to MasterSriovPlugin. Default is 0.
+func (plugin *MasterSriovPlugin) WithVlan(vlanID uint16, vlanQoS uint8) *MasterSriovPlugin {
+	glog.V(100).Infof("Adding vlan %d with QoS %d to MasterSriovPlugin", vlanID, vlanQoS)
+
+	if vlanID > 4094 {
+		glog.V(100).Infof("error vlan id can not be greater than 4094")
+
+		plugin.errorMsg = "MasterSriovPlugin vlanID is greater than 4094"
+	}
+
+	if vlanQoS > 7 {
+		glog.V(100).Infof("error vlan QoS can not be greater than 7")
+
+		plugin.errorMsg = "MasterSriovPlugin vlanQoS is greater than 7"
+	}
+
+	if plugin.errorMsg != "" {
+		return plugin
+	}
+
+	plugin.masterPlugin.Vlan = int(vlanID)
+	plugin.masterPlugin.VlanQoS = int(vlanQoS)
+
+	return plugin
+}
+
+// WithSpoofChk defines the VF spoof checking to MasterSriovPlugin.
+func (plugin *MasterSriovPlugin) WithSpoofChk(enabled bool) *MasterSriovPlugin {
+	glog.V(100).Infof("Adding spoofchk %t to MasterSriovPlugin", enabled)
+
+	if plugin.errorMsg != "" {
+		return plugin
+	}
+
+	plugin.masterPlugin.SpoofChk = onOff(enabled)
+
+	return plugin
+}
+
+// WithTrust defines the VF trust mode to MasterSriovPlugin.
+func (plugin *MasterSriovPlugin) WithTrust(enabled bool) *MasterSriovPlugin {
+	glog.V(100).Infof("Adding trust %t to MasterSriovPlugin", enabled)
+
+	if plugin.errorMsg != "" {
+		return plugin
+	}
+
+	plugin.masterPlugin.Trust = onOff(enabled)
+
+	return plugin
+}
+
+// WithLinkState defines the VF link state to MasterSriovPlugin.
+func (plugin *MasterSriovPlugin) WithLinkState(linkState string) *MasterSriovPlugin {
+	glog.V(100).Infof("Adding link state %s to MasterSriovPlugin", linkState)
+
+	if !slices.Contains([]string{"auto", "enable", "disable"}, linkState) {
+		glog.V(100).Infof("error to add link state %s, allowed values are auto, enable and disable", linkState)
+
+		plugin.errorMsg = "invalid linkState parameter"
+	}
+
+	if plugin.errorMsg != "" {
+		return plugin
+	}
+
+	plugin.masterPlugin.LinkState = linkState
+
+	return plugin
+}
+
+// WithTxRate defines the VF minimum and maximum transmit rate in Mbps to MasterSriovPlugin. 0 means no limit.
+func (plugin *MasterSriovPlugin) WithTxRate(minTxRate, maxTxRate int) *MasterSriovPlugin {
+	glog.V(100).Infof("Adding min tx rate %d and max tx rate %d to MasterSriovPlugin", minTxRate, maxTxRate)
+
+	if minTxRate < 0 || maxTxRate < 0 {
+		glog.V(100).Infof("error tx rates can not be negative")
+
+		plugin.errorMsg = "MasterSriovPlugin tx rate is negative"
+	}
+
+	if maxTxRate != 0 && minTxRate > maxTxRate {
+		glog.V(100).Infof("error min tx rate can not be greater than max tx rate")
+
+		plugin.errorMsg = "MasterSriovPlugin minTxRate is greater than maxTxRate"
+	}
+
+	if plugin.errorMsg != "" {
+		return plugin
+	}
+
+	plugin.masterPlugin.MinTxRate = &minTxRate
+	plugin.masterPlugin.MaxTxRate = &maxTxRate
+
+	return plugin
+}
+
+// WithIPAM defines IPAM configuration to MasterSriovPlugin. Default is empty.
+func (plugin *MasterSriovPlugin) WithIPAM(ipam *IPAM) *MasterSriovPlugin {
+	glog.V(100).Infof("Adding IPAM configuration %v to MasterSriovPlugin", ipam)
+
+	if ipam == nil {
+		glog.V(100).Infof("error adding empty ipam to MasterSriovPlugin")
+
+		plugin.errorMsg = invalidIpamParameterMsg
+	}
+
+	if plugin.errorMsg != "" {
+		return plugin
+	}
+
+	plugin.masterPlugin.Ipam = ipam
+
+	return plugin
+}
+
+// GetMasterPluginConfig returns master plugin if error does not occur.
+func (plugin *MasterSriovPlugin) GetMasterPluginConfig() (*MasterPlugin, error) {
+	if plugin.errorMsg != "" {
+		return nil, fmt.Errorf("error to build MasterPlugin config due to :%s", plugin.errorMsg)
+	}
+
+	return plugin.masterPlugin, nil
+}
+
+// MasterHostDevicePlugin provides struct for MasterPlugin set to host-device in NetworkAttachmentDefinition.
+type MasterHostDevicePlugin struct {
+	masterPlugin *MasterPlugin
+	errorMsg     string
+}
+
+// NewMasterHostDevicePlugin creates new instance of MasterHostDevicePlugin. Exactly one of the device, hwaddr,
+// kernelpath or pciBusID options has to be set.
+func NewMasterHostDevicePlugin(name string) *MasterHostDevicePlugin {
+	glog.V(100).Infof("Initializing new MasterHostDevicePlugin structure %s", name)
+
+	builder := MasterHostDevicePlugin{
+		masterPlugin: &MasterPlugin{
+			CniVersion: "0.3.1",
+			Name:       name,
+			Type:       "host-device",
+		},
+	}
+
+	if builder.masterPlugin.Name == "" {
+		glog.V(100).Infof("error MasterHostDevicePlugin name can not be empty")
+
+		builder.errorMsg = "MasterHostDevicePlugin name is empty"
+	}
+
+	return &builder
+}
+
+// WithDevice defines the name of the host device moved to the pod to MasterHostDevicePlugin.
+func (plugin *MasterHostDevicePlugin) WithDevice(device string) *MasterHostDevicePlugin {
+	glog.V(100).Infof("Adding device %s to MasterHostDevicePlugin", device)
+
+	plugin.validateDeviceSelector("device", device)
+
+	if plugin.errorMsg != "" {
+		return plugin
+	}
+
+	plugin.masterPlugin.Device = device
+
+	return plugin
+}
+
+// WithHWAddr defines the MAC address of the host device moved to the pod to MasterHostDevicePlugin.
+func (plugin *MasterHostDevicePlugin) WithHWAddr(hwAddr string) *MasterHostDevicePlugin {
+	glog.V(100).Infof("Adding hwaddr %s to MasterHostDevicePlugin", hwAddr)
+
+	plugin.validateDeviceSelector("hwaddr", hwAddr)
+
+	if _, err := net.ParseMAC(hwAddr); err != nil && plugin.errorMsg == "" {
+		glog.V(100).Infof("error hwaddr %s is not a valid MAC address", hwAddr)
+
+		plugin.errorMsg = "invalid hwaddr parameter"
+	}
+
+	if plugin.errorMsg != "" {
+		return plugin
+	}
+
+	plugin.masterPlugin.HWAddr = hwAddr
+
+	return plugin
+}
+
+// WithKernelPath defines the kernel device path of the host device moved to the pod to MasterHostDevicePlugin.
+func (plugin *MasterHostDevicePlugin) WithKernelPath(kernelPath string) *MasterHostDevicePlugin {
+	glog.V(100).Infof("Adding kernelpath %s to MasterHostDevicePlugin", kernelPath)
+
+	plugin.validateDeviceSelector("kernelpath", kernelPath)
+
+	if plugin.errorMsg != "" {
+		return plugin
+	}
+
+	plugin.masterPlugin.KernelPath = kernelPath
+
+	return plugin
+}
+
+// WithPCIBusID defines the PCI address of the host device moved to the pod to MasterHostDevicePlugin.
+func (plugin *MasterHostDevicePlugin) WithPCIBusID(pciBusID string) *MasterHostDevicePlugin {
+	glog.V(100).Infof("Adding pciBusID %s to MasterHostDevicePlugin", pciBusID)
+
+	plugin.validateDeviceSelector("pciBusID", pciBusID)
+
+	if plugin.errorMsg != "" {
+		return plugin
+	}
+
+	plugin.masterPlugin.PCIBusID = pciBusID
+
+	return plugin
+}
+
+// WithIPAM defines IPAM configuration to MasterHostDevicePlugin. Default is empty.
+func (plugin *MasterHostDevicePlugin) WithIPAM(ipam *IPAM) *MasterHostDevicePlugin {
+	glog.V(100).Infof("Adding IPAM configuration %v to MasterHostDevicePlugin", ipam)
+
+	if ipam == nil {
+		glog.V(100).Infof("error adding empty ipam to MasterHostDevicePlugin")
+
+		plugin.errorMsg = invalidIpamParameterMsg
+	}
+
+	if plugin.errorMsg != "" {
+		return plugin
+	}
+
+	plugin.masterPlugin.Ipam = ipam
+
+	return plugin
+}
+
+// GetMasterPluginConfig returns master plugin if error does not occur.
+func (plugin *MasterHostDevicePlugin) GetMasterPluginConfig() (*MasterPlugin, error) {
+	if plugin.errorMsg != "" {
+		return nil, fmt.Errorf("error to build MasterPlugin config due to :%s", plugin.errorMsg)
+	}
+
+	if !plugin.hasDeviceSelector() {
+		return nil, fmt.Errorf("error to build MasterPlugin config due to :" +
+			"one of device, hwaddr, kernelpath or pciBusID has to be set")
+	}
+
+	return plugin.masterPlugin, nil
+}
+
+// validateDeviceSelector checks the value of a host device selector and that no other selector is already set.
+func (plugin *MasterHostDevicePlugin) validateDeviceSelector(selector, value string) {
+	if value == "" {
+		glog.V(100).Infof("error to add %s, the value can not be empty", selector)
+
+		plugin.errorMsg = fmt.Sprintf("invalid %s parameter", selector)
+
+		return
+	}
+
+	if plugin.hasDeviceSelector() {
+		glog.V(100).Infof("error to add %s, host device is already selected", selector)
+
+		plugin.errorMsg = "only one of device, hwaddr, kernelpath or pciBusID can be set"
+	}
+}
+
+func (plugin *MasterHostDevicePlugin) hasDeviceSelector() bool {
+	return plugin.masterPlugin.Device != "" || plugin.masterPlugin.HWAddr != "" ||
+		plugin.masterPlugin.KernelPath != "" || plugin.masterPlugin.PCIBusID != ""
+}
+
+// MasterOvnK8sCniOverlayPlugin provides struct for MasterPlugin set to ovn-k8s-cni-overlay in
+// NetworkAttachmentDefinition.
+type MasterOvnK8sCniOverlayPlugin struct {
+	masterPlugin *MasterPlugin
+	errorMsg     string
+}
+
+// NewMasterOvnK8sCniOverlayPlugin creates new instance of MasterOvnK8sCniOverlayPlugin for a secondary network with
+// layer2 or localnet topology. The nadNsName is the NetworkAttachmentDefinition in the form namespace/name.
+func NewMasterOvnK8sCniOverlayPlugin(name, nadNsName, topology string) *MasterOvnK8sCniOverlayPlugin {
+	glog.V(100).Infof("Initializing new MasterOvnK8sCniOverlayPlugin structure %s for %s with topology %s",
+		name, nadNsName, topology)
+
+	builder := MasterOvnK8sCniOverlayPlugin{
+		masterPlugin: &MasterPlugin{
+			CniVersion: "0.3.1",
+			Name:       name,
+			Type:       ovnOverlayPluginType,
+			OvnOverlayConfig: OvnOverlayConfig{
+				Topology:         topology,
+				NetAttachDefName: nadNsName,
+			},
+		},
+	}
+
+	if !slices.Contains([]string{"layer2", "localnet"}, topology) {
+		glog.V(100).Infof("error topology %s is not supported, allowed values are layer2 and localnet", topology)
+
+		builder.errorMsg = "MasterOvnK8sCniOverlayPlugin topology is invalid"
+	}
+
+	if nsName := strings.Split(nadNsName, "/"); len(nsName) != 2 || nsName[0] == "" || nsName[1] == "" {
+		glog.V(100).Infof("error nadNsName %s is not in the form namespace/name", nadNsName)
+
+		builder.errorMsg = "MasterOvnK8sCniOverlayPlugin nadNsName is invalid"
+	}
+
+	if builder.masterPlugin.Name == "" {
+		glog.V(100).Infof("error MasterOvnK8sCniOverlayPlugin name can not be empty")
+
+		builder.errorMsg = "MasterOvnK8sCniOverlayPlugin name is empty"
+	}
+
+	return &builder
+}
+
+// WithSubnets defines the subnets the pod IPs are allocated from to MasterOvnK8sCniOverlayPlugin.
+func (plugin *MasterOvnK8sCniOverlayPlugin) WithSubnets(subnets []string) *MasterOvnK8sCniOverlayPlugin {
+	glog.V(100).Infof("Adding subnets %v to MasterOvnK8sCniOverlayPlugin", subnets)
+
+	if len(subnets) == 0 || validateCIDRList(strings.Join(subnets, ",")) != nil {
+		glog.V(100).Infof("error subnets %v are not valid CIDRs", subnets)
+
+		plugin.errorMsg = "invalid subnets parameter"
+	}
+
+	if plugin.errorMsg != "" {
+		return plugin
+	}
+
+	plugin.masterPlugin.Subnets = strings.Join(subnets, ",")
+
+	return plugin
+}
+
+// WithExcludeSubnets defines the subnets excluded from the pod IP allocation to MasterOvnK8sCniOverlayPlugin.
+func (plugin *MasterOvnK8sCniOverlayPlugin) WithExcludeSubnets(subnets []string) *MasterOvnK8sCniOverlayPlugin {
+	glog.V(100).Infof("Adding exclude subnets %v to MasterOvnK8sCniOverlayPlugin", subnets)
+
+	if len(subnets) == 0 || validateCIDRList(strings.Join(subnets, ",")) != nil {
+		glog.V(100).Infof("error exclude subnets %v are not valid CIDRs", subnets)
+
+		plugin.errorMsg = "invalid excludeSubnets parameter"
+	}
+
+	if plugin.errorMsg != "" {
+		return plugin
+	}
+
+	plugin.masterPlugin.ExcludeSubnets = strings.Join(subnets, ",")
+
+	return plugin
+}
+
+// WithMTU defines the MTU of the network to MasterOvnK8sCniOverlayPlugin.
+func (plugin *MasterOvnK8sCniOverlayPlugin) WithMTU(mtu int) *MasterOvnK8sCniOverlayPlugin {
+	glog.V(100).Infof("Adding mtu %d to MasterOvnK8sCniOverlayPlugin", mtu)
+
+	if mtu < 576 || mtu > 65536 {
+		glog.V(100).Infof("error mtu %d is out of range 576-65536", mtu)
+
+		plugin.errorMsg = "invalid mtu parameter"
+	}
+
+	if plugin.errorMsg != "" {
+		return plugin
+	}
+
+	plugin.masterPlugin.Mtu = mtu
+
+	return plugin
+}
+
+// WithLocalnetVlan defines the vlan and the physical network name of a localnet MasterOvnK8sCniOverlayPlugin.
+// The physical network name has to match a bridge mapping on the nodes and defaults to the plugin name if empty.
+func (plugin *MasterOvnK8sCniOverlayPlugin) WithLocalnetVlan(
+	vlanID uint16, physicalNetworkName string) *MasterOvnK8sCniOverlayPlugin {
+	glog.V(100).Infof("Adding vlan %d and physical network %s to MasterOvnK8sCniOverlayPlugin",
+		vlanID, physicalNetworkName)
+
+	if plugin.masterPlugin.Topology != "localnet" {
+		glog.V(100).Infof("error vlan is only supported with localnet topology")
+
+		plugin.errorMsg = "vlan is only supported with localnet topology"
+	}
+
+	if vlanID == 0 || vlanID > 4094 {
+		glog.V(100).Infof("error vlan id %d is out of range 1-4094", vlanID)
+
+		plugin.errorMsg = "invalid vlanID parameter"
+	}
+
+	if plugin.errorMsg != "" {
+		return plugin
+	}
+
+	plugin.masterPlugin.VlanID = vlanID
+	plugin.masterPlugin.PhysicalNetworkName = physicalNetworkName
+
+	return plugin
+}
+
+// WithAllowPersistentIPs allows KubeVirt VMs to keep their IPs across restarts and migrations in
+// MasterOvnK8sCniOverlayPlugin.
+func (plugin *MasterOvnK8sCniOverlayPlugin) WithAllowPersistentIPs() *MasterOvnK8sCniOverlayPlugin {
+	glog.V(100).Infof("Adding allowPersistentIPs to MasterOvnK8sCniOverlayPlugin")
+
+	if plugin.errorMsg != "" {
+		return plugin
+	}
+
+	plugin.masterPlugin.AllowPersistentIPs = true
+
+	return plugin
+}
+
+// GetMasterPluginConfig returns master plugin if error does not occur.
+func (plugin *MasterOvnK8sCniOverlayPlugin) GetMasterPluginConfig() (*MasterPlugin, error) {
+	if plugin.errorMsg != "" {
+		return nil, fmt.Errorf("error to build MasterPlugin config due to :%s", plugin.errorMsg)
+	}
+
+	return plugin.masterPlugin, nil
+}
+
+func onOff(enabled bool) string {
+	if enabled {
+		return "on"
+	}
+
+	return "off"
+}
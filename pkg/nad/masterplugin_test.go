@@ -0,0 +1,159 @@
+package nad
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMasterSriovPlugin(t *testing.T) {
+	masterPlugin, err := NewMasterSriovPlugin("sriov-net").
+		WithVlan(100, 3).WithSpoofChk(false).WithTrust(true).WithLinkState("enable").WithTxRate(10, 100).
+		WithIPAM(IPAMDhcp()).GetMasterPluginConfig()
+	assert.Nil(t, err)
+	assert.Equal(t, "sriov", masterPlugin.Type)
+	assert.Equal(t, 100, masterPlugin.Vlan)
+	assert.Equal(t, 3, masterPlugin.VlanQoS)
+	assert.Equal(t, "off", masterPlugin.SpoofChk)
+	assert.Equal(t, "on", masterPlugin.Trust)
+	assert.Equal(t, "enable", masterPlugin.LinkState)
+	assert.Equal(t, 10, *masterPlugin.MinTxRate)
+	assert.Equal(t, 100, *masterPlugin.MaxTxRate)
+	assert.Equal(t, "dhcp", masterPlugin.Ipam.Type)
+
+	testCases := []struct {
+		plugin        *MasterSriovPlugin
+		expectedError string
+	}{
+		{
+			plugin:        NewMasterSriovPlugin(""),
+			expectedError: "MasterSriovPlugin name is empty",
+		},
+		{
+			plugin:        NewMasterSriovPlugin("sriov-net").WithVlan(4095, 0),
+			expectedError: "MasterSriovPlugin vlanID is greater than 4094",
+		},
+		{
+			plugin:        NewMasterSriovPlugin("sriov-net").WithVlan(100, 8),
+			expectedError: "MasterSriovPlugin vlanQoS is greater than 7",
+		},
+		{
+			plugin:        NewMasterSriovPlugin("sriov-net").WithLinkState("up"),
+			expectedError: "invalid linkState parameter",
+		},
+		{
+			plugin:        NewMasterSriovPlugin("sriov-net").WithTxRate(100, 10),
+			expectedError: "MasterSriovPlugin minTxRate is greater than maxTxRate",
+		},
+		{
+			plugin:        NewMasterSriovPlugin("sriov-net").WithIPAM(nil),
+			expectedError: invalidIpamParameterMsg,
+		},
+	}
+
+	for _, testCase := range testCases {
+		_, err := testCase.plugin.GetMasterPluginConfig()
+		assert.Equal(t, fmt.Errorf("error to build MasterPlugin config due to :%s", testCase.expectedError), err)
+	}
+}
+
+func TestMasterHostDevicePlugin(t *testing.T) {
+	masterPlugin, err := NewMasterHostDevicePlugin("hostdev-net").WithPCIBusID("0000:3b:00.1").
+		WithIPAM(IPAMStatic()).GetMasterPluginConfig()
+	assert.Nil(t, err)
+	assert.Equal(t, "host-device", masterPlugin.Type)
+	assert.Equal(t, "0000:3b:00.1", masterPlugin.PCIBusID)
+
+	masterPlugin, err = NewMasterHostDevicePlugin("hostdev-net").WithHWAddr("00:11:22:33:44:55").
+		GetMasterPluginConfig()
+	assert.Nil(t, err)
+	assert.Equal(t, "00:11:22:33:44:55", masterPlugin.HWAddr)
+
+	testCases := []struct {
+		plugin        *MasterHostDevicePlugin
+		expectedError error
+	}{
+		{
+			plugin:        NewMasterHostDevicePlugin(""),
+			expectedError: fmt.Errorf("error to build MasterPlugin config due to :MasterHostDevicePlugin name is empty"),
+		},
+		{
+			plugin: NewMasterHostDevicePlugin("hostdev-net"),
+			expectedError: fmt.Errorf("error to build MasterPlugin config due to :" +
+				"one of device, hwaddr, kernelpath or pciBusID has to be set"),
+		},
+		{
+			plugin: NewMasterHostDevicePlugin("hostdev-net").WithDevice("ens1f0").WithKernelPath("/sys/devices/x"),
+			expectedError: fmt.Errorf("error to build MasterPlugin config due to :" +
+				"only one of device, hwaddr, kernelpath or pciBusID can be set"),
+		},
+		{
+			plugin:        NewMasterHostDevicePlugin("hostdev-net").WithHWAddr("invalid"),
+			expectedError: fmt.Errorf("error to build MasterPlugin config due to :invalid hwaddr parameter"),
+		},
+		{
+			plugin:        NewMasterHostDevicePlugin("hostdev-net").WithDevice(""),
+			expectedError: fmt.Errorf("error to build MasterPlugin config due to :invalid device parameter"),
+		},
+	}
+
+	for _, testCase := range testCases {
+		_, err := testCase.plugin.GetMasterPluginConfig()
+		assert.Equal(t, testCase.expectedError, err)
+	}
+}
+
+func TestMasterOvnK8sCniOverlayPlugin(t *testing.T) {
+	masterPlugin, err := NewMasterOvnK8sCniOverlayPlugin("localnet-net", "test-ns/localnet-net", "localnet").
+		WithSubnets([]string{"10.100.0.0/24", "fd00:100::/64"}).WithExcludeSubnets([]string{"10.100.0.0/29"}).
+		WithMTU(1400).WithLocalnetVlan(200, "physnet").WithAllowPersistentIPs().GetMasterPluginConfig()
+	assert.Nil(t, err)
+	assert.Equal(t, "ovn-k8s-cni-overlay", masterPlugin.Type)
+	assert.Equal(t, "test-ns/localnet-net", masterPlugin.NetAttachDefName)
+	assert.Equal(t, "10.100.0.0/24,fd00:100::/64", masterPlugin.Subnets)
+	assert.Equal(t, "10.100.0.0/29", masterPlugin.ExcludeSubnets)
+	assert.Equal(t, 1400, masterPlugin.Mtu)
+	assert.Equal(t, uint16(200), masterPlugin.VlanID)
+	assert.Equal(t, "physnet", masterPlugin.PhysicalNetworkName)
+	assert.True(t, masterPlugin.AllowPersistentIPs)
+
+	testCases := []struct {
+		plugin        *MasterOvnK8sCniOverlayPlugin
+		expectedError string
+	}{
+		{
+			plugin:        NewMasterOvnK8sCniOverlayPlugin("", "test-ns/net", "layer2"),
+			expectedError: "MasterOvnK8sCniOverlayPlugin name is empty",
+		},
+		{
+			plugin:        NewMasterOvnK8sCniOverlayPlugin("net", "net", "layer2"),
+			expectedError: "MasterOvnK8sCniOverlayPlugin nadNsName is invalid",
+		},
+		{
+			plugin:        NewMasterOvnK8sCniOverlayPlugin("net", "test-ns/net", "layer3"),
+			expectedError: "MasterOvnK8sCniOverlayPlugin topology is invalid",
+		},
+		{
+			plugin:        NewMasterOvnK8sCniOverlayPlugin("net", "test-ns/net", "layer2").WithSubnets([]string{"10.0.0.1"}),
+			expectedError: "invalid subnets parameter",
+		},
+		{
+			plugin:        NewMasterOvnK8sCniOverlayPlugin("net", "test-ns/net", "layer2").WithMTU(100),
+			expectedError: "invalid mtu parameter",
+		},
+		{
+			plugin:        NewMasterOvnK8sCniOverlayPlugin("net", "test-ns/net", "layer2").WithLocalnetVlan(10, ""),
+			expectedError: "vlan is only supported with localnet topology",
+		},
+		{
+			plugin:        NewMasterOvnK8sCniOverlayPlugin("net", "test-ns/net", "localnet").WithLocalnetVlan(0, ""),
+			expectedError: "invalid vlanID parameter",
+		},
+	}
+
+	for _, testCase := range testCases {
+		_, err := testCase.plugin.GetMasterPluginConfig()
+		assert.Equal(t, fmt.Errorf("error to build MasterPlugin config due to :%s", testCase.expectedError), err)
+	}
+}
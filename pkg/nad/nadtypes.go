@@ -40,6 +40,42 @@ type (
 		Group            int               `json:"group,omitempty"`
 		MultiQueue       bool              `json:"multiQueue,omitempty"`
 		SelinuxContext   string            `json:"selinuxcontext,omitempty"`
+		VlanID           uint16            `json:"vlanId,omitempty"`
+		SriovConfig
+		HostDeviceConfig
+		OvnOverlayConfig
+	}
+
+	// SriovConfig contains the sriov plugin specific configuration. The VLAN is held by the Vlan field of the
+	// plugin.
+	SriovConfig struct {
+		DeviceID  string `json:"deviceID,omitempty"`
+		VlanQoS   int    `json:"vlanQoS,omitempty"`
+		SpoofChk  string `json:"spoofchk,omitempty"`
+		Trust     string `json:"trust,omitempty"`
+		LinkState string `json:"link_state,omitempty"`
+		MinTxRate *int   `json:"min_tx_rate,omitempty"`
+		MaxTxRate *int   `json:"max_tx_rate,omitempty"`
+	}
+
+	// HostDeviceConfig contains the host-device plugin specific configuration.
+	HostDeviceConfig struct {
+		Device     string `json:"device,omitempty"`
+		HWAddr     string `json:"hwaddr,omitempty"`
+		KernelPath string `json:"kernelpath,omitempty"`
+		PCIBusID   string `json:"pciBusID,omitempty"`
+	}
+
+	// OvnOverlayConfig contains the ovn-k8s-cni-overlay plugin specific configuration. The localnet VLAN is held by
+	// the VlanID field of the plugin, which is written as vlanID for this plugin type.
+	OvnOverlayConfig struct {
+		Topology            string `json:"topology,omitempty"`
+		NetAttachDefName    string `json:"netAttachDefName,omitempty"`
+		Role                string `json:"role,omitempty"`
+		Subnets             string `json:"subnets,omitempty"`
+		ExcludeSubnets      string `json:"excludeSubnets,omitempty"`
+		PhysicalNetworkName string `json:"physicalNetworkName,omitempty"`
+		AllowPersistentIPs  bool   `json:"allowPersistentIPs,omitempty"`
 	}
 
 	// MasterPlugin contains the master plugin configuration for a NAD.
@@ -60,6 +96,11 @@ type (
 		Mtu              int         `json:"mtu,omitempty"`
 		Links            []Link      `json:"links,omitempty"`
 		Capabilities     *Capability `json:"capabilities,omitempty"`
+		// Vlan is the VLAN of the sriov plugin.
+		Vlan int `json:"vlan,omitempty"`
+		SriovConfig
+		HostDeviceConfig
+		OvnOverlayConfig
 	}
 
 	// IPRanges contains ip range for WhereAbout IPAM plugin.
@@ -0,0 +1,411 @@
+package nad
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math/big"
+	"net"
+	"sort"
+	"strings"
+
+	"github.com/golang/glog"
+	"github.com/openshift-kni/eco-goinfra/pkg/clients"
+	"github.com/openshift-kni/eco-goinfra/pkg/schemes/whereabouts/whereaboutstypes"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// WhereaboutsAllocation describes an IP address reserved by the whereabouts IPAM and the pod owning it.
+type WhereaboutsAllocation struct {
+	IP           string
+	Range        string
+	PodNamespace string
+	PodName      string
+	ContainerID  string
+	IfName       string
+	// PoolName is the IPPool holding the allocation. It is empty for OverlappingRangeIPReservations.
+	PoolName string
+	// key is the allocation offset in the IPPool or the OverlappingRangeIPReservation name.
+	key string
+}
+
+// ListWhereaboutsAllocations returns the IP addresses allocated from the whereabouts IPPools in the given namespace,
+// usually openshift-multus, grouped by range and sorted by IP address.
+func ListWhereaboutsAllocations(
+	apiClient *clients.Settings, whereaboutsNsName string) (map[string][]WhereaboutsAllocation, error) {
+	glog.V(100).Infof("Listing whereabouts allocations in namespace %s", whereaboutsNsName)
+
+	ipPools, err := listIPPools(apiClient, whereaboutsNsName)
+	if err != nil {
+		return nil, err
+	}
+
+	allocations := make(map[string][]WhereaboutsAllocation)
+
+	for _, ipPool := range ipPools {
+		poolAllocations, err := getIPPoolAllocations(ipPool)
+		if err != nil {
+			return nil, err
+		}
+
+		allocations[ipPool.Spec.Range] = append(allocations[ipPool.Spec.Range], poolAllocations...)
+	}
+
+	for ipRange := range allocations {
+		sortWhereaboutsAllocations(allocations[ipRange])
+	}
+
+	return allocations, nil
+}
+
+// ListStaleWhereaboutsAllocations returns the IPPool allocations and OverlappingRangeIPReservations in the given
+// namespace whose owning pods no longer exist.
+func ListStaleWhereaboutsAllocations(
+	apiClient *clients.Settings, whereaboutsNsName string) ([]WhereaboutsAllocation, error) {
+	glog.V(100).Infof("Listing stale whereabouts allocations in namespace %s", whereaboutsNsName)
+
+	allocations, err := listAllWhereaboutsAllocations(apiClient, whereaboutsNsName)
+	if err != nil {
+		return nil, err
+	}
+
+	var staleAllocations []WhereaboutsAllocation
+
+	for _, allocation := range allocations {
+		podExists, err := whereaboutsPodExists(apiClient, allocation)
+		if err != nil {
+			return nil, err
+		}
+
+		if !podExists {
+			glog.V(100).Infof("Allocation of IP %s owned by pod %s/%s is stale",
+				allocation.IP, allocation.PodNamespace, allocation.PodName)
+
+			staleAllocations = append(staleAllocations, allocation)
+		}
+	}
+
+	return staleAllocations, nil
+}
+
+// CleanStaleWhereaboutsAllocations releases the IPPool allocations and deletes the OverlappingRangeIPReservations in
+// the given namespace whose owning pods no longer exist.
+func CleanStaleWhereaboutsAllocations(apiClient *clients.Settings, whereaboutsNsName string) error {
+	glog.V(100).Infof("Cleaning stale whereabouts allocations in namespace %s", whereaboutsNsName)
+
+	staleAllocations, err := ListStaleWhereaboutsAllocations(apiClient, whereaboutsNsName)
+	if err != nil {
+		return err
+	}
+
+	return releaseWhereaboutsAllocations(apiClient, whereaboutsNsName, staleAllocations)
+}
+
+// CleanNamespaceWhereaboutsAllocations releases the IPPool allocations and deletes the OverlappingRangeIPReservations
+// in the whereabouts namespace owned by pods of podNsName. It is meant to be called as part of the podNsName
+// namespace teardown, so the reservations are released even if the pods are still terminating.
+func CleanNamespaceWhereaboutsAllocations(apiClient *clients.Settings, whereaboutsNsName, podNsName string) error {
+	glog.V(100).Infof("Cleaning whereabouts allocations of namespace %s in namespace %s", podNsName, whereaboutsNsName)
+
+	if podNsName == "" {
+		glog.V(100).Infof("The podNsName parameter is empty")
+
+		return fmt.Errorf("failed to clean whereabouts allocations, 'podNsName' parameter is empty")
+	}
+
+	allocations, err := listAllWhereaboutsAllocations(apiClient, whereaboutsNsName)
+	if err != nil {
+		return err
+	}
+
+	var namespaceAllocations []WhereaboutsAllocation
+
+	for _, allocation := range allocations {
+		if allocation.PodNamespace == podNsName {
+			namespaceAllocations = append(namespaceAllocations, allocation)
+		}
+	}
+
+	return releaseWhereaboutsAllocations(apiClient, whereaboutsNsName, namespaceAllocations)
+}
+
+// listAllWhereaboutsAllocations returns both the IPPool allocations and the OverlappingRangeIPReservations.
+func listAllWhereaboutsAllocations(
+	apiClient *clients.Settings, whereaboutsNsName string) ([]WhereaboutsAllocation, error) {
+	ipPools, err := listIPPools(apiClient, whereaboutsNsName)
+	if err != nil {
+		return nil, err
+	}
+
+	var allocations []WhereaboutsAllocation
+
+	for _, ipPool := range ipPools {
+		poolAllocations, err := getIPPoolAllocations(ipPool)
+		if err != nil {
+			return nil, err
+		}
+
+		allocations = append(allocations, poolAllocations...)
+	}
+
+	reservationList := &whereaboutstypes.OverlappingRangeIPReservationList{}
+
+	err = apiClient.List(context.TODO(), reservationList, &client.ListOptions{Namespace: whereaboutsNsName})
+	if err != nil {
+		glog.V(100).Infof("Failed to list OverlappingRangeIPReservations in namespace %s due to %s",
+			whereaboutsNsName, err.Error())
+
+		return nil, err
+	}
+
+	for _, reservation := range reservationList.Items {
+		podNamespace, podName := splitWhereaboutsPodRef(reservation.Spec.PodRef)
+
+		allocations = append(allocations, WhereaboutsAllocation{
+			IP:           whereaboutsReservationNameToIP(reservation.Name),
+			PodNamespace: podNamespace,
+			PodName:      podName,
+			ContainerID:  reservation.Spec.ContainerID,
+			IfName:       reservation.Spec.IfName,
+			key:          reservation.Name,
+		})
+	}
+
+	return allocations, nil
+}
+
+func listIPPools(apiClient *clients.Settings, whereaboutsNsName string) ([]whereaboutstypes.IPPool, error) {
+	if apiClient == nil {
+		glog.V(100).Infof("The apiClient is empty")
+
+		return nil, fmt.Errorf("failed to list whereabouts IPPools, 'apiClient' parameter is empty")
+	}
+
+	if whereaboutsNsName == "" {
+		glog.V(100).Infof("The whereaboutsNsName parameter is empty")
+
+		return nil, fmt.Errorf("failed to list whereabouts IPPools, 'whereaboutsNsName' parameter is empty")
+	}
+
+	ipPoolList := &whereaboutstypes.IPPoolList{}
+
+	err := apiClient.List(context.TODO(), ipPoolList, &client.ListOptions{Namespace: whereaboutsNsName})
+	if err != nil {
+		glog.V(100).Infof("Failed to list IPPools in namespace %s due to %s", whereaboutsNsName, err.Error())
+
+		return nil, err
+	}
+
+	return ipPoolList.Items, nil
+}
+
+func getIPPoolAllocations(ipPool whereaboutstypes.IPPool) ([]WhereaboutsAllocation, error) {
+	_, ipNet, err := net.ParseCIDR(ipPool.Spec.Range)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse range %s of IPPool %s: %w", ipPool.Spec.Range, ipPool.Name, err)
+	}
+
+	var allocations []WhereaboutsAllocation
+
+	for offset, ipAllocation := range ipPool.Spec.Allocations {
+		ipOffset, ok := new(big.Int).SetString(offset, 10)
+		if !ok {
+			return nil, fmt.Errorf("IPPool %s has invalid allocation offset %s", ipPool.Name, offset)
+		}
+
+		podNamespace, podName := splitWhereaboutsPodRef(ipAllocation.PodRef)
+
+		allocations = append(allocations, WhereaboutsAllocation{
+			IP:           addOffsetToIP(ipNet.IP, ipOffset).String(),
+			Range:        ipPool.Spec.Range,
+			PodNamespace: podNamespace,
+			PodName:      podName,
+			ContainerID:  ipAllocation.ContainerID,
+			IfName:       ipAllocation.IfName,
+			PoolName:     ipPool.Name,
+			key:          offset,
+		})
+	}
+
+	return allocations, nil
+}
+
+func releaseWhereaboutsAllocations(
+	apiClient *clients.Settings, whereaboutsNsName string, allocations []WhereaboutsAllocation) error {
+	poolAllocations := make(map[string][]WhereaboutsAllocation)
+
+	for _, allocation := range allocations {
+		if allocation.PoolName != "" {
+			poolAllocations[allocation.PoolName] = append(poolAllocations[allocation.PoolName], allocation)
+
+			continue
+		}
+
+		err := deleteOverlappingRangeIPReservation(apiClient, whereaboutsNsName, allocation)
+		if err != nil {
+			return err
+		}
+	}
+
+	for poolName, poolAllocation := range poolAllocations {
+		err := releaseIPPoolAllocations(apiClient, whereaboutsNsName, poolName, poolAllocation)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// releaseIPPoolAllocations removes the given allocations from the IPPool. The whereabouts IPAM updates the IPPool on
+// every allocation, so conflicts are retried on a fresh copy and offsets that were reassigned to another pod in the
+// meantime are kept.
+func releaseIPPoolAllocations(
+	apiClient *clients.Settings, whereaboutsNsName, poolName string, allocations []WhereaboutsAllocation) error {
+	glog.V(100).Infof("Releasing %d allocations of IPPool %s in namespace %s",
+		len(allocations), poolName, whereaboutsNsName)
+
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		ipPool := &whereaboutstypes.IPPool{}
+
+		err := apiClient.Get(context.TODO(), client.ObjectKey{Name: poolName, Namespace: whereaboutsNsName}, ipPool)
+		if err != nil {
+			return fmt.Errorf("failed to get IPPool %s: %w", poolName, err)
+		}
+
+		released := false
+
+		for _, allocation := range allocations {
+			ipAllocation, found := ipPool.Spec.Allocations[allocation.key]
+			if !found || !isWhereaboutsOwner(allocation, ipAllocation.PodRef, ipAllocation.ContainerID) {
+				glog.V(100).Infof("Skipping allocation of IP %s in IPPool %s which is no longer owned by pod %s/%s",
+					allocation.IP, poolName, allocation.PodNamespace, allocation.PodName)
+
+				continue
+			}
+
+			delete(ipPool.Spec.Allocations, allocation.key)
+
+			released = true
+		}
+
+		if !released {
+			return nil
+		}
+
+		return apiClient.Update(context.TODO(), ipPool)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to release allocations of IPPool %s: %w", poolName, err)
+	}
+
+	return nil
+}
+
+// deleteOverlappingRangeIPReservation deletes the OverlappingRangeIPReservation of the given allocation unless it
+// was reassigned to another pod in the meantime.
+func deleteOverlappingRangeIPReservation(
+	apiClient *clients.Settings, whereaboutsNsName string, allocation WhereaboutsAllocation) error {
+	glog.V(100).Infof("Deleting OverlappingRangeIPReservation %s in namespace %s", allocation.key, whereaboutsNsName)
+
+	reservation := &whereaboutstypes.OverlappingRangeIPReservation{}
+
+	err := apiClient.Get(context.TODO(), client.ObjectKey{Name: allocation.key, Namespace: whereaboutsNsName}, reservation)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil
+		}
+
+		return fmt.Errorf("failed to get OverlappingRangeIPReservation %s: %w", allocation.key, err)
+	}
+
+	if !isWhereaboutsOwner(allocation, reservation.Spec.PodRef, reservation.Spec.ContainerID) {
+		glog.V(100).Infof("Skipping OverlappingRangeIPReservation %s which is no longer owned by pod %s/%s",
+			allocation.key, allocation.PodNamespace, allocation.PodName)
+
+		return nil
+	}
+
+	// The preconditions make the delete fail if the reservation was reassigned after it was fetched.
+	err = apiClient.Delete(context.TODO(), reservation, client.Preconditions{
+		UID: &reservation.UID, ResourceVersion: &reservation.ResourceVersion})
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete OverlappingRangeIPReservation %s: %w", allocation.key, err)
+	}
+
+	return nil
+}
+
+// isWhereaboutsOwner checks whether the pod reference and container ID of a reservation still match the allocation.
+func isWhereaboutsOwner(allocation WhereaboutsAllocation, podRef, containerID string) bool {
+	podNamespace, podName := splitWhereaboutsPodRef(podRef)
+
+	return podNamespace == allocation.PodNamespace && podName == allocation.PodName &&
+		containerID == allocation.ContainerID
+}
+
+func whereaboutsPodExists(apiClient *clients.Settings, allocation WhereaboutsAllocation) (bool, error) {
+	if allocation.PodNamespace == "" || allocation.PodName == "" {
+		return false, nil
+	}
+
+	_, err := apiClient.CoreV1Interface.Pods(allocation.PodNamespace).Get(
+		context.TODO(), allocation.PodName, metav1.GetOptions{})
+	if k8serrors.IsNotFound(err) {
+		return false, nil
+	}
+
+	if err != nil {
+		return false, fmt.Errorf("failed to get pod %s/%s: %w", allocation.PodNamespace, allocation.PodName, err)
+	}
+
+	return true, nil
+}
+
+// splitWhereaboutsPodRef splits a whereabouts pod reference in the form namespace/name.
+func splitWhereaboutsPodRef(podRef string) (string, string) {
+	podNamespace, podName, found := strings.Cut(podRef, "/")
+	if !found {
+		return "", podRef
+	}
+
+	return podNamespace, podName
+}
+
+// whereaboutsReservationNameToIP restores the IP address from an OverlappingRangeIPReservation name, in which
+// whereabouts replaces the colons of IPv6 addresses with dashes and appends a 0 to names ending with a dash.
+func whereaboutsReservationNameToIP(name string) string {
+	if ipAddress := net.ParseIP(strings.ReplaceAll(name, "-", ":")); ipAddress != nil {
+		return ipAddress.String()
+	}
+
+	return name
+}
+
+func addOffsetToIP(ipAddress net.IP, offset *big.Int) net.IP {
+	if ipv4 := ipAddress.To4(); ipv4 != nil {
+		ipAddress = ipv4
+	}
+
+	ipInt := new(big.Int).SetBytes(ipAddress)
+	ipInt.Add(ipInt, offset)
+
+	ipBytes := ipInt.Bytes()
+	if len(ipBytes) > len(ipAddress) {
+		ipBytes = ipBytes[len(ipBytes)-len(ipAddress):]
+	}
+
+	result := make(net.IP, len(ipAddress))
+	copy(result[len(result)-len(ipBytes):], ipBytes)
+
+	return result
+}
+
+func sortWhereaboutsAllocations(allocations []WhereaboutsAllocation) {
+	sort.Slice(allocations, func(i, j int) bool {
+		return bytes.Compare(net.ParseIP(allocations[i].IP).To16(), net.ParseIP(allocations[j].IP).To16()) < 0
+	})
+}
@@ -0,0 +1,242 @@
+package nad
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/openshift-kni/eco-goinfra/pkg/clients"
+	"github.com/openshift-kni/eco-goinfra/pkg/schemes/whereabouts/whereaboutstypes"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+const defaultWhereaboutsNsName = "openshift-multus"
+
+func TestListWhereaboutsAllocations(t *testing.T) {
+	testCases := []struct {
+		client            bool
+		whereaboutsNsName string
+		expectedError     error
+	}{
+		{
+			client:            true,
+			whereaboutsNsName: defaultWhereaboutsNsName,
+			expectedError:     nil,
+		},
+		{
+			client:            true,
+			whereaboutsNsName: "",
+			expectedError:     fmt.Errorf("failed to list whereabouts IPPools, 'whereaboutsNsName' parameter is empty"),
+		},
+		{
+			client:            false,
+			whereaboutsNsName: defaultWhereaboutsNsName,
+			expectedError:     fmt.Errorf("failed to list whereabouts IPPools, 'apiClient' parameter is empty"),
+		},
+	}
+
+	for _, testCase := range testCases {
+		var testSettings *clients.Settings
+
+		if testCase.client {
+			testSettings = clients.GetTestClients(clients.TestClientParams{K8sMockObjects: buildDummyWhereaboutsObjects()})
+		}
+
+		allocations, err := ListWhereaboutsAllocations(testSettings, testCase.whereaboutsNsName)
+		assert.Equal(t, testCase.expectedError, err)
+
+		if testCase.expectedError == nil {
+			assert.Len(t, allocations, 2)
+			assert.Equal(t, []WhereaboutsAllocation{
+				{
+					IP: "192.168.10.2", Range: "192.168.10.0/24", PodNamespace: "test-ns", PodName: "pod-a",
+					ContainerID: "id-a", IfName: "net1", PoolName: "192.168.10.0-24", key: "2",
+				},
+				{
+					IP: "192.168.10.10", Range: "192.168.10.0/24", PodNamespace: "test-ns", PodName: "pod-gone",
+					ContainerID: "id-gone", IfName: "net1", PoolName: "192.168.10.0-24", key: "10",
+				},
+			}, allocations["192.168.10.0/24"])
+			assert.Equal(t, "2001:db8::101", allocations["2001:db8::/64"][0].IP)
+		}
+	}
+}
+
+func TestListStaleWhereaboutsAllocations(t *testing.T) {
+	testSettings := clients.GetTestClients(clients.TestClientParams{K8sMockObjects: buildDummyWhereaboutsObjects()})
+
+	staleAllocations, err := ListStaleWhereaboutsAllocations(testSettings, defaultWhereaboutsNsName)
+	assert.Nil(t, err)
+	assert.Len(t, staleAllocations, 3)
+
+	var staleIPs []string
+
+	for _, allocation := range staleAllocations {
+		assert.NotEqual(t, "pod-a", allocation.PodName)
+
+		staleIPs = append(staleIPs, allocation.IP)
+	}
+
+	assert.ElementsMatch(t, []string{"192.168.10.10", "2001:db8::101", "2001:db8::101"}, staleIPs)
+}
+
+func TestCleanStaleWhereaboutsAllocations(t *testing.T) {
+	testSettings := clients.GetTestClients(clients.TestClientParams{K8sMockObjects: buildDummyWhereaboutsObjects()})
+
+	err := CleanStaleWhereaboutsAllocations(testSettings, defaultWhereaboutsNsName)
+	assert.Nil(t, err)
+
+	allocations, err := ListWhereaboutsAllocations(testSettings, defaultWhereaboutsNsName)
+	assert.Nil(t, err)
+	assert.Len(t, allocations["192.168.10.0/24"], 1)
+	assert.Equal(t, "pod-a", allocations["192.168.10.0/24"][0].PodName)
+	assert.Empty(t, allocations["2001:db8::/64"])
+
+	reservationList := &whereaboutstypes.OverlappingRangeIPReservationList{}
+	err = testSettings.List(context.TODO(), reservationList, &client.ListOptions{Namespace: defaultWhereaboutsNsName})
+	assert.Nil(t, err)
+	assert.Len(t, reservationList.Items, 1)
+	assert.Equal(t, "192.168.10.2", reservationList.Items[0].Name)
+}
+
+func TestCleanNamespaceWhereaboutsAllocations(t *testing.T) {
+	testSettings := clients.GetTestClients(clients.TestClientParams{K8sMockObjects: buildDummyWhereaboutsObjects()})
+
+	err := CleanNamespaceWhereaboutsAllocations(testSettings, defaultWhereaboutsNsName, "")
+	assert.Equal(t, fmt.Errorf("failed to clean whereabouts allocations, 'podNsName' parameter is empty"), err)
+
+	err = CleanNamespaceWhereaboutsAllocations(testSettings, defaultWhereaboutsNsName, "test-ns")
+	assert.Nil(t, err)
+
+	allocations, err := ListWhereaboutsAllocations(testSettings, defaultWhereaboutsNsName)
+	assert.Nil(t, err)
+	assert.Empty(t, allocations["192.168.10.0/24"])
+	assert.Len(t, allocations["2001:db8::/64"], 1)
+
+	reservationList := &whereaboutstypes.OverlappingRangeIPReservationList{}
+	err = testSettings.List(context.TODO(), reservationList, &client.ListOptions{Namespace: defaultWhereaboutsNsName})
+	assert.Nil(t, err)
+	assert.Len(t, reservationList.Items, 1)
+	assert.Equal(t, "2001-db8--101", reservationList.Items[0].Name)
+}
+
+func TestCleanStaleWhereaboutsAllocationsConflict(t *testing.T) {
+	testSettings := clients.GetTestClients(clients.TestClientParams{K8sMockObjects: buildDummyWhereaboutsObjects()})
+
+	updateAttempts := 0
+	testSettings.Client = interceptor.NewClient(testSettings.Client.(client.WithWatch), interceptor.Funcs{
+		Update: func(
+			ctx context.Context, apiClient client.WithWatch, obj client.Object, opts ...client.UpdateOption) error {
+			updateAttempts++
+
+			if updateAttempts == 1 {
+				return k8serrors.NewConflict(schema.GroupResource{Resource: "ippools"}, obj.GetName(),
+					fmt.Errorf("the object has been modified"))
+			}
+
+			return apiClient.Update(ctx, obj, opts...)
+		},
+	})
+
+	err := CleanStaleWhereaboutsAllocations(testSettings, defaultWhereaboutsNsName)
+	assert.Nil(t, err)
+	// The first IPPool is updated twice because of the conflict, the second one once.
+	assert.Equal(t, 3, updateAttempts)
+
+	allocations, err := ListWhereaboutsAllocations(testSettings, defaultWhereaboutsNsName)
+	assert.Nil(t, err)
+	assert.Len(t, allocations["192.168.10.0/24"], 1)
+}
+
+func TestReleaseWhereaboutsAllocationsReassigned(t *testing.T) {
+	testSettings := clients.GetTestClients(clients.TestClientParams{K8sMockObjects: buildDummyWhereaboutsObjects()})
+
+	allocations, err := listAllWhereaboutsAllocations(testSettings, defaultWhereaboutsNsName)
+	assert.Nil(t, err)
+
+	// Reassign every IPPool offset and OverlappingRangeIPReservation to a new pod after they were listed.
+	ipPoolList := &whereaboutstypes.IPPoolList{}
+	err = testSettings.List(context.TODO(), ipPoolList, &client.ListOptions{Namespace: defaultWhereaboutsNsName})
+	assert.Nil(t, err)
+
+	newAllocation := whereaboutstypes.IPAllocation{ContainerID: "id-new", PodRef: "test-ns/pod-new"}
+
+	for _, ipPool := range ipPoolList.Items {
+		for offset := range ipPool.Spec.Allocations {
+			ipPool.Spec.Allocations[offset] = newAllocation
+		}
+
+		err = testSettings.Update(context.TODO(), &ipPool)
+		assert.Nil(t, err)
+	}
+
+	reservationList := &whereaboutstypes.OverlappingRangeIPReservationList{}
+	err = testSettings.List(context.TODO(), reservationList, &client.ListOptions{Namespace: defaultWhereaboutsNsName})
+	assert.Nil(t, err)
+
+	for _, reservation := range reservationList.Items {
+		reservation.Spec.ContainerID = newAllocation.ContainerID
+		reservation.Spec.PodRef = newAllocation.PodRef
+
+		err = testSettings.Update(context.TODO(), &reservation)
+		assert.Nil(t, err)
+	}
+
+	err = releaseWhereaboutsAllocations(testSettings, defaultWhereaboutsNsName, allocations)
+	assert.Nil(t, err)
+
+	remainingAllocations, err := listAllWhereaboutsAllocations(testSettings, defaultWhereaboutsNsName)
+	assert.Nil(t, err)
+	assert.Len(t, remainingAllocations, len(allocations))
+
+	for _, allocation := range remainingAllocations {
+		assert.Equal(t, "pod-new", allocation.PodName)
+	}
+}
+
+func TestWhereaboutsReservationNameToIP(t *testing.T) {
+	assert.Equal(t, "192.168.10.2", whereaboutsReservationNameToIP("192.168.10.2"))
+	assert.Equal(t, "2001:db8::101", whereaboutsReservationNameToIP("2001-db8--101"))
+	assert.Equal(t, "fd00::", whereaboutsReservationNameToIP("fd00--0"))
+	assert.Equal(t, "invalid", whereaboutsReservationNameToIP("invalid"))
+}
+
+func buildDummyWhereaboutsObjects() []runtime.Object {
+	return []runtime.Object{
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod-a", Namespace: "test-ns"}},
+		&whereaboutstypes.IPPool{
+			ObjectMeta: metav1.ObjectMeta{Name: "192.168.10.0-24", Namespace: defaultWhereaboutsNsName},
+			Spec: whereaboutstypes.IPPoolSpec{
+				Range: "192.168.10.0/24",
+				Allocations: map[string]whereaboutstypes.IPAllocation{
+					"10": {ContainerID: "id-gone", PodRef: "test-ns/pod-gone", IfName: "net1"},
+					"2":  {ContainerID: "id-a", PodRef: "test-ns/pod-a", IfName: "net1"},
+				},
+			},
+		},
+		&whereaboutstypes.IPPool{
+			ObjectMeta: metav1.ObjectMeta{Name: "2001-db8---64", Namespace: defaultWhereaboutsNsName},
+			Spec: whereaboutstypes.IPPoolSpec{
+				Range: "2001:db8::/64",
+				Allocations: map[string]whereaboutstypes.IPAllocation{
+					"257": {ContainerID: "id-b", PodRef: "other-ns/pod-b"},
+				},
+			},
+		},
+		&whereaboutstypes.OverlappingRangeIPReservation{
+			ObjectMeta: metav1.ObjectMeta{Name: "192.168.10.2", Namespace: defaultWhereaboutsNsName},
+			Spec:       whereaboutstypes.OverlappingRangeIPReservationSpec{ContainerID: "id-a", PodRef: "test-ns/pod-a"},
+		},
+		&whereaboutstypes.OverlappingRangeIPReservation{
+			ObjectMeta: metav1.ObjectMeta{Name: "2001-db8--101", Namespace: defaultWhereaboutsNsName},
+			Spec:       whereaboutstypes.OverlappingRangeIPReservationSpec{ContainerID: "id-b", PodRef: "other-ns/pod-b"},
+		},
+	}
+}
@@ -10,6 +10,7 @@ import (
 	v1 "github.com/openshift/api/config/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	goclient "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 const (
@@ -59,12 +60,79 @@ func (builder *ConfigBuilder) Exists() bool {
 		builder.Definition.Name)
 
 	var err error
-	builder.Object, err = builder.apiClient.ConfigV1Interface.Networks().Get(
-		context.TODO(), builder.Definition.Name, metav1.GetOptions{})
+	builder.Object, err = builder.Get()
 
 	return err == nil || !k8serrors.IsNotFound(err)
 }
 
+// Get returns network object.
+func (builder *ConfigBuilder) Get() (*v1.Network, error) {
+	if valid, err := builder.validate(); !valid {
+		return nil, err
+	}
+
+	glog.V(100).Infof("Getting network %s", builder.Definition.Name)
+
+	network := &v1.Network{}
+	err := builder.apiClient.Get(context.TODO(), goclient.ObjectKey{
+		Name: builder.Definition.Name,
+	}, network)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return network, nil
+}
+
+// GetClusterNetworks returns the cluster networks reported in the network status.
+func (builder *ConfigBuilder) GetClusterNetworks() ([]v1.ClusterNetworkEntry, error) {
+	if valid, err := builder.validate(); !valid {
+		return nil, err
+	}
+
+	glog.V(100).Infof("Getting cluster networks of network %s", builder.Definition.Name)
+
+	network, err := builder.Get()
+	if err != nil {
+		return nil, err
+	}
+
+	return network.Status.ClusterNetwork, nil
+}
+
+// GetServiceNetworks returns the service networks reported in the network status.
+func (builder *ConfigBuilder) GetServiceNetworks() ([]string, error) {
+	if valid, err := builder.validate(); !valid {
+		return nil, err
+	}
+
+	glog.V(100).Infof("Getting service networks of network %s", builder.Definition.Name)
+
+	network, err := builder.Get()
+	if err != nil {
+		return nil, err
+	}
+
+	return network.Status.ServiceNetwork, nil
+}
+
+// GetNetworkType returns the cluster default network type reported in the network status, e.g. OVNKubernetes.
+func (builder *ConfigBuilder) GetNetworkType() (string, error) {
+	if valid, err := builder.validate(); !valid {
+		return "", err
+	}
+
+	glog.V(100).Infof("Getting network type of network %s", builder.Definition.Name)
+
+	network, err := builder.Get()
+	if err != nil {
+		return "", err
+	}
+
+	return network.Status.NetworkType, nil
+}
+
 // validate will check that the builder and builder definition are properly initialized before
 // accessing any member fields.
 func (builder *ConfigBuilder) validate() (bool, error) {
@@ -1 +1,89 @@
 package network
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/openshift-kni/eco-goinfra/pkg/clients"
+	configv1 "github.com/openshift/api/config/v1"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestPullConfig(t *testing.T) {
+	testCases := []struct {
+		addToRuntimeObjects bool
+		expectedError       error
+	}{
+		{
+			addToRuntimeObjects: true,
+			expectedError:       nil,
+		},
+		{
+			addToRuntimeObjects: false,
+			expectedError:       fmt.Errorf("network object cluster does not exist"),
+		},
+	}
+
+	for _, testCase := range testCases {
+		var runtimeObjects []runtime.Object
+
+		if testCase.addToRuntimeObjects {
+			runtimeObjects = append(runtimeObjects, buildDummyNetworkConfig())
+		}
+
+		testSettings := clients.GetTestClients(clients.TestClientParams{K8sMockObjects: runtimeObjects})
+
+		testBuilder, err := PullConfig(testSettings)
+		assert.Equal(t, testCase.expectedError, err)
+
+		if testCase.expectedError == nil {
+			assert.Equal(t, clusterNetworkName, testBuilder.Definition.Name)
+		}
+	}
+}
+
+func TestConfigGetters(t *testing.T) {
+	testBuilder := buildValidNetworkConfigTestBuilder()
+
+	clusterNetworks, err := testBuilder.GetClusterNetworks()
+	assert.Nil(t, err)
+	assert.Equal(t, []configv1.ClusterNetworkEntry{{CIDR: "10.128.0.0/14"}}, clusterNetworks)
+
+	serviceNetworks, err := testBuilder.GetServiceNetworks()
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"172.30.0.0/16"}, serviceNetworks)
+
+	networkType, err := testBuilder.GetNetworkType()
+	assert.Nil(t, err)
+	assert.Equal(t, "OVNKubernetes", networkType)
+
+	testBuilder.apiClient = nil
+	_, err = testBuilder.GetNetworkType()
+	assert.Equal(t, fmt.Errorf("Network.Config builder cannot have nil apiClient"), err)
+}
+
+func buildValidNetworkConfigTestBuilder() *ConfigBuilder {
+	testSettings := clients.GetTestClients(clients.TestClientParams{
+		K8sMockObjects: []runtime.Object{buildDummyNetworkConfig()},
+	})
+
+	return &ConfigBuilder{
+		apiClient:  testSettings,
+		Definition: &configv1.Network{ObjectMeta: metav1.ObjectMeta{Name: clusterNetworkName}},
+	}
+}
+
+func buildDummyNetworkConfig() *configv1.Network {
+	return &configv1.Network{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: clusterNetworkName,
+		},
+		Status: configv1.NetworkStatus{
+			ClusterNetwork: []configv1.ClusterNetworkEntry{{CIDR: "10.128.0.0/14"}},
+			ServiceNetwork: []string{"172.30.0.0/16"},
+			NetworkType:    "OVNKubernetes",
+		},
+	}
+}
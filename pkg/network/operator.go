@@ -2,13 +2,16 @@ package network
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"net"
 	"time"
 
 	"github.com/golang/glog"
 	"github.com/openshift-kni/eco-goinfra/pkg/clients"
 	"github.com/openshift-kni/eco-goinfra/pkg/msg"
 	operatorV1 "github.com/openshift/api/operator/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/wait"
@@ -103,74 +106,365 @@ func (builder *OperatorBuilder) SetLocalGWMode(state bool, timeout time.Duration
 		return builder, err
 	}
 
-	var err error
+	glog.V(100).Infof("Applying local gateway mode %t to network.operator %s", state, builder.Definition.Name)
 
 	if builder.Definition.Spec.DefaultNetwork.OVNKubernetesConfig.GatewayConfig.RoutingViaHost != state {
 		builder.Definition.Spec.DefaultNetwork.OVNKubernetesConfig.GatewayConfig.RoutingViaHost = state
-		builder, err := builder.Update()
 
-		if err != nil {
-			return nil, err
+		return builder.updateAndWaitUntilAvailable(300*time.Second, timeout, false)
+	}
+
+	return builder, nil
+}
+
+// SetMultiNetworkPolicy enables network.operator multinetworkpolicy feature.
+func (builder *OperatorBuilder) SetMultiNetworkPolicy(state bool, timeout time.Duration) (*OperatorBuilder, error) {
+	if valid, err := builder.validate(); !valid {
+		return builder, err
+	}
+
+	glog.V(100).Infof("Applying MultiNetworkPolicy flag %t to network.operator %s", state, builder.Definition.Name)
+
+	if *builder.Definition.Spec.UseMultiNetworkPolicy != state {
+		builder.Definition.Spec.UseMultiNetworkPolicy = &state
+
+		return builder.updateAndWaitUntilAvailable(60*time.Second, timeout, false)
+	}
+
+	return builder, nil
+}
+
+// SetAdditionalNetwork adds the given additional network to network.operator, replacing the additional network
+// with the same name and namespace if it is already defined.
+func (builder *OperatorBuilder) SetAdditionalNetwork(
+	additionalNetwork operatorV1.AdditionalNetworkDefinition, timeout time.Duration) (*OperatorBuilder, error) {
+	if valid, err := builder.validate(); !valid {
+		return builder, err
+	}
+
+	glog.V(100).Infof("Setting additional network %s/%s of type %s to network.operator %s",
+		additionalNetwork.Namespace, additionalNetwork.Name, additionalNetwork.Type, builder.Definition.Name)
+
+	if err := validateAdditionalNetwork(additionalNetwork); err != nil {
+		return builder, err
+	}
+
+	return builder.applySpecChange(60*time.Second, timeout, true, func(spec *operatorV1.NetworkSpec) error {
+		for index, network := range spec.AdditionalNetworks {
+			if network.Name == additionalNetwork.Name && network.Namespace == additionalNetwork.Namespace {
+				spec.AdditionalNetworks[index] = additionalNetwork
+
+				return nil
+			}
 		}
 
-		err = builder.WaitUntilInCondition(
-			operatorV1.OperatorStatusTypeProgressing, 300*time.Second, operatorV1.ConditionTrue)
+		spec.AdditionalNetworks = append(spec.AdditionalNetworks, additionalNetwork)
 
-		if err != nil {
-			return nil, err
+		return nil
+	})
+}
+
+// RemoveAdditionalNetwork removes the additional network with the given name and namespace from network.operator.
+// Removing an additional network that is not defined is a no-op.
+func (builder *OperatorBuilder) RemoveAdditionalNetwork(
+	name, namespace string, timeout time.Duration) (*OperatorBuilder, error) {
+	if valid, err := builder.validate(); !valid {
+		return builder, err
+	}
+
+	glog.V(100).Infof("Removing additional network %s/%s from network.operator %s",
+		namespace, name, builder.Definition.Name)
+
+	return builder.applySpecChange(60*time.Second, timeout, true, func(spec *operatorV1.NetworkSpec) error {
+		var additionalNetworks []operatorV1.AdditionalNetworkDefinition
+
+		for _, network := range spec.AdditionalNetworks {
+			if network.Name != name || network.Namespace != namespace {
+				additionalNetworks = append(additionalNetworks, network)
+			}
 		}
 
-		err = builder.WaitUntilInCondition(
-			operatorV1.OperatorStatusTypeProgressing, timeout, operatorV1.ConditionFalse)
+		spec.AdditionalNetworks = additionalNetworks
+
+		return nil
+	})
+}
+
+// SetIPForwarding sets the OVN-Kubernetes gateway IP forwarding mode of network.operator.
+func (builder *OperatorBuilder) SetIPForwarding(
+	mode operatorV1.IPForwardingMode, timeout time.Duration) (*OperatorBuilder, error) {
+	if valid, err := builder.validate(); !valid {
+		return builder, err
+	}
+
+	glog.V(100).Infof("Setting IP forwarding mode %s to network.operator %s", mode, builder.Definition.Name)
+
+	if mode != operatorV1.IPForwardingRestricted && mode != operatorV1.IPForwardingGlobal {
+		glog.V(100).Infof("Invalid IP forwarding mode %s", mode)
+
+		return builder, fmt.Errorf("invalid IP forwarding mode %s, must be one of %s or %s",
+			mode, operatorV1.IPForwardingRestricted, operatorV1.IPForwardingGlobal)
+	}
 
+	return builder.applySpecChange(300*time.Second, timeout, false, func(spec *operatorV1.NetworkSpec) error {
+		gatewayConfig, err := getOVNKubernetesGatewayConfig(spec)
 		if err != nil {
-			return nil, err
+			return err
 		}
 
-		return builder, builder.WaitUntilInCondition(
-			operatorV1.OperatorStatusTypeAvailable, 60*time.Second, operatorV1.ConditionTrue)
+		gatewayConfig.IPForwarding = mode
+
+		return nil
+	})
+}
+
+// SetGatewayInternalMasqueradeSubnets sets the OVN-Kubernetes gateway IPv4 and IPv6 internal masquerade subnets of
+// network.operator. An empty subnet leaves the current value of that IP family unchanged.
+func (builder *OperatorBuilder) SetGatewayInternalMasqueradeSubnets(
+	ipv4Subnet, ipv6Subnet string, timeout time.Duration) (*OperatorBuilder, error) {
+	if valid, err := builder.validate(); !valid {
+		return builder, err
 	}
 
-	return builder, err
+	glog.V(100).Infof("Setting gateway internal masquerade subnets %s and %s to network.operator %s",
+		ipv4Subnet, ipv6Subnet, builder.Definition.Name)
+
+	if ipv4Subnet == "" && ipv6Subnet == "" {
+		glog.V(100).Infof("Both gateway internal masquerade subnets are empty")
+
+		return builder, fmt.Errorf("at least one of ipv4Subnet or ipv6Subnet must be set")
+	}
+
+	if err := validateSubnetFamily(ipv4Subnet, false); err != nil {
+		return builder, err
+	}
+
+	if err := validateSubnetFamily(ipv6Subnet, true); err != nil {
+		return builder, err
+	}
+
+	return builder.applySpecChange(300*time.Second, timeout, false, func(spec *operatorV1.NetworkSpec) error {
+		gatewayConfig, err := getOVNKubernetesGatewayConfig(spec)
+		if err != nil {
+			return err
+		}
+
+		if ipv4Subnet != "" {
+			gatewayConfig.IPv4.InternalMasqueradeSubnet = ipv4Subnet
+		}
+
+		if ipv6Subnet != "" {
+			gatewayConfig.IPv6.InternalMasqueradeSubnet = ipv6Subnet
+		}
+
+		return nil
+	})
 }
 
-// SetMultiNetworkPolicy enables network.operator multinetworkpolicy feature.
-func (builder *OperatorBuilder) SetMultiNetworkPolicy(state bool, timeout time.Duration) (*OperatorBuilder, error) {
+// SetIPsecMode sets the OVN-Kubernetes IPsec mode of network.operator.
+func (builder *OperatorBuilder) SetIPsecMode(
+	mode operatorV1.IPsecMode, timeout time.Duration) (*OperatorBuilder, error) {
 	if valid, err := builder.validate(); !valid {
 		return builder, err
 	}
 
-	glog.V(100).Infof("Applying MultiNetworkPolicy flag %t to network.operator %s", state, builder.Definition.Name)
+	glog.V(100).Infof("Setting IPsec mode %s to network.operator %s", mode, builder.Definition.Name)
 
-	var err error
+	switch mode {
+	case operatorV1.IPsecModeDisabled, operatorV1.IPsecModeExternal, operatorV1.IPsecModeFull:
+	default:
+		glog.V(100).Infof("Invalid IPsec mode %s", mode)
 
-	if *builder.Definition.Spec.UseMultiNetworkPolicy != state {
-		builder.Definition.Spec.UseMultiNetworkPolicy = &state
-		builder, err := builder.Update()
+		return builder, fmt.Errorf("invalid IPsec mode %s, must be one of %s, %s or %s",
+			mode, operatorV1.IPsecModeDisabled, operatorV1.IPsecModeExternal, operatorV1.IPsecModeFull)
+	}
 
+	return builder.applySpecChange(300*time.Second, timeout, false, func(spec *operatorV1.NetworkSpec) error {
+		ovnConfig, err := getOVNKubernetesConfig(spec)
 		if err != nil {
-			return nil, err
+			return err
 		}
 
-		err = builder.WaitUntilInCondition(
-			operatorV1.OperatorStatusTypeProgressing, 60*time.Second, operatorV1.ConditionTrue)
+		ovnConfig.IPsecConfig = &operatorV1.IPsecConfig{Mode: mode}
+
+		return nil
+	})
+}
 
+// SetEgressIPReachabilityTimeout sets the OVN-Kubernetes EgressIP node reachability check total timeout of
+// network.operator. A value of 0 disables the reachability check.
+func (builder *OperatorBuilder) SetEgressIPReachabilityTimeout(
+	seconds uint32, timeout time.Duration) (*OperatorBuilder, error) {
+	if valid, err := builder.validate(); !valid {
+		return builder, err
+	}
+
+	glog.V(100).Infof("Setting EgressIP reachability timeout %d to network.operator %s",
+		seconds, builder.Definition.Name)
+
+	if seconds > 60 {
+		glog.V(100).Infof("The EgressIP reachability timeout %d is greater than 60", seconds)
+
+		return builder, fmt.Errorf("egressIP reachability timeout %d can not be greater than 60 seconds", seconds)
+	}
+
+	return builder.applySpecChange(60*time.Second, timeout, false, func(spec *operatorV1.NetworkSpec) error {
+		ovnConfig, err := getOVNKubernetesConfig(spec)
 		if err != nil {
-			return nil, err
+			return err
+		}
+
+		ovnConfig.EgressIPConfig.ReachabilityTotalTimeoutSeconds = &seconds
+
+		return nil
+	})
+}
+
+// SetMigrationEgressFeatures sets whether the EgressIP and EgressFirewall configuration are migrated when changing
+// the cluster default network type of network.operator. The multicast migration setting is left unchanged. The
+// change only triggers a rollout while a migration is in progress.
+func (builder *OperatorBuilder) SetMigrationEgressFeatures(
+	egressIP, egressFirewall bool, timeout time.Duration) (*OperatorBuilder, error) {
+	if valid, err := builder.validate(); !valid {
+		return builder, err
+	}
+
+	glog.V(100).Infof("Setting migration features egressIP %t and egressFirewall %t to network.operator %s",
+		egressIP, egressFirewall, builder.Definition.Name)
+
+	return builder.applySpecChange(60*time.Second, timeout, true, func(spec *operatorV1.NetworkSpec) error {
+		if spec.Migration == nil {
+			spec.Migration = &operatorV1.NetworkMigration{}
+		}
+
+		if spec.Migration.Features == nil {
+			spec.Migration.Features = &operatorV1.FeaturesMigration{Multicast: true}
+		}
+
+		spec.Migration.Features.EgressIP = egressIP
+		spec.Migration.Features.EgressFirewall = egressFirewall
+
+		return nil
+	})
+}
+
+// SetMTUMigration starts the MTU migration of network.operator from networkFrom to networkTo for the default
+// network. When machineTo is not 0 the machine uplink MTU is migrated from machineFrom to machineTo as well. Once
+// all nodes are rebooted, the migration is completed using CompleteMTUMigration.
+func (builder *OperatorBuilder) SetMTUMigration(
+	networkFrom, networkTo, machineFrom, machineTo uint32, timeout time.Duration) (*OperatorBuilder, error) {
+	if valid, err := builder.validate(); !valid {
+		return builder, err
+	}
+
+	glog.V(100).Infof("Setting MTU migration of network from %d to %d and machine from %d to %d to "+
+		"network.operator %s", networkFrom, networkTo, machineFrom, machineTo, builder.Definition.Name)
+
+	if networkFrom == 0 || networkTo == 0 {
+		glog.V(100).Infof("The network MTU migration values can not be 0")
+
+		return builder, fmt.Errorf("network MTU migration values can not be 0")
+	}
+
+	if machineTo != 0 && machineTo <= networkTo {
+		glog.V(100).Infof("The machine MTU %d is not greater than the network MTU %d", machineTo, networkTo)
+
+		return builder, fmt.Errorf("machine MTU %d must be greater than network MTU %d", machineTo, networkTo)
+	}
+
+	return builder.applySpecChange(300*time.Second, timeout, false, func(spec *operatorV1.NetworkSpec) error {
+		if spec.Migration == nil {
+			spec.Migration = &operatorV1.NetworkMigration{}
+		}
+
+		spec.Migration.MTU = &operatorV1.MTUMigration{
+			Network: &operatorV1.MTUMigrationValues{From: &networkFrom, To: &networkTo},
 		}
 
-		err = builder.WaitUntilInCondition(
-			operatorV1.OperatorStatusTypeProgressing, timeout, operatorV1.ConditionFalse)
+		if machineTo != 0 {
+			spec.Migration.MTU.Machine = &operatorV1.MTUMigrationValues{From: &machineFrom, To: &machineTo}
+		}
+
+		return nil
+	})
+}
+
+// CompleteMTUMigration sets the OVN-Kubernetes MTU of network.operator to mtu and removes the MTU migration
+// configuration.
+func (builder *OperatorBuilder) CompleteMTUMigration(mtu uint32, timeout time.Duration) (*OperatorBuilder, error) {
+	if valid, err := builder.validate(); !valid {
+		return builder, err
+	}
+
+	glog.V(100).Infof("Completing MTU migration to %d of network.operator %s", mtu, builder.Definition.Name)
 
+	if builder.Definition.Spec.Migration == nil || builder.Definition.Spec.Migration.MTU == nil {
+		glog.V(100).Infof("The network.operator %s has no MTU migration in progress", builder.Definition.Name)
+
+		return builder, fmt.Errorf("network.operator %s has no MTU migration in progress", builder.Definition.Name)
+	}
+
+	return builder.applySpecChange(300*time.Second, timeout, false, func(spec *operatorV1.NetworkSpec) error {
+		ovnConfig, err := getOVNKubernetesConfig(spec)
 		if err != nil {
-			return nil, err
+			return err
 		}
 
-		return builder, builder.WaitUntilInCondition(
-			operatorV1.OperatorStatusTypeAvailable, 60*time.Second, operatorV1.ConditionTrue)
+		ovnConfig.MTU = &mtu
+		spec.Migration.MTU = nil
+
+		if spec.Migration.NetworkType == "" && spec.Migration.Features == nil && spec.Migration.Mode == "" {
+			spec.Migration = nil
+		}
+
+		return nil
+	})
+}
+
+// SetDeployKubeProxy sets whether a standalone kube-proxy is deployed by network.operator.
+func (builder *OperatorBuilder) SetDeployKubeProxy(state bool, timeout time.Duration) (*OperatorBuilder, error) {
+	if valid, err := builder.validate(); !valid {
+		return builder, err
 	}
 
-	return builder, err
+	glog.V(100).Infof("Applying deployKubeProxy flag %t to network.operator %s", state, builder.Definition.Name)
+
+	return builder.applySpecChange(60*time.Second, timeout, false, func(spec *operatorV1.NetworkSpec) error {
+		spec.DeployKubeProxy = &state
+
+		return nil
+	})
+}
+
+// SetKubeProxyConfig sets the kube-proxy configuration of network.operator. The change only triggers a rollout when
+// kube-proxy is deployed.
+func (builder *OperatorBuilder) SetKubeProxyConfig(
+	kubeProxyConfig operatorV1.ProxyConfig, timeout time.Duration) (*OperatorBuilder, error) {
+	if valid, err := builder.validate(); !valid {
+		return builder, err
+	}
+
+	glog.V(100).Infof("Setting kube-proxy config %v to network.operator %s", kubeProxyConfig, builder.Definition.Name)
+
+	if kubeProxyConfig.IptablesSyncPeriod != "" {
+		if _, err := time.ParseDuration(kubeProxyConfig.IptablesSyncPeriod); err != nil {
+			glog.V(100).Infof("Invalid iptables sync period %s", kubeProxyConfig.IptablesSyncPeriod)
+
+			return builder, fmt.Errorf("invalid kube-proxy iptablesSyncPeriod %s", kubeProxyConfig.IptablesSyncPeriod)
+		}
+	}
+
+	if kubeProxyConfig.BindAddress != "" && net.ParseIP(kubeProxyConfig.BindAddress) == nil {
+		glog.V(100).Infof("Invalid bind address %s", kubeProxyConfig.BindAddress)
+
+		return builder, fmt.Errorf("invalid kube-proxy bindAddress %s", kubeProxyConfig.BindAddress)
+	}
+
+	return builder.applySpecChange(60*time.Second, timeout, true, func(spec *operatorV1.NetworkSpec) error {
+		spec.KubeProxyConfig = &kubeProxyConfig
+
+		return nil
+	})
 }
 
 // WaitUntilInCondition waits for a specific time duration until the network.operator will have a
@@ -202,6 +496,62 @@ func (builder *OperatorBuilder) WaitUntilInCondition(
 	return err
 }
 
+// applySpecChange applies mutate to a copy of the network.operator spec. When the spec changes, network.operator is
+// updated and the rollout is awaited, otherwise no update is done. rolloutOptional is passed to
+// updateAndWaitUntilAvailable.
+func (builder *OperatorBuilder) applySpecChange(
+	progressingTimeout, timeout time.Duration,
+	rolloutOptional bool,
+	mutate func(spec *operatorV1.NetworkSpec) error) (*OperatorBuilder, error) {
+	spec := builder.Definition.Spec.DeepCopy()
+
+	if err := mutate(spec); err != nil {
+		return builder, err
+	}
+
+	if equality.Semantic.DeepEqual(builder.Definition.Spec, *spec) {
+		glog.V(100).Infof("The network.operator %s spec is unchanged", builder.Definition.Name)
+
+		return builder, nil
+	}
+
+	builder.Definition.Spec = *spec
+
+	return builder.updateAndWaitUntilAvailable(progressingTimeout, timeout, rolloutOptional)
+}
+
+// updateAndWaitUntilAvailable updates network.operator and waits until it starts progressing within
+// progressingTimeout, stops progressing within timeout and is available again. Some changes, such as the kube-proxy
+// config when kube-proxy is not deployed, do not trigger a rollout. For those rolloutOptional is true and not
+// progressing within progressingTimeout is not an error.
+func (builder *OperatorBuilder) updateAndWaitUntilAvailable(
+	progressingTimeout, timeout time.Duration, rolloutOptional bool) (*OperatorBuilder, error) {
+	builder, err := builder.Update()
+	if err != nil {
+		return nil, err
+	}
+
+	err = builder.WaitUntilInCondition(
+		operatorV1.OperatorStatusTypeProgressing, progressingTimeout, operatorV1.ConditionTrue)
+	if err != nil {
+		if !rolloutOptional || !wait.Interrupted(err) {
+			return nil, err
+		}
+
+		glog.V(100).Infof("The network.operator %s did not start progressing, the change triggered no rollout",
+			builder.Definition.Name)
+	}
+
+	err = builder.WaitUntilInCondition(
+		operatorV1.OperatorStatusTypeProgressing, timeout, operatorV1.ConditionFalse)
+	if err != nil {
+		return nil, err
+	}
+
+	return builder, builder.WaitUntilInCondition(
+		operatorV1.OperatorStatusTypeAvailable, 60*time.Second, operatorV1.ConditionTrue)
+}
+
 // validate will check that the builder and builder definition are properly initialized before
 // accessing any member fields.
 func (builder *OperatorBuilder) validate() (bool, error) {
@@ -233,3 +583,120 @@ func (builder *OperatorBuilder) validate() (bool, error) {
 
 	return true, nil
 }
+
+// NewRawAdditionalNetwork returns an additional network definition of type Raw using the given CNI configuration.
+func NewRawAdditionalNetwork(name, namespace, rawCNIConfig string) operatorV1.AdditionalNetworkDefinition {
+	return operatorV1.AdditionalNetworkDefinition{
+		Type:         operatorV1.NetworkTypeRaw,
+		Name:         name,
+		Namespace:    namespace,
+		RawCNIConfig: rawCNIConfig,
+	}
+}
+
+// NewSimpleMacvlanAdditionalNetwork returns an additional network definition of type SimpleMacvlan using the given
+// macvlan configuration.
+func NewSimpleMacvlanAdditionalNetwork(
+	name, namespace string, macvlanConfig operatorV1.SimpleMacvlanConfig) operatorV1.AdditionalNetworkDefinition {
+	return operatorV1.AdditionalNetworkDefinition{
+		Type:                operatorV1.NetworkTypeSimpleMacvlan,
+		Name:                name,
+		Namespace:           namespace,
+		SimpleMacvlanConfig: &macvlanConfig,
+	}
+}
+
+func validateAdditionalNetwork(additionalNetwork operatorV1.AdditionalNetworkDefinition) error {
+	if additionalNetwork.Name == "" {
+		glog.V(100).Infof("The additional network name is empty")
+
+		return fmt.Errorf("additional network name can not be empty")
+	}
+
+	switch additionalNetwork.Type {
+	case operatorV1.NetworkTypeRaw:
+		if additionalNetwork.RawCNIConfig == "" {
+			glog.V(100).Infof("The additional network %s rawCNIConfig is empty", additionalNetwork.Name)
+
+			return fmt.Errorf("additional network %s of type %s must have a rawCNIConfig",
+				additionalNetwork.Name, additionalNetwork.Type)
+		}
+
+		if !json.Valid([]byte(additionalNetwork.RawCNIConfig)) {
+			glog.V(100).Infof("The additional network %s rawCNIConfig is not valid JSON", additionalNetwork.Name)
+
+			return fmt.Errorf("additional network %s rawCNIConfig is not valid JSON", additionalNetwork.Name)
+		}
+	case operatorV1.NetworkTypeSimpleMacvlan:
+		if additionalNetwork.RawCNIConfig != "" {
+			glog.V(100).Infof("The additional network %s has rawCNIConfig", additionalNetwork.Name)
+
+			return fmt.Errorf("additional network %s of type %s can not have a rawCNIConfig",
+				additionalNetwork.Name, additionalNetwork.Type)
+		}
+	default:
+		glog.V(100).Infof("Invalid additional network type %s", additionalNetwork.Type)
+
+		return fmt.Errorf("invalid additional network type %s, must be one of %s or %s",
+			additionalNetwork.Type, operatorV1.NetworkTypeRaw, operatorV1.NetworkTypeSimpleMacvlan)
+	}
+
+	return nil
+}
+
+// validateSubnetFamily checks that subnet, when not empty, is a CIDR of the expected IP family.
+func validateSubnetFamily(subnet string, ipv6 bool) error {
+	if subnet == "" {
+		return nil
+	}
+
+	ip, _, err := net.ParseCIDR(subnet)
+	if err != nil {
+		glog.V(100).Infof("Invalid subnet %s", subnet)
+
+		return fmt.Errorf("invalid subnet %s", subnet)
+	}
+
+	if (ip.To4() == nil) != ipv6 {
+		glog.V(100).Infof("The subnet %s has the wrong IP family", subnet)
+
+		if ipv6 {
+			return fmt.Errorf("subnet %s is not an IPv6 subnet", subnet)
+		}
+
+		return fmt.Errorf("subnet %s is not an IPv4 subnet", subnet)
+	}
+
+	return nil
+}
+
+// getOVNKubernetesConfig returns the OVN-Kubernetes config of spec, initializing it when unset.
+func getOVNKubernetesConfig(spec *operatorV1.NetworkSpec) (*operatorV1.OVNKubernetesConfig, error) {
+	if spec.DefaultNetwork.Type != operatorV1.NetworkTypeOVNKubernetes {
+		glog.V(100).Infof("The default network type %s is not %s",
+			spec.DefaultNetwork.Type, operatorV1.NetworkTypeOVNKubernetes)
+
+		return nil, fmt.Errorf("default network type %s is not %s",
+			spec.DefaultNetwork.Type, operatorV1.NetworkTypeOVNKubernetes)
+	}
+
+	if spec.DefaultNetwork.OVNKubernetesConfig == nil {
+		spec.DefaultNetwork.OVNKubernetesConfig = &operatorV1.OVNKubernetesConfig{}
+	}
+
+	return spec.DefaultNetwork.OVNKubernetesConfig, nil
+}
+
+// getOVNKubernetesGatewayConfig returns the OVN-Kubernetes gateway config of spec, initializing it when unset.
+func getOVNKubernetesGatewayConfig(spec *operatorV1.NetworkSpec) (*operatorV1.GatewayConfig, error) {
+	ovnConfig, err := getOVNKubernetesConfig(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	if ovnConfig.GatewayConfig == nil {
+		ovnConfig.GatewayConfig = &operatorV1.GatewayConfig{}
+	}
+
+	return ovnConfig.GatewayConfig, nil
+}
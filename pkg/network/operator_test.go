@@ -0,0 +1,325 @@
+package network
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/openshift-kni/eco-goinfra/pkg/clients"
+	operatorv1 "github.com/openshift/api/operator/v1"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestPullOperator(t *testing.T) {
+	testCases := []struct {
+		addToRuntimeObjects bool
+		expectedError       error
+	}{
+		{
+			addToRuntimeObjects: true,
+			expectedError:       nil,
+		},
+		{
+			addToRuntimeObjects: false,
+			expectedError:       fmt.Errorf("network.operator object cluster does not exist"),
+		},
+	}
+
+	for _, testCase := range testCases {
+		var runtimeObjects []runtime.Object
+
+		if testCase.addToRuntimeObjects {
+			runtimeObjects = append(runtimeObjects, buildDummyNetworkOperator())
+		}
+
+		testSettings := clients.GetTestClients(clients.TestClientParams{K8sMockObjects: runtimeObjects})
+
+		testBuilder, err := PullOperator(testSettings)
+		assert.Equal(t, testCase.expectedError, err)
+
+		if testCase.expectedError == nil {
+			assert.Equal(t, clusterNetworkName, testBuilder.Definition.Name)
+		}
+	}
+}
+
+func TestOperatorSetAdditionalNetwork(t *testing.T) {
+	testCases := []struct {
+		additionalNetwork operatorv1.AdditionalNetworkDefinition
+		expectedError     error
+	}{
+		{
+			additionalNetwork: NewRawAdditionalNetwork("test", "test-ns", `{"cniVersion":"0.3.1","type":"bridge"}`),
+			expectedError:     nil,
+		},
+		{
+			additionalNetwork: NewSimpleMacvlanAdditionalNetwork(
+				"test", "test-ns", operatorv1.SimpleMacvlanConfig{Master: "eth1", Mode: operatorv1.MacvlanModeBridge}),
+			expectedError: nil,
+		},
+		{
+			additionalNetwork: NewRawAdditionalNetwork("", "test-ns", `{}`),
+			expectedError:     fmt.Errorf("additional network name can not be empty"),
+		},
+		{
+			additionalNetwork: NewRawAdditionalNetwork("test", "test-ns", ""),
+			expectedError:     fmt.Errorf("additional network test of type Raw must have a rawCNIConfig"),
+		},
+		{
+			additionalNetwork: NewRawAdditionalNetwork("test", "test-ns", "{"),
+			expectedError:     fmt.Errorf("additional network test rawCNIConfig is not valid JSON"),
+		},
+		{
+			additionalNetwork: operatorv1.AdditionalNetworkDefinition{Name: "test", Type: "Bridge"},
+			expectedError:     fmt.Errorf("invalid additional network type Bridge, must be one of Raw or SimpleMacvlan"),
+		},
+	}
+
+	for _, testCase := range testCases {
+		testBuilder := buildValidNetworkOperatorTestBuilder()
+
+		testBuilder, err := testBuilder.SetAdditionalNetwork(testCase.additionalNetwork, time.Second)
+		assert.Equal(t, testCase.expectedError, err)
+
+		if testCase.expectedError == nil {
+			assert.Equal(t, []operatorv1.AdditionalNetworkDefinition{
+				{Type: operatorv1.NetworkTypeRaw, Name: "existing", RawCNIConfig: "{}"},
+				testCase.additionalNetwork,
+			}, testBuilder.Object.Spec.AdditionalNetworks)
+		}
+	}
+
+	testBuilder, err := buildValidNetworkOperatorTestBuilder().SetAdditionalNetwork(
+		NewRawAdditionalNetwork("existing", "", `{"type":"bridge"}`), time.Second)
+	assert.Nil(t, err)
+	assert.Equal(t, []operatorv1.AdditionalNetworkDefinition{
+		{Type: operatorv1.NetworkTypeRaw, Name: "existing", RawCNIConfig: `{"type":"bridge"}`},
+	}, testBuilder.Object.Spec.AdditionalNetworks)
+
+	testBuilder, err = testBuilder.RemoveAdditionalNetwork("existing", "", time.Second)
+	assert.Nil(t, err)
+	assert.Empty(t, testBuilder.Object.Spec.AdditionalNetworks)
+}
+
+func TestOperatorSetOVNKubernetesOptions(t *testing.T) {
+	testCases := []struct {
+		apply         func(builder *OperatorBuilder) (*OperatorBuilder, error)
+		assertSpec    func(spec operatorv1.NetworkSpec)
+		expectedError error
+	}{
+		{
+			apply: func(builder *OperatorBuilder) (*OperatorBuilder, error) {
+				return builder.SetIPForwarding(operatorv1.IPForwardingGlobal, time.Second)
+			},
+			assertSpec: func(spec operatorv1.NetworkSpec) {
+				assert.Equal(t, operatorv1.IPForwardingGlobal,
+					spec.DefaultNetwork.OVNKubernetesConfig.GatewayConfig.IPForwarding)
+			},
+		},
+		{
+			apply: func(builder *OperatorBuilder) (*OperatorBuilder, error) {
+				return builder.SetIPForwarding("Local", time.Second)
+			},
+			expectedError: fmt.Errorf("invalid IP forwarding mode Local, must be one of Restricted or Global"),
+		},
+		{
+			apply: func(builder *OperatorBuilder) (*OperatorBuilder, error) {
+				return builder.SetGatewayInternalMasqueradeSubnets("169.254.0.0/17", "", time.Second)
+			},
+			assertSpec: func(spec operatorv1.NetworkSpec) {
+				gatewayConfig := spec.DefaultNetwork.OVNKubernetesConfig.GatewayConfig
+				assert.Equal(t, "169.254.0.0/17", gatewayConfig.IPv4.InternalMasqueradeSubnet)
+				assert.Equal(t, "", gatewayConfig.IPv6.InternalMasqueradeSubnet)
+			},
+		},
+		{
+			apply: func(builder *OperatorBuilder) (*OperatorBuilder, error) {
+				return builder.SetGatewayInternalMasqueradeSubnets("", "", time.Second)
+			},
+			expectedError: fmt.Errorf("at least one of ipv4Subnet or ipv6Subnet must be set"),
+		},
+		{
+			apply: func(builder *OperatorBuilder) (*OperatorBuilder, error) {
+				return builder.SetGatewayInternalMasqueradeSubnets("", "169.254.0.0/17", time.Second)
+			},
+			expectedError: fmt.Errorf("subnet 169.254.0.0/17 is not an IPv6 subnet"),
+		},
+		{
+			apply: func(builder *OperatorBuilder) (*OperatorBuilder, error) {
+				return builder.SetIPsecMode(operatorv1.IPsecModeFull, time.Second)
+			},
+			assertSpec: func(spec operatorv1.NetworkSpec) {
+				assert.Equal(t, operatorv1.IPsecModeFull, spec.DefaultNetwork.OVNKubernetesConfig.IPsecConfig.Mode)
+			},
+		},
+		{
+			apply: func(builder *OperatorBuilder) (*OperatorBuilder, error) {
+				return builder.SetIPsecMode("Partial", time.Second)
+			},
+			expectedError: fmt.Errorf("invalid IPsec mode Partial, must be one of Disabled, External or Full"),
+		},
+		{
+			apply: func(builder *OperatorBuilder) (*OperatorBuilder, error) {
+				return builder.SetEgressIPReachabilityTimeout(5, time.Second)
+			},
+			assertSpec: func(spec operatorv1.NetworkSpec) {
+				assert.Equal(t, uint32(5),
+					*spec.DefaultNetwork.OVNKubernetesConfig.EgressIPConfig.ReachabilityTotalTimeoutSeconds)
+			},
+		},
+		{
+			apply: func(builder *OperatorBuilder) (*OperatorBuilder, error) {
+				return builder.SetEgressIPReachabilityTimeout(61, time.Second)
+			},
+			expectedError: fmt.Errorf("egressIP reachability timeout 61 can not be greater than 60 seconds"),
+		},
+		{
+			apply: func(builder *OperatorBuilder) (*OperatorBuilder, error) {
+				builder.Definition.Spec.DefaultNetwork.Type = operatorv1.NetworkTypeOpenShiftSDN
+
+				return builder.SetIPsecMode(operatorv1.IPsecModeFull, time.Second)
+			},
+			expectedError: fmt.Errorf("default network type OpenShiftSDN is not OVNKubernetes"),
+		},
+	}
+
+	for _, testCase := range testCases {
+		testBuilder, err := testCase.apply(buildValidNetworkOperatorTestBuilder())
+		assert.Equal(t, testCase.expectedError, err)
+
+		if testCase.expectedError == nil {
+			testCase.assertSpec(testBuilder.Object.Spec)
+		}
+	}
+}
+
+func TestOperatorMTUMigration(t *testing.T) {
+	testBuilder := buildValidNetworkOperatorTestBuilder()
+
+	_, err := testBuilder.CompleteMTUMigration(1400, time.Second)
+	assert.Equal(t, fmt.Errorf("network.operator cluster has no MTU migration in progress"), err)
+
+	_, err = testBuilder.SetMTUMigration(1400, 0, 1500, 0, time.Second)
+	assert.Equal(t, fmt.Errorf("network MTU migration values can not be 0"), err)
+
+	_, err = testBuilder.SetMTUMigration(1400, 8900, 1500, 8900, time.Second)
+	assert.Equal(t, fmt.Errorf("machine MTU 8900 must be greater than network MTU 8900"), err)
+
+	testBuilder, err = testBuilder.SetMTUMigration(1400, 8900, 1500, 9000, time.Second)
+	assert.Nil(t, err)
+	assert.Equal(t, uint32(8900), *testBuilder.Object.Spec.Migration.MTU.Network.To)
+	assert.Equal(t, uint32(9000), *testBuilder.Object.Spec.Migration.MTU.Machine.To)
+
+	testBuilder, err = testBuilder.CompleteMTUMigration(8900, time.Second)
+	assert.Nil(t, err)
+	assert.Nil(t, testBuilder.Object.Spec.Migration)
+	assert.Equal(t, uint32(8900), *testBuilder.Object.Spec.DefaultNetwork.OVNKubernetesConfig.MTU)
+
+	testBuilder, err = testBuilder.SetMigrationEgressFeatures(true, false, time.Second)
+	assert.Nil(t, err)
+	assert.Equal(t, &operatorv1.FeaturesMigration{EgressIP: true, Multicast: true},
+		testBuilder.Object.Spec.Migration.Features)
+}
+
+func TestOperatorKubeProxy(t *testing.T) {
+	testBuilder, err := buildValidNetworkOperatorTestBuilder().SetDeployKubeProxy(true, time.Second)
+	assert.Nil(t, err)
+	assert.True(t, *testBuilder.Object.Spec.DeployKubeProxy)
+
+	kubeProxyConfig := operatorv1.ProxyConfig{IptablesSyncPeriod: "30s", BindAddress: "0.0.0.0"}
+	testBuilder, err = testBuilder.SetKubeProxyConfig(kubeProxyConfig, time.Second)
+	assert.Nil(t, err)
+	assert.Equal(t, &kubeProxyConfig, testBuilder.Object.Spec.KubeProxyConfig)
+
+	_, err = testBuilder.SetKubeProxyConfig(operatorv1.ProxyConfig{IptablesSyncPeriod: "30"}, time.Second)
+	assert.Equal(t, fmt.Errorf("invalid kube-proxy iptablesSyncPeriod 30"), err)
+
+	_, err = testBuilder.SetKubeProxyConfig(operatorv1.ProxyConfig{BindAddress: "any"}, time.Second)
+	assert.Equal(t, fmt.Errorf("invalid kube-proxy bindAddress any"), err)
+}
+
+func TestOperatorApplySpecChangeUnchanged(t *testing.T) {
+	testBuilder := buildValidNetworkOperatorTestBuilder()
+	resourceVersion := testBuilder.Definition.ResourceVersion
+
+	testBuilder, err := testBuilder.SetIPsecMode(operatorv1.IPsecModeDisabled, time.Second)
+	assert.Nil(t, err)
+	assert.Equal(t, resourceVersion, testBuilder.Definition.ResourceVersion)
+
+	testBuilder, err = testBuilder.RemoveAdditionalNetwork("missing", "", time.Second)
+	assert.Nil(t, err)
+	assert.Equal(t, resourceVersion, testBuilder.Definition.ResourceVersion)
+}
+
+func TestOperatorApplySpecChangeWithoutRollout(t *testing.T) {
+	networkOperator := buildDummyNetworkOperator()
+	networkOperator.Status.Conditions = []operatorv1.OperatorCondition{
+		{Type: operatorv1.OperatorStatusTypeProgressing, Status: operatorv1.ConditionFalse},
+		{Type: operatorv1.OperatorStatusTypeAvailable, Status: operatorv1.ConditionTrue},
+	}
+
+	testBuilder, err := PullOperator(clients.GetTestClients(clients.TestClientParams{
+		K8sMockObjects: []runtime.Object{networkOperator},
+	}))
+	assert.Nil(t, err)
+
+	kubeProxyConfig := operatorv1.ProxyConfig{IptablesSyncPeriod: "30s"}
+	testBuilder, err = testBuilder.applySpecChange(time.Second, time.Second, true,
+		func(spec *operatorv1.NetworkSpec) error {
+			spec.KubeProxyConfig = &kubeProxyConfig
+
+			return nil
+		})
+	assert.Nil(t, err)
+	assert.Equal(t, &kubeProxyConfig, testBuilder.Object.Spec.KubeProxyConfig)
+
+	_, err = testBuilder.applySpecChange(time.Second, time.Second, false,
+		func(spec *operatorv1.NetworkSpec) error {
+			spec.KubeProxyConfig = nil
+
+			return nil
+		})
+	assert.NotNil(t, err)
+}
+
+func buildValidNetworkOperatorTestBuilder() *OperatorBuilder {
+	testSettings := clients.GetTestClients(clients.TestClientParams{
+		K8sMockObjects: []runtime.Object{buildDummyNetworkOperator()},
+	})
+
+	testBuilder, _ := PullOperator(testSettings)
+
+	return testBuilder
+}
+
+// buildDummyNetworkOperator returns a network.operator which reports both progressing states so that every wait on
+// the rollout succeeds immediately.
+func buildDummyNetworkOperator() *operatorv1.Network {
+	return &operatorv1.Network{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: clusterNetworkName,
+		},
+		Spec: operatorv1.NetworkSpec{
+			AdditionalNetworks: []operatorv1.AdditionalNetworkDefinition{
+				{Type: operatorv1.NetworkTypeRaw, Name: "existing", RawCNIConfig: "{}"},
+			},
+			DefaultNetwork: operatorv1.DefaultNetworkDefinition{
+				Type: operatorv1.NetworkTypeOVNKubernetes,
+				OVNKubernetesConfig: &operatorv1.OVNKubernetesConfig{
+					IPsecConfig: &operatorv1.IPsecConfig{Mode: operatorv1.IPsecModeDisabled},
+				},
+			},
+		},
+		Status: operatorv1.NetworkStatus{
+			OperatorStatus: operatorv1.OperatorStatus{
+				Conditions: []operatorv1.OperatorCondition{
+					{Type: operatorv1.OperatorStatusTypeProgressing, Status: operatorv1.ConditionTrue},
+					{Type: operatorv1.OperatorStatusTypeProgressing, Status: operatorv1.ConditionFalse},
+					{Type: operatorv1.OperatorStatusTypeAvailable, Status: operatorv1.ConditionTrue},
+				},
+			},
+		},
+	}
+}
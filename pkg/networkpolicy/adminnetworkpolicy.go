@@ -0,0 +1,381 @@
+package networkpolicy
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/golang/glog"
+	"github.com/openshift-kni/eco-goinfra/pkg/clients"
+	"github.com/openshift-kni/eco-goinfra/pkg/msg"
+	"github.com/openshift-kni/eco-goinfra/pkg/schemes/networkpolicy/anptypes"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	goclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// adminNetworkPolicyMaxPriority is the highest priority value accepted by the AdminNetworkPolicy API.
+	adminNetworkPolicyMaxPriority = 1000
+	// adminNetworkPolicyMaxRuleNameLength is the longest rule name accepted by the AdminNetworkPolicy API.
+	adminNetworkPolicyMaxRuleNameLength = 100
+)
+
+// AdminNetworkPolicyBuilder provides struct for AdminNetworkPolicy object which contains connection to cluster and
+// AdminNetworkPolicy definition.
+type AdminNetworkPolicyBuilder struct {
+	// AdminNetworkPolicy definition. Used to create AdminNetworkPolicy object.
+	Definition *anptypes.AdminNetworkPolicy
+	// Created AdminNetworkPolicy object.
+	Object *anptypes.AdminNetworkPolicy
+	// Used in functions that define or mutate AdminNetworkPolicy definitions. errorMsg is processed before
+	// AdminNetworkPolicy object is created.
+	errorMsg string
+	// apiClient opens api connection to the cluster.
+	apiClient *clients.Settings
+}
+
+// AdminNetworkPolicyAdditionalOptions additional options for AdminNetworkPolicy object.
+type AdminNetworkPolicyAdditionalOptions func(builder *AdminNetworkPolicyBuilder) (*AdminNetworkPolicyBuilder, error)
+
+// NewAdminNetworkPolicyBuilder creates new instance of AdminNetworkPolicyBuilder. The priority ranges from 0 to 1000,
+// policies with lower values take precedence. The subject must be set using WithNamespaceSubject or WithPodSubject.
+func NewAdminNetworkPolicyBuilder(apiClient *clients.Settings, name string, priority int32) *AdminNetworkPolicyBuilder {
+	glog.V(100).Infof(
+		"Initializing new AdminNetworkPolicy structure with the following params: name: %s, priority: %d",
+		name, priority)
+
+	builder := AdminNetworkPolicyBuilder{
+		apiClient: apiClient,
+		Definition: &anptypes.AdminNetworkPolicy{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: name,
+			},
+			Spec: anptypes.AdminNetworkPolicySpec{
+				Priority: priority,
+			},
+		},
+	}
+
+	if name == "" {
+		glog.V(100).Infof("The name of the AdminNetworkPolicy is empty")
+
+		builder.errorMsg = "AdminNetworkPolicy 'name' cannot be empty"
+
+		return &builder
+	}
+
+	if priority < 0 || priority > adminNetworkPolicyMaxPriority {
+		glog.V(100).Infof("The priority of the AdminNetworkPolicy is out of range: %d", priority)
+
+		builder.errorMsg = fmt.Sprintf(
+			"AdminNetworkPolicy 'priority' must be between 0 and %d", adminNetworkPolicyMaxPriority)
+	}
+
+	return &builder
+}
+
+// WithNamespaceSubject sets the subject of the AdminNetworkPolicy to all pods in the namespaces matching the selector.
+func (builder *AdminNetworkPolicyBuilder) WithNamespaceSubject(
+	namespaceSelector metav1.LabelSelector) *AdminNetworkPolicyBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Setting AdminNetworkPolicy %s namespace subject: %v", builder.Definition.Name, namespaceSelector)
+
+	builder.Definition.Spec.Subject = anptypes.AdminNetworkPolicySubject{Namespaces: &namespaceSelector}
+
+	return builder
+}
+
+// WithPodSubject sets the subject of the AdminNetworkPolicy to the pods matching podSelector in the namespaces
+// matching namespaceSelector.
+func (builder *AdminNetworkPolicyBuilder) WithPodSubject(
+	namespaceSelector, podSelector metav1.LabelSelector) *AdminNetworkPolicyBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Setting AdminNetworkPolicy %s pod subject: namespaces %v, pods %v",
+		builder.Definition.Name, namespaceSelector, podSelector)
+
+	builder.Definition.Spec.Subject = anptypes.AdminNetworkPolicySubject{
+		Pods: &anptypes.NamespacedPod{NamespaceSelector: namespaceSelector, PodSelector: podSelector},
+	}
+
+	return builder
+}
+
+// WithIngressRule adds Ingress rule to the AdminNetworkPolicy. Rules are evaluated in the order they are added.
+func (builder *AdminNetworkPolicyBuilder) WithIngressRule(
+	ingressRule anptypes.AdminNetworkPolicyIngressRule) *AdminNetworkPolicyBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Adding Ingress rule %s to AdminNetworkPolicy %s", ingressRule.Name, builder.Definition.Name)
+
+	if err := validateAdminNetworkPolicyRule(
+		ingressRule.Name, string(ingressRule.Action), len(ingressRule.From), true); err != nil {
+		builder.errorMsg = err.Error()
+
+		return builder
+	}
+
+	builder.Definition.Spec.Ingress = append(builder.Definition.Spec.Ingress, ingressRule)
+
+	return builder
+}
+
+// WithEgressRule adds Egress rule to the AdminNetworkPolicy. Rules are evaluated in the order they are added.
+func (builder *AdminNetworkPolicyBuilder) WithEgressRule(
+	egressRule anptypes.AdminNetworkPolicyEgressRule) *AdminNetworkPolicyBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Adding Egress rule %s to AdminNetworkPolicy %s", egressRule.Name, builder.Definition.Name)
+
+	if err := validateAdminNetworkPolicyRule(
+		egressRule.Name, string(egressRule.Action), len(egressRule.To), true); err != nil {
+		builder.errorMsg = err.Error()
+
+		return builder
+	}
+
+	builder.Definition.Spec.Egress = append(builder.Definition.Spec.Egress, egressRule)
+
+	return builder
+}
+
+// WithOptions creates AdminNetworkPolicy with generic mutation options.
+func (builder *AdminNetworkPolicyBuilder) WithOptions(
+	options ...AdminNetworkPolicyAdditionalOptions) *AdminNetworkPolicyBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Setting AdminNetworkPolicy additional options")
+
+	for _, option := range options {
+		if option != nil {
+			builder, err := option(builder)
+
+			if err != nil {
+				glog.V(100).Infof("Error occurred in mutation function")
+
+				builder.errorMsg = err.Error()
+
+				return builder
+			}
+		}
+	}
+
+	return builder
+}
+
+// PullAdminNetworkPolicy pulls existing AdminNetworkPolicy from cluster.
+func PullAdminNetworkPolicy(apiClient *clients.Settings, name string) (*AdminNetworkPolicyBuilder, error) {
+	glog.V(100).Infof("Pulling existing AdminNetworkPolicy name %s from cluster", name)
+
+	if apiClient == nil {
+		glog.V(100).Infof("The apiClient is empty")
+
+		return nil, fmt.Errorf("AdminNetworkPolicy 'apiClient' cannot be empty")
+	}
+
+	builder := AdminNetworkPolicyBuilder{
+		apiClient: apiClient,
+		Definition: &anptypes.AdminNetworkPolicy{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: name,
+			},
+		},
+	}
+
+	if name == "" {
+		glog.V(100).Infof("The name of the AdminNetworkPolicy is empty")
+
+		return nil, fmt.Errorf("AdminNetworkPolicy 'name' cannot be empty")
+	}
+
+	if !builder.Exists() {
+		return nil, fmt.Errorf("AdminNetworkPolicy object %s does not exist", name)
+	}
+
+	builder.Definition = builder.Object
+
+	return &builder, nil
+}
+
+// Get returns AdminNetworkPolicy object if found.
+func (builder *AdminNetworkPolicyBuilder) Get() (*anptypes.AdminNetworkPolicy, error) {
+	if valid, err := builder.validate(); !valid {
+		return nil, err
+	}
+
+	glog.V(100).Infof("Collecting AdminNetworkPolicy object %s", builder.Definition.Name)
+
+	adminNetworkPolicy := &anptypes.AdminNetworkPolicy{}
+	err := builder.apiClient.Get(context.TODO(), goclient.ObjectKey{Name: builder.Definition.Name}, adminNetworkPolicy)
+
+	if err != nil {
+		glog.V(100).Infof("Failed to get AdminNetworkPolicy %s", builder.Definition.Name)
+
+		return nil, err
+	}
+
+	return adminNetworkPolicy, nil
+}
+
+// Exists checks whether the given AdminNetworkPolicy object exists in a cluster.
+func (builder *AdminNetworkPolicyBuilder) Exists() bool {
+	if valid, _ := builder.validate(); !valid {
+		return false
+	}
+
+	glog.V(100).Infof("Checking if AdminNetworkPolicy %s exists", builder.Definition.Name)
+
+	var err error
+	builder.Object, err = builder.Get()
+
+	return err == nil || !k8serrors.IsNotFound(err)
+}
+
+// Create generates AdminNetworkPolicy in a cluster and stores the created object in struct.
+func (builder *AdminNetworkPolicyBuilder) Create() (*AdminNetworkPolicyBuilder, error) {
+	if valid, err := builder.validate(); !valid {
+		return builder, err
+	}
+
+	glog.V(100).Infof("Creating the AdminNetworkPolicy %s", builder.Definition.Name)
+
+	if !builder.Exists() {
+		err := builder.apiClient.Create(context.TODO(), builder.Definition)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	builder.Object = builder.Definition
+
+	return builder, nil
+}
+
+// Delete removes AdminNetworkPolicy object.
+func (builder *AdminNetworkPolicyBuilder) Delete() error {
+	if valid, err := builder.validate(); !valid {
+		return err
+	}
+
+	glog.V(100).Infof("Deleting the AdminNetworkPolicy object %s", builder.Definition.Name)
+
+	if !builder.Exists() {
+		builder.Object = nil
+
+		return nil
+	}
+
+	err := builder.apiClient.Delete(context.TODO(), builder.Object)
+
+	if err != nil {
+		return fmt.Errorf("cannot delete AdminNetworkPolicy: %w", err)
+	}
+
+	builder.Object = nil
+
+	return nil
+}
+
+// Update renovates the existing AdminNetworkPolicy object with the AdminNetworkPolicy definition in builder.
+func (builder *AdminNetworkPolicyBuilder) Update() (*AdminNetworkPolicyBuilder, error) {
+	if valid, err := builder.validate(); !valid {
+		return builder, err
+	}
+
+	glog.V(100).Infof("Updating the AdminNetworkPolicy object %s", builder.Definition.Name)
+
+	if !builder.Exists() {
+		return nil, fmt.Errorf("failed to update AdminNetworkPolicy, object does not exist on cluster")
+	}
+
+	builder.Definition.ResourceVersion = builder.Object.ResourceVersion
+
+	err := builder.apiClient.Update(context.TODO(), builder.Definition)
+	if err != nil {
+		return nil, err
+	}
+
+	builder.Object = builder.Definition
+
+	return builder, nil
+}
+
+// validate will check that the builder and builder definition are properly initialized before
+// accessing any member fields.
+func (builder *AdminNetworkPolicyBuilder) validate() (bool, error) {
+	resourceCRD := "AdminNetworkPolicy"
+
+	if builder == nil {
+		glog.V(100).Infof("The %s builder is uninitialized", resourceCRD)
+
+		return false, fmt.Errorf("error: received nil %s builder", resourceCRD)
+	}
+
+	if builder.Definition == nil {
+		glog.V(100).Infof("The %s is undefined", resourceCRD)
+
+		builder.errorMsg = msg.UndefinedCrdObjectErrString(resourceCRD)
+	}
+
+	if builder.apiClient == nil {
+		glog.V(100).Infof("The %s builder apiclient is nil", resourceCRD)
+
+		builder.errorMsg = fmt.Sprintf("%s builder cannot have nil apiClient", resourceCRD)
+	}
+
+	if builder.errorMsg != "" {
+		glog.V(100).Infof("The %s builder has error message: %s", resourceCRD, builder.errorMsg)
+
+		return false, fmt.Errorf(builder.errorMsg)
+	}
+
+	return true, nil
+}
+
+// validateAdminNetworkPolicyRule checks the fields shared by AdminNetworkPolicy and BaselineAdminNetworkPolicy rules.
+// The Pass action is only valid for AdminNetworkPolicy rules.
+func validateAdminNetworkPolicyRule(name, action string, peerCount int, allowPass bool) error {
+	if len(name) > adminNetworkPolicyMaxRuleNameLength {
+		glog.V(100).Infof("The rule name %s is longer than %d characters", name, adminNetworkPolicyMaxRuleNameLength)
+
+		return fmt.Errorf("rule name cannot be longer than %d characters", adminNetworkPolicyMaxRuleNameLength)
+	}
+
+	switch action {
+	case string(anptypes.AdminNetworkPolicyRuleActionAllow), string(anptypes.AdminNetworkPolicyRuleActionDeny):
+	case string(anptypes.AdminNetworkPolicyRuleActionPass):
+		if !allowPass {
+			glog.V(100).Infof("The rule %s uses the Pass action which is not allowed", name)
+
+			return fmt.Errorf("rule %s action must be one of Allow or Deny", name)
+		}
+	default:
+		glog.V(100).Infof("The rule %s has invalid action %s", name, action)
+
+		if allowPass {
+			return fmt.Errorf("rule %s action must be one of Allow, Deny or Pass", name)
+		}
+
+		return fmt.Errorf("rule %s action must be one of Allow or Deny", name)
+	}
+
+	if peerCount == 0 {
+		glog.V(100).Infof("The rule %s has no peers", name)
+
+		return fmt.Errorf("rule %s must have at least one peer", name)
+	}
+
+	return nil
+}
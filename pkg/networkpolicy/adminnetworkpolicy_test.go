@@ -0,0 +1,253 @@
+package networkpolicy
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/openshift-kni/eco-goinfra/pkg/clients"
+	"github.com/openshift-kni/eco-goinfra/pkg/schemes/networkpolicy/anptypes"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+var defaultAdminNetworkPolicyName = "anp-test"
+
+func TestNewAdminNetworkPolicyBuilder(t *testing.T) {
+	testCases := []struct {
+		name          string
+		priority      int32
+		client        bool
+		expectedError string
+	}{
+		{
+			name:          defaultAdminNetworkPolicyName,
+			priority:      10,
+			client:        true,
+			expectedError: "",
+		},
+		{
+			name:          "",
+			priority:      10,
+			client:        true,
+			expectedError: "AdminNetworkPolicy 'name' cannot be empty",
+		},
+		{
+			name:          defaultAdminNetworkPolicyName,
+			priority:      1001,
+			client:        true,
+			expectedError: "AdminNetworkPolicy 'priority' must be between 0 and 1000",
+		},
+		{
+			name:          defaultAdminNetworkPolicyName,
+			priority:      -1,
+			client:        true,
+			expectedError: "AdminNetworkPolicy 'priority' must be between 0 and 1000",
+		},
+	}
+
+	for _, testCase := range testCases {
+		testBuilder := NewAdminNetworkPolicyBuilder(
+			clients.GetTestClients(clients.TestClientParams{}), testCase.name, testCase.priority)
+		assert.Equal(t, testCase.expectedError, testBuilder.errorMsg)
+
+		if testCase.expectedError == "" {
+			assert.Equal(t, testCase.name, testBuilder.Definition.Name)
+			assert.Equal(t, testCase.priority, testBuilder.Definition.Spec.Priority)
+		}
+	}
+
+	testBuilder := NewAdminNetworkPolicyBuilder(nil, defaultAdminNetworkPolicyName, 10)
+	_, err := testBuilder.Create()
+	assert.Equal(t, fmt.Errorf("AdminNetworkPolicy builder cannot have nil apiClient"), err)
+}
+
+func TestAdminNetworkPolicySubject(t *testing.T) {
+	namespaceSelector := metav1.LabelSelector{MatchLabels: map[string]string{"env": "test"}}
+	podSelector := metav1.LabelSelector{MatchLabels: map[string]string{"app": "server"}}
+
+	testBuilder := buildValidAdminNetworkPolicyTestBuilder(clients.GetTestClients(clients.TestClientParams{})).
+		WithNamespaceSubject(namespaceSelector)
+	assert.Equal(t, &namespaceSelector, testBuilder.Definition.Spec.Subject.Namespaces)
+	assert.Nil(t, testBuilder.Definition.Spec.Subject.Pods)
+
+	testBuilder.WithPodSubject(namespaceSelector, podSelector)
+	assert.Nil(t, testBuilder.Definition.Spec.Subject.Namespaces)
+	assert.Equal(t, &anptypes.NamespacedPod{NamespaceSelector: namespaceSelector, PodSelector: podSelector},
+		testBuilder.Definition.Spec.Subject.Pods)
+}
+
+func TestAdminNetworkPolicyWithIngressRule(t *testing.T) {
+	testCases := []struct {
+		rule          anptypes.AdminNetworkPolicyIngressRule
+		expectedError string
+	}{
+		{
+			rule:          buildDummyAdminNetworkPolicyIngressRule("allow-monitoring", "Allow"),
+			expectedError: "",
+		},
+		{
+			rule:          buildDummyAdminNetworkPolicyIngressRule("pass-monitoring", "Pass"),
+			expectedError: "",
+		},
+		{
+			rule:          buildDummyAdminNetworkPolicyIngressRule("reject", "Reject"),
+			expectedError: "rule reject action must be one of Allow, Deny or Pass",
+		},
+		{
+			rule:          buildDummyAdminNetworkPolicyIngressRule(strings.Repeat("a", 101), "Deny"),
+			expectedError: "rule name cannot be longer than 100 characters",
+		},
+		{
+			rule:          anptypes.AdminNetworkPolicyIngressRule{Name: "no-peers", Action: "Deny"},
+			expectedError: "rule no-peers must have at least one peer",
+		},
+	}
+
+	for _, testCase := range testCases {
+		testBuilder := buildValidAdminNetworkPolicyTestBuilder(clients.GetTestClients(clients.TestClientParams{})).
+			WithIngressRule(testCase.rule)
+		assert.Equal(t, testCase.expectedError, testBuilder.errorMsg)
+
+		if testCase.expectedError == "" {
+			assert.Equal(t, []anptypes.AdminNetworkPolicyIngressRule{testCase.rule},
+				testBuilder.Definition.Spec.Ingress)
+		}
+	}
+}
+
+func TestAdminNetworkPolicyWithEgressRule(t *testing.T) {
+	rule := anptypes.AdminNetworkPolicyEgressRule{
+		Name:   "deny-external",
+		Action: anptypes.AdminNetworkPolicyRuleActionDeny,
+		To:     []anptypes.AdminNetworkPolicyEgressPeer{{Networks: []anptypes.CIDR{"0.0.0.0/0"}}},
+	}
+
+	testBuilder := buildValidAdminNetworkPolicyTestBuilder(clients.GetTestClients(clients.TestClientParams{})).
+		WithEgressRule(rule)
+	assert.Equal(t, "", testBuilder.errorMsg)
+	assert.Equal(t, []anptypes.AdminNetworkPolicyEgressRule{rule}, testBuilder.Definition.Spec.Egress)
+
+	testBuilder = buildValidAdminNetworkPolicyTestBuilder(clients.GetTestClients(clients.TestClientParams{})).
+		WithEgressRule(anptypes.AdminNetworkPolicyEgressRule{Name: "no-peers", Action: "Allow"})
+	assert.Equal(t, "rule no-peers must have at least one peer", testBuilder.errorMsg)
+}
+
+func TestAdminNetworkPolicyWithOptions(t *testing.T) {
+	testBuilder := buildValidAdminNetworkPolicyTestBuilder(clients.GetTestClients(clients.TestClientParams{})).
+		WithOptions(func(builder *AdminNetworkPolicyBuilder) (*AdminNetworkPolicyBuilder, error) {
+			return builder, fmt.Errorf("error")
+		})
+	assert.Equal(t, "error", testBuilder.errorMsg)
+}
+
+func TestPullAdminNetworkPolicy(t *testing.T) {
+	testCases := []struct {
+		name                string
+		addToRuntimeObjects bool
+		client              bool
+		expectedError       error
+	}{
+		{
+			name:                defaultAdminNetworkPolicyName,
+			addToRuntimeObjects: true,
+			client:              true,
+			expectedError:       nil,
+		},
+		{
+			name:                "",
+			addToRuntimeObjects: true,
+			client:              true,
+			expectedError:       fmt.Errorf("AdminNetworkPolicy 'name' cannot be empty"),
+		},
+		{
+			name:                defaultAdminNetworkPolicyName,
+			addToRuntimeObjects: false,
+			client:              true,
+			expectedError:       fmt.Errorf("AdminNetworkPolicy object %s does not exist", defaultAdminNetworkPolicyName),
+		},
+		{
+			name:                defaultAdminNetworkPolicyName,
+			addToRuntimeObjects: true,
+			client:              false,
+			expectedError:       fmt.Errorf("AdminNetworkPolicy 'apiClient' cannot be empty"),
+		},
+	}
+
+	for _, testCase := range testCases {
+		var (
+			runtimeObjects []runtime.Object
+			testSettings   *clients.Settings
+		)
+
+		if testCase.addToRuntimeObjects {
+			runtimeObjects = buildDummyAdminNetworkPolicyObject()
+		}
+
+		if testCase.client {
+			testSettings = clients.GetTestClients(clients.TestClientParams{K8sMockObjects: runtimeObjects})
+		}
+
+		testBuilder, err := PullAdminNetworkPolicy(testSettings, testCase.name)
+		assert.Equal(t, testCase.expectedError, err)
+
+		if testCase.expectedError == nil {
+			assert.Equal(t, int32(20), testBuilder.Definition.Spec.Priority)
+		}
+	}
+}
+
+func TestAdminNetworkPolicyCreateUpdateDelete(t *testing.T) {
+	testSettings := clients.GetTestClients(clients.TestClientParams{})
+
+	testBuilder, err := buildValidAdminNetworkPolicyTestBuilder(testSettings).
+		WithNamespaceSubject(metav1.LabelSelector{}).
+		Create()
+	assert.Nil(t, err)
+	assert.True(t, testBuilder.Exists())
+
+	testBuilder, err = testBuilder.
+		WithIngressRule(buildDummyAdminNetworkPolicyIngressRule("allow-monitoring", "Allow")).
+		Update()
+	assert.Nil(t, err)
+	assert.Len(t, testBuilder.Object.Spec.Ingress, 1)
+
+	err = testBuilder.Delete()
+	assert.Nil(t, err)
+	assert.Nil(t, testBuilder.Object)
+	assert.False(t, testBuilder.Exists())
+
+	_, err = buildValidAdminNetworkPolicyTestBuilder(testSettings).Update()
+	assert.Equal(t, fmt.Errorf("failed to update AdminNetworkPolicy, object does not exist on cluster"), err)
+}
+
+func buildValidAdminNetworkPolicyTestBuilder(apiClient *clients.Settings) *AdminNetworkPolicyBuilder {
+	return NewAdminNetworkPolicyBuilder(apiClient, defaultAdminNetworkPolicyName, 10)
+}
+
+func buildDummyAdminNetworkPolicyIngressRule(name, action string) anptypes.AdminNetworkPolicyIngressRule {
+	return anptypes.AdminNetworkPolicyIngressRule{
+		Name:   name,
+		Action: anptypes.AdminNetworkPolicyRuleAction(action),
+		From: []anptypes.AdminNetworkPolicyIngressPeer{{
+			Namespaces: &metav1.LabelSelector{MatchLabels: map[string]string{"name": "monitoring"}},
+		}},
+		Ports: &[]anptypes.AdminNetworkPolicyPort{{
+			PortRange: &anptypes.PortRange{Protocol: corev1.ProtocolTCP, Start: 9000, End: 9100},
+		}},
+	}
+}
+
+func buildDummyAdminNetworkPolicyObject() []runtime.Object {
+	return append([]runtime.Object{}, &anptypes.AdminNetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: defaultAdminNetworkPolicyName,
+		},
+		Spec: anptypes.AdminNetworkPolicySpec{
+			Priority: 20,
+			Subject:  anptypes.AdminNetworkPolicySubject{Namespaces: &metav1.LabelSelector{}},
+		},
+	})
+}
@@ -0,0 +1,324 @@
+package networkpolicy
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/golang/glog"
+	"github.com/openshift-kni/eco-goinfra/pkg/clients"
+	"github.com/openshift-kni/eco-goinfra/pkg/msg"
+	"github.com/openshift-kni/eco-goinfra/pkg/schemes/networkpolicy/anptypes"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	goclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// BaselineAdminNetworkPolicyName is the only name accepted for the cluster singleton BaselineAdminNetworkPolicy.
+	BaselineAdminNetworkPolicyName = "default"
+)
+
+// BaselineAdminNetworkPolicyBuilder provides struct for BaselineAdminNetworkPolicy object which contains connection to
+// cluster and BaselineAdminNetworkPolicy definition.
+type BaselineAdminNetworkPolicyBuilder struct {
+	// BaselineAdminNetworkPolicy definition. Used to create BaselineAdminNetworkPolicy object.
+	Definition *anptypes.BaselineAdminNetworkPolicy
+	// Created BaselineAdminNetworkPolicy object.
+	Object *anptypes.BaselineAdminNetworkPolicy
+	// Used in functions that define or mutate BaselineAdminNetworkPolicy definitions. errorMsg is processed before
+	// BaselineAdminNetworkPolicy object is created.
+	errorMsg string
+	// apiClient opens api connection to the cluster.
+	apiClient *clients.Settings
+}
+
+// BaselineAdminNetworkPolicyAdditionalOptions additional options for BaselineAdminNetworkPolicy object.
+type BaselineAdminNetworkPolicyAdditionalOptions func(
+	builder *BaselineAdminNetworkPolicyBuilder) (*BaselineAdminNetworkPolicyBuilder, error)
+
+// NewBaselineAdminNetworkPolicyBuilder creates new instance of BaselineAdminNetworkPolicyBuilder. Only a single
+// BaselineAdminNetworkPolicy named default may exist, which is why the name is not configurable. The subject must be
+// set using WithNamespaceSubject or WithPodSubject.
+func NewBaselineAdminNetworkPolicyBuilder(apiClient *clients.Settings) *BaselineAdminNetworkPolicyBuilder {
+	glog.V(100).Infof(
+		"Initializing new BaselineAdminNetworkPolicy structure with the name %s", BaselineAdminNetworkPolicyName)
+
+	builder := BaselineAdminNetworkPolicyBuilder{
+		apiClient: apiClient,
+		Definition: &anptypes.BaselineAdminNetworkPolicy{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: BaselineAdminNetworkPolicyName,
+			},
+		},
+	}
+
+	return &builder
+}
+
+// WithNamespaceSubject sets the subject of the BaselineAdminNetworkPolicy to all pods in the namespaces matching the
+// selector.
+func (builder *BaselineAdminNetworkPolicyBuilder) WithNamespaceSubject(
+	namespaceSelector metav1.LabelSelector) *BaselineAdminNetworkPolicyBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Setting BaselineAdminNetworkPolicy %s namespace subject: %v",
+		builder.Definition.Name, namespaceSelector)
+
+	builder.Definition.Spec.Subject = anptypes.AdminNetworkPolicySubject{Namespaces: &namespaceSelector}
+
+	return builder
+}
+
+// WithPodSubject sets the subject of the BaselineAdminNetworkPolicy to the pods matching podSelector in the namespaces
+// matching namespaceSelector.
+func (builder *BaselineAdminNetworkPolicyBuilder) WithPodSubject(
+	namespaceSelector, podSelector metav1.LabelSelector) *BaselineAdminNetworkPolicyBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Setting BaselineAdminNetworkPolicy %s pod subject: namespaces %v, pods %v",
+		builder.Definition.Name, namespaceSelector, podSelector)
+
+	builder.Definition.Spec.Subject = anptypes.AdminNetworkPolicySubject{
+		Pods: &anptypes.NamespacedPod{NamespaceSelector: namespaceSelector, PodSelector: podSelector},
+	}
+
+	return builder
+}
+
+// WithIngressRule adds Ingress rule to the BaselineAdminNetworkPolicy. Rules are evaluated in the order they are added.
+func (builder *BaselineAdminNetworkPolicyBuilder) WithIngressRule(
+	ingressRule anptypes.BaselineAdminNetworkPolicyIngressRule) *BaselineAdminNetworkPolicyBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Adding Ingress rule %s to BaselineAdminNetworkPolicy %s", ingressRule.Name, builder.Definition.Name)
+
+	if err := validateAdminNetworkPolicyRule(
+		ingressRule.Name, string(ingressRule.Action), len(ingressRule.From), false); err != nil {
+		builder.errorMsg = err.Error()
+
+		return builder
+	}
+
+	builder.Definition.Spec.Ingress = append(builder.Definition.Spec.Ingress, ingressRule)
+
+	return builder
+}
+
+// WithEgressRule adds Egress rule to the BaselineAdminNetworkPolicy. Rules are evaluated in the order they are added.
+func (builder *BaselineAdminNetworkPolicyBuilder) WithEgressRule(
+	egressRule anptypes.BaselineAdminNetworkPolicyEgressRule) *BaselineAdminNetworkPolicyBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Adding Egress rule %s to BaselineAdminNetworkPolicy %s", egressRule.Name, builder.Definition.Name)
+
+	if err := validateAdminNetworkPolicyRule(
+		egressRule.Name, string(egressRule.Action), len(egressRule.To), false); err != nil {
+		builder.errorMsg = err.Error()
+
+		return builder
+	}
+
+	builder.Definition.Spec.Egress = append(builder.Definition.Spec.Egress, egressRule)
+
+	return builder
+}
+
+// WithOptions creates BaselineAdminNetworkPolicy with generic mutation options.
+func (builder *BaselineAdminNetworkPolicyBuilder) WithOptions(
+	options ...BaselineAdminNetworkPolicyAdditionalOptions) *BaselineAdminNetworkPolicyBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Setting BaselineAdminNetworkPolicy additional options")
+
+	for _, option := range options {
+		if option != nil {
+			builder, err := option(builder)
+
+			if err != nil {
+				glog.V(100).Infof("Error occurred in mutation function")
+
+				builder.errorMsg = err.Error()
+
+				return builder
+			}
+		}
+	}
+
+	return builder
+}
+
+// PullBaselineAdminNetworkPolicy pulls the existing default BaselineAdminNetworkPolicy from cluster.
+func PullBaselineAdminNetworkPolicy(apiClient *clients.Settings) (*BaselineAdminNetworkPolicyBuilder, error) {
+	glog.V(100).Infof("Pulling existing BaselineAdminNetworkPolicy %s from cluster", BaselineAdminNetworkPolicyName)
+
+	if apiClient == nil {
+		glog.V(100).Infof("The apiClient is empty")
+
+		return nil, fmt.Errorf("BaselineAdminNetworkPolicy 'apiClient' cannot be empty")
+	}
+
+	builder := BaselineAdminNetworkPolicyBuilder{
+		apiClient: apiClient,
+		Definition: &anptypes.BaselineAdminNetworkPolicy{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: BaselineAdminNetworkPolicyName,
+			},
+		},
+	}
+
+	if !builder.Exists() {
+		return nil, fmt.Errorf("BaselineAdminNetworkPolicy object %s does not exist", BaselineAdminNetworkPolicyName)
+	}
+
+	builder.Definition = builder.Object
+
+	return &builder, nil
+}
+
+// Get returns BaselineAdminNetworkPolicy object if found.
+func (builder *BaselineAdminNetworkPolicyBuilder) Get() (*anptypes.BaselineAdminNetworkPolicy, error) {
+	if valid, err := builder.validate(); !valid {
+		return nil, err
+	}
+
+	glog.V(100).Infof("Collecting BaselineAdminNetworkPolicy object %s", builder.Definition.Name)
+
+	baselineAdminNetworkPolicy := &anptypes.BaselineAdminNetworkPolicy{}
+	err := builder.apiClient.Get(
+		context.TODO(), goclient.ObjectKey{Name: builder.Definition.Name}, baselineAdminNetworkPolicy)
+
+	if err != nil {
+		glog.V(100).Infof("Failed to get BaselineAdminNetworkPolicy %s", builder.Definition.Name)
+
+		return nil, err
+	}
+
+	return baselineAdminNetworkPolicy, nil
+}
+
+// Exists checks whether the given BaselineAdminNetworkPolicy object exists in a cluster.
+func (builder *BaselineAdminNetworkPolicyBuilder) Exists() bool {
+	if valid, _ := builder.validate(); !valid {
+		return false
+	}
+
+	glog.V(100).Infof("Checking if BaselineAdminNetworkPolicy %s exists", builder.Definition.Name)
+
+	var err error
+	builder.Object, err = builder.Get()
+
+	return err == nil || !k8serrors.IsNotFound(err)
+}
+
+// Create generates BaselineAdminNetworkPolicy in a cluster and stores the created object in struct.
+func (builder *BaselineAdminNetworkPolicyBuilder) Create() (*BaselineAdminNetworkPolicyBuilder, error) {
+	if valid, err := builder.validate(); !valid {
+		return builder, err
+	}
+
+	glog.V(100).Infof("Creating the BaselineAdminNetworkPolicy %s", builder.Definition.Name)
+
+	if !builder.Exists() {
+		err := builder.apiClient.Create(context.TODO(), builder.Definition)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	builder.Object = builder.Definition
+
+	return builder, nil
+}
+
+// Delete removes BaselineAdminNetworkPolicy object.
+func (builder *BaselineAdminNetworkPolicyBuilder) Delete() error {
+	if valid, err := builder.validate(); !valid {
+		return err
+	}
+
+	glog.V(100).Infof("Deleting the BaselineAdminNetworkPolicy object %s", builder.Definition.Name)
+
+	if !builder.Exists() {
+		builder.Object = nil
+
+		return nil
+	}
+
+	err := builder.apiClient.Delete(context.TODO(), builder.Object)
+
+	if err != nil {
+		return fmt.Errorf("cannot delete BaselineAdminNetworkPolicy: %w", err)
+	}
+
+	builder.Object = nil
+
+	return nil
+}
+
+// Update renovates the existing BaselineAdminNetworkPolicy object with the BaselineAdminNetworkPolicy definition in
+// builder.
+func (builder *BaselineAdminNetworkPolicyBuilder) Update() (*BaselineAdminNetworkPolicyBuilder, error) {
+	if valid, err := builder.validate(); !valid {
+		return builder, err
+	}
+
+	glog.V(100).Infof("Updating the BaselineAdminNetworkPolicy object %s", builder.Definition.Name)
+
+	if !builder.Exists() {
+		return nil, fmt.Errorf("failed to update BaselineAdminNetworkPolicy, object does not exist on cluster")
+	}
+
+	builder.Definition.ResourceVersion = builder.Object.ResourceVersion
+
+	err := builder.apiClient.Update(context.TODO(), builder.Definition)
+	if err != nil {
+		return nil, err
+	}
+
+	builder.Object = builder.Definition
+
+	return builder, nil
+}
+
+// validate will check that the builder and builder definition are properly initialized before
+// accessing any member fields.
+func (builder *BaselineAdminNetworkPolicyBuilder) validate() (bool, error) {
+	resourceCRD := "BaselineAdminNetworkPolicy"
+
+	if builder == nil {
+		glog.V(100).Infof("The %s builder is uninitialized", resourceCRD)
+
+		return false, fmt.Errorf("error: received nil %s builder", resourceCRD)
+	}
+
+	if builder.Definition == nil {
+		glog.V(100).Infof("The %s is undefined", resourceCRD)
+
+		builder.errorMsg = msg.UndefinedCrdObjectErrString(resourceCRD)
+	}
+
+	if builder.apiClient == nil {
+		glog.V(100).Infof("The %s builder apiclient is nil", resourceCRD)
+
+		builder.errorMsg = fmt.Sprintf("%s builder cannot have nil apiClient", resourceCRD)
+	}
+
+	if builder.errorMsg != "" {
+		glog.V(100).Infof("The %s builder has error message: %s", resourceCRD, builder.errorMsg)
+
+		return false, fmt.Errorf(builder.errorMsg)
+	}
+
+	return true, nil
+}
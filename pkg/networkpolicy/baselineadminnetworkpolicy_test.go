@@ -0,0 +1,107 @@
+package networkpolicy
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/openshift-kni/eco-goinfra/pkg/clients"
+	"github.com/openshift-kni/eco-goinfra/pkg/schemes/networkpolicy/anptypes"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestNewBaselineAdminNetworkPolicyBuilder(t *testing.T) {
+	testBuilder := NewBaselineAdminNetworkPolicyBuilder(clients.GetTestClients(clients.TestClientParams{}))
+	assert.Equal(t, "", testBuilder.errorMsg)
+	assert.Equal(t, BaselineAdminNetworkPolicyName, testBuilder.Definition.Name)
+
+	_, err := NewBaselineAdminNetworkPolicyBuilder(nil).Create()
+	assert.Equal(t, fmt.Errorf("BaselineAdminNetworkPolicy builder cannot have nil apiClient"), err)
+}
+
+func TestBaselineAdminNetworkPolicyWithRules(t *testing.T) {
+	ingressRule := anptypes.BaselineAdminNetworkPolicyIngressRule{
+		Name:   "deny-all-ingress",
+		Action: anptypes.BaselineAdminNetworkPolicyRuleActionDeny,
+		From:   []anptypes.AdminNetworkPolicyIngressPeer{{Namespaces: &metav1.LabelSelector{}}},
+	}
+	egressRule := anptypes.BaselineAdminNetworkPolicyEgressRule{
+		Name:   "allow-dns",
+		Action: anptypes.BaselineAdminNetworkPolicyRuleActionAllow,
+		To: []anptypes.AdminNetworkPolicyEgressPeer{{
+			Pods: &anptypes.NamespacedPod{
+				NamespaceSelector: metav1.LabelSelector{
+					MatchLabels: map[string]string{"kubernetes.io/metadata.name": "openshift-dns"},
+				},
+			},
+		}},
+	}
+
+	testBuilder := NewBaselineAdminNetworkPolicyBuilder(clients.GetTestClients(clients.TestClientParams{})).
+		WithPodSubject(metav1.LabelSelector{}, metav1.LabelSelector{MatchLabels: map[string]string{"app": "test"}}).
+		WithIngressRule(ingressRule).
+		WithEgressRule(egressRule)
+	assert.Equal(t, "", testBuilder.errorMsg)
+	assert.Equal(t, []anptypes.BaselineAdminNetworkPolicyIngressRule{ingressRule}, testBuilder.Definition.Spec.Ingress)
+	assert.Equal(t, []anptypes.BaselineAdminNetworkPolicyEgressRule{egressRule}, testBuilder.Definition.Spec.Egress)
+
+	ingressRule.Action = "Pass"
+	testBuilder = NewBaselineAdminNetworkPolicyBuilder(clients.GetTestClients(clients.TestClientParams{})).
+		WithIngressRule(ingressRule)
+	assert.Equal(t, "rule deny-all-ingress action must be one of Allow or Deny", testBuilder.errorMsg)
+
+	testBuilder = NewBaselineAdminNetworkPolicyBuilder(clients.GetTestClients(clients.TestClientParams{})).
+		WithOptions(func(builder *BaselineAdminNetworkPolicyBuilder) (*BaselineAdminNetworkPolicyBuilder, error) {
+			return builder, fmt.Errorf("error")
+		})
+	assert.Equal(t, "error", testBuilder.errorMsg)
+}
+
+func TestPullBaselineAdminNetworkPolicy(t *testing.T) {
+	testSettings := clients.GetTestClients(clients.TestClientParams{
+		K8sMockObjects: buildDummyBaselineAdminNetworkPolicyObject()})
+
+	testBuilder, err := PullBaselineAdminNetworkPolicy(testSettings)
+	assert.Nil(t, err)
+	assert.NotNil(t, testBuilder.Definition.Spec.Subject.Namespaces)
+
+	_, err = PullBaselineAdminNetworkPolicy(clients.GetTestClients(clients.TestClientParams{}))
+	assert.Equal(t, fmt.Errorf("BaselineAdminNetworkPolicy object default does not exist"), err)
+
+	_, err = PullBaselineAdminNetworkPolicy(nil)
+	assert.Equal(t, fmt.Errorf("BaselineAdminNetworkPolicy 'apiClient' cannot be empty"), err)
+}
+
+func TestBaselineAdminNetworkPolicyCreateUpdateDelete(t *testing.T) {
+	testSettings := clients.GetTestClients(clients.TestClientParams{})
+
+	testBuilder, err := NewBaselineAdminNetworkPolicyBuilder(testSettings).
+		WithNamespaceSubject(metav1.LabelSelector{}).
+		Create()
+	assert.Nil(t, err)
+	assert.True(t, testBuilder.Exists())
+
+	testBuilder, err = testBuilder.WithEgressRule(anptypes.BaselineAdminNetworkPolicyEgressRule{
+		Action: anptypes.BaselineAdminNetworkPolicyRuleActionDeny,
+		To:     []anptypes.AdminNetworkPolicyEgressPeer{{Networks: []anptypes.CIDR{"0.0.0.0/0"}}},
+	}).Update()
+	assert.Nil(t, err)
+	assert.Len(t, testBuilder.Object.Spec.Egress, 1)
+
+	err = testBuilder.Delete()
+	assert.Nil(t, err)
+	assert.Nil(t, testBuilder.Object)
+	assert.False(t, testBuilder.Exists())
+}
+
+func buildDummyBaselineAdminNetworkPolicyObject() []runtime.Object {
+	return append([]runtime.Object{}, &anptypes.BaselineAdminNetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: BaselineAdminNetworkPolicyName,
+		},
+		Spec: anptypes.BaselineAdminNetworkPolicySpec{
+			Subject: anptypes.AdminNetworkPolicySubject{Namespaces: &metav1.LabelSelector{}},
+		},
+	})
+}
@@ -0,0 +1,655 @@
+package networkpolicy
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+	nadV1 "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1"
+	"github.com/openshift-kni/eco-goinfra/pkg/pod"
+	corev1 "k8s.io/api/core/v1"
+	utilexec "k8s.io/client-go/util/exec"
+)
+
+const (
+	defaultConnectivityProbeTimeout = 2 * time.Second
+	defaultConnectivityParallelism  = 10
+	// probeCommandNotExecutableCode and probeCommandNotFoundCode are the exit codes of a command which could not be
+	// run, as opposed to a probe which ran and failed to reach the destination.
+	probeCommandNotExecutableCode = 126
+	probeCommandNotFoundCode      = 127
+)
+
+// ConnectivityPort is a port and protocol probed by the ConnectivityTester.
+type ConnectivityPort struct {
+	Port     uint16
+	Protocol corev1.Protocol
+}
+
+// String returns the port in the protocol/port format, e.g. TCP/80.
+func (port ConnectivityPort) String() string {
+	return fmt.Sprintf("%s/%d", port.Protocol, port.Port)
+}
+
+// ConnectivityProbeCommand returns the command executed in the source pod to probe the destination IP and port. The
+// destination is reachable if the command exits successfully and unreachable if it exits with a non-zero code. Exit
+// codes 126 and 127, reported by the shell when the command cannot be executed or is not found, fail the run.
+type ConnectivityProbeCommand func(destinationIP string, port ConnectivityPort, timeout time.Duration) []string
+
+// connectivityKey identifies a single source to destination probe.
+type connectivityKey struct {
+	source      string
+	destination string
+	port        ConnectivityPort
+}
+
+// ConnectivityMatrix holds whether traffic is allowed from each source pod to each destination pod and port. Pods are
+// identified by their namespace/name. A matrix created with NewConnectivityMatrix can be used to describe the
+// expected connectivity, in which case pairs that were not set explicitly use the default.
+type ConnectivityMatrix struct {
+	defaultAllowed bool
+	results        map[connectivityKey]bool
+}
+
+// ConnectivityMismatch is a pair whose probed connectivity differs from the expected one.
+type ConnectivityMismatch struct {
+	Source      string
+	Destination string
+	Port        ConnectivityPort
+	Expected    bool
+	Actual      bool
+}
+
+// String returns a readable description of the mismatch.
+func (mismatch ConnectivityMismatch) String() string {
+	return fmt.Sprintf("%s -> %s %s: expected %s, got %s", mismatch.Source, mismatch.Destination, mismatch.Port,
+		connectivityVerdict(mismatch.Expected), connectivityVerdict(mismatch.Actual))
+}
+
+// NewConnectivityMatrix creates an empty ConnectivityMatrix where pairs that are not set are allowed if
+// defaultAllowed is true.
+func NewConnectivityMatrix(defaultAllowed bool) *ConnectivityMatrix {
+	return &ConnectivityMatrix{
+		defaultAllowed: defaultAllowed,
+		results:        make(map[connectivityKey]bool),
+	}
+}
+
+// Set records whether traffic from source to destination on the given port is allowed. Source and destination are
+// pods in the namespace/name format.
+func (matrix *ConnectivityMatrix) Set(
+	source, destination string, port ConnectivityPort, allowed bool) *ConnectivityMatrix {
+	matrix.results[connectivityKey{source: source, destination: destination, port: port}] = allowed
+
+	return matrix
+}
+
+// IsAllowed returns whether traffic from source to destination on the given port is allowed, falling back to the
+// default of the matrix if the pair was not set.
+func (matrix *ConnectivityMatrix) IsAllowed(source, destination string, port ConnectivityPort) bool {
+	allowed, ok := matrix.results[connectivityKey{source: source, destination: destination, port: port}]
+	if !ok {
+		return matrix.defaultAllowed
+	}
+
+	return allowed
+}
+
+// Diff compares the matrix with the expected matrix and returns the pairs of the matrix that differ, sorted by
+// source, destination and port.
+func (matrix *ConnectivityMatrix) Diff(expected *ConnectivityMatrix) []ConnectivityMismatch {
+	var mismatches []ConnectivityMismatch
+
+	for _, key := range matrix.sortedKeys() {
+		actual := matrix.results[key]
+
+		if expectedAllowed := expected.IsAllowed(key.source, key.destination, key.port); expectedAllowed != actual {
+			mismatches = append(mismatches, ConnectivityMismatch{
+				Source:      key.source,
+				Destination: key.destination,
+				Port:        key.port,
+				Expected:    expectedAllowed,
+				Actual:      actual,
+			})
+		}
+	}
+
+	return mismatches
+}
+
+// Verify compares the matrix with the expected matrix and returns an error listing every mismatch, followed by the
+// matrix itself, if they differ.
+func (matrix *ConnectivityMatrix) Verify(expected *ConnectivityMatrix) error {
+	mismatches := matrix.Diff(expected)
+	if len(mismatches) == 0 {
+		return nil
+	}
+
+	var report strings.Builder
+
+	fmt.Fprintf(&report, "connectivity matrix has %d unexpected results:\n", len(mismatches))
+
+	for _, mismatch := range mismatches {
+		fmt.Fprintf(&report, "  %s\n", mismatch)
+	}
+
+	report.WriteString(matrix.String())
+
+	return fmt.Errorf("%s", strings.TrimSuffix(report.String(), "\n"))
+}
+
+// String returns a table per port with sources as rows and destinations as columns.
+func (matrix *ConnectivityMatrix) String() string {
+	var (
+		pods   []string
+		ports  []ConnectivityPort
+		report strings.Builder
+	)
+
+	seenPods := make(map[string]bool)
+	seenPorts := make(map[ConnectivityPort]bool)
+
+	for key := range matrix.results {
+		for _, podName := range []string{key.source, key.destination} {
+			if !seenPods[podName] {
+				seenPods[podName] = true
+				pods = append(pods, podName)
+			}
+		}
+
+		if !seenPorts[key.port] {
+			seenPorts[key.port] = true
+			ports = append(ports, key.port)
+		}
+	}
+
+	sort.Strings(pods)
+	sortConnectivityPorts(ports)
+
+	width := len("source \\ destination")
+	for _, podName := range pods {
+		width = max(width, len(podName))
+	}
+
+	for _, port := range ports {
+		fmt.Fprintf(&report, "%s\n%-*s", port, width, "source \\ destination")
+
+		for _, destination := range pods {
+			fmt.Fprintf(&report, " | %-*s", width, destination)
+		}
+
+		report.WriteString("\n")
+
+		for _, source := range pods {
+			fmt.Fprintf(&report, "%-*s", width, source)
+
+			for _, destination := range pods {
+				verdict := "-"
+
+				if allowed, ok := matrix.results[connectivityKey{source, destination, port}]; ok {
+					verdict = connectivityVerdict(allowed)
+				}
+
+				fmt.Fprintf(&report, " | %-*s", width, verdict)
+			}
+
+			report.WriteString("\n")
+		}
+	}
+
+	return report.String()
+}
+
+func (matrix *ConnectivityMatrix) sortedKeys() []connectivityKey {
+	keys := make([]connectivityKey, 0, len(matrix.results))
+
+	for key := range matrix.results {
+		keys = append(keys, key)
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].source != keys[j].source {
+			return keys[i].source < keys[j].source
+		}
+
+		if keys[i].destination != keys[j].destination {
+			return keys[i].destination < keys[j].destination
+		}
+
+		return lessConnectivityPort(keys[i].port, keys[j].port)
+	})
+
+	return keys
+}
+
+// ConnectivityTester probes every source to destination pair of a set of pods on a set of ports in parallel.
+type ConnectivityTester struct {
+	pods          []*pod.Builder
+	ports         []ConnectivityPort
+	networkName   string
+	ipFamily      corev1.IPFamily
+	containerName string
+	probeTimeout  time.Duration
+	parallelism   int
+	probeCommand  ConnectivityProbeCommand
+	// probe is replaced in unit tests since the fake client cannot exec into pods.
+	probe func(source *pod.Builder, command []string, containerName string) (bool, error)
+	// Used to store latest error message upon defining or mutating the tester.
+	errorMsg string
+}
+
+// NewConnectivityTester creates a new instance of ConnectivityTester for the given pods. The pods must be running
+// and listening on the probed ports, with UDP servers echoing the received data back.
+func NewConnectivityTester(pods ...*pod.Builder) *ConnectivityTester {
+	glog.V(100).Infof("Initializing new ConnectivityTester with %d pods", len(pods))
+
+	tester := &ConnectivityTester{
+		pods:         pods,
+		probeTimeout: defaultConnectivityProbeTimeout,
+		parallelism:  defaultConnectivityParallelism,
+		probeCommand: defaultConnectivityProbeCommand,
+		probe:        execConnectivityProbe,
+	}
+
+	if len(pods) < 2 {
+		glog.V(100).Infof("The ConnectivityTester requires at least 2 pods")
+
+		tester.errorMsg = "ConnectivityTester requires at least 2 pods"
+
+		return tester
+	}
+
+	for _, podBuilder := range pods {
+		if podBuilder == nil || podBuilder.Definition == nil {
+			glog.V(100).Infof("The ConnectivityTester received an undefined pod")
+
+			tester.errorMsg = "ConnectivityTester 'pods' cannot contain undefined pods"
+
+			return tester
+		}
+	}
+
+	return tester
+}
+
+// WithPort adds a port and protocol to probe on every destination pod.
+func (tester *ConnectivityTester) WithPort(port uint16, protocol corev1.Protocol) *ConnectivityTester {
+	if valid, _ := tester.validate(); !valid {
+		return tester
+	}
+
+	glog.V(100).Infof("Adding port %d and protocol %s to ConnectivityTester", port, protocol)
+
+	if port == 0 {
+		tester.errorMsg = "port number can not be 0"
+
+		return tester
+	}
+
+	if err := validateNetworkPolicyProtocol(protocol); err != nil {
+		tester.errorMsg = err.Error()
+
+		return tester
+	}
+
+	tester.ports = append(tester.ports, ConnectivityPort{Port: port, Protocol: protocol})
+
+	return tester
+}
+
+// WithSecondaryNetwork probes the destination IPs attached to the given secondary network, identified by the
+// namespace/name of the NetworkAttachmentDefinition, instead of the primary pod IPs.
+func (tester *ConnectivityTester) WithSecondaryNetwork(networkName string) *ConnectivityTester {
+	if valid, _ := tester.validate(); !valid {
+		return tester
+	}
+
+	glog.V(100).Infof("Setting ConnectivityTester secondary network %s", networkName)
+
+	if networkName == "" {
+		tester.errorMsg = "ConnectivityTester 'networkName' cannot be empty"
+
+		return tester
+	}
+
+	tester.networkName = networkName
+
+	return tester
+}
+
+// WithIPFamily probes only the destination IPs of the given family. By default the first IP of each pod is probed.
+func (tester *ConnectivityTester) WithIPFamily(ipFamily corev1.IPFamily) *ConnectivityTester {
+	if valid, _ := tester.validate(); !valid {
+		return tester
+	}
+
+	glog.V(100).Infof("Setting ConnectivityTester IP family %s", ipFamily)
+
+	if ipFamily != corev1.IPv4Protocol && ipFamily != corev1.IPv6Protocol {
+		tester.errorMsg = fmt.Sprintf("invalid IP family %s, must be one of IPv4 or IPv6", ipFamily)
+
+		return tester
+	}
+
+	tester.ipFamily = ipFamily
+
+	return tester
+}
+
+// WithContainer sets the container of the source pods the probes are executed in. By default the first container.
+func (tester *ConnectivityTester) WithContainer(containerName string) *ConnectivityTester {
+	if valid, _ := tester.validate(); !valid {
+		return tester
+	}
+
+	glog.V(100).Infof("Setting ConnectivityTester container %s", containerName)
+
+	if containerName == "" {
+		tester.errorMsg = "ConnectivityTester 'containerName' cannot be empty"
+
+		return tester
+	}
+
+	tester.containerName = containerName
+
+	return tester
+}
+
+// WithProbeTimeout sets the timeout of each probe.
+func (tester *ConnectivityTester) WithProbeTimeout(timeout time.Duration) *ConnectivityTester {
+	if valid, _ := tester.validate(); !valid {
+		return tester
+	}
+
+	glog.V(100).Infof("Setting ConnectivityTester probe timeout %s", timeout)
+
+	if timeout < time.Second {
+		tester.errorMsg = "ConnectivityTester probe timeout must be at least 1s"
+
+		return tester
+	}
+
+	tester.probeTimeout = timeout
+
+	return tester
+}
+
+// WithParallelism sets the maximum number of probes running at the same time.
+func (tester *ConnectivityTester) WithParallelism(parallelism int) *ConnectivityTester {
+	if valid, _ := tester.validate(); !valid {
+		return tester
+	}
+
+	glog.V(100).Infof("Setting ConnectivityTester parallelism %d", parallelism)
+
+	if parallelism < 1 {
+		tester.errorMsg = "ConnectivityTester parallelism must be at least 1"
+
+		return tester
+	}
+
+	tester.parallelism = parallelism
+
+	return tester
+}
+
+// WithProbeCommand replaces the default nc based probe command.
+func (tester *ConnectivityTester) WithProbeCommand(probeCommand ConnectivityProbeCommand) *ConnectivityTester {
+	if valid, _ := tester.validate(); !valid {
+		return tester
+	}
+
+	glog.V(100).Infof("Setting ConnectivityTester probe command")
+
+	if probeCommand == nil {
+		tester.errorMsg = "ConnectivityTester 'probeCommand' cannot be nil"
+
+		return tester
+	}
+
+	tester.probeCommand = probeCommand
+
+	return tester
+}
+
+// Run probes every source to destination pair on every port and returns the resulting matrix. A probe command exiting
+// with a non-zero code is recorded as deny, while a probe which could not be run, e.g. due to a missing binary, a
+// wrong container name or an exec failure, fails the run.
+func (tester *ConnectivityTester) Run() (*ConnectivityMatrix, error) {
+	if valid, err := tester.validate(); !valid {
+		return nil, err
+	}
+
+	glog.V(100).Infof("Running ConnectivityTester on %d pods and ports %v", len(tester.pods), tester.ports)
+
+	if len(tester.ports) == 0 {
+		return nil, fmt.Errorf("failed to run ConnectivityTester, no ports were defined")
+	}
+
+	destinationIPs := make(map[string]string)
+
+	for _, podBuilder := range tester.pods {
+		if !podBuilder.Exists() {
+			return nil, fmt.Errorf("failed to run ConnectivityTester, pod %s does not exist",
+				getConnectivityPodName(podBuilder))
+		}
+
+		destinationIP, err := tester.getDestinationIP(podBuilder.Object)
+		if err != nil {
+			return nil, err
+		}
+
+		destinationIPs[getConnectivityPodName(podBuilder)] = destinationIP
+	}
+
+	var (
+		mutex     sync.Mutex
+		waitGroup sync.WaitGroup
+		probeErrs []error
+	)
+
+	matrix := NewConnectivityMatrix(false)
+	semaphore := make(chan struct{}, tester.parallelism)
+
+	for _, source := range tester.pods {
+		for _, destination := range tester.pods {
+			if source == destination {
+				continue
+			}
+
+			for _, port := range tester.ports {
+				waitGroup.Add(1)
+
+				go func(source, destination *pod.Builder, port ConnectivityPort) {
+					defer waitGroup.Done()
+
+					semaphore <- struct{}{}
+					defer func() { <-semaphore }()
+
+					sourceName := getConnectivityPodName(source)
+					destinationName := getConnectivityPodName(destination)
+					command := tester.probeCommand(destinationIPs[destinationName], port, tester.probeTimeout)
+					allowed, err := tester.probe(source, command, tester.containerName)
+					if err != nil {
+						glog.V(100).Infof("Failed to probe connectivity %s -> %s (%s) %s: %v", sourceName,
+							destinationName, destinationIPs[destinationName], port, err)
+
+						mutex.Lock()
+						probeErrs = append(probeErrs, fmt.Errorf("failed to probe connectivity %s -> %s %s: %w",
+							sourceName, destinationName, port, err))
+						mutex.Unlock()
+
+						return
+					}
+
+					glog.V(100).Infof("Connectivity %s -> %s (%s) %s: %s", sourceName, destinationName,
+						destinationIPs[destinationName], port, connectivityVerdict(allowed))
+
+					mutex.Lock()
+					matrix.Set(sourceName, destinationName, port, allowed)
+					mutex.Unlock()
+				}(source, destination, port)
+			}
+		}
+	}
+
+	waitGroup.Wait()
+
+	if len(probeErrs) > 0 {
+		return nil, errors.Join(probeErrs...)
+	}
+
+	return matrix, nil
+}
+
+func (tester *ConnectivityTester) getDestinationIP(podObject *corev1.Pod) (string, error) {
+	var ips []string
+
+	if tester.networkName == "" {
+		for _, podIP := range podObject.Status.PodIPs {
+			ips = append(ips, podIP.IP)
+		}
+	} else {
+		networkStatusAnnotation, ok := podObject.Annotations[nadV1.NetworkStatusAnnot]
+		if !ok {
+			return "", fmt.Errorf("pod %s/%s has no %s annotation",
+				podObject.Namespace, podObject.Name, nadV1.NetworkStatusAnnot)
+		}
+
+		var networkStatuses []nadV1.NetworkStatus
+
+		err := json.Unmarshal([]byte(networkStatusAnnotation), &networkStatuses)
+		if err != nil {
+			return "", fmt.Errorf("failed to unmarshal %s annotation of pod %s/%s: %w",
+				nadV1.NetworkStatusAnnot, podObject.Namespace, podObject.Name, err)
+		}
+
+		for _, networkStatus := range networkStatuses {
+			if networkStatus.Name == tester.networkName {
+				ips = append(ips, networkStatus.IPs...)
+			}
+		}
+	}
+
+	for _, ip := range ips {
+		parsedIP := net.ParseIP(ip)
+		if parsedIP == nil {
+			continue
+		}
+
+		if tester.ipFamily == "" ||
+			(tester.ipFamily == corev1.IPv4Protocol) == (parsedIP.To4() != nil) {
+			return ip, nil
+		}
+	}
+
+	network := "primary network"
+	if tester.networkName != "" {
+		network = "network " + tester.networkName
+	}
+
+	if tester.ipFamily != "" {
+		network = fmt.Sprintf("%s %s", tester.ipFamily, network)
+	}
+
+	return "", fmt.Errorf("pod %s/%s has no IP on %s", podObject.Namespace, podObject.Name, network)
+}
+
+// validate will check that the tester is properly initialized before accessing any member fields.
+func (tester *ConnectivityTester) validate() (bool, error) {
+	if tester == nil {
+		glog.V(100).Infof("The ConnectivityTester is uninitialized")
+
+		return false, fmt.Errorf("error: received nil ConnectivityTester")
+	}
+
+	if tester.errorMsg != "" {
+		glog.V(100).Infof("The ConnectivityTester has error message: %s", tester.errorMsg)
+
+		return false, fmt.Errorf(tester.errorMsg)
+	}
+
+	return true, nil
+}
+
+// defaultConnectivityProbeCommand probes TCP and SCTP ports by opening a connection and UDP ports by expecting the
+// probe data to be echoed back.
+func defaultConnectivityProbeCommand(destinationIP string, port ConnectivityPort, timeout time.Duration) []string {
+	timeoutSeconds := strconv.Itoa(int(timeout.Seconds()))
+	portNumber := strconv.Itoa(int(port.Port))
+
+	switch port.Protocol {
+	case corev1.ProtocolUDP:
+		return []string{"sh", "-c", fmt.Sprintf("echo probe | nc -u -w %s %s %s | grep -q probe",
+			timeoutSeconds, destinationIP, portNumber)}
+	case corev1.ProtocolSCTP:
+		return []string{"nc", "--sctp", "-z", "-w", timeoutSeconds, destinationIP, portNumber}
+	default:
+		return []string{"nc", "-z", "-w", timeoutSeconds, destinationIP, portNumber}
+	}
+}
+
+// execConnectivityProbe runs the probe command in the source pod. Only a non-zero exit of the command is reported as
+// deny, any other failure is returned as an error.
+func execConnectivityProbe(source *pod.Builder, command []string, containerName string) (bool, error) {
+	var err error
+
+	if containerName == "" {
+		_, err = source.ExecCommand(command)
+	} else {
+		_, err = source.ExecCommand(command, containerName)
+	}
+
+	return connectivityProbeResult(err)
+}
+
+// connectivityProbeResult converts the error of the probe command execution into the probe verdict.
+func connectivityProbeResult(err error) (bool, error) {
+	if err == nil {
+		return true, nil
+	}
+
+	var exitErr utilexec.CodeExitError
+	if !errors.As(err, &exitErr) {
+		return false, err
+	}
+
+	if exitErr.Code == probeCommandNotExecutableCode || exitErr.Code == probeCommandNotFoundCode {
+		return false, fmt.Errorf("probe command could not be run, exit code %d: %w", exitErr.Code, err)
+	}
+
+	return false, nil
+}
+
+func getConnectivityPodName(podBuilder *pod.Builder) string {
+	return podBuilder.Definition.Namespace + "/" + podBuilder.Definition.Name
+}
+
+func connectivityVerdict(allowed bool) string {
+	if allowed {
+		return "allow"
+	}
+
+	return "deny"
+}
+
+func lessConnectivityPort(first, second ConnectivityPort) bool {
+	if first.Protocol != second.Protocol {
+		return first.Protocol < second.Protocol
+	}
+
+	return first.Port < second.Port
+}
+
+func sortConnectivityPorts(ports []ConnectivityPort) {
+	sort.Slice(ports, func(i, j int) bool {
+		return lessConnectivityPort(ports[i], ports[j])
+	})
+}
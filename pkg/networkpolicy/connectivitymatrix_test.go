@@ -0,0 +1,277 @@
+package networkpolicy
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	nadV1 "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1"
+	"github.com/openshift-kni/eco-goinfra/pkg/clients"
+	"github.com/openshift-kni/eco-goinfra/pkg/pod"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilexec "k8s.io/client-go/util/exec"
+)
+
+var (
+	connectivityTCP80 = ConnectivityPort{Port: 80, Protocol: corev1.ProtocolTCP}
+	connectivityUDP53 = ConnectivityPort{Port: 53, Protocol: corev1.ProtocolUDP}
+)
+
+func TestNewConnectivityTester(t *testing.T) {
+	testPods := buildDummyConnectivityPodBuilders(t)
+
+	testTester := NewConnectivityTester(testPods...)
+	assert.Equal(t, "", testTester.errorMsg)
+
+	testTester = NewConnectivityTester(testPods[0])
+	assert.Equal(t, "ConnectivityTester requires at least 2 pods", testTester.errorMsg)
+
+	testTester = NewConnectivityTester(testPods[0], nil)
+	assert.Equal(t, "ConnectivityTester 'pods' cannot contain undefined pods", testTester.errorMsg)
+}
+
+func TestConnectivityTesterOptions(t *testing.T) {
+	testCases := []struct {
+		mutate        func(tester *ConnectivityTester) *ConnectivityTester
+		expectedError string
+	}{
+		{
+			mutate: func(tester *ConnectivityTester) *ConnectivityTester {
+				return tester.WithPort(80, corev1.ProtocolTCP).WithIPFamily(corev1.IPv6Protocol).
+					WithContainer("test").WithProbeTimeout(time.Second).WithParallelism(1).
+					WithSecondaryNetwork("test-ns/macvlan")
+			},
+			expectedError: "",
+		},
+		{
+			mutate: func(tester *ConnectivityTester) *ConnectivityTester {
+				return tester.WithPort(0, corev1.ProtocolTCP)
+			},
+			expectedError: "port number can not be 0",
+		},
+		{
+			mutate: func(tester *ConnectivityTester) *ConnectivityTester {
+				return tester.WithPort(80, "ICMP")
+			},
+			expectedError: "invalid protocol ICMP, must be one of TCP, UDP or SCTP",
+		},
+		{
+			mutate: func(tester *ConnectivityTester) *ConnectivityTester {
+				return tester.WithIPFamily("IPv5")
+			},
+			expectedError: "invalid IP family IPv5, must be one of IPv4 or IPv6",
+		},
+		{
+			mutate: func(tester *ConnectivityTester) *ConnectivityTester {
+				return tester.WithSecondaryNetwork("")
+			},
+			expectedError: "ConnectivityTester 'networkName' cannot be empty",
+		},
+		{
+			mutate: func(tester *ConnectivityTester) *ConnectivityTester {
+				return tester.WithProbeTimeout(time.Millisecond)
+			},
+			expectedError: "ConnectivityTester probe timeout must be at least 1s",
+		},
+		{
+			mutate: func(tester *ConnectivityTester) *ConnectivityTester {
+				return tester.WithParallelism(0)
+			},
+			expectedError: "ConnectivityTester parallelism must be at least 1",
+		},
+		{
+			mutate: func(tester *ConnectivityTester) *ConnectivityTester {
+				return tester.WithProbeCommand(nil)
+			},
+			expectedError: "ConnectivityTester 'probeCommand' cannot be nil",
+		},
+	}
+
+	for _, testCase := range testCases {
+		testTester := testCase.mutate(NewConnectivityTester(buildDummyConnectivityPodBuilders(t)...))
+		assert.Equal(t, testCase.expectedError, testTester.errorMsg)
+	}
+}
+
+func TestConnectivityTesterRun(t *testing.T) {
+	testPods := buildDummyConnectivityPodBuilders(t)
+	testTester := NewConnectivityTester(testPods...).
+		WithPort(80, corev1.ProtocolTCP).
+		WithPort(53, corev1.ProtocolUDP)
+
+	var probedCommands []string
+
+	// The server only accepts TCP traffic from the client.
+	testTester.probe = func(source *pod.Builder, command []string, _ string) (bool, error) {
+		probedCommands = append(probedCommands, strings.Join(command, " "))
+
+		return source.Definition.Name == "client" && command[0] == "nc" && strings.Contains(command[4], "10.128.0.11"),
+			nil
+	}
+
+	matrix, err := testTester.WithParallelism(1).Run()
+	assert.Nil(t, err)
+	assert.Len(t, probedCommands, 4)
+	assert.Contains(t, probedCommands, "nc -z -w 2 10.128.0.11 80")
+	assert.Contains(t, probedCommands, "sh -c echo probe | nc -u -w 2 10.128.0.10 53 | grep -q probe")
+	assert.True(t, matrix.IsAllowed("test-ns/client", "test-ns/server", connectivityTCP80))
+	assert.False(t, matrix.IsAllowed("test-ns/client", "test-ns/server", connectivityUDP53))
+	assert.False(t, matrix.IsAllowed("test-ns/server", "test-ns/client", connectivityTCP80))
+
+	expected := NewConnectivityMatrix(false).Set("test-ns/client", "test-ns/server", connectivityTCP80, true)
+	assert.Empty(t, matrix.Diff(expected))
+	assert.Nil(t, matrix.Verify(expected))
+
+	_, err = NewConnectivityTester(testPods...).Run()
+	assert.Equal(t, fmt.Errorf("failed to run ConnectivityTester, no ports were defined"), err)
+
+	_, err = NewConnectivityTester(testPods[0]).Run()
+	assert.Equal(t, fmt.Errorf("ConnectivityTester requires at least 2 pods"), err)
+}
+
+func TestConnectivityTesterRunProbeError(t *testing.T) {
+	testTester := NewConnectivityTester(buildDummyConnectivityPodBuilders(t)...).WithPort(80, corev1.ProtocolTCP)
+	testTester.probe = func(source *pod.Builder, _ []string, _ string) (bool, error) {
+		if source.Definition.Name == "client" {
+			return false, fmt.Errorf("container not found")
+		}
+
+		return false, nil
+	}
+
+	matrix, err := testTester.Run()
+	assert.Nil(t, matrix)
+	assert.EqualError(t, err, "failed to probe connectivity test-ns/client -> test-ns/server TCP/80: container not found")
+}
+
+func TestConnectivityProbeResult(t *testing.T) {
+	testCases := []struct {
+		err             error
+		expectedAllowed bool
+		expectedError   bool
+	}{
+		{
+			err:             nil,
+			expectedAllowed: true,
+			expectedError:   false,
+		},
+		{
+			err:             utilexec.CodeExitError{Err: fmt.Errorf("command terminated with exit code 1"), Code: 1},
+			expectedAllowed: false,
+			expectedError:   false,
+		},
+		{
+			err:             utilexec.CodeExitError{Err: fmt.Errorf("command terminated with exit code 127"), Code: 127},
+			expectedAllowed: false,
+			expectedError:   true,
+		},
+		{
+			err:             fmt.Errorf("unable to upgrade connection: container not found"),
+			expectedAllowed: false,
+			expectedError:   true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		allowed, err := connectivityProbeResult(testCase.err)
+		assert.Equal(t, testCase.expectedAllowed, allowed)
+		assert.Equal(t, testCase.expectedError, err != nil)
+	}
+}
+
+func TestConnectivityTesterRunSecondaryNetwork(t *testing.T) {
+	testPods := buildDummyConnectivityPodBuilders(t)
+
+	var probedIPs []string
+
+	testTester := NewConnectivityTester(testPods...).
+		WithPort(5001, corev1.ProtocolSCTP).
+		WithSecondaryNetwork("test-ns/macvlan").
+		WithIPFamily(corev1.IPv6Protocol).
+		WithProbeCommand(func(destinationIP string, port ConnectivityPort, timeout time.Duration) []string {
+			probedIPs = append(probedIPs, destinationIP)
+
+			return []string{"true"}
+		})
+	testTester.probe = func(*pod.Builder, []string, string) (bool, error) { return true, nil }
+
+	matrix, err := testTester.WithParallelism(1).Run()
+	assert.Nil(t, err)
+	assert.ElementsMatch(t, []string{"2001:db8::10", "2001:db8::11"}, probedIPs)
+	assert.True(t, matrix.IsAllowed("test-ns/server", "test-ns/client",
+		ConnectivityPort{Port: 5001, Protocol: corev1.ProtocolSCTP}))
+
+	_, err = NewConnectivityTester(testPods...).WithPort(80, corev1.ProtocolTCP).
+		WithSecondaryNetwork("test-ns/sriov").Run()
+	assert.Equal(t, fmt.Errorf("pod test-ns/client has no IP on network test-ns/sriov"), err)
+
+	_, err = NewConnectivityTester(testPods...).WithPort(80, corev1.ProtocolTCP).
+		WithIPFamily(corev1.IPv6Protocol).Run()
+	assert.Equal(t, fmt.Errorf("pod test-ns/client has no IP on IPv6 primary network"), err)
+}
+
+func TestConnectivityMatrixVerify(t *testing.T) {
+	matrix := NewConnectivityMatrix(false).
+		Set("test-ns/client", "test-ns/server", connectivityTCP80, false).
+		Set("test-ns/server", "test-ns/client", connectivityTCP80, true)
+
+	expected := NewConnectivityMatrix(true).Set("test-ns/server", "test-ns/client", connectivityTCP80, false)
+
+	mismatches := matrix.Diff(expected)
+	assert.Equal(t, []ConnectivityMismatch{
+		{Source: "test-ns/client", Destination: "test-ns/server", Port: connectivityTCP80, Expected: true},
+		{Source: "test-ns/server", Destination: "test-ns/client", Port: connectivityTCP80, Actual: true},
+	}, mismatches)
+	assert.Equal(t, "test-ns/client -> test-ns/server TCP/80: expected allow, got deny", mismatches[0].String())
+
+	err := matrix.Verify(expected)
+	assert.NotNil(t, err)
+	assert.True(t, strings.HasPrefix(err.Error(), "connectivity matrix has 2 unexpected results:\n"+
+		"  test-ns/client -> test-ns/server TCP/80: expected allow, got deny\n"+
+		"  test-ns/server -> test-ns/client TCP/80: expected deny, got allow\nTCP/80\n"))
+	assert.Contains(t, err.Error(), "test-ns/client       | -                    | deny")
+}
+
+func buildDummyConnectivityPodBuilders(t *testing.T) []*pod.Builder {
+	t.Helper()
+
+	testSettings := clients.GetTestClients(clients.TestClientParams{K8sMockObjects: buildDummyConnectivityPods()})
+
+	var podBuilders []*pod.Builder
+
+	for _, podName := range []string{"client", "server"} {
+		podBuilder, err := pod.Pull(testSettings, podName, "test-ns")
+		assert.Nil(t, err)
+
+		podBuilders = append(podBuilders, podBuilder)
+	}
+
+	return podBuilders
+}
+
+func buildDummyConnectivityPods() []runtime.Object {
+	var pods []runtime.Object
+
+	for index, podName := range []string{"client", "server"} {
+		pods = append(pods, &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      podName,
+				Namespace: "test-ns",
+				Annotations: map[string]string{nadV1.NetworkStatusAnnot: fmt.Sprintf(
+					`[{"name":"ovn-kubernetes","interface":"eth0","ips":["10.128.0.1%d"],"default":true},`+
+						`{"name":"test-ns/macvlan","interface":"net1","ips":["192.168.0.1%d","2001:db8::1%d"]}]`,
+					index, index, index)},
+			},
+			Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "test"}}},
+			Status: corev1.PodStatus{
+				PodIPs: []corev1.PodIP{{IP: fmt.Sprintf("10.128.0.1%d", index)}},
+			},
+		})
+	}
+
+	return pods
+}
@@ -162,6 +162,52 @@ func (builder *NetworkPolicyBuilder) WithPodSelector(podSelectorMatchLabels map[
 	return builder
 }
 
+// WithPodLabelSelector sets the podSelector of the networkPolicy. Unlike WithPodSelector it accepts matchExpressions
+// and an empty selector, which selects all pods in the namespace.
+func (builder *NetworkPolicyBuilder) WithPodLabelSelector(podSelector metav1.LabelSelector) *NetworkPolicyBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof(
+		"Creating networkPolicy %s in %s namespace with podSelector defined: %v",
+		builder.Definition.Name, builder.Definition.Namespace, podSelector)
+
+	builder.Definition.Spec.PodSelector = podSelector
+
+	return builder
+}
+
+// WithIngressRule adds Ingress rule to the networkPolicy. Empty rule is allowed and works as allow all traffic.
+func (builder *NetworkPolicyBuilder) WithIngressRule(ingressRule netv1.NetworkPolicyIngressRule) *NetworkPolicyBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof(
+		"Creating networkPolicy %s in %s namespace with the Ingress rule defined: %v",
+		builder.Definition.Name, builder.Definition.Namespace, ingressRule)
+
+	builder.Definition.Spec.Ingress = append(builder.Definition.Spec.Ingress, ingressRule)
+
+	return builder
+}
+
+// WithEgressRule adds Egress rule to the networkPolicy. Empty rule is allowed and works as allow all traffic.
+func (builder *NetworkPolicyBuilder) WithEgressRule(egressRule netv1.NetworkPolicyEgressRule) *NetworkPolicyBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof(
+		"Creating networkPolicy %s in %s namespace with the Egress rule defined: %v",
+		builder.Definition.Name, builder.Definition.Namespace, egressRule)
+
+	builder.Definition.Spec.Egress = append(builder.Definition.Spec.Egress, egressRule)
+
+	return builder
+}
+
 // Pull loads an existing networkPolicy into the Builder struct.
 func Pull(apiClient *clients.Settings, name, nsname string) (*NetworkPolicyBuilder, error) {
 	if apiClient == nil {
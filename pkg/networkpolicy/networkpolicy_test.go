@@ -220,6 +220,46 @@ func TestNetworkPolicyWithPodSelector(t *testing.T) {
 	}
 }
 
+func TestNetworkPolicyWithPodLabelSelector(t *testing.T) {
+	podSelector := metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{{
+		Key:      "app",
+		Operator: metav1.LabelSelectorOpIn,
+		Values:   []string{"client", "server"},
+	}}}
+
+	testBuilder := buildTestBuilderWithFakeObjects(nil, "test-name", "test-namespace").
+		WithPodLabelSelector(podSelector)
+	assert.Equal(t, "", testBuilder.errorMsg)
+	assert.Equal(t, podSelector, testBuilder.Definition.Spec.PodSelector)
+
+	testBuilder = buildTestBuilderWithFakeObjects(nil, "test-name", "test-namespace").
+		WithPodLabelSelector(metav1.LabelSelector{})
+	assert.Equal(t, "", testBuilder.errorMsg)
+	assert.Equal(t, metav1.LabelSelector{}, testBuilder.Definition.Spec.PodSelector)
+}
+
+func TestNetworkPolicyWithIngressAndEgressRule(t *testing.T) {
+	ingressRule, err := NewNetworkPolicyIngressRuleBuilder().
+		WithPortAndProtocol(8080, "TCP").
+		WithPeerNamespaceSelector(metav1.LabelSelector{MatchLabels: map[string]string{"env": "test"}}).
+		GetIngressRuleCfg()
+	assert.Nil(t, err)
+
+	egressRule, err := NewNetworkPolicyEgressRuleBuilder().WithCIDR("10.0.0.0/8").GetEgressRuleCfg()
+	assert.Nil(t, err)
+
+	testBuilder := buildTestBuilderWithFakeObjects(nil, "test-name", "test-namespace").
+		WithIngressRule(*ingressRule).
+		WithEgressRule(*egressRule).
+		WithEgressRule(netv1.NetworkPolicyEgressRule{})
+	assert.Equal(t, "", testBuilder.errorMsg)
+	assert.Equal(t, []netv1.NetworkPolicyIngressRule{*ingressRule}, testBuilder.Definition.Spec.Ingress)
+	assert.Equal(t, []netv1.NetworkPolicyEgressRule{*egressRule, {}}, testBuilder.Definition.Spec.Egress)
+
+	testBuilder = buildTestBuilderWithFakeObjects(nil, "", "test-namespace").WithIngressRule(*ingressRule)
+	assert.Empty(t, testBuilder.Definition.Spec.Ingress)
+}
+
 func TestNetworkPolicyCreate(t *testing.T) {
 	generateNetworkPolicy := func(name, namespace string) *netv1.NetworkPolicy {
 		return &netv1.NetworkPolicy{
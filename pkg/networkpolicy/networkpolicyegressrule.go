@@ -0,0 +1,202 @@
+package networkpolicy
+
+import (
+	"fmt"
+
+	"github.com/golang/glog"
+	corev1 "k8s.io/api/core/v1"
+	netv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// NetworkPolicyEgressAdditionalOptions additional options for NetworkPolicyEgressRule object.
+type NetworkPolicyEgressAdditionalOptions func(
+	builder *NetworkPolicyEgressRuleBuilder) (*NetworkPolicyEgressRuleBuilder, error)
+
+// NetworkPolicyEgressRuleBuilder provides a struct for NetworkPolicy EgressRule's object definition.
+type NetworkPolicyEgressRuleBuilder struct {
+	// EgressRule definition, used to create the EgressRule object.
+	definition *netv1.NetworkPolicyEgressRule
+	// Used to store latest error message upon defining or mutating EgressRule definition.
+	errorMsg string
+}
+
+// NewNetworkPolicyEgressRuleBuilder creates a new instance of NetworkPolicyEgressRuleBuilder. A rule without peers
+// and ports allows all egress traffic.
+func NewNetworkPolicyEgressRuleBuilder() *NetworkPolicyEgressRuleBuilder {
+	glog.V(100).Infof("Initializing new NetworkPolicy Egress rule structure")
+
+	builder := &NetworkPolicyEgressRuleBuilder{
+		definition: &netv1.NetworkPolicyEgressRule{},
+	}
+
+	return builder
+}
+
+// WithPortAndProtocol adds port and protocol to Egress rule.
+func (builder *NetworkPolicyEgressRuleBuilder) WithPortAndProtocol(
+	port uint16, protocol corev1.Protocol) *NetworkPolicyEgressRuleBuilder {
+	glog.V(100).Infof("Adding port %d and protocol %s to NetworkPolicy EgressRule", port, protocol)
+
+	if builder.errorMsg != "" {
+		return builder
+	}
+
+	policyPort, err := newNetworkPolicyPort(port, 0, protocol)
+	if err != nil {
+		builder.errorMsg = err.Error()
+
+		return builder
+	}
+
+	builder.definition.Ports = append(builder.definition.Ports, *policyPort)
+
+	return builder
+}
+
+// WithPortRangeAndProtocol adds the port range from port to endPort inclusive and protocol to Egress rule.
+func (builder *NetworkPolicyEgressRuleBuilder) WithPortRangeAndProtocol(
+	port, endPort uint16, protocol corev1.Protocol) *NetworkPolicyEgressRuleBuilder {
+	glog.V(100).Infof("Adding port range %d-%d and protocol %s to NetworkPolicy EgressRule", port, endPort, protocol)
+
+	if builder.errorMsg != "" {
+		return builder
+	}
+
+	policyPort, err := newNetworkPolicyPort(port, endPort, protocol)
+	if err != nil {
+		builder.errorMsg = err.Error()
+
+		return builder
+	}
+
+	builder.definition.Ports = append(builder.definition.Ports, *policyPort)
+
+	return builder
+}
+
+// WithNamedPortAndProtocol adds the named container port and protocol to Egress rule.
+func (builder *NetworkPolicyEgressRuleBuilder) WithNamedPortAndProtocol(
+	portName string, protocol corev1.Protocol) *NetworkPolicyEgressRuleBuilder {
+	glog.V(100).Infof("Adding named port %s and protocol %s to NetworkPolicy EgressRule", portName, protocol)
+
+	if builder.errorMsg != "" {
+		return builder
+	}
+
+	policyPort, err := newNetworkPolicyNamedPort(portName, protocol)
+	if err != nil {
+		builder.errorMsg = err.Error()
+
+		return builder
+	}
+
+	builder.definition.Ports = append(builder.definition.Ports, *policyPort)
+
+	return builder
+}
+
+// WithOptions adds generic options to Egress rule.
+func (builder *NetworkPolicyEgressRuleBuilder) WithOptions(
+	options ...NetworkPolicyEgressAdditionalOptions) *NetworkPolicyEgressRuleBuilder {
+	glog.V(100).Infof("Setting NetworkPolicy EgressRule additional options")
+
+	for _, option := range options {
+		if option != nil {
+			builder, err := option(builder)
+
+			if err != nil {
+				glog.V(100).Infof("Error occurred in mutation function")
+
+				builder.errorMsg = err.Error()
+
+				return builder
+			}
+		}
+	}
+
+	return builder
+}
+
+// WithPeerPodSelector adds peer pod selector to Egress rule. The selector applies to the namespace of the
+// NetworkPolicy and may use both matchLabels and matchExpressions.
+func (builder *NetworkPolicyEgressRuleBuilder) WithPeerPodSelector(
+	podSelector metav1.LabelSelector) *NetworkPolicyEgressRuleBuilder {
+	glog.V(100).Infof("Adding peer pod selector %v to NetworkPolicy EgressRule", podSelector)
+
+	if builder.errorMsg != "" {
+		return builder
+	}
+
+	builder.definition.To = append(builder.definition.To, netv1.NetworkPolicyPeer{PodSelector: &podSelector})
+
+	return builder
+}
+
+// WithPeerNamespaceSelector adds peer namespace selector to Egress rule, selecting all pods in the namespaces.
+func (builder *NetworkPolicyEgressRuleBuilder) WithPeerNamespaceSelector(
+	namespaceSelector metav1.LabelSelector) *NetworkPolicyEgressRuleBuilder {
+	glog.V(100).Infof("Adding peer namespace selector %v to NetworkPolicy EgressRule", namespaceSelector)
+
+	if builder.errorMsg != "" {
+		return builder
+	}
+
+	builder.definition.To = append(
+		builder.definition.To, netv1.NetworkPolicyPeer{NamespaceSelector: &namespaceSelector})
+
+	return builder
+}
+
+// WithPeerPodAndNamespaceSelector adds a single peer to Egress rule selecting the pods matching podSelector in the
+// namespaces matching namespaceSelector.
+func (builder *NetworkPolicyEgressRuleBuilder) WithPeerPodAndNamespaceSelector(
+	podSelector, namespaceSelector metav1.LabelSelector) *NetworkPolicyEgressRuleBuilder {
+	glog.V(100).Infof("Adding peer pod selector %v and namespace selector %v to NetworkPolicy EgressRule",
+		podSelector, namespaceSelector)
+
+	if builder.errorMsg != "" {
+		return builder
+	}
+
+	builder.definition.To = append(builder.definition.To, netv1.NetworkPolicyPeer{
+		PodSelector:       &podSelector,
+		NamespaceSelector: &namespaceSelector,
+	})
+
+	return builder
+}
+
+// WithCIDR adds CIDR with optional excepts to Egress rule.
+func (builder *NetworkPolicyEgressRuleBuilder) WithCIDR(
+	cidr string, except ...[]string) *NetworkPolicyEgressRuleBuilder {
+	glog.V(100).Infof("Adding peer CIDR %s to NetworkPolicy EgressRule", cidr)
+
+	if builder.errorMsg != "" {
+		return builder
+	}
+
+	ipBlock, err := newIPBlock(cidr, except...)
+	if err != nil {
+		builder.errorMsg = err.Error()
+
+		return builder
+	}
+
+	builder.definition.To = append(builder.definition.To, netv1.NetworkPolicyPeer{IPBlock: ipBlock})
+
+	return builder
+}
+
+// GetEgressRuleCfg returns NetworkPolicyEgressRule.
+func (builder *NetworkPolicyEgressRuleBuilder) GetEgressRuleCfg() (*netv1.NetworkPolicyEgressRule, error) {
+	glog.V(100).Infof("Returning configuration for NetworkPolicy egress rule")
+
+	if builder.errorMsg != "" {
+		glog.V(100).Infof("Failed to build NetworkPolicy Egress rule configuration due to %s", builder.errorMsg)
+
+		return nil, fmt.Errorf(builder.errorMsg)
+	}
+
+	return builder.definition, nil
+}
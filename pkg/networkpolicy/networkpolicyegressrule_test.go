@@ -0,0 +1,82 @@
+package networkpolicy
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func TestNewNetworkPolicyEgressRuleBuilder(t *testing.T) {
+	builder := NewNetworkPolicyEgressRuleBuilder()
+
+	assert.NotNil(t, builder)
+	assert.NotNil(t, builder.definition)
+}
+
+func TestNetworkPolicyEgressWithPorts(t *testing.T) {
+	builder := NewNetworkPolicyEgressRuleBuilder().
+		WithPortAndProtocol(53, corev1.ProtocolUDP).
+		WithPortRangeAndProtocol(8000, 8080, corev1.ProtocolTCP).
+		WithNamedPortAndProtocol("metrics", corev1.ProtocolTCP)
+
+	assert.Equal(t, "", builder.errorMsg)
+	assert.Len(t, builder.definition.Ports, 3)
+	assert.Equal(t, corev1.ProtocolUDP, *builder.definition.Ports[0].Protocol)
+	assert.Equal(t, int32(8080), *builder.definition.Ports[1].EndPort)
+	assert.Equal(t, intstr.FromString("metrics"), *builder.definition.Ports[2].Port)
+
+	builder = NewNetworkPolicyEgressRuleBuilder().WithPortAndProtocol(0, corev1.ProtocolTCP)
+	assert.Equal(t, "port number can not be 0", builder.errorMsg)
+
+	builder.WithPortAndProtocol(80, corev1.ProtocolTCP)
+	assert.Len(t, builder.definition.Ports, 0)
+}
+
+func TestNetworkPolicyEgressWithOptions(t *testing.T) {
+	builder := NewNetworkPolicyEgressRuleBuilder().WithOptions(
+		func(builder *NetworkPolicyEgressRuleBuilder) (*NetworkPolicyEgressRuleBuilder, error) {
+			return builder, errors.New("this is an error")
+		})
+	assert.Equal(t, "this is an error", builder.errorMsg)
+}
+
+func TestNetworkPolicyEgressWithPeers(t *testing.T) {
+	podSelector := metav1.LabelSelector{MatchLabels: map[string]string{"app": "nginx"}}
+	namespaceSelector := metav1.LabelSelector{MatchLabels: map[string]string{"env": "test"}}
+
+	builder := NewNetworkPolicyEgressRuleBuilder().
+		WithPeerPodSelector(podSelector).
+		WithPeerNamespaceSelector(namespaceSelector).
+		WithPeerPodAndNamespaceSelector(podSelector, namespaceSelector).
+		WithCIDR("10.0.0.0/8", []string{"10.10.0.0/16"})
+
+	assert.Equal(t, "", builder.errorMsg)
+	assert.Len(t, builder.definition.To, 4)
+	assert.Equal(t, podSelector, *builder.definition.To[0].PodSelector)
+	assert.Equal(t, namespaceSelector, *builder.definition.To[1].NamespaceSelector)
+	assert.Equal(t, podSelector, *builder.definition.To[2].PodSelector)
+	assert.Equal(t, namespaceSelector, *builder.definition.To[2].NamespaceSelector)
+	assert.Equal(t, "10.0.0.0/8", builder.definition.To[3].IPBlock.CIDR)
+	assert.Equal(t, []string{"10.10.0.0/16"}, builder.definition.To[3].IPBlock.Except)
+
+	builder = NewNetworkPolicyEgressRuleBuilder().WithCIDR("10.0.0.0/8", []string{"192.168.0.0/16"})
+	assert.Equal(t, "except CIDR 192.168.0.0/16 is not within CIDR 10.0.0.0/8", builder.errorMsg)
+}
+
+func TestNetworkPolicyEgressGetEgressRuleCfg(t *testing.T) {
+	builder := NewNetworkPolicyEgressRuleBuilder()
+
+	cfg, err := builder.GetEgressRuleCfg()
+	assert.Nil(t, err)
+	assert.NotNil(t, cfg)
+
+	builder.errorMsg = "error"
+
+	cfg, err = builder.GetEgressRuleCfg()
+	assert.NotNil(t, err)
+	assert.Nil(t, cfg)
+}
@@ -0,0 +1,296 @@
+package networkpolicy
+
+import (
+	"fmt"
+	"net"
+
+	"github.com/golang/glog"
+	corev1 "k8s.io/api/core/v1"
+	netv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// NetworkPolicyIngressAdditionalOptions additional options for NetworkPolicyIngressRule object.
+type NetworkPolicyIngressAdditionalOptions func(
+	builder *NetworkPolicyIngressRuleBuilder) (*NetworkPolicyIngressRuleBuilder, error)
+
+// NetworkPolicyIngressRuleBuilder provides a struct for NetworkPolicy IngressRule's object definition.
+type NetworkPolicyIngressRuleBuilder struct {
+	// IngressRule definition, used to create the IngressRule object.
+	definition *netv1.NetworkPolicyIngressRule
+	// Used to store latest error message upon defining or mutating IngressRule definition.
+	errorMsg string
+}
+
+// NewNetworkPolicyIngressRuleBuilder creates a new instance of NetworkPolicyIngressRuleBuilder. A rule without peers
+// and ports allows all ingress traffic.
+func NewNetworkPolicyIngressRuleBuilder() *NetworkPolicyIngressRuleBuilder {
+	glog.V(100).Infof("Initializing new NetworkPolicy Ingress rule structure")
+
+	builder := &NetworkPolicyIngressRuleBuilder{
+		definition: &netv1.NetworkPolicyIngressRule{},
+	}
+
+	return builder
+}
+
+// WithPortAndProtocol adds port and protocol to Ingress rule.
+func (builder *NetworkPolicyIngressRuleBuilder) WithPortAndProtocol(
+	port uint16, protocol corev1.Protocol) *NetworkPolicyIngressRuleBuilder {
+	glog.V(100).Infof("Adding port %d and protocol %s to NetworkPolicy IngressRule", port, protocol)
+
+	if builder.errorMsg != "" {
+		return builder
+	}
+
+	policyPort, err := newNetworkPolicyPort(port, 0, protocol)
+	if err != nil {
+		builder.errorMsg = err.Error()
+
+		return builder
+	}
+
+	builder.definition.Ports = append(builder.definition.Ports, *policyPort)
+
+	return builder
+}
+
+// WithPortRangeAndProtocol adds the port range from port to endPort inclusive and protocol to Ingress rule.
+func (builder *NetworkPolicyIngressRuleBuilder) WithPortRangeAndProtocol(
+	port, endPort uint16, protocol corev1.Protocol) *NetworkPolicyIngressRuleBuilder {
+	glog.V(100).Infof("Adding port range %d-%d and protocol %s to NetworkPolicy IngressRule", port, endPort, protocol)
+
+	if builder.errorMsg != "" {
+		return builder
+	}
+
+	policyPort, err := newNetworkPolicyPort(port, endPort, protocol)
+	if err != nil {
+		builder.errorMsg = err.Error()
+
+		return builder
+	}
+
+	builder.definition.Ports = append(builder.definition.Ports, *policyPort)
+
+	return builder
+}
+
+// WithNamedPortAndProtocol adds the named container port and protocol to Ingress rule.
+func (builder *NetworkPolicyIngressRuleBuilder) WithNamedPortAndProtocol(
+	portName string, protocol corev1.Protocol) *NetworkPolicyIngressRuleBuilder {
+	glog.V(100).Infof("Adding named port %s and protocol %s to NetworkPolicy IngressRule", portName, protocol)
+
+	if builder.errorMsg != "" {
+		return builder
+	}
+
+	policyPort, err := newNetworkPolicyNamedPort(portName, protocol)
+	if err != nil {
+		builder.errorMsg = err.Error()
+
+		return builder
+	}
+
+	builder.definition.Ports = append(builder.definition.Ports, *policyPort)
+
+	return builder
+}
+
+// WithOptions adds generic options to Ingress rule.
+func (builder *NetworkPolicyIngressRuleBuilder) WithOptions(
+	options ...NetworkPolicyIngressAdditionalOptions) *NetworkPolicyIngressRuleBuilder {
+	glog.V(100).Infof("Setting NetworkPolicy IngressRule additional options")
+
+	for _, option := range options {
+		if option != nil {
+			builder, err := option(builder)
+
+			if err != nil {
+				glog.V(100).Infof("Error occurred in mutation function")
+
+				builder.errorMsg = err.Error()
+
+				return builder
+			}
+		}
+	}
+
+	return builder
+}
+
+// WithPeerPodSelector adds peer pod selector to Ingress rule. The selector applies to the namespace of the
+// NetworkPolicy and may use both matchLabels and matchExpressions.
+func (builder *NetworkPolicyIngressRuleBuilder) WithPeerPodSelector(
+	podSelector metav1.LabelSelector) *NetworkPolicyIngressRuleBuilder {
+	glog.V(100).Infof("Adding peer pod selector %v to NetworkPolicy IngressRule", podSelector)
+
+	if builder.errorMsg != "" {
+		return builder
+	}
+
+	builder.definition.From = append(builder.definition.From, netv1.NetworkPolicyPeer{PodSelector: &podSelector})
+
+	return builder
+}
+
+// WithPeerNamespaceSelector adds peer namespace selector to Ingress rule, selecting all pods in the namespaces.
+func (builder *NetworkPolicyIngressRuleBuilder) WithPeerNamespaceSelector(
+	namespaceSelector metav1.LabelSelector) *NetworkPolicyIngressRuleBuilder {
+	glog.V(100).Infof("Adding peer namespace selector %v to NetworkPolicy IngressRule", namespaceSelector)
+
+	if builder.errorMsg != "" {
+		return builder
+	}
+
+	builder.definition.From = append(
+		builder.definition.From, netv1.NetworkPolicyPeer{NamespaceSelector: &namespaceSelector})
+
+	return builder
+}
+
+// WithPeerPodAndNamespaceSelector adds a single peer to Ingress rule selecting the pods matching podSelector in the
+// namespaces matching namespaceSelector.
+func (builder *NetworkPolicyIngressRuleBuilder) WithPeerPodAndNamespaceSelector(
+	podSelector, namespaceSelector metav1.LabelSelector) *NetworkPolicyIngressRuleBuilder {
+	glog.V(100).Infof("Adding peer pod selector %v and namespace selector %v to NetworkPolicy IngressRule",
+		podSelector, namespaceSelector)
+
+	if builder.errorMsg != "" {
+		return builder
+	}
+
+	builder.definition.From = append(builder.definition.From, netv1.NetworkPolicyPeer{
+		PodSelector:       &podSelector,
+		NamespaceSelector: &namespaceSelector,
+	})
+
+	return builder
+}
+
+// WithCIDR adds CIDR with optional excepts to Ingress rule.
+func (builder *NetworkPolicyIngressRuleBuilder) WithCIDR(
+	cidr string, except ...[]string) *NetworkPolicyIngressRuleBuilder {
+	glog.V(100).Infof("Adding peer CIDR %s to NetworkPolicy IngressRule", cidr)
+
+	if builder.errorMsg != "" {
+		return builder
+	}
+
+	ipBlock, err := newIPBlock(cidr, except...)
+	if err != nil {
+		builder.errorMsg = err.Error()
+
+		return builder
+	}
+
+	builder.definition.From = append(builder.definition.From, netv1.NetworkPolicyPeer{IPBlock: ipBlock})
+
+	return builder
+}
+
+// GetIngressRuleCfg returns NetworkPolicyIngressRule.
+func (builder *NetworkPolicyIngressRuleBuilder) GetIngressRuleCfg() (*netv1.NetworkPolicyIngressRule, error) {
+	glog.V(100).Infof("Returning configuration for NetworkPolicy ingress rule")
+
+	if builder.errorMsg != "" {
+		glog.V(100).Infof("Failed to build NetworkPolicy Ingress rule configuration due to %s", builder.errorMsg)
+
+		return nil, fmt.Errorf(builder.errorMsg)
+	}
+
+	return builder.definition, nil
+}
+
+// newNetworkPolicyPort returns a NetworkPolicyPort for the given port, or port range when endPort is not 0.
+func newNetworkPolicyPort(port, endPort uint16, protocol corev1.Protocol) (*netv1.NetworkPolicyPort, error) {
+	if port == 0 {
+		glog.V(100).Infof("Port number can not be 0")
+
+		return nil, fmt.Errorf("port number can not be 0")
+	}
+
+	if endPort != 0 && endPort < port {
+		glog.V(100).Infof("The endPort %d is lower than port %d", endPort, port)
+
+		return nil, fmt.Errorf("endPort %d can not be lower than port %d", endPort, port)
+	}
+
+	if err := validateNetworkPolicyProtocol(protocol); err != nil {
+		return nil, err
+	}
+
+	formattedPort := intstr.FromInt32(int32(port))
+	policyPort := &netv1.NetworkPolicyPort{Port: &formattedPort, Protocol: &protocol}
+
+	if endPort != 0 {
+		formattedEndPort := int32(endPort)
+		policyPort.EndPort = &formattedEndPort
+	}
+
+	return policyPort, nil
+}
+
+// newNetworkPolicyNamedPort returns a NetworkPolicyPort referencing a named container port.
+func newNetworkPolicyNamedPort(portName string, protocol corev1.Protocol) (*netv1.NetworkPolicyPort, error) {
+	if portName == "" {
+		glog.V(100).Infof("Port name can not be empty")
+
+		return nil, fmt.Errorf("port name can not be empty")
+	}
+
+	if err := validateNetworkPolicyProtocol(protocol); err != nil {
+		return nil, err
+	}
+
+	formattedPort := intstr.FromString(portName)
+
+	return &netv1.NetworkPolicyPort{Port: &formattedPort, Protocol: &protocol}, nil
+}
+
+func validateNetworkPolicyProtocol(protocol corev1.Protocol) error {
+	switch protocol {
+	case corev1.ProtocolTCP, corev1.ProtocolUDP, corev1.ProtocolSCTP:
+		return nil
+	default:
+		glog.V(100).Infof("Invalid protocol %s", protocol)
+
+		return fmt.Errorf("invalid protocol %s, must be one of TCP, UDP or SCTP", protocol)
+	}
+}
+
+// newIPBlock returns an IPBlock for the given cidr, ensuring every except is a CIDR within it.
+func newIPBlock(cidr string, except ...[]string) (*netv1.IPBlock, error) {
+	_, ipNet, err := net.ParseCIDR(cidr)
+	if err != nil {
+		glog.V(100).Infof("Invalid CIDR %s", cidr)
+
+		return nil, fmt.Errorf("invalid CIDR argument %s", cidr)
+	}
+
+	ipBlock := &netv1.IPBlock{CIDR: cidr}
+
+	if len(except) == 0 {
+		return ipBlock, nil
+	}
+
+	for _, exceptCIDR := range except[0] {
+		exceptIP, _, err := net.ParseCIDR(exceptCIDR)
+		if err != nil {
+			glog.V(100).Infof("Invalid except CIDR %s", exceptCIDR)
+
+			return nil, fmt.Errorf("invalid except CIDR argument %s", exceptCIDR)
+		}
+
+		if !ipNet.Contains(exceptIP) {
+			glog.V(100).Infof("The except CIDR %s is not within CIDR %s", exceptCIDR, cidr)
+
+			return nil, fmt.Errorf("except CIDR %s is not within CIDR %s", exceptCIDR, cidr)
+		}
+	}
+
+	ipBlock.Except = except[0]
+
+	return ipBlock, nil
+}
@@ -0,0 +1,189 @@
+package networkpolicy
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func TestNewNetworkPolicyIngressRuleBuilder(t *testing.T) {
+	builder := NewNetworkPolicyIngressRuleBuilder()
+
+	assert.NotNil(t, builder)
+	assert.NotNil(t, builder.definition)
+}
+
+func TestNetworkPolicyIngressWithPortAndProtocol(t *testing.T) {
+	testCases := []struct {
+		port          uint16
+		protocol      corev1.Protocol
+		expectedError string
+	}{
+		{
+			port:          80,
+			protocol:      corev1.ProtocolTCP,
+			expectedError: "",
+		},
+		{
+			port:          53,
+			protocol:      corev1.ProtocolUDP,
+			expectedError: "",
+		},
+		{
+			port:          0,
+			protocol:      corev1.ProtocolTCP,
+			expectedError: "port number can not be 0",
+		},
+		{
+			port:          80,
+			protocol:      "ICMP",
+			expectedError: "invalid protocol ICMP, must be one of TCP, UDP or SCTP",
+		},
+	}
+
+	for _, testCase := range testCases {
+		builder := NewNetworkPolicyIngressRuleBuilder().WithPortAndProtocol(testCase.port, testCase.protocol)
+		assert.Equal(t, testCase.expectedError, builder.errorMsg)
+
+		if testCase.expectedError == "" {
+			assert.Len(t, builder.definition.Ports, 1)
+			assert.Equal(t, intstr.FromInt32(int32(testCase.port)), *builder.definition.Ports[0].Port)
+			assert.Equal(t, testCase.protocol, *builder.definition.Ports[0].Protocol)
+			assert.Nil(t, builder.definition.Ports[0].EndPort)
+		}
+	}
+}
+
+func TestNetworkPolicyIngressWithPortRangeAndProtocol(t *testing.T) {
+	builder := NewNetworkPolicyIngressRuleBuilder().WithPortRangeAndProtocol(30000, 32767, corev1.ProtocolSCTP)
+	assert.Equal(t, "", builder.errorMsg)
+	assert.Len(t, builder.definition.Ports, 1)
+	assert.Equal(t, int32(30000), builder.definition.Ports[0].Port.IntVal)
+	assert.Equal(t, int32(32767), *builder.definition.Ports[0].EndPort)
+
+	builder = NewNetworkPolicyIngressRuleBuilder().WithPortRangeAndProtocol(8080, 80, corev1.ProtocolTCP)
+	assert.Equal(t, "endPort 80 can not be lower than port 8080", builder.errorMsg)
+}
+
+func TestNetworkPolicyIngressWithNamedPortAndProtocol(t *testing.T) {
+	builder := NewNetworkPolicyIngressRuleBuilder().WithNamedPortAndProtocol("http", corev1.ProtocolTCP)
+	assert.Equal(t, "", builder.errorMsg)
+	assert.Len(t, builder.definition.Ports, 1)
+	assert.Equal(t, intstr.FromString("http"), *builder.definition.Ports[0].Port)
+
+	builder = NewNetworkPolicyIngressRuleBuilder().WithNamedPortAndProtocol("", corev1.ProtocolTCP)
+	assert.Equal(t, "port name can not be empty", builder.errorMsg)
+}
+
+func TestNetworkPolicyIngressWithOptions(t *testing.T) {
+	builder := NewNetworkPolicyIngressRuleBuilder().WithOptions(
+		func(builder *NetworkPolicyIngressRuleBuilder) (*NetworkPolicyIngressRuleBuilder, error) {
+			return builder, errors.New("this is an error")
+		})
+	assert.Equal(t, "this is an error", builder.errorMsg)
+
+	builder = NewNetworkPolicyIngressRuleBuilder().WithOptions(
+		func(builder *NetworkPolicyIngressRuleBuilder) (*NetworkPolicyIngressRuleBuilder, error) {
+			return builder, nil
+		})
+	assert.Equal(t, "", builder.errorMsg)
+}
+
+func TestNetworkPolicyIngressWithPeerSelectors(t *testing.T) {
+	podSelector := metav1.LabelSelector{MatchLabels: map[string]string{"app": "nginx"}}
+	namespaceSelector := metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{{
+		Key:      "kubernetes.io/metadata.name",
+		Operator: metav1.LabelSelectorOpNotIn,
+		Values:   []string{"default"},
+	}}}
+
+	builder := NewNetworkPolicyIngressRuleBuilder().
+		WithPeerPodSelector(podSelector).
+		WithPeerNamespaceSelector(namespaceSelector).
+		WithPeerPodAndNamespaceSelector(podSelector, namespaceSelector)
+
+	assert.Equal(t, "", builder.errorMsg)
+	assert.Len(t, builder.definition.From, 3)
+	assert.Equal(t, podSelector, *builder.definition.From[0].PodSelector)
+	assert.Nil(t, builder.definition.From[0].NamespaceSelector)
+	assert.Equal(t, namespaceSelector, *builder.definition.From[1].NamespaceSelector)
+	assert.Nil(t, builder.definition.From[1].PodSelector)
+	assert.Equal(t, podSelector, *builder.definition.From[2].PodSelector)
+	assert.Equal(t, namespaceSelector, *builder.definition.From[2].NamespaceSelector)
+
+	builder = NewNetworkPolicyIngressRuleBuilder()
+	builder.errorMsg = "error"
+
+	builder.WithPeerPodSelector(podSelector).WithPeerNamespaceSelector(namespaceSelector)
+	assert.Len(t, builder.definition.From, 0)
+}
+
+func TestNetworkPolicyIngressWithCIDR(t *testing.T) {
+	testCases := []struct {
+		cidr          string
+		except        []string
+		expectedError string
+	}{
+		{
+			cidr:          "192.168.1.0/24",
+			except:        nil,
+			expectedError: "",
+		},
+		{
+			cidr:          "192.168.1.0/24",
+			except:        []string{"192.168.1.128/25"},
+			expectedError: "",
+		},
+		{
+			cidr:          "2001:db8::/64",
+			except:        []string{"2001:db8::1/128"},
+			expectedError: "",
+		},
+		{
+			cidr:          "192.168.1.1",
+			except:        nil,
+			expectedError: "invalid CIDR argument 192.168.1.1",
+		},
+		{
+			cidr:          "192.168.1.0/24",
+			except:        []string{"192.168.1.1"},
+			expectedError: "invalid except CIDR argument 192.168.1.1",
+		},
+		{
+			cidr:          "192.168.1.0/24",
+			except:        []string{"192.168.2.0/25"},
+			expectedError: "except CIDR 192.168.2.0/25 is not within CIDR 192.168.1.0/24",
+		},
+	}
+
+	for _, testCase := range testCases {
+		builder := NewNetworkPolicyIngressRuleBuilder().WithCIDR(testCase.cidr, testCase.except)
+		assert.Equal(t, testCase.expectedError, builder.errorMsg)
+
+		if testCase.expectedError == "" {
+			assert.Len(t, builder.definition.From, 1)
+			assert.Equal(t, testCase.cidr, builder.definition.From[0].IPBlock.CIDR)
+			assert.Equal(t, testCase.except, builder.definition.From[0].IPBlock.Except)
+		} else {
+			assert.Len(t, builder.definition.From, 0)
+		}
+	}
+}
+
+func TestNetworkPolicyIngressGetIngressRuleCfg(t *testing.T) {
+	builder := NewNetworkPolicyIngressRuleBuilder()
+
+	cfg, err := builder.GetIngressRuleCfg()
+	assert.Nil(t, err)
+	assert.NotNil(t, cfg)
+
+	builder.errorMsg = "error"
+
+	cfg, err = builder.GetIngressRuleCfg()
+	assert.NotNil(t, err)
+	assert.Nil(t, cfg)
+}
@@ -0,0 +1,278 @@
+package nmstate
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/golang/glog"
+
+	nmstateShared "github.com/nmstate/kubernetes-nmstate/api/shared"
+	nmstateV1alpha1 "github.com/nmstate/kubernetes-nmstate/api/v1alpha1"
+
+	"github.com/openshift-kni/eco-goinfra/pkg/clients"
+	"github.com/openshift-kni/eco-goinfra/pkg/msg"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	goclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// EnactmentBuilder provides struct for the NodeNetworkConfigurationEnactment object containing connection to the
+// cluster. Enactments are created by nmstate for each node matched by a NodeNetworkConfigurationPolicy and hold the
+// result of applying the policy on that node.
+type EnactmentBuilder struct {
+	// NodeNetworkConfigurationEnactment definition, used to look up the enactment on the cluster.
+	Definition *nmstateV1alpha1.NodeNetworkConfigurationEnactment
+	// Created NodeNetworkConfigurationEnactment object on the cluster.
+	Object *nmstateV1alpha1.NodeNetworkConfigurationEnactment
+	// API client to interact with the cluster.
+	apiClient *clients.Settings
+	// errorMsg is processed before NodeNetworkConfigurationEnactment object is collected.
+	errorMsg string
+}
+
+// PullEnactment retrieves an existing NodeNetworkConfigurationEnactment object from the cluster. Enactments are named
+// after the node and the policy, e.g. worker-0.policy-name.
+func PullEnactment(apiClient *clients.Settings, name string) (*EnactmentBuilder, error) {
+	glog.V(100).Infof("Pulling NodeNetworkConfigurationEnactment object name: %s", name)
+
+	if apiClient == nil {
+		glog.V(100).Infof("The apiClient of the NodeNetworkConfigurationEnactment is nil")
+
+		return nil, fmt.Errorf("nodeNetworkConfigurationEnactment 'apiClient' cannot be nil")
+	}
+
+	builder := EnactmentBuilder{
+		apiClient: apiClient,
+		Definition: &nmstateV1alpha1.NodeNetworkConfigurationEnactment{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: name,
+			},
+		},
+	}
+
+	if name == "" {
+		glog.V(100).Infof("The name of the NodeNetworkConfigurationEnactment is empty")
+
+		return nil, fmt.Errorf("nodeNetworkConfigurationEnactment 'name' cannot be empty")
+	}
+
+	if !builder.Exists() {
+		return nil, fmt.Errorf("nodeNetworkConfigurationEnactment object %s does not exist", name)
+	}
+
+	return &builder, nil
+}
+
+// Exists checks whether the given NodeNetworkConfigurationEnactment exists.
+func (builder *EnactmentBuilder) Exists() bool {
+	if valid, _ := builder.validate(); !valid {
+		return false
+	}
+
+	glog.V(100).Infof("Checking if NodeNetworkConfigurationEnactment %s exists", builder.Definition.Name)
+
+	enactment, err := builder.Get()
+	if err != nil {
+		glog.V(100).Infof("Failed to collect NodeNetworkConfigurationEnactment object due to %s", err.Error())
+	} else {
+		builder.Object = enactment
+	}
+
+	return err == nil || !k8serrors.IsNotFound(err)
+}
+
+// Get returns NodeNetworkConfigurationEnactment object if found.
+func (builder *EnactmentBuilder) Get() (*nmstateV1alpha1.NodeNetworkConfigurationEnactment, error) {
+	if valid, err := builder.validate(); !valid {
+		return nil, err
+	}
+
+	glog.V(100).Infof("Collecting NodeNetworkConfigurationEnactment object %s", builder.Definition.Name)
+
+	enactment := &nmstateV1alpha1.NodeNetworkConfigurationEnactment{}
+	err := builder.apiClient.Get(context.TODO(), goclient.ObjectKey{
+		Name: builder.Definition.Name,
+	}, enactment)
+
+	if err != nil {
+		glog.V(100).Infof("NodeNetworkConfigurationEnactment object %s does not exist", builder.Definition.Name)
+
+		return nil, err
+	}
+
+	return enactment, nil
+}
+
+// GetNodeName returns the name of the node the NodeNetworkConfigurationEnactment belongs to. The node label is used
+// when present, otherwise the node name is taken from the enactment name.
+func (builder *EnactmentBuilder) GetNodeName() (string, error) {
+	if valid, err := builder.validate(); !valid {
+		return "", err
+	}
+
+	if builder.Object == nil {
+		return "", fmt.Errorf("nodeNetworkConfigurationEnactment object %s has not been collected",
+			builder.Definition.Name)
+	}
+
+	if nodeName, ok := builder.Object.Labels[nmstateShared.EnactmentNodeLabel]; ok && nodeName != "" {
+		return nodeName, nil
+	}
+
+	policyName := builder.Object.Labels[nmstateShared.EnactmentPolicyLabel]
+	if policyName != "" && strings.HasSuffix(builder.Definition.Name, "."+policyName) {
+		return strings.TrimSuffix(builder.Definition.Name, "."+policyName), nil
+	}
+
+	glog.V(100).Infof("Failed to determine the node of NodeNetworkConfigurationEnactment %s", builder.Definition.Name)
+
+	return "", fmt.Errorf("failed to determine the node of nodeNetworkConfigurationEnactment %s",
+		builder.Definition.Name)
+}
+
+// IsInCondition checks whether the NodeNetworkConfigurationEnactment has the given condition set to true. The object
+// stored in the builder is used, so Exists or Get should be called first to refresh it.
+func (builder *EnactmentBuilder) IsInCondition(condition nmstateShared.ConditionType) bool {
+	if valid, _ := builder.validate(); !valid || builder.Object == nil {
+		return false
+	}
+
+	foundCondition := builder.Object.Status.Conditions.Find(condition)
+
+	return foundCondition != nil && foundCondition.Status == corev1.ConditionTrue
+}
+
+// GetFailureMessage returns the nmstate error output of a failing NodeNetworkConfigurationEnactment. An error is
+// returned if the enactment is not failing.
+func (builder *EnactmentBuilder) GetFailureMessage() (string, error) {
+	if valid, err := builder.validate(); !valid {
+		return "", err
+	}
+
+	glog.V(100).Infof("Getting failure message of NodeNetworkConfigurationEnactment %s", builder.Definition.Name)
+
+	if builder.Object == nil {
+		return "", fmt.Errorf("nodeNetworkConfigurationEnactment object %s has not been collected",
+			builder.Definition.Name)
+	}
+
+	failingCondition := builder.Object.Status.Conditions.Find(
+		nmstateShared.NodeNetworkConfigurationEnactmentConditionFailing)
+	if failingCondition == nil || failingCondition.Status != corev1.ConditionTrue {
+		glog.V(100).Infof("NodeNetworkConfigurationEnactment %s is not failing", builder.Definition.Name)
+
+		return "", fmt.Errorf("nodeNetworkConfigurationEnactment %s is not failing", builder.Definition.Name)
+	}
+
+	return failingCondition.Message, nil
+}
+
+// WaitUntilCondition waits for the duration of the defined timeout or until the
+// NodeNetworkConfigurationEnactment gets to a specific condition.
+func (builder *EnactmentBuilder) WaitUntilCondition(
+	condition nmstateShared.ConditionType, timeout time.Duration) error {
+	if valid, err := builder.validate(); !valid {
+		return err
+	}
+
+	glog.V(100).Infof("Waiting for the defined period until NodeNetworkConfigurationEnactment %s has condition %v",
+		builder.Definition.Name, condition)
+
+	return wait.PollUntilContextTimeout(
+		context.TODO(), retryInterval, timeout, true, func(ctx context.Context) (bool, error) {
+			enactment, err := builder.Get()
+			if err != nil {
+				return false, nil
+			}
+
+			builder.Object = enactment
+
+			return builder.IsInCondition(condition), nil
+		})
+}
+
+// ListEnactments returns a list of NodeNetworkConfigurationEnactment.
+func ListEnactments(apiClient *clients.Settings, options ...goclient.ListOptions) ([]*EnactmentBuilder, error) {
+	if apiClient == nil {
+		glog.V(100).Infof("NodeNetworkConfigurationEnactment 'apiClient' parameter can not be empty")
+
+		return nil, fmt.Errorf("failed to list NodeNetworkConfigurationEnactment, 'apiClient' parameter is empty")
+	}
+
+	passedOptions := goclient.ListOptions{}
+	logMessage := "Listing NodeNetworkConfigurationEnactment"
+
+	if len(options) > 1 {
+		glog.V(100).Infof("'options' parameter must be empty or single-valued")
+
+		return nil, fmt.Errorf("error: more than one ListOptions was passed")
+	}
+
+	if len(options) == 1 {
+		passedOptions = options[0]
+		logMessage += fmt.Sprintf(" with the options %v", passedOptions)
+	}
+
+	glog.V(100).Infof(logMessage)
+
+	enactmentList := &nmstateV1alpha1.NodeNetworkConfigurationEnactmentList{}
+	err := apiClient.Client.List(context.TODO(), enactmentList, &passedOptions)
+
+	if err != nil {
+		glog.V(100).Infof("Failed to list NodeNetworkConfigurationEnactment due to %s", err.Error())
+
+		return nil, err
+	}
+
+	var enactmentObjects []*EnactmentBuilder
+
+	for _, enactment := range enactmentList.Items {
+		copiedEnactment := enactment
+		enactmentBuilder := &EnactmentBuilder{
+			apiClient:  apiClient,
+			Definition: &copiedEnactment,
+			Object:     &copiedEnactment,
+		}
+
+		enactmentObjects = append(enactmentObjects, enactmentBuilder)
+	}
+
+	return enactmentObjects, nil
+}
+
+// validate will check that the builder and builder definition are properly initialized before
+// accessing any member fields.
+func (builder *EnactmentBuilder) validate() (bool, error) {
+	resourceCRD := "NodeNetworkConfigurationEnactment"
+
+	if builder == nil {
+		glog.V(100).Infof("The %s builder is uninitialized", resourceCRD)
+
+		return false, fmt.Errorf("error: received nil %s builder", resourceCRD)
+	}
+
+	if builder.Definition == nil {
+		glog.V(100).Infof("The %s is undefined", resourceCRD)
+
+		builder.errorMsg = msg.UndefinedCrdObjectErrString(resourceCRD)
+	}
+
+	if builder.apiClient == nil {
+		glog.V(100).Infof("The %s builder apiclient is nil", resourceCRD)
+
+		builder.errorMsg = fmt.Sprintf("%s builder cannot have nil apiClient", resourceCRD)
+	}
+
+	if builder.errorMsg != "" {
+		glog.V(100).Infof("The %s builder has error message: %s", resourceCRD, builder.errorMsg)
+
+		return false, fmt.Errorf("%s", builder.errorMsg)
+	}
+
+	return true, nil
+}
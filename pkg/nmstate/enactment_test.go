@@ -0,0 +1,228 @@
+package nmstate
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	nmstateShared "github.com/nmstate/kubernetes-nmstate/api/shared"
+	nmstateV1alpha1 "github.com/nmstate/kubernetes-nmstate/api/v1alpha1"
+	"github.com/openshift-kni/eco-goinfra/pkg/clients"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	goclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+const (
+	defaultEnactmentNode   = "worker-0"
+	defaultEnactmentPolicy = "policy"
+	defaultFailureMessage  = "error reconciling NodeNetworkConfigurationPolicy: failed to execute nmstatectl"
+)
+
+func TestPullEnactment(t *testing.T) {
+	testCases := []struct {
+		name                string
+		addToRuntimeObjects bool
+		client              bool
+		expectedError       error
+	}{
+		{
+			name:                buildEnactmentTestName(defaultEnactmentNode),
+			addToRuntimeObjects: true,
+			client:              true,
+			expectedError:       nil,
+		},
+		{
+			name:                "",
+			addToRuntimeObjects: true,
+			client:              true,
+			expectedError:       fmt.Errorf("nodeNetworkConfigurationEnactment 'name' cannot be empty"),
+		},
+		{
+			name:                buildEnactmentTestName(defaultEnactmentNode),
+			addToRuntimeObjects: false,
+			client:              true,
+			expectedError: fmt.Errorf(
+				"nodeNetworkConfigurationEnactment object %s does not exist", buildEnactmentTestName(defaultEnactmentNode)),
+		},
+		{
+			name:                buildEnactmentTestName(defaultEnactmentNode),
+			addToRuntimeObjects: true,
+			client:              false,
+			expectedError:       fmt.Errorf("nodeNetworkConfigurationEnactment 'apiClient' cannot be nil"),
+		},
+	}
+
+	for _, testCase := range testCases {
+		var (
+			runtimeObjects []runtime.Object
+			testSettings   *clients.Settings
+		)
+
+		if testCase.addToRuntimeObjects {
+			runtimeObjects = append(runtimeObjects,
+				buildDummyEnactment(defaultEnactmentNode, nmstateShared.NodeNetworkConfigurationEnactmentConditionAvailable, ""))
+		}
+
+		if testCase.client {
+			testSettings = clients.GetTestClients(clients.TestClientParams{K8sMockObjects: runtimeObjects})
+		}
+
+		testBuilder, err := PullEnactment(testSettings, testCase.name)
+		assert.Equal(t, testCase.expectedError, err)
+
+		if testCase.expectedError == nil {
+			assert.Equal(t, testCase.name, testBuilder.Object.Name)
+		}
+	}
+}
+
+func TestEnactmentGetNodeName(t *testing.T) {
+	enactment := buildDummyEnactment(
+		defaultEnactmentNode, nmstateShared.NodeNetworkConfigurationEnactmentConditionAvailable, "")
+	testBuilder := buildEnactmentTestBuilder(enactment)
+
+	nodeName, err := testBuilder.GetNodeName()
+	assert.Nil(t, err)
+	assert.Equal(t, defaultEnactmentNode, nodeName)
+
+	delete(enactment.Labels, nmstateShared.EnactmentNodeLabel)
+
+	nodeName, err = testBuilder.GetNodeName()
+	assert.Nil(t, err)
+	assert.Equal(t, defaultEnactmentNode, nodeName)
+
+	enactment.Labels = nil
+
+	_, err = testBuilder.GetNodeName()
+	assert.Equal(t, fmt.Errorf("failed to determine the node of nodeNetworkConfigurationEnactment %s",
+		enactment.Name), err)
+}
+
+func TestEnactmentGetFailureMessage(t *testing.T) {
+	testCases := []struct {
+		condition       nmstateShared.ConditionType
+		expectedMessage string
+		expectedError   error
+	}{
+		{
+			condition:       nmstateShared.NodeNetworkConfigurationEnactmentConditionFailing,
+			expectedMessage: defaultFailureMessage,
+			expectedError:   nil,
+		},
+		{
+			condition:       nmstateShared.NodeNetworkConfigurationEnactmentConditionAvailable,
+			expectedMessage: "",
+			expectedError: fmt.Errorf("nodeNetworkConfigurationEnactment %s is not failing",
+				buildEnactmentTestName(defaultEnactmentNode)),
+		},
+	}
+
+	for _, testCase := range testCases {
+		testBuilder := buildEnactmentTestBuilder(
+			buildDummyEnactment(defaultEnactmentNode, testCase.condition, defaultFailureMessage))
+
+		message, err := testBuilder.GetFailureMessage()
+		assert.Equal(t, testCase.expectedError, err)
+		assert.Equal(t, testCase.expectedMessage, message)
+	}
+}
+
+func TestEnactmentWaitUntilCondition(t *testing.T) {
+	testCases := []struct {
+		condition     nmstateShared.ConditionType
+		expectedError error
+	}{
+		{
+			condition:     nmstateShared.NodeNetworkConfigurationEnactmentConditionAvailable,
+			expectedError: nil,
+		},
+		{
+			condition:     nmstateShared.NodeNetworkConfigurationEnactmentConditionFailing,
+			expectedError: context.DeadlineExceeded,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testBuilder := buildEnactmentTestBuilder(buildDummyEnactment(
+			defaultEnactmentNode, nmstateShared.NodeNetworkConfigurationEnactmentConditionAvailable, ""))
+
+		err := testBuilder.WaitUntilCondition(testCase.condition, time.Second)
+		assert.Equal(t, testCase.expectedError, err)
+	}
+}
+
+func TestListEnactments(t *testing.T) {
+	testSettings := clients.GetTestClients(clients.TestClientParams{K8sMockObjects: []runtime.Object{
+		buildDummyEnactment("worker-0", nmstateShared.NodeNetworkConfigurationEnactmentConditionAvailable, ""),
+		buildDummyEnactment("worker-1", nmstateShared.NodeNetworkConfigurationEnactmentConditionAvailable, ""),
+	}})
+
+	enactments, err := ListEnactments(testSettings)
+	assert.Nil(t, err)
+	assert.Len(t, enactments, 2)
+
+	_, err = ListEnactments(testSettings, goclient.ListOptions{}, goclient.ListOptions{})
+	assert.Equal(t, fmt.Errorf("error: more than one ListOptions was passed"), err)
+
+	_, err = ListEnactments(nil)
+	assert.Equal(t, fmt.Errorf("failed to list NodeNetworkConfigurationEnactment, 'apiClient' parameter is empty"), err)
+}
+
+func TestEnactmentExistsTransientError(t *testing.T) {
+	enactment := buildDummyEnactment(
+		defaultEnactmentNode, nmstateShared.NodeNetworkConfigurationEnactmentConditionAvailable, "")
+	testBuilder := buildEnactmentTestBuilder(enactment)
+
+	fakeClient := testBuilder.apiClient.Client
+	testBuilder.apiClient.Client = interceptor.NewClient(fakeClient.(goclient.WithWatch), interceptor.Funcs{
+		Get: func(
+			ctx context.Context, client goclient.WithWatch, key goclient.ObjectKey, obj goclient.Object,
+			opts ...goclient.GetOption) error {
+			return fmt.Errorf("transient error")
+		},
+	})
+
+	assert.True(t, testBuilder.Exists())
+	assert.Equal(t, enactment, testBuilder.Object)
+
+	testBuilder.apiClient.Client = fakeClient
+
+	err := testBuilder.WaitUntilCondition(
+		nmstateShared.NodeNetworkConfigurationEnactmentConditionAvailable, time.Second)
+	assert.Nil(t, err)
+}
+
+func buildEnactmentTestName(nodeName string) string {
+	return nmstateShared.EnactmentKey(nodeName, defaultEnactmentPolicy).Name
+}
+
+func buildEnactmentTestBuilder(enactment *nmstateV1alpha1.NodeNetworkConfigurationEnactment) *EnactmentBuilder {
+	testSettings := clients.GetTestClients(clients.TestClientParams{K8sMockObjects: []runtime.Object{enactment}})
+
+	return &EnactmentBuilder{apiClient: testSettings, Definition: enactment, Object: enactment}
+}
+
+func buildDummyEnactment(
+	nodeName string,
+	condition nmstateShared.ConditionType,
+	message string) *nmstateV1alpha1.NodeNetworkConfigurationEnactment {
+	return &nmstateV1alpha1.NodeNetworkConfigurationEnactment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: buildEnactmentTestName(nodeName),
+			Labels: map[string]string{
+				nmstateShared.EnactmentPolicyLabel: defaultEnactmentPolicy,
+				nmstateShared.EnactmentNodeLabel:   nodeName,
+			},
+		},
+		Status: nmstateShared.NodeNetworkConfigurationEnactmentStatus{
+			Conditions: nmstateShared.ConditionList{
+				nmstateShared.NewCondition(condition, corev1.ConditionTrue, "", message),
+			},
+		},
+	}
+}
@@ -19,10 +19,15 @@ import (
 	goclient "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+const (
+	// mainRouteTableID is the ID of the main routing table, where routes are added when no table is specified.
+	mainRouteTableID = 254
+)
+
 var (
 	// allowedInterfaceTypes represents all allowed types for interface.
-	allowedInterfaceTypes = []string{"ethernet", "bond", "ovs-bridge", "unknown",
-		"vlan", "vxlan", "linux-bridge", "team", "veth"}
+	allowedInterfaceTypes = []string{"ethernet", "bond", "ovs-bridge", "ovs-interface", "unknown",
+		"vlan", "vxlan", "linux-bridge", "team", "veth", "vrf", "mac-vlan"}
 )
 
 // StateBuilder provides struct for the NodeNetworkState object containing connection to the cluster.
@@ -179,6 +184,244 @@ func (builder *StateBuilder) GetSriovVfs(sriovInterfaceName string) ([]Vf, error
 		"or SR-IOV VFs are not configured on it", sriovInterfaceName)
 }
 
+// GetInterfaces returns the interfaces reported in the NodeNetworkState current state with the given type and state,
+// e.g. ethernet and up. An empty interfaceType or interfaceState matches any value.
+func (builder *StateBuilder) GetInterfaces(interfaceType, interfaceState string) ([]NetworkInterface, error) {
+	if valid, err := builder.validate(); !valid {
+		return nil, err
+	}
+
+	glog.V(100).Infof("Getting interfaces with type %q and state %q from NodeNetworkState %s",
+		interfaceType, interfaceState, builder.Object.Name)
+
+	currentState, err := builder.getCurrentState()
+	if err != nil {
+		return nil, err
+	}
+
+	var interfaces []NetworkInterface
+
+	for _, networkInterface := range currentState.Interfaces {
+		if interfaceType != "" && networkInterface.Type != interfaceType {
+			continue
+		}
+
+		if interfaceState != "" && networkInterface.State != interfaceState {
+			continue
+		}
+
+		interfaces = append(interfaces, networkInterface)
+	}
+
+	return interfaces, nil
+}
+
+// GetInterface returns the interface with the given name from the NodeNetworkState current state. When an ovs-bridge
+// and its ovs-interface share the name, the ovs-interface is returned as it is the one holding the IP configuration.
+func (builder *StateBuilder) GetInterface(interfaceName string) (NetworkInterface, error) {
+	if valid, err := builder.validate(); !valid {
+		return NetworkInterface{}, err
+	}
+
+	glog.V(100).Infof("Getting interface %s from NodeNetworkState %s", interfaceName, builder.Object.Name)
+
+	if interfaceName == "" {
+		glog.V(100).Infof("The interfaceName can not be empty string")
+
+		return NetworkInterface{}, fmt.Errorf("the interfaceName is empty sting")
+	}
+
+	currentState, err := builder.getCurrentState()
+	if err != nil {
+		return NetworkInterface{}, err
+	}
+
+	var (
+		foundInterface NetworkInterface
+		found          bool
+	)
+
+	for _, networkInterface := range currentState.Interfaces {
+		if networkInterface.Name != interfaceName {
+			continue
+		}
+
+		if !found || networkInterface.Type != "ovs-bridge" {
+			foundInterface = networkInterface
+			found = true
+		}
+	}
+
+	if !found {
+		return NetworkInterface{}, fmt.Errorf("failed to find interface %s", interfaceName)
+	}
+
+	return foundInterface, nil
+}
+
+// GetInterfaceIPv4Addresses returns the IPv4 addresses of the given interface. Interfaces with IPv4 disabled return
+// no addresses.
+func (builder *StateBuilder) GetInterfaceIPv4Addresses(interfaceName string) ([]IPAddress, error) {
+	networkInterface, err := builder.GetInterface(interfaceName)
+	if err != nil {
+		return nil, err
+	}
+
+	if networkInterface.IPv4 == nil {
+		return nil, nil
+	}
+
+	return networkInterface.IPv4.Address, nil
+}
+
+// GetInterfaceIPv6Addresses returns the IPv6 addresses of the given interface, including link-local ones. Interfaces
+// with IPv6 disabled return no addresses.
+func (builder *StateBuilder) GetInterfaceIPv6Addresses(interfaceName string) ([]IPAddress, error) {
+	networkInterface, err := builder.GetInterface(interfaceName)
+	if err != nil {
+		return nil, err
+	}
+
+	if networkInterface.IPv6 == nil {
+		return nil, nil
+	}
+
+	return networkInterface.IPv6.Address, nil
+}
+
+// GetInterfaceMTU returns the MTU of the given interface.
+func (builder *StateBuilder) GetInterfaceMTU(interfaceName string) (int, error) {
+	networkInterface, err := builder.GetInterface(interfaceName)
+	if err != nil {
+		return 0, err
+	}
+
+	return networkInterface.MTU, nil
+}
+
+// GetRoutes returns the running routes reported in the NodeNetworkState current state.
+func (builder *StateBuilder) GetRoutes() ([]Route, error) {
+	if valid, err := builder.validate(); !valid {
+		return nil, err
+	}
+
+	glog.V(100).Infof("Getting running routes from NodeNetworkState %s", builder.Object.Name)
+
+	currentState, err := builder.getCurrentState()
+	if err != nil {
+		return nil, err
+	}
+
+	if currentState.Routes == nil {
+		return nil, nil
+	}
+
+	return currentState.Routes.Running, nil
+}
+
+// GetDefaultRouteInterface returns the next hop interface of the IPv4 or IPv6 default route in the main routing
+// table. When several default routes are present, the one with the lowest metric is used.
+func (builder *StateBuilder) GetDefaultRouteInterface(ipv6 bool) (string, error) {
+	if valid, err := builder.validate(); !valid {
+		return "", err
+	}
+
+	glog.V(100).Infof("Getting default route interface (ipv6: %t) from NodeNetworkState %s", ipv6, builder.Object.Name)
+
+	routes, err := builder.GetRoutes()
+	if err != nil {
+		return "", err
+	}
+
+	defaultDestination := "0.0.0.0/0"
+	if ipv6 {
+		defaultDestination = "::/0"
+	}
+
+	var defaultRoute *Route
+
+	for index, route := range routes {
+		if route.Destination != defaultDestination || route.NextHopInterface == "" {
+			continue
+		}
+
+		if route.TableID != nil && *route.TableID != mainRouteTableID {
+			continue
+		}
+
+		if defaultRoute == nil || getRouteMetric(route) < getRouteMetric(*defaultRoute) {
+			defaultRoute = &routes[index]
+		}
+	}
+
+	if defaultRoute == nil {
+		return "", fmt.Errorf("failed to find default route %s in NodeNetworkState %s",
+			defaultDestination, builder.Object.Name)
+	}
+
+	return defaultRoute.NextHopInterface, nil
+}
+
+// GetDNSResolver returns the running DNS resolver configuration reported in the NodeNetworkState current state.
+func (builder *StateBuilder) GetDNSResolver() (*DNSConfig, error) {
+	if valid, err := builder.validate(); !valid {
+		return nil, err
+	}
+
+	glog.V(100).Infof("Getting running DNS resolver from NodeNetworkState %s", builder.Object.Name)
+
+	currentState, err := builder.getCurrentState()
+	if err != nil {
+		return nil, err
+	}
+
+	if currentState.DNSResolver == nil || currentState.DNSResolver.Running == nil {
+		return nil, fmt.Errorf("failed to find running dns resolver in NodeNetworkState %s", builder.Object.Name)
+	}
+
+	return currentState.DNSResolver.Running, nil
+}
+
+// GetBondMembers returns the ports of the given bond interface.
+func (builder *StateBuilder) GetBondMembers(bondName string) ([]string, error) {
+	if valid, err := builder.validate(); !valid {
+		return nil, err
+	}
+
+	glog.V(100).Infof("Getting members of bond %s from NodeNetworkState %s", bondName, builder.Object.Name)
+
+	bondInterface, err := builder.GetInterfaceType(bondName, "bond")
+	if err != nil {
+		return nil, err
+	}
+
+	return bondInterface.LinkAggregation.Port, nil
+}
+
+// GetBridgePorts returns the names of the ports of the given linux-bridge or ovs-bridge interface.
+func (builder *StateBuilder) GetBridgePorts(bridgeName string) ([]string, error) {
+	if valid, err := builder.validate(); !valid {
+		return nil, err
+	}
+
+	glog.V(100).Infof("Getting ports of bridge %s from NodeNetworkState %s", bridgeName, builder.Object.Name)
+
+	bridgeInterface, err := builder.GetInterfaceType(bridgeName, "linux-bridge")
+	if err != nil {
+		bridgeInterface, err = builder.GetInterfaceType(bridgeName, "ovs-bridge")
+		if err != nil {
+			return nil, fmt.Errorf("failed to find bridge %s", bridgeName)
+		}
+	}
+
+	var ports []string
+	for _, port := range bridgeInterface.Bridge.Ports {
+		ports = append(ports, port.Name)
+	}
+
+	return ports, nil
+}
+
 // PullNodeNetworkState retrieves an existing NodeNetworkState object from the cluster.
 func PullNodeNetworkState(apiClient *clients.Settings, name string) (*StateBuilder, error) {
 	glog.V(100).Infof("Pulling NodeNetworkState object name:%s", name)
@@ -236,3 +479,26 @@ func (builder *StateBuilder) validate() (bool, error) {
 
 	return true, nil
 }
+
+// getCurrentState unmarshals the current state reported in the NodeNetworkState.
+func (builder *StateBuilder) getCurrentState() (*DesiredState, error) {
+	var currentState DesiredState
+
+	err := yaml.Unmarshal(builder.Object.Status.CurrentState.Raw, &currentState)
+	if err != nil {
+		glog.V(100).Infof("Failed to unmarshal NodeNetworkState %s current state: %v", builder.Object.Name, err)
+
+		return nil, fmt.Errorf("failed to Unmarshal NMState state")
+	}
+
+	return &currentState, nil
+}
+
+// getRouteMetric returns the metric of the route, treating an unset metric as zero like the kernel does.
+func getRouteMetric(route Route) int {
+	if route.Metric == nil {
+		return 0
+	}
+
+	return *route.Metric
+}
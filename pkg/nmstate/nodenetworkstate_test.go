@@ -0,0 +1,199 @@
+package nmstate
+
+import (
+	"fmt"
+	"testing"
+
+	nmstateShared "github.com/nmstate/kubernetes-nmstate/api/shared"
+	nmstateV1alpha1 "github.com/nmstate/kubernetes-nmstate/api/v1alpha1"
+	"github.com/openshift-kni/eco-goinfra/pkg/clients"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const defaultNodeNetworkStateName = "worker-0"
+
+func TestStateGetInterfaces(t *testing.T) {
+	testCases := []struct {
+		interfaceType  string
+		interfaceState string
+		expectedNames  []string
+	}{
+		{
+			interfaceType:  "ethernet",
+			interfaceState: "",
+			expectedNames:  []string{"eno1", "eno7"},
+		},
+		{
+			interfaceType:  "ethernet",
+			interfaceState: "up",
+			expectedNames:  []string{"eno1"},
+		},
+		{
+			interfaceType:  "",
+			interfaceState: "down",
+			expectedNames:  []string{"eno7"},
+		},
+		{
+			interfaceType:  "team",
+			interfaceState: "",
+			expectedNames:  nil,
+		},
+	}
+
+	testBuilder := buildValidStateTestBuilder(t)
+
+	for _, testCase := range testCases {
+		interfaces, err := testBuilder.GetInterfaces(testCase.interfaceType, testCase.interfaceState)
+		assert.Nil(t, err)
+
+		var names []string
+		for _, networkInterface := range interfaces {
+			names = append(names, networkInterface.Name)
+		}
+
+		assert.Equal(t, testCase.expectedNames, names)
+	}
+}
+
+func TestStateGetInterface(t *testing.T) {
+	testCases := []struct {
+		interfaceName string
+		expectedType  string
+		expectedError error
+	}{
+		{
+			interfaceName: "eno1",
+			expectedType:  "ethernet",
+			expectedError: nil,
+		},
+		{
+			interfaceName: "br-ex",
+			expectedType:  "ovs-interface",
+			expectedError: nil,
+		},
+		{
+			interfaceName: "eno9",
+			expectedType:  "",
+			expectedError: fmt.Errorf("failed to find interface eno9"),
+		},
+		{
+			interfaceName: "",
+			expectedType:  "",
+			expectedError: fmt.Errorf("the interfaceName is empty sting"),
+		},
+	}
+
+	testBuilder := buildValidStateTestBuilder(t)
+
+	for _, testCase := range testCases {
+		networkInterface, err := testBuilder.GetInterface(testCase.interfaceName)
+		assert.Equal(t, testCase.expectedError, err)
+		assert.Equal(t, testCase.expectedType, networkInterface.Type)
+	}
+}
+
+func TestStateGetInterfaceAddressesAndMTU(t *testing.T) {
+	testBuilder := buildValidStateTestBuilder(t)
+
+	ipv4Addresses, err := testBuilder.GetInterfaceIPv4Addresses("eno1")
+	assert.Nil(t, err)
+	assert.Equal(t, []IPAddress{{IP: "10.0.0.10", PrefixLength: 24}}, ipv4Addresses)
+
+	ipv6Addresses, err := testBuilder.GetInterfaceIPv6Addresses("eno1")
+	assert.Nil(t, err)
+	assert.Equal(t, []IPAddress{{IP: "2001:db8::10", PrefixLength: 64}}, ipv6Addresses)
+
+	ipv6Addresses, err = testBuilder.GetInterfaceIPv6Addresses("vrf100")
+	assert.Nil(t, err)
+	assert.Nil(t, ipv6Addresses)
+
+	mtu, err := testBuilder.GetInterfaceMTU("eno1")
+	assert.Nil(t, err)
+	assert.Equal(t, 9000, mtu)
+
+	_, err = testBuilder.GetInterfaceMTU("eno9")
+	assert.Equal(t, fmt.Errorf("failed to find interface eno9"), err)
+}
+
+func TestStateGetRoutes(t *testing.T) {
+	testBuilder := buildValidStateTestBuilder(t)
+
+	routes, err := testBuilder.GetRoutes()
+	assert.Nil(t, err)
+	assert.Len(t, routes, 4)
+
+	interfaceName, err := testBuilder.GetDefaultRouteInterface(false)
+	assert.Nil(t, err)
+	assert.Equal(t, "eno1", interfaceName)
+
+	interfaceName, err = testBuilder.GetDefaultRouteInterface(true)
+	assert.Nil(t, err)
+	assert.Equal(t, "br-ex", interfaceName)
+
+	testBuilder = buildStateTestBuilder(t, "interfaces: []\n")
+
+	_, err = testBuilder.GetDefaultRouteInterface(false)
+	assert.Equal(t, fmt.Errorf("failed to find default route 0.0.0.0/0 in NodeNetworkState %s",
+		defaultNodeNetworkStateName), err)
+}
+
+func TestStateGetDNSResolver(t *testing.T) {
+	dnsConfig, err := buildValidStateTestBuilder(t).GetDNSResolver()
+	assert.Nil(t, err)
+	assert.Equal(t, &DNSConfig{Search: []string{"example.com"}, Server: []string{"10.0.0.53", "2001:db8::53"}},
+		dnsConfig)
+
+	_, err = buildStateTestBuilder(t, "interfaces: []\n").GetDNSResolver()
+	assert.Equal(t, fmt.Errorf("failed to find running dns resolver in NodeNetworkState %s",
+		defaultNodeNetworkStateName), err)
+}
+
+func TestStateGetBondMembersAndBridgePorts(t *testing.T) {
+	testBuilder := buildValidStateTestBuilder(t)
+
+	members, err := testBuilder.GetBondMembers("bond0")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"eno5", "eno6"}, members)
+
+	_, err = testBuilder.GetBondMembers("eno1")
+	assert.Equal(t, fmt.Errorf("failed to find interface eno1 or it is not a bond type"), err)
+
+	ports, err := testBuilder.GetBridgePorts("br1")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"eno3"}, ports)
+
+	ports, err = testBuilder.GetBridgePorts("br-ex")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"eno2", "br-ex"}, ports)
+
+	_, err = testBuilder.GetBridgePorts("eno1")
+	assert.Equal(t, fmt.Errorf("failed to find bridge eno1"), err)
+}
+
+func buildValidStateTestBuilder(t *testing.T) *StateBuilder {
+	t.Helper()
+
+	return buildStateTestBuilder(t, defaultNodeNetworkState)
+}
+
+func buildStateTestBuilder(t *testing.T, currentState string) *StateBuilder {
+	t.Helper()
+
+	testSettings := clients.GetTestClients(clients.TestClientParams{K8sMockObjects: []runtime.Object{
+		&nmstateV1alpha1.NodeNetworkState{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: defaultNodeNetworkStateName,
+			},
+			Status: nmstateShared.NodeNetworkStateStatus{
+				CurrentState: nmstateShared.NewState(currentState),
+			},
+		},
+	}})
+
+	testBuilder, err := PullNodeNetworkState(testSettings, defaultNodeNetworkStateName)
+	assert.Nil(t, err)
+
+	return testBuilder
+}
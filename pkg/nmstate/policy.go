@@ -3,6 +3,8 @@ package nmstate
 import (
 	"context"
 	"fmt"
+	"net"
+	"strings"
 	"time"
 
 	"gopkg.in/yaml.v2"
@@ -18,7 +20,9 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/utils/ptr"
 	"k8s.io/utils/strings/slices"
 	goclient "sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -26,6 +30,15 @@ import (
 var (
 	// allowedBondModes represents all allowed modes for Bond interface.
 	allowedBondModes = []string{"balance-rr", "active-backup", "balance-xor", "broadcast", "802.3ad"}
+	// allowedMacVlanModes represents all allowed modes for MAC-VLAN interface.
+	allowedMacVlanModes = []string{"vepa", "bridge", "private", "passthru", "source"}
+)
+
+const (
+	// minimumMTU is the lowest MTU accepted for an interface, the minimum required by IPv4.
+	minimumMTU = 68
+	// maximumVxlanID is the highest VXLAN network identifier, limited to 24 bits.
+	maximumVxlanID = 16777215
 )
 
 // AdditionalOptions additional options for pod object.
@@ -327,6 +340,549 @@ func (builder *PolicyBuilder) WithAbsentInterface(interfaceName string) *PolicyB
 	return builder.withInterface(newInterface)
 }
 
+// WithEthernetInterface adds an ethernet interface in up state to the NodeNetworkConfigurationPolicy. It can then
+// be further configured using the WithInterface* methods.
+func (builder *PolicyBuilder) WithEthernetInterface(interfaceName string) *PolicyBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Creating NodeNetworkConfigurationPolicy %s with ethernet interface %s",
+		builder.Definition.Name, interfaceName)
+
+	if interfaceName == "" {
+		glog.V(100).Infof("The interfaceName can not be empty string")
+
+		builder.errorMsg = "nodenetworkconfigurationpolicy 'interfaceName' cannot be empty"
+
+		return builder
+	}
+
+	return builder.withInterface(NetworkInterface{
+		Name:  interfaceName,
+		Type:  "ethernet",
+		State: "up",
+	})
+}
+
+// WithLinuxBridgeInterface adds linux-bridge interface configuration with the given ports and STP disabled to the
+// NodeNetworkConfigurationPolicy.
+func (builder *PolicyBuilder) WithLinuxBridgeInterface(bridgeName string, ports ...string) *PolicyBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Creating NodeNetworkConfigurationPolicy %s with linux-bridge interface %s and ports %v",
+		builder.Definition.Name, bridgeName, ports)
+
+	return builder.withBridgeInterface("linux-bridge", bridgeName, ports)
+}
+
+// WithOVSBridgeInterface adds ovs-bridge interface configuration with the given ports and STP disabled to the
+// NodeNetworkConfigurationPolicy. An ovs-interface port is needed to assign IP addresses to the bridge.
+func (builder *PolicyBuilder) WithOVSBridgeInterface(bridgeName string, ports ...string) *PolicyBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Creating NodeNetworkConfigurationPolicy %s with ovs-bridge interface %s and ports %v",
+		builder.Definition.Name, bridgeName, ports)
+
+	return builder.withBridgeInterface("ovs-bridge", bridgeName, ports)
+}
+
+// WithOVSInternalInterface adds an ovs-interface in up state to the NodeNetworkConfigurationPolicy. It must also be
+// listed as a port of an ovs-bridge.
+func (builder *PolicyBuilder) WithOVSInternalInterface(interfaceName string) *PolicyBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Creating NodeNetworkConfigurationPolicy %s with ovs-interface %s",
+		builder.Definition.Name, interfaceName)
+
+	if interfaceName == "" {
+		glog.V(100).Infof("The interfaceName can not be empty string")
+
+		builder.errorMsg = "nodenetworkconfigurationpolicy 'interfaceName' cannot be empty"
+
+		return builder
+	}
+
+	return builder.withInterface(NetworkInterface{
+		Name:  interfaceName,
+		Type:  "ovs-interface",
+		State: "up",
+	})
+}
+
+// WithVRFInterface adds VRF interface configuration bound to the given route table to the
+// NodeNetworkConfigurationPolicy.
+func (builder *PolicyBuilder) WithVRFInterface(vrfName string, routeTableID uint32, ports ...string) *PolicyBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Creating NodeNetworkConfigurationPolicy %s with VRF interface %s, route table %d and ports %v",
+		builder.Definition.Name, vrfName, routeTableID, ports)
+
+	if vrfName == "" {
+		glog.V(100).Infof("The vrfName can not be empty string")
+
+		builder.errorMsg = "nodenetworkconfigurationpolicy 'vrfName' cannot be empty"
+	}
+
+	if routeTableID == 0 {
+		glog.V(100).Infof("The routeTableID can not be zero")
+
+		builder.errorMsg = "nodenetworkconfigurationpolicy 'routeTableID' cannot be zero"
+	}
+
+	if builder.errorMsg != "" {
+		return builder
+	}
+
+	return builder.withInterface(NetworkInterface{
+		Name:  vrfName,
+		Type:  "vrf",
+		State: "up",
+		Vrf: &Vrf{
+			Port:         ports,
+			RouteTableID: int(routeTableID),
+		},
+	})
+}
+
+// WithMacVlanInterface adds MAC-VLAN interface configuration on top of the base interface to the
+// NodeNetworkConfigurationPolicy.
+func (builder *PolicyBuilder) WithMacVlanInterface(interfaceName, baseInterface, mode string) *PolicyBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Creating NodeNetworkConfigurationPolicy %s with MAC-VLAN interface %s, base interface %s"+
+		" and mode %s", builder.Definition.Name, interfaceName, baseInterface, mode)
+
+	if interfaceName == "" {
+		glog.V(100).Infof("The interfaceName can not be empty string")
+
+		builder.errorMsg = "nodenetworkconfigurationpolicy 'interfaceName' cannot be empty"
+	}
+
+	if baseInterface == "" {
+		glog.V(100).Infof("The baseInterface can not be empty string")
+
+		builder.errorMsg = "nodenetworkconfigurationpolicy 'baseInterface' cannot be empty"
+	}
+
+	if !slices.Contains(allowedMacVlanModes, mode) {
+		glog.V(100).Infof("error to add MAC-VLAN mode %s, allowed modes are %v", mode, allowedMacVlanModes)
+
+		builder.errorMsg = "invalid MAC-VLAN mode parameter"
+	}
+
+	if builder.errorMsg != "" {
+		return builder
+	}
+
+	return builder.withInterface(NetworkInterface{
+		Name:  interfaceName,
+		Type:  "mac-vlan",
+		State: "up",
+		MacVlan: &MacVlan{
+			BaseIface: baseInterface,
+			Mode:      mode,
+		},
+	})
+}
+
+// WithVxlanInterface adds VXLAN interface configuration on top of the base interface to the
+// NodeNetworkConfigurationPolicy. The remote address is optional and destinationPort uses the kernel default when
+// zero.
+func (builder *PolicyBuilder) WithVxlanInterface(
+	interfaceName, baseInterface string, vxlanID uint32, remote string, destinationPort uint16) *PolicyBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Creating NodeNetworkConfigurationPolicy %s with VXLAN interface %s, base interface %s,"+
+		" id %d, remote %s and destination port %d",
+		builder.Definition.Name, interfaceName, baseInterface, vxlanID, remote, destinationPort)
+
+	if interfaceName == "" {
+		glog.V(100).Infof("The interfaceName can not be empty string")
+
+		builder.errorMsg = "nodenetworkconfigurationpolicy 'interfaceName' cannot be empty"
+	}
+
+	if baseInterface == "" {
+		glog.V(100).Infof("The baseInterface can not be empty string")
+
+		builder.errorMsg = "nodenetworkconfigurationpolicy 'baseInterface' cannot be empty"
+	}
+
+	if vxlanID > maximumVxlanID {
+		builder.errorMsg = fmt.Sprintf("invalid vxlanID, allowed vxlanID values are between 0-%d", maximumVxlanID)
+	}
+
+	if remote != "" && net.ParseIP(remote) == nil {
+		glog.V(100).Infof("The remote %s is not a valid IP address", remote)
+
+		builder.errorMsg = fmt.Sprintf("nodenetworkconfigurationpolicy 'remote' %s is not a valid IP address", remote)
+	}
+
+	if builder.errorMsg != "" {
+		return builder
+	}
+
+	vxlan := &Vxlan{
+		BaseIface: baseInterface,
+		ID:        int(vxlanID),
+		Remote:    remote,
+	}
+
+	if destinationPort != 0 {
+		vxlan.DestinationPort = ptr.To(int(destinationPort))
+	}
+
+	return builder.withInterface(NetworkInterface{
+		Name:  interfaceName,
+		Type:  "vxlan",
+		State: "up",
+		Vxlan: vxlan,
+	})
+}
+
+// WithInterfaceIPv4Address adds a static IPv4 address to an interface already present in the
+// NodeNetworkConfigurationPolicy, enabling IPv4 and disabling DHCP on it.
+func (builder *PolicyBuilder) WithInterfaceIPv4Address(
+	interfaceName, address string, prefixLength uint8) *PolicyBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Adding IPv4 address %s/%d to interface %s of NodeNetworkConfigurationPolicy %s",
+		address, prefixLength, interfaceName, builder.Definition.Name)
+
+	parsedIP := net.ParseIP(address)
+	if parsedIP == nil || parsedIP.To4() == nil {
+		glog.V(100).Infof("The address %s is not a valid IPv4 address", address)
+
+		builder.errorMsg = fmt.Sprintf("nodenetworkconfigurationpolicy 'address' %s is not a valid IPv4 address", address)
+
+		return builder
+	}
+
+	if prefixLength > 32 {
+		builder.errorMsg = "invalid prefixLength, allowed IPv4 prefixLength values are between 0-32"
+
+		return builder
+	}
+
+	return builder.withInterfaceUpdate(interfaceName, func(networkInterface *NetworkInterface) {
+		if networkInterface.IPv4 == nil {
+			networkInterface.IPv4 = &InterfaceIP{}
+		}
+
+		networkInterface.IPv4.Enabled = true
+		networkInterface.IPv4.Dhcp = ptr.To(false)
+		networkInterface.IPv4.Address = append(
+			networkInterface.IPv4.Address, IPAddress{IP: address, PrefixLength: int(prefixLength)})
+	})
+}
+
+// WithInterfaceIPv6Address adds a static IPv6 address to an interface already present in the
+// NodeNetworkConfigurationPolicy, enabling IPv6 and disabling DHCPv6 and autoconf on it.
+func (builder *PolicyBuilder) WithInterfaceIPv6Address(
+	interfaceName, address string, prefixLength uint8) *PolicyBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Adding IPv6 address %s/%d to interface %s of NodeNetworkConfigurationPolicy %s",
+		address, prefixLength, interfaceName, builder.Definition.Name)
+
+	parsedIP := net.ParseIP(address)
+	if parsedIP == nil || parsedIP.To4() != nil {
+		glog.V(100).Infof("The address %s is not a valid IPv6 address", address)
+
+		builder.errorMsg = fmt.Sprintf("nodenetworkconfigurationpolicy 'address' %s is not a valid IPv6 address", address)
+
+		return builder
+	}
+
+	if prefixLength > 128 {
+		builder.errorMsg = "invalid prefixLength, allowed IPv6 prefixLength values are between 0-128"
+
+		return builder
+	}
+
+	return builder.withInterfaceUpdate(interfaceName, func(networkInterface *NetworkInterface) {
+		if networkInterface.IPv6 == nil {
+			networkInterface.IPv6 = &InterfaceIP{}
+		}
+
+		networkInterface.IPv6.Enabled = true
+		networkInterface.IPv6.Dhcp = ptr.To(false)
+		networkInterface.IPv6.Autoconf = ptr.To(false)
+		networkInterface.IPv6.Address = append(
+			networkInterface.IPv6.Address, IPAddress{IP: address, PrefixLength: int(prefixLength)})
+	})
+}
+
+// WithInterfaceIPv4DHCP enables IPv4 with DHCP on an interface already present in the NodeNetworkConfigurationPolicy.
+// Any static IPv4 address configured on the interface is removed.
+func (builder *PolicyBuilder) WithInterfaceIPv4DHCP(interfaceName string) *PolicyBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Enabling IPv4 DHCP on interface %s of NodeNetworkConfigurationPolicy %s",
+		interfaceName, builder.Definition.Name)
+
+	return builder.withInterfaceUpdate(interfaceName, func(networkInterface *NetworkInterface) {
+		networkInterface.IPv4 = &InterfaceIP{Enabled: true, Dhcp: ptr.To(true)}
+	})
+}
+
+// WithInterfaceIPv6DHCP enables IPv6 with DHCPv6 on an interface already present in the
+// NodeNetworkConfigurationPolicy. When autoconf is true, SLAAC addresses from router advertisements are also
+// configured. Any static IPv6 address configured on the interface is removed.
+func (builder *PolicyBuilder) WithInterfaceIPv6DHCP(interfaceName string, autoconf bool) *PolicyBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Enabling IPv6 DHCP (autoconf: %t) on interface %s of NodeNetworkConfigurationPolicy %s",
+		autoconf, interfaceName, builder.Definition.Name)
+
+	return builder.withInterfaceUpdate(interfaceName, func(networkInterface *NetworkInterface) {
+		networkInterface.IPv6 = &InterfaceIP{Enabled: true, Dhcp: ptr.To(true), Autoconf: ptr.To(autoconf)}
+	})
+}
+
+// WithInterfaceIPDisabled disables both IPv4 and IPv6 on an interface already present in the
+// NodeNetworkConfigurationPolicy.
+func (builder *PolicyBuilder) WithInterfaceIPDisabled(interfaceName string) *PolicyBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Disabling IP on interface %s of NodeNetworkConfigurationPolicy %s",
+		interfaceName, builder.Definition.Name)
+
+	return builder.withInterfaceUpdate(interfaceName, func(networkInterface *NetworkInterface) {
+		networkInterface.IPv4 = &InterfaceIP{Enabled: false}
+		networkInterface.IPv6 = &InterfaceIP{Enabled: false}
+	})
+}
+
+// WithInterfaceMTU sets the MTU of an interface already present in the NodeNetworkConfigurationPolicy.
+func (builder *PolicyBuilder) WithInterfaceMTU(interfaceName string, mtu uint16) *PolicyBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Setting MTU %d on interface %s of NodeNetworkConfigurationPolicy %s",
+		mtu, interfaceName, builder.Definition.Name)
+
+	if mtu < minimumMTU {
+		builder.errorMsg = fmt.Sprintf("invalid mtu, minimum allowed mtu value is %d", minimumMTU)
+
+		return builder
+	}
+
+	return builder.withInterfaceUpdate(interfaceName, func(networkInterface *NetworkInterface) {
+		networkInterface.MTU = int(mtu)
+	})
+}
+
+// WithInterfaceEthtoolFeature enables or disables an ethtool feature, e.g. rx-checksum, on an interface already
+// present in the NodeNetworkConfigurationPolicy.
+func (builder *PolicyBuilder) WithInterfaceEthtoolFeature(interfaceName, feature string, enabled bool) *PolicyBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Setting ethtool feature %s to %t on interface %s of NodeNetworkConfigurationPolicy %s",
+		feature, enabled, interfaceName, builder.Definition.Name)
+
+	if feature == "" {
+		glog.V(100).Infof("The ethtool feature can not be empty string")
+
+		builder.errorMsg = "nodenetworkconfigurationpolicy ethtool 'feature' cannot be empty"
+
+		return builder
+	}
+
+	return builder.withInterfaceUpdate(interfaceName, func(networkInterface *NetworkInterface) {
+		if networkInterface.Ethtool == nil {
+			networkInterface.Ethtool = &Ethtool{}
+		}
+
+		if networkInterface.Ethtool.Feature == nil {
+			networkInterface.Ethtool.Feature = make(map[string]bool)
+		}
+
+		networkInterface.Ethtool.Feature[feature] = enabled
+	})
+}
+
+// WithInterfaceEthtoolRing sets the ethtool rx and tx ring sizes of an interface already present in the
+// NodeNetworkConfigurationPolicy.
+func (builder *PolicyBuilder) WithInterfaceEthtoolRing(interfaceName string, rx, tx uint32) *PolicyBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Setting ethtool ring rx %d and tx %d on interface %s of NodeNetworkConfigurationPolicy %s",
+		rx, tx, interfaceName, builder.Definition.Name)
+
+	if rx == 0 || tx == 0 {
+		builder.errorMsg = "nodenetworkconfigurationpolicy ethtool ring 'rx' and 'tx' cannot be zero"
+
+		return builder
+	}
+
+	return builder.withInterfaceUpdate(interfaceName, func(networkInterface *NetworkInterface) {
+		if networkInterface.Ethtool == nil {
+			networkInterface.Ethtool = &Ethtool{}
+		}
+
+		networkInterface.Ethtool.Ring = &EthtoolRing{Rx: ptr.To(int(rx)), Tx: ptr.To(int(tx))}
+	})
+}
+
+// WithRoute adds a route to the NodeNetworkConfigurationPolicy. The destination must be in CIDR notation and the
+// next hop interface must be set. Routes with state absent remove matching routes instead.
+func (builder *PolicyBuilder) WithRoute(route Route) *PolicyBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Creating NodeNetworkConfigurationPolicy %s with route %v", builder.Definition.Name, route)
+
+	if route.State != "absent" {
+		if _, _, err := net.ParseCIDR(route.Destination); err != nil {
+			glog.V(100).Infof("The route destination %s is not a valid CIDR", route.Destination)
+
+			builder.errorMsg = fmt.Sprintf("nodenetworkconfigurationpolicy route 'destination' %s is not a valid CIDR",
+				route.Destination)
+
+			return builder
+		}
+
+		if route.NextHopInterface == "" {
+			glog.V(100).Infof("The route next hop interface can not be empty string")
+
+			builder.errorMsg = "nodenetworkconfigurationpolicy route 'nextHopInterface' cannot be empty"
+
+			return builder
+		}
+	}
+
+	if route.NextHopAddress != "" && net.ParseIP(route.NextHopAddress) == nil {
+		glog.V(100).Infof("The route next hop address %s is not a valid IP address", route.NextHopAddress)
+
+		builder.errorMsg = fmt.Sprintf("nodenetworkconfigurationpolicy route 'nextHopAddress' %s is not a valid IP address",
+			route.NextHopAddress)
+
+		return builder
+	}
+
+	return builder.updateDesiredState(func(desiredState *DesiredState) error {
+		if desiredState.Routes == nil {
+			desiredState.Routes = &Routes{}
+		}
+
+		desiredState.Routes.Config = append(desiredState.Routes.Config, route)
+
+		return nil
+	})
+}
+
+// WithRouteRule adds a route rule to the NodeNetworkConfigurationPolicy. At least one of IPFrom and IPTo must be
+// set, both in CIDR notation.
+func (builder *PolicyBuilder) WithRouteRule(rule RouteRule) *PolicyBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Creating NodeNetworkConfigurationPolicy %s with route rule %v", builder.Definition.Name, rule)
+
+	if rule.IPFrom == "" && rule.IPTo == "" {
+		glog.V(100).Infof("The route rule has neither ipFrom nor ipTo")
+
+		builder.errorMsg = "nodenetworkconfigurationpolicy route rule must have 'ipFrom' or 'ipTo'"
+
+		return builder
+	}
+
+	for _, cidr := range []string{rule.IPFrom, rule.IPTo} {
+		if cidr == "" {
+			continue
+		}
+
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			glog.V(100).Infof("The route rule address %s is not a valid CIDR", cidr)
+
+			builder.errorMsg = fmt.Sprintf("nodenetworkconfigurationpolicy route rule address %s is not a valid CIDR", cidr)
+
+			return builder
+		}
+	}
+
+	return builder.updateDesiredState(func(desiredState *DesiredState) error {
+		if desiredState.RouteRules == nil {
+			desiredState.RouteRules = &RouteRules{}
+		}
+
+		desiredState.RouteRules.Config = append(desiredState.RouteRules.Config, rule)
+
+		return nil
+	})
+}
+
+// WithDNSResolver sets the static DNS resolver configuration of the NodeNetworkConfigurationPolicy, replacing any
+// previously set one. Servers must be IP addresses.
+func (builder *PolicyBuilder) WithDNSResolver(servers, search []string) *PolicyBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Creating NodeNetworkConfigurationPolicy %s with DNS servers %v and search %v",
+		builder.Definition.Name, servers, search)
+
+	if len(servers) == 0 && len(search) == 0 {
+		glog.V(100).Infof("The DNS servers and search can not be both empty")
+
+		builder.errorMsg = "nodenetworkconfigurationpolicy dns resolver must have 'servers' or 'search'"
+
+		return builder
+	}
+
+	for _, server := range servers {
+		if net.ParseIP(server) == nil {
+			glog.V(100).Infof("The DNS server %s is not a valid IP address", server)
+
+			builder.errorMsg = fmt.Sprintf("nodenetworkconfigurationpolicy dns server %s is not a valid IP address", server)
+
+			return builder
+		}
+	}
+
+	return builder.updateDesiredState(func(desiredState *DesiredState) error {
+		desiredState.DNSResolver = &DNSResolver{
+			Config: &DNSConfig{
+				Search: search,
+				Server: servers,
+			},
+		}
+
+		return nil
+	})
+}
+
 // WithOptions creates pod with generic mutation options.
 func (builder *PolicyBuilder) WithOptions(options ...AdditionalOptions) *PolicyBuilder {
 	if valid, _ := builder.validate(); !valid {
@@ -387,6 +943,113 @@ func (builder *PolicyBuilder) WaitUntilCondition(condition nmstateShared.Conditi
 		})
 }
 
+// ListEnactments returns the NodeNetworkConfigurationEnactments created by nmstate for the
+// NodeNetworkConfigurationPolicy, one for each node matched by its nodeSelector.
+func (builder *PolicyBuilder) ListEnactments() ([]*EnactmentBuilder, error) {
+	if valid, err := builder.validate(); !valid {
+		return nil, err
+	}
+
+	glog.V(100).Infof("Listing NodeNetworkConfigurationEnactments of NodeNetworkConfigurationPolicy %s",
+		builder.Definition.Name)
+
+	return ListEnactments(builder.apiClient, goclient.ListOptions{
+		LabelSelector: labels.SelectorFromSet(labels.Set{nmstateShared.EnactmentPolicyLabel: builder.Definition.Name}),
+	})
+}
+
+// WaitUntilEnactmentsCondition waits for the duration of the defined timeout or until every
+// NodeNetworkConfigurationEnactment of the NodeNetworkConfigurationPolicy gets to a specific condition. Enactments
+// still reporting the result of a previous policy generation are not considered. When waiting for the Available
+// condition, an error with the nmstate output of each failing node is returned as soon as any enactment is failing.
+func (builder *PolicyBuilder) WaitUntilEnactmentsCondition(
+	condition nmstateShared.ConditionType, timeout time.Duration) error {
+	if valid, err := builder.validate(); !valid {
+		return err
+	}
+
+	glog.V(100).Infof("Waiting for the defined period until NodeNetworkConfigurationEnactments of "+
+		"NodeNetworkConfigurationPolicy %s have condition %v", builder.Definition.Name, condition)
+
+	if !builder.Exists() {
+		return fmt.Errorf("cannot wait for NodeNetworkConfigurationEnactments condition because " +
+			"NodeNetworkConfigurationPolicy does not exist")
+	}
+
+	var failureErr error
+
+	err := wait.PollUntilContextTimeout(
+		context.TODO(), retryInterval, timeout, true, func(ctx context.Context) (bool, error) {
+			policy, err := builder.Get()
+			if err != nil {
+				return false, nil
+			}
+
+			builder.Object = policy
+
+			enactments, err := builder.ListEnactments()
+			if err != nil || len(enactments) == 0 {
+				return false, nil
+			}
+
+			if condition == nmstateShared.NodeNetworkConfigurationEnactmentConditionAvailable {
+				if failureErr = getEnactmentsFailure(enactments, policy.Generation); failureErr != nil {
+					return false, failureErr
+				}
+			}
+
+			for _, enactment := range enactments {
+				if enactment.Object.Status.PolicyGeneration < policy.Generation || !enactment.IsInCondition(condition) {
+					return false, nil
+				}
+			}
+
+			return true, nil
+		})
+
+	if failureErr != nil {
+		return failureErr
+	}
+
+	return err
+}
+
+// GetFailingEnactmentsMessages returns the nmstate error output of each failing NodeNetworkConfigurationEnactment of
+// the NodeNetworkConfigurationPolicy, keyed by node name.
+func (builder *PolicyBuilder) GetFailingEnactmentsMessages() (map[string]string, error) {
+	if valid, err := builder.validate(); !valid {
+		return nil, err
+	}
+
+	glog.V(100).Infof("Collecting failing NodeNetworkConfigurationEnactments of NodeNetworkConfigurationPolicy %s",
+		builder.Definition.Name)
+
+	enactments, err := builder.ListEnactments()
+	if err != nil {
+		return nil, err
+	}
+
+	failureMessages := make(map[string]string)
+
+	for _, enactment := range enactments {
+		if !enactment.IsInCondition(nmstateShared.NodeNetworkConfigurationEnactmentConditionFailing) {
+			continue
+		}
+
+		nodeName, err := enactment.GetNodeName()
+		if err != nil {
+			return nil, err
+		}
+
+		failureMessages[nodeName], err = enactment.GetFailureMessage()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return failureMessages, nil
+}
+
 // validate will check that the builder and builder definition are properly initialized before
 // accessing any member fields.
 func (builder *PolicyBuilder) validate() (bool, error) {
@@ -430,6 +1093,70 @@ func (builder *PolicyBuilder) withInterface(networkInterface NetworkInterface) *
 	glog.V(100).Infof("Creating NodeNetworkConfigurationPolicy %s with network interface %s",
 		builder.Definition.Name, networkInterface.Name)
 
+	return builder.updateDesiredState(func(desiredState *DesiredState) error {
+		desiredState.Interfaces = append(desiredState.Interfaces, networkInterface)
+
+		return nil
+	})
+}
+
+// withBridgeInterface adds a bridge interface of the given type with STP disabled to the
+// NodeNetworkConfigurationPolicy.
+func (builder *PolicyBuilder) withBridgeInterface(bridgeType, bridgeName string, ports []string) *PolicyBuilder {
+	if bridgeName == "" {
+		glog.V(100).Infof("The bridgeName can not be empty string")
+
+		builder.errorMsg = "nodenetworkconfigurationpolicy 'bridgeName' cannot be empty"
+
+		return builder
+	}
+
+	var bridgePorts []BridgePort
+	for _, port := range ports {
+		bridgePorts = append(bridgePorts, BridgePort{Name: port})
+	}
+
+	return builder.withInterface(NetworkInterface{
+		Name:  bridgeName,
+		Type:  bridgeType,
+		State: "up",
+		Bridge: Bridge{
+			Options: &BridgeOptions{Stp: &BridgeStp{Enabled: ptr.To(false)}},
+			Ports:   bridgePorts,
+		},
+	})
+}
+
+// withInterfaceUpdate applies mutate to the interface with the given name in the NodeNetworkConfigurationPolicy.
+// The interface must have been added before.
+func (builder *PolicyBuilder) withInterfaceUpdate(
+	interfaceName string, mutate func(networkInterface *NetworkInterface)) *PolicyBuilder {
+	if interfaceName == "" {
+		glog.V(100).Infof("The interfaceName can not be empty string")
+
+		builder.errorMsg = "nodenetworkconfigurationpolicy 'interfaceName' cannot be empty"
+
+		return builder
+	}
+
+	return builder.updateDesiredState(func(desiredState *DesiredState) error {
+		for index := range desiredState.Interfaces {
+			if desiredState.Interfaces[index].Name == interfaceName {
+				mutate(&desiredState.Interfaces[index])
+
+				return nil
+			}
+		}
+
+		glog.V(100).Infof("The interface %s is not present in the DesiredState", interfaceName)
+
+		return fmt.Errorf("interface %s is not present in nodenetworkconfigurationpolicy desired state", interfaceName)
+	})
+}
+
+// updateDesiredState unmarshals the desired state of the NodeNetworkConfigurationPolicy, applies mutate to it and
+// marshals it back into the definition. Errors are stored in the builder errorMsg.
+func (builder *PolicyBuilder) updateDesiredState(mutate func(desiredState *DesiredState) error) *PolicyBuilder {
 	var CurrentState DesiredState
 
 	err := yaml.Unmarshal(builder.Definition.Spec.DesiredState.Raw, &CurrentState)
@@ -442,7 +1169,13 @@ func (builder *PolicyBuilder) withInterface(networkInterface NetworkInterface) *
 		return builder
 	}
 
-	CurrentState.Interfaces = append(CurrentState.Interfaces, networkInterface)
+	err = mutate(&CurrentState)
+
+	if err != nil {
+		builder.errorMsg = err.Error()
+
+		return builder
+	}
 
 	desiredStateYaml, err := yaml.Marshal(CurrentState)
 
@@ -458,3 +1191,30 @@ func (builder *PolicyBuilder) withInterface(networkInterface NetworkInterface) *
 
 	return builder
 }
+
+// getEnactmentsFailure returns an error holding the node names and nmstate output of the enactments failing for the
+// given policy generation, or nil if none of them is failing.
+func getEnactmentsFailure(enactments []*EnactmentBuilder, policyGeneration int64) error {
+	var failures []string
+
+	for _, enactment := range enactments {
+		if enactment.Object.Status.PolicyGeneration < policyGeneration ||
+			!enactment.IsInCondition(nmstateShared.NodeNetworkConfigurationEnactmentConditionFailing) {
+			continue
+		}
+
+		nodeName, err := enactment.GetNodeName()
+		if err != nil {
+			nodeName = enactment.Object.Name
+		}
+
+		failureMessage, _ := enactment.GetFailureMessage()
+		failures = append(failures, fmt.Sprintf("%s: %s", nodeName, failureMessage))
+	}
+
+	if len(failures) == 0 {
+		return nil
+	}
+
+	return fmt.Errorf("nodenetworkconfigurationenactments are failing: %s", strings.Join(failures, "; "))
+}
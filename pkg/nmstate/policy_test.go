@@ -0,0 +1,530 @@
+package nmstate
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	nmstateShared "github.com/nmstate/kubernetes-nmstate/api/shared"
+	nmstateV1 "github.com/nmstate/kubernetes-nmstate/api/v1"
+	nmstateV1alpha1 "github.com/nmstate/kubernetes-nmstate/api/v1alpha1"
+	"github.com/openshift-kni/eco-goinfra/pkg/clients"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+var defaultPolicyNodeSelector = map[string]string{"node-role.kubernetes.io/worker": ""}
+
+func TestPolicyWithInterfaceIPv4Address(t *testing.T) {
+	testCases := []struct {
+		interfaceName string
+		address       string
+		prefixLength  uint8
+		expectedError string
+	}{
+		{
+			interfaceName: "eno1",
+			address:       "10.0.0.10",
+			prefixLength:  24,
+			expectedError: "",
+		},
+		{
+			interfaceName: "eno1",
+			address:       "2001:db8::10",
+			prefixLength:  24,
+			expectedError: "nodenetworkconfigurationpolicy 'address' 2001:db8::10 is not a valid IPv4 address",
+		},
+		{
+			interfaceName: "eno1",
+			address:       "10.0.0.10",
+			prefixLength:  33,
+			expectedError: "invalid prefixLength, allowed IPv4 prefixLength values are between 0-32",
+		},
+		{
+			interfaceName: "eno2",
+			address:       "10.0.0.10",
+			prefixLength:  24,
+			expectedError: "interface eno2 is not present in nodenetworkconfigurationpolicy desired state",
+		},
+		{
+			interfaceName: "",
+			address:       "10.0.0.10",
+			prefixLength:  24,
+			expectedError: "nodenetworkconfigurationpolicy 'interfaceName' cannot be empty",
+		},
+	}
+
+	for _, testCase := range testCases {
+		testBuilder := buildValidPolicyTestBuilder().WithEthernetInterface("eno1").
+			WithInterfaceIPv4Address(testCase.interfaceName, testCase.address, testCase.prefixLength)
+		assert.Equal(t, testCase.expectedError, testBuilder.errorMsg)
+
+		if testCase.expectedError == "" {
+			state := getPolicyTestDesiredState(t, testBuilder)
+			assert.True(t, state.Interfaces[0].IPv4.Enabled)
+			assert.False(t, *state.Interfaces[0].IPv4.Dhcp)
+			assert.Equal(t, []IPAddress{{IP: testCase.address, PrefixLength: int(testCase.prefixLength)}},
+				state.Interfaces[0].IPv4.Address)
+		}
+	}
+}
+
+func TestPolicyWithInterfaceIPv6Address(t *testing.T) {
+	testCases := []struct {
+		address       string
+		prefixLength  uint8
+		expectedError string
+	}{
+		{
+			address:       "2001:db8::10",
+			prefixLength:  64,
+			expectedError: "",
+		},
+		{
+			address:       "10.0.0.10",
+			prefixLength:  64,
+			expectedError: "nodenetworkconfigurationpolicy 'address' 10.0.0.10 is not a valid IPv6 address",
+		},
+		{
+			address:       "2001:db8::10",
+			prefixLength:  129,
+			expectedError: "invalid prefixLength, allowed IPv6 prefixLength values are between 0-128",
+		},
+	}
+
+	for _, testCase := range testCases {
+		testBuilder := buildValidPolicyTestBuilder().WithEthernetInterface("eno1").
+			WithInterfaceIPv6Address("eno1", testCase.address, testCase.prefixLength)
+		assert.Equal(t, testCase.expectedError, testBuilder.errorMsg)
+
+		if testCase.expectedError == "" {
+			state := getPolicyTestDesiredState(t, testBuilder)
+			assert.True(t, state.Interfaces[0].IPv6.Enabled)
+			assert.False(t, *state.Interfaces[0].IPv6.Autoconf)
+			assert.Equal(t, []IPAddress{{IP: testCase.address, PrefixLength: int(testCase.prefixLength)}},
+				state.Interfaces[0].IPv6.Address)
+		}
+	}
+}
+
+func TestPolicyWithInterfaceDHCP(t *testing.T) {
+	testBuilder := buildValidPolicyTestBuilder().WithEthernetInterface("eno1").
+		WithInterfaceIPv4Address("eno1", "10.0.0.10", 24).
+		WithInterfaceIPv4DHCP("eno1").
+		WithInterfaceIPv6DHCP("eno1", true)
+	assert.Empty(t, testBuilder.errorMsg)
+
+	state := getPolicyTestDesiredState(t, testBuilder)
+	assert.Empty(t, state.Interfaces[0].IPv4.Address)
+	assert.True(t, *state.Interfaces[0].IPv4.Dhcp)
+	assert.True(t, *state.Interfaces[0].IPv6.Dhcp)
+	assert.True(t, *state.Interfaces[0].IPv6.Autoconf)
+
+	testBuilder = testBuilder.WithInterfaceIPDisabled("eno1")
+	assert.Empty(t, testBuilder.errorMsg)
+
+	state = getPolicyTestDesiredState(t, testBuilder)
+	assert.False(t, state.Interfaces[0].IPv4.Enabled)
+	assert.False(t, state.Interfaces[0].IPv6.Enabled)
+}
+
+func TestPolicyWithInterfaceMTU(t *testing.T) {
+	testCases := []struct {
+		mtu           uint16
+		expectedError string
+	}{
+		{
+			mtu:           9000,
+			expectedError: "",
+		},
+		{
+			mtu:           67,
+			expectedError: "invalid mtu, minimum allowed mtu value is 68",
+		},
+	}
+
+	for _, testCase := range testCases {
+		testBuilder := buildValidPolicyTestBuilder().WithEthernetInterface("eno1").
+			WithInterfaceMTU("eno1", testCase.mtu)
+		assert.Equal(t, testCase.expectedError, testBuilder.errorMsg)
+
+		if testCase.expectedError == "" {
+			assert.Equal(t, int(testCase.mtu), getPolicyTestDesiredState(t, testBuilder).Interfaces[0].MTU)
+		}
+	}
+}
+
+func TestPolicyWithInterfaceEthtool(t *testing.T) {
+	testBuilder := buildValidPolicyTestBuilder().WithEthernetInterface("eno1").
+		WithInterfaceEthtoolFeature("eno1", "rx-checksum", false).
+		WithInterfaceEthtoolFeature("eno1", "rx-gro", true).
+		WithInterfaceEthtoolRing("eno1", 4096, 2048)
+	assert.Empty(t, testBuilder.errorMsg)
+
+	ethtool := getPolicyTestDesiredState(t, testBuilder).Interfaces[0].Ethtool
+	assert.Equal(t, map[string]bool{"rx-checksum": false, "rx-gro": true}, ethtool.Feature)
+	assert.Equal(t, 4096, *ethtool.Ring.Rx)
+	assert.Equal(t, 2048, *ethtool.Ring.Tx)
+
+	testBuilder = buildValidPolicyTestBuilder().WithEthernetInterface("eno1").WithInterfaceEthtoolFeature("eno1", "", true)
+	assert.Equal(t, "nodenetworkconfigurationpolicy ethtool 'feature' cannot be empty", testBuilder.errorMsg)
+
+	testBuilder = buildValidPolicyTestBuilder().WithEthernetInterface("eno1").WithInterfaceEthtoolRing("eno1", 0, 2048)
+	assert.Equal(t, "nodenetworkconfigurationpolicy ethtool ring 'rx' and 'tx' cannot be zero", testBuilder.errorMsg)
+}
+
+func TestPolicyWithBridgeInterfaces(t *testing.T) {
+	testBuilder := buildValidPolicyTestBuilder().
+		WithLinuxBridgeInterface("br1", "eno1").
+		WithOVSBridgeInterface("br-ex", "eno2", "br-ex").
+		WithOVSInternalInterface("br-ex")
+	assert.Empty(t, testBuilder.errorMsg)
+
+	state := getPolicyTestDesiredState(t, testBuilder)
+	assert.Len(t, state.Interfaces, 3)
+	assert.Equal(t, "linux-bridge", state.Interfaces[0].Type)
+	assert.Equal(t, []BridgePort{{Name: "eno1"}}, state.Interfaces[0].Bridge.Ports)
+	assert.False(t, *state.Interfaces[0].Bridge.Options.Stp.Enabled)
+	assert.Equal(t, "ovs-bridge", state.Interfaces[1].Type)
+	assert.Equal(t, []BridgePort{{Name: "eno2"}, {Name: "br-ex"}}, state.Interfaces[1].Bridge.Ports)
+	assert.Equal(t, "ovs-interface", state.Interfaces[2].Type)
+
+	testBuilder = buildValidPolicyTestBuilder().WithOVSBridgeInterface("", "eno2")
+	assert.Equal(t, "nodenetworkconfigurationpolicy 'bridgeName' cannot be empty", testBuilder.errorMsg)
+}
+
+func TestPolicyWithVRFInterface(t *testing.T) {
+	testCases := []struct {
+		vrfName       string
+		routeTableID  uint32
+		expectedError string
+	}{
+		{
+			vrfName:       "vrf100",
+			routeTableID:  100,
+			expectedError: "",
+		},
+		{
+			vrfName:       "",
+			routeTableID:  100,
+			expectedError: "nodenetworkconfigurationpolicy 'vrfName' cannot be empty",
+		},
+		{
+			vrfName:       "vrf100",
+			routeTableID:  0,
+			expectedError: "nodenetworkconfigurationpolicy 'routeTableID' cannot be zero",
+		},
+	}
+
+	for _, testCase := range testCases {
+		testBuilder := buildValidPolicyTestBuilder().WithVRFInterface(testCase.vrfName, testCase.routeTableID, "eno1")
+		assert.Equal(t, testCase.expectedError, testBuilder.errorMsg)
+
+		if testCase.expectedError == "" {
+			vrf := getPolicyTestDesiredState(t, testBuilder).Interfaces[0].Vrf
+			assert.Equal(t, &Vrf{Port: []string{"eno1"}, RouteTableID: int(testCase.routeTableID)}, vrf)
+		}
+	}
+}
+
+func TestPolicyWithMacVlanInterface(t *testing.T) {
+	testCases := []struct {
+		baseInterface string
+		mode          string
+		expectedError string
+	}{
+		{
+			baseInterface: "eno1",
+			mode:          "bridge",
+			expectedError: "",
+		},
+		{
+			baseInterface: "",
+			mode:          "bridge",
+			expectedError: "nodenetworkconfigurationpolicy 'baseInterface' cannot be empty",
+		},
+		{
+			baseInterface: "eno1",
+			mode:          "invalid",
+			expectedError: "invalid MAC-VLAN mode parameter",
+		},
+	}
+
+	for _, testCase := range testCases {
+		testBuilder := buildValidPolicyTestBuilder().WithMacVlanInterface("macvlan0", testCase.baseInterface, testCase.mode)
+		assert.Equal(t, testCase.expectedError, testBuilder.errorMsg)
+
+		if testCase.expectedError == "" {
+			macVlan := getPolicyTestDesiredState(t, testBuilder).Interfaces[0].MacVlan
+			assert.Equal(t, &MacVlan{BaseIface: testCase.baseInterface, Mode: testCase.mode}, macVlan)
+		}
+	}
+}
+
+func TestPolicyWithVxlanInterface(t *testing.T) {
+	testCases := []struct {
+		vxlanID         uint32
+		remote          string
+		destinationPort uint16
+		expectedError   string
+	}{
+		{
+			vxlanID:         10,
+			remote:          "192.168.100.2",
+			destinationPort: 4789,
+			expectedError:   "",
+		},
+		{
+			vxlanID:         10,
+			remote:          "",
+			destinationPort: 0,
+			expectedError:   "",
+		},
+		{
+			vxlanID:         16777216,
+			remote:          "192.168.100.2",
+			destinationPort: 4789,
+			expectedError:   "invalid vxlanID, allowed vxlanID values are between 0-16777215",
+		},
+		{
+			vxlanID:         10,
+			remote:          "invalid",
+			destinationPort: 4789,
+			expectedError:   "nodenetworkconfigurationpolicy 'remote' invalid is not a valid IP address",
+		},
+	}
+
+	for _, testCase := range testCases {
+		testBuilder := buildValidPolicyTestBuilder().WithVxlanInterface(
+			"vxlan10", "eno1", testCase.vxlanID, testCase.remote, testCase.destinationPort)
+		assert.Equal(t, testCase.expectedError, testBuilder.errorMsg)
+
+		if testCase.expectedError == "" {
+			vxlan := getPolicyTestDesiredState(t, testBuilder).Interfaces[0].Vxlan
+			assert.Equal(t, int(testCase.vxlanID), vxlan.ID)
+			assert.Equal(t, testCase.remote, vxlan.Remote)
+
+			if testCase.destinationPort == 0 {
+				assert.Nil(t, vxlan.DestinationPort)
+			} else {
+				assert.Equal(t, int(testCase.destinationPort), *vxlan.DestinationPort)
+			}
+		}
+	}
+}
+
+func TestPolicyWithRoute(t *testing.T) {
+	testCases := []struct {
+		route         Route
+		expectedError string
+	}{
+		{
+			route:         Route{Destination: "0.0.0.0/0", NextHopAddress: "10.0.0.1", NextHopInterface: "eno1"},
+			expectedError: "",
+		},
+		{
+			route:         Route{Destination: "192.168.1.0/24", State: "absent"},
+			expectedError: "",
+		},
+		{
+			route:         Route{Destination: "10.0.0.1", NextHopInterface: "eno1"},
+			expectedError: "nodenetworkconfigurationpolicy route 'destination' 10.0.0.1 is not a valid CIDR",
+		},
+		{
+			route:         Route{Destination: "0.0.0.0/0", NextHopAddress: "10.0.0.1"},
+			expectedError: "nodenetworkconfigurationpolicy route 'nextHopInterface' cannot be empty",
+		},
+		{
+			route:         Route{Destination: "0.0.0.0/0", NextHopAddress: "invalid", NextHopInterface: "eno1"},
+			expectedError: "nodenetworkconfigurationpolicy route 'nextHopAddress' invalid is not a valid IP address",
+		},
+	}
+
+	for _, testCase := range testCases {
+		testBuilder := buildValidPolicyTestBuilder().WithRoute(testCase.route)
+		assert.Equal(t, testCase.expectedError, testBuilder.errorMsg)
+
+		if testCase.expectedError == "" {
+			assert.Equal(t, []Route{testCase.route}, getPolicyTestDesiredState(t, testBuilder).Routes.Config)
+		}
+	}
+}
+
+func TestPolicyWithRouteRule(t *testing.T) {
+	testCases := []struct {
+		rule          RouteRule
+		expectedError string
+	}{
+		{
+			rule:          RouteRule{IPFrom: "192.168.10.0/24"},
+			expectedError: "",
+		},
+		{
+			rule:          RouteRule{},
+			expectedError: "nodenetworkconfigurationpolicy route rule must have 'ipFrom' or 'ipTo'",
+		},
+		{
+			rule:          RouteRule{IPTo: "192.168.10.1"},
+			expectedError: "nodenetworkconfigurationpolicy route rule address 192.168.10.1 is not a valid CIDR",
+		},
+	}
+
+	for _, testCase := range testCases {
+		testBuilder := buildValidPolicyTestBuilder().WithRouteRule(testCase.rule)
+		assert.Equal(t, testCase.expectedError, testBuilder.errorMsg)
+
+		if testCase.expectedError == "" {
+			assert.Equal(t, []RouteRule{testCase.rule}, getPolicyTestDesiredState(t, testBuilder).RouteRules.Config)
+		}
+	}
+}
+
+func TestPolicyWithDNSResolver(t *testing.T) {
+	testCases := []struct {
+		servers       []string
+		search        []string
+		expectedError string
+	}{
+		{
+			servers:       []string{"10.0.0.53", "2001:db8::53"},
+			search:        []string{"example.com"},
+			expectedError: "",
+		},
+		{
+			servers:       nil,
+			search:        nil,
+			expectedError: "nodenetworkconfigurationpolicy dns resolver must have 'servers' or 'search'",
+		},
+		{
+			servers:       []string{"invalid"},
+			search:        nil,
+			expectedError: "nodenetworkconfigurationpolicy dns server invalid is not a valid IP address",
+		},
+	}
+
+	for _, testCase := range testCases {
+		testBuilder := buildValidPolicyTestBuilder().WithDNSResolver(testCase.servers, testCase.search)
+		assert.Equal(t, testCase.expectedError, testBuilder.errorMsg)
+
+		if testCase.expectedError == "" {
+			assert.Equal(t, &DNSConfig{Server: testCase.servers, Search: testCase.search},
+				getPolicyTestDesiredState(t, testBuilder).DNSResolver.Config)
+		}
+	}
+}
+
+func TestPolicyListEnactments(t *testing.T) {
+	testSettings := clients.GetTestClients(clients.TestClientParams{K8sMockObjects: []runtime.Object{
+		buildDummyEnactment("worker-0", nmstateShared.NodeNetworkConfigurationEnactmentConditionAvailable, ""),
+		buildDummyEnactment("worker-1", nmstateShared.NodeNetworkConfigurationEnactmentConditionAvailable, ""),
+		&nmstateV1alpha1.NodeNetworkConfigurationEnactment{ObjectMeta: metav1.ObjectMeta{
+			Name:   "worker-0.other-policy",
+			Labels: map[string]string{nmstateShared.EnactmentPolicyLabel: "other-policy"},
+		}},
+	}})
+
+	enactments, err := NewPolicyBuilder(testSettings, defaultEnactmentPolicy, defaultPolicyNodeSelector).ListEnactments()
+	assert.Nil(t, err)
+	assert.Len(t, enactments, 2)
+}
+
+func TestPolicyWaitUntilEnactmentsCondition(t *testing.T) {
+	testCases := []struct {
+		enactmentConditions []nmstateShared.ConditionType
+		condition           nmstateShared.ConditionType
+		expectedError       error
+	}{
+		{
+			enactmentConditions: []nmstateShared.ConditionType{
+				nmstateShared.NodeNetworkConfigurationEnactmentConditionAvailable,
+				nmstateShared.NodeNetworkConfigurationEnactmentConditionAvailable,
+			},
+			condition:     nmstateShared.NodeNetworkConfigurationEnactmentConditionAvailable,
+			expectedError: nil,
+		},
+		{
+			enactmentConditions: []nmstateShared.ConditionType{
+				nmstateShared.NodeNetworkConfigurationEnactmentConditionAvailable,
+				nmstateShared.NodeNetworkConfigurationEnactmentConditionFailing,
+			},
+			condition: nmstateShared.NodeNetworkConfigurationEnactmentConditionAvailable,
+			expectedError: fmt.Errorf(
+				"nodenetworkconfigurationenactments are failing: worker-1: %s", defaultFailureMessage),
+		},
+		{
+			enactmentConditions: []nmstateShared.ConditionType{
+				nmstateShared.NodeNetworkConfigurationEnactmentConditionFailing,
+				nmstateShared.NodeNetworkConfigurationEnactmentConditionFailing,
+			},
+			condition:     nmstateShared.NodeNetworkConfigurationEnactmentConditionFailing,
+			expectedError: nil,
+		},
+		{
+			enactmentConditions: []nmstateShared.ConditionType{
+				nmstateShared.NodeNetworkConfigurationEnactmentConditionAvailable,
+				nmstateShared.NodeNetworkConfigurationEnactmentConditionProgressing,
+			},
+			condition:     nmstateShared.NodeNetworkConfigurationEnactmentConditionAvailable,
+			expectedError: context.DeadlineExceeded,
+		},
+		{
+			enactmentConditions: nil,
+			condition:           nmstateShared.NodeNetworkConfigurationEnactmentConditionAvailable,
+			expectedError:       context.DeadlineExceeded,
+		},
+	}
+
+	for _, testCase := range testCases {
+		runtimeObjects := []runtime.Object{buildDummyPolicy(defaultEnactmentPolicy)}
+
+		for index, condition := range testCase.enactmentConditions {
+			runtimeObjects = append(runtimeObjects,
+				buildDummyEnactment(fmt.Sprintf("worker-%d", index), condition, defaultFailureMessage))
+		}
+
+		testSettings := clients.GetTestClients(clients.TestClientParams{K8sMockObjects: runtimeObjects})
+		testBuilder := NewPolicyBuilder(testSettings, defaultEnactmentPolicy, defaultPolicyNodeSelector)
+
+		err := testBuilder.WaitUntilEnactmentsCondition(testCase.condition, time.Second)
+		assert.Equal(t, testCase.expectedError, err)
+	}
+}
+
+func TestPolicyGetFailingEnactmentsMessages(t *testing.T) {
+	testSettings := clients.GetTestClients(clients.TestClientParams{K8sMockObjects: []runtime.Object{
+		buildDummyEnactment("worker-0", nmstateShared.NodeNetworkConfigurationEnactmentConditionAvailable, ""),
+		buildDummyEnactment("worker-1", nmstateShared.NodeNetworkConfigurationEnactmentConditionFailing,
+			defaultFailureMessage),
+	}})
+
+	messages, err := NewPolicyBuilder(
+		testSettings, defaultEnactmentPolicy, defaultPolicyNodeSelector).GetFailingEnactmentsMessages()
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{"worker-1": defaultFailureMessage}, messages)
+}
+
+func buildDummyPolicy(name string) *nmstateV1.NodeNetworkConfigurationPolicy {
+	return &nmstateV1.NodeNetworkConfigurationPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		},
+	}
+}
+
+func buildValidPolicyTestBuilder() *PolicyBuilder {
+	return NewPolicyBuilder(clients.GetTestClients(clients.TestClientParams{}), "policy", defaultPolicyNodeSelector)
+}
+
+func getPolicyTestDesiredState(t *testing.T, builder *PolicyBuilder) DesiredState {
+	t.Helper()
+
+	var state DesiredState
+
+	err := yaml.Unmarshal(builder.Definition.Spec.DesiredState.Raw, &state)
+	assert.Nil(t, err)
+
+	return state
+}
@@ -2,7 +2,10 @@ package nmstate
 
 // DesiredState provides struct for the NMState desired state object containing all NMState configuration.
 type DesiredState struct {
-	Interfaces []NetworkInterface `yaml:"interfaces,omitempty"`
+	Interfaces  []NetworkInterface `yaml:"interfaces,omitempty"`
+	Routes      *Routes            `yaml:"routes,omitempty"`
+	RouteRules  *RouteRules        `yaml:"route-rules,omitempty"`
+	DNSResolver *DNSResolver       `yaml:"dns-resolver,omitempty"`
 }
 
 // NetworkInterface provides struct for the NMState interface state object containing interface information.
@@ -10,15 +13,67 @@ type NetworkInterface struct {
 	Name            string          `yaml:"name"`
 	Type            string          `yaml:"type"`
 	State           string          `yaml:"state"`
+	Description     string          `yaml:"description,omitempty"`
+	MacAddress      string          `yaml:"mac-address,omitempty"`
+	MTU             int             `yaml:"mtu,omitempty"`
+	Controller      string          `yaml:"controller,omitempty"`
+	IPv4            *InterfaceIP    `yaml:"ipv4,omitempty"`
+	IPv6            *InterfaceIP    `yaml:"ipv6,omitempty"`
 	Ethernet        Ethernet        `yaml:"ethernet,omitempty"`
+	Ethtool         *Ethtool        `yaml:"ethtool,omitempty"`
 	Bridge          Bridge          `yaml:"bridge,omitempty"`
 	LinkAggregation LinkAggregation `yaml:"link-aggregation,omitempty"`
 	Vlan            Vlan            `yaml:"vlan,omitempty"`
+	Vrf             *Vrf            `yaml:"vrf,omitempty"`
+	MacVlan         *MacVlan        `yaml:"mac-vlan,omitempty"`
+	Vxlan           *Vxlan          `yaml:"vxlan,omitempty"`
+}
+
+// InterfaceIP provides struct for the NMState Interface IPv4 and IPv6 state object containing
+// interface IP configuration.
+type InterfaceIP struct {
+	Enabled          bool        `yaml:"enabled"`
+	Address          []IPAddress `yaml:"address,omitempty"`
+	Dhcp             *bool       `yaml:"dhcp,omitempty"`
+	Autoconf         *bool       `yaml:"autoconf,omitempty"`
+	AutoDNS          *bool       `yaml:"auto-dns,omitempty"`
+	AutoGateway      *bool       `yaml:"auto-gateway,omitempty"`
+	AutoRoutes       *bool       `yaml:"auto-routes,omitempty"`
+	AutoRouteTableID *int        `yaml:"auto-route-table-id,omitempty"`
+}
+
+// IPAddress provides struct for the NMState Interface IP address object.
+type IPAddress struct {
+	IP           string `yaml:"ip"`
+	PrefixLength int    `yaml:"prefix-length"`
 }
 
 // Ethernet provides struct for the NMState Interface Ethernet state object containing interface Ethernet information.
 type Ethernet struct {
-	Sriov Sriov `yaml:"sr-iov,omitempty"`
+	AutoNegotiation *bool  `yaml:"auto-negotiation,omitempty"`
+	Duplex          string `yaml:"duplex,omitempty"`
+	Speed           *int   `yaml:"speed,omitempty"`
+	Sriov           Sriov  `yaml:"sr-iov,omitempty"`
+}
+
+// Ethtool provides struct for the NMState Interface Ethtool state object containing interface Ethtool information.
+type Ethtool struct {
+	Feature map[string]bool `yaml:"feature,omitempty"`
+	Pause   *EthtoolPause   `yaml:"pause,omitempty"`
+	Ring    *EthtoolRing    `yaml:"ring,omitempty"`
+}
+
+// EthtoolPause provides struct for the NMState Interface Ethtool pause parameters.
+type EthtoolPause struct {
+	Autoneg *bool `yaml:"autoneg,omitempty"`
+	Rx      *bool `yaml:"rx,omitempty"`
+	Tx      *bool `yaml:"tx,omitempty"`
+}
+
+// EthtoolRing provides struct for the NMState Interface Ethtool ring parameters.
+type EthtoolRing struct {
+	Rx *int `yaml:"rx,omitempty"`
+	Tx *int `yaml:"tx,omitempty"`
 }
 
 // Sriov provides struct for the NMState Interface Ethernet Sriov state object containing
@@ -40,10 +95,145 @@ type Vf struct {
 	VlanID     *int   `yaml:"vlan-id,omitempty"`
 }
 
-// Bridge provides struct for the NMState Interface Ethernet Bridge state object
-// containing interface Bridge information.
+// Bridge provides struct for the NMState Interface Bridge state object containing linux-bridge
+// and ovs-bridge interface information.
 type Bridge struct {
-	Port []map[string]string `yaml:"port,omitempty"`
+	Options *BridgeOptions `yaml:"-"`
+	// Port holds the bridge ports as plain key-value maps, for example {"name": "eno1"}.
+	Port []map[string]string `yaml:"-"`
+	// Ports holds the bridge ports including their STP and VLAN filtering settings. Both Port and Ports are written
+	// to the same port list, with entries of Ports taking precedence over entries of Port with the same name.
+	Ports []BridgePort `yaml:"-"`
+}
+
+// bridgeYAML is the serialized form of Bridge where Port and Ports share the port key.
+type bridgeYAML struct {
+	Options *BridgeOptions `yaml:"options,omitempty"`
+	Port    []interface{}  `yaml:"port,omitempty"`
+}
+
+// bridgeYAMLPorts is used to decode the port key of a Bridge into typed ports.
+type bridgeYAMLPorts struct {
+	Options *BridgeOptions `yaml:"options,omitempty"`
+	Port    []BridgePort   `yaml:"port,omitempty"`
+}
+
+// MarshalYAML implements the yaml.Marshaler interface, merging Port and Ports into a single port list.
+func (bridge Bridge) MarshalYAML() (interface{}, error) {
+	serialized := bridgeYAML{Options: bridge.Options}
+	portNames := make(map[string]bool)
+
+	for _, port := range bridge.Ports {
+		portNames[port.Name] = true
+
+		serialized.Port = append(serialized.Port, port)
+	}
+
+	for _, port := range bridge.Port {
+		if name, ok := port["name"]; ok && portNames[name] {
+			continue
+		}
+
+		serialized.Port = append(serialized.Port, port)
+	}
+
+	return serialized, nil
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface, filling Ports with the decoded ports and Port with their
+// names.
+func (bridge *Bridge) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var serialized bridgeYAMLPorts
+
+	err := unmarshal(&serialized)
+	if err != nil {
+		return err
+	}
+
+	bridge.Options = serialized.Options
+	bridge.Ports = serialized.Port
+	bridge.Port = nil
+
+	for _, port := range serialized.Port {
+		bridge.Port = append(bridge.Port, map[string]string{"name": port.Name})
+	}
+
+	return nil
+}
+
+// BridgeOptions provides struct for the NMState Interface Bridge Options state object. FailMode and
+// McastSnoopingEnable only apply to ovs-bridge while MacAgeingTime and MulticastSnooping only apply to linux-bridge.
+type BridgeOptions struct {
+	Stp                 *BridgeStp `yaml:"stp,omitempty"`
+	MacAgeingTime       *int       `yaml:"mac-ageing-time,omitempty"`
+	MulticastSnooping   *bool      `yaml:"multicast-snooping,omitempty"`
+	FailMode            string     `yaml:"fail-mode,omitempty"`
+	McastSnoopingEnable *bool      `yaml:"mcast-snooping-enable,omitempty"`
+}
+
+// BridgeStp provides struct for the NMState Interface Bridge STP options. nmstate also accepts stp as a plain bool,
+// which is decoded into Enabled.
+type BridgeStp struct {
+	Enabled      *bool `yaml:"enabled,omitempty"`
+	ForwardDelay *int  `yaml:"forward-delay,omitempty"`
+	HelloTime    *int  `yaml:"hello-time,omitempty"`
+	MaxAge       *int  `yaml:"max-age,omitempty"`
+	Priority     *int  `yaml:"priority,omitempty"`
+
+	// boolForm records that stp was decoded from a plain bool so it is encoded back the same way.
+	boolForm bool
+}
+
+// bridgeStpYAML is used to encode and decode the mapping form of BridgeStp without its custom yaml methods.
+type bridgeStpYAML BridgeStp
+
+// MarshalYAML implements the yaml.Marshaler interface, writing stp back as a plain bool when it was decoded from one
+// and only Enabled is set.
+func (stp BridgeStp) MarshalYAML() (interface{}, error) {
+	if stp.boolForm && stp.Enabled != nil &&
+		stp.ForwardDelay == nil && stp.HelloTime == nil && stp.MaxAge == nil && stp.Priority == nil {
+		return *stp.Enabled, nil
+	}
+
+	return bridgeStpYAML(stp), nil
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface, accepting both a plain bool and the STP options mapping.
+func (stp *BridgeStp) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var enabled bool
+
+	if err := unmarshal(&enabled); err == nil {
+		*stp = BridgeStp{Enabled: &enabled, boolForm: true}
+
+		return nil
+	}
+
+	var serialized bridgeStpYAML
+
+	err := unmarshal(&serialized)
+	if err != nil {
+		return err
+	}
+
+	*stp = BridgeStp(serialized)
+	stp.boolForm = false
+
+	return nil
+}
+
+// BridgePort provides struct for the NMState Interface Bridge port object.
+type BridgePort struct {
+	Name           string          `yaml:"name"`
+	StpHairpinMode *bool           `yaml:"stp-hairpin-mode,omitempty"`
+	StpPathCost    *int            `yaml:"stp-path-cost,omitempty"`
+	StpPriority    *int            `yaml:"stp-priority,omitempty"`
+	Vlan           *BridgePortVlan `yaml:"vlan,omitempty"`
+}
+
+// BridgePortVlan provides struct for the NMState Interface Bridge port VLAN filtering configuration.
+type BridgePortVlan struct {
+	Mode string `yaml:"mode,omitempty"`
+	Tag  *int   `yaml:"tag,omitempty"`
 }
 
 // LinkAggregation provides struct for the NMState Interface Ethernet LinkAggregation state object
@@ -68,3 +258,69 @@ type Vlan struct {
 	BaseIface string `yaml:"base-iface"`
 	ID        int    `yaml:"id"`
 }
+
+// Vrf provides struct for the NMState Interface Vrf state object containing interface Vrf information.
+type Vrf struct {
+	Port         []string `yaml:"port,omitempty"`
+	RouteTableID int      `yaml:"route-table-id"`
+}
+
+// MacVlan provides struct for the NMState Interface MacVlan state object containing interface MacVlan information.
+type MacVlan struct {
+	BaseIface   string `yaml:"base-iface"`
+	Mode        string `yaml:"mode"`
+	Promiscuous *bool  `yaml:"promiscuous,omitempty"`
+}
+
+// Vxlan provides struct for the NMState Interface Vxlan state object containing interface Vxlan information.
+type Vxlan struct {
+	BaseIface       string `yaml:"base-iface,omitempty"`
+	ID              int    `yaml:"id"`
+	Remote          string `yaml:"remote,omitempty"`
+	Local           string `yaml:"local,omitempty"`
+	DestinationPort *int   `yaml:"destination-port,omitempty"`
+}
+
+// Routes provides struct for the NMState routes state object containing the configured and running routes.
+type Routes struct {
+	Config  []Route `yaml:"config,omitempty"`
+	Running []Route `yaml:"running,omitempty"`
+}
+
+// Route provides struct for the NMState route object. Setting State to absent removes the matching routes.
+type Route struct {
+	Destination      string `yaml:"destination,omitempty"`
+	NextHopAddress   string `yaml:"next-hop-address,omitempty"`
+	NextHopInterface string `yaml:"next-hop-interface,omitempty"`
+	Metric           *int   `yaml:"metric,omitempty"`
+	TableID          *int   `yaml:"table-id,omitempty"`
+	State            string `yaml:"state,omitempty"`
+}
+
+// RouteRules provides struct for the NMState route rules state object.
+type RouteRules struct {
+	Config []RouteRule `yaml:"config,omitempty"`
+}
+
+// RouteRule provides struct for the NMState route rule object. Setting State to absent removes the matching rules.
+type RouteRule struct {
+	IPFrom     string `yaml:"ip-from,omitempty"`
+	IPTo       string `yaml:"ip-to,omitempty"`
+	Priority   *int   `yaml:"priority,omitempty"`
+	RouteTable *int   `yaml:"route-table,omitempty"`
+	Family     string `yaml:"family,omitempty"`
+	State      string `yaml:"state,omitempty"`
+}
+
+// DNSResolver provides struct for the NMState dns-resolver state object containing the configured and running
+// DNS resolver.
+type DNSResolver struct {
+	Config  *DNSConfig `yaml:"config,omitempty"`
+	Running *DNSConfig `yaml:"running,omitempty"`
+}
+
+// DNSConfig provides struct for the NMState DNS resolver configuration object.
+type DNSConfig struct {
+	Search []string `yaml:"search,omitempty"`
+	Server []string `yaml:"server,omitempty"`
+}
@@ -0,0 +1,319 @@
+package nmstate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v2"
+	"k8s.io/utils/ptr"
+)
+
+// defaultNodeNetworkState is a trimmed down current state as reported by nmstate in NodeNetworkState.
+const defaultNodeNetworkState = `dns-resolver:
+  config:
+    search:
+    - example.com
+    server:
+    - 10.0.0.53
+  running:
+    search:
+    - example.com
+    server:
+    - 10.0.0.53
+    - 2001:db8::53
+interfaces:
+- name: eno1
+  type: ethernet
+  state: up
+  mac-address: "B8:CE:F6:00:00:01"
+  mtu: 9000
+  ipv4:
+    enabled: true
+    address:
+    - ip: 10.0.0.10
+      prefix-length: 24
+    dhcp: false
+  ipv6:
+    enabled: true
+    address:
+    - ip: 2001:db8::10
+      prefix-length: 64
+    dhcp: false
+    autoconf: false
+  ethernet:
+    auto-negotiation: true
+    duplex: full
+    speed: 25000
+    sr-iov:
+      total-vfs: 2
+      vfs:
+      - id: 0
+        mac-address: "02:00:00:00:00:01"
+        spoof-check: true
+        trust: false
+  ethtool:
+    feature:
+      rx-checksum: true
+      tx-tcp-segmentation: false
+    pause:
+      autoneg: false
+      rx: true
+      tx: true
+    ring:
+      rx: 1024
+      tx: 1024
+- name: br-ex
+  type: ovs-bridge
+  state: up
+  bridge:
+    options:
+      stp:
+        enabled: false
+      fail-mode: standalone
+      mcast-snooping-enable: false
+    port:
+    - name: eno2
+    - name: br-ex
+- name: br-ex
+  type: ovs-interface
+  state: up
+  controller: br-ex
+  ipv4:
+    enabled: true
+    dhcp: true
+    auto-dns: true
+    auto-gateway: true
+    auto-routes: true
+- name: br1
+  type: linux-bridge
+  state: up
+  bridge:
+    options:
+      stp:
+        enabled: true
+        forward-delay: 15
+        hello-time: 2
+        max-age: 20
+        priority: 32768
+      mac-ageing-time: 300
+      multicast-snooping: true
+    port:
+    - name: eno3
+      stp-hairpin-mode: false
+      stp-path-cost: 100
+      stp-priority: 32
+      vlan: {}
+- name: vrf100
+  type: vrf
+  state: up
+  vrf:
+    port:
+    - eno4
+    route-table-id: 100
+- name: macvlan0
+  type: mac-vlan
+  state: up
+  mac-vlan:
+    base-iface: eno4
+    mode: bridge
+    promiscuous: true
+- name: bond0
+  type: bond
+  state: up
+  link-aggregation:
+    mode: active-backup
+    options:
+      primary: eno5
+      miimon: 100
+    port:
+    - eno5
+    - eno6
+- name: eno7
+  type: ethernet
+  state: down
+  ipv4:
+    enabled: false
+  ipv6:
+    enabled: false
+- name: vxlan10
+  type: vxlan
+  state: up
+  vxlan:
+    base-iface: eno4
+    id: 10
+    remote: 192.168.100.2
+    destination-port: 4789
+route-rules:
+  config:
+  - ip-from: 192.168.10.0/24
+    priority: 1000
+    route-table: 100
+routes:
+  config:
+  - destination: 0.0.0.0/0
+    next-hop-address: 10.0.0.1
+    next-hop-interface: eno1
+    table-id: 254
+  running:
+  - destination: 0.0.0.0/0
+    next-hop-address: 10.0.0.1
+    next-hop-interface: eno1
+    metric: 100
+    table-id: 254
+  - destination: 0.0.0.0/0
+    next-hop-address: 192.168.1.1
+    next-hop-interface: bond0
+    metric: 50
+    table-id: 100
+  - destination: 0.0.0.0/0
+    next-hop-address: 10.0.1.1
+    next-hop-interface: br-ex
+    metric: 425
+    table-id: 254
+  - destination: ::/0
+    next-hop-address: fe80::1
+    next-hop-interface: br-ex
+    metric: 48
+    table-id: 254
+`
+
+func TestDesiredStateUnmarshal(t *testing.T) {
+	var state DesiredState
+
+	err := yaml.Unmarshal([]byte(defaultNodeNetworkState), &state)
+	assert.Nil(t, err)
+
+	assert.Len(t, state.Interfaces, 9)
+	assert.Equal(t, []string{"10.0.0.53", "2001:db8::53"}, state.DNSResolver.Running.Server)
+	assert.Equal(t, 100, *state.Routes.Running[0].Metric)
+	assert.Equal(t, 100, *state.RouteRules.Config[0].RouteTable)
+
+	ethernet := state.Interfaces[0]
+	assert.Equal(t, 9000, ethernet.MTU)
+	assert.Equal(t, []IPAddress{{IP: "10.0.0.10", PrefixLength: 24}}, ethernet.IPv4.Address)
+	assert.False(t, *ethernet.IPv6.Autoconf)
+	assert.Equal(t, 25000, *ethernet.Ethernet.Speed)
+	assert.Equal(t, 2, *ethernet.Ethernet.Sriov.TotalVfs)
+	assert.False(t, ethernet.Ethtool.Feature["tx-tcp-segmentation"])
+	assert.Equal(t, 1024, *ethernet.Ethtool.Ring.Rx)
+
+	assert.Equal(t, "standalone", state.Interfaces[1].Bridge.Options.FailMode)
+	assert.Equal(t, "br-ex", state.Interfaces[2].Controller)
+	assert.True(t, *state.Interfaces[2].IPv4.Dhcp)
+	assert.Equal(t, 32, *state.Interfaces[3].Bridge.Ports[0].StpPriority)
+	assert.NotNil(t, state.Interfaces[3].Bridge.Ports[0].Vlan)
+	assert.Equal(t, 100, state.Interfaces[4].Vrf.RouteTableID)
+	assert.Equal(t, "bridge", state.Interfaces[5].MacVlan.Mode)
+	assert.Equal(t, 4789, *state.Interfaces[8].Vxlan.DestinationPort)
+}
+
+func TestDesiredStateRoundTrip(t *testing.T) {
+	var state DesiredState
+
+	err := yaml.Unmarshal([]byte(defaultNodeNetworkState), &state)
+	assert.Nil(t, err)
+
+	marshaledState, err := yaml.Marshal(state)
+	assert.Nil(t, err)
+
+	var roundTripState DesiredState
+
+	err = yaml.Unmarshal(marshaledState, &roundTripState)
+	assert.Nil(t, err)
+	assert.Equal(t, state, roundTripState)
+
+	var expected, actual map[string]interface{}
+
+	err = yaml.Unmarshal([]byte(defaultNodeNetworkState), &expected)
+	assert.Nil(t, err)
+
+	err = yaml.Unmarshal(marshaledState, &actual)
+	assert.Nil(t, err)
+	assert.Equal(t, expected, actual)
+}
+
+func TestBridgeStpYAML(t *testing.T) {
+	testCases := []struct {
+		stateYAML       string
+		expectedEnabled bool
+		expectedPrio    *int
+	}{
+		{
+			stateYAML: `interfaces:
+- name: br-ex
+  type: ovs-bridge
+  state: up
+  bridge:
+    options:
+      stp: false
+`,
+			expectedEnabled: false,
+		},
+		{
+			stateYAML: `interfaces:
+- name: br-ex
+  type: ovs-bridge
+  state: up
+  bridge:
+    options:
+      stp: true
+`,
+			expectedEnabled: true,
+		},
+		{
+			stateYAML: `interfaces:
+- name: br0
+  type: linux-bridge
+  state: up
+  bridge:
+    options:
+      stp:
+        enabled: true
+        priority: 4096
+`,
+			expectedEnabled: true,
+			expectedPrio:    ptr.To(4096),
+		},
+	}
+
+	for _, testCase := range testCases {
+		var state DesiredState
+
+		err := yaml.Unmarshal([]byte(testCase.stateYAML), &state)
+		assert.Nil(t, err)
+		assert.Equal(t, testCase.expectedEnabled, *state.Interfaces[0].Bridge.Options.Stp.Enabled)
+		assert.Equal(t, testCase.expectedPrio, state.Interfaces[0].Bridge.Options.Stp.Priority)
+
+		marshaledState, err := yaml.Marshal(state)
+		assert.Nil(t, err)
+		assert.Equal(t, testCase.stateYAML, string(marshaledState))
+
+		var roundTripState DesiredState
+
+		err = yaml.Unmarshal(marshaledState, &roundTripState)
+		assert.Nil(t, err)
+		assert.Equal(t, state, roundTripState)
+	}
+}
+
+func TestBridgePortsYAML(t *testing.T) {
+	bridge := Bridge{
+		Port:  []map[string]string{{"name": "eno1"}, {"name": "eno2"}},
+		Ports: []BridgePort{{Name: "eno2", StpPriority: ptr.To(32)}},
+	}
+
+	marshaledBridge, err := yaml.Marshal(bridge)
+	assert.Nil(t, err)
+	assert.Equal(t, "port:\n- name: eno2\n  stp-priority: 32\n- name: eno1\n", string(marshaledBridge))
+
+	var unmarshaledBridge Bridge
+
+	err = yaml.Unmarshal(marshaledBridge, &unmarshaledBridge)
+	assert.Nil(t, err)
+	assert.Equal(t, []map[string]string{{"name": "eno2"}, {"name": "eno1"}}, unmarshaledBridge.Port)
+	assert.Equal(t, []BridgePort{{Name: "eno2", StpPriority: ptr.To(32)}, {Name: "eno1"}}, unmarshaledBridge.Ports)
+
+	legacyBridge, err := yaml.Marshal(Bridge{Port: []map[string]string{{"name": "eno1"}}})
+	assert.Nil(t, err)
+	assert.Equal(t, "port:\n- name: eno1\n", string(legacyBridge))
+}
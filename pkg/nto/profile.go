@@ -0,0 +1,352 @@
+package nto //nolint:misspell
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/openshift-kni/eco-goinfra/pkg/clients"
+	"github.com/openshift-kni/eco-goinfra/pkg/msg"
+	tunedv1 "github.com/openshift/cluster-node-tuning-operator/pkg/apis/tuned/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	goclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// stalldServiceStartOption is the TuneD [service] plugin option which starts stalld on the node.
+	stalldServiceStartOption = "service.stalld=start"
+)
+
+// ProfileBuilder provides a struct for the per-node tuned Profile object written by the node tuning operator.
+// The Profile object is named after the node it describes.
+type ProfileBuilder struct {
+	// Profile definition. Profiles are managed by the node tuning operator and are only pulled from the cluster.
+	Definition *tunedv1.Profile
+	// Pulled Profile object.
+	Object *tunedv1.Profile
+	// Used to store latest error message upon defining or mutating Profile definition.
+	errorMsg string
+	// api client to interact with the cluster.
+	apiClient goclient.Client
+}
+
+// PullProfile pulls existing tuned Profile of the given node from cluster.
+func PullProfile(apiClient *clients.Settings, nodeName, nsname string) (*ProfileBuilder, error) {
+	glog.V(100).Infof("Pulling existing tuned Profile %s in namespace %s from cluster", nodeName, nsname)
+
+	if apiClient == nil {
+		glog.V(100).Infof("The apiClient is empty")
+
+		return nil, fmt.Errorf("profile 'apiClient' cannot be empty")
+	}
+
+	builder := ProfileBuilder{
+		apiClient: apiClient.Client,
+		Definition: &tunedv1.Profile{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      nodeName,
+				Namespace: nsname,
+			},
+		},
+	}
+
+	if nodeName == "" {
+		glog.V(100).Infof("The name of the tuned Profile is empty")
+
+		return nil, fmt.Errorf("profile 'nodeName' cannot be empty")
+	}
+
+	if nsname == "" {
+		glog.V(100).Infof("The namespace of the tuned Profile is empty")
+
+		return nil, fmt.Errorf("profile 'nsname' cannot be empty")
+	}
+
+	if !builder.Exists() {
+		return nil, fmt.Errorf("profile object %s does not exist in namespace %s", nodeName, nsname)
+	}
+
+	builder.Definition = builder.Object
+
+	return &builder, nil
+}
+
+// Get fetches the defined tuned Profile from the cluster.
+func (builder *ProfileBuilder) Get() (*tunedv1.Profile, error) {
+	if valid, err := builder.validate(); !valid {
+		return nil, err
+	}
+
+	glog.V(100).Infof("Getting tuned Profile %s in namespace %s",
+		builder.Definition.Name, builder.Definition.Namespace)
+
+	profileObj := &tunedv1.Profile{}
+	err := builder.apiClient.Get(context.TODO(), goclient.ObjectKey{
+		Name:      builder.Definition.Name,
+		Namespace: builder.Definition.Namespace,
+	}, profileObj)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return profileObj, nil
+}
+
+// Exists checks whether the given tuned Profile exists.
+func (builder *ProfileBuilder) Exists() bool {
+	if valid, _ := builder.validate(); !valid {
+		return false
+	}
+
+	glog.V(100).Infof("Checking if tuned Profile %s exists in namespace %s",
+		builder.Definition.Name, builder.Definition.Namespace)
+
+	var err error
+	builder.Object, err = builder.Get()
+
+	return err == nil || !k8serrors.IsNotFound(err)
+}
+
+// GetAppliedProfile returns the name of the TuneD profile currently in use by the tuned daemon on the node.
+func (builder *ProfileBuilder) GetAppliedProfile() (string, error) {
+	if valid, err := builder.validate(); !valid {
+		return "", err
+	}
+
+	glog.V(100).Infof("Getting applied TuneD profile of tuned Profile %s in namespace %s",
+		builder.Definition.Name, builder.Definition.Namespace)
+
+	if !builder.Exists() {
+		return "", fmt.Errorf("profile object %s does not exist in namespace %s",
+			builder.Definition.Name, builder.Definition.Namespace)
+	}
+
+	return builder.Object.Status.TunedProfile, nil
+}
+
+// GetCondition returns the status condition of the given type reported by the tuned daemon.
+func (builder *ProfileBuilder) GetCondition(
+	conditionType tunedv1.ProfileConditionType) (*tunedv1.ProfileStatusCondition, error) {
+	if valid, err := builder.validate(); !valid {
+		return nil, err
+	}
+
+	glog.V(100).Infof("Getting %s condition of tuned Profile %s in namespace %s",
+		conditionType, builder.Definition.Name, builder.Definition.Namespace)
+
+	if !builder.Exists() {
+		return nil, fmt.Errorf("profile object %s does not exist in namespace %s",
+			builder.Definition.Name, builder.Definition.Namespace)
+	}
+
+	for _, condition := range builder.Object.Status.Conditions {
+		if condition.Type == conditionType {
+			return &condition, nil
+		}
+	}
+
+	return nil, fmt.Errorf("the %s condition not found in tuned Profile %s",
+		conditionType, builder.Definition.Name)
+}
+
+// IsApplied returns true when the tuned daemon reports the profile as Applied and not Degraded.
+func (builder *ProfileBuilder) IsApplied() (bool, error) {
+	if valid, err := builder.validate(); !valid {
+		return false, err
+	}
+
+	glog.V(100).Infof("Checking if tuned Profile %s in namespace %s is applied",
+		builder.Definition.Name, builder.Definition.Namespace)
+
+	if !builder.Exists() {
+		return false, fmt.Errorf("profile object %s does not exist in namespace %s",
+			builder.Definition.Name, builder.Definition.Namespace)
+	}
+
+	return isProfileObjectApplied(builder.Object), nil
+}
+
+// GetDaemonErrors returns the messages of the Applied and Degraded conditions when the tuned daemon
+// failed to apply the profile cleanly. An empty string is returned when no errors were reported.
+func (builder *ProfileBuilder) GetDaemonErrors() (string, error) {
+	if valid, err := builder.validate(); !valid {
+		return "", err
+	}
+
+	glog.V(100).Infof("Getting tuned daemon errors of tuned Profile %s in namespace %s",
+		builder.Definition.Name, builder.Definition.Namespace)
+
+	if !builder.Exists() {
+		return "", fmt.Errorf("profile object %s does not exist in namespace %s",
+			builder.Definition.Name, builder.Definition.Namespace)
+	}
+
+	return getProfileObjectErrors(builder.Object), nil
+}
+
+// GetBootcmdline returns the kernel command-line parameters calculated by TuneD for the applied profile.
+func (builder *ProfileBuilder) GetBootcmdline() (string, error) {
+	if valid, err := builder.validate(); !valid {
+		return "", err
+	}
+
+	glog.V(100).Infof("Getting bootcmdline of tuned Profile %s in namespace %s",
+		builder.Definition.Name, builder.Definition.Namespace)
+
+	if !builder.Exists() {
+		return "", fmt.Errorf("profile object %s does not exist in namespace %s",
+			builder.Definition.Name, builder.Definition.Namespace)
+	}
+
+	return builder.Object.Annotations[tunedv1.TunedBootcmdlineAnnotationKey], nil
+}
+
+// IsStalldEnabled returns true when one of the TuneD profiles handed to the node starts the stalld service.
+func (builder *ProfileBuilder) IsStalldEnabled() (bool, error) {
+	if valid, err := builder.validate(); !valid {
+		return false, err
+	}
+
+	glog.V(100).Infof("Checking if stalld is enabled by tuned Profile %s in namespace %s",
+		builder.Definition.Name, builder.Definition.Namespace)
+
+	if !builder.Exists() {
+		return false, fmt.Errorf("profile object %s does not exist in namespace %s",
+			builder.Definition.Name, builder.Definition.Namespace)
+	}
+
+	for _, tunedProfile := range builder.Object.Spec.Profile {
+		if tunedProfile.Data == nil {
+			continue
+		}
+
+		for _, line := range strings.Split(*tunedProfile.Data, "\n") {
+			if strings.HasPrefix(strings.ReplaceAll(line, " ", ""), stalldServiceStartOption) {
+				return true, nil
+			}
+		}
+	}
+
+	return false, nil
+}
+
+// WaitUntilProfileApplied waits for timeout duration or until the given TuneD profile is applied on the node.
+// On timeout the returned error contains the tuned daemon errors reported in the Profile conditions.
+func (builder *ProfileBuilder) WaitUntilProfileApplied(tunedProfileName string, timeout time.Duration) error {
+	if valid, err := builder.validate(); !valid {
+		return err
+	}
+
+	if tunedProfileName == "" {
+		glog.V(100).Infof("The tunedProfileName is empty")
+
+		return fmt.Errorf("profile 'tunedProfileName' cannot be empty")
+	}
+
+	glog.V(100).Infof("Waiting until TuneD profile %s is applied by tuned Profile %s in namespace %s",
+		tunedProfileName, builder.Definition.Name, builder.Definition.Namespace)
+
+	err := wait.PollUntilContextTimeout(
+		context.TODO(), time.Second, timeout, true, func(ctx context.Context) (bool, error) {
+			if !builder.Exists() || builder.Object == nil {
+				return false, nil
+			}
+
+			return builder.Object.Status.TunedProfile == tunedProfileName &&
+				isProfileObjectApplied(builder.Object), nil
+		})
+
+	if err != nil {
+		return fmt.Errorf("TuneD profile %s was not applied on node %s: %s: %w",
+			tunedProfileName, builder.Definition.Name, describeProfileObject(builder.Object), err)
+	}
+
+	return nil
+}
+
+// validate will check that the builder and builder definition are properly initialized before
+// accessing any member fields.
+func (builder *ProfileBuilder) validate() (bool, error) {
+	resourceCRD := "Profile"
+
+	if builder == nil {
+		glog.V(100).Infof("The %s builder is uninitialized", resourceCRD)
+
+		return false, fmt.Errorf("error: received nil %s builder", resourceCRD)
+	}
+
+	if builder.Definition == nil {
+		glog.V(100).Infof("The %s is undefined", resourceCRD)
+
+		return false, fmt.Errorf(msg.UndefinedCrdObjectErrString(resourceCRD))
+	}
+
+	if builder.apiClient == nil {
+		glog.V(100).Infof("The %s builder apiclient is nil", resourceCRD)
+
+		return false, fmt.Errorf("%s builder cannot have nil apiClient", resourceCRD)
+	}
+
+	if builder.errorMsg != "" {
+		glog.V(100).Infof("The %s builder has error message: %s", resourceCRD, builder.errorMsg)
+
+		return false, fmt.Errorf(builder.errorMsg)
+	}
+
+	return true, nil
+}
+
+// isProfileObjectApplied checks that the Applied condition is True and the Degraded condition is not True.
+func isProfileObjectApplied(profile *tunedv1.Profile) bool {
+	applied := false
+
+	for _, condition := range profile.Status.Conditions {
+		switch condition.Type {
+		case tunedv1.TunedProfileApplied:
+			applied = condition.Status == corev1.ConditionTrue
+		case tunedv1.TunedDegraded:
+			if condition.Status == corev1.ConditionTrue {
+				return false
+			}
+		}
+	}
+
+	return applied
+}
+
+// getProfileObjectErrors joins the messages of the failing Applied and Degraded conditions.
+func getProfileObjectErrors(profile *tunedv1.Profile) string {
+	var daemonErrors []string
+
+	for _, condition := range profile.Status.Conditions {
+		failing := (condition.Type == tunedv1.TunedProfileApplied && condition.Status != corev1.ConditionTrue) ||
+			(condition.Type == tunedv1.TunedDegraded && condition.Status == corev1.ConditionTrue)
+
+		if failing && condition.Message != "" {
+			daemonErrors = append(daemonErrors, fmt.Sprintf("%s: %s", condition.Type, condition.Message))
+		}
+	}
+
+	return strings.Join(daemonErrors, "; ")
+}
+
+// describeProfileObject returns a short human readable summary of the Profile status used in wait errors.
+func describeProfileObject(profile *tunedv1.Profile) string {
+	if profile == nil {
+		return "profile not found"
+	}
+
+	description := fmt.Sprintf("applied profile is %q", profile.Status.TunedProfile)
+
+	if daemonErrors := getProfileObjectErrors(profile); daemonErrors != "" {
+		description += fmt.Sprintf(", tuned daemon errors: %s", daemonErrors)
+	}
+
+	return description
+}
@@ -0,0 +1,297 @@
+package nto //nolint:misspell
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/openshift-kni/eco-goinfra/pkg/clients"
+	tunedv1 "github.com/openshift/cluster-node-tuning-operator/pkg/apis/tuned/v1"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+var (
+	defaultProfileNodeName    = "worker-0"
+	defaultTunedProfileStalld = "[main]\nsummary=Realtime profile\n[service]\nservice.stalld=start,enable\n"
+)
+
+func TestPullProfile(t *testing.T) {
+	testCases := []struct {
+		nodeName            string
+		namespace           string
+		addToRuntimeObjects bool
+		client              bool
+		expectedError       error
+	}{
+		{
+			nodeName:            defaultProfileNodeName,
+			namespace:           defaultTunedNamespace,
+			addToRuntimeObjects: true,
+			client:              true,
+			expectedError:       nil,
+		},
+		{
+			nodeName:            "",
+			namespace:           defaultTunedNamespace,
+			addToRuntimeObjects: true,
+			client:              true,
+			expectedError:       fmt.Errorf("profile 'nodeName' cannot be empty"),
+		},
+		{
+			nodeName:            defaultProfileNodeName,
+			namespace:           "",
+			addToRuntimeObjects: true,
+			client:              true,
+			expectedError:       fmt.Errorf("profile 'nsname' cannot be empty"),
+		},
+		{
+			nodeName:            defaultProfileNodeName,
+			namespace:           defaultTunedNamespace,
+			addToRuntimeObjects: false,
+			client:              true,
+			expectedError: fmt.Errorf("profile object worker-0 does not exist in " +
+				"namespace openshift-cluster-node-tuning-operator"),
+		},
+		{
+			nodeName:            defaultProfileNodeName,
+			namespace:           defaultTunedNamespace,
+			addToRuntimeObjects: true,
+			client:              false,
+			expectedError:       fmt.Errorf("profile 'apiClient' cannot be empty"),
+		},
+	}
+
+	for _, testCase := range testCases {
+		var (
+			runtimeObjects []runtime.Object
+			testSettings   *clients.Settings
+		)
+
+		if testCase.addToRuntimeObjects {
+			runtimeObjects = append(runtimeObjects, buildDummyProfile(defaultProfileNodeName, "openshift-node",
+				corev1.ConditionTrue, corev1.ConditionFalse, ""))
+		}
+
+		if testCase.client {
+			testSettings = clients.GetTestClients(clients.TestClientParams{K8sMockObjects: runtimeObjects})
+		}
+
+		profileBuilder, err := PullProfile(testSettings, testCase.nodeName, testCase.namespace)
+		assert.Equal(t, testCase.expectedError, err)
+
+		if testCase.expectedError == nil {
+			assert.Equal(t, testCase.nodeName, profileBuilder.Object.Name)
+		}
+	}
+}
+
+func TestProfileStatusGetters(t *testing.T) {
+	testCases := []struct {
+		appliedStatus   corev1.ConditionStatus
+		degradedStatus  corev1.ConditionStatus
+		message         string
+		expectedApplied bool
+		expectedErrors  string
+	}{
+		{
+			appliedStatus:   corev1.ConditionTrue,
+			degradedStatus:  corev1.ConditionFalse,
+			expectedApplied: true,
+			expectedErrors:  "",
+		},
+		{
+			appliedStatus:   corev1.ConditionTrue,
+			degradedStatus:  corev1.ConditionTrue,
+			message:         "TuneD daemon issued one or more error message(s)",
+			expectedApplied: false,
+			expectedErrors:  "Degraded: TuneD daemon issued one or more error message(s)",
+		},
+		{
+			appliedStatus:   corev1.ConditionFalse,
+			degradedStatus:  corev1.ConditionFalse,
+			message:         "The TuneD daemon profile not yet applied",
+			expectedApplied: false,
+			expectedErrors:  "Applied: The TuneD daemon profile not yet applied",
+		},
+	}
+
+	for _, testCase := range testCases {
+		testSettings := clients.GetTestClients(clients.TestClientParams{
+			K8sMockObjects: []runtime.Object{buildDummyProfile(defaultProfileNodeName, "openshift-node",
+				testCase.appliedStatus, testCase.degradedStatus, testCase.message)},
+		})
+
+		profileBuilder, err := PullProfile(testSettings, defaultProfileNodeName, defaultTunedNamespace)
+		assert.Nil(t, err)
+
+		appliedProfile, err := profileBuilder.GetAppliedProfile()
+		assert.Nil(t, err)
+		assert.Equal(t, "openshift-node", appliedProfile)
+
+		applied, err := profileBuilder.IsApplied()
+		assert.Nil(t, err)
+		assert.Equal(t, testCase.expectedApplied, applied)
+
+		daemonErrors, err := profileBuilder.GetDaemonErrors()
+		assert.Nil(t, err)
+		assert.Equal(t, testCase.expectedErrors, daemonErrors)
+
+		condition, err := profileBuilder.GetCondition(tunedv1.TunedDegraded)
+		assert.Nil(t, err)
+		assert.Equal(t, testCase.degradedStatus, condition.Status)
+
+		bootcmdline, err := profileBuilder.GetBootcmdline()
+		assert.Nil(t, err)
+		assert.Equal(t, "skew_tick=1 nohz=on", bootcmdline)
+
+		stalld, err := profileBuilder.IsStalldEnabled()
+		assert.Nil(t, err)
+		assert.True(t, stalld)
+	}
+}
+
+func TestProfileWaitUntilProfileApplied(t *testing.T) {
+	testCases := []struct {
+		tunedProfileName string
+		appliedStatus    corev1.ConditionStatus
+		expectedError    bool
+	}{
+		{
+			tunedProfileName: "openshift-node",
+			appliedStatus:    corev1.ConditionTrue,
+			expectedError:    false,
+		},
+		{
+			tunedProfileName: "openshift-node-performance",
+			appliedStatus:    corev1.ConditionTrue,
+			expectedError:    true,
+		},
+		{
+			tunedProfileName: "openshift-node",
+			appliedStatus:    corev1.ConditionFalse,
+			expectedError:    true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testSettings := clients.GetTestClients(clients.TestClientParams{
+			K8sMockObjects: []runtime.Object{buildDummyProfile(defaultProfileNodeName, "openshift-node",
+				testCase.appliedStatus, corev1.ConditionFalse, "")},
+		})
+
+		profileBuilder, err := PullProfile(testSettings, defaultProfileNodeName, defaultTunedNamespace)
+		assert.Nil(t, err)
+
+		err = profileBuilder.WaitUntilProfileApplied(testCase.tunedProfileName, time.Second)
+		assert.Equal(t, testCase.expectedError, err != nil)
+	}
+}
+
+func TestListTunedProfiles(t *testing.T) {
+	testCases := []struct {
+		profiles      []runtime.Object
+		nsname        string
+		expectedError error
+		expectedCount int
+	}{
+		{
+			profiles: []runtime.Object{
+				buildDummyProfile("worker-0", "openshift-node", corev1.ConditionTrue, corev1.ConditionFalse, ""),
+				buildDummyProfile("worker-1", "openshift-node", corev1.ConditionTrue, corev1.ConditionFalse, ""),
+			},
+			nsname:        defaultTunedNamespace,
+			expectedError: nil,
+			expectedCount: 2,
+		},
+		{
+			profiles:      []runtime.Object{},
+			nsname:        "",
+			expectedError: fmt.Errorf("failed to list tuned Profiles, 'nsname' parameter is empty"),
+			expectedCount: 0,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testSettings := clients.GetTestClients(clients.TestClientParams{K8sMockObjects: testCase.profiles})
+
+		profileBuilders, err := ListTunedProfiles(testSettings, testCase.nsname)
+		assert.Equal(t, testCase.expectedError, err)
+		assert.Len(t, profileBuilders, testCase.expectedCount)
+	}
+}
+
+func TestWaitForTunedProfileApplied(t *testing.T) {
+	testCases := []struct {
+		nodeNames     []string
+		expectedError bool
+	}{
+		{
+			nodeNames:     []string{"worker-0"},
+			expectedError: false,
+		},
+		{
+			nodeNames:     []string{"worker-0", "worker-1"},
+			expectedError: true,
+		},
+		{
+			nodeNames:     []string{"worker-0", "worker-2"},
+			expectedError: true,
+		},
+		{
+			nodeNames:     []string{},
+			expectedError: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testSettings := clients.GetTestClients(clients.TestClientParams{
+			K8sMockObjects: []runtime.Object{
+				buildDummyProfile("worker-0", "openshift-node", corev1.ConditionTrue, corev1.ConditionFalse, ""),
+				buildDummyProfile("worker-1", "openshift-node", corev1.ConditionTrue, corev1.ConditionTrue,
+					"TuneD daemon issued one or more error message(s)"),
+			},
+		})
+
+		err := WaitForTunedProfileApplied(
+			testSettings, "openshift-node", defaultTunedNamespace, testCase.nodeNames, time.Second)
+		assert.Equal(t, testCase.expectedError, err != nil)
+	}
+}
+
+func buildDummyProfile(
+	nodeName, tunedProfile string, applied, degraded corev1.ConditionStatus, message string) *tunedv1.Profile {
+	return &tunedv1.Profile{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      nodeName,
+			Namespace: defaultTunedNamespace,
+			Annotations: map[string]string{
+				tunedv1.TunedBootcmdlineAnnotationKey: "skew_tick=1 nohz=on",
+			},
+		},
+		Spec: tunedv1.ProfileSpec{
+			Config: tunedv1.ProfileConfig{TunedProfile: tunedProfile},
+			Profile: []tunedv1.TunedProfile{{
+				Name: &tunedProfile,
+				Data: &defaultTunedProfileStalld,
+			}},
+		},
+		Status: tunedv1.ProfileStatus{
+			TunedProfile: tunedProfile,
+			Conditions: []tunedv1.ProfileStatusCondition{
+				{
+					Type:    tunedv1.TunedProfileApplied,
+					Status:  applied,
+					Message: message,
+				},
+				{
+					Type:    tunedv1.TunedDegraded,
+					Status:  degraded,
+					Message: message,
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,152 @@
+package nto //nolint:misspell
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/openshift-kni/eco-goinfra/pkg/clients"
+	tunedv1 "github.com/openshift/cluster-node-tuning-operator/pkg/apis/tuned/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	goclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ListTunedProfiles returns a list of per-node tuned Profiles in the given namespace.
+func ListTunedProfiles(
+	apiClient *clients.Settings, nsname string, options ...goclient.ListOptions) ([]*ProfileBuilder, error) {
+	if apiClient == nil {
+		glog.V(100).Infof("The apiClient is empty")
+
+		return nil, fmt.Errorf("profile 'apiClient' cannot be empty")
+	}
+
+	if nsname == "" {
+		glog.V(100).Infof("The namespace of the tuned Profiles is empty")
+
+		return nil, fmt.Errorf("failed to list tuned Profiles, 'nsname' parameter is empty")
+	}
+
+	passedOptions := goclient.ListOptions{}
+	logMessage := fmt.Sprintf("Listing tuned Profiles in namespace %s", nsname)
+
+	if len(options) > 1 {
+		glog.V(100).Infof("'options' parameter must be empty or single-valued")
+
+		return nil, fmt.Errorf("error: more than one ListOptions was passed")
+	}
+
+	if len(options) == 1 {
+		passedOptions = options[0]
+		logMessage += fmt.Sprintf(" with the options %v", passedOptions)
+	}
+
+	passedOptions.Namespace = nsname
+
+	glog.V(100).Infof(logMessage)
+
+	var profiles tunedv1.ProfileList
+	err := apiClient.List(context.TODO(), &profiles, &passedOptions)
+
+	if err != nil {
+		glog.V(100).Infof("Failed to list tuned Profiles in namespace %s due to %s", nsname, err.Error())
+
+		return nil, err
+	}
+
+	var profileObjects []*ProfileBuilder
+
+	for _, profile := range profiles.Items {
+		copiedProfile := profile
+		profileBuilder := &ProfileBuilder{
+			apiClient:  apiClient.Client,
+			Object:     &copiedProfile,
+			Definition: &copiedProfile,
+		}
+
+		profileObjects = append(profileObjects, profileBuilder)
+	}
+
+	return profileObjects, nil
+}
+
+// WaitForTunedProfileApplied waits for timeout duration or until the given TuneD profile is applied on all
+// of the given nodes. On timeout the returned error lists every node that did not apply the profile together
+// with the tuned daemon errors reported for it.
+func WaitForTunedProfileApplied(
+	apiClient *clients.Settings, tunedProfileName, nsname string, nodeNames []string, timeout time.Duration) error {
+	if apiClient == nil {
+		glog.V(100).Infof("The apiClient is empty")
+
+		return fmt.Errorf("profile 'apiClient' cannot be empty")
+	}
+
+	if tunedProfileName == "" {
+		glog.V(100).Infof("The tunedProfileName is empty")
+
+		return fmt.Errorf("profile 'tunedProfileName' cannot be empty")
+	}
+
+	if nsname == "" {
+		glog.V(100).Infof("The namespace of the tuned Profiles is empty")
+
+		return fmt.Errorf("profile 'nsname' cannot be empty")
+	}
+
+	if len(nodeNames) == 0 {
+		glog.V(100).Infof("The nodeNames list is empty")
+
+		return fmt.Errorf("profile 'nodeNames' cannot be empty")
+	}
+
+	glog.V(100).Infof("Waiting until TuneD profile %s is applied on nodes %v", tunedProfileName, nodeNames)
+
+	pendingNodes := map[string]string{}
+
+	err := wait.PollUntilContextTimeout(
+		context.TODO(), time.Second, timeout, true, func(ctx context.Context) (bool, error) {
+			pendingNodes = map[string]string{}
+
+			for _, nodeName := range nodeNames {
+				profileBuilder := &ProfileBuilder{
+					apiClient: apiClient.Client,
+					Definition: &tunedv1.Profile{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      nodeName,
+							Namespace: nsname,
+						},
+					},
+				}
+
+				if !profileBuilder.Exists() || profileBuilder.Object == nil {
+					pendingNodes[nodeName] = describeProfileObject(nil)
+
+					continue
+				}
+
+				if profileBuilder.Object.Status.TunedProfile != tunedProfileName ||
+					!isProfileObjectApplied(profileBuilder.Object) {
+					pendingNodes[nodeName] = describeProfileObject(profileBuilder.Object)
+				}
+			}
+
+			return len(pendingNodes) == 0, nil
+		})
+
+	if err != nil {
+		var nodeErrors []string
+
+		for _, nodeName := range nodeNames {
+			if description, ok := pendingNodes[nodeName]; ok {
+				nodeErrors = append(nodeErrors, fmt.Sprintf("%s (%s)", nodeName, description))
+			}
+		}
+
+		return fmt.Errorf("TuneD profile %s was not applied on nodes: %s: %w",
+			tunedProfileName, strings.Join(nodeErrors, ", "), err)
+	}
+
+	return nil
+}
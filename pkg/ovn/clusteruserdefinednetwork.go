@@ -0,0 +1,532 @@
+package ovn
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/openshift-kni/eco-goinfra/pkg/clients"
+	"github.com/openshift-kni/eco-goinfra/pkg/msg"
+	"github.com/openshift-kni/eco-goinfra/pkg/schemes/ovn/ovntypes"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	goclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ClusterUserDefinedNetworkBuilder provides struct for ClusterUserDefinedNetwork object which contains connection
+// to cluster and ClusterUserDefinedNetwork definition.
+type ClusterUserDefinedNetworkBuilder struct {
+	// ClusterUserDefinedNetwork definition. Used to create ClusterUserDefinedNetwork object.
+	Definition *ovntypes.ClusterUserDefinedNetwork
+	// Created ClusterUserDefinedNetwork object.
+	Object *ovntypes.ClusterUserDefinedNetwork
+	// Used in functions that define or mutate ClusterUserDefinedNetwork definitions. errorMsg is processed before
+	// ClusterUserDefinedNetwork object is created.
+	errorMsg string
+	// apiClient opens api connection to the cluster.
+	apiClient *clients.Settings
+}
+
+// ClusterUserDefinedNetworkAdditionalOptions additional options for ClusterUserDefinedNetwork object.
+type ClusterUserDefinedNetworkAdditionalOptions func(
+	builder *ClusterUserDefinedNetworkBuilder) (*ClusterUserDefinedNetworkBuilder, error)
+
+// NewClusterUserDefinedNetworkBuilder creates new instance of ClusterUserDefinedNetworkBuilder making the network
+// available to the namespaces matching the selector. The topology must be set using WithLayer2, WithLayer3 or
+// WithLocalnet before the ClusterUserDefinedNetwork is created.
+func NewClusterUserDefinedNetworkBuilder(
+	apiClient *clients.Settings, name string, namespaceSelector metav1.LabelSelector) *ClusterUserDefinedNetworkBuilder {
+	glog.V(100).Infof("Initializing new ClusterUserDefinedNetwork structure with the name %s and namespace selector %v",
+		name, namespaceSelector)
+
+	builder := ClusterUserDefinedNetworkBuilder{
+		apiClient: apiClient,
+		Definition: &ovntypes.ClusterUserDefinedNetwork{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: name,
+			},
+			Spec: ovntypes.ClusterUserDefinedNetworkSpec{
+				NamespaceSelector: namespaceSelector,
+			},
+		},
+	}
+
+	if name == "" {
+		glog.V(100).Infof("The name of the ClusterUserDefinedNetwork is empty")
+
+		builder.errorMsg = "ClusterUserDefinedNetwork 'name' cannot be empty"
+
+		return &builder
+	}
+
+	if len(namespaceSelector.MatchLabels) == 0 && len(namespaceSelector.MatchExpressions) == 0 {
+		glog.V(100).Infof("The namespaceSelector of the ClusterUserDefinedNetwork is empty")
+
+		builder.errorMsg = "ClusterUserDefinedNetwork 'namespaceSelector' cannot be empty"
+
+		return &builder
+	}
+
+	return &builder
+}
+
+// WithLayer2 sets the ClusterUserDefinedNetwork topology to Layer2 with the given role and subnets, at most one per
+// IP family. Any previously configured topology is replaced.
+func (builder *ClusterUserDefinedNetworkBuilder) WithLayer2(
+	role ovntypes.NetworkRole, subnets ...string) *ClusterUserDefinedNetworkBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Setting Layer2 topology with role %s and subnets %v to ClusterUserDefinedNetwork %s",
+		role, subnets, builder.Definition.Name)
+
+	layer2, err := newLayer2Config(role, subnets)
+	if err != nil {
+		builder.errorMsg = fmt.Sprintf("ClusterUserDefinedNetwork %v", err)
+
+		return builder
+	}
+
+	builder.Definition.Spec.Network = ovntypes.NetworkSpec{
+		Topology: ovntypes.NetworkTopologyLayer2,
+		Layer2:   layer2,
+	}
+
+	return builder
+}
+
+// WithLayer3 sets the ClusterUserDefinedNetwork topology to Layer3 with the given role and subnets, at most one per
+// IP family. Any previously configured topology is replaced.
+func (builder *ClusterUserDefinedNetworkBuilder) WithLayer3(
+	role ovntypes.NetworkRole, subnets ...ovntypes.Layer3Subnet) *ClusterUserDefinedNetworkBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Setting Layer3 topology with role %s and subnets %v to ClusterUserDefinedNetwork %s",
+		role, subnets, builder.Definition.Name)
+
+	layer3, err := newLayer3Config(role, subnets)
+	if err != nil {
+		builder.errorMsg = fmt.Sprintf("ClusterUserDefinedNetwork %v", err)
+
+		return builder
+	}
+
+	builder.Definition.Spec.Network = ovntypes.NetworkSpec{
+		Topology: ovntypes.NetworkTopologyLayer3,
+		Layer3:   layer3,
+	}
+
+	return builder
+}
+
+// WithLocalnet sets the ClusterUserDefinedNetwork topology to a secondary Localnet network attached to the given
+// physical network, which must match a bridge mapping configured on the nodes. Any previously configured topology is
+// replaced.
+func (builder *ClusterUserDefinedNetworkBuilder) WithLocalnet(
+	physicalNetworkName string, subnets ...string) *ClusterUserDefinedNetworkBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Setting Localnet topology with physical network %s and subnets %v to ClusterUserDefinedNetwork %s",
+		physicalNetworkName, subnets, builder.Definition.Name)
+
+	if physicalNetworkName == "" {
+		glog.V(100).Infof("The physicalNetworkName of the ClusterUserDefinedNetwork is empty")
+
+		builder.errorMsg = "ClusterUserDefinedNetwork 'physicalNetworkName' cannot be empty"
+
+		return builder
+	}
+
+	dualStackSubnets, err := parseDualStackCIDRs("subnets", subnets)
+	if err != nil {
+		builder.errorMsg = fmt.Sprintf("ClusterUserDefinedNetwork %v", err)
+
+		return builder
+	}
+
+	builder.Definition.Spec.Network = ovntypes.NetworkSpec{
+		Topology: ovntypes.NetworkTopologyLocalnet,
+		Localnet: &ovntypes.LocalnetConfig{
+			Role:                ovntypes.NetworkRoleSecondary,
+			PhysicalNetworkName: physicalNetworkName,
+			Subnets:             dualStackSubnets,
+		},
+	}
+
+	return builder
+}
+
+// WithExcludeSubnets sets the CIDRs removed from the subnets of a Localnet ClusterUserDefinedNetwork, for example
+// addresses already used on the physical network.
+func (builder *ClusterUserDefinedNetworkBuilder) WithExcludeSubnets(
+	subnets ...string) *ClusterUserDefinedNetworkBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Setting exclude subnets %v to ClusterUserDefinedNetwork %s", subnets, builder.Definition.Name)
+
+	if builder.Definition.Spec.Network.Localnet == nil {
+		glog.V(100).Infof("The ClusterUserDefinedNetwork %s topology is not Localnet", builder.Definition.Name)
+
+		builder.errorMsg = "ClusterUserDefinedNetwork exclude subnets can only be set on a Localnet network"
+
+		return builder
+	}
+
+	var excludeSubnets []ovntypes.CIDR
+
+	for _, subnet := range subnets {
+		if _, _, err := net.ParseCIDR(subnet); err != nil {
+			glog.V(100).Infof("The exclude subnet %s is not a valid CIDR", subnet)
+
+			builder.errorMsg = fmt.Sprintf("ClusterUserDefinedNetwork excludeSubnets has invalid CIDR %s", subnet)
+
+			return builder
+		}
+
+		excludeSubnets = append(excludeSubnets, ovntypes.CIDR(subnet))
+	}
+
+	builder.Definition.Spec.Network.Localnet.ExcludeSubnets = excludeSubnets
+
+	return builder
+}
+
+// WithVLAN sets the access VLAN ID the traffic of a Localnet ClusterUserDefinedNetwork is tagged with.
+func (builder *ClusterUserDefinedNetworkBuilder) WithVLAN(vlanID int32) *ClusterUserDefinedNetworkBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Setting VLAN %d to ClusterUserDefinedNetwork %s", vlanID, builder.Definition.Name)
+
+	if builder.Definition.Spec.Network.Localnet == nil {
+		glog.V(100).Infof("The ClusterUserDefinedNetwork %s topology is not Localnet", builder.Definition.Name)
+
+		builder.errorMsg = "ClusterUserDefinedNetwork VLAN can only be set on a Localnet network"
+
+		return builder
+	}
+
+	if vlanID < 1 || vlanID > 4094 {
+		glog.V(100).Infof("The VLAN ID %d is out of range", vlanID)
+
+		builder.errorMsg = fmt.Sprintf("ClusterUserDefinedNetwork VLAN %d must be between 1 and 4094", vlanID)
+
+		return builder
+	}
+
+	builder.Definition.Spec.Network.Localnet.VLAN = &ovntypes.VLANConfig{
+		Mode:   ovntypes.VLANModeAccess,
+		Access: &ovntypes.AccessVLANConfig{ID: vlanID},
+	}
+
+	return builder
+}
+
+// WithMTU sets the MTU of the ClusterUserDefinedNetwork. The topology must be set first.
+func (builder *ClusterUserDefinedNetworkBuilder) WithMTU(mtu int32) *ClusterUserDefinedNetworkBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Setting MTU %d to ClusterUserDefinedNetwork %s", mtu, builder.Definition.Name)
+
+	if err := setNetworkMTU(&builder.Definition.Spec.Network, mtu); err != nil {
+		builder.errorMsg = fmt.Sprintf("ClusterUserDefinedNetwork %v", err)
+	}
+
+	return builder
+}
+
+// WithJoinSubnets sets the subnets used inside the OVN network topology, at most one per IP family. They can only be
+// set on Layer2 or Layer3 networks with the Primary role.
+func (builder *ClusterUserDefinedNetworkBuilder) WithJoinSubnets(subnets ...string) *ClusterUserDefinedNetworkBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Setting join subnets %v to ClusterUserDefinedNetwork %s", subnets, builder.Definition.Name)
+
+	if err := setNetworkJoinSubnets(&builder.Definition.Spec.Network, subnets); err != nil {
+		builder.errorMsg = fmt.Sprintf("ClusterUserDefinedNetwork %v", err)
+	}
+
+	return builder
+}
+
+// WithIPAM sets the IPAM mode and lifecycle of a Layer2 or Localnet ClusterUserDefinedNetwork. The lifecycle may be
+// left empty and can only be set when IPAM is enabled.
+func (builder *ClusterUserDefinedNetworkBuilder) WithIPAM(
+	mode ovntypes.IPAMMode, lifecycle ovntypes.NetworkIPAMLifecycle) *ClusterUserDefinedNetworkBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Setting IPAM mode %s and lifecycle %s to ClusterUserDefinedNetwork %s",
+		mode, lifecycle, builder.Definition.Name)
+
+	if err := setNetworkIPAM(&builder.Definition.Spec.Network, mode, lifecycle); err != nil {
+		builder.errorMsg = fmt.Sprintf("ClusterUserDefinedNetwork %v", err)
+	}
+
+	return builder
+}
+
+// WithOptions creates ClusterUserDefinedNetwork with generic mutation options.
+func (builder *ClusterUserDefinedNetworkBuilder) WithOptions(
+	options ...ClusterUserDefinedNetworkAdditionalOptions) *ClusterUserDefinedNetworkBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Setting ClusterUserDefinedNetwork additional options")
+
+	for _, option := range options {
+		if option != nil {
+			builder, err := option(builder)
+
+			if err != nil {
+				glog.V(100).Infof("Error occurred in mutation function")
+
+				builder.errorMsg = err.Error()
+
+				return builder
+			}
+		}
+	}
+
+	return builder
+}
+
+// PullClusterUserDefinedNetwork pulls existing ClusterUserDefinedNetwork from cluster.
+func PullClusterUserDefinedNetwork(
+	apiClient *clients.Settings, name string) (*ClusterUserDefinedNetworkBuilder, error) {
+	glog.V(100).Infof("Pulling existing ClusterUserDefinedNetwork name %s from cluster", name)
+
+	if apiClient == nil {
+		glog.V(100).Infof("The apiClient is empty")
+
+		return nil, fmt.Errorf("ClusterUserDefinedNetwork 'apiClient' cannot be empty")
+	}
+
+	builder := ClusterUserDefinedNetworkBuilder{
+		apiClient: apiClient,
+		Definition: &ovntypes.ClusterUserDefinedNetwork{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: name,
+			},
+		},
+	}
+
+	if name == "" {
+		glog.V(100).Infof("The name of the ClusterUserDefinedNetwork is empty")
+
+		return nil, fmt.Errorf("ClusterUserDefinedNetwork 'name' cannot be empty")
+	}
+
+	if !builder.Exists() {
+		return nil, fmt.Errorf("ClusterUserDefinedNetwork object %s does not exist", name)
+	}
+
+	builder.Definition = builder.Object
+
+	return &builder, nil
+}
+
+// Get returns ClusterUserDefinedNetwork object if found.
+func (builder *ClusterUserDefinedNetworkBuilder) Get() (*ovntypes.ClusterUserDefinedNetwork, error) {
+	if valid, err := builder.validate(); !valid {
+		return nil, err
+	}
+
+	glog.V(100).Infof("Collecting ClusterUserDefinedNetwork object %s", builder.Definition.Name)
+
+	clusterUserDefinedNetwork := &ovntypes.ClusterUserDefinedNetwork{}
+	err := builder.apiClient.Get(context.TODO(),
+		goclient.ObjectKey{Name: builder.Definition.Name}, clusterUserDefinedNetwork)
+
+	if err != nil {
+		glog.V(100).Infof("Failed to get ClusterUserDefinedNetwork %s", builder.Definition.Name)
+
+		return nil, err
+	}
+
+	return clusterUserDefinedNetwork, nil
+}
+
+// Exists checks whether the given ClusterUserDefinedNetwork object exists in a cluster.
+func (builder *ClusterUserDefinedNetworkBuilder) Exists() bool {
+	if valid, _ := builder.validate(); !valid {
+		return false
+	}
+
+	glog.V(100).Infof("Checking if ClusterUserDefinedNetwork %s exists", builder.Definition.Name)
+
+	var err error
+	builder.Object, err = builder.Get()
+
+	return err == nil || !k8serrors.IsNotFound(err)
+}
+
+// Create generates ClusterUserDefinedNetwork in a cluster and stores the created object in struct.
+func (builder *ClusterUserDefinedNetworkBuilder) Create() (*ClusterUserDefinedNetworkBuilder, error) {
+	if valid, err := builder.validate(); !valid {
+		return builder, err
+	}
+
+	glog.V(100).Infof("Creating the ClusterUserDefinedNetwork %s", builder.Definition.Name)
+
+	if !builder.Exists() {
+		err := builder.apiClient.Create(context.TODO(), builder.Definition)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	builder.Object = builder.Definition
+
+	return builder, nil
+}
+
+// Delete removes ClusterUserDefinedNetwork object.
+func (builder *ClusterUserDefinedNetworkBuilder) Delete() error {
+	if valid, err := builder.validate(); !valid {
+		return err
+	}
+
+	glog.V(100).Infof("Deleting the ClusterUserDefinedNetwork object %s", builder.Definition.Name)
+
+	if !builder.Exists() {
+		return nil
+	}
+
+	err := builder.apiClient.Delete(context.TODO(), builder.Object)
+
+	if err != nil {
+		return err
+	}
+
+	builder.Object = nil
+
+	return nil
+}
+
+// Update renovates the existing ClusterUserDefinedNetwork object with the ClusterUserDefinedNetwork definition in
+// builder. Since the network spec is immutable, changing it requires force to delete and recreate the object.
+func (builder *ClusterUserDefinedNetworkBuilder) Update(force bool) (*ClusterUserDefinedNetworkBuilder, error) {
+	if valid, err := builder.validate(); !valid {
+		return builder, err
+	}
+
+	glog.V(100).Infof("Updating the ClusterUserDefinedNetwork object %s", builder.Definition.Name)
+
+	if !builder.Exists() {
+		glog.V(100).Infof("ClusterUserDefinedNetwork %s does not exist", builder.Definition.Name)
+
+		return nil, fmt.Errorf("cannot update non-existent ClusterUserDefinedNetwork")
+	}
+
+	builder.Definition.ResourceVersion = builder.Object.ResourceVersion
+	err := builder.apiClient.Update(context.TODO(), builder.Definition)
+
+	if err != nil {
+		if force {
+			glog.V(100).Infof(
+				msg.FailToUpdateNotification("ClusterUserDefinedNetwork", builder.Definition.Name))
+
+			err = builder.Delete()
+			if err == nil {
+				err = waitForNetworkDeletion(builder.Exists)
+			}
+
+			if err != nil {
+				glog.V(100).Infof(
+					msg.FailToUpdateError("ClusterUserDefinedNetwork", builder.Definition.Name))
+
+				return nil, err
+			}
+
+			builder.Definition.ResourceVersion = ""
+
+			return builder.Create()
+		}
+
+		return nil, err
+	}
+
+	builder.Object = builder.Definition
+
+	return builder, nil
+}
+
+// WaitUntilConditionTrue waits for the duration of the defined timeout until the ClusterUserDefinedNetwork reports
+// the given condition type with status True.
+func (builder *ClusterUserDefinedNetworkBuilder) WaitUntilConditionTrue(
+	conditionType string, timeout time.Duration) error {
+	if valid, err := builder.validate(); !valid {
+		return err
+	}
+
+	glog.V(100).Infof("Waiting until ClusterUserDefinedNetwork %s has condition %s True",
+		builder.Definition.Name, conditionType)
+
+	return wait.PollUntilContextTimeout(
+		context.TODO(), 3*time.Second, timeout, true, func(ctx context.Context) (bool, error) {
+			if !builder.Exists() {
+				glog.V(100).Infof("ClusterUserDefinedNetwork %s does not exist yet", builder.Definition.Name)
+
+				return false, nil
+			}
+
+			return isNetworkConditionTrue(builder.Object.Status.Conditions, conditionType), nil
+		})
+}
+
+// WaitUntilNetworkCreated waits for the duration of the defined timeout until the ClusterUserDefinedNetwork reports
+// its network as created in every selected namespace.
+func (builder *ClusterUserDefinedNetworkBuilder) WaitUntilNetworkCreated(timeout time.Duration) error {
+	return builder.WaitUntilConditionTrue(NetworkCreatedCondition, timeout)
+}
+
+// validate will check that the builder and builder definition are properly initialized before
+// accessing any member fields.
+func (builder *ClusterUserDefinedNetworkBuilder) validate() (bool, error) {
+	resourceCRD := "ClusterUserDefinedNetwork"
+
+	if builder == nil {
+		glog.V(100).Infof("The %s builder is uninitialized", resourceCRD)
+
+		return false, fmt.Errorf("error: received nil %s builder", resourceCRD)
+	}
+
+	if builder.Definition == nil {
+		glog.V(100).Infof("The %s is undefined", resourceCRD)
+
+		builder.errorMsg = msg.UndefinedCrdObjectErrString(resourceCRD)
+	}
+
+	if builder.apiClient == nil {
+		glog.V(100).Infof("The %s builder apiclient is nil", resourceCRD)
+
+		builder.errorMsg = fmt.Sprintf("%s builder cannot have nil apiClient", resourceCRD)
+	}
+
+	if builder.errorMsg != "" {
+		glog.V(100).Infof("The %s builder has error message: %s", resourceCRD, builder.errorMsg)
+
+		return false, fmt.Errorf(builder.errorMsg)
+	}
+
+	return true, nil
+}
@@ -0,0 +1,321 @@
+package ovn
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/openshift-kni/eco-goinfra/pkg/clients"
+	"github.com/openshift-kni/eco-goinfra/pkg/schemes/ovn/ovntypes"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const defaultCUDNName = "cudn"
+
+var defaultCUDNNamespaceSelector = metav1.LabelSelector{MatchLabels: map[string]string{"udn": "enabled"}}
+
+func TestNewClusterUserDefinedNetworkBuilder(t *testing.T) {
+	testCases := []struct {
+		name              string
+		namespaceSelector metav1.LabelSelector
+		expectedError     string
+	}{
+		{
+			name:              defaultCUDNName,
+			namespaceSelector: defaultCUDNNamespaceSelector,
+			expectedError:     "",
+		},
+		{
+			name:              "",
+			namespaceSelector: defaultCUDNNamespaceSelector,
+			expectedError:     "ClusterUserDefinedNetwork 'name' cannot be empty",
+		},
+		{
+			name:              defaultCUDNName,
+			namespaceSelector: metav1.LabelSelector{},
+			expectedError:     "ClusterUserDefinedNetwork 'namespaceSelector' cannot be empty",
+		},
+	}
+
+	for _, testCase := range testCases {
+		testBuilder := NewClusterUserDefinedNetworkBuilder(
+			clients.GetTestClients(clients.TestClientParams{}), testCase.name, testCase.namespaceSelector)
+		assert.Equal(t, testCase.expectedError, testBuilder.errorMsg)
+
+		if testCase.expectedError == "" {
+			assert.Equal(t, testCase.namespaceSelector, testBuilder.Definition.Spec.NamespaceSelector)
+		}
+	}
+}
+
+func TestPullClusterUserDefinedNetwork(t *testing.T) {
+	testCases := []struct {
+		name                string
+		addToRuntimeObjects bool
+		client              bool
+		expectedError       error
+	}{
+		{
+			name:                defaultCUDNName,
+			addToRuntimeObjects: true,
+			client:              true,
+			expectedError:       nil,
+		},
+		{
+			name:                "",
+			addToRuntimeObjects: true,
+			client:              true,
+			expectedError:       fmt.Errorf("ClusterUserDefinedNetwork 'name' cannot be empty"),
+		},
+		{
+			name:                defaultCUDNName,
+			addToRuntimeObjects: false,
+			client:              true,
+			expectedError:       fmt.Errorf("ClusterUserDefinedNetwork object %s does not exist", defaultCUDNName),
+		},
+		{
+			name:                defaultCUDNName,
+			addToRuntimeObjects: true,
+			client:              false,
+			expectedError:       fmt.Errorf("ClusterUserDefinedNetwork 'apiClient' cannot be empty"),
+		},
+	}
+
+	for _, testCase := range testCases {
+		var (
+			runtimeObjects []runtime.Object
+			testSettings   *clients.Settings
+		)
+
+		if testCase.addToRuntimeObjects {
+			runtimeObjects = append(runtimeObjects, buildDummyClusterUserDefinedNetwork(metav1.ConditionTrue))
+		}
+
+		if testCase.client {
+			testSettings = clients.GetTestClients(clients.TestClientParams{K8sMockObjects: runtimeObjects})
+		}
+
+		testBuilder, err := PullClusterUserDefinedNetwork(testSettings, testCase.name)
+		assert.Equal(t, testCase.expectedError, err)
+
+		if testCase.expectedError == nil {
+			assert.Equal(t, ovntypes.NetworkTopologyLocalnet, testBuilder.Definition.Spec.Network.Topology)
+		}
+	}
+}
+
+func TestClusterUserDefinedNetworkWithLocalnet(t *testing.T) {
+	testBuilder := buildValidClusterUserDefinedNetworkTestBuilder(clients.GetTestClients(clients.TestClientParams{})).
+		WithLocalnet("physnet", "192.168.100.0/24").
+		WithExcludeSubnets("192.168.100.1/32").
+		WithVLAN(100).
+		WithMTU(1500).
+		WithIPAM(ovntypes.IPAMEnabled, ovntypes.IPAMLifecyclePersistent)
+	assert.Equal(t, "", testBuilder.errorMsg)
+	assert.Equal(t, ovntypes.NetworkSpec{
+		Topology: ovntypes.NetworkTopologyLocalnet,
+		Localnet: &ovntypes.LocalnetConfig{
+			Role:                ovntypes.NetworkRoleSecondary,
+			PhysicalNetworkName: "physnet",
+			Subnets:             ovntypes.DualStackCIDRs{"192.168.100.0/24"},
+			ExcludeSubnets:      []ovntypes.CIDR{"192.168.100.1/32"},
+			IPAM: &ovntypes.IPAMConfig{
+				Mode:      ovntypes.IPAMEnabled,
+				Lifecycle: ovntypes.IPAMLifecyclePersistent,
+			},
+			MTU: 1500,
+			VLAN: &ovntypes.VLANConfig{
+				Mode:   ovntypes.VLANModeAccess,
+				Access: &ovntypes.AccessVLANConfig{ID: 100},
+			},
+		},
+	}, testBuilder.Definition.Spec.Network)
+}
+
+func TestClusterUserDefinedNetworkWithLayer2AndLayer3(t *testing.T) {
+	testBuilder := buildValidClusterUserDefinedNetworkTestBuilder(clients.GetTestClients(clients.TestClientParams{})).
+		WithLayer2(ovntypes.NetworkRolePrimary, "10.100.0.0/16").
+		WithJoinSubnets("100.65.0.0/16")
+	assert.Equal(t, "", testBuilder.errorMsg)
+	assert.Equal(t, ovntypes.NetworkSpec{
+		Topology: ovntypes.NetworkTopologyLayer2,
+		Layer2: &ovntypes.Layer2Config{
+			Role:        ovntypes.NetworkRolePrimary,
+			Subnets:     ovntypes.DualStackCIDRs{"10.100.0.0/16"},
+			JoinSubnets: ovntypes.DualStackCIDRs{"100.65.0.0/16"},
+		},
+	}, testBuilder.Definition.Spec.Network)
+
+	subnet := ovntypes.Layer3Subnet{CIDR: "fd00:10:200::/48", HostSubnet: 64}
+
+	testBuilder = testBuilder.WithLayer3(ovntypes.NetworkRoleSecondary, subnet)
+	assert.Equal(t, "", testBuilder.errorMsg)
+	assert.Equal(t, ovntypes.NetworkSpec{
+		Topology: ovntypes.NetworkTopologyLayer3,
+		Layer3: &ovntypes.Layer3Config{
+			Role:    ovntypes.NetworkRoleSecondary,
+			Subnets: []ovntypes.Layer3Subnet{subnet},
+		},
+	}, testBuilder.Definition.Spec.Network)
+}
+
+func TestClusterUserDefinedNetworkWithOptionsErrors(t *testing.T) {
+	testCases := []struct {
+		mutate        func(builder *ClusterUserDefinedNetworkBuilder) *ClusterUserDefinedNetworkBuilder
+		expectedError string
+	}{
+		{
+			mutate: func(builder *ClusterUserDefinedNetworkBuilder) *ClusterUserDefinedNetworkBuilder {
+				return builder.WithLocalnet("")
+			},
+			expectedError: "ClusterUserDefinedNetwork 'physicalNetworkName' cannot be empty",
+		},
+		{
+			mutate: func(builder *ClusterUserDefinedNetworkBuilder) *ClusterUserDefinedNetworkBuilder {
+				return builder.WithLocalnet("physnet", "2001:db8::/64", "2001:db9::/64")
+			},
+			expectedError: "ClusterUserDefinedNetwork subnets can contain at most one CIDR per IP family",
+		},
+		{
+			mutate: func(builder *ClusterUserDefinedNetworkBuilder) *ClusterUserDefinedNetworkBuilder {
+				return builder.WithLayer2(ovntypes.NetworkRoleSecondary).WithExcludeSubnets("10.0.0.1/32")
+			},
+			expectedError: "ClusterUserDefinedNetwork exclude subnets can only be set on a Localnet network",
+		},
+		{
+			mutate: func(builder *ClusterUserDefinedNetworkBuilder) *ClusterUserDefinedNetworkBuilder {
+				return builder.WithLocalnet("physnet").WithExcludeSubnets("10.0.0.1")
+			},
+			expectedError: "ClusterUserDefinedNetwork excludeSubnets has invalid CIDR 10.0.0.1",
+		},
+		{
+			mutate: func(builder *ClusterUserDefinedNetworkBuilder) *ClusterUserDefinedNetworkBuilder {
+				return builder.WithLayer2(ovntypes.NetworkRoleSecondary).WithVLAN(100)
+			},
+			expectedError: "ClusterUserDefinedNetwork VLAN can only be set on a Localnet network",
+		},
+		{
+			mutate: func(builder *ClusterUserDefinedNetworkBuilder) *ClusterUserDefinedNetworkBuilder {
+				return builder.WithLocalnet("physnet").WithVLAN(4095)
+			},
+			expectedError: "ClusterUserDefinedNetwork VLAN 4095 must be between 1 and 4094",
+		},
+		{
+			mutate: func(builder *ClusterUserDefinedNetworkBuilder) *ClusterUserDefinedNetworkBuilder {
+				return builder.WithLocalnet("physnet").WithJoinSubnets("100.65.0.0/16")
+			},
+			expectedError: "ClusterUserDefinedNetwork join subnets can only be set on a Layer2 or Layer3 network " +
+				"with the Primary role",
+		},
+		{
+			mutate: func(builder *ClusterUserDefinedNetworkBuilder) *ClusterUserDefinedNetworkBuilder {
+				return builder.WithLocalnet("physnet").WithIPAM("Static", "")
+			},
+			expectedError: "ClusterUserDefinedNetwork IPAM mode Static is invalid, must be one of Enabled or Disabled",
+		},
+		{
+			mutate: func(builder *ClusterUserDefinedNetworkBuilder) *ClusterUserDefinedNetworkBuilder {
+				return builder.WithOptions(
+					func(builder *ClusterUserDefinedNetworkBuilder) (*ClusterUserDefinedNetworkBuilder, error) {
+						return builder, fmt.Errorf("error adding additional option")
+					})
+			},
+			expectedError: "error adding additional option",
+		},
+	}
+
+	for _, testCase := range testCases {
+		testBuilder := testCase.mutate(
+			buildValidClusterUserDefinedNetworkTestBuilder(clients.GetTestClients(clients.TestClientParams{})))
+		assert.Equal(t, testCase.expectedError, testBuilder.errorMsg)
+	}
+}
+
+func TestClusterUserDefinedNetworkCreateUpdateAndDelete(t *testing.T) {
+	testSettings := clients.GetTestClients(clients.TestClientParams{})
+
+	testBuilder, err := buildValidClusterUserDefinedNetworkTestBuilder(testSettings).
+		WithLocalnet("physnet", "192.168.100.0/24").
+		Create()
+	assert.Nil(t, err)
+	assert.True(t, testBuilder.Exists())
+
+	namespaceSelector := metav1.LabelSelector{MatchLabels: map[string]string{"udn": "shared"}}
+	testBuilder.Definition.Spec.NamespaceSelector = namespaceSelector
+	testBuilder, err = testBuilder.Update(false)
+	assert.Nil(t, err)
+	assert.Equal(t, namespaceSelector, testBuilder.Object.Spec.NamespaceSelector)
+
+	err = testBuilder.Delete()
+	assert.Nil(t, err)
+	assert.Nil(t, testBuilder.Object)
+	assert.False(t, testBuilder.Exists())
+
+	_, err = buildValidClusterUserDefinedNetworkTestBuilder(testSettings).Update(false)
+	assert.Equal(t, fmt.Errorf("cannot update non-existent ClusterUserDefinedNetwork"), err)
+}
+
+func TestClusterUserDefinedNetworkForceUpdate(t *testing.T) {
+	testSettings := clients.GetTestClients(clients.TestClientParams{})
+
+	testBuilder, err := buildValidClusterUserDefinedNetworkTestBuilder(testSettings).
+		WithLayer2(ovntypes.NetworkRoleSecondary, "10.100.0.0/16").
+		Create()
+	assert.Nil(t, err)
+
+	rejectNetworkUpdates(testSettings)
+
+	_, err = testBuilder.WithMTU(1400).Update(false)
+	assert.Equal(t, errImmutableNetworkSpec, err)
+
+	testBuilder, err = testBuilder.Update(true)
+	assert.Nil(t, err)
+	assert.Equal(t, int32(1400), testBuilder.Object.Spec.Network.Layer2.MTU)
+
+	pulledBuilder, err := PullClusterUserDefinedNetwork(testSettings, testBuilder.Definition.Name)
+	assert.Nil(t, err)
+	assert.Equal(t, int32(1400), pulledBuilder.Object.Spec.Network.Layer2.MTU)
+}
+
+func TestClusterUserDefinedNetworkWaitUntilNetworkCreated(t *testing.T) {
+	testSettings := clients.GetTestClients(clients.TestClientParams{
+		K8sMockObjects: []runtime.Object{buildDummyClusterUserDefinedNetwork(metav1.ConditionTrue)},
+	})
+
+	err := buildValidClusterUserDefinedNetworkTestBuilder(testSettings).WaitUntilNetworkCreated(time.Second)
+	assert.Nil(t, err)
+
+	testSettings = clients.GetTestClients(clients.TestClientParams{
+		K8sMockObjects: []runtime.Object{buildDummyClusterUserDefinedNetwork(metav1.ConditionFalse)},
+	})
+
+	err = buildValidClusterUserDefinedNetworkTestBuilder(testSettings).WaitUntilNetworkCreated(time.Second)
+	assert.NotNil(t, err)
+}
+
+func buildValidClusterUserDefinedNetworkTestBuilder(apiClient *clients.Settings) *ClusterUserDefinedNetworkBuilder {
+	return NewClusterUserDefinedNetworkBuilder(apiClient, defaultCUDNName, defaultCUDNNamespaceSelector)
+}
+
+func buildDummyClusterUserDefinedNetwork(status metav1.ConditionStatus) *ovntypes.ClusterUserDefinedNetwork {
+	return &ovntypes.ClusterUserDefinedNetwork{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: defaultCUDNName,
+		},
+		Spec: ovntypes.ClusterUserDefinedNetworkSpec{
+			NamespaceSelector: defaultCUDNNamespaceSelector,
+			Network: ovntypes.NetworkSpec{
+				Topology: ovntypes.NetworkTopologyLocalnet,
+				Localnet: &ovntypes.LocalnetConfig{
+					Role:                ovntypes.NetworkRoleSecondary,
+					PhysicalNetworkName: "physnet",
+				},
+			},
+		},
+		Status: ovntypes.ClusterUserDefinedNetworkStatus{
+			Conditions: []metav1.Condition{{Type: NetworkCreatedCondition, Status: status}},
+		},
+	}
+}
@@ -0,0 +1,426 @@
+package ovn
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/openshift-kni/eco-goinfra/pkg/clients"
+	"github.com/openshift-kni/eco-goinfra/pkg/msg"
+	"github.com/openshift-kni/eco-goinfra/pkg/schemes/ovn/ovntypes"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	goclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// EgressFirewallName is the only name OVN-Kubernetes accepts for an EgressFirewall.
+	EgressFirewallName = "default"
+	// EgressFirewallStatusApplied is the EgressFirewall status once its rules are applied on every node.
+	EgressFirewallStatusApplied = "EgressFirewall Rules applied"
+	// EgressFirewallStatusFailed is the EgressFirewall status when its rules failed to be applied.
+	EgressFirewallStatusFailed = "EgressFirewall Rules not correctly applied"
+)
+
+// EgressFirewallBuilder provides struct for EgressFirewall object which contains connection to cluster and
+// EgressFirewall definition.
+type EgressFirewallBuilder struct {
+	// EgressFirewall definition. Used to create EgressFirewall object.
+	Definition *ovntypes.EgressFirewall
+	// Created EgressFirewall object.
+	Object *ovntypes.EgressFirewall
+	// Used in functions that define or mutate EgressFirewall definitions. errorMsg is processed before
+	// EgressFirewall object is created.
+	errorMsg string
+	// apiClient opens api connection to the cluster.
+	apiClient *clients.Settings
+}
+
+// EgressFirewallAdditionalOptions additional options for EgressFirewall object.
+type EgressFirewallAdditionalOptions func(builder *EgressFirewallBuilder) (*EgressFirewallBuilder, error)
+
+// NewEgressFirewallBuilder creates new instance of EgressFirewallBuilder in the given namespace. Rules are evaluated
+// in the order they are added, traffic not matching any rule is allowed.
+func NewEgressFirewallBuilder(apiClient *clients.Settings, nsname string) *EgressFirewallBuilder {
+	glog.V(100).Infof("Initializing new EgressFirewall structure in the namespace %s", nsname)
+
+	builder := EgressFirewallBuilder{
+		apiClient: apiClient,
+		Definition: &ovntypes.EgressFirewall{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      EgressFirewallName,
+				Namespace: nsname,
+			},
+			Spec: ovntypes.EgressFirewallSpec{
+				Egress: []ovntypes.EgressFirewallRule{},
+			},
+		},
+	}
+
+	if nsname == "" {
+		glog.V(100).Infof("The namespace of the EgressFirewall is empty")
+
+		builder.errorMsg = "EgressFirewall 'nsname' cannot be empty"
+	}
+
+	return &builder
+}
+
+// WithCIDRRule adds a rule allowing or denying the egress traffic to the given CIDR. When ports are given, the rule
+// only matches the traffic to those ports.
+func (builder *EgressFirewallBuilder) WithCIDRRule(
+	ruleType ovntypes.EgressFirewallRuleType,
+	cidr string,
+	ports ...ovntypes.EgressFirewallPort) *EgressFirewallBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Adding %s rule for CIDR %s to EgressFirewall in namespace %s",
+		ruleType, cidr, builder.Definition.Namespace)
+
+	if _, _, err := net.ParseCIDR(cidr); err != nil {
+		glog.V(100).Infof("The EgressFirewall CIDR %s is invalid", cidr)
+
+		builder.errorMsg = fmt.Sprintf("EgressFirewall rule has invalid CIDR %s", cidr)
+
+		return builder
+	}
+
+	return builder.withRule(ruleType, ovntypes.EgressFirewallDestination{CIDRSelector: cidr}, ports)
+}
+
+// WithDNSNameRule adds a rule allowing or denying the egress traffic to the given DNS name, which may start with a
+// "*." wildcard. When ports are given, the rule only matches the traffic to those ports.
+func (builder *EgressFirewallBuilder) WithDNSNameRule(
+	ruleType ovntypes.EgressFirewallRuleType,
+	dnsName string,
+	ports ...ovntypes.EgressFirewallPort) *EgressFirewallBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Adding %s rule for DNS name %s to EgressFirewall in namespace %s",
+		ruleType, dnsName, builder.Definition.Namespace)
+
+	if dnsName == "" {
+		glog.V(100).Infof("The EgressFirewall DNS name is empty")
+
+		builder.errorMsg = "EgressFirewall rule 'dnsName' cannot be empty"
+
+		return builder
+	}
+
+	return builder.withRule(ruleType, ovntypes.EgressFirewallDestination{DNSName: dnsName}, ports)
+}
+
+// WithNodeSelectorRule adds a rule allowing or denying the egress traffic to the node IPs of the nodes matching the
+// given selector. When ports are given, the rule only matches the traffic to those ports.
+func (builder *EgressFirewallBuilder) WithNodeSelectorRule(
+	ruleType ovntypes.EgressFirewallRuleType,
+	nodeSelector metav1.LabelSelector,
+	ports ...ovntypes.EgressFirewallPort) *EgressFirewallBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Adding %s rule for node selector %v to EgressFirewall in namespace %s",
+		ruleType, nodeSelector, builder.Definition.Namespace)
+
+	return builder.withRule(ruleType, ovntypes.EgressFirewallDestination{NodeSelector: &nodeSelector}, ports)
+}
+
+// WithOptions creates EgressFirewall with generic mutation options.
+func (builder *EgressFirewallBuilder) WithOptions(
+	options ...EgressFirewallAdditionalOptions) *EgressFirewallBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Setting EgressFirewall additional options")
+
+	for _, option := range options {
+		if option != nil {
+			builder, err := option(builder)
+
+			if err != nil {
+				glog.V(100).Infof("Error occurred in mutation function")
+
+				builder.errorMsg = err.Error()
+
+				return builder
+			}
+		}
+	}
+
+	return builder
+}
+
+// PullEgressFirewall pulls existing EgressFirewall of the given namespace from cluster.
+func PullEgressFirewall(apiClient *clients.Settings, nsname string) (*EgressFirewallBuilder, error) {
+	glog.V(100).Infof("Pulling existing EgressFirewall under namespace %s from cluster", nsname)
+
+	if apiClient == nil {
+		glog.V(100).Infof("The apiClient is empty")
+
+		return nil, fmt.Errorf("EgressFirewall 'apiClient' cannot be empty")
+	}
+
+	builder := EgressFirewallBuilder{
+		apiClient: apiClient,
+		Definition: &ovntypes.EgressFirewall{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      EgressFirewallName,
+				Namespace: nsname,
+			},
+		},
+	}
+
+	if nsname == "" {
+		glog.V(100).Infof("The namespace of the EgressFirewall is empty")
+
+		return nil, fmt.Errorf("EgressFirewall 'namespace' cannot be empty")
+	}
+
+	if !builder.Exists() {
+		return nil, fmt.Errorf("EgressFirewall object %s does not exist in namespace %s", EgressFirewallName, nsname)
+	}
+
+	builder.Definition = builder.Object
+
+	return &builder, nil
+}
+
+// Get returns EgressFirewall object if found.
+func (builder *EgressFirewallBuilder) Get() (*ovntypes.EgressFirewall, error) {
+	if valid, err := builder.validate(); !valid {
+		return nil, err
+	}
+
+	glog.V(100).Infof("Collecting EgressFirewall object %s in namespace %s",
+		builder.Definition.Name, builder.Definition.Namespace)
+
+	egressFirewall := &ovntypes.EgressFirewall{}
+	err := builder.apiClient.Get(context.TODO(), goclient.ObjectKey{
+		Name:      builder.Definition.Name,
+		Namespace: builder.Definition.Namespace,
+	}, egressFirewall)
+
+	if err != nil {
+		glog.V(100).Infof("Failed to get EgressFirewall %s in namespace %s",
+			builder.Definition.Name, builder.Definition.Namespace)
+
+		return nil, err
+	}
+
+	return egressFirewall, nil
+}
+
+// Exists checks whether the given EgressFirewall object exists in a cluster.
+func (builder *EgressFirewallBuilder) Exists() bool {
+	if valid, _ := builder.validate(); !valid {
+		return false
+	}
+
+	glog.V(100).Infof("Checking if EgressFirewall %s exists in namespace %s",
+		builder.Definition.Name, builder.Definition.Namespace)
+
+	var err error
+	builder.Object, err = builder.Get()
+
+	return err == nil || !k8serrors.IsNotFound(err)
+}
+
+// Create generates EgressFirewall in a cluster and stores the created object in struct.
+func (builder *EgressFirewallBuilder) Create() (*EgressFirewallBuilder, error) {
+	if valid, err := builder.validate(); !valid {
+		return builder, err
+	}
+
+	glog.V(100).Infof("Creating the EgressFirewall %s in namespace %s",
+		builder.Definition.Name, builder.Definition.Namespace)
+
+	if !builder.Exists() {
+		err := builder.apiClient.Create(context.TODO(), builder.Definition)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	builder.Object = builder.Definition
+
+	return builder, nil
+}
+
+// Delete removes EgressFirewall object.
+func (builder *EgressFirewallBuilder) Delete() error {
+	if valid, err := builder.validate(); !valid {
+		return err
+	}
+
+	glog.V(100).Infof("Deleting the EgressFirewall object %s in namespace %s",
+		builder.Definition.Name, builder.Definition.Namespace)
+
+	if !builder.Exists() {
+		return nil
+	}
+
+	err := builder.apiClient.Delete(context.TODO(), builder.Object)
+
+	if err != nil {
+		return err
+	}
+
+	builder.Object = nil
+
+	return nil
+}
+
+// Update renovates the existing EgressFirewall object with the EgressFirewall definition in builder.
+func (builder *EgressFirewallBuilder) Update(force bool) (*EgressFirewallBuilder, error) {
+	if valid, err := builder.validate(); !valid {
+		return builder, err
+	}
+
+	glog.V(100).Infof("Updating the EgressFirewall object %s in namespace %s",
+		builder.Definition.Name, builder.Definition.Namespace)
+
+	err := builder.apiClient.Update(context.TODO(), builder.Definition)
+
+	if err != nil {
+		if force {
+			glog.V(100).Infof(
+				msg.FailToUpdateNotification("EgressFirewall", builder.Definition.Name, builder.Definition.Namespace))
+
+			err = builder.Delete()
+
+			if err != nil {
+				glog.V(100).Infof(
+					msg.FailToUpdateError("EgressFirewall", builder.Definition.Name, builder.Definition.Namespace))
+
+				return nil, err
+			}
+
+			return builder.Create()
+		}
+
+		return nil, err
+	}
+
+	builder.Object = builder.Definition
+
+	return builder, nil
+}
+
+// WaitUntilApplied waits for the duration of the defined timeout until the EgressFirewall rules are applied. An
+// error is returned as soon as OVN-Kubernetes reports that the rules could not be applied.
+func (builder *EgressFirewallBuilder) WaitUntilApplied(timeout time.Duration) error {
+	if valid, err := builder.validate(); !valid {
+		return err
+	}
+
+	glog.V(100).Infof("Waiting until EgressFirewall %s in namespace %s is applied",
+		builder.Definition.Name, builder.Definition.Namespace)
+
+	return wait.PollUntilContextTimeout(
+		context.TODO(), 3*time.Second, timeout, true, func(ctx context.Context) (bool, error) {
+			if !builder.Exists() {
+				glog.V(100).Infof("EgressFirewall %s in namespace %s does not exist yet",
+					builder.Definition.Name, builder.Definition.Namespace)
+
+				return false, nil
+			}
+
+			switch builder.Object.Status.Status {
+			case EgressFirewallStatusApplied:
+				return true, nil
+			case EgressFirewallStatusFailed:
+				return false, fmt.Errorf("EgressFirewall in namespace %s failed to apply: %s",
+					builder.Definition.Namespace, strings.Join(builder.Object.Status.Messages, "; "))
+			default:
+				return false, nil
+			}
+		})
+}
+
+// withRule validates and appends a rule with the given type, destination and ports to the definition.
+func (builder *EgressFirewallBuilder) withRule(
+	ruleType ovntypes.EgressFirewallRuleType,
+	destination ovntypes.EgressFirewallDestination,
+	ports []ovntypes.EgressFirewallPort) *EgressFirewallBuilder {
+	if ruleType != ovntypes.EgressFirewallRuleAllow && ruleType != ovntypes.EgressFirewallRuleDeny {
+		glog.V(100).Infof("The EgressFirewall rule type %s is invalid", ruleType)
+
+		builder.errorMsg = fmt.Sprintf("EgressFirewall rule type %s is invalid, must be one of %s or %s",
+			ruleType, ovntypes.EgressFirewallRuleAllow, ovntypes.EgressFirewallRuleDeny)
+
+		return builder
+	}
+
+	for _, port := range ports {
+		if port.Port < 1 || port.Port > 65535 {
+			glog.V(100).Infof("The EgressFirewall port %d is invalid", port.Port)
+
+			builder.errorMsg = fmt.Sprintf("EgressFirewall rule port %d must be between 1 and 65535", port.Port)
+
+			return builder
+		}
+
+		switch corev1.Protocol(port.Protocol) {
+		case corev1.ProtocolTCP, corev1.ProtocolUDP, corev1.ProtocolSCTP:
+		default:
+			glog.V(100).Infof("The EgressFirewall protocol %s is invalid", port.Protocol)
+
+			builder.errorMsg = fmt.Sprintf(
+				"EgressFirewall rule protocol %s is invalid, must be one of TCP, UDP or SCTP", port.Protocol)
+
+			return builder
+		}
+	}
+
+	builder.Definition.Spec.Egress = append(builder.Definition.Spec.Egress, ovntypes.EgressFirewallRule{
+		Type:  ruleType,
+		Ports: ports,
+		To:    destination,
+	})
+
+	return builder
+}
+
+// validate will check that the builder and builder definition are properly initialized before
+// accessing any member fields.
+func (builder *EgressFirewallBuilder) validate() (bool, error) {
+	resourceCRD := "EgressFirewall"
+
+	if builder == nil {
+		glog.V(100).Infof("The %s builder is uninitialized", resourceCRD)
+
+		return false, fmt.Errorf("error: received nil %s builder", resourceCRD)
+	}
+
+	if builder.Definition == nil {
+		glog.V(100).Infof("The %s is undefined", resourceCRD)
+
+		builder.errorMsg = msg.UndefinedCrdObjectErrString(resourceCRD)
+	}
+
+	if builder.apiClient == nil {
+		glog.V(100).Infof("The %s builder apiclient is nil", resourceCRD)
+
+		builder.errorMsg = fmt.Sprintf("%s builder cannot have nil apiClient", resourceCRD)
+	}
+
+	if builder.errorMsg != "" {
+		glog.V(100).Infof("The %s builder has error message: %s", resourceCRD, builder.errorMsg)
+
+		return false, fmt.Errorf(builder.errorMsg)
+	}
+
+	return true, nil
+}
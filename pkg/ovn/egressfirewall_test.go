@@ -0,0 +1,226 @@
+package ovn
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/openshift-kni/eco-goinfra/pkg/clients"
+	"github.com/openshift-kni/eco-goinfra/pkg/schemes/ovn/ovntypes"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const defaultEgressNsName = "test-ns"
+
+func TestNewEgressFirewallBuilder(t *testing.T) {
+	testBuilder := NewEgressFirewallBuilder(clients.GetTestClients(clients.TestClientParams{}), defaultEgressNsName)
+	assert.Equal(t, "", testBuilder.errorMsg)
+	assert.Equal(t, EgressFirewallName, testBuilder.Definition.Name)
+	assert.Equal(t, defaultEgressNsName, testBuilder.Definition.Namespace)
+
+	testBuilder = NewEgressFirewallBuilder(clients.GetTestClients(clients.TestClientParams{}), "")
+	assert.Equal(t, "EgressFirewall 'nsname' cannot be empty", testBuilder.errorMsg)
+}
+
+func TestPullEgressFirewall(t *testing.T) {
+	testCases := []struct {
+		nsname              string
+		addToRuntimeObjects bool
+		client              bool
+		expectedError       error
+	}{
+		{
+			nsname:              defaultEgressNsName,
+			addToRuntimeObjects: true,
+			client:              true,
+			expectedError:       nil,
+		},
+		{
+			nsname:              "",
+			addToRuntimeObjects: true,
+			client:              true,
+			expectedError:       fmt.Errorf("EgressFirewall 'namespace' cannot be empty"),
+		},
+		{
+			nsname:              defaultEgressNsName,
+			addToRuntimeObjects: false,
+			client:              true,
+			expectedError: fmt.Errorf(
+				"EgressFirewall object default does not exist in namespace %s", defaultEgressNsName),
+		},
+		{
+			nsname:              defaultEgressNsName,
+			addToRuntimeObjects: true,
+			client:              false,
+			expectedError:       fmt.Errorf("EgressFirewall 'apiClient' cannot be empty"),
+		},
+	}
+
+	for _, testCase := range testCases {
+		var (
+			runtimeObjects []runtime.Object
+			testSettings   *clients.Settings
+		)
+
+		if testCase.addToRuntimeObjects {
+			runtimeObjects = append(runtimeObjects, buildDummyEgressFirewall(EgressFirewallStatusApplied))
+		}
+
+		if testCase.client {
+			testSettings = clients.GetTestClients(clients.TestClientParams{K8sMockObjects: runtimeObjects})
+		}
+
+		testBuilder, err := PullEgressFirewall(testSettings, testCase.nsname)
+		assert.Equal(t, testCase.expectedError, err)
+
+		if testCase.expectedError == nil {
+			assert.Len(t, testBuilder.Definition.Spec.Egress, 1)
+		}
+	}
+}
+
+func TestEgressFirewallWithRules(t *testing.T) {
+	tcp80 := ovntypes.EgressFirewallPort{Protocol: "TCP", Port: 80}
+	nodeSelector := metav1.LabelSelector{MatchLabels: map[string]string{"node-role.kubernetes.io/worker": ""}}
+
+	testBuilder := buildValidEgressFirewallTestBuilder(clients.GetTestClients(clients.TestClientParams{})).
+		WithDNSNameRule(ovntypes.EgressFirewallRuleAllow, "*.example.com", tcp80).
+		WithNodeSelectorRule(ovntypes.EgressFirewallRuleAllow, nodeSelector).
+		WithCIDRRule(ovntypes.EgressFirewallRuleDeny, "0.0.0.0/0")
+	assert.Equal(t, "", testBuilder.errorMsg)
+	assert.Equal(t, []ovntypes.EgressFirewallRule{
+		{
+			Type:  ovntypes.EgressFirewallRuleAllow,
+			Ports: []ovntypes.EgressFirewallPort{tcp80},
+			To:    ovntypes.EgressFirewallDestination{DNSName: "*.example.com"},
+		},
+		{
+			Type: ovntypes.EgressFirewallRuleAllow,
+			To:   ovntypes.EgressFirewallDestination{NodeSelector: &nodeSelector},
+		},
+		{
+			Type: ovntypes.EgressFirewallRuleDeny,
+			To:   ovntypes.EgressFirewallDestination{CIDRSelector: "0.0.0.0/0"},
+		},
+	}, testBuilder.Definition.Spec.Egress)
+
+	testCases := []struct {
+		mutate        func(builder *EgressFirewallBuilder) *EgressFirewallBuilder
+		expectedError string
+	}{
+		{
+			mutate: func(builder *EgressFirewallBuilder) *EgressFirewallBuilder {
+				return builder.WithCIDRRule("Drop", "0.0.0.0/0")
+			},
+			expectedError: "EgressFirewall rule type Drop is invalid, must be one of Allow or Deny",
+		},
+		{
+			mutate: func(builder *EgressFirewallBuilder) *EgressFirewallBuilder {
+				return builder.WithCIDRRule(ovntypes.EgressFirewallRuleDeny, "0.0.0.0")
+			},
+			expectedError: "EgressFirewall rule has invalid CIDR 0.0.0.0",
+		},
+		{
+			mutate: func(builder *EgressFirewallBuilder) *EgressFirewallBuilder {
+				return builder.WithDNSNameRule(ovntypes.EgressFirewallRuleAllow, "")
+			},
+			expectedError: "EgressFirewall rule 'dnsName' cannot be empty",
+		},
+		{
+			mutate: func(builder *EgressFirewallBuilder) *EgressFirewallBuilder {
+				return builder.WithDNSNameRule(ovntypes.EgressFirewallRuleAllow, "example.com",
+					ovntypes.EgressFirewallPort{Protocol: "TCP", Port: 0})
+			},
+			expectedError: "EgressFirewall rule port 0 must be between 1 and 65535",
+		},
+		{
+			mutate: func(builder *EgressFirewallBuilder) *EgressFirewallBuilder {
+				return builder.WithDNSNameRule(ovntypes.EgressFirewallRuleAllow, "example.com",
+					ovntypes.EgressFirewallPort{Protocol: "ICMP", Port: 1})
+			},
+			expectedError: "EgressFirewall rule protocol ICMP is invalid, must be one of TCP, UDP or SCTP",
+		},
+		{
+			mutate: func(builder *EgressFirewallBuilder) *EgressFirewallBuilder {
+				return builder.WithOptions(func(builder *EgressFirewallBuilder) (*EgressFirewallBuilder, error) {
+					return builder, fmt.Errorf("error adding additional option")
+				})
+			},
+			expectedError: "error adding additional option",
+		},
+	}
+
+	for _, testCase := range testCases {
+		testBuilder := testCase.mutate(
+			buildValidEgressFirewallTestBuilder(clients.GetTestClients(clients.TestClientParams{})))
+		assert.Equal(t, testCase.expectedError, testBuilder.errorMsg)
+	}
+}
+
+func TestEgressFirewallCreateUpdateAndDelete(t *testing.T) {
+	testBuilder, err := buildValidEgressFirewallTestBuilder(clients.GetTestClients(clients.TestClientParams{})).
+		WithCIDRRule(ovntypes.EgressFirewallRuleDeny, "0.0.0.0/0").
+		Create()
+	assert.Nil(t, err)
+	assert.True(t, testBuilder.Exists())
+
+	testBuilder, err = testBuilder.WithCIDRRule(ovntypes.EgressFirewallRuleDeny, "::/0").Update(false)
+	assert.Nil(t, err)
+	assert.Len(t, testBuilder.Object.Spec.Egress, 2)
+
+	err = testBuilder.Delete()
+	assert.Nil(t, err)
+	assert.Nil(t, testBuilder.Object)
+	assert.False(t, testBuilder.Exists())
+}
+
+func TestEgressFirewallWaitUntilApplied(t *testing.T) {
+	testCases := []struct {
+		status        string
+		expectedError error
+	}{
+		{
+			status:        EgressFirewallStatusApplied,
+			expectedError: nil,
+		},
+		{
+			status: EgressFirewallStatusFailed,
+			expectedError: fmt.Errorf(
+				"EgressFirewall in namespace %s failed to apply: worker-0: failed", defaultEgressNsName),
+		},
+	}
+
+	for _, testCase := range testCases {
+		testSettings := clients.GetTestClients(clients.TestClientParams{
+			K8sMockObjects: []runtime.Object{buildDummyEgressFirewall(testCase.status)},
+		})
+
+		err := buildValidEgressFirewallTestBuilder(testSettings).WaitUntilApplied(time.Second)
+		assert.Equal(t, testCase.expectedError, err)
+	}
+}
+
+func buildValidEgressFirewallTestBuilder(apiClient *clients.Settings) *EgressFirewallBuilder {
+	return NewEgressFirewallBuilder(apiClient, defaultEgressNsName)
+}
+
+func buildDummyEgressFirewall(status string) *ovntypes.EgressFirewall {
+	return &ovntypes.EgressFirewall{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      EgressFirewallName,
+			Namespace: defaultEgressNsName,
+		},
+		Spec: ovntypes.EgressFirewallSpec{
+			Egress: []ovntypes.EgressFirewallRule{{
+				Type: ovntypes.EgressFirewallRuleDeny,
+				To:   ovntypes.EgressFirewallDestination{CIDRSelector: "0.0.0.0/0"},
+			}},
+		},
+		Status: ovntypes.EgressFirewallStatus{
+			Status:   status,
+			Messages: []string{"worker-0: failed"},
+		},
+	}
+}
@@ -0,0 +1,431 @@
+package ovn
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/openshift-kni/eco-goinfra/pkg/clients"
+	"github.com/openshift-kni/eco-goinfra/pkg/msg"
+	"github.com/openshift-kni/eco-goinfra/pkg/nodes"
+	"github.com/openshift-kni/eco-goinfra/pkg/schemes/ovn/ovntypes"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	goclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// EgressAssignableLabel is the node label marking the nodes that EgressIPs can be assigned to.
+	EgressAssignableLabel = "k8s.ovn.org/egress-assignable"
+)
+
+// EgressIPBuilder provides struct for EgressIP object which contains connection to cluster and EgressIP definition.
+type EgressIPBuilder struct {
+	// EgressIP definition. Used to create EgressIP object.
+	Definition *ovntypes.EgressIP
+	// Created EgressIP object.
+	Object *ovntypes.EgressIP
+	// Used in functions that define or mutate EgressIP definitions. errorMsg is processed before
+	// EgressIP object is created.
+	errorMsg string
+	// apiClient opens api connection to the cluster.
+	apiClient *clients.Settings
+}
+
+// EgressIPAdditionalOptions additional options for EgressIP object.
+type EgressIPAdditionalOptions func(builder *EgressIPBuilder) (*EgressIPBuilder, error)
+
+// NewEgressIPBuilder creates new instance of EgressIPBuilder requesting the given egress IPs. Without a namespace
+// selector the EgressIP does not apply to any namespace.
+func NewEgressIPBuilder(apiClient *clients.Settings, name string, egressIPs ...string) *EgressIPBuilder {
+	glog.V(100).Infof("Initializing new EgressIP structure with the name %s and egress IPs %v", name, egressIPs)
+
+	builder := EgressIPBuilder{
+		apiClient: apiClient,
+		Definition: &ovntypes.EgressIP{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: name,
+			},
+			Spec: ovntypes.EgressIPSpec{
+				EgressIPs: egressIPs,
+			},
+		},
+	}
+
+	if name == "" {
+		glog.V(100).Infof("The name of the EgressIP is empty")
+
+		builder.errorMsg = "EgressIP 'name' cannot be empty"
+
+		return &builder
+	}
+
+	if len(egressIPs) == 0 {
+		glog.V(100).Infof("The egressIPs of the EgressIP are empty")
+
+		builder.errorMsg = "EgressIP 'egressIPs' cannot be empty"
+
+		return &builder
+	}
+
+	for _, egressIP := range egressIPs {
+		if net.ParseIP(egressIP) == nil {
+			glog.V(100).Infof("The egress IP %s is not a valid IP address", egressIP)
+
+			builder.errorMsg = fmt.Sprintf("EgressIP egress IP %s is not a valid IP address", egressIP)
+
+			return &builder
+		}
+	}
+
+	return &builder
+}
+
+// WithNamespaceSelector sets the selector of the namespaces the EgressIP applies to.
+func (builder *EgressIPBuilder) WithNamespaceSelector(namespaceSelector metav1.LabelSelector) *EgressIPBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Setting namespace selector %v to EgressIP %s", namespaceSelector, builder.Definition.Name)
+
+	builder.Definition.Spec.NamespaceSelector = namespaceSelector
+
+	return builder
+}
+
+// WithPodSelector sets the selector of the pods the EgressIP applies to within the selected namespaces. Without a
+// pod selector the EgressIP applies to all pods in the selected namespaces.
+func (builder *EgressIPBuilder) WithPodSelector(podSelector metav1.LabelSelector) *EgressIPBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Setting pod selector %v to EgressIP %s", podSelector, builder.Definition.Name)
+
+	builder.Definition.Spec.PodSelector = podSelector
+
+	return builder
+}
+
+// WithOptions creates EgressIP with generic mutation options.
+func (builder *EgressIPBuilder) WithOptions(options ...EgressIPAdditionalOptions) *EgressIPBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Setting EgressIP additional options")
+
+	for _, option := range options {
+		if option != nil {
+			builder, err := option(builder)
+
+			if err != nil {
+				glog.V(100).Infof("Error occurred in mutation function")
+
+				builder.errorMsg = err.Error()
+
+				return builder
+			}
+		}
+	}
+
+	return builder
+}
+
+// PullEgressIP pulls existing EgressIP from cluster.
+func PullEgressIP(apiClient *clients.Settings, name string) (*EgressIPBuilder, error) {
+	glog.V(100).Infof("Pulling existing EgressIP name %s from cluster", name)
+
+	if apiClient == nil {
+		glog.V(100).Infof("The apiClient is empty")
+
+		return nil, fmt.Errorf("EgressIP 'apiClient' cannot be empty")
+	}
+
+	builder := EgressIPBuilder{
+		apiClient: apiClient,
+		Definition: &ovntypes.EgressIP{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: name,
+			},
+		},
+	}
+
+	if name == "" {
+		glog.V(100).Infof("The name of the EgressIP is empty")
+
+		return nil, fmt.Errorf("EgressIP 'name' cannot be empty")
+	}
+
+	if !builder.Exists() {
+		return nil, fmt.Errorf("EgressIP object %s does not exist", name)
+	}
+
+	builder.Definition = builder.Object
+
+	return &builder, nil
+}
+
+// Get returns EgressIP object if found.
+func (builder *EgressIPBuilder) Get() (*ovntypes.EgressIP, error) {
+	if valid, err := builder.validate(); !valid {
+		return nil, err
+	}
+
+	glog.V(100).Infof("Collecting EgressIP object %s", builder.Definition.Name)
+
+	egressIP := &ovntypes.EgressIP{}
+	err := builder.apiClient.Get(context.TODO(), goclient.ObjectKey{Name: builder.Definition.Name}, egressIP)
+
+	if err != nil {
+		glog.V(100).Infof("Failed to get EgressIP %s", builder.Definition.Name)
+
+		return nil, err
+	}
+
+	return egressIP, nil
+}
+
+// Exists checks whether the given EgressIP object exists in a cluster.
+func (builder *EgressIPBuilder) Exists() bool {
+	if valid, _ := builder.validate(); !valid {
+		return false
+	}
+
+	glog.V(100).Infof("Checking if EgressIP %s exists", builder.Definition.Name)
+
+	var err error
+	builder.Object, err = builder.Get()
+
+	return err == nil || !k8serrors.IsNotFound(err)
+}
+
+// Create generates EgressIP in a cluster and stores the created object in struct.
+func (builder *EgressIPBuilder) Create() (*EgressIPBuilder, error) {
+	if valid, err := builder.validate(); !valid {
+		return builder, err
+	}
+
+	glog.V(100).Infof("Creating the EgressIP %s", builder.Definition.Name)
+
+	if !builder.Exists() {
+		err := builder.apiClient.Create(context.TODO(), builder.Definition)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	builder.Object = builder.Definition
+
+	return builder, nil
+}
+
+// Delete removes EgressIP object.
+func (builder *EgressIPBuilder) Delete() error {
+	if valid, err := builder.validate(); !valid {
+		return err
+	}
+
+	glog.V(100).Infof("Deleting the EgressIP object %s", builder.Definition.Name)
+
+	if !builder.Exists() {
+		return nil
+	}
+
+	err := builder.apiClient.Delete(context.TODO(), builder.Object)
+
+	if err != nil {
+		return err
+	}
+
+	builder.Object = nil
+
+	return nil
+}
+
+// Update renovates the existing EgressIP object with the EgressIP definition in builder. If force is true and the
+// update fails, the EgressIP is deleted and recreated.
+func (builder *EgressIPBuilder) Update(force bool) (*EgressIPBuilder, error) {
+	if valid, err := builder.validate(); !valid {
+		return builder, err
+	}
+
+	glog.V(100).Infof("Updating the EgressIP object %s", builder.Definition.Name)
+
+	if !builder.Exists() {
+		glog.V(100).Infof("EgressIP %s does not exist", builder.Definition.Name)
+
+		return nil, fmt.Errorf("cannot update non-existent EgressIP")
+	}
+
+	builder.Definition.ResourceVersion = builder.Object.ResourceVersion
+	err := builder.apiClient.Update(context.TODO(), builder.Definition)
+
+	if err != nil {
+		if force {
+			glog.V(100).Infof(msg.FailToUpdateNotification("EgressIP", builder.Definition.Name))
+
+			err = builder.Delete()
+
+			if err != nil {
+				glog.V(100).Infof(msg.FailToUpdateError("EgressIP", builder.Definition.Name))
+
+				return nil, err
+			}
+
+			builder.Definition.ResourceVersion = ""
+
+			return builder.Create()
+		}
+
+		return nil, err
+	}
+
+	builder.Object = builder.Definition
+
+	return builder, nil
+}
+
+// GetAssignedNodes returns the name of the node each egress IP is assigned to, keyed by egress IP. Egress IPs that
+// are not assigned yet are not included.
+func (builder *EgressIPBuilder) GetAssignedNodes() (map[string]string, error) {
+	if valid, err := builder.validate(); !valid {
+		return nil, err
+	}
+
+	glog.V(100).Infof("Getting assigned nodes of EgressIP %s", builder.Definition.Name)
+
+	if !builder.Exists() {
+		return nil, fmt.Errorf("EgressIP object %s does not exist", builder.Definition.Name)
+	}
+
+	assignedNodes := make(map[string]string)
+
+	for _, item := range builder.Object.Status.Items {
+		assignedNodes[item.EgressIP] = item.Node
+	}
+
+	return assignedNodes, nil
+}
+
+// WaitUntilAssigned waits for the duration of the defined timeout until every egress IP of the EgressIP is assigned
+// to a node.
+func (builder *EgressIPBuilder) WaitUntilAssigned(timeout time.Duration) error {
+	if valid, err := builder.validate(); !valid {
+		return err
+	}
+
+	glog.V(100).Infof("Waiting until EgressIP %s egress IPs are assigned", builder.Definition.Name)
+
+	return wait.PollUntilContextTimeout(
+		context.TODO(), 3*time.Second, timeout, true, func(ctx context.Context) (bool, error) {
+			assignedNodes, err := builder.GetAssignedNodes()
+			if err != nil {
+				glog.V(100).Infof("Failed to get assigned nodes of EgressIP %s: %v", builder.Definition.Name, err)
+
+				return false, nil
+			}
+
+			for _, egressIP := range builder.Definition.Spec.EgressIPs {
+				if assignedNodes[egressIP] == "" {
+					glog.V(100).Infof("EgressIP %s egress IP %s is not assigned yet", builder.Definition.Name, egressIP)
+
+					return false, nil
+				}
+			}
+
+			return true, nil
+		})
+}
+
+// validate will check that the builder and builder definition are properly initialized before
+// accessing any member fields.
+func (builder *EgressIPBuilder) validate() (bool, error) {
+	resourceCRD := "EgressIP"
+
+	if builder == nil {
+		glog.V(100).Infof("The %s builder is uninitialized", resourceCRD)
+
+		return false, fmt.Errorf("error: received nil %s builder", resourceCRD)
+	}
+
+	if builder.Definition == nil {
+		glog.V(100).Infof("The %s is undefined", resourceCRD)
+
+		builder.errorMsg = msg.UndefinedCrdObjectErrString(resourceCRD)
+	}
+
+	if builder.apiClient == nil {
+		glog.V(100).Infof("The %s builder apiclient is nil", resourceCRD)
+
+		builder.errorMsg = fmt.Sprintf("%s builder cannot have nil apiClient", resourceCRD)
+	}
+
+	if builder.errorMsg != "" {
+		glog.V(100).Infof("The %s builder has error message: %s", resourceCRD, builder.errorMsg)
+
+		return false, fmt.Errorf(builder.errorMsg)
+	}
+
+	return true, nil
+}
+
+// LabelEgressAssignableNodes adds the egress-assignable label to the given nodes so that EgressIPs can be assigned to
+// them. Nodes which are already labeled are left unchanged.
+func LabelEgressAssignableNodes(apiClient *clients.Settings, nodeNames ...string) error {
+	glog.V(100).Infof("Labeling nodes %v as egress-assignable", nodeNames)
+
+	return updateEgressAssignableLabel(apiClient, true, nodeNames)
+}
+
+// UnlabelEgressAssignableNodes removes the egress-assignable label from the given nodes. Nodes which are not labeled
+// are left unchanged.
+func UnlabelEgressAssignableNodes(apiClient *clients.Settings, nodeNames ...string) error {
+	glog.V(100).Infof("Removing egress-assignable label from nodes %v", nodeNames)
+
+	return updateEgressAssignableLabel(apiClient, false, nodeNames)
+}
+
+// updateEgressAssignableLabel adds or removes the egress-assignable label on the given nodes.
+func updateEgressAssignableLabel(apiClient *clients.Settings, assignable bool, nodeNames []string) error {
+	if apiClient == nil {
+		glog.V(100).Infof("The apiClient is empty")
+
+		return fmt.Errorf("'apiClient' cannot be empty")
+	}
+
+	if len(nodeNames) == 0 {
+		glog.V(100).Infof("The nodeNames are empty")
+
+		return fmt.Errorf("'nodeNames' cannot be empty")
+	}
+
+	for _, nodeName := range nodeNames {
+		nodeBuilder, err := nodes.Pull(apiClient, nodeName)
+		if err != nil {
+			return err
+		}
+
+		_, labeled := nodeBuilder.Definition.Labels[EgressAssignableLabel]
+		if labeled == assignable {
+			continue
+		}
+
+		if assignable {
+			nodeBuilder = nodeBuilder.WithNewLabel(EgressAssignableLabel, "")
+		} else {
+			nodeBuilder = nodeBuilder.RemoveLabel(EgressAssignableLabel, "")
+		}
+
+		if _, err := nodeBuilder.Update(); err != nil {
+			return fmt.Errorf("failed to update egress-assignable label of node %s: %w", nodeName, err)
+		}
+	}
+
+	return nil
+}
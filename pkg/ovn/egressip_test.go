@@ -0,0 +1,239 @@
+package ovn
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/openshift-kni/eco-goinfra/pkg/clients"
+	"github.com/openshift-kni/eco-goinfra/pkg/nodes"
+	"github.com/openshift-kni/eco-goinfra/pkg/schemes/ovn/ovntypes"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const defaultEgressIPName = "egressip"
+
+func TestNewEgressIPBuilder(t *testing.T) {
+	testCases := []struct {
+		name          string
+		egressIPs     []string
+		expectedError string
+	}{
+		{
+			name:          defaultEgressIPName,
+			egressIPs:     []string{"192.168.10.100", "2001:db8::100"},
+			expectedError: "",
+		},
+		{
+			name:          "",
+			egressIPs:     []string{"192.168.10.100"},
+			expectedError: "EgressIP 'name' cannot be empty",
+		},
+		{
+			name:          defaultEgressIPName,
+			egressIPs:     nil,
+			expectedError: "EgressIP 'egressIPs' cannot be empty",
+		},
+		{
+			name:          defaultEgressIPName,
+			egressIPs:     []string{"192.168.10.300"},
+			expectedError: "EgressIP egress IP 192.168.10.300 is not a valid IP address",
+		},
+	}
+
+	for _, testCase := range testCases {
+		testBuilder := NewEgressIPBuilder(
+			clients.GetTestClients(clients.TestClientParams{}), testCase.name, testCase.egressIPs...)
+		assert.Equal(t, testCase.expectedError, testBuilder.errorMsg)
+
+		if testCase.expectedError == "" {
+			assert.Equal(t, testCase.egressIPs, testBuilder.Definition.Spec.EgressIPs)
+		}
+	}
+}
+
+func TestPullEgressIP(t *testing.T) {
+	testCases := []struct {
+		name                string
+		addToRuntimeObjects bool
+		client              bool
+		expectedError       error
+	}{
+		{
+			name:                defaultEgressIPName,
+			addToRuntimeObjects: true,
+			client:              true,
+			expectedError:       nil,
+		},
+		{
+			name:                "",
+			addToRuntimeObjects: true,
+			client:              true,
+			expectedError:       fmt.Errorf("EgressIP 'name' cannot be empty"),
+		},
+		{
+			name:                defaultEgressIPName,
+			addToRuntimeObjects: false,
+			client:              true,
+			expectedError:       fmt.Errorf("EgressIP object %s does not exist", defaultEgressIPName),
+		},
+		{
+			name:                defaultEgressIPName,
+			addToRuntimeObjects: true,
+			client:              false,
+			expectedError:       fmt.Errorf("EgressIP 'apiClient' cannot be empty"),
+		},
+	}
+
+	for _, testCase := range testCases {
+		var (
+			runtimeObjects []runtime.Object
+			testSettings   *clients.Settings
+		)
+
+		if testCase.addToRuntimeObjects {
+			runtimeObjects = append(runtimeObjects, buildDummyEgressIP())
+		}
+
+		if testCase.client {
+			testSettings = clients.GetTestClients(clients.TestClientParams{K8sMockObjects: runtimeObjects})
+		}
+
+		testBuilder, err := PullEgressIP(testSettings, testCase.name)
+		assert.Equal(t, testCase.expectedError, err)
+
+		if testCase.expectedError == nil {
+			assert.Equal(t, []string{"192.168.10.100"}, testBuilder.Definition.Spec.EgressIPs)
+		}
+	}
+}
+
+func TestEgressIPWithSelectors(t *testing.T) {
+	namespaceSelector := metav1.LabelSelector{MatchLabels: map[string]string{"env": "qe"}}
+	podSelector := metav1.LabelSelector{MatchLabels: map[string]string{"app": "test"}}
+
+	testBuilder := buildValidEgressIPTestBuilder(clients.GetTestClients(clients.TestClientParams{})).
+		WithNamespaceSelector(namespaceSelector).
+		WithPodSelector(podSelector)
+	assert.Equal(t, "", testBuilder.errorMsg)
+	assert.Equal(t, namespaceSelector, testBuilder.Definition.Spec.NamespaceSelector)
+	assert.Equal(t, podSelector, testBuilder.Definition.Spec.PodSelector)
+
+	testBuilder = buildValidEgressIPTestBuilder(clients.GetTestClients(clients.TestClientParams{})).WithOptions(
+		func(builder *EgressIPBuilder) (*EgressIPBuilder, error) {
+			return builder, fmt.Errorf("error adding additional option")
+		})
+	assert.Equal(t, "error adding additional option", testBuilder.errorMsg)
+}
+
+func TestEgressIPCreateUpdateAndDelete(t *testing.T) {
+	testSettings := clients.GetTestClients(clients.TestClientParams{})
+
+	testBuilder, err := buildValidEgressIPTestBuilder(testSettings).Create()
+	assert.Nil(t, err)
+	assert.True(t, testBuilder.Exists())
+
+	testBuilder.Definition.Spec.EgressIPs = []string{"192.168.10.101"}
+	testBuilder, err = testBuilder.Update(false)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"192.168.10.101"}, testBuilder.Object.Spec.EgressIPs)
+
+	err = testBuilder.Delete()
+	assert.Nil(t, err)
+	assert.Nil(t, testBuilder.Object)
+	assert.False(t, testBuilder.Exists())
+
+	_, err = buildValidEgressIPTestBuilder(testSettings).Update(false)
+	assert.Equal(t, fmt.Errorf("cannot update non-existent EgressIP"), err)
+}
+
+func TestEgressIPForceUpdate(t *testing.T) {
+	testSettings := clients.GetTestClients(clients.TestClientParams{})
+
+	testBuilder, err := buildValidEgressIPTestBuilder(testSettings).Create()
+	assert.Nil(t, err)
+
+	rejectNetworkUpdates(testSettings)
+
+	testBuilder.Definition.Spec.EgressIPs = []string{"192.168.10.101"}
+	_, err = testBuilder.Update(false)
+	assert.Equal(t, errImmutableNetworkSpec, err)
+
+	testBuilder, err = testBuilder.Update(true)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"192.168.10.101"}, testBuilder.Object.Spec.EgressIPs)
+}
+
+func TestEgressIPAssignment(t *testing.T) {
+	testSettings := clients.GetTestClients(clients.TestClientParams{
+		K8sMockObjects: []runtime.Object{buildDummyEgressIP()},
+	})
+
+	testBuilder, err := PullEgressIP(testSettings, defaultEgressIPName)
+	assert.Nil(t, err)
+
+	assignedNodes, err := testBuilder.GetAssignedNodes()
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{"192.168.10.100": "worker-0"}, assignedNodes)
+	assert.Nil(t, testBuilder.WaitUntilAssigned(time.Second))
+
+	testBuilder.Definition.Spec.EgressIPs = append(testBuilder.Definition.Spec.EgressIPs, "192.168.10.101")
+	assert.NotNil(t, testBuilder.WaitUntilAssigned(time.Second))
+
+	_, err = buildValidEgressIPTestBuilder(clients.GetTestClients(clients.TestClientParams{})).GetAssignedNodes()
+	assert.Equal(t, fmt.Errorf("EgressIP object %s does not exist", defaultEgressIPName), err)
+}
+
+func TestEgressAssignableNodes(t *testing.T) {
+	testSettings := clients.GetTestClients(clients.TestClientParams{K8sMockObjects: []runtime.Object{
+		&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "worker-0"}},
+		&corev1.Node{ObjectMeta: metav1.ObjectMeta{
+			Name: "worker-1", Labels: map[string]string{EgressAssignableLabel: ""}}},
+	}})
+
+	err := LabelEgressAssignableNodes(testSettings, "worker-0", "worker-1")
+	assert.Nil(t, err)
+
+	for _, nodeName := range []string{"worker-0", "worker-1"} {
+		nodeBuilder, err := nodes.Pull(testSettings, nodeName)
+		assert.Nil(t, err)
+		assert.Contains(t, nodeBuilder.Definition.Labels, EgressAssignableLabel)
+	}
+
+	err = UnlabelEgressAssignableNodes(testSettings, "worker-1")
+	assert.Nil(t, err)
+
+	nodeBuilder, err := nodes.Pull(testSettings, "worker-1")
+	assert.Nil(t, err)
+	assert.NotContains(t, nodeBuilder.Definition.Labels, EgressAssignableLabel)
+
+	err = LabelEgressAssignableNodes(testSettings)
+	assert.Equal(t, fmt.Errorf("'nodeNames' cannot be empty"), err)
+
+	err = LabelEgressAssignableNodes(testSettings, "worker-2")
+	assert.Equal(t, fmt.Errorf("node object worker-2 does not exist"), err)
+
+	err = LabelEgressAssignableNodes(nil, "worker-0")
+	assert.Equal(t, fmt.Errorf("'apiClient' cannot be empty"), err)
+}
+
+func buildValidEgressIPTestBuilder(apiClient *clients.Settings) *EgressIPBuilder {
+	return NewEgressIPBuilder(apiClient, defaultEgressIPName, "192.168.10.100")
+}
+
+func buildDummyEgressIP() *ovntypes.EgressIP {
+	return &ovntypes.EgressIP{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: defaultEgressIPName,
+		},
+		Spec: ovntypes.EgressIPSpec{
+			EgressIPs: []string{"192.168.10.100"},
+		},
+		Status: ovntypes.EgressIPStatus{
+			Items: []ovntypes.EgressIPStatusItem{{Node: "worker-0", EgressIP: "192.168.10.100"}},
+		},
+	}
+}
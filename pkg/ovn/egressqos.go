@@ -0,0 +1,362 @@
+package ovn
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/openshift-kni/eco-goinfra/pkg/clients"
+	"github.com/openshift-kni/eco-goinfra/pkg/msg"
+	"github.com/openshift-kni/eco-goinfra/pkg/schemes/ovn/ovntypes"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	goclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// EgressQoSName is the only name OVN-Kubernetes accepts for an EgressQoS.
+	EgressQoSName = "default"
+	// egressQoSReadyConditionPrefix is the prefix of the per zone conditions reporting the EgressQoS is applied.
+	egressQoSReadyConditionPrefix = "Ready-In-Zone-"
+)
+
+// EgressQoSBuilder provides struct for EgressQoS object which contains connection to cluster and EgressQoS
+// definition.
+type EgressQoSBuilder struct {
+	// EgressQoS definition. Used to create EgressQoS object.
+	Definition *ovntypes.EgressQoS
+	// Created EgressQoS object.
+	Object *ovntypes.EgressQoS
+	// Used in functions that define or mutate EgressQoS definitions. errorMsg is processed before
+	// EgressQoS object is created.
+	errorMsg string
+	// apiClient opens api connection to the cluster.
+	apiClient *clients.Settings
+}
+
+// EgressQoSAdditionalOptions additional options for EgressQoS object.
+type EgressQoSAdditionalOptions func(builder *EgressQoSBuilder) (*EgressQoSBuilder, error)
+
+// NewEgressQoSBuilder creates new instance of EgressQoSBuilder in the given namespace. Rules added first take
+// precedence over the rules added after them.
+func NewEgressQoSBuilder(apiClient *clients.Settings, nsname string) *EgressQoSBuilder {
+	glog.V(100).Infof("Initializing new EgressQoS structure in the namespace %s", nsname)
+
+	builder := EgressQoSBuilder{
+		apiClient: apiClient,
+		Definition: &ovntypes.EgressQoS{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      EgressQoSName,
+				Namespace: nsname,
+			},
+			Spec: ovntypes.EgressQoSSpec{
+				Egress: []ovntypes.EgressQoSRule{},
+			},
+		},
+	}
+
+	if nsname == "" {
+		glog.V(100).Infof("The namespace of the EgressQoS is empty")
+
+		builder.errorMsg = "EgressQoS 'nsname' cannot be empty"
+	}
+
+	return &builder
+}
+
+// WithRule adds a rule marking the egress traffic of the pods matching podSelector to dstCIDR with the given DSCP
+// value. An empty dstCIDR matches all destinations and an empty podSelector matches all pods in the namespace.
+func (builder *EgressQoSBuilder) WithRule(
+	dscp int, dstCIDR string, podSelector metav1.LabelSelector) *EgressQoSBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Adding rule with DSCP %d for destination %s and pod selector %v to EgressQoS in namespace %s",
+		dscp, dstCIDR, podSelector, builder.Definition.Namespace)
+
+	if dscp < 0 || dscp > 63 {
+		glog.V(100).Infof("The EgressQoS DSCP %d is invalid", dscp)
+
+		builder.errorMsg = fmt.Sprintf("EgressQoS rule DSCP %d must be between 0 and 63", dscp)
+
+		return builder
+	}
+
+	rule := ovntypes.EgressQoSRule{DSCP: dscp, PodSelector: podSelector}
+
+	if dstCIDR != "" {
+		if _, _, err := net.ParseCIDR(dstCIDR); err != nil {
+			glog.V(100).Infof("The EgressQoS destination CIDR %s is invalid", dstCIDR)
+
+			builder.errorMsg = fmt.Sprintf("EgressQoS rule has invalid destination CIDR %s", dstCIDR)
+
+			return builder
+		}
+
+		rule.DstCIDR = &dstCIDR
+	}
+
+	builder.Definition.Spec.Egress = append(builder.Definition.Spec.Egress, rule)
+
+	return builder
+}
+
+// WithOptions creates EgressQoS with generic mutation options.
+func (builder *EgressQoSBuilder) WithOptions(options ...EgressQoSAdditionalOptions) *EgressQoSBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Setting EgressQoS additional options")
+
+	for _, option := range options {
+		if option != nil {
+			builder, err := option(builder)
+
+			if err != nil {
+				glog.V(100).Infof("Error occurred in mutation function")
+
+				builder.errorMsg = err.Error()
+
+				return builder
+			}
+		}
+	}
+
+	return builder
+}
+
+// PullEgressQoS pulls existing EgressQoS of the given namespace from cluster.
+func PullEgressQoS(apiClient *clients.Settings, nsname string) (*EgressQoSBuilder, error) {
+	glog.V(100).Infof("Pulling existing EgressQoS under namespace %s from cluster", nsname)
+
+	if apiClient == nil {
+		glog.V(100).Infof("The apiClient is empty")
+
+		return nil, fmt.Errorf("EgressQoS 'apiClient' cannot be empty")
+	}
+
+	builder := EgressQoSBuilder{
+		apiClient: apiClient,
+		Definition: &ovntypes.EgressQoS{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      EgressQoSName,
+				Namespace: nsname,
+			},
+		},
+	}
+
+	if nsname == "" {
+		glog.V(100).Infof("The namespace of the EgressQoS is empty")
+
+		return nil, fmt.Errorf("EgressQoS 'namespace' cannot be empty")
+	}
+
+	if !builder.Exists() {
+		return nil, fmt.Errorf("EgressQoS object %s does not exist in namespace %s", EgressQoSName, nsname)
+	}
+
+	builder.Definition = builder.Object
+
+	return &builder, nil
+}
+
+// Get returns EgressQoS object if found.
+func (builder *EgressQoSBuilder) Get() (*ovntypes.EgressQoS, error) {
+	if valid, err := builder.validate(); !valid {
+		return nil, err
+	}
+
+	glog.V(100).Infof("Collecting EgressQoS object %s in namespace %s",
+		builder.Definition.Name, builder.Definition.Namespace)
+
+	egressQoS := &ovntypes.EgressQoS{}
+	err := builder.apiClient.Get(context.TODO(), goclient.ObjectKey{
+		Name:      builder.Definition.Name,
+		Namespace: builder.Definition.Namespace,
+	}, egressQoS)
+
+	if err != nil {
+		glog.V(100).Infof("Failed to get EgressQoS %s in namespace %s",
+			builder.Definition.Name, builder.Definition.Namespace)
+
+		return nil, err
+	}
+
+	return egressQoS, nil
+}
+
+// Exists checks whether the given EgressQoS object exists in a cluster.
+func (builder *EgressQoSBuilder) Exists() bool {
+	if valid, _ := builder.validate(); !valid {
+		return false
+	}
+
+	glog.V(100).Infof("Checking if EgressQoS %s exists in namespace %s",
+		builder.Definition.Name, builder.Definition.Namespace)
+
+	var err error
+	builder.Object, err = builder.Get()
+
+	return err == nil || !k8serrors.IsNotFound(err)
+}
+
+// Create generates EgressQoS in a cluster and stores the created object in struct.
+func (builder *EgressQoSBuilder) Create() (*EgressQoSBuilder, error) {
+	if valid, err := builder.validate(); !valid {
+		return builder, err
+	}
+
+	glog.V(100).Infof("Creating the EgressQoS %s in namespace %s",
+		builder.Definition.Name, builder.Definition.Namespace)
+
+	if !builder.Exists() {
+		err := builder.apiClient.Create(context.TODO(), builder.Definition)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	builder.Object = builder.Definition
+
+	return builder, nil
+}
+
+// Delete removes EgressQoS object.
+func (builder *EgressQoSBuilder) Delete() error {
+	if valid, err := builder.validate(); !valid {
+		return err
+	}
+
+	glog.V(100).Infof("Deleting the EgressQoS object %s in namespace %s",
+		builder.Definition.Name, builder.Definition.Namespace)
+
+	if !builder.Exists() {
+		return nil
+	}
+
+	err := builder.apiClient.Delete(context.TODO(), builder.Object)
+
+	if err != nil {
+		return err
+	}
+
+	builder.Object = nil
+
+	return nil
+}
+
+// Update renovates the existing EgressQoS object with the EgressQoS definition in builder.
+func (builder *EgressQoSBuilder) Update(force bool) (*EgressQoSBuilder, error) {
+	if valid, err := builder.validate(); !valid {
+		return builder, err
+	}
+
+	glog.V(100).Infof("Updating the EgressQoS object %s in namespace %s",
+		builder.Definition.Name, builder.Definition.Namespace)
+
+	err := builder.apiClient.Update(context.TODO(), builder.Definition)
+
+	if err != nil {
+		if force {
+			glog.V(100).Infof(
+				msg.FailToUpdateNotification("EgressQoS", builder.Definition.Name, builder.Definition.Namespace))
+
+			err = builder.Delete()
+
+			if err != nil {
+				glog.V(100).Infof(
+					msg.FailToUpdateError("EgressQoS", builder.Definition.Name, builder.Definition.Namespace))
+
+				return nil, err
+			}
+
+			return builder.Create()
+		}
+
+		return nil, err
+	}
+
+	builder.Object = builder.Definition
+
+	return builder, nil
+}
+
+// WaitUntilApplied waits for the duration of the defined timeout until the EgressQoS is reported as applied in
+// every zone.
+func (builder *EgressQoSBuilder) WaitUntilApplied(timeout time.Duration) error {
+	if valid, err := builder.validate(); !valid {
+		return err
+	}
+
+	glog.V(100).Infof("Waiting until EgressQoS %s in namespace %s is applied",
+		builder.Definition.Name, builder.Definition.Namespace)
+
+	return wait.PollUntilContextTimeout(
+		context.TODO(), 3*time.Second, timeout, true, func(ctx context.Context) (bool, error) {
+			if !builder.Exists() {
+				glog.V(100).Infof("EgressQoS %s in namespace %s does not exist yet",
+					builder.Definition.Name, builder.Definition.Namespace)
+
+				return false, nil
+			}
+
+			readyZones := 0
+
+			for _, condition := range builder.Object.Status.Conditions {
+				if !strings.HasPrefix(condition.Type, egressQoSReadyConditionPrefix) {
+					continue
+				}
+
+				if condition.Status != metav1.ConditionTrue {
+					glog.V(100).Infof("EgressQoS in namespace %s is not applied in zone %s: %s",
+						builder.Definition.Namespace,
+						strings.TrimPrefix(condition.Type, egressQoSReadyConditionPrefix), condition.Message)
+
+					return false, nil
+				}
+
+				readyZones++
+			}
+
+			return readyZones > 0, nil
+		})
+}
+
+// validate will check that the builder and builder definition are properly initialized before
+// accessing any member fields.
+func (builder *EgressQoSBuilder) validate() (bool, error) {
+	resourceCRD := "EgressQoS"
+
+	if builder == nil {
+		glog.V(100).Infof("The %s builder is uninitialized", resourceCRD)
+
+		return false, fmt.Errorf("error: received nil %s builder", resourceCRD)
+	}
+
+	if builder.Definition == nil {
+		glog.V(100).Infof("The %s is undefined", resourceCRD)
+
+		builder.errorMsg = msg.UndefinedCrdObjectErrString(resourceCRD)
+	}
+
+	if builder.apiClient == nil {
+		glog.V(100).Infof("The %s builder apiclient is nil", resourceCRD)
+
+		builder.errorMsg = fmt.Sprintf("%s builder cannot have nil apiClient", resourceCRD)
+	}
+
+	if builder.errorMsg != "" {
+		glog.V(100).Infof("The %s builder has error message: %s", resourceCRD, builder.errorMsg)
+
+		return false, fmt.Errorf(builder.errorMsg)
+	}
+
+	return true, nil
+}
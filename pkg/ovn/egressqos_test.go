@@ -0,0 +1,160 @@
+package ovn
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/openshift-kni/eco-goinfra/pkg/clients"
+	"github.com/openshift-kni/eco-goinfra/pkg/schemes/ovn/ovntypes"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+)
+
+func TestNewEgressQoSBuilder(t *testing.T) {
+	testBuilder := NewEgressQoSBuilder(clients.GetTestClients(clients.TestClientParams{}), defaultEgressNsName)
+	assert.Equal(t, "", testBuilder.errorMsg)
+	assert.Equal(t, EgressQoSName, testBuilder.Definition.Name)
+	assert.Equal(t, defaultEgressNsName, testBuilder.Definition.Namespace)
+
+	testBuilder = NewEgressQoSBuilder(clients.GetTestClients(clients.TestClientParams{}), "")
+	assert.Equal(t, "EgressQoS 'nsname' cannot be empty", testBuilder.errorMsg)
+}
+
+func TestPullEgressQoS(t *testing.T) {
+	testCases := []struct {
+		nsname              string
+		addToRuntimeObjects bool
+		client              bool
+		expectedError       error
+	}{
+		{
+			nsname:              defaultEgressNsName,
+			addToRuntimeObjects: true,
+			client:              true,
+			expectedError:       nil,
+		},
+		{
+			nsname:              "",
+			addToRuntimeObjects: true,
+			client:              true,
+			expectedError:       fmt.Errorf("EgressQoS 'namespace' cannot be empty"),
+		},
+		{
+			nsname:              defaultEgressNsName,
+			addToRuntimeObjects: false,
+			client:              true,
+			expectedError: fmt.Errorf(
+				"EgressQoS object default does not exist in namespace %s", defaultEgressNsName),
+		},
+		{
+			nsname:              defaultEgressNsName,
+			addToRuntimeObjects: true,
+			client:              false,
+			expectedError:       fmt.Errorf("EgressQoS 'apiClient' cannot be empty"),
+		},
+	}
+
+	for _, testCase := range testCases {
+		var (
+			runtimeObjects []runtime.Object
+			testSettings   *clients.Settings
+		)
+
+		if testCase.addToRuntimeObjects {
+			runtimeObjects = append(runtimeObjects, buildDummyEgressQoS(metav1.ConditionTrue))
+		}
+
+		if testCase.client {
+			testSettings = clients.GetTestClients(clients.TestClientParams{K8sMockObjects: runtimeObjects})
+		}
+
+		testBuilder, err := PullEgressQoS(testSettings, testCase.nsname)
+		assert.Equal(t, testCase.expectedError, err)
+
+		if testCase.expectedError == nil {
+			assert.Equal(t, 46, testBuilder.Definition.Spec.Egress[0].DSCP)
+		}
+	}
+}
+
+func TestEgressQoSWithRule(t *testing.T) {
+	podSelector := metav1.LabelSelector{MatchLabels: map[string]string{"app": "test"}}
+
+	testBuilder := buildValidEgressQoSTestBuilder(clients.GetTestClients(clients.TestClientParams{})).
+		WithRule(46, "1.2.3.0/24", podSelector).
+		WithRule(30, "", metav1.LabelSelector{})
+	assert.Equal(t, "", testBuilder.errorMsg)
+	assert.Equal(t, []ovntypes.EgressQoSRule{
+		{DSCP: 46, DstCIDR: ptr.To("1.2.3.0/24"), PodSelector: podSelector},
+		{DSCP: 30},
+	}, testBuilder.Definition.Spec.Egress)
+
+	testBuilder = buildValidEgressQoSTestBuilder(clients.GetTestClients(clients.TestClientParams{})).
+		WithRule(64, "", metav1.LabelSelector{})
+	assert.Equal(t, "EgressQoS rule DSCP 64 must be between 0 and 63", testBuilder.errorMsg)
+
+	testBuilder = buildValidEgressQoSTestBuilder(clients.GetTestClients(clients.TestClientParams{})).
+		WithRule(46, "1.2.3.4", metav1.LabelSelector{})
+	assert.Equal(t, "EgressQoS rule has invalid destination CIDR 1.2.3.4", testBuilder.errorMsg)
+
+	testBuilder = buildValidEgressQoSTestBuilder(clients.GetTestClients(clients.TestClientParams{})).WithOptions(
+		func(builder *EgressQoSBuilder) (*EgressQoSBuilder, error) {
+			return builder, fmt.Errorf("error adding additional option")
+		})
+	assert.Equal(t, "error adding additional option", testBuilder.errorMsg)
+}
+
+func TestEgressQoSCreateUpdateAndDelete(t *testing.T) {
+	testBuilder, err := buildValidEgressQoSTestBuilder(clients.GetTestClients(clients.TestClientParams{})).
+		WithRule(46, "", metav1.LabelSelector{}).
+		Create()
+	assert.Nil(t, err)
+	assert.True(t, testBuilder.Exists())
+
+	testBuilder, err = testBuilder.WithRule(30, "::/0", metav1.LabelSelector{}).Update(false)
+	assert.Nil(t, err)
+	assert.Len(t, testBuilder.Object.Spec.Egress, 2)
+
+	err = testBuilder.Delete()
+	assert.Nil(t, err)
+	assert.Nil(t, testBuilder.Object)
+	assert.False(t, testBuilder.Exists())
+}
+
+func TestEgressQoSWaitUntilApplied(t *testing.T) {
+	testSettings := clients.GetTestClients(clients.TestClientParams{
+		K8sMockObjects: []runtime.Object{buildDummyEgressQoS(metav1.ConditionTrue)},
+	})
+
+	err := buildValidEgressQoSTestBuilder(testSettings).WaitUntilApplied(time.Second)
+	assert.Nil(t, err)
+
+	testSettings = clients.GetTestClients(clients.TestClientParams{
+		K8sMockObjects: []runtime.Object{buildDummyEgressQoS(metav1.ConditionFalse)},
+	})
+
+	err = buildValidEgressQoSTestBuilder(testSettings).WaitUntilApplied(time.Second)
+	assert.NotNil(t, err)
+}
+
+func buildValidEgressQoSTestBuilder(apiClient *clients.Settings) *EgressQoSBuilder {
+	return NewEgressQoSBuilder(apiClient, defaultEgressNsName)
+}
+
+func buildDummyEgressQoS(status metav1.ConditionStatus) *ovntypes.EgressQoS {
+	return &ovntypes.EgressQoS{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      EgressQoSName,
+			Namespace: defaultEgressNsName,
+		},
+		Spec: ovntypes.EgressQoSSpec{
+			Egress: []ovntypes.EgressQoSRule{{DSCP: 46}},
+		},
+		Status: ovntypes.EgressQoSStatus{
+			Conditions: []metav1.Condition{{Type: egressQoSReadyConditionPrefix + "global", Status: status}},
+		},
+	}
+}
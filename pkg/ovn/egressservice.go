@@ -0,0 +1,374 @@
+package ovn
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/openshift-kni/eco-goinfra/pkg/clients"
+	"github.com/openshift-kni/eco-goinfra/pkg/msg"
+	"github.com/openshift-kni/eco-goinfra/pkg/schemes/ovn/ovntypes"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	goclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// EgressServiceBuilder provides struct for EgressService object which contains connection to cluster and
+// EgressService definition.
+type EgressServiceBuilder struct {
+	// EgressService definition. Used to create EgressService object.
+	Definition *ovntypes.EgressService
+	// Created EgressService object.
+	Object *ovntypes.EgressService
+	// Used in functions that define or mutate EgressService definitions. errorMsg is processed before
+	// EgressService object is created.
+	errorMsg string
+	// apiClient opens api connection to the cluster.
+	apiClient *clients.Settings
+}
+
+// EgressServiceAdditionalOptions additional options for EgressService object.
+type EgressServiceAdditionalOptions func(builder *EgressServiceBuilder) (*EgressServiceBuilder, error)
+
+// NewEgressServiceBuilder creates new instance of EgressServiceBuilder. The name must match the name of the
+// LoadBalancer service in the same namespace the EgressService applies to.
+func NewEgressServiceBuilder(apiClient *clients.Settings, name, nsname string) *EgressServiceBuilder {
+	glog.V(100).Infof(
+		"Initializing new EgressService structure with the name %s in the namespace %s", name, nsname)
+
+	builder := EgressServiceBuilder{
+		apiClient: apiClient,
+		Definition: &ovntypes.EgressService{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: nsname,
+			},
+		},
+	}
+
+	if name == "" {
+		glog.V(100).Infof("The name of the EgressService is empty")
+
+		builder.errorMsg = "EgressService 'name' cannot be empty"
+	}
+
+	if nsname == "" {
+		glog.V(100).Infof("The namespace of the EgressService is empty")
+
+		builder.errorMsg = "EgressService 'nsname' cannot be empty"
+	}
+
+	return &builder
+}
+
+// WithSourceIPBy sets how the source IP of the service egress traffic is determined, either by the LoadBalancer
+// ingress IP or by the network the traffic leaves on.
+func (builder *EgressServiceBuilder) WithSourceIPBy(sourceIPBy ovntypes.SourceIPMode) *EgressServiceBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Setting sourceIPBy %s to EgressService %s in namespace %s",
+		sourceIPBy, builder.Definition.Name, builder.Definition.Namespace)
+
+	if sourceIPBy != ovntypes.SourceIPLoadBalancer && sourceIPBy != ovntypes.SourceIPNetwork {
+		glog.V(100).Infof("The EgressService sourceIPBy %s is invalid", sourceIPBy)
+
+		builder.errorMsg = fmt.Sprintf("EgressService sourceIPBy %s is invalid, must be one of %s or %s",
+			sourceIPBy, ovntypes.SourceIPLoadBalancer, ovntypes.SourceIPNetwork)
+
+		return builder
+	}
+
+	builder.Definition.Spec.SourceIPBy = sourceIPBy
+
+	return builder
+}
+
+// WithNodeSelector limits the nodes that can be selected to handle the service egress traffic.
+func (builder *EgressServiceBuilder) WithNodeSelector(nodeSelector metav1.LabelSelector) *EgressServiceBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Setting node selector %v to EgressService %s in namespace %s",
+		nodeSelector, builder.Definition.Name, builder.Definition.Namespace)
+
+	builder.Definition.Spec.NodeSelector = nodeSelector
+
+	return builder
+}
+
+// WithNetwork sets the network the service egress traffic and its ingress replies are sent to, e.g. the name of a
+// routing table on the nodes.
+func (builder *EgressServiceBuilder) WithNetwork(network string) *EgressServiceBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Setting network %s to EgressService %s in namespace %s",
+		network, builder.Definition.Name, builder.Definition.Namespace)
+
+	if network == "" {
+		glog.V(100).Infof("The EgressService network is empty")
+
+		builder.errorMsg = "EgressService 'network' cannot be empty"
+
+		return builder
+	}
+
+	builder.Definition.Spec.Network = network
+
+	return builder
+}
+
+// WithOptions creates EgressService with generic mutation options.
+func (builder *EgressServiceBuilder) WithOptions(options ...EgressServiceAdditionalOptions) *EgressServiceBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Setting EgressService additional options")
+
+	for _, option := range options {
+		if option != nil {
+			builder, err := option(builder)
+
+			if err != nil {
+				glog.V(100).Infof("Error occurred in mutation function")
+
+				builder.errorMsg = err.Error()
+
+				return builder
+			}
+		}
+	}
+
+	return builder
+}
+
+// PullEgressService pulls existing EgressService from cluster.
+func PullEgressService(apiClient *clients.Settings, name, nsname string) (*EgressServiceBuilder, error) {
+	glog.V(100).Infof("Pulling existing EgressService name %s under namespace %s from cluster", name, nsname)
+
+	if apiClient == nil {
+		glog.V(100).Infof("The apiClient is empty")
+
+		return nil, fmt.Errorf("EgressService 'apiClient' cannot be empty")
+	}
+
+	builder := EgressServiceBuilder{
+		apiClient: apiClient,
+		Definition: &ovntypes.EgressService{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: nsname,
+			},
+		},
+	}
+
+	if name == "" {
+		glog.V(100).Infof("The name of the EgressService is empty")
+
+		return nil, fmt.Errorf("EgressService 'name' cannot be empty")
+	}
+
+	if nsname == "" {
+		glog.V(100).Infof("The namespace of the EgressService is empty")
+
+		return nil, fmt.Errorf("EgressService 'namespace' cannot be empty")
+	}
+
+	if !builder.Exists() {
+		return nil, fmt.Errorf("EgressService object %s does not exist in namespace %s", name, nsname)
+	}
+
+	builder.Definition = builder.Object
+
+	return &builder, nil
+}
+
+// Get returns EgressService object if found.
+func (builder *EgressServiceBuilder) Get() (*ovntypes.EgressService, error) {
+	if valid, err := builder.validate(); !valid {
+		return nil, err
+	}
+
+	glog.V(100).Infof("Collecting EgressService object %s in namespace %s",
+		builder.Definition.Name, builder.Definition.Namespace)
+
+	egressService := &ovntypes.EgressService{}
+	err := builder.apiClient.Get(context.TODO(), goclient.ObjectKey{
+		Name:      builder.Definition.Name,
+		Namespace: builder.Definition.Namespace,
+	}, egressService)
+
+	if err != nil {
+		glog.V(100).Infof("Failed to get EgressService %s in namespace %s",
+			builder.Definition.Name, builder.Definition.Namespace)
+
+		return nil, err
+	}
+
+	return egressService, nil
+}
+
+// Exists checks whether the given EgressService object exists in a cluster.
+func (builder *EgressServiceBuilder) Exists() bool {
+	if valid, _ := builder.validate(); !valid {
+		return false
+	}
+
+	glog.V(100).Infof("Checking if EgressService %s exists in namespace %s",
+		builder.Definition.Name, builder.Definition.Namespace)
+
+	var err error
+	builder.Object, err = builder.Get()
+
+	return err == nil || !k8serrors.IsNotFound(err)
+}
+
+// Create generates EgressService in a cluster and stores the created object in struct.
+func (builder *EgressServiceBuilder) Create() (*EgressServiceBuilder, error) {
+	if valid, err := builder.validate(); !valid {
+		return builder, err
+	}
+
+	glog.V(100).Infof("Creating the EgressService %s in namespace %s",
+		builder.Definition.Name, builder.Definition.Namespace)
+
+	if !builder.Exists() {
+		err := builder.apiClient.Create(context.TODO(), builder.Definition)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	builder.Object = builder.Definition
+
+	return builder, nil
+}
+
+// Delete removes EgressService object.
+func (builder *EgressServiceBuilder) Delete() error {
+	if valid, err := builder.validate(); !valid {
+		return err
+	}
+
+	glog.V(100).Infof("Deleting the EgressService object %s in namespace %s",
+		builder.Definition.Name, builder.Definition.Namespace)
+
+	if !builder.Exists() {
+		return nil
+	}
+
+	err := builder.apiClient.Delete(context.TODO(), builder.Object)
+
+	if err != nil {
+		return err
+	}
+
+	builder.Object = nil
+
+	return nil
+}
+
+// Update renovates the existing EgressService object with the EgressService definition in builder.
+func (builder *EgressServiceBuilder) Update(force bool) (*EgressServiceBuilder, error) {
+	if valid, err := builder.validate(); !valid {
+		return builder, err
+	}
+
+	glog.V(100).Infof("Updating the EgressService object %s in namespace %s",
+		builder.Definition.Name, builder.Definition.Namespace)
+
+	err := builder.apiClient.Update(context.TODO(), builder.Definition)
+
+	if err != nil {
+		if force {
+			glog.V(100).Infof(
+				msg.FailToUpdateNotification("EgressService", builder.Definition.Name, builder.Definition.Namespace))
+
+			err = builder.Delete()
+
+			if err != nil {
+				glog.V(100).Infof(
+					msg.FailToUpdateError("EgressService", builder.Definition.Name, builder.Definition.Namespace))
+
+				return nil, err
+			}
+
+			return builder.Create()
+		}
+
+		return nil, err
+	}
+
+	builder.Object = builder.Definition
+
+	return builder, nil
+}
+
+// WaitUntilHostAssigned waits for the duration of the defined timeout until a host is selected to handle the
+// service egress traffic and returns it. The host is ALL when the source IP is determined by the network.
+func (builder *EgressServiceBuilder) WaitUntilHostAssigned(timeout time.Duration) (string, error) {
+	if valid, err := builder.validate(); !valid {
+		return "", err
+	}
+
+	glog.V(100).Infof("Waiting until EgressService %s in namespace %s has a host assigned",
+		builder.Definition.Name, builder.Definition.Namespace)
+
+	var host string
+
+	err := wait.PollUntilContextTimeout(
+		context.TODO(), 3*time.Second, timeout, true, func(ctx context.Context) (bool, error) {
+			if !builder.Exists() {
+				glog.V(100).Infof("EgressService %s in namespace %s does not exist yet",
+					builder.Definition.Name, builder.Definition.Namespace)
+
+				return false, nil
+			}
+
+			host = builder.Object.Status.Host
+
+			return host != "", nil
+		})
+
+	return host, err
+}
+
+// validate will check that the builder and builder definition are properly initialized before
+// accessing any member fields.
+func (builder *EgressServiceBuilder) validate() (bool, error) {
+	resourceCRD := "EgressService"
+
+	if builder == nil {
+		glog.V(100).Infof("The %s builder is uninitialized", resourceCRD)
+
+		return false, fmt.Errorf("error: received nil %s builder", resourceCRD)
+	}
+
+	if builder.Definition == nil {
+		glog.V(100).Infof("The %s is undefined", resourceCRD)
+
+		builder.errorMsg = msg.UndefinedCrdObjectErrString(resourceCRD)
+	}
+
+	if builder.apiClient == nil {
+		glog.V(100).Infof("The %s builder apiclient is nil", resourceCRD)
+
+		builder.errorMsg = fmt.Sprintf("%s builder cannot have nil apiClient", resourceCRD)
+	}
+
+	if builder.errorMsg != "" {
+		glog.V(100).Infof("The %s builder has error message: %s", resourceCRD, builder.errorMsg)
+
+		return false, fmt.Errorf(builder.errorMsg)
+	}
+
+	return true, nil
+}
@@ -0,0 +1,197 @@
+package ovn
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/openshift-kni/eco-goinfra/pkg/clients"
+	"github.com/openshift-kni/eco-goinfra/pkg/schemes/ovn/ovntypes"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const defaultEgressServiceName = "egress-service"
+
+func TestNewEgressServiceBuilder(t *testing.T) {
+	testCases := []struct {
+		name          string
+		nsname        string
+		expectedError string
+	}{
+		{
+			name:          defaultEgressServiceName,
+			nsname:        defaultEgressNsName,
+			expectedError: "",
+		},
+		{
+			name:          "",
+			nsname:        defaultEgressNsName,
+			expectedError: "EgressService 'name' cannot be empty",
+		},
+		{
+			name:          defaultEgressServiceName,
+			nsname:        "",
+			expectedError: "EgressService 'nsname' cannot be empty",
+		},
+	}
+
+	for _, testCase := range testCases {
+		testBuilder := NewEgressServiceBuilder(
+			clients.GetTestClients(clients.TestClientParams{}), testCase.name, testCase.nsname)
+		assert.Equal(t, testCase.expectedError, testBuilder.errorMsg)
+	}
+}
+
+func TestPullEgressService(t *testing.T) {
+	testCases := []struct {
+		name                string
+		nsname              string
+		addToRuntimeObjects bool
+		client              bool
+		expectedError       error
+	}{
+		{
+			name:                defaultEgressServiceName,
+			nsname:              defaultEgressNsName,
+			addToRuntimeObjects: true,
+			client:              true,
+			expectedError:       nil,
+		},
+		{
+			name:                "",
+			nsname:              defaultEgressNsName,
+			addToRuntimeObjects: true,
+			client:              true,
+			expectedError:       fmt.Errorf("EgressService 'name' cannot be empty"),
+		},
+		{
+			name:                defaultEgressServiceName,
+			nsname:              "",
+			addToRuntimeObjects: true,
+			client:              true,
+			expectedError:       fmt.Errorf("EgressService 'namespace' cannot be empty"),
+		},
+		{
+			name:                defaultEgressServiceName,
+			nsname:              defaultEgressNsName,
+			addToRuntimeObjects: false,
+			client:              true,
+			expectedError: fmt.Errorf("EgressService object %s does not exist in namespace %s",
+				defaultEgressServiceName, defaultEgressNsName),
+		},
+		{
+			name:                defaultEgressServiceName,
+			nsname:              defaultEgressNsName,
+			addToRuntimeObjects: true,
+			client:              false,
+			expectedError:       fmt.Errorf("EgressService 'apiClient' cannot be empty"),
+		},
+	}
+
+	for _, testCase := range testCases {
+		var (
+			runtimeObjects []runtime.Object
+			testSettings   *clients.Settings
+		)
+
+		if testCase.addToRuntimeObjects {
+			runtimeObjects = append(runtimeObjects, buildDummyEgressService("worker-0"))
+		}
+
+		if testCase.client {
+			testSettings = clients.GetTestClients(clients.TestClientParams{K8sMockObjects: runtimeObjects})
+		}
+
+		testBuilder, err := PullEgressService(testSettings, testCase.name, testCase.nsname)
+		assert.Equal(t, testCase.expectedError, err)
+
+		if testCase.expectedError == nil {
+			assert.Equal(t, ovntypes.SourceIPLoadBalancer, testBuilder.Definition.Spec.SourceIPBy)
+		}
+	}
+}
+
+func TestEgressServiceWithOptions(t *testing.T) {
+	nodeSelector := metav1.LabelSelector{MatchLabels: map[string]string{"egress": ""}}
+
+	testBuilder := buildValidEgressServiceTestBuilder(clients.GetTestClients(clients.TestClientParams{})).
+		WithSourceIPBy(ovntypes.SourceIPNetwork).
+		WithNodeSelector(nodeSelector).
+		WithNetwork("100")
+	assert.Equal(t, "", testBuilder.errorMsg)
+	assert.Equal(t, ovntypes.EgressServiceSpec{
+		SourceIPBy:   ovntypes.SourceIPNetwork,
+		NodeSelector: nodeSelector,
+		Network:      "100",
+	}, testBuilder.Definition.Spec)
+
+	testBuilder = buildValidEgressServiceTestBuilder(clients.GetTestClients(clients.TestClientParams{})).
+		WithSourceIPBy("NodeIP")
+	assert.Equal(t, "EgressService sourceIPBy NodeIP is invalid, must be one of LoadBalancerIP or Network",
+		testBuilder.errorMsg)
+
+	testBuilder = buildValidEgressServiceTestBuilder(clients.GetTestClients(clients.TestClientParams{})).
+		WithNetwork("")
+	assert.Equal(t, "EgressService 'network' cannot be empty", testBuilder.errorMsg)
+
+	testBuilder = buildValidEgressServiceTestBuilder(clients.GetTestClients(clients.TestClientParams{})).WithOptions(
+		func(builder *EgressServiceBuilder) (*EgressServiceBuilder, error) {
+			return builder, fmt.Errorf("error adding additional option")
+		})
+	assert.Equal(t, "error adding additional option", testBuilder.errorMsg)
+}
+
+func TestEgressServiceCreateUpdateAndDelete(t *testing.T) {
+	testBuilder, err := buildValidEgressServiceTestBuilder(clients.GetTestClients(clients.TestClientParams{})).
+		WithSourceIPBy(ovntypes.SourceIPLoadBalancer).
+		Create()
+	assert.Nil(t, err)
+	assert.True(t, testBuilder.Exists())
+
+	testBuilder, err = testBuilder.WithNetwork("100").Update(false)
+	assert.Nil(t, err)
+	assert.Equal(t, "100", testBuilder.Object.Spec.Network)
+
+	err = testBuilder.Delete()
+	assert.Nil(t, err)
+	assert.Nil(t, testBuilder.Object)
+	assert.False(t, testBuilder.Exists())
+}
+
+func TestEgressServiceWaitUntilHostAssigned(t *testing.T) {
+	testSettings := clients.GetTestClients(clients.TestClientParams{
+		K8sMockObjects: []runtime.Object{buildDummyEgressService("worker-0")},
+	})
+
+	host, err := buildValidEgressServiceTestBuilder(testSettings).WaitUntilHostAssigned(time.Second)
+	assert.Nil(t, err)
+	assert.Equal(t, "worker-0", host)
+
+	testSettings = clients.GetTestClients(clients.TestClientParams{
+		K8sMockObjects: []runtime.Object{buildDummyEgressService("")},
+	})
+
+	_, err = buildValidEgressServiceTestBuilder(testSettings).WaitUntilHostAssigned(time.Second)
+	assert.NotNil(t, err)
+}
+
+func buildValidEgressServiceTestBuilder(apiClient *clients.Settings) *EgressServiceBuilder {
+	return NewEgressServiceBuilder(apiClient, defaultEgressServiceName, defaultEgressNsName)
+}
+
+func buildDummyEgressService(host string) *ovntypes.EgressService {
+	return &ovntypes.EgressService{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      defaultEgressServiceName,
+			Namespace: defaultEgressNsName,
+		},
+		Spec: ovntypes.EgressServiceSpec{
+			SourceIPBy: ovntypes.SourceIPLoadBalancer,
+		},
+		Status: ovntypes.EgressServiceStatus{
+			Host: host,
+		},
+	}
+}
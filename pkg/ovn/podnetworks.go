@@ -0,0 +1,120 @@
+package ovn
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+
+	"github.com/golang/glog"
+	"github.com/openshift-kni/eco-goinfra/pkg/pod"
+)
+
+const (
+	// PodNetworksAnnotation is the pod annotation where OVN-Kubernetes records the networks a pod is attached to.
+	PodNetworksAnnotation = "k8s.ovn.org/pod-networks"
+	// DefaultPodNetworkName is the key of the cluster default network in the pod networks annotation.
+	DefaultPodNetworkName = "default"
+
+	// PodNetworkRolePrimary is the role of the network used as the primary pod network.
+	PodNetworkRolePrimary = "primary"
+	// PodNetworkRoleSecondary is the role of a network attached as an additional pod interface.
+	PodNetworkRoleSecondary = "secondary"
+	// PodNetworkRoleInfrastructure is the role of the default network when a primary user-defined network is used.
+	// It only carries infrastructure traffic such as kubelet probes.
+	PodNetworkRoleInfrastructure = "infrastructure-locked"
+)
+
+// PodNetworkInfo describes the attachment of a pod to a network as reported in the pod networks annotation.
+type PodNetworkInfo struct {
+	IPAddresses []string `json:"ip_addresses"`
+	MACAddress  string   `json:"mac_address"`
+	GatewayIPs  []string `json:"gateway_ips,omitempty"`
+	Role        string   `json:"role,omitempty"`
+}
+
+// GetPodNetworks returns the networks the pod is attached to, keyed by network name. The cluster default network is
+// keyed as default and user-defined networks as namespace/name.
+func GetPodNetworks(podBuilder *pod.Builder) (map[string]PodNetworkInfo, error) {
+	if podBuilder == nil || podBuilder.Definition == nil {
+		glog.V(100).Infof("The podBuilder is empty")
+
+		return nil, fmt.Errorf("'podBuilder' cannot be empty")
+	}
+
+	if !podBuilder.Exists() {
+		return nil, fmt.Errorf("pod %s does not exist in namespace %s",
+			podBuilder.Definition.Name, podBuilder.Definition.Namespace)
+	}
+
+	glog.V(100).Infof("Getting networks of pod %s in namespace %s",
+		podBuilder.Object.Name, podBuilder.Object.Namespace)
+
+	annotation, ok := podBuilder.Object.Annotations[PodNetworksAnnotation]
+	if !ok {
+		return nil, fmt.Errorf("pod %s in namespace %s has no %s annotation",
+			podBuilder.Object.Name, podBuilder.Object.Namespace, PodNetworksAnnotation)
+	}
+
+	podNetworks := make(map[string]PodNetworkInfo)
+
+	err := json.Unmarshal([]byte(annotation), &podNetworks)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal %s annotation of pod %s in namespace %s: %w",
+			PodNetworksAnnotation, podBuilder.Object.Name, podBuilder.Object.Namespace, err)
+	}
+
+	return podNetworks, nil
+}
+
+// GetPodNetworkIPs returns the IP addresses, without prefix length, of the pod on the given network.
+func GetPodNetworkIPs(podBuilder *pod.Builder, networkName string) ([]string, error) {
+	podNetworks, err := GetPodNetworks(podBuilder)
+	if err != nil {
+		return nil, err
+	}
+
+	podNetwork, ok := podNetworks[networkName]
+	if !ok {
+		return nil, fmt.Errorf("pod %s in namespace %s is not attached to network %s",
+			podBuilder.Object.Name, podBuilder.Object.Namespace, networkName)
+	}
+
+	return stripPrefixLength(podNetwork.IPAddresses)
+}
+
+// GetPodPrimaryUDNIPs returns the IP addresses, without prefix length, of the pod on its primary user-defined
+// network.
+func GetPodPrimaryUDNIPs(podBuilder *pod.Builder) ([]string, error) {
+	podNetworks, err := GetPodNetworks(podBuilder)
+	if err != nil {
+		return nil, err
+	}
+
+	for networkName, podNetwork := range podNetworks {
+		if networkName != DefaultPodNetworkName && podNetwork.Role == PodNetworkRolePrimary {
+			glog.V(100).Infof("Found primary user-defined network %s of pod %s in namespace %s",
+				networkName, podBuilder.Object.Name, podBuilder.Object.Namespace)
+
+			return stripPrefixLength(podNetwork.IPAddresses)
+		}
+	}
+
+	return nil, fmt.Errorf("pod %s in namespace %s is not attached to a primary user-defined network",
+		podBuilder.Object.Name, podBuilder.Object.Namespace)
+}
+
+// stripPrefixLength converts the addresses in CIDR notation to plain IP addresses.
+func stripPrefixLength(ipAddresses []string) ([]string, error) {
+	var ips []string
+
+	for _, ipAddress := range ipAddresses {
+		ip, _, err := net.ParseCIDR(ipAddress)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse pod network address %s: %w", ipAddress, err)
+		}
+
+		ips = append(ips, ip.String())
+	}
+
+	return ips, nil
+}
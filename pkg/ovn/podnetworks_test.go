@@ -0,0 +1,98 @@
+package ovn
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/openshift-kni/eco-goinfra/pkg/clients"
+	"github.com/openshift-kni/eco-goinfra/pkg/pod"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const (
+	defaultPodNetworksPodName = "udn-pod"
+	defaultPodNetworks        = `{` +
+		`"default":{"ip_addresses":["10.128.2.15/23"],"mac_address":"0a:58:0a:80:02:0f",` +
+		`"role":"infrastructure-locked"},` +
+		`"test-ns/udn":{"ip_addresses":["10.100.0.5/16","2001:db8::5/64"],"mac_address":"0a:58:0a:64:00:05",` +
+		`"gateway_ips":["10.100.0.1"],"role":"primary"}}`
+)
+
+func TestGetPodNetworks(t *testing.T) {
+	testCases := []struct {
+		annotations   map[string]string
+		expectedError error
+	}{
+		{
+			annotations:   map[string]string{PodNetworksAnnotation: defaultPodNetworks},
+			expectedError: nil,
+		},
+		{
+			annotations: nil,
+			expectedError: fmt.Errorf("pod %s in namespace %s has no %s annotation",
+				defaultPodNetworksPodName, defaultEgressNsName, PodNetworksAnnotation),
+		},
+	}
+
+	for _, testCase := range testCases {
+		podNetworks, err := GetPodNetworks(buildPodNetworksTestPodBuilder(t, testCase.annotations))
+		assert.Equal(t, testCase.expectedError, err)
+
+		if testCase.expectedError == nil {
+			assert.Len(t, podNetworks, 2)
+			assert.Equal(t, PodNetworkRoleInfrastructure, podNetworks[DefaultPodNetworkName].Role)
+			assert.Equal(t, []string{"10.100.0.1"}, podNetworks["test-ns/udn"].GatewayIPs)
+		}
+	}
+
+	_, err := GetPodNetworks(nil)
+	assert.Equal(t, fmt.Errorf("'podBuilder' cannot be empty"), err)
+
+	_, err = GetPodNetworks(buildPodNetworksTestPodBuilder(t, map[string]string{PodNetworksAnnotation: "{"}))
+	assert.NotNil(t, err)
+}
+
+func TestGetPodNetworkIPs(t *testing.T) {
+	podBuilder := buildPodNetworksTestPodBuilder(t, map[string]string{PodNetworksAnnotation: defaultPodNetworks})
+
+	podIPs, err := GetPodNetworkIPs(podBuilder, DefaultPodNetworkName)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"10.128.2.15"}, podIPs)
+
+	_, err = GetPodNetworkIPs(podBuilder, "test-ns/other")
+	assert.Equal(t, fmt.Errorf("pod %s in namespace %s is not attached to network test-ns/other",
+		defaultPodNetworksPodName, defaultEgressNsName), err)
+}
+
+func TestGetPodPrimaryUDNIPs(t *testing.T) {
+	podIPs, err := GetPodPrimaryUDNIPs(
+		buildPodNetworksTestPodBuilder(t, map[string]string{PodNetworksAnnotation: defaultPodNetworks}))
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"10.100.0.5", "2001:db8::5"}, podIPs)
+
+	_, err = GetPodPrimaryUDNIPs(buildPodNetworksTestPodBuilder(t, map[string]string{
+		PodNetworksAnnotation: `{"default":{"ip_addresses":["10.128.2.15/23"],"role":"primary"}}`,
+	}))
+	assert.Equal(t, fmt.Errorf("pod %s in namespace %s is not attached to a primary user-defined network",
+		defaultPodNetworksPodName, defaultEgressNsName), err)
+}
+
+func buildPodNetworksTestPodBuilder(t *testing.T, annotations map[string]string) *pod.Builder {
+	t.Helper()
+
+	testSettings := clients.GetTestClients(clients.TestClientParams{K8sMockObjects: []runtime.Object{
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+			Name:        defaultPodNetworksPodName,
+			Namespace:   defaultEgressNsName,
+			Annotations: annotations,
+		}},
+	}})
+
+	podBuilder, err := pod.Pull(testSettings, defaultPodNetworksPodName, defaultEgressNsName)
+	assert.Nil(t, err)
+
+	return podBuilder
+}
@@ -0,0 +1,656 @@
+package ovn
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/openshift-kni/eco-goinfra/pkg/clients"
+	"github.com/openshift-kni/eco-goinfra/pkg/msg"
+	"github.com/openshift-kni/eco-goinfra/pkg/schemes/ovn/ovntypes"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	goclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// NetworkCreatedCondition is the condition type reporting whether the NetworkAttachmentDefinitions of a
+	// UserDefinedNetwork or ClusterUserDefinedNetwork were created.
+	NetworkCreatedCondition = "NetworkCreated"
+	// NetworkAllocationSucceededCondition is the condition type reporting whether the network was allocated on
+	// every node.
+	NetworkAllocationSucceededCondition = "NetworkAllocationSucceeded"
+
+	minNetworkMTU = 576
+	maxNetworkMTU = 65536
+
+	// networkDeletionTimeout is how long a forced Update waits for the deleted UserDefinedNetwork or
+	// ClusterUserDefinedNetwork to be removed before recreating it, since OVN-Kubernetes keeps a finalizer on it
+	// until the network is torn down.
+	networkDeletionTimeout = time.Minute
+)
+
+// UserDefinedNetworkBuilder provides struct for UserDefinedNetwork object which contains connection to cluster and
+// UserDefinedNetwork definition.
+type UserDefinedNetworkBuilder struct {
+	// UserDefinedNetwork definition. Used to create UserDefinedNetwork object.
+	Definition *ovntypes.UserDefinedNetwork
+	// Created UserDefinedNetwork object.
+	Object *ovntypes.UserDefinedNetwork
+	// Used in functions that define or mutate UserDefinedNetwork definitions. errorMsg is processed before
+	// UserDefinedNetwork object is created.
+	errorMsg string
+	// apiClient opens api connection to the cluster.
+	apiClient *clients.Settings
+}
+
+// UserDefinedNetworkAdditionalOptions additional options for UserDefinedNetwork object.
+type UserDefinedNetworkAdditionalOptions func(builder *UserDefinedNetworkBuilder) (*UserDefinedNetworkBuilder, error)
+
+// NewUserDefinedNetworkBuilder creates new instance of UserDefinedNetworkBuilder. The topology must be set using
+// WithLayer2 or WithLayer3 before the UserDefinedNetwork is created.
+func NewUserDefinedNetworkBuilder(apiClient *clients.Settings, name, nsname string) *UserDefinedNetworkBuilder {
+	glog.V(100).Infof(
+		"Initializing new UserDefinedNetwork structure with the name %s in the namespace %s", name, nsname)
+
+	builder := UserDefinedNetworkBuilder{
+		apiClient: apiClient,
+		Definition: &ovntypes.UserDefinedNetwork{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: nsname,
+			},
+		},
+	}
+
+	if name == "" {
+		glog.V(100).Infof("The name of the UserDefinedNetwork is empty")
+
+		builder.errorMsg = "UserDefinedNetwork 'name' cannot be empty"
+	}
+
+	if nsname == "" {
+		glog.V(100).Infof("The namespace of the UserDefinedNetwork is empty")
+
+		builder.errorMsg = "UserDefinedNetwork 'nsname' cannot be empty"
+	}
+
+	return &builder
+}
+
+// WithLayer2 sets the UserDefinedNetwork topology to Layer2 with the given role and subnets, at most one per IP
+// family. Any previously configured topology is replaced.
+func (builder *UserDefinedNetworkBuilder) WithLayer2(
+	role ovntypes.NetworkRole, subnets ...string) *UserDefinedNetworkBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Setting Layer2 topology with role %s and subnets %v to UserDefinedNetwork %s in namespace %s",
+		role, subnets, builder.Definition.Name, builder.Definition.Namespace)
+
+	layer2, err := newLayer2Config(role, subnets)
+	if err != nil {
+		builder.errorMsg = fmt.Sprintf("UserDefinedNetwork %v", err)
+
+		return builder
+	}
+
+	builder.Definition.Spec = ovntypes.UserDefinedNetworkSpec{
+		Topology: ovntypes.NetworkTopologyLayer2,
+		Layer2:   layer2,
+	}
+
+	return builder
+}
+
+// WithLayer3 sets the UserDefinedNetwork topology to Layer3 with the given role and subnets, at most one per IP
+// family. Any previously configured topology is replaced.
+func (builder *UserDefinedNetworkBuilder) WithLayer3(
+	role ovntypes.NetworkRole, subnets ...ovntypes.Layer3Subnet) *UserDefinedNetworkBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Setting Layer3 topology with role %s and subnets %v to UserDefinedNetwork %s in namespace %s",
+		role, subnets, builder.Definition.Name, builder.Definition.Namespace)
+
+	layer3, err := newLayer3Config(role, subnets)
+	if err != nil {
+		builder.errorMsg = fmt.Sprintf("UserDefinedNetwork %v", err)
+
+		return builder
+	}
+
+	builder.Definition.Spec = ovntypes.UserDefinedNetworkSpec{
+		Topology: ovntypes.NetworkTopologyLayer3,
+		Layer3:   layer3,
+	}
+
+	return builder
+}
+
+// WithMTU sets the MTU of the UserDefinedNetwork. The topology must be set first.
+func (builder *UserDefinedNetworkBuilder) WithMTU(mtu int32) *UserDefinedNetworkBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Setting MTU %d to UserDefinedNetwork %s in namespace %s",
+		mtu, builder.Definition.Name, builder.Definition.Namespace)
+
+	if err := setNetworkMTU(builder.networkSpec(), mtu); err != nil {
+		builder.errorMsg = fmt.Sprintf("UserDefinedNetwork %v", err)
+	}
+
+	return builder
+}
+
+// WithJoinSubnets sets the subnets used inside the OVN network topology, at most one per IP family. They can only be
+// set on networks with the Primary role.
+func (builder *UserDefinedNetworkBuilder) WithJoinSubnets(subnets ...string) *UserDefinedNetworkBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Setting join subnets %v to UserDefinedNetwork %s in namespace %s",
+		subnets, builder.Definition.Name, builder.Definition.Namespace)
+
+	if err := setNetworkJoinSubnets(builder.networkSpec(), subnets); err != nil {
+		builder.errorMsg = fmt.Sprintf("UserDefinedNetwork %v", err)
+	}
+
+	return builder
+}
+
+// WithIPAM sets the IPAM mode and lifecycle of a Layer2 UserDefinedNetwork. The lifecycle may be left empty and
+// can only be set when IPAM is enabled.
+func (builder *UserDefinedNetworkBuilder) WithIPAM(
+	mode ovntypes.IPAMMode, lifecycle ovntypes.NetworkIPAMLifecycle) *UserDefinedNetworkBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Setting IPAM mode %s and lifecycle %s to UserDefinedNetwork %s in namespace %s",
+		mode, lifecycle, builder.Definition.Name, builder.Definition.Namespace)
+
+	if err := setNetworkIPAM(builder.networkSpec(), mode, lifecycle); err != nil {
+		builder.errorMsg = fmt.Sprintf("UserDefinedNetwork %v", err)
+	}
+
+	return builder
+}
+
+// WithOptions creates UserDefinedNetwork with generic mutation options.
+func (builder *UserDefinedNetworkBuilder) WithOptions(
+	options ...UserDefinedNetworkAdditionalOptions) *UserDefinedNetworkBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Setting UserDefinedNetwork additional options")
+
+	for _, option := range options {
+		if option != nil {
+			builder, err := option(builder)
+
+			if err != nil {
+				glog.V(100).Infof("Error occurred in mutation function")
+
+				builder.errorMsg = err.Error()
+
+				return builder
+			}
+		}
+	}
+
+	return builder
+}
+
+// PullUserDefinedNetwork pulls existing UserDefinedNetwork from cluster.
+func PullUserDefinedNetwork(apiClient *clients.Settings, name, nsname string) (*UserDefinedNetworkBuilder, error) {
+	glog.V(100).Infof("Pulling existing UserDefinedNetwork name %s under namespace %s from cluster", name, nsname)
+
+	if apiClient == nil {
+		glog.V(100).Infof("The apiClient is empty")
+
+		return nil, fmt.Errorf("UserDefinedNetwork 'apiClient' cannot be empty")
+	}
+
+	builder := UserDefinedNetworkBuilder{
+		apiClient: apiClient,
+		Definition: &ovntypes.UserDefinedNetwork{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: nsname,
+			},
+		},
+	}
+
+	if name == "" {
+		glog.V(100).Infof("The name of the UserDefinedNetwork is empty")
+
+		return nil, fmt.Errorf("UserDefinedNetwork 'name' cannot be empty")
+	}
+
+	if nsname == "" {
+		glog.V(100).Infof("The namespace of the UserDefinedNetwork is empty")
+
+		return nil, fmt.Errorf("UserDefinedNetwork 'namespace' cannot be empty")
+	}
+
+	if !builder.Exists() {
+		return nil, fmt.Errorf("UserDefinedNetwork object %s does not exist in namespace %s", name, nsname)
+	}
+
+	builder.Definition = builder.Object
+
+	return &builder, nil
+}
+
+// Get returns UserDefinedNetwork object if found.
+func (builder *UserDefinedNetworkBuilder) Get() (*ovntypes.UserDefinedNetwork, error) {
+	if valid, err := builder.validate(); !valid {
+		return nil, err
+	}
+
+	glog.V(100).Infof("Collecting UserDefinedNetwork object %s in namespace %s",
+		builder.Definition.Name, builder.Definition.Namespace)
+
+	userDefinedNetwork := &ovntypes.UserDefinedNetwork{}
+	err := builder.apiClient.Get(context.TODO(), goclient.ObjectKey{
+		Name:      builder.Definition.Name,
+		Namespace: builder.Definition.Namespace,
+	}, userDefinedNetwork)
+
+	if err != nil {
+		glog.V(100).Infof("Failed to get UserDefinedNetwork %s in namespace %s",
+			builder.Definition.Name, builder.Definition.Namespace)
+
+		return nil, err
+	}
+
+	return userDefinedNetwork, nil
+}
+
+// Exists checks whether the given UserDefinedNetwork object exists in a cluster.
+func (builder *UserDefinedNetworkBuilder) Exists() bool {
+	if valid, _ := builder.validate(); !valid {
+		return false
+	}
+
+	glog.V(100).Infof("Checking if UserDefinedNetwork %s exists in namespace %s",
+		builder.Definition.Name, builder.Definition.Namespace)
+
+	var err error
+	builder.Object, err = builder.Get()
+
+	return err == nil || !k8serrors.IsNotFound(err)
+}
+
+// Create generates UserDefinedNetwork in a cluster and stores the created object in struct.
+func (builder *UserDefinedNetworkBuilder) Create() (*UserDefinedNetworkBuilder, error) {
+	if valid, err := builder.validate(); !valid {
+		return builder, err
+	}
+
+	glog.V(100).Infof("Creating the UserDefinedNetwork %s in namespace %s",
+		builder.Definition.Name, builder.Definition.Namespace)
+
+	if !builder.Exists() {
+		err := builder.apiClient.Create(context.TODO(), builder.Definition)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	builder.Object = builder.Definition
+
+	return builder, nil
+}
+
+// Delete removes UserDefinedNetwork object.
+func (builder *UserDefinedNetworkBuilder) Delete() error {
+	if valid, err := builder.validate(); !valid {
+		return err
+	}
+
+	glog.V(100).Infof("Deleting the UserDefinedNetwork object %s in namespace %s",
+		builder.Definition.Name, builder.Definition.Namespace)
+
+	if !builder.Exists() {
+		return nil
+	}
+
+	err := builder.apiClient.Delete(context.TODO(), builder.Object)
+
+	if err != nil {
+		return err
+	}
+
+	builder.Object = nil
+
+	return nil
+}
+
+// Update renovates the existing UserDefinedNetwork object with the UserDefinedNetwork definition in builder. Since
+// the spec of a UserDefinedNetwork is immutable, changing it requires force to delete and recreate the object.
+func (builder *UserDefinedNetworkBuilder) Update(force bool) (*UserDefinedNetworkBuilder, error) {
+	if valid, err := builder.validate(); !valid {
+		return builder, err
+	}
+
+	glog.V(100).Infof("Updating the UserDefinedNetwork object %s in namespace %s",
+		builder.Definition.Name, builder.Definition.Namespace)
+
+	if !builder.Exists() {
+		glog.V(100).Infof("UserDefinedNetwork %s does not exist", builder.Definition.Name)
+
+		return nil, fmt.Errorf("cannot update non-existent UserDefinedNetwork")
+	}
+
+	builder.Definition.ResourceVersion = builder.Object.ResourceVersion
+	err := builder.apiClient.Update(context.TODO(), builder.Definition)
+
+	if err != nil {
+		if force {
+			glog.V(100).Infof(
+				msg.FailToUpdateNotification("UserDefinedNetwork", builder.Definition.Name, builder.Definition.Namespace))
+
+			err = builder.Delete()
+			if err == nil {
+				err = waitForNetworkDeletion(builder.Exists)
+			}
+
+			if err != nil {
+				glog.V(100).Infof(
+					msg.FailToUpdateError("UserDefinedNetwork", builder.Definition.Name, builder.Definition.Namespace))
+
+				return nil, err
+			}
+
+			builder.Definition.ResourceVersion = ""
+
+			return builder.Create()
+		}
+
+		return nil, err
+	}
+
+	builder.Object = builder.Definition
+
+	return builder, nil
+}
+
+// WaitUntilConditionTrue waits for the duration of the defined timeout until the UserDefinedNetwork reports the
+// given condition type with status True.
+func (builder *UserDefinedNetworkBuilder) WaitUntilConditionTrue(conditionType string, timeout time.Duration) error {
+	if valid, err := builder.validate(); !valid {
+		return err
+	}
+
+	glog.V(100).Infof("Waiting until UserDefinedNetwork %s in namespace %s has condition %s True",
+		builder.Definition.Name, builder.Definition.Namespace, conditionType)
+
+	return wait.PollUntilContextTimeout(
+		context.TODO(), 3*time.Second, timeout, true, func(ctx context.Context) (bool, error) {
+			if !builder.Exists() {
+				glog.V(100).Infof("UserDefinedNetwork %s in namespace %s does not exist yet",
+					builder.Definition.Name, builder.Definition.Namespace)
+
+				return false, nil
+			}
+
+			return isNetworkConditionTrue(builder.Object.Status.Conditions, conditionType), nil
+		})
+}
+
+// WaitUntilNetworkCreated waits for the duration of the defined timeout until the UserDefinedNetwork reports its
+// network as created.
+func (builder *UserDefinedNetworkBuilder) WaitUntilNetworkCreated(timeout time.Duration) error {
+	return builder.WaitUntilConditionTrue(NetworkCreatedCondition, timeout)
+}
+
+// networkSpec returns a NetworkSpec sharing the topology configuration of the UserDefinedNetwork definition so
+// that the helpers shared with ClusterUserDefinedNetwork can mutate it.
+func (builder *UserDefinedNetworkBuilder) networkSpec() *ovntypes.NetworkSpec {
+	return &ovntypes.NetworkSpec{
+		Topology: builder.Definition.Spec.Topology,
+		Layer3:   builder.Definition.Spec.Layer3,
+		Layer2:   builder.Definition.Spec.Layer2,
+	}
+}
+
+// validate will check that the builder and builder definition are properly initialized before
+// accessing any member fields.
+func (builder *UserDefinedNetworkBuilder) validate() (bool, error) {
+	resourceCRD := "UserDefinedNetwork"
+
+	if builder == nil {
+		glog.V(100).Infof("The %s builder is uninitialized", resourceCRD)
+
+		return false, fmt.Errorf("error: received nil %s builder", resourceCRD)
+	}
+
+	if builder.Definition == nil {
+		glog.V(100).Infof("The %s is undefined", resourceCRD)
+
+		builder.errorMsg = msg.UndefinedCrdObjectErrString(resourceCRD)
+	}
+
+	if builder.apiClient == nil {
+		glog.V(100).Infof("The %s builder apiclient is nil", resourceCRD)
+
+		builder.errorMsg = fmt.Sprintf("%s builder cannot have nil apiClient", resourceCRD)
+	}
+
+	if builder.errorMsg != "" {
+		glog.V(100).Infof("The %s builder has error message: %s", resourceCRD, builder.errorMsg)
+
+		return false, fmt.Errorf(builder.errorMsg)
+	}
+
+	return true, nil
+}
+
+// newLayer2Config returns a validated Layer2 configuration with the given role and subnets.
+func newLayer2Config(role ovntypes.NetworkRole, subnets []string) (*ovntypes.Layer2Config, error) {
+	if err := validateNetworkRole(role); err != nil {
+		return nil, err
+	}
+
+	dualStackSubnets, err := parseDualStackCIDRs("subnets", subnets)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ovntypes.Layer2Config{Role: role, Subnets: dualStackSubnets}, nil
+}
+
+// newLayer3Config returns a validated Layer3 configuration with the given role and subnets.
+func newLayer3Config(role ovntypes.NetworkRole, subnets []ovntypes.Layer3Subnet) (*ovntypes.Layer3Config, error) {
+	if err := validateNetworkRole(role); err != nil {
+		return nil, err
+	}
+
+	if len(subnets) == 0 {
+		glog.V(100).Infof("The Layer3 subnets are empty")
+
+		return nil, fmt.Errorf("'subnets' cannot be empty for Layer3 topology")
+	}
+
+	cidrs := []string{}
+
+	for _, subnet := range subnets {
+		_, ipNet, err := net.ParseCIDR(string(subnet.CIDR))
+		if err != nil {
+			glog.V(100).Infof("The Layer3 subnet %s is not a valid CIDR", subnet.CIDR)
+
+			return nil, fmt.Errorf("subnets has invalid CIDR %s", subnet.CIDR)
+		}
+
+		prefixLength, bits := ipNet.Mask.Size()
+		if subnet.HostSubnet != 0 && (int(subnet.HostSubnet) <= prefixLength || int(subnet.HostSubnet) > bits) {
+			glog.V(100).Infof("The Layer3 host subnet %d does not fit subnet %s", subnet.HostSubnet, subnet.CIDR)
+
+			return nil, fmt.Errorf("host subnet %d must be longer than the prefix of subnet %s and at most %d",
+				subnet.HostSubnet, subnet.CIDR, bits)
+		}
+
+		cidrs = append(cidrs, string(subnet.CIDR))
+	}
+
+	if _, err := parseDualStackCIDRs("subnets", cidrs); err != nil {
+		return nil, err
+	}
+
+	return &ovntypes.Layer3Config{Role: role, Subnets: subnets}, nil
+}
+
+// setNetworkMTU sets the MTU on the configured topology of the network spec.
+func setNetworkMTU(spec *ovntypes.NetworkSpec, mtu int32) error {
+	if mtu < minNetworkMTU || mtu > maxNetworkMTU {
+		glog.V(100).Infof("The MTU %d is out of range", mtu)
+
+		return fmt.Errorf("MTU %d must be between %d and %d", mtu, minNetworkMTU, maxNetworkMTU)
+	}
+
+	switch {
+	case spec.Layer2 != nil:
+		spec.Layer2.MTU = mtu
+	case spec.Layer3 != nil:
+		spec.Layer3.MTU = mtu
+	case spec.Localnet != nil:
+		spec.Localnet.MTU = mtu
+	default:
+		return fmt.Errorf("topology must be set before the MTU")
+	}
+
+	return nil
+}
+
+// setNetworkJoinSubnets sets the join subnets on the Layer2 or Layer3 topology of a primary network.
+func setNetworkJoinSubnets(spec *ovntypes.NetworkSpec, subnets []string) error {
+	joinSubnets, err := parseDualStackCIDRs("joinSubnets", subnets)
+	if err != nil {
+		return err
+	}
+
+	if len(joinSubnets) == 0 {
+		glog.V(100).Infof("The join subnets are empty")
+
+		return fmt.Errorf("'joinSubnets' cannot be empty")
+	}
+
+	switch {
+	case spec.Layer2 != nil && spec.Layer2.Role == ovntypes.NetworkRolePrimary:
+		spec.Layer2.JoinSubnets = joinSubnets
+	case spec.Layer3 != nil && spec.Layer3.Role == ovntypes.NetworkRolePrimary:
+		spec.Layer3.JoinSubnets = joinSubnets
+	default:
+		return fmt.Errorf("join subnets can only be set on a Layer2 or Layer3 network with the %s role",
+			ovntypes.NetworkRolePrimary)
+	}
+
+	return nil
+}
+
+// setNetworkIPAM sets the IPAM configuration on the Layer2 or Localnet topology of the network spec.
+func setNetworkIPAM(
+	spec *ovntypes.NetworkSpec, mode ovntypes.IPAMMode, lifecycle ovntypes.NetworkIPAMLifecycle) error {
+	if mode != ovntypes.IPAMEnabled && mode != ovntypes.IPAMDisabled {
+		glog.V(100).Infof("The IPAM mode %s is invalid", mode)
+
+		return fmt.Errorf("IPAM mode %s is invalid, must be one of %s or %s",
+			mode, ovntypes.IPAMEnabled, ovntypes.IPAMDisabled)
+	}
+
+	if lifecycle != "" && lifecycle != ovntypes.IPAMLifecyclePersistent {
+		glog.V(100).Infof("The IPAM lifecycle %s is invalid", lifecycle)
+
+		return fmt.Errorf("IPAM lifecycle %s is invalid, must be %s", lifecycle, ovntypes.IPAMLifecyclePersistent)
+	}
+
+	if lifecycle != "" && mode == ovntypes.IPAMDisabled {
+		return fmt.Errorf("IPAM lifecycle cannot be set when IPAM is %s", ovntypes.IPAMDisabled)
+	}
+
+	ipam := &ovntypes.IPAMConfig{Mode: mode, Lifecycle: lifecycle}
+
+	switch {
+	case spec.Layer2 != nil:
+		spec.Layer2.IPAM = ipam
+	case spec.Localnet != nil:
+		spec.Localnet.IPAM = ipam
+	default:
+		return fmt.Errorf("IPAM can only be set on a Layer2 or Localnet network")
+	}
+
+	return nil
+}
+
+// validateNetworkRole checks that the role is either Primary or Secondary.
+func validateNetworkRole(role ovntypes.NetworkRole) error {
+	if role != ovntypes.NetworkRolePrimary && role != ovntypes.NetworkRoleSecondary {
+		glog.V(100).Infof("The network role %s is invalid", role)
+
+		return fmt.Errorf("role %s is invalid, must be one of %s or %s",
+			role, ovntypes.NetworkRolePrimary, ovntypes.NetworkRoleSecondary)
+	}
+
+	return nil
+}
+
+// parseDualStackCIDRs validates that the CIDRs are valid and contain at most one CIDR per IP family.
+func parseDualStackCIDRs(field string, cidrs []string) (ovntypes.DualStackCIDRs, error) {
+	var (
+		dualStackCIDRs ovntypes.DualStackCIDRs
+		hasIPv4        bool
+		hasIPv6        bool
+	)
+
+	for _, cidr := range cidrs {
+		ip, _, err := net.ParseCIDR(cidr)
+		if err != nil {
+			glog.V(100).Infof("The %s CIDR %s is invalid", field, cidr)
+
+			return nil, fmt.Errorf("%s has invalid CIDR %s", field, cidr)
+		}
+
+		isIPv4 := ip.To4() != nil
+		if (isIPv4 && hasIPv4) || (!isIPv4 && hasIPv6) {
+			glog.V(100).Infof("The %s contain more than one CIDR of the IP family of %s", field, cidr)
+
+			return nil, fmt.Errorf("%s can contain at most one CIDR per IP family", field)
+		}
+
+		hasIPv4 = hasIPv4 || isIPv4
+		hasIPv6 = hasIPv6 || !isIPv4
+		dualStackCIDRs = append(dualStackCIDRs, ovntypes.CIDR(cidr))
+	}
+
+	return dualStackCIDRs, nil
+}
+
+// waitForNetworkDeletion waits until the deleted UserDefinedNetwork or ClusterUserDefinedNetwork no longer exists.
+func waitForNetworkDeletion(exists func() bool) error {
+	return wait.PollUntilContextTimeout(
+		context.TODO(), time.Second, networkDeletionTimeout, true, func(ctx context.Context) (bool, error) {
+			return !exists(), nil
+		})
+}
+
+// isNetworkConditionTrue checks whether the conditions contain the given condition type with status True.
+func isNetworkConditionTrue(conditions []metav1.Condition, conditionType string) bool {
+	for _, condition := range conditions {
+		if condition.Type == conditionType {
+			return condition.Status == metav1.ConditionTrue
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,343 @@
+package ovn
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/openshift-kni/eco-goinfra/pkg/clients"
+	"github.com/openshift-kni/eco-goinfra/pkg/schemes/ovn/ovntypes"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	goclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+const defaultUDNName = "udn"
+
+func TestNewUserDefinedNetworkBuilder(t *testing.T) {
+	testCases := []struct {
+		name          string
+		nsname        string
+		expectedError string
+	}{
+		{
+			name:          defaultUDNName,
+			nsname:        defaultEgressNsName,
+			expectedError: "",
+		},
+		{
+			name:          "",
+			nsname:        defaultEgressNsName,
+			expectedError: "UserDefinedNetwork 'name' cannot be empty",
+		},
+		{
+			name:          defaultUDNName,
+			nsname:        "",
+			expectedError: "UserDefinedNetwork 'nsname' cannot be empty",
+		},
+	}
+
+	for _, testCase := range testCases {
+		testBuilder := NewUserDefinedNetworkBuilder(
+			clients.GetTestClients(clients.TestClientParams{}), testCase.name, testCase.nsname)
+		assert.Equal(t, testCase.expectedError, testBuilder.errorMsg)
+	}
+}
+
+func TestPullUserDefinedNetwork(t *testing.T) {
+	testCases := []struct {
+		name                string
+		nsname              string
+		addToRuntimeObjects bool
+		client              bool
+		expectedError       error
+	}{
+		{
+			name:                defaultUDNName,
+			nsname:              defaultEgressNsName,
+			addToRuntimeObjects: true,
+			client:              true,
+			expectedError:       nil,
+		},
+		{
+			name:                "",
+			nsname:              defaultEgressNsName,
+			addToRuntimeObjects: true,
+			client:              true,
+			expectedError:       fmt.Errorf("UserDefinedNetwork 'name' cannot be empty"),
+		},
+		{
+			name:                defaultUDNName,
+			nsname:              "",
+			addToRuntimeObjects: true,
+			client:              true,
+			expectedError:       fmt.Errorf("UserDefinedNetwork 'namespace' cannot be empty"),
+		},
+		{
+			name:                defaultUDNName,
+			nsname:              defaultEgressNsName,
+			addToRuntimeObjects: false,
+			client:              true,
+			expectedError: fmt.Errorf("UserDefinedNetwork object %s does not exist in namespace %s",
+				defaultUDNName, defaultEgressNsName),
+		},
+		{
+			name:                defaultUDNName,
+			nsname:              defaultEgressNsName,
+			addToRuntimeObjects: true,
+			client:              false,
+			expectedError:       fmt.Errorf("UserDefinedNetwork 'apiClient' cannot be empty"),
+		},
+	}
+
+	for _, testCase := range testCases {
+		var (
+			runtimeObjects []runtime.Object
+			testSettings   *clients.Settings
+		)
+
+		if testCase.addToRuntimeObjects {
+			runtimeObjects = append(runtimeObjects, buildDummyUserDefinedNetwork(metav1.ConditionTrue))
+		}
+
+		if testCase.client {
+			testSettings = clients.GetTestClients(clients.TestClientParams{K8sMockObjects: runtimeObjects})
+		}
+
+		testBuilder, err := PullUserDefinedNetwork(testSettings, testCase.name, testCase.nsname)
+		assert.Equal(t, testCase.expectedError, err)
+
+		if testCase.expectedError == nil {
+			assert.Equal(t, ovntypes.NetworkTopologyLayer2, testBuilder.Definition.Spec.Topology)
+		}
+	}
+}
+
+func TestUserDefinedNetworkWithLayer2(t *testing.T) {
+	testBuilder := buildValidUserDefinedNetworkTestBuilder(clients.GetTestClients(clients.TestClientParams{})).
+		WithLayer2(ovntypes.NetworkRolePrimary, "10.100.0.0/16", "2001:db8::/64").
+		WithMTU(1400).
+		WithJoinSubnets("100.65.0.0/16").
+		WithIPAM(ovntypes.IPAMEnabled, ovntypes.IPAMLifecyclePersistent)
+	assert.Equal(t, "", testBuilder.errorMsg)
+	assert.Equal(t, ovntypes.UserDefinedNetworkSpec{
+		Topology: ovntypes.NetworkTopologyLayer2,
+		Layer2: &ovntypes.Layer2Config{
+			Role:        ovntypes.NetworkRolePrimary,
+			MTU:         1400,
+			Subnets:     ovntypes.DualStackCIDRs{"10.100.0.0/16", "2001:db8::/64"},
+			JoinSubnets: ovntypes.DualStackCIDRs{"100.65.0.0/16"},
+			IPAM: &ovntypes.IPAMConfig{
+				Mode:      ovntypes.IPAMEnabled,
+				Lifecycle: ovntypes.IPAMLifecyclePersistent,
+			},
+		},
+	}, testBuilder.Definition.Spec)
+}
+
+func TestUserDefinedNetworkWithLayer3(t *testing.T) {
+	subnet := ovntypes.Layer3Subnet{CIDR: "10.200.0.0/16", HostSubnet: 24}
+
+	testBuilder := buildValidUserDefinedNetworkTestBuilder(clients.GetTestClients(clients.TestClientParams{})).
+		WithLayer3(ovntypes.NetworkRoleSecondary, subnet).
+		WithMTU(9000)
+	assert.Equal(t, "", testBuilder.errorMsg)
+	assert.Equal(t, ovntypes.UserDefinedNetworkSpec{
+		Topology: ovntypes.NetworkTopologyLayer3,
+		Layer3: &ovntypes.Layer3Config{
+			Role:    ovntypes.NetworkRoleSecondary,
+			MTU:     9000,
+			Subnets: []ovntypes.Layer3Subnet{subnet},
+		},
+	}, testBuilder.Definition.Spec)
+}
+
+func TestUserDefinedNetworkWithOptionsErrors(t *testing.T) {
+	testCases := []struct {
+		mutate        func(builder *UserDefinedNetworkBuilder) *UserDefinedNetworkBuilder
+		expectedError string
+	}{
+		{
+			mutate: func(builder *UserDefinedNetworkBuilder) *UserDefinedNetworkBuilder {
+				return builder.WithLayer2("Tertiary", "10.100.0.0/16")
+			},
+			expectedError: "UserDefinedNetwork role Tertiary is invalid, must be one of Primary or Secondary",
+		},
+		{
+			mutate: func(builder *UserDefinedNetworkBuilder) *UserDefinedNetworkBuilder {
+				return builder.WithLayer2(ovntypes.NetworkRolePrimary, "10.100.0.0")
+			},
+			expectedError: "UserDefinedNetwork subnets has invalid CIDR 10.100.0.0",
+		},
+		{
+			mutate: func(builder *UserDefinedNetworkBuilder) *UserDefinedNetworkBuilder {
+				return builder.WithLayer2(ovntypes.NetworkRolePrimary, "10.100.0.0/16", "10.101.0.0/16")
+			},
+			expectedError: "UserDefinedNetwork subnets can contain at most one CIDR per IP family",
+		},
+		{
+			mutate: func(builder *UserDefinedNetworkBuilder) *UserDefinedNetworkBuilder {
+				return builder.WithLayer3(ovntypes.NetworkRolePrimary)
+			},
+			expectedError: "UserDefinedNetwork 'subnets' cannot be empty for Layer3 topology",
+		},
+		{
+			mutate: func(builder *UserDefinedNetworkBuilder) *UserDefinedNetworkBuilder {
+				return builder.WithLayer3(
+					ovntypes.NetworkRolePrimary, ovntypes.Layer3Subnet{CIDR: "10.200.0.0/16", HostSubnet: 16})
+			},
+			expectedError: "UserDefinedNetwork host subnet 16 must be longer than the prefix of subnet " +
+				"10.200.0.0/16 and at most 32",
+		},
+		{
+			mutate: func(builder *UserDefinedNetworkBuilder) *UserDefinedNetworkBuilder {
+				return builder.WithMTU(1400)
+			},
+			expectedError: "UserDefinedNetwork topology must be set before the MTU",
+		},
+		{
+			mutate: func(builder *UserDefinedNetworkBuilder) *UserDefinedNetworkBuilder {
+				return builder.WithLayer2(ovntypes.NetworkRolePrimary, "10.100.0.0/16").WithMTU(100)
+			},
+			expectedError: "UserDefinedNetwork MTU 100 must be between 576 and 65536",
+		},
+		{
+			mutate: func(builder *UserDefinedNetworkBuilder) *UserDefinedNetworkBuilder {
+				return builder.WithLayer2(ovntypes.NetworkRoleSecondary, "10.100.0.0/16").WithJoinSubnets("100.65.0.0/16")
+			},
+			expectedError: "UserDefinedNetwork join subnets can only be set on a Layer2 or Layer3 network with the " +
+				"Primary role",
+		},
+		{
+			mutate: func(builder *UserDefinedNetworkBuilder) *UserDefinedNetworkBuilder {
+				return builder.WithLayer3(ovntypes.NetworkRolePrimary, ovntypes.Layer3Subnet{CIDR: "10.200.0.0/16"}).
+					WithIPAM(ovntypes.IPAMEnabled, "")
+			},
+			expectedError: "UserDefinedNetwork IPAM can only be set on a Layer2 or Localnet network",
+		},
+		{
+			mutate: func(builder *UserDefinedNetworkBuilder) *UserDefinedNetworkBuilder {
+				return builder.WithLayer2(ovntypes.NetworkRoleSecondary).
+					WithIPAM(ovntypes.IPAMDisabled, ovntypes.IPAMLifecyclePersistent)
+			},
+			expectedError: "UserDefinedNetwork IPAM lifecycle cannot be set when IPAM is Disabled",
+		},
+		{
+			mutate: func(builder *UserDefinedNetworkBuilder) *UserDefinedNetworkBuilder {
+				return builder.WithOptions(func(builder *UserDefinedNetworkBuilder) (*UserDefinedNetworkBuilder, error) {
+					return builder, fmt.Errorf("error adding additional option")
+				})
+			},
+			expectedError: "error adding additional option",
+		},
+	}
+
+	for _, testCase := range testCases {
+		testBuilder := testCase.mutate(
+			buildValidUserDefinedNetworkTestBuilder(clients.GetTestClients(clients.TestClientParams{})))
+		assert.Equal(t, testCase.expectedError, testBuilder.errorMsg)
+	}
+}
+
+func TestUserDefinedNetworkCreateUpdateAndDelete(t *testing.T) {
+	testBuilder, err := buildValidUserDefinedNetworkTestBuilder(clients.GetTestClients(clients.TestClientParams{})).
+		WithLayer2(ovntypes.NetworkRolePrimary, "10.100.0.0/16").
+		Create()
+	assert.Nil(t, err)
+	assert.True(t, testBuilder.Exists())
+
+	testBuilder, err = testBuilder.WithMTU(1400).Update(false)
+	assert.Nil(t, err)
+	assert.Equal(t, int32(1400), testBuilder.Object.Spec.Layer2.MTU)
+
+	err = testBuilder.Delete()
+	assert.Nil(t, err)
+	assert.Nil(t, testBuilder.Object)
+	assert.False(t, testBuilder.Exists())
+
+	_, err = testBuilder.Update(false)
+	assert.Equal(t, fmt.Errorf("cannot update non-existent UserDefinedNetwork"), err)
+}
+
+func TestUserDefinedNetworkForceUpdate(t *testing.T) {
+	testSettings := clients.GetTestClients(clients.TestClientParams{})
+
+	testBuilder, err := buildValidUserDefinedNetworkTestBuilder(testSettings).
+		WithLayer2(ovntypes.NetworkRolePrimary, "10.100.0.0/16").
+		Create()
+	assert.Nil(t, err)
+
+	rejectNetworkUpdates(testSettings)
+
+	_, err = testBuilder.WithMTU(1400).Update(false)
+	assert.Equal(t, errImmutableNetworkSpec, err)
+
+	testBuilder, err = testBuilder.Update(true)
+	assert.Nil(t, err)
+	assert.Equal(t, int32(1400), testBuilder.Object.Spec.Layer2.MTU)
+
+	pulledBuilder, err := PullUserDefinedNetwork(
+		testSettings, testBuilder.Definition.Name, testBuilder.Definition.Namespace)
+	assert.Nil(t, err)
+	assert.Equal(t, int32(1400), pulledBuilder.Object.Spec.Layer2.MTU)
+}
+
+// errImmutableNetworkSpec is returned for every update of a network object by the client of rejectNetworkUpdates.
+var errImmutableNetworkSpec = fmt.Errorf("spec.network is immutable")
+
+// rejectNetworkUpdates makes the test client reject every update, as the API server does for changes to the
+// immutable spec of UserDefinedNetwork and ClusterUserDefinedNetwork objects.
+func rejectNetworkUpdates(testSettings *clients.Settings) {
+	testSettings.Client = interceptor.NewClient(testSettings.Client.(goclient.WithWatch), interceptor.Funcs{
+		Update: func(
+			ctx context.Context, client goclient.WithWatch, obj goclient.Object, opts ...goclient.UpdateOption) error {
+			return errImmutableNetworkSpec
+		},
+	})
+}
+
+func TestUserDefinedNetworkWaitUntilNetworkCreated(t *testing.T) {
+	testSettings := clients.GetTestClients(clients.TestClientParams{
+		K8sMockObjects: []runtime.Object{buildDummyUserDefinedNetwork(metav1.ConditionTrue)},
+	})
+
+	err := buildValidUserDefinedNetworkTestBuilder(testSettings).WaitUntilNetworkCreated(time.Second)
+	assert.Nil(t, err)
+
+	err = buildValidUserDefinedNetworkTestBuilder(testSettings).
+		WaitUntilConditionTrue(NetworkAllocationSucceededCondition, time.Second)
+	assert.NotNil(t, err)
+
+	testSettings = clients.GetTestClients(clients.TestClientParams{
+		K8sMockObjects: []runtime.Object{buildDummyUserDefinedNetwork(metav1.ConditionFalse)},
+	})
+
+	err = buildValidUserDefinedNetworkTestBuilder(testSettings).WaitUntilNetworkCreated(time.Second)
+	assert.NotNil(t, err)
+}
+
+func buildValidUserDefinedNetworkTestBuilder(apiClient *clients.Settings) *UserDefinedNetworkBuilder {
+	return NewUserDefinedNetworkBuilder(apiClient, defaultUDNName, defaultEgressNsName)
+}
+
+func buildDummyUserDefinedNetwork(status metav1.ConditionStatus) *ovntypes.UserDefinedNetwork {
+	return &ovntypes.UserDefinedNetwork{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      defaultUDNName,
+			Namespace: defaultEgressNsName,
+		},
+		Spec: ovntypes.UserDefinedNetworkSpec{
+			Topology: ovntypes.NetworkTopologyLayer2,
+			Layer2: &ovntypes.Layer2Config{
+				Role:    ovntypes.NetworkRolePrimary,
+				Subnets: ovntypes.DualStackCIDRs{"10.100.0.0/16"},
+			},
+		},
+		Status: ovntypes.UserDefinedNetworkStatus{
+			Conditions: []metav1.Condition{{Type: NetworkCreatedCondition, Status: status}},
+		},
+	}
+}
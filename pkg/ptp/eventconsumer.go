@@ -0,0 +1,609 @@
+package ptp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+const (
+	// EventResourceSyncState is the resource address suffix of the overall node synchronization state.
+	EventResourceSyncState = "/sync/sync-status/sync-state"
+	// EventResourceLockState is the resource address suffix of the ptp4l lock state.
+	EventResourceLockState = "/sync/ptp-status/lock-state"
+	// EventResourceOsClockSyncState is the resource address suffix of the system clock synchronization state.
+	EventResourceOsClockSyncState = "/sync/sync-status/os-clock-sync-state"
+	// EventResourceGnssSyncState is the resource address suffix of the GNSS synchronization state.
+	EventResourceGnssSyncState = "/sync/gnss-status/gnss-sync-state"
+	// EventResourceClockClass is the resource address suffix of the PTP clock class.
+	EventResourceClockClass = "/sync/ptp-status/clock-class"
+
+	// EventTypeSyncStateChange is the type of the events published for EventResourceSyncState.
+	EventTypeSyncStateChange = "event.sync.sync-status.synchronization-state-change"
+	// EventTypeLockStateChange is the type of the events published for EventResourceLockState.
+	EventTypeLockStateChange = "event.sync.ptp-status.ptp-state-change"
+	// EventTypeOsClockSyncStateChange is the type of the events published for EventResourceOsClockSyncState.
+	EventTypeOsClockSyncStateChange = "event.sync.sync-status.os-clock-sync-state-change"
+	// EventTypeGnssSyncStateChange is the type of the events published for EventResourceGnssSyncState.
+	EventTypeGnssSyncStateChange = "event.sync.gnss-status.gnss-state-change"
+	// EventTypeClockClassChange is the type of the events published for EventResourceClockClass.
+	EventTypeClockClassChange = "event.sync.ptp-status.ptp-clock-class-change"
+
+	eventDataTypeNotification = "notification"
+	eventConsumerPath         = "/event"
+)
+
+// CloudEvent is a structured mode cloud event as published by the cloud-event-proxy.
+type CloudEvent struct {
+	ID          string    `json:"id"`
+	Type        string    `json:"type"`
+	Source      string    `json:"source"`
+	SpecVersion string    `json:"specversion"`
+	Time        time.Time `json:"time"`
+	Data        EventData `json:"data"`
+}
+
+// EventData is the payload of a PTP cloud event.
+type EventData struct {
+	Version string           `json:"version"`
+	Values  []EventDataValue `json:"values"`
+}
+
+// EventDataValue is a single notification or metric value of a PTP cloud event.
+type EventDataValue struct {
+	ResourceAddress string      `json:"ResourceAddress"`
+	DataType        string      `json:"data_type"`
+	ValueType       string      `json:"value_type"`
+	Value           interface{} `json:"value"`
+}
+
+// GetState returns the value of the first notification of the event, e.g. LOCKED or FREERUN. An empty string is
+// returned if the event does not contain any notification.
+func (event CloudEvent) GetState() string {
+	for _, value := range event.Data.Values {
+		if value.DataType == eventDataTypeNotification {
+			return fmt.Sprint(value.Value)
+		}
+	}
+
+	return ""
+}
+
+// RecordedEvent is a cloud event together with the time it was received by the EventConsumer.
+type RecordedEvent struct {
+	ReceivedAt time.Time
+	Event      CloudEvent
+}
+
+// eventSubscription is the subscription object of the cloud-event-proxy REST API.
+type eventSubscription struct {
+	ID              string `json:"SubscriptionId,omitempty"`
+	EndpointURI     string `json:"EndpointUri"`
+	ResourceAddress string `json:"ResourceAddress"`
+	URILocation     string `json:"UriLocation,omitempty"`
+}
+
+// EventConsumer subscribes to the cloud-event-proxy REST API and records the PTP events delivered to it. The
+// publisher URL may be a port-forwarded address or the publisher service when running in a pod on the cluster. Events
+// are delivered by the publisher to the endpoint URI, so it must be reachable from the publisher. When it is not, for
+// instance when only a port-forward is available, RecordCurrentState can be polled instead.
+type EventConsumer struct {
+	// publisherURL is the base URL of the REST API, e.g. http://localhost:9043/api/ocloudNotifications/v2.
+	publisherURL string
+	// listenAddress is the local address the consumer server listens on for events.
+	listenAddress string
+	// endpointURI is the URI the publisher delivers events to, as provided to NewEventConsumer.
+	endpointURI string
+	// derivedEndpointURI is the URI derived from the listener address when no endpointURI was provided. It is only set
+	// while the consumer is started since the listener address may change between starts.
+	derivedEndpointURI string
+	// Used to store latest error message upon defining or mutating the consumer.
+	errorMsg string
+	// httpClient is used to send requests to the REST API.
+	httpClient *http.Client
+
+	mutex         sync.Mutex
+	server        *http.Server
+	listener      net.Listener
+	events        []RecordedEvent
+	subscriptions []eventSubscription
+}
+
+// NewEventConsumer creates new instance of EventConsumer. The listenAddress is the local address, such as :9043 or
+// 127.0.0.1:0, events are received on. The endpointURI is where the publisher delivers events to, if empty it defaults
+// to the /event path of the listen address once the consumer is started. Since the publisher cannot deliver events to
+// a wildcard address, the endpointURI is required when listening on all interfaces, e.g. on :9043 or 0.0.0.0:9043.
+func NewEventConsumer(publisherURL, listenAddress, endpointURI string) *EventConsumer {
+	glog.V(100).Infof("Initializing new PTP EventConsumer for publisher %s listening on %s", publisherURL, listenAddress)
+
+	consumer := EventConsumer{
+		publisherURL:  strings.TrimSuffix(publisherURL, "/"),
+		listenAddress: listenAddress,
+		endpointURI:   endpointURI,
+		httpClient:    &http.Client{Timeout: 10 * time.Second},
+	}
+
+	if publisherURL == "" {
+		glog.V(100).Infof("The publisherURL of the EventConsumer is empty")
+
+		consumer.errorMsg = "EventConsumer 'publisherURL' cannot be empty"
+
+		return &consumer
+	}
+
+	if listenAddress == "" {
+		glog.V(100).Infof("The listenAddress of the EventConsumer is empty")
+
+		consumer.errorMsg = "EventConsumer 'listenAddress' cannot be empty"
+
+		return &consumer
+	}
+
+	if endpointURI == "" && isWildcardAddress(listenAddress) {
+		glog.V(100).Infof("The EventConsumer listens on wildcard address %s without an endpointURI", listenAddress)
+
+		consumer.errorMsg = "EventConsumer 'endpointURI' cannot be empty when listening on a wildcard address"
+	}
+
+	return &consumer
+}
+
+// GetNodeResourceAddress returns the full resource address of the given resource on the given node, e.g.
+// /cluster/node/worker-0/sync/ptp-status/lock-state.
+func GetNodeResourceAddress(nodeName, resource string) string {
+	return fmt.Sprintf("/cluster/node/%s%s", nodeName, resource)
+}
+
+// Start starts the server receiving the events delivered by the publisher.
+func (consumer *EventConsumer) Start() error {
+	if valid, err := consumer.validate(); !valid {
+		return err
+	}
+
+	glog.V(100).Infof("Starting PTP EventConsumer on %s", consumer.listenAddress)
+
+	consumer.mutex.Lock()
+	defer consumer.mutex.Unlock()
+
+	if consumer.server != nil {
+		return fmt.Errorf("failed to start EventConsumer, it is already started")
+	}
+
+	listener, err := net.Listen("tcp", consumer.listenAddress)
+	if err != nil {
+		glog.V(100).Infof("Failed to listen on %s: %v", consumer.listenAddress, err)
+
+		return err
+	}
+
+	if consumer.endpointURI == "" {
+		consumer.derivedEndpointURI = fmt.Sprintf("http://%s%s", listener.Addr().String(), eventConsumerPath)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(eventConsumerPath, consumer.handleEvent)
+	mux.HandleFunc("/health", func(writer http.ResponseWriter, _ *http.Request) {
+		writer.WriteHeader(http.StatusOK)
+	})
+
+	consumer.listener = listener
+	consumer.server = &http.Server{Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+
+	go func(server *http.Server) {
+		err := server.Serve(listener)
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
+			glog.V(100).Infof("PTP EventConsumer server stopped unexpectedly: %v", err)
+		}
+	}(consumer.server)
+
+	return nil
+}
+
+// Stop deletes the subscriptions of the consumer and stops the server receiving events.
+func (consumer *EventConsumer) Stop() error {
+	if valid, err := consumer.validate(); !valid {
+		return err
+	}
+
+	glog.V(100).Infof("Stopping PTP EventConsumer on %s", consumer.listenAddress)
+
+	err := consumer.Unsubscribe()
+
+	consumer.mutex.Lock()
+	defer consumer.mutex.Unlock()
+
+	if consumer.server != nil {
+		shutdownErr := consumer.server.Shutdown(context.TODO())
+		if err == nil {
+			err = shutdownErr
+		}
+
+		// The server may not track the listener yet if it was stopped right after starting, so it is closed here to
+		// release the address before returning.
+		_ = consumer.listener.Close()
+
+		consumer.server = nil
+		consumer.listener = nil
+		consumer.derivedEndpointURI = ""
+	}
+
+	return err
+}
+
+// GetEndpointURI returns the URI the publisher delivers events to. If it was not provided to NewEventConsumer, it is
+// only known while the consumer is started.
+func (consumer *EventConsumer) GetEndpointURI() string {
+	if valid, _ := consumer.validate(); !valid {
+		return ""
+	}
+
+	consumer.mutex.Lock()
+	defer consumer.mutex.Unlock()
+
+	if consumer.endpointURI != "" {
+		return consumer.endpointURI
+	}
+
+	return consumer.derivedEndpointURI
+}
+
+// Subscribe creates a subscription for each of the given resource addresses delivering events to the consumer.
+func (consumer *EventConsumer) Subscribe(resourceAddresses ...string) error {
+	if valid, err := consumer.validate(); !valid {
+		return err
+	}
+
+	glog.V(100).Infof("Subscribing PTP EventConsumer to resources %v", resourceAddresses)
+
+	if len(resourceAddresses) == 0 {
+		return fmt.Errorf("failed to subscribe, 'resourceAddresses' parameter is empty")
+	}
+
+	endpointURI := consumer.GetEndpointURI()
+	if endpointURI == "" {
+		return fmt.Errorf("failed to subscribe, consumer has no endpoint URI and is not started")
+	}
+
+	for _, resourceAddress := range resourceAddresses {
+		if resourceAddress == "" {
+			return fmt.Errorf("failed to subscribe, resource address cannot be empty")
+		}
+
+		subscription := eventSubscription{EndpointURI: endpointURI, ResourceAddress: resourceAddress}
+
+		err := consumer.doRequest(
+			http.MethodPost, consumer.publisherURL+"/subscriptions", &subscription, &subscription, http.StatusCreated)
+		if err != nil {
+			glog.V(100).Infof("Failed to subscribe to resource %s: %v", resourceAddress, err)
+
+			return fmt.Errorf("failed to subscribe to resource %s: %w", resourceAddress, err)
+		}
+
+		consumer.mutex.Lock()
+		consumer.subscriptions = append(consumer.subscriptions, subscription)
+		consumer.mutex.Unlock()
+	}
+
+	return nil
+}
+
+// Unsubscribe deletes all the subscriptions created by the consumer.
+func (consumer *EventConsumer) Unsubscribe() error {
+	if valid, err := consumer.validate(); !valid {
+		return err
+	}
+
+	consumer.mutex.Lock()
+	subscriptions := consumer.subscriptions
+	consumer.subscriptions = nil
+	consumer.mutex.Unlock()
+
+	glog.V(100).Infof("Deleting %d subscriptions of PTP EventConsumer", len(subscriptions))
+
+	var failedSubscriptions []eventSubscription
+
+	for _, subscription := range subscriptions {
+		err := consumer.doRequest(http.MethodDelete,
+			fmt.Sprintf("%s/subscriptions/%s", consumer.publisherURL, subscription.ID), nil, nil, http.StatusNoContent)
+		if err != nil {
+			glog.V(100).Infof("Failed to delete subscription %s: %v", subscription.ID, err)
+
+			failedSubscriptions = append(failedSubscriptions, subscription)
+		}
+	}
+
+	if len(failedSubscriptions) > 0 {
+		consumer.mutex.Lock()
+		consumer.subscriptions = append(consumer.subscriptions, failedSubscriptions...)
+		consumer.mutex.Unlock()
+
+		return fmt.Errorf("failed to delete %d subscriptions", len(failedSubscriptions))
+	}
+
+	return nil
+}
+
+// GetCurrentState returns the current state of the given resource address from the publisher.
+func (consumer *EventConsumer) GetCurrentState(resourceAddress string) (*CloudEvent, error) {
+	if valid, err := consumer.validate(); !valid {
+		return nil, err
+	}
+
+	glog.V(100).Infof("Getting current state of resource %s", resourceAddress)
+
+	if resourceAddress == "" {
+		return nil, fmt.Errorf("failed to get current state, 'resourceAddress' parameter is empty")
+	}
+
+	event := &CloudEvent{}
+
+	err := consumer.doRequest(http.MethodGet,
+		fmt.Sprintf("%s%s/CurrentState", consumer.publisherURL, resourceAddress), nil, event, http.StatusOK)
+	if err != nil {
+		glog.V(100).Infof("Failed to get current state of resource %s: %v", resourceAddress, err)
+
+		return nil, fmt.Errorf("failed to get current state of resource %s: %w", resourceAddress, err)
+	}
+
+	return event, nil
+}
+
+// RecordCurrentState gets the current state of the given resource address and records it as an event if the state
+// differs from the latest recorded event of the same type. This allows recording transitions by polling when the
+// publisher cannot deliver events to the consumer.
+func (consumer *EventConsumer) RecordCurrentState(resourceAddress string) error {
+	event, err := consumer.GetCurrentState(resourceAddress)
+	if err != nil {
+		return err
+	}
+
+	consumer.mutex.Lock()
+	defer consumer.mutex.Unlock()
+
+	for index := len(consumer.events) - 1; index >= 0; index-- {
+		if consumer.events[index].Event.Type == event.Type {
+			if consumer.events[index].Event.GetState() == event.GetState() {
+				return nil
+			}
+
+			break
+		}
+	}
+
+	consumer.events = append(consumer.events, RecordedEvent{ReceivedAt: time.Now(), Event: *event})
+
+	return nil
+}
+
+// GetEvents returns all the events recorded by the consumer in the order they were received.
+func (consumer *EventConsumer) GetEvents() []RecordedEvent {
+	return consumer.GetEventsOfType("")
+}
+
+// GetEventsOfType returns the recorded events of the given type in the order they were received. All events are
+// returned if eventType is empty.
+func (consumer *EventConsumer) GetEventsOfType(eventType string) []RecordedEvent {
+	if valid, _ := consumer.validate(); !valid {
+		return nil
+	}
+
+	consumer.mutex.Lock()
+	defer consumer.mutex.Unlock()
+
+	var events []RecordedEvent
+
+	for _, event := range consumer.events {
+		if eventType == "" || event.Event.Type == eventType {
+			events = append(events, event)
+		}
+	}
+
+	return events
+}
+
+// ClearEvents removes all the events recorded by the consumer.
+func (consumer *EventConsumer) ClearEvents() {
+	if valid, _ := consumer.validate(); !valid {
+		return
+	}
+
+	glog.V(100).Infof("Clearing events of PTP EventConsumer")
+
+	consumer.mutex.Lock()
+	defer consumer.mutex.Unlock()
+
+	consumer.events = nil
+}
+
+// WaitForEvent waits until an event of the given type with the given state is recorded and returns it.
+func (consumer *EventConsumer) WaitForEvent(eventType, state string, timeout time.Duration) (*RecordedEvent, error) {
+	if valid, err := consumer.validate(); !valid {
+		return nil, err
+	}
+
+	glog.V(100).Infof("Waiting for event %s with state %s", eventType, state)
+
+	if eventType == "" {
+		return nil, fmt.Errorf("failed to wait for event, 'eventType' parameter is empty")
+	}
+
+	var matchingEvent *RecordedEvent
+
+	err := wait.PollUntilContextTimeout(
+		context.TODO(), 500*time.Millisecond, timeout, true, func(ctx context.Context) (bool, error) {
+			for _, event := range consumer.GetEventsOfType(eventType) {
+				if event.Event.GetState() == state {
+					matchingEvent = &event
+
+					return true, nil
+				}
+			}
+
+			return false, nil
+		})
+	if err != nil {
+		glog.V(100).Infof("Failed to find event %s with state %s: %v", eventType, state, err)
+
+		return nil, fmt.Errorf("failed to find event %s with state %s: %w", eventType, state, err)
+	}
+
+	return matchingEvent, nil
+}
+
+// WaitForTransitions waits until the recorded events of the given type went through the given states in order. Other
+// events may be received in between, e.g. LOCKED, FREERUN, LOCKED matches LOCKED, HOLDOVER, FREERUN, LOCKED.
+func (consumer *EventConsumer) WaitForTransitions(eventType string, states []string, timeout time.Duration) error {
+	if valid, err := consumer.validate(); !valid {
+		return err
+	}
+
+	glog.V(100).Infof("Waiting for event %s to transition through states %v", eventType, states)
+
+	if eventType == "" {
+		return fmt.Errorf("failed to wait for transitions, 'eventType' parameter is empty")
+	}
+
+	if len(states) == 0 {
+		return fmt.Errorf("failed to wait for transitions, 'states' parameter is empty")
+	}
+
+	var receivedStates []string
+
+	err := wait.PollUntilContextTimeout(
+		context.TODO(), 500*time.Millisecond, timeout, true, func(ctx context.Context) (bool, error) {
+			receivedStates = nil
+			matchedStates := 0
+
+			for _, event := range consumer.GetEventsOfType(eventType) {
+				receivedStates = append(receivedStates, event.Event.GetState())
+
+				if matchedStates < len(states) && event.Event.GetState() == states[matchedStates] {
+					matchedStates++
+				}
+			}
+
+			return matchedStates == len(states), nil
+		})
+	if err != nil {
+		glog.V(100).Infof("Event %s did not transition through states %v, received %v", eventType, states, receivedStates)
+
+		return fmt.Errorf("event %s did not transition through states %v, received %v", eventType, states, receivedStates)
+	}
+
+	return nil
+}
+
+func (consumer *EventConsumer) handleEvent(writer http.ResponseWriter, request *http.Request) {
+	if request.Method != http.MethodPost {
+		writer.WriteHeader(http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	var event CloudEvent
+
+	err := json.NewDecoder(request.Body).Decode(&event)
+	if err != nil {
+		glog.V(100).Infof("Failed to decode event received by PTP EventConsumer: %v", err)
+
+		writer.WriteHeader(http.StatusBadRequest)
+
+		return
+	}
+
+	glog.V(100).Infof("PTP EventConsumer received event %s from %s with state %s",
+		event.Type, event.Source, event.GetState())
+
+	consumer.mutex.Lock()
+	consumer.events = append(consumer.events, RecordedEvent{ReceivedAt: time.Now(), Event: event})
+	consumer.mutex.Unlock()
+
+	writer.WriteHeader(http.StatusNoContent)
+}
+
+func (consumer *EventConsumer) doRequest(method, url string, body, result interface{}, expectedStatus int) error {
+	var requestBody io.Reader
+
+	if body != nil {
+		bodyBytes, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+
+		requestBody = bytes.NewReader(bodyBytes)
+	}
+
+	request, err := http.NewRequestWithContext(context.TODO(), method, url, requestBody)
+	if err != nil {
+		return err
+	}
+
+	request.Header.Set("Content-Type", "application/json")
+
+	response, err := consumer.httpClient.Do(request)
+	if err != nil {
+		return err
+	}
+
+	defer response.Body.Close()
+
+	responseBody, err := io.ReadAll(response.Body)
+	if err != nil {
+		return err
+	}
+
+	if response.StatusCode != expectedStatus {
+		return fmt.Errorf("unexpected status code %d: %s", response.StatusCode, strings.TrimSpace(string(responseBody)))
+	}
+
+	if result != nil {
+		return json.Unmarshal(responseBody, result)
+	}
+
+	return nil
+}
+
+// isWildcardAddress checks whether the host of the listen address is empty or an unspecified IP address, in which
+// case the consumer listens on all interfaces.
+func isWildcardAddress(listenAddress string) bool {
+	host, _, err := net.SplitHostPort(listenAddress)
+	if err != nil {
+		return false
+	}
+
+	if host == "" {
+		return true
+	}
+
+	ip := net.ParseIP(host)
+
+	return ip != nil && ip.IsUnspecified()
+}
+
+// validate will check that the consumer is properly initialized before accessing any member fields.
+func (consumer *EventConsumer) validate() (bool, error) {
+	if consumer == nil {
+		glog.V(100).Infof("The EventConsumer is uninitialized")
+
+		return false, fmt.Errorf("error: received nil EventConsumer")
+	}
+
+	if consumer.errorMsg != "" {
+		glog.V(100).Infof("The EventConsumer has error message: %s", consumer.errorMsg)
+
+		return false, fmt.Errorf(consumer.errorMsg)
+	}
+
+	return true, nil
+}
@@ -0,0 +1,333 @@
+package ptp
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var defaultLockStateAddress = GetNodeResourceAddress("worker-0", EventResourceLockState)
+
+// fakeEventPublisher mimics the subscription and current state endpoints of the cloud-event-proxy REST API and
+// delivers events to the subscribed endpoints.
+type fakeEventPublisher struct {
+	mutex         sync.Mutex
+	server        *httptest.Server
+	subscriptions map[string]eventSubscription
+	currentState  map[string]CloudEvent
+}
+
+func newFakeEventPublisher() *fakeEventPublisher {
+	publisher := &fakeEventPublisher{
+		subscriptions: make(map[string]eventSubscription),
+		currentState:  make(map[string]CloudEvent),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/subscriptions", func(writer http.ResponseWriter, request *http.Request) {
+		var subscription eventSubscription
+
+		if err := json.NewDecoder(request.Body).Decode(&subscription); err != nil || subscription.EndpointURI == "" {
+			writer.WriteHeader(http.StatusBadRequest)
+
+			return
+		}
+
+		publisher.mutex.Lock()
+		subscription.ID = fmt.Sprintf("sub-%d", len(publisher.subscriptions))
+		publisher.subscriptions[subscription.ID] = subscription
+		publisher.mutex.Unlock()
+
+		writer.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(writer).Encode(subscription)
+	})
+	mux.HandleFunc("/subscriptions/", func(writer http.ResponseWriter, request *http.Request) {
+		publisher.mutex.Lock()
+		defer publisher.mutex.Unlock()
+
+		subscriptionID := strings.TrimPrefix(request.URL.Path, "/subscriptions/")
+		if _, ok := publisher.subscriptions[subscriptionID]; !ok || request.Method != http.MethodDelete {
+			writer.WriteHeader(http.StatusNotFound)
+
+			return
+		}
+
+		delete(publisher.subscriptions, subscriptionID)
+		writer.WriteHeader(http.StatusNoContent)
+	})
+	mux.HandleFunc("/cluster/", func(writer http.ResponseWriter, request *http.Request) {
+		publisher.mutex.Lock()
+		defer publisher.mutex.Unlock()
+
+		event, ok := publisher.currentState[strings.TrimSuffix(request.URL.Path, "/CurrentState")]
+		if !ok {
+			writer.WriteHeader(http.StatusNotFound)
+
+			return
+		}
+
+		_ = json.NewEncoder(writer).Encode(event)
+	})
+
+	publisher.server = httptest.NewServer(mux)
+
+	return publisher
+}
+
+func (publisher *fakeEventPublisher) publish(t *testing.T, resourceAddress, eventType, state string) {
+	t.Helper()
+
+	event := buildDummyCloudEvent(resourceAddress, eventType, state)
+
+	publisher.mutex.Lock()
+	publisher.currentState[resourceAddress] = event
+
+	var endpoints []string
+
+	for _, subscription := range publisher.subscriptions {
+		if subscription.ResourceAddress == resourceAddress {
+			endpoints = append(endpoints, subscription.EndpointURI)
+		}
+	}
+	publisher.mutex.Unlock()
+
+	eventBytes, err := json.Marshal(event)
+	assert.Nil(t, err)
+
+	for _, endpoint := range endpoints {
+		response, err := http.Post(endpoint, "application/cloudevents+json", bytes.NewReader(eventBytes))
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusNoContent, response.StatusCode)
+
+		_ = response.Body.Close()
+	}
+}
+
+func TestNewEventConsumer(t *testing.T) {
+	testCases := []struct {
+		publisherURL  string
+		listenAddress string
+		endpointURI   string
+		expectedError string
+	}{
+		{
+			publisherURL:  "http://localhost:9043/api/ocloudNotifications/v2/",
+			listenAddress: "127.0.0.1:0",
+			expectedError: "",
+		},
+		{
+			publisherURL:  "",
+			listenAddress: "127.0.0.1:0",
+			expectedError: "EventConsumer 'publisherURL' cannot be empty",
+		},
+		{
+			publisherURL:  "http://localhost:9043/api/ocloudNotifications/v2",
+			listenAddress: "",
+			expectedError: "EventConsumer 'listenAddress' cannot be empty",
+		},
+		{
+			publisherURL:  "http://localhost:9043/api/ocloudNotifications/v2",
+			listenAddress: ":9043",
+			expectedError: "EventConsumer 'endpointURI' cannot be empty when listening on a wildcard address",
+		},
+		{
+			publisherURL:  "http://localhost:9043/api/ocloudNotifications/v2",
+			listenAddress: "[::]:9043",
+			expectedError: "EventConsumer 'endpointURI' cannot be empty when listening on a wildcard address",
+		},
+		{
+			publisherURL:  "http://localhost:9043/api/ocloudNotifications/v2",
+			listenAddress: ":9043",
+			endpointURI:   "http://consumer.test-ns.svc:9043/event",
+			expectedError: "",
+		},
+	}
+
+	for _, testCase := range testCases {
+		testConsumer := NewEventConsumer(testCase.publisherURL, testCase.listenAddress, testCase.endpointURI)
+		assert.Equal(t, testCase.expectedError, testConsumer.errorMsg)
+
+		if testCase.expectedError == "" {
+			assert.Equal(t, "http://localhost:9043/api/ocloudNotifications/v2", testConsumer.publisherURL)
+		}
+	}
+}
+
+func TestEventConsumerRestartEndpointURI(t *testing.T) {
+	publisher := newFakeEventPublisher()
+	defer publisher.server.Close()
+
+	testConsumer := NewEventConsumer(publisher.server.URL, "127.0.0.1:0", "")
+
+	err := testConsumer.Start()
+	assert.Nil(t, err)
+
+	firstEndpointURI := testConsumer.GetEndpointURI()
+	assert.NotEmpty(t, firstEndpointURI)
+
+	err = testConsumer.Stop()
+	assert.Nil(t, err)
+	assert.Empty(t, testConsumer.GetEndpointURI())
+
+	// Keep the first port busy so the next start is assigned a different one.
+	firstEndpoint, err := url.Parse(firstEndpointURI)
+	assert.Nil(t, err)
+
+	listener, err := net.Listen("tcp", firstEndpoint.Host)
+	assert.Nil(t, err)
+
+	defer listener.Close()
+
+	err = testConsumer.Start()
+	assert.Nil(t, err)
+	assert.NotEqual(t, firstEndpointURI, testConsumer.GetEndpointURI())
+
+	err = testConsumer.Stop()
+	assert.Nil(t, err)
+
+	testConsumer = NewEventConsumer(publisher.server.URL, "127.0.0.1:0", "http://consumer.test-ns.svc:9043/event")
+
+	err = testConsumer.Start()
+	assert.Nil(t, err)
+	assert.Equal(t, "http://consumer.test-ns.svc:9043/event", testConsumer.GetEndpointURI())
+
+	err = testConsumer.Stop()
+	assert.Nil(t, err)
+	assert.Equal(t, "http://consumer.test-ns.svc:9043/event", testConsumer.GetEndpointURI())
+}
+
+func TestEventConsumerSubscribeAndReceive(t *testing.T) {
+	publisher := newFakeEventPublisher()
+	defer publisher.server.Close()
+
+	testConsumer := NewEventConsumer(publisher.server.URL, "127.0.0.1:0", "")
+
+	err := testConsumer.Subscribe(defaultLockStateAddress)
+	assert.Equal(t, fmt.Errorf("failed to subscribe, consumer has no endpoint URI and is not started"), err)
+
+	err = testConsumer.Start()
+	assert.Nil(t, err)
+	assert.True(t, strings.HasSuffix(testConsumer.GetEndpointURI(), eventConsumerPath))
+
+	err = testConsumer.Start()
+	assert.Equal(t, fmt.Errorf("failed to start EventConsumer, it is already started"), err)
+
+	err = testConsumer.Subscribe()
+	assert.Equal(t, fmt.Errorf("failed to subscribe, 'resourceAddresses' parameter is empty"), err)
+
+	err = testConsumer.Subscribe(defaultLockStateAddress)
+	assert.Nil(t, err)
+	assert.Len(t, publisher.subscriptions, 1)
+
+	publisher.publish(t, defaultLockStateAddress, EventTypeLockStateChange, "LOCKED")
+	publisher.publish(t, defaultLockStateAddress, EventTypeLockStateChange, "HOLDOVER")
+	publisher.publish(t, defaultLockStateAddress, EventTypeLockStateChange, "FREERUN")
+	publisher.publish(t, defaultLockStateAddress, EventTypeLockStateChange, "LOCKED")
+
+	events := testConsumer.GetEvents()
+	assert.Len(t, events, 4)
+	assert.Equal(t, "HOLDOVER", events[1].Event.GetState())
+	assert.False(t, events[1].ReceivedAt.IsZero())
+	assert.Empty(t, testConsumer.GetEventsOfType(EventTypeGnssSyncStateChange))
+
+	event, err := testConsumer.WaitForEvent(EventTypeLockStateChange, "FREERUN", time.Second)
+	assert.Nil(t, err)
+	assert.Equal(t, defaultLockStateAddress, event.Event.Data.Values[0].ResourceAddress)
+
+	err = testConsumer.WaitForTransitions(
+		EventTypeLockStateChange, []string{"LOCKED", "FREERUN", "LOCKED"}, time.Second)
+	assert.Nil(t, err)
+
+	err = testConsumer.WaitForTransitions(EventTypeLockStateChange, []string{"FREERUN", "HOLDOVER"}, time.Second)
+	assert.Equal(t, fmt.Errorf("event %s did not transition through states [FREERUN HOLDOVER], "+
+		"received [LOCKED HOLDOVER FREERUN LOCKED]", EventTypeLockStateChange), err)
+
+	testConsumer.ClearEvents()
+	assert.Empty(t, testConsumer.GetEvents())
+
+	err = testConsumer.Stop()
+	assert.Nil(t, err)
+	assert.Empty(t, publisher.subscriptions)
+}
+
+func TestEventConsumerRecordCurrentState(t *testing.T) {
+	publisher := newFakeEventPublisher()
+	defer publisher.server.Close()
+
+	testConsumer := NewEventConsumer(publisher.server.URL, "127.0.0.1:0", "")
+
+	err := testConsumer.RecordCurrentState(defaultLockStateAddress)
+	assert.NotNil(t, err)
+
+	publisher.publish(t, defaultLockStateAddress, EventTypeLockStateChange, "FREERUN")
+
+	currentState, err := testConsumer.GetCurrentState(defaultLockStateAddress)
+	assert.Nil(t, err)
+	assert.Equal(t, "FREERUN", currentState.GetState())
+
+	assert.Nil(t, testConsumer.RecordCurrentState(defaultLockStateAddress))
+	assert.Nil(t, testConsumer.RecordCurrentState(defaultLockStateAddress))
+
+	publisher.publish(t, defaultLockStateAddress, EventTypeLockStateChange, "LOCKED")
+	assert.Nil(t, testConsumer.RecordCurrentState(defaultLockStateAddress))
+
+	err = testConsumer.WaitForTransitions(EventTypeLockStateChange, []string{"FREERUN", "LOCKED"}, time.Second)
+	assert.Nil(t, err)
+	assert.Len(t, testConsumer.GetEvents(), 2)
+
+	_, err = testConsumer.GetCurrentState("")
+	assert.Equal(t, fmt.Errorf("failed to get current state, 'resourceAddress' parameter is empty"), err)
+}
+
+func TestEventConsumerWaitForEvent(t *testing.T) {
+	testConsumer := NewEventConsumer("http://localhost:9043", "127.0.0.1:0", "")
+
+	_, err := testConsumer.WaitForEvent("", "LOCKED", time.Second)
+	assert.Equal(t, fmt.Errorf("failed to wait for event, 'eventType' parameter is empty"), err)
+
+	_, err = testConsumer.WaitForEvent(EventTypeOsClockSyncStateChange, "LOCKED", time.Second)
+	assert.NotNil(t, err)
+
+	err = testConsumer.WaitForTransitions(EventTypeOsClockSyncStateChange, nil, time.Second)
+	assert.Equal(t, fmt.Errorf("failed to wait for transitions, 'states' parameter is empty"), err)
+
+	_, err = NewEventConsumer("", "127.0.0.1:0", "").WaitForEvent(EventTypeSyncStateChange, "LOCKED", time.Second)
+	assert.Equal(t, fmt.Errorf("EventConsumer 'publisherURL' cannot be empty"), err)
+}
+
+func buildDummyCloudEvent(resourceAddress, eventType, state string) CloudEvent {
+	return CloudEvent{
+		ID:          fmt.Sprintf("%s-%s", eventType, state),
+		Type:        eventType,
+		Source:      resourceAddress,
+		SpecVersion: "1.0",
+		Time:        time.Now(),
+		Data: EventData{
+			Version: "1.0",
+			Values: []EventDataValue{
+				{
+					ResourceAddress: resourceAddress,
+					DataType:        eventDataTypeNotification,
+					ValueType:       "enumeration",
+					Value:           state,
+				},
+				{
+					ResourceAddress: resourceAddress,
+					DataType:        "metric",
+					ValueType:       "decimal64.3",
+					Value:           -3.0,
+				},
+			},
+		},
+	}
+}
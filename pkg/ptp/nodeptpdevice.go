@@ -0,0 +1,214 @@
+package ptp
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/golang/glog"
+	"github.com/openshift-kni/eco-goinfra/pkg/clients"
+	"github.com/openshift-kni/eco-goinfra/pkg/msg"
+	ptpv1 "github.com/openshift/ptp-operator/api/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	goclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// NodePtpDeviceBuilder provides struct for NodePtpDevice object. NodePtpDevices are created by the ptp operator for
+// every node running the linuxptp-daemon and list the PTP capable interfaces discovered on the node.
+type NodePtpDeviceBuilder struct {
+	// NodePtpDevice definition. Used to store the NodePtpDevice object.
+	Definition *ptpv1.NodePtpDevice
+	// Created NodePtpDevice object.
+	Object *ptpv1.NodePtpDevice
+	// Used in functions that define or mutate NodePtpDevice definitions.
+	errorMsg string
+	// apiClient opens api connection to the cluster.
+	apiClient *clients.Settings
+}
+
+// PullNodePtpDevice pulls the existing NodePtpDevice of the given node from cluster.
+func PullNodePtpDevice(apiClient *clients.Settings, nodeName, nsname string) (*NodePtpDeviceBuilder, error) {
+	glog.V(100).Infof("Pulling existing NodePtpDevice name %s under namespace %s from cluster", nodeName, nsname)
+
+	if apiClient == nil {
+		glog.V(100).Infof("The apiClient is empty")
+
+		return nil, fmt.Errorf("NodePtpDevice 'apiClient' cannot be empty")
+	}
+
+	builder := NodePtpDeviceBuilder{
+		apiClient: apiClient,
+		Definition: &ptpv1.NodePtpDevice{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      nodeName,
+				Namespace: nsname,
+			},
+		},
+	}
+
+	if nodeName == "" {
+		glog.V(100).Infof("The name of the NodePtpDevice is empty")
+
+		return nil, fmt.Errorf("NodePtpDevice 'nodeName' cannot be empty")
+	}
+
+	if nsname == "" {
+		glog.V(100).Infof("The namespace of the NodePtpDevice is empty")
+
+		return nil, fmt.Errorf("NodePtpDevice 'namespace' cannot be empty")
+	}
+
+	if !builder.Exists() {
+		return nil, fmt.Errorf("NodePtpDevice object %s does not exist in namespace %s", nodeName, nsname)
+	}
+
+	builder.Definition = builder.Object
+
+	return &builder, nil
+}
+
+// ListNodePtpDevices returns the NodePtpDevices in the given namespace.
+func ListNodePtpDevices(apiClient *clients.Settings, nsname string) ([]*NodePtpDeviceBuilder, error) {
+	glog.V(100).Infof("Listing NodePtpDevices in the namespace %s", nsname)
+
+	if apiClient == nil {
+		glog.V(100).Infof("The apiClient is empty")
+
+		return nil, fmt.Errorf("failed to list NodePtpDevices, 'apiClient' parameter is empty")
+	}
+
+	if nsname == "" {
+		glog.V(100).Infof("NodePtpDevices 'nsname' parameter can not be empty")
+
+		return nil, fmt.Errorf("failed to list NodePtpDevices, 'nsname' parameter is empty")
+	}
+
+	nodePtpDeviceList := &ptpv1.NodePtpDeviceList{}
+
+	err := apiClient.List(context.TODO(), nodePtpDeviceList, &goclient.ListOptions{Namespace: nsname})
+	if err != nil {
+		glog.V(100).Infof("Failed to list NodePtpDevices in namespace %s due to %s", nsname, err.Error())
+
+		return nil, err
+	}
+
+	var nodePtpDeviceBuilders []*NodePtpDeviceBuilder
+
+	for _, nodePtpDeviceObj := range nodePtpDeviceList.Items {
+		nodePtpDevice := nodePtpDeviceObj
+		nodePtpDeviceBuilder := &NodePtpDeviceBuilder{
+			apiClient:  apiClient,
+			Definition: &nodePtpDevice,
+			Object:     &nodePtpDevice,
+		}
+
+		nodePtpDeviceBuilders = append(nodePtpDeviceBuilders, nodePtpDeviceBuilder)
+	}
+
+	return nodePtpDeviceBuilders, nil
+}
+
+// Get returns NodePtpDevice object if found.
+func (builder *NodePtpDeviceBuilder) Get() (*ptpv1.NodePtpDevice, error) {
+	if valid, err := builder.validate(); !valid {
+		return nil, err
+	}
+
+	glog.V(100).Infof("Collecting NodePtpDevice object %s in namespace %s",
+		builder.Definition.Name, builder.Definition.Namespace)
+
+	nodePtpDevice := &ptpv1.NodePtpDevice{}
+	err := builder.apiClient.Get(context.TODO(), goclient.ObjectKey{
+		Name:      builder.Definition.Name,
+		Namespace: builder.Definition.Namespace,
+	}, nodePtpDevice)
+
+	if err != nil {
+		glog.V(100).Infof("Failed to get NodePtpDevice %s in namespace %s",
+			builder.Definition.Name, builder.Definition.Namespace)
+
+		return nil, err
+	}
+
+	return nodePtpDevice, nil
+}
+
+// Exists checks whether the given NodePtpDevice object exists in a cluster.
+func (builder *NodePtpDeviceBuilder) Exists() bool {
+	if valid, _ := builder.validate(); !valid {
+		return false
+	}
+
+	glog.V(100).Infof("Checking if NodePtpDevice %s exists in namespace %s",
+		builder.Definition.Name, builder.Definition.Namespace)
+
+	var err error
+	builder.Object, err = builder.Get()
+
+	return err == nil || !k8serrors.IsNotFound(err)
+}
+
+// GetDevices returns the PTP capable interfaces discovered on the node.
+func (builder *NodePtpDeviceBuilder) GetDevices() ([]ptpv1.PtpDevice, error) {
+	if valid, err := builder.validate(); !valid {
+		return nil, err
+	}
+
+	glog.V(100).Infof("Getting devices of NodePtpDevice %s in namespace %s",
+		builder.Definition.Name, builder.Definition.Namespace)
+
+	if !builder.Exists() {
+		return nil, fmt.Errorf("NodePtpDevice object %s does not exist in namespace %s",
+			builder.Definition.Name, builder.Definition.Namespace)
+	}
+
+	return builder.Object.Status.Devices, nil
+}
+
+// GetDeviceNames returns the names of the PTP capable interfaces discovered on the node.
+func (builder *NodePtpDeviceBuilder) GetDeviceNames() ([]string, error) {
+	devices, err := builder.GetDevices()
+	if err != nil {
+		return nil, err
+	}
+
+	var deviceNames []string
+
+	for _, device := range devices {
+		deviceNames = append(deviceNames, device.Name)
+	}
+
+	return deviceNames, nil
+}
+
+// validate will check that the builder and builder definition are properly initialized before
+// accessing any member fields.
+func (builder *NodePtpDeviceBuilder) validate() (bool, error) {
+	resourceCRD := "NodePtpDevice"
+
+	if builder == nil {
+		glog.V(100).Infof("The %s builder is uninitialized", resourceCRD)
+
+		return false, fmt.Errorf("error: received nil %s builder", resourceCRD)
+	}
+
+	if builder.Definition == nil {
+		glog.V(100).Infof("The %s is undefined", resourceCRD)
+
+		builder.errorMsg = msg.UndefinedCrdObjectErrString(resourceCRD)
+	}
+
+	if builder.apiClient == nil {
+		glog.V(100).Infof("The %s builder apiclient is nil", resourceCRD)
+
+		builder.errorMsg = fmt.Sprintf("%s builder cannot have nil apiClient", resourceCRD)
+	}
+
+	if builder.errorMsg != "" {
+		glog.V(100).Infof("The %s builder has error message: %s", resourceCRD, builder.errorMsg)
+
+		return false, fmt.Errorf(builder.errorMsg)
+	}
+
+	return true, nil
+}
@@ -0,0 +1,125 @@
+package ptp
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/openshift-kni/eco-goinfra/pkg/clients"
+	ptpv1 "github.com/openshift/ptp-operator/api/v1"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+var defaultNodePtpDeviceName = "worker-0"
+
+func TestPullNodePtpDevice(t *testing.T) {
+	testCases := []struct {
+		nodeName            string
+		nsname              string
+		addToRuntimeObjects bool
+		client              bool
+		expectedError       error
+	}{
+		{
+			nodeName:            defaultNodePtpDeviceName,
+			nsname:              defaultPtpConfigNsName,
+			addToRuntimeObjects: true,
+			client:              true,
+			expectedError:       nil,
+		},
+		{
+			nodeName:            "",
+			nsname:              defaultPtpConfigNsName,
+			addToRuntimeObjects: true,
+			client:              true,
+			expectedError:       fmt.Errorf("NodePtpDevice 'nodeName' cannot be empty"),
+		},
+		{
+			nodeName:            defaultNodePtpDeviceName,
+			nsname:              "",
+			addToRuntimeObjects: true,
+			client:              true,
+			expectedError:       fmt.Errorf("NodePtpDevice 'namespace' cannot be empty"),
+		},
+		{
+			nodeName:            defaultNodePtpDeviceName,
+			nsname:              defaultPtpConfigNsName,
+			addToRuntimeObjects: false,
+			client:              true,
+			expectedError: fmt.Errorf(
+				"NodePtpDevice object %s does not exist in namespace %s", defaultNodePtpDeviceName, defaultPtpConfigNsName),
+		},
+		{
+			nodeName:            defaultNodePtpDeviceName,
+			nsname:              defaultPtpConfigNsName,
+			addToRuntimeObjects: true,
+			client:              false,
+			expectedError:       fmt.Errorf("NodePtpDevice 'apiClient' cannot be empty"),
+		},
+	}
+
+	for _, testCase := range testCases {
+		var (
+			runtimeObjects []runtime.Object
+			testSettings   *clients.Settings
+		)
+
+		if testCase.addToRuntimeObjects {
+			runtimeObjects = buildDummyNodePtpDeviceObject()
+		}
+
+		if testCase.client {
+			testSettings = clients.GetTestClients(clients.TestClientParams{K8sMockObjects: runtimeObjects})
+		}
+
+		testBuilder, err := PullNodePtpDevice(testSettings, testCase.nodeName, testCase.nsname)
+		assert.Equal(t, testCase.expectedError, err)
+
+		if testCase.expectedError == nil {
+			assert.Equal(t, testCase.nodeName, testBuilder.Definition.Name)
+		}
+	}
+}
+
+func TestListNodePtpDevices(t *testing.T) {
+	testSettings := clients.GetTestClients(clients.TestClientParams{K8sMockObjects: buildDummyNodePtpDeviceObject()})
+
+	nodePtpDevices, err := ListNodePtpDevices(testSettings, defaultPtpConfigNsName)
+	assert.Nil(t, err)
+	assert.Len(t, nodePtpDevices, 1)
+
+	_, err = ListNodePtpDevices(testSettings, "")
+	assert.Equal(t, fmt.Errorf("failed to list NodePtpDevices, 'nsname' parameter is empty"), err)
+
+	_, err = ListNodePtpDevices(nil, defaultPtpConfigNsName)
+	assert.Equal(t, fmt.Errorf("failed to list NodePtpDevices, 'apiClient' parameter is empty"), err)
+}
+
+func TestNodePtpDeviceGetDeviceNames(t *testing.T) {
+	testSettings := clients.GetTestClients(clients.TestClientParams{K8sMockObjects: buildDummyNodePtpDeviceObject()})
+
+	testBuilder, err := PullNodePtpDevice(testSettings, defaultNodePtpDeviceName, defaultPtpConfigNsName)
+	assert.Nil(t, err)
+
+	deviceNames, err := testBuilder.GetDeviceNames()
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"ens1f0", "ens1f1"}, deviceNames)
+
+	testBuilder.Definition.Name = "worker-1"
+	_, err = testBuilder.GetDevices()
+	assert.Equal(t, fmt.Errorf("NodePtpDevice object worker-1 does not exist in namespace %s", defaultPtpConfigNsName),
+		err)
+}
+
+func buildDummyNodePtpDeviceObject() []runtime.Object {
+	return append([]runtime.Object{}, &ptpv1.NodePtpDevice{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      defaultNodePtpDeviceName,
+			Namespace: defaultPtpConfigNsName,
+		},
+		Status: ptpv1.NodePtpDeviceStatus{
+			Devices: []ptpv1.PtpDevice{{Name: "ens1f0"}, {Name: "ens1f1"}},
+		},
+	})
+}
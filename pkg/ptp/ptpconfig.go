@@ -0,0 +1,500 @@
+package ptp
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/golang/glog"
+	"github.com/openshift-kni/eco-goinfra/pkg/clients"
+	"github.com/openshift-kni/eco-goinfra/pkg/msg"
+	ptpv1 "github.com/openshift/ptp-operator/api/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	goclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// PtpConfigBuilder provides struct for PtpConfig object which contains connection to cluster and
+// PtpConfig definition.
+type PtpConfigBuilder struct {
+	// PtpConfig definition. Used to create PtpConfig object.
+	Definition *ptpv1.PtpConfig
+	// Created PtpConfig object.
+	Object *ptpv1.PtpConfig
+	// Used in functions that define or mutate PtpConfig definitions. errorMsg is processed before
+	// PtpConfig object is created.
+	errorMsg string
+	// apiClient opens api connection to the cluster.
+	apiClient *clients.Settings
+}
+
+// PtpConfigAdditionalOptions additional options for PtpConfig object.
+type PtpConfigAdditionalOptions func(builder *PtpConfigBuilder) (*PtpConfigBuilder, error)
+
+// NewPtpConfigBuilder creates new instance of PtpConfigBuilder. Profiles and recommend rules are added with the
+// WithProfile and WithRecommend options.
+func NewPtpConfigBuilder(apiClient *clients.Settings, name, nsname string) *PtpConfigBuilder {
+	glog.V(100).Infof(
+		"Initializing new PtpConfig structure with the name %s in the namespace %s", name, nsname)
+
+	builder := PtpConfigBuilder{
+		apiClient: apiClient,
+		Definition: &ptpv1.PtpConfig{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: nsname,
+			},
+			Spec: ptpv1.PtpConfigSpec{
+				Profile:   []ptpv1.PtpProfile{},
+				Recommend: []ptpv1.PtpRecommend{},
+			},
+		},
+	}
+
+	if name == "" {
+		glog.V(100).Infof("The name of the PtpConfig is empty")
+
+		builder.errorMsg = "PtpConfig 'name' cannot be empty"
+	}
+
+	if nsname == "" {
+		glog.V(100).Infof("The namespace of the PtpConfig is empty")
+
+		builder.errorMsg = "PtpConfig 'nsname' cannot be empty"
+	}
+
+	return &builder
+}
+
+// WithProfile adds a profile with the given name and interface to the PtpConfig definition spec. The interface may
+// be empty for profiles which define the interfaces in the ptp4l configuration, e.g. boundary clocks.
+func (builder *PtpConfigBuilder) WithProfile(profileName, interfaceName string) *PtpConfigBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Adding profile %s with interface %s to PtpConfig %s",
+		profileName, interfaceName, builder.Definition.Name)
+
+	if profileName == "" {
+		builder.errorMsg = "PtpConfig profile 'name' cannot be empty"
+
+		return builder
+	}
+
+	if builder.getProfile(profileName) != nil {
+		builder.errorMsg = fmt.Sprintf("PtpConfig profile %s already exists", profileName)
+
+		return builder
+	}
+
+	profile := ptpv1.PtpProfile{Name: ptr.To(profileName)}
+
+	if interfaceName != "" {
+		profile.Interface = ptr.To(interfaceName)
+	}
+
+	builder.Definition.Spec.Profile = append(builder.Definition.Spec.Profile, profile)
+
+	return builder
+}
+
+// WithPtp4lOpts sets the ptp4l command line options of the given profile, e.g. "-2 -s".
+func (builder *PtpConfigBuilder) WithPtp4lOpts(profileName, ptp4lOpts string) *PtpConfigBuilder {
+	return builder.setProfileField(profileName, "ptp4lOpts", func(profile *ptpv1.PtpProfile) {
+		profile.Ptp4lOpts = ptr.To(ptp4lOpts)
+	})
+}
+
+// WithPtp4lConf sets the ptp4l configuration file content of the given profile.
+func (builder *PtpConfigBuilder) WithPtp4lConf(profileName, ptp4lConf string) *PtpConfigBuilder {
+	return builder.setProfileField(profileName, "ptp4lConf", func(profile *ptpv1.PtpProfile) {
+		profile.Ptp4lConf = ptr.To(ptp4lConf)
+	})
+}
+
+// WithPhc2sysOpts sets the phc2sys command line options of the given profile, e.g. "-a -r".
+func (builder *PtpConfigBuilder) WithPhc2sysOpts(profileName, phc2sysOpts string) *PtpConfigBuilder {
+	return builder.setProfileField(profileName, "phc2sysOpts", func(profile *ptpv1.PtpProfile) {
+		profile.Phc2sysOpts = ptr.To(phc2sysOpts)
+	})
+}
+
+// WithTs2PhcOpts sets the ts2phc command line options of the given profile.
+func (builder *PtpConfigBuilder) WithTs2PhcOpts(profileName, ts2phcOpts string) *PtpConfigBuilder {
+	return builder.setProfileField(profileName, "ts2phcOpts", func(profile *ptpv1.PtpProfile) {
+		profile.Ts2PhcOpts = ptr.To(ts2phcOpts)
+	})
+}
+
+// WithTs2PhcConf sets the ts2phc configuration file content of the given profile.
+func (builder *PtpConfigBuilder) WithTs2PhcConf(profileName, ts2phcConf string) *PtpConfigBuilder {
+	return builder.setProfileField(profileName, "ts2phcConf", func(profile *ptpv1.PtpProfile) {
+		profile.Ts2PhcConf = ptr.To(ts2phcConf)
+	})
+}
+
+// WithSchedulingPolicy sets the scheduling policy and priority of the ptp4l and phc2sys processes of the given
+// profile. Allowed policies are SCHED_OTHER and SCHED_FIFO, the priority is only used with SCHED_FIFO.
+func (builder *PtpConfigBuilder) WithSchedulingPolicy(
+	profileName, policy string, priority int64) *PtpConfigBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	if policy != "SCHED_OTHER" && policy != "SCHED_FIFO" {
+		builder.errorMsg = fmt.Sprintf(
+			"invalid scheduling policy %s, allowed values are SCHED_OTHER and SCHED_FIFO", policy)
+
+		return builder
+	}
+
+	if policy == "SCHED_FIFO" && (priority < 1 || priority > 65) {
+		builder.errorMsg = "invalid scheduling priority, allowed values are between 1-65"
+
+		return builder
+	}
+
+	return builder.setProfileField(profileName, "ptpSchedulingPolicy", func(profile *ptpv1.PtpProfile) {
+		profile.PtpSchedulingPolicy = ptr.To(policy)
+		profile.PtpSchedulingPriority = ptr.To(priority)
+	})
+}
+
+// WithClockThreshold sets the holdover timeout in seconds and the offset thresholds in nanoseconds used by the
+// linuxptp-daemon to report the clock state of the given profile.
+func (builder *PtpConfigBuilder) WithClockThreshold(
+	profileName string, holdOverTimeout, maxOffset, minOffset int64) *PtpConfigBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	if minOffset > maxOffset {
+		builder.errorMsg = "invalid clock threshold, 'minOffset' cannot be greater than 'maxOffset'"
+
+		return builder
+	}
+
+	return builder.setProfileField(profileName, "ptpClockThreshold", func(profile *ptpv1.PtpProfile) {
+		profile.PtpClockThreshold = &ptpv1.PtpClockThreshold{
+			HoldOverTimeout:    holdOverTimeout,
+			MaxOffsetThreshold: maxOffset,
+			MinOffsetThreshold: minOffset,
+		}
+	})
+}
+
+// WithPtpSettings adds a key value pair to the ptpSettings of the given profile, e.g. logReduce: "true".
+func (builder *PtpConfigBuilder) WithPtpSettings(profileName, key, value string) *PtpConfigBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	if key == "" {
+		builder.errorMsg = "PtpConfig ptpSettings 'key' cannot be empty"
+
+		return builder
+	}
+
+	return builder.setProfileField(profileName, "ptpSettings", func(profile *ptpv1.PtpProfile) {
+		if profile.PtpSettings == nil {
+			profile.PtpSettings = make(map[string]string)
+		}
+
+		profile.PtpSettings[key] = value
+	})
+}
+
+// WithRecommend adds a recommend rule applying the given profile with the given priority to the nodes matching the
+// match rules. A lower priority value takes precedence.
+func (builder *PtpConfigBuilder) WithRecommend(
+	profileName string, priority int64, matchRules ...ptpv1.MatchRule) *PtpConfigBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Adding recommend rule for profile %s with priority %d to PtpConfig %s",
+		profileName, priority, builder.Definition.Name)
+
+	if profileName == "" {
+		builder.errorMsg = "PtpConfig recommend 'profileName' cannot be empty"
+
+		return builder
+	}
+
+	if priority < 0 {
+		builder.errorMsg = "PtpConfig recommend 'priority' cannot be negative"
+
+		return builder
+	}
+
+	for _, matchRule := range matchRules {
+		if matchRule.NodeLabel == nil && matchRule.NodeName == nil {
+			builder.errorMsg = "PtpConfig recommend match rule must have either nodeLabel or nodeName"
+
+			return builder
+		}
+	}
+
+	builder.Definition.Spec.Recommend = append(builder.Definition.Spec.Recommend, ptpv1.PtpRecommend{
+		Profile:  ptr.To(profileName),
+		Priority: ptr.To(priority),
+		Match:    matchRules,
+	})
+
+	return builder
+}
+
+// WithOptions creates PtpConfig with generic mutation options.
+func (builder *PtpConfigBuilder) WithOptions(options ...PtpConfigAdditionalOptions) *PtpConfigBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Setting PtpConfig additional options")
+
+	for _, option := range options {
+		if option != nil {
+			builder, err := option(builder)
+
+			if err != nil {
+				glog.V(100).Infof("Error occurred in mutation function")
+
+				builder.errorMsg = err.Error()
+
+				return builder
+			}
+		}
+	}
+
+	return builder
+}
+
+// PullPtpConfig pulls existing PtpConfig from cluster.
+func PullPtpConfig(apiClient *clients.Settings, name, nsname string) (*PtpConfigBuilder, error) {
+	glog.V(100).Infof("Pulling existing PtpConfig name %s under namespace %s from cluster", name, nsname)
+
+	if apiClient == nil {
+		glog.V(100).Infof("The apiClient is empty")
+
+		return nil, fmt.Errorf("PtpConfig 'apiClient' cannot be empty")
+	}
+
+	builder := PtpConfigBuilder{
+		apiClient: apiClient,
+		Definition: &ptpv1.PtpConfig{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: nsname,
+			},
+		},
+	}
+
+	if name == "" {
+		glog.V(100).Infof("The name of the PtpConfig is empty")
+
+		return nil, fmt.Errorf("PtpConfig 'name' cannot be empty")
+	}
+
+	if nsname == "" {
+		glog.V(100).Infof("The namespace of the PtpConfig is empty")
+
+		return nil, fmt.Errorf("PtpConfig 'namespace' cannot be empty")
+	}
+
+	if !builder.Exists() {
+		return nil, fmt.Errorf("PtpConfig object %s does not exist in namespace %s", name, nsname)
+	}
+
+	builder.Definition = builder.Object
+
+	return &builder, nil
+}
+
+// Get returns PtpConfig object if found.
+func (builder *PtpConfigBuilder) Get() (*ptpv1.PtpConfig, error) {
+	if valid, err := builder.validate(); !valid {
+		return nil, err
+	}
+
+	glog.V(100).Infof("Collecting PtpConfig object %s in namespace %s",
+		builder.Definition.Name, builder.Definition.Namespace)
+
+	ptpConfig := &ptpv1.PtpConfig{}
+	err := builder.apiClient.Get(context.TODO(), goclient.ObjectKey{
+		Name:      builder.Definition.Name,
+		Namespace: builder.Definition.Namespace,
+	}, ptpConfig)
+
+	if err != nil {
+		glog.V(100).Infof("Failed to get PtpConfig %s in namespace %s",
+			builder.Definition.Name, builder.Definition.Namespace)
+
+		return nil, err
+	}
+
+	return ptpConfig, nil
+}
+
+// Exists checks whether the given PtpConfig object exists in a cluster.
+func (builder *PtpConfigBuilder) Exists() bool {
+	if valid, _ := builder.validate(); !valid {
+		return false
+	}
+
+	glog.V(100).Infof("Checking if PtpConfig %s exists in namespace %s",
+		builder.Definition.Name, builder.Definition.Namespace)
+
+	var err error
+	builder.Object, err = builder.Get()
+
+	return err == nil || !k8serrors.IsNotFound(err)
+}
+
+// Create generates PtpConfig in a cluster and stores the created object in struct.
+func (builder *PtpConfigBuilder) Create() (*PtpConfigBuilder, error) {
+	if valid, err := builder.validate(); !valid {
+		return builder, err
+	}
+
+	glog.V(100).Infof("Creating the PtpConfig %s in namespace %s",
+		builder.Definition.Name, builder.Definition.Namespace)
+
+	if !builder.Exists() {
+		err := builder.apiClient.Create(context.TODO(), builder.Definition)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	builder.Object = builder.Definition
+
+	return builder, nil
+}
+
+// Delete removes PtpConfig object.
+func (builder *PtpConfigBuilder) Delete() error {
+	if valid, err := builder.validate(); !valid {
+		return err
+	}
+
+	glog.V(100).Infof("Deleting the PtpConfig object %s in namespace %s",
+		builder.Definition.Name, builder.Definition.Namespace)
+
+	if !builder.Exists() {
+		return nil
+	}
+
+	err := builder.apiClient.Delete(context.TODO(), builder.Object)
+
+	if err != nil {
+		return err
+	}
+
+	builder.Object = nil
+
+	return nil
+}
+
+// Update renovates the existing PtpConfig object with the PtpConfig definition in builder.
+func (builder *PtpConfigBuilder) Update(force bool) (*PtpConfigBuilder, error) {
+	if valid, err := builder.validate(); !valid {
+		return builder, err
+	}
+
+	glog.V(100).Infof("Updating the PtpConfig object %s in namespace %s",
+		builder.Definition.Name, builder.Definition.Namespace)
+
+	err := builder.apiClient.Update(context.TODO(), builder.Definition)
+
+	if err != nil {
+		if force {
+			glog.V(100).Infof(
+				msg.FailToUpdateNotification("PtpConfig", builder.Definition.Name, builder.Definition.Namespace))
+
+			err = builder.Delete()
+
+			if err != nil {
+				glog.V(100).Infof(
+					msg.FailToUpdateError("PtpConfig", builder.Definition.Name, builder.Definition.Namespace))
+
+				return nil, err
+			}
+
+			return builder.Create()
+		}
+
+		return nil, err
+	}
+
+	builder.Object = builder.Definition
+
+	return builder, nil
+}
+
+// getProfile returns a pointer to the profile with the given name in the definition or nil if it does not exist.
+func (builder *PtpConfigBuilder) getProfile(profileName string) *ptpv1.PtpProfile {
+	for index := range builder.Definition.Spec.Profile {
+		profile := &builder.Definition.Spec.Profile[index]
+
+		if profile.Name != nil && *profile.Name == profileName {
+			return profile
+		}
+	}
+
+	return nil
+}
+
+// setProfileField applies the mutation to the profile with the given name, which must have been added before.
+func (builder *PtpConfigBuilder) setProfileField(
+	profileName, field string, mutate func(profile *ptpv1.PtpProfile)) *PtpConfigBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Setting %s of profile %s in PtpConfig %s", field, profileName, builder.Definition.Name)
+
+	profile := builder.getProfile(profileName)
+	if profile == nil {
+		builder.errorMsg = fmt.Sprintf("PtpConfig profile %s does not exist", profileName)
+
+		return builder
+	}
+
+	mutate(profile)
+
+	return builder
+}
+
+// validate will check that the builder and builder definition are properly initialized before
+// accessing any member fields.
+func (builder *PtpConfigBuilder) validate() (bool, error) {
+	resourceCRD := "PtpConfig"
+
+	if builder == nil {
+		glog.V(100).Infof("The %s builder is uninitialized", resourceCRD)
+
+		return false, fmt.Errorf("error: received nil %s builder", resourceCRD)
+	}
+
+	if builder.Definition == nil {
+		glog.V(100).Infof("The %s is undefined", resourceCRD)
+
+		builder.errorMsg = msg.UndefinedCrdObjectErrString(resourceCRD)
+	}
+
+	if builder.apiClient == nil {
+		glog.V(100).Infof("The %s builder apiclient is nil", resourceCRD)
+
+		builder.errorMsg = fmt.Sprintf("%s builder cannot have nil apiClient", resourceCRD)
+	}
+
+	if builder.errorMsg != "" {
+		glog.V(100).Infof("The %s builder has error message: %s", resourceCRD, builder.errorMsg)
+
+		return false, fmt.Errorf(builder.errorMsg)
+	}
+
+	return true, nil
+}
@@ -0,0 +1,351 @@
+package ptp
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/openshift-kni/eco-goinfra/pkg/clients"
+	ptpv1 "github.com/openshift/ptp-operator/api/v1"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+)
+
+var (
+	defaultPtpConfigName    = "ptpconfig"
+	defaultPtpConfigNsName  = "openshift-ptp"
+	defaultPtpProfileName   = "slave"
+	defaultPtpInterfaceName = "ens1f0"
+)
+
+func TestNewPtpConfigBuilder(t *testing.T) {
+	testCases := []struct {
+		name              string
+		nsname            string
+		expectedErrorText string
+	}{
+		{
+			name:   defaultPtpConfigName,
+			nsname: defaultPtpConfigNsName,
+		},
+		{
+			name:              "",
+			nsname:            defaultPtpConfigNsName,
+			expectedErrorText: "PtpConfig 'name' cannot be empty",
+		},
+		{
+			name:              defaultPtpConfigName,
+			nsname:            "",
+			expectedErrorText: "PtpConfig 'nsname' cannot be empty",
+		},
+	}
+
+	for _, testCase := range testCases {
+		testBuilder := NewPtpConfigBuilder(
+			clients.GetTestClients(clients.TestClientParams{}), testCase.name, testCase.nsname)
+		assert.Equal(t, testCase.expectedErrorText, testBuilder.errorMsg)
+
+		if testCase.expectedErrorText == "" {
+			assert.Equal(t, testCase.name, testBuilder.Definition.Name)
+			assert.Equal(t, testCase.nsname, testBuilder.Definition.Namespace)
+			assert.NotNil(t, testBuilder.Definition.Spec.Profile)
+			assert.NotNil(t, testBuilder.Definition.Spec.Recommend)
+		}
+	}
+}
+
+func TestPullPtpConfig(t *testing.T) {
+	testCases := []struct {
+		name                string
+		nsname              string
+		addToRuntimeObjects bool
+		client              bool
+		expectedError       error
+	}{
+		{
+			name:                defaultPtpConfigName,
+			nsname:              defaultPtpConfigNsName,
+			addToRuntimeObjects: true,
+			client:              true,
+			expectedError:       nil,
+		},
+		{
+			name:                "",
+			nsname:              defaultPtpConfigNsName,
+			addToRuntimeObjects: true,
+			client:              true,
+			expectedError:       fmt.Errorf("PtpConfig 'name' cannot be empty"),
+		},
+		{
+			name:                defaultPtpConfigName,
+			nsname:              "",
+			addToRuntimeObjects: true,
+			client:              true,
+			expectedError:       fmt.Errorf("PtpConfig 'namespace' cannot be empty"),
+		},
+		{
+			name:                defaultPtpConfigName,
+			nsname:              defaultPtpConfigNsName,
+			addToRuntimeObjects: false,
+			client:              true,
+			expectedError: fmt.Errorf(
+				"PtpConfig object %s does not exist in namespace %s", defaultPtpConfigName, defaultPtpConfigNsName),
+		},
+		{
+			name:                defaultPtpConfigName,
+			nsname:              defaultPtpConfigNsName,
+			addToRuntimeObjects: true,
+			client:              false,
+			expectedError:       fmt.Errorf("PtpConfig 'apiClient' cannot be empty"),
+		},
+	}
+
+	for _, testCase := range testCases {
+		var (
+			runtimeObjects []runtime.Object
+			testSettings   *clients.Settings
+		)
+
+		if testCase.addToRuntimeObjects {
+			runtimeObjects = buildDummyPtpConfigObject()
+		}
+
+		if testCase.client {
+			testSettings = clients.GetTestClients(clients.TestClientParams{K8sMockObjects: runtimeObjects})
+		}
+
+		testBuilder, err := PullPtpConfig(testSettings, testCase.name, testCase.nsname)
+		assert.Equal(t, testCase.expectedError, err)
+
+		if testCase.expectedError == nil {
+			assert.Equal(t, testCase.name, testBuilder.Definition.Name)
+			assert.Equal(t, defaultPtpInterfaceName, *testBuilder.Definition.Spec.Profile[0].Interface)
+		}
+	}
+}
+
+func TestPtpConfigWithProfile(t *testing.T) {
+	testBuilder := buildValidPtpConfigTestBuilder(buildTestPtpConfigClientWithDummyObject()).
+		WithProfile(defaultPtpProfileName, defaultPtpInterfaceName).
+		WithPtp4lOpts(defaultPtpProfileName, "-2 -s").
+		WithPtp4lConf(defaultPtpProfileName, "[global]").
+		WithPhc2sysOpts(defaultPtpProfileName, "-a -r").
+		WithTs2PhcOpts(defaultPtpProfileName, "-s generic").
+		WithTs2PhcConf(defaultPtpProfileName, "[nmea]").
+		WithSchedulingPolicy(defaultPtpProfileName, "SCHED_FIFO", 10).
+		WithClockThreshold(defaultPtpProfileName, 5, 100, -100).
+		WithPtpSettings(defaultPtpProfileName, "logReduce", "true")
+	assert.Equal(t, "", testBuilder.errorMsg)
+
+	profile := testBuilder.Definition.Spec.Profile[0]
+	assert.Equal(t, defaultPtpProfileName, *profile.Name)
+	assert.Equal(t, defaultPtpInterfaceName, *profile.Interface)
+	assert.Equal(t, "-2 -s", *profile.Ptp4lOpts)
+	assert.Equal(t, "[global]", *profile.Ptp4lConf)
+	assert.Equal(t, "-a -r", *profile.Phc2sysOpts)
+	assert.Equal(t, "-s generic", *profile.Ts2PhcOpts)
+	assert.Equal(t, "[nmea]", *profile.Ts2PhcConf)
+	assert.Equal(t, "SCHED_FIFO", *profile.PtpSchedulingPolicy)
+	assert.Equal(t, int64(10), *profile.PtpSchedulingPriority)
+	assert.Equal(t, &ptpv1.PtpClockThreshold{
+		HoldOverTimeout: 5, MaxOffsetThreshold: 100, MinOffsetThreshold: -100}, profile.PtpClockThreshold)
+	assert.Equal(t, map[string]string{"logReduce": "true"}, profile.PtpSettings)
+
+	testBuilder = buildValidPtpConfigTestBuilder(buildTestPtpConfigClientWithDummyObject()).WithProfile("bc", "")
+	assert.Equal(t, "", testBuilder.errorMsg)
+	assert.Nil(t, testBuilder.Definition.Spec.Profile[0].Interface)
+
+	testCases := []struct {
+		testBuilder       *PtpConfigBuilder
+		expectedErrorText string
+	}{
+		{
+			testBuilder:       buildValidPtpConfigTestBuilder(buildTestPtpConfigClientWithDummyObject()).WithProfile("", ""),
+			expectedErrorText: "PtpConfig profile 'name' cannot be empty",
+		},
+		{
+			testBuilder: buildValidPtpConfigTestBuilder(buildTestPtpConfigClientWithDummyObject()).
+				WithProfile(defaultPtpProfileName, "").WithProfile(defaultPtpProfileName, ""),
+			expectedErrorText: "PtpConfig profile slave already exists",
+		},
+		{
+			testBuilder: buildValidPtpConfigTestBuilder(buildTestPtpConfigClientWithDummyObject()).
+				WithPtp4lOpts(defaultPtpProfileName, "-2"),
+			expectedErrorText: "PtpConfig profile slave does not exist",
+		},
+		{
+			testBuilder: buildValidPtpConfigTestBuilder(buildTestPtpConfigClientWithDummyObject()).
+				WithProfile(defaultPtpProfileName, "").WithSchedulingPolicy(defaultPtpProfileName, "SCHED_RR", 10),
+			expectedErrorText: "invalid scheduling policy SCHED_RR, allowed values are SCHED_OTHER and SCHED_FIFO",
+		},
+		{
+			testBuilder: buildValidPtpConfigTestBuilder(buildTestPtpConfigClientWithDummyObject()).
+				WithProfile(defaultPtpProfileName, "").WithSchedulingPolicy(defaultPtpProfileName, "SCHED_FIFO", 0),
+			expectedErrorText: "invalid scheduling priority, allowed values are between 1-65",
+		},
+		{
+			testBuilder: buildValidPtpConfigTestBuilder(buildTestPtpConfigClientWithDummyObject()).
+				WithProfile(defaultPtpProfileName, "").WithClockThreshold(defaultPtpProfileName, 5, -100, 100),
+			expectedErrorText: "invalid clock threshold, 'minOffset' cannot be greater than 'maxOffset'",
+		},
+		{
+			testBuilder: buildValidPtpConfigTestBuilder(buildTestPtpConfigClientWithDummyObject()).
+				WithProfile(defaultPtpProfileName, "").WithPtpSettings(defaultPtpProfileName, "", "true"),
+			expectedErrorText: "PtpConfig ptpSettings 'key' cannot be empty",
+		},
+	}
+
+	for _, testCase := range testCases {
+		assert.Equal(t, testCase.expectedErrorText, testCase.testBuilder.errorMsg)
+	}
+}
+
+func TestPtpConfigWithRecommend(t *testing.T) {
+	testBuilder := buildValidPtpConfigTestBuilder(buildTestPtpConfigClientWithDummyObject()).
+		WithRecommend(defaultPtpProfileName, 4, ptpv1.MatchRule{NodeLabel: ptr.To("node-role.kubernetes.io/worker")})
+	assert.Equal(t, "", testBuilder.errorMsg)
+	assert.Equal(t, []ptpv1.PtpRecommend{{
+		Profile:  ptr.To(defaultPtpProfileName),
+		Priority: ptr.To(int64(4)),
+		Match:    []ptpv1.MatchRule{{NodeLabel: ptr.To("node-role.kubernetes.io/worker")}},
+	}}, testBuilder.Definition.Spec.Recommend)
+
+	testBuilder = buildValidPtpConfigTestBuilder(buildTestPtpConfigClientWithDummyObject()).WithRecommend("", 4)
+	assert.Equal(t, "PtpConfig recommend 'profileName' cannot be empty", testBuilder.errorMsg)
+
+	testBuilder = buildValidPtpConfigTestBuilder(buildTestPtpConfigClientWithDummyObject()).
+		WithRecommend(defaultPtpProfileName, -1)
+	assert.Equal(t, "PtpConfig recommend 'priority' cannot be negative", testBuilder.errorMsg)
+
+	testBuilder = buildValidPtpConfigTestBuilder(buildTestPtpConfigClientWithDummyObject()).
+		WithRecommend(defaultPtpProfileName, 4, ptpv1.MatchRule{})
+	assert.Equal(t, "PtpConfig recommend match rule must have either nodeLabel or nodeName", testBuilder.errorMsg)
+}
+
+func TestPtpConfigWithOptions(t *testing.T) {
+	testBuilder := buildValidPtpConfigTestBuilder(buildTestPtpConfigClientWithDummyObject()).WithOptions(
+		func(builder *PtpConfigBuilder) (*PtpConfigBuilder, error) {
+			return builder, nil
+		})
+	assert.Equal(t, "", testBuilder.errorMsg)
+
+	testBuilder = buildValidPtpConfigTestBuilder(buildTestPtpConfigClientWithDummyObject()).WithOptions(
+		func(builder *PtpConfigBuilder) (*PtpConfigBuilder, error) {
+			return builder, fmt.Errorf("error")
+		})
+	assert.Equal(t, "error", testBuilder.errorMsg)
+}
+
+func TestPtpConfigCreate(t *testing.T) {
+	testCases := []struct {
+		testPtpConfig *PtpConfigBuilder
+		expectedError error
+	}{
+		{
+			testPtpConfig: buildValidPtpConfigTestBuilder(clients.GetTestClients(clients.TestClientParams{})),
+			expectedError: nil,
+		},
+		{
+			testPtpConfig: buildValidPtpConfigTestBuilder(buildTestPtpConfigClientWithDummyObject()),
+			expectedError: nil,
+		},
+		{
+			testPtpConfig: buildInvalidPtpConfigTestBuilder(buildTestPtpConfigClientWithDummyObject()),
+			expectedError: fmt.Errorf("PtpConfig 'nsname' cannot be empty"),
+		},
+	}
+
+	for _, testCase := range testCases {
+		testBuilder, err := testCase.testPtpConfig.Create()
+		assert.Equal(t, testCase.expectedError, err)
+
+		if testCase.expectedError == nil {
+			assert.Equal(t, testBuilder.Definition.Name, testBuilder.Object.Name)
+			assert.True(t, testBuilder.Exists())
+		}
+	}
+}
+
+func TestPtpConfigDelete(t *testing.T) {
+	testCases := []struct {
+		testPtpConfig *PtpConfigBuilder
+		expectedError error
+	}{
+		{
+			testPtpConfig: buildValidPtpConfigTestBuilder(buildTestPtpConfigClientWithDummyObject()),
+			expectedError: nil,
+		},
+		{
+			testPtpConfig: buildValidPtpConfigTestBuilder(clients.GetTestClients(clients.TestClientParams{})),
+			expectedError: nil,
+		},
+		{
+			testPtpConfig: buildInvalidPtpConfigTestBuilder(buildTestPtpConfigClientWithDummyObject()),
+			expectedError: fmt.Errorf("PtpConfig 'nsname' cannot be empty"),
+		},
+	}
+
+	for _, testCase := range testCases {
+		err := testCase.testPtpConfig.Delete()
+		assert.Equal(t, testCase.expectedError, err)
+
+		if testCase.expectedError == nil {
+			assert.Nil(t, testCase.testPtpConfig.Object)
+			assert.False(t, testCase.testPtpConfig.Exists())
+		}
+	}
+}
+
+func TestPtpConfigUpdate(t *testing.T) {
+	testBuilder := buildValidPtpConfigTestBuilder(buildTestPtpConfigClientWithDummyObject())
+	assert.True(t, testBuilder.Exists())
+
+	testBuilder.Definition.ResourceVersion = testBuilder.Object.ResourceVersion
+	testBuilder.WithProfile(defaultPtpProfileName, "ens2f0")
+
+	testBuilder, err := testBuilder.Update(false)
+	assert.Nil(t, err)
+	assert.Equal(t, "ens2f0", *testBuilder.Object.Spec.Profile[0].Interface)
+
+	testBuilder = buildValidPtpConfigTestBuilder(clients.GetTestClients(clients.TestClientParams{}))
+	_, err = testBuilder.Update(false)
+	assert.NotNil(t, err)
+
+	testBuilder, err = testBuilder.Update(true)
+	assert.Nil(t, err)
+	assert.True(t, testBuilder.Exists())
+}
+
+func buildValidPtpConfigTestBuilder(apiClient *clients.Settings) *PtpConfigBuilder {
+	return NewPtpConfigBuilder(apiClient, defaultPtpConfigName, defaultPtpConfigNsName)
+}
+
+func buildInvalidPtpConfigTestBuilder(apiClient *clients.Settings) *PtpConfigBuilder {
+	return NewPtpConfigBuilder(apiClient, defaultPtpConfigName, "")
+}
+
+func buildTestPtpConfigClientWithDummyObject() *clients.Settings {
+	return clients.GetTestClients(clients.TestClientParams{
+		K8sMockObjects: buildDummyPtpConfigObject(),
+	})
+}
+
+func buildDummyPtpConfigObject() []runtime.Object {
+	return append([]runtime.Object{}, &ptpv1.PtpConfig{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      defaultPtpConfigName,
+			Namespace: defaultPtpConfigNsName,
+		},
+		Spec: ptpv1.PtpConfigSpec{
+			Profile: []ptpv1.PtpProfile{{
+				Name:      ptr.To(defaultPtpProfileName),
+				Interface: ptr.To(defaultPtpInterfaceName),
+			}},
+			Recommend: []ptpv1.PtpRecommend{{
+				Profile:  ptr.To(defaultPtpProfileName),
+				Priority: ptr.To(int64(4)),
+			}},
+		},
+	})
+}
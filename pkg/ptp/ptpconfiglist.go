@@ -0,0 +1,76 @@
+package ptp
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/golang/glog"
+	"github.com/openshift-kni/eco-goinfra/pkg/clients"
+	ptpv1 "github.com/openshift/ptp-operator/api/v1"
+	goclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ListPtpConfigs returns a PtpConfig list in a given namespace.
+func ListPtpConfigs(apiClient *clients.Settings, nsname string) ([]*PtpConfigBuilder, error) {
+	glog.V(100).Infof("Listing PtpConfigs in the namespace %s", nsname)
+
+	if apiClient == nil {
+		glog.V(100).Infof("The apiClient is empty")
+
+		return nil, fmt.Errorf("failed to list PtpConfigs, 'apiClient' parameter is empty")
+	}
+
+	if nsname == "" {
+		glog.V(100).Infof("PtpConfigs 'nsname' parameter can not be empty")
+
+		return nil, fmt.Errorf("failed to list PtpConfigs, 'nsname' parameter is empty")
+	}
+
+	ptpConfigList := &ptpv1.PtpConfigList{}
+
+	err := apiClient.List(context.TODO(), ptpConfigList, &goclient.ListOptions{Namespace: nsname})
+	if err != nil {
+		glog.V(100).Infof("Failed to list PtpConfigs in namespace %s due to %s", nsname, err.Error())
+
+		return nil, err
+	}
+
+	var ptpConfigBuilders []*PtpConfigBuilder
+
+	for _, ptpConfigObj := range ptpConfigList.Items {
+		ptpConfig := ptpConfigObj
+		ptpConfigBuilder := &PtpConfigBuilder{
+			apiClient:  apiClient,
+			Definition: &ptpConfig,
+			Object:     &ptpConfig,
+		}
+
+		ptpConfigBuilders = append(ptpConfigBuilders, ptpConfigBuilder)
+	}
+
+	return ptpConfigBuilders, nil
+}
+
+// CleanAllPtpConfigs removes all PtpConfigs in the given namespace.
+func CleanAllPtpConfigs(apiClient *clients.Settings, nsname string) error {
+	glog.V(100).Infof("Cleaning up PtpConfigs in the %s namespace", nsname)
+
+	ptpConfigs, err := ListPtpConfigs(apiClient, nsname)
+	if err != nil {
+		glog.V(100).Infof("Failed to list PtpConfigs in namespace: %s", nsname)
+
+		return err
+	}
+
+	for _, ptpConfig := range ptpConfigs {
+		err = ptpConfig.Delete()
+
+		if err != nil {
+			glog.V(100).Infof("Failed to delete PtpConfig: %s", ptpConfig.Object.Name)
+
+			return err
+		}
+	}
+
+	return nil
+}
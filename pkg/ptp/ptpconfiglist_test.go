@@ -0,0 +1,63 @@
+package ptp
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/openshift-kni/eco-goinfra/pkg/clients"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListPtpConfigs(t *testing.T) {
+	testCases := []struct {
+		nsname        string
+		client        bool
+		expectedError error
+	}{
+		{
+			nsname:        defaultPtpConfigNsName,
+			client:        true,
+			expectedError: nil,
+		},
+		{
+			nsname:        "",
+			client:        true,
+			expectedError: fmt.Errorf("failed to list PtpConfigs, 'nsname' parameter is empty"),
+		},
+		{
+			nsname:        defaultPtpConfigNsName,
+			client:        false,
+			expectedError: fmt.Errorf("failed to list PtpConfigs, 'apiClient' parameter is empty"),
+		},
+	}
+
+	for _, testCase := range testCases {
+		var testSettings *clients.Settings
+
+		if testCase.client {
+			testSettings = buildTestPtpConfigClientWithDummyObject()
+		}
+
+		ptpConfigs, err := ListPtpConfigs(testSettings, testCase.nsname)
+		assert.Equal(t, testCase.expectedError, err)
+
+		if testCase.expectedError == nil {
+			assert.Len(t, ptpConfigs, 1)
+			assert.Equal(t, defaultPtpConfigName, ptpConfigs[0].Definition.Name)
+		}
+	}
+}
+
+func TestCleanAllPtpConfigs(t *testing.T) {
+	testSettings := buildTestPtpConfigClientWithDummyObject()
+
+	err := CleanAllPtpConfigs(testSettings, defaultPtpConfigNsName)
+	assert.Nil(t, err)
+
+	ptpConfigs, err := ListPtpConfigs(testSettings, defaultPtpConfigNsName)
+	assert.Nil(t, err)
+	assert.Empty(t, ptpConfigs)
+
+	err = CleanAllPtpConfigs(testSettings, "")
+	assert.Equal(t, fmt.Errorf("failed to list PtpConfigs, 'nsname' parameter is empty"), err)
+}
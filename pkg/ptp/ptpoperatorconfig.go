@@ -0,0 +1,318 @@
+package ptp
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/golang/glog"
+	"github.com/openshift-kni/eco-goinfra/pkg/clients"
+	"github.com/openshift-kni/eco-goinfra/pkg/msg"
+	ptpv1 "github.com/openshift/ptp-operator/api/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	goclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// PtpOperatorConfigName is the name of the PtpOperatorConfig created by the ptp operator.
+	PtpOperatorConfigName = "default"
+	// EventStorageTypeEmptyDir stores the cloud-event-proxy subscriptions in an emptyDir volume.
+	EventStorageTypeEmptyDir = "emptyDir"
+)
+
+// PtpOperatorConfigBuilder provides struct for PtpOperatorConfig object which contains connection to cluster and
+// PtpOperatorConfig definition.
+type PtpOperatorConfigBuilder struct {
+	// PtpOperatorConfig definition. Used to create PtpOperatorConfig object.
+	Definition *ptpv1.PtpOperatorConfig
+	// Created PtpOperatorConfig object.
+	Object *ptpv1.PtpOperatorConfig
+	// Used in functions that define or mutate PtpOperatorConfig definitions. errorMsg is processed before
+	// PtpOperatorConfig object is created.
+	errorMsg string
+	// apiClient opens api connection to the cluster.
+	apiClient *clients.Settings
+}
+
+// PtpOperatorConfigAdditionalOptions additional options for PtpOperatorConfig object.
+type PtpOperatorConfigAdditionalOptions func(builder *PtpOperatorConfigBuilder) (*PtpOperatorConfigBuilder, error)
+
+// NewPtpOperatorConfigBuilder creates new instance of PtpOperatorConfigBuilder. The ptp operator only reconciles the
+// PtpOperatorConfig named default, which is why the name is not configurable.
+func NewPtpOperatorConfigBuilder(apiClient *clients.Settings, nsname string) *PtpOperatorConfigBuilder {
+	glog.V(100).Infof(
+		"Initializing new PtpOperatorConfig structure with the name %s in the namespace %s",
+		PtpOperatorConfigName, nsname)
+
+	builder := PtpOperatorConfigBuilder{
+		apiClient: apiClient,
+		Definition: &ptpv1.PtpOperatorConfig{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      PtpOperatorConfigName,
+				Namespace: nsname,
+			},
+			Spec: ptpv1.PtpOperatorConfigSpec{
+				DaemonNodeSelector: map[string]string{},
+			},
+		},
+	}
+
+	if nsname == "" {
+		glog.V(100).Infof("The namespace of the PtpOperatorConfig is empty")
+
+		builder.errorMsg = "PtpOperatorConfig 'nsname' cannot be empty"
+	}
+
+	return &builder
+}
+
+// WithDaemonNodeSelector sets the node selector of the linuxptp-daemon in the PtpOperatorConfig definition spec.
+func (builder *PtpOperatorConfigBuilder) WithDaemonNodeSelector(
+	nodeSelector map[string]string) *PtpOperatorConfigBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Setting PtpOperatorConfig daemon node selector: %v", nodeSelector)
+
+	if len(nodeSelector) == 0 {
+		builder.errorMsg = "PtpOperatorConfig 'nodeSelector' cannot be empty"
+
+		return builder
+	}
+
+	builder.Definition.Spec.DaemonNodeSelector = nodeSelector
+
+	return builder
+}
+
+// WithEventConfig enables the cloud-event-proxy publisher in the PtpOperatorConfig definition spec. The
+// transportHost is the AMQP or HTTP transport address of the events, and the storageType is either a storage class
+// name or emptyDir. The storageType is only required with the HTTP transport.
+func (builder *PtpOperatorConfigBuilder) WithEventConfig(transportHost, storageType string) *PtpOperatorConfigBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Setting PtpOperatorConfig event config with transportHost %s and storageType %s",
+		transportHost, storageType)
+
+	if transportHost == "" {
+		builder.errorMsg = "PtpOperatorConfig event 'transportHost' cannot be empty"
+
+		return builder
+	}
+
+	builder.Definition.Spec.EventConfig = &ptpv1.PtpEventConfig{
+		EnableEventPublisher: true,
+		TransportHost:        transportHost,
+		StorageType:          storageType,
+	}
+
+	return builder
+}
+
+// WithOptions creates PtpOperatorConfig with generic mutation options.
+func (builder *PtpOperatorConfigBuilder) WithOptions(
+	options ...PtpOperatorConfigAdditionalOptions) *PtpOperatorConfigBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Setting PtpOperatorConfig additional options")
+
+	for _, option := range options {
+		if option != nil {
+			builder, err := option(builder)
+
+			if err != nil {
+				glog.V(100).Infof("Error occurred in mutation function")
+
+				builder.errorMsg = err.Error()
+
+				return builder
+			}
+		}
+	}
+
+	return builder
+}
+
+// PullPtpOperatorConfig pulls the existing default PtpOperatorConfig from cluster.
+func PullPtpOperatorConfig(apiClient *clients.Settings, nsname string) (*PtpOperatorConfigBuilder, error) {
+	glog.V(100).Infof("Pulling existing PtpOperatorConfig under namespace %s from cluster", nsname)
+
+	if apiClient == nil {
+		glog.V(100).Infof("The apiClient is empty")
+
+		return nil, fmt.Errorf("PtpOperatorConfig 'apiClient' cannot be empty")
+	}
+
+	builder := PtpOperatorConfigBuilder{
+		apiClient: apiClient,
+		Definition: &ptpv1.PtpOperatorConfig{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      PtpOperatorConfigName,
+				Namespace: nsname,
+			},
+		},
+	}
+
+	if nsname == "" {
+		glog.V(100).Infof("The namespace of the PtpOperatorConfig is empty")
+
+		return nil, fmt.Errorf("PtpOperatorConfig 'namespace' cannot be empty")
+	}
+
+	if !builder.Exists() {
+		return nil, fmt.Errorf("PtpOperatorConfig object %s does not exist in namespace %s",
+			PtpOperatorConfigName, nsname)
+	}
+
+	builder.Definition = builder.Object
+
+	return &builder, nil
+}
+
+// Get returns PtpOperatorConfig object if found.
+func (builder *PtpOperatorConfigBuilder) Get() (*ptpv1.PtpOperatorConfig, error) {
+	if valid, err := builder.validate(); !valid {
+		return nil, err
+	}
+
+	glog.V(100).Infof("Collecting PtpOperatorConfig object %s in namespace %s",
+		builder.Definition.Name, builder.Definition.Namespace)
+
+	ptpOperatorConfig := &ptpv1.PtpOperatorConfig{}
+	err := builder.apiClient.Get(context.TODO(), goclient.ObjectKey{
+		Name:      builder.Definition.Name,
+		Namespace: builder.Definition.Namespace,
+	}, ptpOperatorConfig)
+
+	if err != nil {
+		glog.V(100).Infof("Failed to get PtpOperatorConfig %s in namespace %s",
+			builder.Definition.Name, builder.Definition.Namespace)
+
+		return nil, err
+	}
+
+	return ptpOperatorConfig, nil
+}
+
+// Exists checks whether the given PtpOperatorConfig object exists in a cluster.
+func (builder *PtpOperatorConfigBuilder) Exists() bool {
+	if valid, _ := builder.validate(); !valid {
+		return false
+	}
+
+	glog.V(100).Infof("Checking if PtpOperatorConfig %s exists in namespace %s",
+		builder.Definition.Name, builder.Definition.Namespace)
+
+	var err error
+	builder.Object, err = builder.Get()
+
+	return err == nil || !k8serrors.IsNotFound(err)
+}
+
+// Create generates PtpOperatorConfig in a cluster and stores the created object in struct.
+func (builder *PtpOperatorConfigBuilder) Create() (*PtpOperatorConfigBuilder, error) {
+	if valid, err := builder.validate(); !valid {
+		return builder, err
+	}
+
+	glog.V(100).Infof("Creating the PtpOperatorConfig %s in namespace %s",
+		builder.Definition.Name, builder.Definition.Namespace)
+
+	if !builder.Exists() {
+		err := builder.apiClient.Create(context.TODO(), builder.Definition)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	builder.Object = builder.Definition
+
+	return builder, nil
+}
+
+// Delete removes PtpOperatorConfig object.
+func (builder *PtpOperatorConfigBuilder) Delete() error {
+	if valid, err := builder.validate(); !valid {
+		return err
+	}
+
+	glog.V(100).Infof("Deleting the PtpOperatorConfig object %s in namespace %s",
+		builder.Definition.Name, builder.Definition.Namespace)
+
+	if !builder.Exists() {
+		return nil
+	}
+
+	err := builder.apiClient.Delete(context.TODO(), builder.Object)
+
+	if err != nil {
+		return err
+	}
+
+	builder.Object = nil
+
+	return nil
+}
+
+// Update renovates the existing PtpOperatorConfig object with the PtpOperatorConfig definition in builder.
+func (builder *PtpOperatorConfigBuilder) Update() (*PtpOperatorConfigBuilder, error) {
+	if valid, err := builder.validate(); !valid {
+		return builder, err
+	}
+
+	glog.V(100).Infof("Updating the PtpOperatorConfig object %s in namespace %s",
+		builder.Definition.Name, builder.Definition.Namespace)
+
+	if !builder.Exists() {
+		return nil, fmt.Errorf("failed to update PtpOperatorConfig, object does not exist on cluster")
+	}
+
+	builder.Definition.ResourceVersion = builder.Object.ResourceVersion
+
+	err := builder.apiClient.Update(context.TODO(), builder.Definition)
+	if err != nil {
+		return nil, err
+	}
+
+	builder.Object = builder.Definition
+
+	return builder, nil
+}
+
+// validate will check that the builder and builder definition are properly initialized before
+// accessing any member fields.
+func (builder *PtpOperatorConfigBuilder) validate() (bool, error) {
+	resourceCRD := "PtpOperatorConfig"
+
+	if builder == nil {
+		glog.V(100).Infof("The %s builder is uninitialized", resourceCRD)
+
+		return false, fmt.Errorf("error: received nil %s builder", resourceCRD)
+	}
+
+	if builder.Definition == nil {
+		glog.V(100).Infof("The %s is undefined", resourceCRD)
+
+		builder.errorMsg = msg.UndefinedCrdObjectErrString(resourceCRD)
+	}
+
+	if builder.apiClient == nil {
+		glog.V(100).Infof("The %s builder apiclient is nil", resourceCRD)
+
+		builder.errorMsg = fmt.Sprintf("%s builder cannot have nil apiClient", resourceCRD)
+	}
+
+	if builder.errorMsg != "" {
+		glog.V(100).Infof("The %s builder has error message: %s", resourceCRD, builder.errorMsg)
+
+		return false, fmt.Errorf(builder.errorMsg)
+	}
+
+	return true, nil
+}
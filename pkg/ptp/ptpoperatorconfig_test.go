@@ -0,0 +1,157 @@
+package ptp
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/openshift-kni/eco-goinfra/pkg/clients"
+	ptpv1 "github.com/openshift/ptp-operator/api/v1"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestNewPtpOperatorConfigBuilder(t *testing.T) {
+	testBuilder := NewPtpOperatorConfigBuilder(clients.GetTestClients(clients.TestClientParams{}), defaultPtpConfigNsName)
+	assert.Equal(t, "", testBuilder.errorMsg)
+	assert.Equal(t, PtpOperatorConfigName, testBuilder.Definition.Name)
+	assert.Equal(t, defaultPtpConfigNsName, testBuilder.Definition.Namespace)
+
+	testBuilder = NewPtpOperatorConfigBuilder(clients.GetTestClients(clients.TestClientParams{}), "")
+	assert.Equal(t, "PtpOperatorConfig 'nsname' cannot be empty", testBuilder.errorMsg)
+}
+
+func TestPullPtpOperatorConfig(t *testing.T) {
+	testCases := []struct {
+		nsname              string
+		addToRuntimeObjects bool
+		client              bool
+		expectedError       error
+	}{
+		{
+			nsname:              defaultPtpConfigNsName,
+			addToRuntimeObjects: true,
+			client:              true,
+			expectedError:       nil,
+		},
+		{
+			nsname:              "",
+			addToRuntimeObjects: true,
+			client:              true,
+			expectedError:       fmt.Errorf("PtpOperatorConfig 'namespace' cannot be empty"),
+		},
+		{
+			nsname:              defaultPtpConfigNsName,
+			addToRuntimeObjects: false,
+			client:              true,
+			expectedError: fmt.Errorf(
+				"PtpOperatorConfig object default does not exist in namespace %s", defaultPtpConfigNsName),
+		},
+		{
+			nsname:              defaultPtpConfigNsName,
+			addToRuntimeObjects: true,
+			client:              false,
+			expectedError:       fmt.Errorf("PtpOperatorConfig 'apiClient' cannot be empty"),
+		},
+	}
+
+	for _, testCase := range testCases {
+		var (
+			runtimeObjects []runtime.Object
+			testSettings   *clients.Settings
+		)
+
+		if testCase.addToRuntimeObjects {
+			runtimeObjects = buildDummyPtpOperatorConfigObject()
+		}
+
+		if testCase.client {
+			testSettings = clients.GetTestClients(clients.TestClientParams{K8sMockObjects: runtimeObjects})
+		}
+
+		testBuilder, err := PullPtpOperatorConfig(testSettings, testCase.nsname)
+		assert.Equal(t, testCase.expectedError, err)
+
+		if testCase.expectedError == nil {
+			assert.Equal(t, map[string]string{"node-role.kubernetes.io/worker": ""},
+				testBuilder.Definition.Spec.DaemonNodeSelector)
+		}
+	}
+}
+
+func TestPtpOperatorConfigWithDaemonNodeSelector(t *testing.T) {
+	testBuilder := buildValidPtpOperatorConfigTestBuilder(clients.GetTestClients(clients.TestClientParams{})).
+		WithDaemonNodeSelector(map[string]string{"ptp/slave": ""})
+	assert.Equal(t, "", testBuilder.errorMsg)
+	assert.Equal(t, map[string]string{"ptp/slave": ""}, testBuilder.Definition.Spec.DaemonNodeSelector)
+
+	testBuilder = buildValidPtpOperatorConfigTestBuilder(clients.GetTestClients(clients.TestClientParams{})).
+		WithDaemonNodeSelector(map[string]string{})
+	assert.Equal(t, "PtpOperatorConfig 'nodeSelector' cannot be empty", testBuilder.errorMsg)
+}
+
+func TestPtpOperatorConfigWithEventConfig(t *testing.T) {
+	testBuilder := buildValidPtpOperatorConfigTestBuilder(clients.GetTestClients(clients.TestClientParams{})).
+		WithEventConfig("http://ptp-event-publisher-service-NODE_NAME.openshift-ptp.svc.cluster.local:9043",
+			EventStorageTypeEmptyDir)
+	assert.Equal(t, "", testBuilder.errorMsg)
+	assert.True(t, testBuilder.Definition.Spec.EventConfig.EnableEventPublisher)
+	assert.Equal(t, EventStorageTypeEmptyDir, testBuilder.Definition.Spec.EventConfig.StorageType)
+
+	testBuilder = buildValidPtpOperatorConfigTestBuilder(clients.GetTestClients(clients.TestClientParams{})).
+		WithEventConfig("", EventStorageTypeEmptyDir)
+	assert.Equal(t, "PtpOperatorConfig event 'transportHost' cannot be empty", testBuilder.errorMsg)
+}
+
+func TestPtpOperatorConfigWithOptions(t *testing.T) {
+	testBuilder := buildValidPtpOperatorConfigTestBuilder(clients.GetTestClients(clients.TestClientParams{})).
+		WithOptions(func(builder *PtpOperatorConfigBuilder) (*PtpOperatorConfigBuilder, error) {
+			return builder, fmt.Errorf("error")
+		})
+	assert.Equal(t, "error", testBuilder.errorMsg)
+}
+
+func TestPtpOperatorConfigCreateAndDelete(t *testing.T) {
+	testBuilder, err := buildValidPtpOperatorConfigTestBuilder(clients.GetTestClients(clients.TestClientParams{})).
+		Create()
+	assert.Nil(t, err)
+	assert.True(t, testBuilder.Exists())
+
+	err = testBuilder.Delete()
+	assert.Nil(t, err)
+	assert.Nil(t, testBuilder.Object)
+	assert.False(t, testBuilder.Exists())
+
+	_, err = NewPtpOperatorConfigBuilder(clients.GetTestClients(clients.TestClientParams{}), "").Create()
+	assert.Equal(t, fmt.Errorf("PtpOperatorConfig 'nsname' cannot be empty"), err)
+}
+
+func TestPtpOperatorConfigUpdate(t *testing.T) {
+	testSettings := clients.GetTestClients(clients.TestClientParams{K8sMockObjects: buildDummyPtpOperatorConfigObject()})
+
+	testBuilder, err := PullPtpOperatorConfig(testSettings, defaultPtpConfigNsName)
+	assert.Nil(t, err)
+
+	testBuilder, err = testBuilder.WithEventConfig("http://localhost:9043", EventStorageTypeEmptyDir).Update()
+	assert.Nil(t, err)
+	assert.True(t, testBuilder.Object.Spec.EventConfig.EnableEventPublisher)
+
+	_, err = buildValidPtpOperatorConfigTestBuilder(clients.GetTestClients(clients.TestClientParams{})).Update()
+	assert.Equal(t, fmt.Errorf("failed to update PtpOperatorConfig, object does not exist on cluster"), err)
+}
+
+func buildValidPtpOperatorConfigTestBuilder(apiClient *clients.Settings) *PtpOperatorConfigBuilder {
+	return NewPtpOperatorConfigBuilder(apiClient, defaultPtpConfigNsName)
+}
+
+func buildDummyPtpOperatorConfigObject() []runtime.Object {
+	return append([]runtime.Object{}, &ptpv1.PtpOperatorConfig{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      PtpOperatorConfigName,
+			Namespace: defaultPtpConfigNsName,
+		},
+		Spec: ptpv1.PtpOperatorConfigSpec{
+			DaemonNodeSelector: map[string]string{"node-role.kubernetes.io/worker": ""},
+		},
+	})
+}
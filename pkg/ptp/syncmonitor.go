@@ -0,0 +1,461 @@
+package ptp
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/openshift-kni/eco-goinfra/pkg/clients"
+	"github.com/openshift-kni/eco-goinfra/pkg/pod"
+	"github.com/prometheus/common/expfmt"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+const (
+	// LinuxPtpDaemonLabelSelector selects the linuxptp-daemon pods running ptp4l, phc2sys and ts2phc.
+	LinuxPtpDaemonLabelSelector = "app=linuxptp-daemon"
+	// ProcessPtp4l is the name of the ptp4l process synchronizing the PHC to the PTP time source.
+	ProcessPtp4l = "ptp4l"
+	// ProcessPhc2sys is the name of the phc2sys process synchronizing the system clock to the PHC.
+	ProcessPhc2sys = "phc2sys"
+	// ProcessTs2phc is the name of the ts2phc process synchronizing the PHC to an external time source.
+	ProcessTs2phc = "ts2phc"
+
+	linuxPtpDaemonContainerName = "linuxptp-daemon-container"
+	linuxPtpDaemonMetricsURL    = "http://127.0.0.1:9091/metrics"
+	offsetMetricName            = "openshift_ptp_offset_ns"
+	clockStateMetricName        = "openshift_ptp_clock_state"
+	clockClassMetricName        = "openshift_ptp_clock_class"
+)
+
+// ClockState represents the synchronization state of a clock as reported by the linuxptp-daemon.
+type ClockState int
+
+const (
+	// ClockStateFreerun means the clock is not synchronized to its time source.
+	ClockStateFreerun ClockState = iota
+	// ClockStateLocked means the clock is synchronized to its time source.
+	ClockStateLocked
+	// ClockStateHoldover means the clock lost its time source and is within the holdover timeout.
+	ClockStateHoldover
+)
+
+// String returns the name used by the linuxptp-daemon for the clock state.
+func (state ClockState) String() string {
+	switch state {
+	case ClockStateLocked:
+		return "LOCKED"
+	case ClockStateHoldover:
+		return "HOLDOVER"
+	default:
+		return "FREERUN"
+	}
+}
+
+// SyncSample is a single synchronization measurement of a linuxptp process.
+type SyncSample struct {
+	// Process is the linuxptp process the sample belongs to, e.g. ptp4l or phc2sys.
+	Process string
+	// Config is the configuration file of the process, e.g. ptp4l.0.config. Only set for log samples.
+	Config string
+	// Interface is the interface or clock the sample refers to, e.g. ens1f0 or CLOCK_REALTIME. It is empty for
+	// ptp4l log samples, since ptp4l does not print the interface.
+	Interface string
+	// Offset is the offset from the time source in nanoseconds.
+	Offset float64
+	// ClockState is the synchronization state of the clock.
+	ClockState ClockState
+	// ServoState is the servo state printed in the logs: s0 unlocked, s1 clock step, s2 locked and s3 locked
+	// stable. Only set for log samples.
+	ServoState string
+	// Uptime is the monotonic time in seconds at which the process logged the sample. Only set for log samples.
+	Uptime float64
+}
+
+// NodeSyncStatus holds the synchronization state of the linuxptp processes running on a node.
+type NodeSyncStatus struct {
+	// NodeName is the name of the node.
+	NodeName string
+	// Samples are the latest samples of each process and interface.
+	Samples []SyncSample
+	// ClockClass is the PTP clock class announced by each process, e.g. 6 for a locked grandmaster or 248 for a
+	// free running clock.
+	ClockClass map[string]int
+}
+
+// OffsetStatistics summarizes the offsets of a list of samples in nanoseconds.
+type OffsetStatistics struct {
+	Count  int
+	Min    float64
+	Max    float64
+	Mean   float64
+	StdDev float64
+	// MaxAbs is the largest absolute offset.
+	MaxAbs float64
+}
+
+// SyncMonitor reads the synchronization state of the linuxptp-daemon pods from their metrics and logs.
+type SyncMonitor struct {
+	// ptpNsName is the namespace of the linuxptp-daemon pods.
+	ptpNsName string
+	// Used to store latest error message upon defining or mutating the monitor.
+	errorMsg string
+	// apiClient opens api connection to the cluster.
+	apiClient *clients.Settings
+}
+
+var (
+	// ptpLogLineRegex matches the offset lines of ptp4l, phc2sys and ts2phc, e.g.
+	// ptp4l[1234.567]: [ptp4l.0.config] master offset -5 s2 freq -1234 path delay 345
+	// phc2sys[1234.567]: [ptp4l.0.config] CLOCK_REALTIME phc offset -10 s2 freq -2000 delay 500
+	// ts2phc[1234.567]: [ts2phc.0.config] ens2f0 master offset 1 s2 freq +2.
+	ptpLogLineRegex = regexp.MustCompile(
+		`(ptp4l|phc2sys|ts2phc)\[(\d+(?:\.\d+)?)\]:\s+(?:\[([^\]:]*)(?::\d+)?\]\s+)?(?:(\S+)\s+)?` +
+			`(?:master|phc|sys) offset\s+(-?\d+)\s+(s\d)`)
+)
+
+// NewSyncMonitor creates new instance of SyncMonitor for the linuxptp-daemon pods in the given namespace.
+func NewSyncMonitor(apiClient *clients.Settings, ptpNsName string) *SyncMonitor {
+	glog.V(100).Infof("Initializing new PTP SyncMonitor for namespace %s", ptpNsName)
+
+	monitor := SyncMonitor{
+		apiClient: apiClient,
+		ptpNsName: ptpNsName,
+	}
+
+	if ptpNsName == "" {
+		glog.V(100).Infof("The namespace of the SyncMonitor is empty")
+
+		monitor.errorMsg = "SyncMonitor 'ptpNsName' cannot be empty"
+	}
+
+	return &monitor
+}
+
+// GetNodeSyncStatus returns the synchronization state of the given node scraped from the linuxptp-daemon metrics.
+func (monitor *SyncMonitor) GetNodeSyncStatus(nodeName string) (*NodeSyncStatus, error) {
+	if valid, err := monitor.validate(); !valid {
+		return nil, err
+	}
+
+	glog.V(100).Infof("Getting PTP sync status of node %s", nodeName)
+
+	daemonPod, err := monitor.getDaemonPod(nodeName)
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := daemonPod.ExecCommand(
+		[]string{"curl", "-s", linuxPtpDaemonMetricsURL}, linuxPtpDaemonContainerName)
+	if err != nil {
+		glog.V(100).Infof("Failed to get metrics from pod %s: %v", daemonPod.Object.Name, err)
+
+		return nil, fmt.Errorf("failed to get metrics from pod %s: %w", daemonPod.Object.Name, err)
+	}
+
+	nodeStatus, err := parsePtpMetrics(output.String())
+	if err != nil {
+		return nil, err
+	}
+
+	nodeStatus.NodeName = nodeName
+
+	return nodeStatus, nil
+}
+
+// GetLogSamples returns the offset samples logged by ptp4l, phc2sys and ts2phc on the given node within the window.
+func (monitor *SyncMonitor) GetLogSamples(nodeName string, window time.Duration) ([]SyncSample, error) {
+	if valid, err := monitor.validate(); !valid {
+		return nil, err
+	}
+
+	glog.V(100).Infof("Getting PTP log samples of node %s for the last %s", nodeName, window)
+
+	if window <= 0 {
+		return nil, fmt.Errorf("failed to get PTP log samples, 'window' must be positive")
+	}
+
+	daemonPod, err := monitor.getDaemonPod(nodeName)
+	if err != nil {
+		return nil, err
+	}
+
+	logs, err := daemonPod.GetLog(window, linuxPtpDaemonContainerName)
+	if err != nil {
+		glog.V(100).Infof("Failed to get logs of pod %s: %v", daemonPod.Object.Name, err)
+
+		return nil, fmt.Errorf("failed to get logs of pod %s: %w", daemonPod.Object.Name, err)
+	}
+
+	return parsePtpLogs(logs), nil
+}
+
+// GetOffsetStatistics returns the statistics of the offsets logged by the given process on the given node within
+// the window. The samples are further filtered by interface if it is not empty.
+func (monitor *SyncMonitor) GetOffsetStatistics(
+	nodeName, process, interfaceName string, window time.Duration) (*OffsetStatistics, error) {
+	samples, err := monitor.GetLogSamples(nodeName, window)
+	if err != nil {
+		return nil, err
+	}
+
+	var filteredSamples []SyncSample
+
+	for _, sample := range samples {
+		if sample.Process == process && (interfaceName == "" || sample.Interface == interfaceName) {
+			filteredSamples = append(filteredSamples, sample)
+		}
+	}
+
+	return CalculateOffsetStatistics(filteredSamples)
+}
+
+// WaitForClockLocked waits until all the clocks reported by the linuxptp-daemon metrics of the given node have been
+// locked with an absolute offset not greater than maxOffset nanoseconds for at least stableFor.
+func (monitor *SyncMonitor) WaitForClockLocked(
+	nodeName string, maxOffset float64, stableFor, timeout time.Duration) error {
+	if valid, err := monitor.validate(); !valid {
+		return err
+	}
+
+	glog.V(100).Infof("Waiting for PTP clocks of node %s to be locked with max offset %v for %s",
+		nodeName, maxOffset, stableFor)
+
+	if nodeName == "" {
+		return fmt.Errorf("failed to wait for PTP clock lock, 'nodeName' parameter is empty")
+	}
+
+	var lockedSince time.Time
+
+	return wait.PollUntilContextTimeout(
+		context.TODO(), time.Second, timeout, true, func(ctx context.Context) (bool, error) {
+			nodeStatus, err := monitor.GetNodeSyncStatus(nodeName)
+			if err != nil {
+				glog.V(100).Infof("Failed to get PTP sync status of node %s: %v", nodeName, err)
+
+				lockedSince = time.Time{}
+
+				return false, nil
+			}
+
+			if !isNodeClockLocked(nodeStatus, maxOffset) {
+				lockedSince = time.Time{}
+
+				return false, nil
+			}
+
+			if lockedSince.IsZero() {
+				lockedSince = time.Now()
+			}
+
+			return time.Since(lockedSince) >= stableFor, nil
+		})
+}
+
+// CalculateOffsetStatistics returns the statistics of the offsets of the given samples.
+func CalculateOffsetStatistics(samples []SyncSample) (*OffsetStatistics, error) {
+	if len(samples) == 0 {
+		return nil, fmt.Errorf("failed to calculate offset statistics, no samples found")
+	}
+
+	statistics := &OffsetStatistics{
+		Count: len(samples),
+		Min:   math.Inf(1),
+		Max:   math.Inf(-1),
+	}
+
+	var sum float64
+
+	for _, sample := range samples {
+		statistics.Min = math.Min(statistics.Min, sample.Offset)
+		statistics.Max = math.Max(statistics.Max, sample.Offset)
+		statistics.MaxAbs = math.Max(statistics.MaxAbs, math.Abs(sample.Offset))
+		sum += sample.Offset
+	}
+
+	statistics.Mean = sum / float64(len(samples))
+
+	var squaredDiffSum float64
+
+	for _, sample := range samples {
+		squaredDiffSum += math.Pow(sample.Offset-statistics.Mean, 2)
+	}
+
+	statistics.StdDev = math.Sqrt(squaredDiffSum / float64(len(samples)))
+
+	return statistics, nil
+}
+
+func (monitor *SyncMonitor) getDaemonPod(nodeName string) (*pod.Builder, error) {
+	if nodeName == "" {
+		glog.V(100).Infof("The nodeName is empty")
+
+		return nil, fmt.Errorf("failed to get linuxptp-daemon pod, 'nodeName' parameter is empty")
+	}
+
+	daemonPods, err := pod.List(monitor.apiClient, monitor.ptpNsName, metav1.ListOptions{
+		LabelSelector: LinuxPtpDaemonLabelSelector,
+	})
+	if err != nil {
+		glog.V(100).Infof("Failed to list linuxptp-daemon pods in namespace %s: %v", monitor.ptpNsName, err)
+
+		return nil, err
+	}
+
+	for _, daemonPod := range daemonPods {
+		if daemonPod.Object.Spec.NodeName == nodeName {
+			return daemonPod, nil
+		}
+	}
+
+	return nil, fmt.Errorf("failed to find linuxptp-daemon pod on node %s", nodeName)
+}
+
+func isNodeClockLocked(nodeStatus *NodeSyncStatus, maxOffset float64) bool {
+	if len(nodeStatus.Samples) == 0 {
+		return false
+	}
+
+	for _, sample := range nodeStatus.Samples {
+		if sample.ClockState != ClockStateLocked || math.Abs(sample.Offset) > maxOffset {
+			glog.V(100).Infof("Clock %s of process %s on node %s is %s with offset %v",
+				sample.Interface, sample.Process, nodeStatus.NodeName, sample.ClockState, sample.Offset)
+
+			return false
+		}
+	}
+
+	return true
+}
+
+func parsePtpMetrics(output string) (*NodeSyncStatus, error) {
+	var parser expfmt.TextParser
+
+	metricFamilies, err := parser.TextToMetricFamilies(strings.NewReader(output))
+	if err != nil {
+		glog.V(100).Infof("Failed to parse linuxptp-daemon metrics: %v", err)
+
+		return nil, fmt.Errorf("failed to parse linuxptp-daemon metrics: %w", err)
+	}
+
+	nodeStatus := &NodeSyncStatus{ClockClass: make(map[string]int)}
+	samples := make(map[string]*SyncSample)
+
+	getSample := func(labels map[string]string) *SyncSample {
+		key := labels["process"] + "/" + labels["iface"]
+
+		if _, ok := samples[key]; !ok {
+			samples[key] = &SyncSample{Process: labels["process"], Interface: labels["iface"]}
+		}
+
+		return samples[key]
+	}
+
+	if metricFamily, ok := metricFamilies[offsetMetricName]; ok {
+		for _, metric := range metricFamily.GetMetric() {
+			getSample(getMetricLabels(metric.GetLabel())).Offset = metric.GetGauge().GetValue()
+		}
+	}
+
+	if metricFamily, ok := metricFamilies[clockStateMetricName]; ok {
+		for _, metric := range metricFamily.GetMetric() {
+			getSample(getMetricLabels(metric.GetLabel())).ClockState = ClockState(metric.GetGauge().GetValue())
+		}
+	}
+
+	if metricFamily, ok := metricFamilies[clockClassMetricName]; ok {
+		for _, metric := range metricFamily.GetMetric() {
+			labels := getMetricLabels(metric.GetLabel())
+			nodeStatus.ClockClass[labels["process"]] = int(metric.GetGauge().GetValue())
+		}
+	}
+
+	for _, sample := range samples {
+		nodeStatus.Samples = append(nodeStatus.Samples, *sample)
+	}
+
+	sort.Slice(nodeStatus.Samples, func(i, j int) bool {
+		if nodeStatus.Samples[i].Process != nodeStatus.Samples[j].Process {
+			return nodeStatus.Samples[i].Process < nodeStatus.Samples[j].Process
+		}
+
+		return nodeStatus.Samples[i].Interface < nodeStatus.Samples[j].Interface
+	})
+
+	return nodeStatus, nil
+}
+
+func getMetricLabels[T interface {
+	GetName() string
+	GetValue() string
+}](labelPairs []T) map[string]string {
+	labels := make(map[string]string)
+
+	for _, labelPair := range labelPairs {
+		labels[labelPair.GetName()] = labelPair.GetValue()
+	}
+
+	return labels
+}
+
+func parsePtpLogs(logs string) []SyncSample {
+	var samples []SyncSample
+
+	for _, line := range strings.Split(logs, "\n") {
+		match := ptpLogLineRegex.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+
+		uptime, _ := strconv.ParseFloat(match[2], 64)
+		offset, _ := strconv.ParseFloat(match[5], 64)
+
+		sample := SyncSample{
+			Process:    match[1],
+			Config:     match[3],
+			Interface:  match[4],
+			Offset:     offset,
+			ClockState: ClockStateFreerun,
+			ServoState: match[6],
+			Uptime:     uptime,
+		}
+
+		if sample.ServoState == "s2" || sample.ServoState == "s3" {
+			sample.ClockState = ClockStateLocked
+		}
+
+		samples = append(samples, sample)
+	}
+
+	return samples
+}
+
+// validate will check that the monitor is properly initialized before accessing any member fields.
+func (monitor *SyncMonitor) validate() (bool, error) {
+	if monitor == nil {
+		glog.V(100).Infof("The SyncMonitor is uninitialized")
+
+		return false, fmt.Errorf("error: received nil SyncMonitor")
+	}
+
+	if monitor.apiClient == nil {
+		glog.V(100).Infof("The SyncMonitor apiclient is nil")
+
+		monitor.errorMsg = "SyncMonitor cannot have nil apiClient"
+	}
+
+	if monitor.errorMsg != "" {
+		glog.V(100).Infof("The SyncMonitor has error message: %s", monitor.errorMsg)
+
+		return false, fmt.Errorf(monitor.errorMsg)
+	}
+
+	return true, nil
+}
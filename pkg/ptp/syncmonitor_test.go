@@ -0,0 +1,188 @@
+package ptp
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/openshift-kni/eco-goinfra/pkg/clients"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const (
+	dummyPtpMetrics = `# HELP openshift_ptp_offset_ns
+# TYPE openshift_ptp_offset_ns gauge
+openshift_ptp_offset_ns{from="master",iface="ens1f0",node="worker-0",process="ptp4l"} -3
+openshift_ptp_offset_ns{from="phc",iface="CLOCK_REALTIME",node="worker-0",process="phc2sys"} 12
+# HELP openshift_ptp_clock_state
+# TYPE openshift_ptp_clock_state gauge
+openshift_ptp_clock_state{iface="ens1f0",node="worker-0",process="ptp4l"} 1
+openshift_ptp_clock_state{iface="CLOCK_REALTIME",node="worker-0",process="phc2sys"} 2
+# HELP openshift_ptp_clock_class
+# TYPE openshift_ptp_clock_class gauge
+openshift_ptp_clock_class{node="worker-0",process="ptp4l"} 248
+`
+	dummyPtpLogs = `I1019 10:00:00.000000 1 daemon.go:100] starting ptp4l
+ptp4l[1000.100]: [ptp4l.0.config] master offset         -5 s2 freq   -1234 path delay       345
+phc2sys[1000.200]: [ptp4l.0.config] CLOCK_REALTIME phc offset        10 s2 freq   -2000 delay    500
+ptp4l[1001.100]: [ptp4l.0.config:5] master offset          7 s0 freq   -1230 path delay       346
+ts2phc[1001.300]: [ts2phc.0.config] ens2f0 master offset          1 s3 freq      +2
+ptp4l[1002.100]: [ptp4l.0.config] selected best master clock 001122.fffe.334455
+`
+)
+
+func TestNewSyncMonitor(t *testing.T) {
+	testMonitor := NewSyncMonitor(clients.GetTestClients(clients.TestClientParams{}), defaultPtpConfigNsName)
+	assert.Equal(t, "", testMonitor.errorMsg)
+	assert.Equal(t, defaultPtpConfigNsName, testMonitor.ptpNsName)
+
+	testMonitor = NewSyncMonitor(clients.GetTestClients(clients.TestClientParams{}), "")
+	assert.Equal(t, "SyncMonitor 'ptpNsName' cannot be empty", testMonitor.errorMsg)
+}
+
+func TestSyncMonitorGetLogSamples(t *testing.T) {
+	testCases := []struct {
+		nodeName      string
+		window        time.Duration
+		client        bool
+		expectedError error
+	}{
+		{
+			nodeName:      "worker-0",
+			window:        time.Minute,
+			client:        true,
+			expectedError: nil,
+		},
+		{
+			nodeName:      "",
+			window:        time.Minute,
+			client:        true,
+			expectedError: fmt.Errorf("failed to get linuxptp-daemon pod, 'nodeName' parameter is empty"),
+		},
+		{
+			nodeName:      "worker-1",
+			window:        time.Minute,
+			client:        true,
+			expectedError: fmt.Errorf("failed to find linuxptp-daemon pod on node worker-1"),
+		},
+		{
+			nodeName:      "worker-0",
+			window:        0,
+			client:        true,
+			expectedError: fmt.Errorf("failed to get PTP log samples, 'window' must be positive"),
+		},
+		{
+			nodeName:      "worker-0",
+			window:        time.Minute,
+			client:        false,
+			expectedError: fmt.Errorf("SyncMonitor cannot have nil apiClient"),
+		},
+	}
+
+	for _, testCase := range testCases {
+		var testSettings *clients.Settings
+
+		if testCase.client {
+			testSettings = clients.GetTestClients(clients.TestClientParams{K8sMockObjects: buildDummyLinuxPtpDaemonPods()})
+		}
+
+		testMonitor := NewSyncMonitor(testSettings, defaultPtpConfigNsName)
+
+		// The fake clientset always returns "fake logs" which do not contain any offset lines.
+		samples, err := testMonitor.GetLogSamples(testCase.nodeName, testCase.window)
+		assert.Equal(t, testCase.expectedError, err)
+
+		if testCase.expectedError == nil {
+			assert.Empty(t, samples)
+		}
+	}
+}
+
+func TestSyncMonitorWaitForClockLocked(t *testing.T) {
+	testSettings := clients.GetTestClients(clients.TestClientParams{K8sMockObjects: buildDummyLinuxPtpDaemonPods()})
+
+	err := NewSyncMonitor(testSettings, defaultPtpConfigNsName).WaitForClockLocked("", 100, time.Second, time.Second)
+	assert.Equal(t, fmt.Errorf("failed to wait for PTP clock lock, 'nodeName' parameter is empty"), err)
+
+	err = NewSyncMonitor(testSettings, "").WaitForClockLocked("worker-0", 100, time.Second, time.Second)
+	assert.Equal(t, fmt.Errorf("SyncMonitor 'ptpNsName' cannot be empty"), err)
+
+	err = NewSyncMonitor(testSettings, defaultPtpConfigNsName).WaitForClockLocked(
+		"worker-1", 100, time.Second, time.Second)
+	assert.NotNil(t, err)
+}
+
+func TestParsePtpMetrics(t *testing.T) {
+	nodeStatus, err := parsePtpMetrics(dummyPtpMetrics)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]int{ProcessPtp4l: 248}, nodeStatus.ClockClass)
+	assert.Equal(t, []SyncSample{
+		{Process: ProcessPhc2sys, Interface: "CLOCK_REALTIME", Offset: 12, ClockState: ClockStateHoldover},
+		{Process: ProcessPtp4l, Interface: "ens1f0", Offset: -3, ClockState: ClockStateLocked},
+	}, nodeStatus.Samples)
+
+	assert.True(t, isNodeClockLocked(&NodeSyncStatus{Samples: nodeStatus.Samples[1:]}, 5))
+	assert.False(t, isNodeClockLocked(&NodeSyncStatus{Samples: nodeStatus.Samples[1:]}, 2))
+	assert.False(t, isNodeClockLocked(nodeStatus, 100))
+	assert.False(t, isNodeClockLocked(&NodeSyncStatus{}, 100))
+
+	_, err = parsePtpMetrics("openshift_ptp_offset_ns{iface=} 1\n")
+	assert.NotNil(t, err)
+}
+
+func TestParsePtpLogs(t *testing.T) {
+	samples := parsePtpLogs(dummyPtpLogs)
+	assert.Equal(t, []SyncSample{
+		{
+			Process: ProcessPtp4l, Config: "ptp4l.0.config", Offset: -5,
+			ClockState: ClockStateLocked, ServoState: "s2", Uptime: 1000.1,
+		},
+		{
+			Process: ProcessPhc2sys, Config: "ptp4l.0.config", Interface: "CLOCK_REALTIME", Offset: 10,
+			ClockState: ClockStateLocked, ServoState: "s2", Uptime: 1000.2,
+		},
+		{
+			Process: ProcessPtp4l, Config: "ptp4l.0.config", Offset: 7,
+			ClockState: ClockStateFreerun, ServoState: "s0", Uptime: 1001.1,
+		},
+		{
+			Process: ProcessTs2phc, Config: "ts2phc.0.config", Interface: "ens2f0", Offset: 1,
+			ClockState: ClockStateLocked, ServoState: "s3", Uptime: 1001.3,
+		},
+	}, samples)
+}
+
+func TestCalculateOffsetStatistics(t *testing.T) {
+	statistics, err := CalculateOffsetStatistics([]SyncSample{{Offset: -4}, {Offset: 8}, {Offset: -4}, {Offset: 8}})
+	assert.Nil(t, err)
+	assert.Equal(t, &OffsetStatistics{Count: 4, Min: -4, Max: 8, Mean: 2, StdDev: 6, MaxAbs: 8},
+		statistics)
+
+	_, err = CalculateOffsetStatistics(nil)
+	assert.Equal(t, fmt.Errorf("failed to calculate offset statistics, no samples found"), err)
+}
+
+func TestClockStateString(t *testing.T) {
+	assert.Equal(t, "FREERUN", ClockStateFreerun.String())
+	assert.Equal(t, "LOCKED", ClockStateLocked.String())
+	assert.Equal(t, "HOLDOVER", ClockStateHoldover.String())
+}
+
+func buildDummyLinuxPtpDaemonPods() []runtime.Object {
+	return []runtime.Object{
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "linuxptp-daemon-abcde",
+				Namespace: defaultPtpConfigNsName,
+				Labels:    map[string]string{"app": "linuxptp-daemon"},
+			},
+			Spec: corev1.PodSpec{
+				NodeName:   "worker-0",
+				Containers: []corev1.Container{{Name: linuxPtpDaemonContainerName}},
+			},
+		},
+	}
+}
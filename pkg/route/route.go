@@ -3,19 +3,31 @@ package route
 import (
 	"context"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/golang/glog"
 	"github.com/openshift-kni/eco-goinfra/pkg/clients"
 	"github.com/openshift-kni/eco-goinfra/pkg/msg"
 	routev1 "github.com/openshift/api/route/v1"
+	corev1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/utils/strings/slices"
 
 	goclient "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+const (
+	// DefaultRouterName is the name of the router of the default ingresscontroller.
+	DefaultRouterName = "default"
+
+	maxAlternateBackends = 3
+	maxBackendWeight     = 256
+)
+
 // Builder provides struct for route object containing connection to the cluster and the route definitions.
 type Builder struct {
 	// Route definition. Used to create a route object
@@ -189,6 +201,235 @@ func (builder *Builder) WithWildCardPolicy(wildcardPolicy string) *Builder {
 	return builder
 }
 
+// WithHostDomain sets the host of the route. Without a host the router generates one from the route name,
+// namespace and the ingresscontroller domain.
+func (builder *Builder) WithHostDomain(hostDomain string) *Builder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Adding host %s to route %s in namespace %s",
+		hostDomain, builder.Definition.Name, builder.Definition.Namespace)
+
+	if hostDomain == "" {
+		glog.V(100).Infof("Received empty route hostDomain")
+
+		builder.errorMsg = "route host domain cannot be empty string"
+
+		return builder
+	}
+
+	builder.Definition.Spec.Host = hostDomain
+
+	return builder
+}
+
+// WithPath sets the path the route matches on. Path based routing is not supported for passthrough TLS.
+func (builder *Builder) WithPath(path string) *Builder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Adding path %s to route %s in namespace %s",
+		path, builder.Definition.Name, builder.Definition.Namespace)
+
+	if !strings.HasPrefix(path, "/") {
+		glog.V(100).Infof("Received route path %s not starting with /", path)
+
+		builder.errorMsg = fmt.Sprintf("route path %s must start with /", path)
+
+		return builder
+	}
+
+	builder.Definition.Spec.Path = path
+
+	return builder
+}
+
+// WithEdgeTLS terminates TLS at the router using the given PEM encoded certificate, key and CA certificate. The
+// certificate and key must be set together and, when empty, the default certificate of the router is served.
+func (builder *Builder) WithEdgeTLS(certificate, key, caCertificate string) *Builder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Adding edge TLS termination to route %s in namespace %s",
+		builder.Definition.Name, builder.Definition.Namespace)
+
+	if (certificate == "") != (key == "") {
+		glog.V(100).Infof("Received route certificate and key not set together")
+
+		builder.errorMsg = "route TLS certificate and key must be set together"
+
+		return builder
+	}
+
+	builder.Definition.Spec.TLS = &routev1.TLSConfig{
+		Termination:   routev1.TLSTerminationEdge,
+		Certificate:   certificate,
+		Key:           key,
+		CACertificate: caCertificate,
+	}
+
+	return builder
+}
+
+// WithPassthroughTLS sends the encrypted traffic straight to the backend, which terminates TLS.
+func (builder *Builder) WithPassthroughTLS() *Builder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Adding passthrough TLS termination to route %s in namespace %s",
+		builder.Definition.Name, builder.Definition.Namespace)
+
+	builder.Definition.Spec.TLS = &routev1.TLSConfig{
+		Termination: routev1.TLSTerminationPassthrough,
+	}
+
+	return builder
+}
+
+// WithReencryptTLS terminates TLS at the router like WithEdgeTLS and re-encrypts the traffic to the backend. The
+// destination CA certificate validates the backend certificate and, when empty, the service serving CA is used.
+func (builder *Builder) WithReencryptTLS(certificate, key, caCertificate, destinationCACertificate string) *Builder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Adding reencrypt TLS termination to route %s in namespace %s",
+		builder.Definition.Name, builder.Definition.Namespace)
+
+	if (certificate == "") != (key == "") {
+		glog.V(100).Infof("Received route certificate and key not set together")
+
+		builder.errorMsg = "route TLS certificate and key must be set together"
+
+		return builder
+	}
+
+	builder.Definition.Spec.TLS = &routev1.TLSConfig{
+		Termination:              routev1.TLSTerminationReencrypt,
+		Certificate:              certificate,
+		Key:                      key,
+		CACertificate:            caCertificate,
+		DestinationCACertificate: destinationCACertificate,
+	}
+
+	return builder
+}
+
+// WithInsecureEdgeTerminationPolicy sets how insecure HTTP traffic is handled by a TLS route. Passthrough routes
+// only support the None and Redirect policies.
+func (builder *Builder) WithInsecureEdgeTerminationPolicy(
+	policy routev1.InsecureEdgeTerminationPolicyType) *Builder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Adding insecure edge termination policy %s to route %s in namespace %s",
+		policy, builder.Definition.Name, builder.Definition.Namespace)
+
+	if builder.Definition.Spec.TLS == nil {
+		glog.V(100).Infof("The route has no TLS termination")
+
+		builder.errorMsg = "route insecure edge termination policy requires TLS termination to be set"
+
+		return builder
+	}
+
+	switch policy {
+	case routev1.InsecureEdgeTerminationPolicyNone, routev1.InsecureEdgeTerminationPolicyRedirect:
+	case routev1.InsecureEdgeTerminationPolicyAllow:
+		if builder.Definition.Spec.TLS.Termination == routev1.TLSTerminationPassthrough {
+			glog.V(100).Infof("The route insecure edge termination policy Allow is unsupported for passthrough")
+
+			builder.errorMsg = "route insecure edge termination policy Allow is not supported for passthrough TLS"
+
+			return builder
+		}
+	default:
+		glog.V(100).Infof("Received unsupported route insecure edge termination policy %s", policy)
+
+		builder.errorMsg = fmt.Sprintf("route insecure edge termination policy %s is invalid, "+
+			"must be one of %s, %s or %s", policy, routev1.InsecureEdgeTerminationPolicyNone,
+			routev1.InsecureEdgeTerminationPolicyAllow, routev1.InsecureEdgeTerminationPolicyRedirect)
+
+		return builder
+	}
+
+	builder.Definition.Spec.TLS.InsecureEdgeTerminationPolicy = policy
+
+	return builder
+}
+
+// WithServiceWeight sets the weight of the target service of the route relative to its alternate backends. A
+// weight of 0 stops traffic to the service.
+func (builder *Builder) WithServiceWeight(weight int32) *Builder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Adding weight %d to target service of route %s in namespace %s",
+		weight, builder.Definition.Name, builder.Definition.Namespace)
+
+	if weight < 0 || weight > maxBackendWeight {
+		glog.V(100).Infof("Received route weight %d out of range", weight)
+
+		builder.errorMsg = fmt.Sprintf("route weight %d must be between 0 and %d", weight, maxBackendWeight)
+
+		return builder
+	}
+
+	builder.Definition.Spec.To.Weight = &weight
+
+	return builder
+}
+
+// WithAlternateBackend adds a service receiving part of the route traffic according to its weight. Up to 3
+// alternate backends are supported.
+func (builder *Builder) WithAlternateBackend(serviceName string, weight int32) *Builder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Adding alternate backend service %s with weight %d to route %s in namespace %s",
+		serviceName, weight, builder.Definition.Name, builder.Definition.Namespace)
+
+	if serviceName == "" {
+		glog.V(100).Infof("Received empty route alternate backend serviceName")
+
+		builder.errorMsg = "route alternate backend service name cannot be empty string"
+
+		return builder
+	}
+
+	if weight < 0 || weight > maxBackendWeight {
+		glog.V(100).Infof("Received route weight %d out of range", weight)
+
+		builder.errorMsg = fmt.Sprintf("route weight %d must be between 0 and %d", weight, maxBackendWeight)
+
+		return builder
+	}
+
+	if len(builder.Definition.Spec.AlternateBackends) >= maxAlternateBackends {
+		glog.V(100).Infof("The route already has %d alternate backends", maxAlternateBackends)
+
+		builder.errorMsg = fmt.Sprintf("route cannot have more than %d alternate backends", maxAlternateBackends)
+
+		return builder
+	}
+
+	builder.Definition.Spec.AlternateBackends = append(builder.Definition.Spec.AlternateBackends,
+		routev1.RouteTargetReference{
+			Kind:   "Service",
+			Name:   serviceName,
+			Weight: &weight,
+		})
+
+	return builder
+}
+
 // Exists checks whether the given route exists.
 func (builder *Builder) Exists() bool {
 	if valid, _ := builder.validate(); !valid {
@@ -273,6 +514,62 @@ func (builder *Builder) Delete() (*Builder, error) {
 	return builder, nil
 }
 
+// WaitUntilAdmitted waits for the duration of the defined timeout until the route is admitted by the given router
+// and returns the canonical hostname of the router, which the route host should resolve to. It fails immediately
+// if the router rejects the route, for example because its host is already claimed.
+func (builder *Builder) WaitUntilAdmitted(routerName string, timeout time.Duration) (string, error) {
+	if valid, err := builder.validate(); !valid {
+		return "", err
+	}
+
+	glog.V(100).Infof("Waiting until route %s in namespace %s is admitted by router %s",
+		builder.Definition.Name, builder.Definition.Namespace, routerName)
+
+	if routerName == "" {
+		glog.V(100).Infof("Received empty routerName")
+
+		return "", fmt.Errorf("route router name cannot be empty string")
+	}
+
+	var canonicalHostname string
+
+	err := wait.PollUntilContextTimeout(
+		context.TODO(), 3*time.Second, timeout, true, func(ctx context.Context) (bool, error) {
+			if !builder.Exists() {
+				glog.V(100).Infof("The route %s in namespace %s does not exist yet",
+					builder.Definition.Name, builder.Definition.Namespace)
+
+				return false, nil
+			}
+
+			for _, ingress := range builder.Object.Status.Ingress {
+				if ingress.RouterName != routerName {
+					continue
+				}
+
+				for _, condition := range ingress.Conditions {
+					if condition.Type != routev1.RouteAdmitted {
+						continue
+					}
+
+					if condition.Status == corev1.ConditionFalse {
+						return false, fmt.Errorf("route %s in namespace %s was rejected by router %s: %s: %s",
+							builder.Definition.Name, builder.Definition.Namespace, routerName,
+							condition.Reason, condition.Message)
+					}
+
+					canonicalHostname = ingress.RouterCanonicalHostname
+
+					return condition.Status == corev1.ConditionTrue && canonicalHostname != "", nil
+				}
+			}
+
+			return false, nil
+		})
+
+	return canonicalHostname, err
+}
+
 // validate will check that the builder and builder definition are properly initialized before
 // accessing any member fields.
 func (builder *Builder) validate() (bool, error) {
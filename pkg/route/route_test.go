@@ -3,11 +3,14 @@ package route
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	routev1 "github.com/openshift/api/route/v1"
 	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
 
 	"github.com/openshift-kni/eco-goinfra/pkg/clients"
 )
@@ -207,3 +210,182 @@ func TestWithWildCardPolicy(t *testing.T) {
 		assert.Equal(t, test.expectedErrMsg, testBuilder.errorMsg)
 	}
 }
+
+func TestWithHostDomainAndPath(t *testing.T) {
+	testBuilder := buildValidTestBuilder().WithHostDomain("app.apps.example.com").WithPath("/api")
+	assert.Equal(t, "", testBuilder.errorMsg)
+	assert.Equal(t, "app.apps.example.com", testBuilder.Definition.Spec.Host)
+	assert.Equal(t, "/api", testBuilder.Definition.Spec.Path)
+
+	testBuilder = buildValidTestBuilder().WithHostDomain("")
+	assert.Equal(t, "route host domain cannot be empty string", testBuilder.errorMsg)
+
+	testBuilder = buildValidTestBuilder().WithPath("api")
+	assert.Equal(t, "route path api must start with /", testBuilder.errorMsg)
+}
+
+func TestWithTLS(t *testing.T) {
+	testBuilder := buildValidTestBuilder().
+		WithEdgeTLS("cert", "key", "ca").
+		WithInsecureEdgeTerminationPolicy(routev1.InsecureEdgeTerminationPolicyRedirect)
+	assert.Equal(t, "", testBuilder.errorMsg)
+	assert.Equal(t, &routev1.TLSConfig{
+		Termination:                   routev1.TLSTerminationEdge,
+		Certificate:                   "cert",
+		Key:                           "key",
+		CACertificate:                 "ca",
+		InsecureEdgeTerminationPolicy: routev1.InsecureEdgeTerminationPolicyRedirect,
+	}, testBuilder.Definition.Spec.TLS)
+
+	testBuilder = buildValidTestBuilder().WithReencryptTLS("", "", "", "destination-ca")
+	assert.Equal(t, "", testBuilder.errorMsg)
+	assert.Equal(t, &routev1.TLSConfig{
+		Termination:              routev1.TLSTerminationReencrypt,
+		DestinationCACertificate: "destination-ca",
+	}, testBuilder.Definition.Spec.TLS)
+
+	testBuilder = buildValidTestBuilder().WithPassthroughTLS()
+	assert.Equal(t, "", testBuilder.errorMsg)
+	assert.Equal(t, &routev1.TLSConfig{Termination: routev1.TLSTerminationPassthrough}, testBuilder.Definition.Spec.TLS)
+
+	testCases := []struct {
+		mutate         func(builder *Builder) *Builder
+		expectedErrMsg string
+	}{
+		{
+			mutate:         func(builder *Builder) *Builder { return builder.WithEdgeTLS("cert", "", "") },
+			expectedErrMsg: "route TLS certificate and key must be set together",
+		},
+		{
+			mutate:         func(builder *Builder) *Builder { return builder.WithReencryptTLS("", "key", "", "") },
+			expectedErrMsg: "route TLS certificate and key must be set together",
+		},
+		{
+			mutate: func(builder *Builder) *Builder {
+				return builder.WithInsecureEdgeTerminationPolicy(routev1.InsecureEdgeTerminationPolicyAllow)
+			},
+			expectedErrMsg: "route insecure edge termination policy requires TLS termination to be set",
+		},
+		{
+			mutate: func(builder *Builder) *Builder {
+				return builder.WithPassthroughTLS().
+					WithInsecureEdgeTerminationPolicy(routev1.InsecureEdgeTerminationPolicyAllow)
+			},
+			expectedErrMsg: "route insecure edge termination policy Allow is not supported for passthrough TLS",
+		},
+		{
+			mutate: func(builder *Builder) *Builder {
+				return builder.WithEdgeTLS("", "", "").WithInsecureEdgeTerminationPolicy("Deny")
+			},
+			expectedErrMsg: "route insecure edge termination policy Deny is invalid, must be one of None, Allow or Redirect",
+		},
+	}
+
+	for _, test := range testCases {
+		testBuilder := test.mutate(buildValidTestBuilder())
+		assert.Equal(t, test.expectedErrMsg, testBuilder.errorMsg)
+	}
+}
+
+func TestWithAlternateBackends(t *testing.T) {
+	testBuilder := buildValidTestBuilder().
+		WithServiceWeight(80).
+		WithAlternateBackend("route-test-service-canary", 20)
+	assert.Equal(t, "", testBuilder.errorMsg)
+	assert.Equal(t, ptr.To[int32](80), testBuilder.Definition.Spec.To.Weight)
+	assert.Equal(t, []routev1.RouteTargetReference{
+		{Kind: "Service", Name: "route-test-service-canary", Weight: ptr.To[int32](20)},
+	}, testBuilder.Definition.Spec.AlternateBackends)
+
+	testCases := []struct {
+		mutate         func(builder *Builder) *Builder
+		expectedErrMsg string
+	}{
+		{
+			mutate:         func(builder *Builder) *Builder { return builder.WithServiceWeight(257) },
+			expectedErrMsg: "route weight 257 must be between 0 and 256",
+		},
+		{
+			mutate:         func(builder *Builder) *Builder { return builder.WithAlternateBackend("", 10) },
+			expectedErrMsg: "route alternate backend service name cannot be empty string",
+		},
+		{
+			mutate:         func(builder *Builder) *Builder { return builder.WithAlternateBackend("canary", -1) },
+			expectedErrMsg: "route weight -1 must be between 0 and 256",
+		},
+		{
+			mutate: func(builder *Builder) *Builder {
+				return builder.WithAlternateBackend("canary-1", 10).
+					WithAlternateBackend("canary-2", 10).
+					WithAlternateBackend("canary-3", 10).
+					WithAlternateBackend("canary-4", 10)
+			},
+			expectedErrMsg: "route cannot have more than 3 alternate backends",
+		},
+	}
+
+	for _, test := range testCases {
+		testBuilder := test.mutate(buildValidTestBuilder())
+		assert.Equal(t, test.expectedErrMsg, testBuilder.errorMsg)
+	}
+}
+
+func TestWaitUntilAdmitted(t *testing.T) {
+	testCases := []struct {
+		routerName        string
+		status            corev1.ConditionStatus
+		expectedHostname  string
+		expectedErr       error
+		expectedErrNotNil bool
+	}{
+		{
+			routerName:       DefaultRouterName,
+			status:           corev1.ConditionTrue,
+			expectedHostname: "router-default.apps.example.com",
+		},
+		{
+			routerName: DefaultRouterName,
+			status:     corev1.ConditionFalse,
+			expectedErr: fmt.Errorf("route route-test-name in namespace route-test-namespace was rejected by " +
+				"router default: HostAlreadyClaimed: host already claimed"),
+		},
+		{
+			routerName:        "sharded",
+			status:            corev1.ConditionTrue,
+			expectedErrNotNil: true,
+		},
+		{
+			routerName:  "",
+			status:      corev1.ConditionTrue,
+			expectedErr: fmt.Errorf("route router name cannot be empty string"),
+		},
+	}
+
+	for _, test := range testCases {
+		testSettings := clients.GetTestClients(clients.TestClientParams{K8sMockObjects: []runtime.Object{
+			&routev1.Route{
+				ObjectMeta: metav1.ObjectMeta{Name: "route-test-name", Namespace: "route-test-namespace"},
+				Status: routev1.RouteStatus{Ingress: []routev1.RouteIngress{{
+					RouterName:              DefaultRouterName,
+					RouterCanonicalHostname: "router-default.apps.example.com",
+					Conditions: []routev1.RouteIngressCondition{{
+						Type:    routev1.RouteAdmitted,
+						Status:  test.status,
+						Reason:  "HostAlreadyClaimed",
+						Message: "host already claimed",
+					}},
+				}}},
+			},
+		}})
+
+		testBuilder := NewBuilder(testSettings, "route-test-name", "route-test-namespace", "route-test-service")
+
+		hostname, err := testBuilder.WaitUntilAdmitted(test.routerName, time.Second)
+		if test.expectedErrNotNil {
+			assert.NotNil(t, err)
+		} else {
+			assert.Equal(t, test.expectedErr, err)
+			assert.Equal(t, test.expectedHostname, hostname)
+		}
+	}
+}
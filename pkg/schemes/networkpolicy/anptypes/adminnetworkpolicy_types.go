@@ -0,0 +1,109 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package anptypes
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// AdminNetworkPolicyRuleAction string describes the AdminNetworkPolicy action type.
+type AdminNetworkPolicyRuleAction string
+
+const (
+	// AdminNetworkPolicyRuleActionAllow indicates that matching traffic will be allowed regardless of other rules.
+	AdminNetworkPolicyRuleActionAllow AdminNetworkPolicyRuleAction = "Allow"
+	// AdminNetworkPolicyRuleActionDeny indicates that matching traffic will be denied regardless of other rules.
+	AdminNetworkPolicyRuleActionDeny AdminNetworkPolicyRuleAction = "Deny"
+	// AdminNetworkPolicyRuleActionPass indicates that matching traffic will skip the remaining AdminNetworkPolicy
+	// rules and be evaluated by NetworkPolicies and the BaselineAdminNetworkPolicy.
+	AdminNetworkPolicyRuleActionPass AdminNetworkPolicyRuleAction = "Pass"
+)
+
+// AdminNetworkPolicySpec defines the desired state of AdminNetworkPolicy.
+type AdminNetworkPolicySpec struct {
+	// Priority is a value from 0 to 1000. Rules with lower priority values have higher precedence.
+	Priority int32 `json:"priority"`
+	// Subject defines the pods to which this AdminNetworkPolicy applies.
+	Subject AdminNetworkPolicySubject `json:"subject"`
+	// Ingress is the list of Ingress rules to be applied to the selected pods, evaluated in order.
+	// +optional
+	Ingress []AdminNetworkPolicyIngressRule `json:"ingress,omitempty"`
+	// Egress is the list of Egress rules to be applied to the selected pods, evaluated in order.
+	// +optional
+	Egress []AdminNetworkPolicyEgressRule `json:"egress,omitempty"`
+}
+
+// AdminNetworkPolicyIngressRule describes an action to take on a particular set of traffic destined for pods
+// selected by an AdminNetworkPolicy's Subject field.
+type AdminNetworkPolicyIngressRule struct {
+	// Name is an identifier for this rule, that may be no more than 100 characters in length.
+	// +optional
+	Name string `json:"name,omitempty"`
+	// Action specifies the effect this rule will have on matching traffic.
+	Action AdminNetworkPolicyRuleAction `json:"action"`
+	// From is the list of sources whose traffic this rule applies to.
+	From []AdminNetworkPolicyIngressPeer `json:"from"`
+	// Ports allows for matching traffic based on port and protocols.
+	// +optional
+	Ports *[]AdminNetworkPolicyPort `json:"ports,omitempty"`
+}
+
+// AdminNetworkPolicyEgressRule describes an action to take on a particular set of traffic originating from pods
+// selected by a AdminNetworkPolicy's Subject field.
+type AdminNetworkPolicyEgressRule struct {
+	// Name is an identifier for this rule, that may be no more than 100 characters in length.
+	// +optional
+	Name string `json:"name,omitempty"`
+	// Action specifies the effect this rule will have on matching traffic.
+	Action AdminNetworkPolicyRuleAction `json:"action"`
+	// To is the List of destinations whose traffic this rule applies to.
+	To []AdminNetworkPolicyEgressPeer `json:"to"`
+	// Ports allows for matching traffic based on port and protocols.
+	// +optional
+	Ports *[]AdminNetworkPolicyPort `json:"ports,omitempty"`
+}
+
+// AdminNetworkPolicyStatus defines the observed state of AdminNetworkPolicy.
+type AdminNetworkPolicyStatus struct {
+	Conditions []metav1.Condition `json:"conditions"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:resource:shortName=anp,scope=Cluster
+//+kubebuilder:subresource:status
+
+// AdminNetworkPolicy is a cluster level resource that is part of the AdminNetworkPolicy API.
+type AdminNetworkPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   AdminNetworkPolicySpec   `json:"spec"`
+	Status AdminNetworkPolicyStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// AdminNetworkPolicyList contains a list of AdminNetworkPolicy.
+type AdminNetworkPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []AdminNetworkPolicy `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&AdminNetworkPolicy{}, &AdminNetworkPolicyList{})
+}
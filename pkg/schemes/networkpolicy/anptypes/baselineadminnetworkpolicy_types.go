@@ -0,0 +1,105 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package anptypes
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// BaselineAdminNetworkPolicyRuleAction string describes the BaselineAdminNetworkPolicy action type.
+type BaselineAdminNetworkPolicyRuleAction string
+
+const (
+	// BaselineAdminNetworkPolicyRuleActionAllow indicates that matching traffic will be allowed.
+	BaselineAdminNetworkPolicyRuleActionAllow BaselineAdminNetworkPolicyRuleAction = "Allow"
+	// BaselineAdminNetworkPolicyRuleActionDeny indicates that matching traffic will be denied.
+	BaselineAdminNetworkPolicyRuleActionDeny BaselineAdminNetworkPolicyRuleAction = "Deny"
+)
+
+// BaselineAdminNetworkPolicySpec defines the desired state of BaselineAdminNetworkPolicy.
+type BaselineAdminNetworkPolicySpec struct {
+	// Subject defines the pods to which this BaselineAdminNetworkPolicy applies.
+	Subject AdminNetworkPolicySubject `json:"subject"`
+	// Ingress is the list of Ingress rules to be applied to the selected pods, evaluated in order.
+	// +optional
+	Ingress []BaselineAdminNetworkPolicyIngressRule `json:"ingress,omitempty"`
+	// Egress is the list of Egress rules to be applied to the selected pods, evaluated in order.
+	// +optional
+	Egress []BaselineAdminNetworkPolicyEgressRule `json:"egress,omitempty"`
+}
+
+// BaselineAdminNetworkPolicyIngressRule describes an action to take on a particular set of traffic destined for
+// pods selected by a BaselineAdminNetworkPolicy's Subject field.
+type BaselineAdminNetworkPolicyIngressRule struct {
+	// Name is an identifier for this rule, that may be no more than 100 characters in length.
+	// +optional
+	Name string `json:"name,omitempty"`
+	// Action specifies the effect this rule will have on matching traffic.
+	Action BaselineAdminNetworkPolicyRuleAction `json:"action"`
+	// From is the list of sources whose traffic this rule applies to.
+	From []AdminNetworkPolicyIngressPeer `json:"from"`
+	// Ports allows for matching traffic based on port and protocols.
+	// +optional
+	Ports *[]AdminNetworkPolicyPort `json:"ports,omitempty"`
+}
+
+// BaselineAdminNetworkPolicyEgressRule describes an action to take on a particular set of traffic originating from
+// pods selected by a BaselineAdminNetworkPolicy's Subject field.
+type BaselineAdminNetworkPolicyEgressRule struct {
+	// Name is an identifier for this rule, that may be no more than 100 characters in length.
+	// +optional
+	Name string `json:"name,omitempty"`
+	// Action specifies the effect this rule will have on matching traffic.
+	Action BaselineAdminNetworkPolicyRuleAction `json:"action"`
+	// To is the list of destinations whose traffic this rule applies to.
+	To []AdminNetworkPolicyEgressPeer `json:"to"`
+	// Ports allows for matching traffic based on port and protocols.
+	// +optional
+	Ports *[]AdminNetworkPolicyPort `json:"ports,omitempty"`
+}
+
+// BaselineAdminNetworkPolicyStatus defines the observed state of BaselineAdminNetworkPolicy.
+type BaselineAdminNetworkPolicyStatus struct {
+	Conditions []metav1.Condition `json:"conditions"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:resource:shortName=banp,scope=Cluster
+//+kubebuilder:subresource:status
+
+// BaselineAdminNetworkPolicy is a cluster level resource that is part of the AdminNetworkPolicy API. Only a single
+// BaselineAdminNetworkPolicy named default may exist in the cluster.
+type BaselineAdminNetworkPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   BaselineAdminNetworkPolicySpec   `json:"spec"`
+	Status BaselineAdminNetworkPolicyStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// BaselineAdminNetworkPolicyList contains a list of BaselineAdminNetworkPolicy.
+type BaselineAdminNetworkPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []BaselineAdminNetworkPolicy `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&BaselineAdminNetworkPolicy{}, &BaselineAdminNetworkPolicyList{})
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package anptypes
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// AdminNetworkPolicySubject defines what objects the policy selects. Exactly one field must be set.
+type AdminNetworkPolicySubject struct {
+	// Namespaces is used to select pods via namespace selectors.
+	// +optional
+	Namespaces *metav1.LabelSelector `json:"namespaces,omitempty"`
+	// Pods is used to select pods via namespace AND pod selectors.
+	// +optional
+	Pods *NamespacedPod `json:"pods,omitempty"`
+}
+
+// NamespacedPod allows the user to select a given set of pod(s) in selected namespace(s).
+type NamespacedPod struct {
+	// NamespaceSelector follows standard label selector semantics. An empty selector selects all namespaces.
+	NamespaceSelector metav1.LabelSelector `json:"namespaceSelector"`
+	// PodSelector is used to explicitly select pods within a namespace. An empty selector selects all pods.
+	PodSelector metav1.LabelSelector `json:"podSelector"`
+}
+
+// AdminNetworkPolicyPort describes how to select network ports on pod(s). Exactly one field must be set.
+type AdminNetworkPolicyPort struct {
+	// PortNumber selects a port on a pod(s) based on number.
+	// +optional
+	PortNumber *Port `json:"portNumber,omitempty"`
+	// NamedPort selects a port on a pod(s) based on name.
+	// +optional
+	NamedPort *string `json:"namedPort,omitempty"`
+	// PortRange selects a port range on a pod(s) based on provided start and end values.
+	// +optional
+	PortRange *PortRange `json:"portRange,omitempty"`
+}
+
+// Port matches a single port and protocol.
+type Port struct {
+	// Protocol is the network protocol (TCP, UDP, or SCTP) which traffic must match.
+	Protocol corev1.Protocol `json:"protocol"`
+	// Number defines a network port value.
+	Port int32 `json:"port"`
+}
+
+// PortRange defines an inclusive range of ports from the assigned Start value to End value.
+type PortRange struct {
+	// Protocol is the network protocol (TCP, UDP, or SCTP) which traffic must match.
+	Protocol corev1.Protocol `json:"protocol,omitempty"`
+	// Start defines a network port that is the start of a port range, the Start value must be less than End.
+	Start int32 `json:"start"`
+	// End specifies the last port in the range. It must be greater than start.
+	End int32 `json:"end"`
+}
+
+// AdminNetworkPolicyIngressPeer defines an in-cluster peer to allow traffic from. Exactly one field must be set.
+type AdminNetworkPolicyIngressPeer struct {
+	// Namespaces defines a way to select all pods within a set of Namespaces.
+	// +optional
+	Namespaces *metav1.LabelSelector `json:"namespaces,omitempty"`
+	// Pods defines a way to select a set of pods in a set of namespaces.
+	// +optional
+	Pods *NamespacedPod `json:"pods,omitempty"`
+}
+
+// AdminNetworkPolicyEgressPeer defines a peer to allow traffic to. Exactly one field must be set.
+type AdminNetworkPolicyEgressPeer struct {
+	// Namespaces defines a way to select all pods within a set of Namespaces.
+	// +optional
+	Namespaces *metav1.LabelSelector `json:"namespaces,omitempty"`
+	// Pods defines a way to select a set of pods in a set of namespaces.
+	// +optional
+	Pods *NamespacedPod `json:"pods,omitempty"`
+	// Nodes defines a way to select a set of nodes in the cluster.
+	// +optional
+	Nodes *metav1.LabelSelector `json:"nodes,omitempty"`
+	// Networks defines a way to select peers via CIDR blocks.
+	// +optional
+	Networks []CIDR `json:"networks,omitempty"`
+}
+
+// CIDR is an IP address range in CIDR notation (for example, "10.0.0.0/8" or "fd00::/8").
+type CIDR string
@@ -0,0 +1,123 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ovntypes
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// VLANMode describes how the VLAN of a localnet network is configured.
+type VLANMode string
+
+const (
+	// VLANModeAccess tags the traffic of the network with a single VLAN ID.
+	VLANModeAccess VLANMode = "Access"
+)
+
+// AccessVLANConfig describes the VLAN ID the network traffic is tagged with.
+type AccessVLANConfig struct {
+	// ID is the VLAN ID (1-4094) to be used for the network.
+	ID int32 `json:"id"`
+}
+
+// VLANConfig describes the VLAN configuration of a localnet network.
+type VLANConfig struct {
+	// Mode describe the network VLAN mode.
+	Mode VLANMode `json:"mode"`
+	// Access is the access VLAN configuration.
+	// +optional
+	Access *AccessVLANConfig `json:"access,omitempty"`
+}
+
+// LocalnetConfig is the configuration of a Localnet network.
+type LocalnetConfig struct {
+	// Role describes the network role in the pod. Only Secondary is supported.
+	Role NetworkRole `json:"role"`
+	// PhysicalNetworkName points to the OVS bridge-mapping's network-name configured in the nodes.
+	PhysicalNetworkName string `json:"physicalNetworkName"`
+	// Subnets are used for the pod network across the cluster. Required unless IPAM is disabled.
+	// +optional
+	Subnets DualStackCIDRs `json:"subnets,omitempty"`
+	// ExcludeSubnets is a list of CIDRs to be removed from the specified Subnets.
+	// +optional
+	ExcludeSubnets []CIDR `json:"excludeSubnets,omitempty"`
+	// IPAM configurations for the network.
+	// +optional
+	IPAM *IPAMConfig `json:"ipam,omitempty"`
+	// MTU is the maximum transmission unit for a network.
+	// +optional
+	MTU int32 `json:"mtu,omitempty"`
+	// VLAN configuration for the network.
+	// +optional
+	VLAN *VLANConfig `json:"vlan,omitempty"`
+}
+
+// NetworkSpec defines the network of a ClusterUserDefinedNetwork.
+type NetworkSpec struct {
+	// Topology describes network configuration. Allowed values are Layer2, Layer3 and Localnet.
+	Topology NetworkTopology `json:"topology"`
+	// Layer3 is the Layer3 topology configuration.
+	// +optional
+	Layer3 *Layer3Config `json:"layer3,omitempty"`
+	// Layer2 is the Layer2 topology configuration.
+	// +optional
+	Layer2 *Layer2Config `json:"layer2,omitempty"`
+	// Localnet is the Localnet topology configuration.
+	// +optional
+	Localnet *LocalnetConfig `json:"localnet,omitempty"`
+}
+
+// ClusterUserDefinedNetworkSpec defines the desired state of ClusterUserDefinedNetwork.
+type ClusterUserDefinedNetworkSpec struct {
+	// NamespaceSelector Label selector for which namespace network should be available for.
+	NamespaceSelector metav1.LabelSelector `json:"namespaceSelector"`
+	// Network is the user-defined-network spec.
+	Network NetworkSpec `json:"network"`
+}
+
+// ClusterUserDefinedNetworkStatus contains the observed status of the ClusterUserDefinedNetwork.
+type ClusterUserDefinedNetworkStatus struct {
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:resource:shortName=cudn,scope=Cluster
+//+kubebuilder:subresource:status
+
+// ClusterUserDefinedNetwork describes a network request for a shared network across namespaces. Its network spec is
+// immutable.
+type ClusterUserDefinedNetwork struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ClusterUserDefinedNetworkSpec   `json:"spec"`
+	Status ClusterUserDefinedNetworkStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// ClusterUserDefinedNetworkList contains a list of ClusterUserDefinedNetwork.
+type ClusterUserDefinedNetworkList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ClusterUserDefinedNetwork `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ClusterUserDefinedNetwork{}, &ClusterUserDefinedNetworkList{})
+}
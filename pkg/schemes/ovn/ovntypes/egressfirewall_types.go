@@ -0,0 +1,109 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ovntypes
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// EgressFirewallRuleType indicates whether an EgressFirewall rule allows or denies traffic.
+type EgressFirewallRuleType string
+
+const (
+	// EgressFirewallRuleAllow allows the traffic matching the rule.
+	EgressFirewallRuleAllow EgressFirewallRuleType = "Allow"
+	// EgressFirewallRuleDeny denies the traffic matching the rule.
+	EgressFirewallRuleDeny EgressFirewallRuleType = "Deny"
+)
+
+// EgressFirewallSpec is a desired state description of EgressFirewall.
+type EgressFirewallSpec struct {
+	// A collection of egress firewall rule objects, evaluated in order.
+	Egress []EgressFirewallRule `json:"egress"`
+}
+
+// EgressFirewallRule is a single egressfirewall rule object.
+type EgressFirewallRule struct {
+	// Type marks this as an "Allow" or "Deny" rule.
+	Type EgressFirewallRuleType `json:"type"`
+	// Ports specify what ports and protocols the rule applies to.
+	// +optional
+	Ports []EgressFirewallPort `json:"ports,omitempty"`
+	// To is the target that traffic is allowed/denied to.
+	To EgressFirewallDestination `json:"to"`
+}
+
+// EgressFirewallPort specifies the port to allow or deny traffic to.
+type EgressFirewallPort struct {
+	// Protocol (tcp, udp, sctp) that the traffic must match.
+	Protocol string `json:"protocol"`
+	// Port that the traffic must match.
+	Port int32 `json:"port"`
+}
+
+// EgressFirewallDestination is the endpoint that traffic is either allowed or denied to. Exactly one of
+// cidrSelector, dnsName or nodeSelector must be set.
+type EgressFirewallDestination struct {
+	// CIDRSelector is the CIDR range to allow/deny traffic to.
+	// +optional
+	CIDRSelector string `json:"cidrSelector,omitempty"`
+	// DNSName is the domain name to allow/deny traffic to.
+	// +optional
+	DNSName string `json:"dnsName,omitempty"`
+	// NodeSelector will allow/deny traffic to the Kubernetes node IP of selected nodes.
+	// +optional
+	NodeSelector *metav1.LabelSelector `json:"nodeSelector,omitempty"`
+}
+
+// EgressFirewallStatus defines the observed state of EgressFirewall.
+type EgressFirewallStatus struct {
+	// +optional
+	Status string `json:"status,omitempty"`
+	// +optional
+	Messages []string `json:"messages,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:resource:shortName=ef
+//+kubebuilder:subresource:status
+
+// EgressFirewall describes the current egress firewall for a Namespace. Traffic from a pod to an IP address outside
+// the cluster will be checked against each EgressFirewallRule in the pod's namespace's EgressFirewall, in order. Only
+// a single EgressFirewall named default may exist in a namespace.
+type EgressFirewall struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Specification of the desired behavior of EgressFirewall.
+	Spec EgressFirewallSpec `json:"spec"`
+	// Observed status of EgressFirewall.
+	// +optional
+	Status EgressFirewallStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// EgressFirewallList contains a list of EgressFirewall.
+type EgressFirewallList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []EgressFirewall `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&EgressFirewall{}, &EgressFirewallList{})
+}
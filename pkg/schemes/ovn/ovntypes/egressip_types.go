@@ -0,0 +1,80 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ovntypes
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// EgressIPSpec is a desired state description of EgressIP.
+type EgressIPSpec struct {
+	// EgressIPs is the list of egress IP addresses requested. Can be IPv4 and/or IPv6.
+	// This field is mandatory.
+	EgressIPs []string `json:"egressIPs"`
+	// NamespaceSelector applies the egress IP only to the namespace(s) whose label
+	// matches this definition. This field is mandatory.
+	NamespaceSelector metav1.LabelSelector `json:"namespaceSelector"`
+	// PodSelector applies the egress IP only to the pods whose label
+	// matches this definition. This field is optional, and in case it is not set:
+	// results in the egress IP being applied to all pods in the namespace(s).
+	// +optional
+	PodSelector metav1.LabelSelector `json:"podSelector,omitempty"`
+}
+
+// EgressIPStatus defines the observed state of EgressIP.
+type EgressIPStatus struct {
+	// The list of assigned egress IPs and their corresponding node assignment.
+	Items []EgressIPStatusItem `json:"items"`
+}
+
+// EgressIPStatusItem describes the node to which an egress IP is assigned.
+type EgressIPStatusItem struct {
+	// Assigned node name
+	Node string `json:"node"`
+	// Assigned egress IP
+	EgressIP string `json:"egressIP"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:resource:shortName=eip,scope=Cluster
+//+kubebuilder:subresource:status
+
+// EgressIP is a CRD allowing the user to define a fixed source IP for all egress traffic originating from any pods
+// which match the EgressIP resource according to its spec definition.
+type EgressIP struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Specification of the desired behavior of EgressIP.
+	Spec EgressIPSpec `json:"spec"`
+	// Observed status of EgressIP. Read-only.
+	// +optional
+	Status EgressIPStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// EgressIPList contains a list of EgressIP.
+type EgressIPList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []EgressIP `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&EgressIP{}, &EgressIPList{})
+}
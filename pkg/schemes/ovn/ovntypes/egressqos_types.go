@@ -0,0 +1,80 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ovntypes
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// EgressQoSSpec defines the desired state of EgressQoS.
+type EgressQoSSpec struct {
+	// A collection of Egress QoS rule objects. A rule matching a destination and pods takes precedence over the
+	// rules defined after it.
+	Egress []EgressQoSRule `json:"egress"`
+}
+
+// EgressQoSRule marks the egress traffic of the selected pods matching the destination with a DSCP value.
+type EgressQoSRule struct {
+	// DSCP marking value for matching pods' traffic.
+	DSCP int `json:"dscp"`
+	// DstCIDR specifies the destination's CIDR. Only traffic heading to this CIDR will be marked with the DSCP value.
+	// This field is optional, and in case it is not set the rule is applied to all egress traffic regardless of the
+	// destination.
+	// +optional
+	DstCIDR *string `json:"dstCIDR,omitempty"`
+	// PodSelector applies the QoS rule only to the pods in the namespace whose label matches this definition.
+	// This field is optional, and in case it is not set results in the rule being applied to all pods in the
+	// namespace.
+	// +optional
+	PodSelector metav1.LabelSelector `json:"podSelector,omitempty"`
+}
+
+// EgressQoSStatus defines the observed state of EgressQoS.
+type EgressQoSStatus struct {
+	// A concise indication of whether the EgressQoS resource is applied with success.
+	// +optional
+	Status string `json:"status,omitempty"`
+	// An array of condition objects indicating details about status of EgressQoS object.
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+
+// EgressQoS is a CRD that allows the user to define a DSCP value for pods egress traffic on its namespace to
+// specified CIDRs. Only a single EgressQoS named default may exist in a namespace.
+type EgressQoS struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   EgressQoSSpec   `json:"spec,omitempty"`
+	Status EgressQoSStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// EgressQoSList contains a list of EgressQoS.
+type EgressQoSList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []EgressQoS `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&EgressQoS{}, &EgressQoSList{})
+}
@@ -0,0 +1,78 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ovntypes
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// SourceIPMode indicates which source IP is used for the egress traffic of a service.
+type SourceIPMode string
+
+const (
+	// SourceIPLoadBalancer sets the source IP of the egress traffic to the ingress IP of the LoadBalancer service.
+	SourceIPLoadBalancer SourceIPMode = "LoadBalancerIP"
+	// SourceIPNetwork sets the source IP of the egress traffic to the IP of the node interface the traffic leaves on.
+	SourceIPNetwork SourceIPMode = "Network"
+)
+
+// EgressServiceSpec defines the desired state of EgressService.
+type EgressServiceSpec struct {
+	// Determines the source IP of egress traffic originating from the pods backing the LoadBalancer Service.
+	// +optional
+	SourceIPBy SourceIPMode `json:"sourceIPBy,omitempty"`
+	// Allows limiting the nodes that can be selected to handle the service's traffic when sourceIPBy=LoadBalancerIP.
+	// +optional
+	NodeSelector metav1.LabelSelector `json:"nodeSelector,omitempty"`
+	// The network which this service should send egress and corresponding ingress replies to.
+	// +optional
+	Network string `json:"network,omitempty"`
+}
+
+// EgressServiceStatus defines the observed state of EgressService.
+type EgressServiceStatus struct {
+	// The name of the node selected to handle the service's traffic.
+	// In case sourceIPBy=Network the field will be set to "ALL".
+	Host string `json:"host"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+
+// EgressService is a CRD that allows the user to request that the source IP of egress packets originating from all
+// of the pods that are endpoints of the corresponding LoadBalancer Service would be its ingress IP. The
+// EgressService has the same name and namespace as the Service it applies to.
+type EgressService struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   EgressServiceSpec   `json:"spec,omitempty"`
+	Status EgressServiceStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// EgressServiceList contains a list of EgressService.
+type EgressServiceList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []EgressService `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&EgressService{}, &EgressServiceList{})
+}
@@ -0,0 +1,162 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ovntypes
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// NetworkTopology describes the topology of a user-defined network.
+type NetworkTopology string
+
+const (
+	// NetworkTopologyLayer2 is a network where all pods are connected to a single switch spanning all nodes.
+	NetworkTopologyLayer2 NetworkTopology = "Layer2"
+	// NetworkTopologyLayer3 is a network where each node has its own subnet connected through a router.
+	NetworkTopologyLayer3 NetworkTopology = "Layer3"
+	// NetworkTopologyLocalnet is a network connecting pods directly to a physical network of the nodes.
+	NetworkTopologyLocalnet NetworkTopology = "Localnet"
+)
+
+// NetworkRole describes how the network is attached to the pods.
+type NetworkRole string
+
+const (
+	// NetworkRolePrimary replaces the cluster default network for the pods of the namespace.
+	NetworkRolePrimary NetworkRole = "Primary"
+	// NetworkRoleSecondary attaches the network as an additional interface requested with a NAD.
+	NetworkRoleSecondary NetworkRole = "Secondary"
+)
+
+// IPAMMode describes whether IP addresses are allocated by OVN-Kubernetes.
+type IPAMMode string
+
+const (
+	// IPAMEnabled enables the allocation of IP addresses from the network subnets.
+	IPAMEnabled IPAMMode = "Enabled"
+	// IPAMDisabled disables IP address allocation, only layer 2 connectivity is provided.
+	IPAMDisabled IPAMMode = "Disabled"
+)
+
+// NetworkIPAMLifecycle describes the lifecycle of the IP addresses allocated to the pods.
+type NetworkIPAMLifecycle string
+
+const (
+	// IPAMLifecyclePersistent keeps the IP addresses of KubeVirt virtual machines across restarts and migrations.
+	IPAMLifecyclePersistent NetworkIPAMLifecycle = "Persistent"
+)
+
+// CIDR is a network subnet in CIDR notation.
+type CIDR string
+
+// DualStackCIDRs is a list of at most one IPv4 and one IPv6 CIDR.
+type DualStackCIDRs []CIDR
+
+// Layer3Subnet is a subnet of a Layer3 network split in per node subnets.
+type Layer3Subnet struct {
+	// CIDR specifies L3Subnet, which is split into smaller subnets for every node.
+	CIDR CIDR `json:"cidr"`
+	// HostSubnet specifies the subnet size for every node. When not set, it will be assigned automatically.
+	// +optional
+	HostSubnet int32 `json:"hostSubnet,omitempty"`
+}
+
+// Layer3Config is the configuration of a Layer3 network.
+type Layer3Config struct {
+	// Role describes the network role in the pod.
+	Role NetworkRole `json:"role"`
+	// MTU is the maximum transmission unit for a network.
+	// +optional
+	MTU int32 `json:"mtu,omitempty"`
+	// Subnets are used for the pod network across the cluster.
+	Subnets []Layer3Subnet `json:"subnets"`
+	// JoinSubnets are used inside the OVN network topology. Only allowed for the Primary role.
+	// +optional
+	JoinSubnets DualStackCIDRs `json:"joinSubnets,omitempty"`
+}
+
+// IPAMConfig is the IP address management configuration of a network.
+type IPAMConfig struct {
+	// Mode controls how much of the IP configuration will be managed by OVN.
+	// +optional
+	Mode IPAMMode `json:"mode,omitempty"`
+	// Lifecycle controls IP addresses management lifecycle.
+	// +optional
+	Lifecycle NetworkIPAMLifecycle `json:"lifecycle,omitempty"`
+}
+
+// Layer2Config is the configuration of a Layer2 network.
+type Layer2Config struct {
+	// Role describes the network role in the pod.
+	Role NetworkRole `json:"role"`
+	// MTU is the maximum transmission unit for a network.
+	// +optional
+	MTU int32 `json:"mtu,omitempty"`
+	// Subnets are used for the pod network across the cluster. Required unless IPAM is disabled.
+	// +optional
+	Subnets DualStackCIDRs `json:"subnets,omitempty"`
+	// JoinSubnets are used inside the OVN network topology. Only allowed for the Primary role.
+	// +optional
+	JoinSubnets DualStackCIDRs `json:"joinSubnets,omitempty"`
+	// IPAM section contains IPAM-related configuration for the network.
+	// +optional
+	IPAM *IPAMConfig `json:"ipam,omitempty"`
+}
+
+// UserDefinedNetworkSpec defines the desired state of UserDefinedNetwork.
+type UserDefinedNetworkSpec struct {
+	// Topology describes network configuration. Allowed values are Layer2 and Layer3.
+	Topology NetworkTopology `json:"topology"`
+	// Layer3 is the Layer3 topology configuration.
+	// +optional
+	Layer3 *Layer3Config `json:"layer3,omitempty"`
+	// Layer2 is the Layer2 topology configuration.
+	// +optional
+	Layer2 *Layer2Config `json:"layer2,omitempty"`
+}
+
+// UserDefinedNetworkStatus contains the observed status of the UserDefinedNetwork.
+type UserDefinedNetworkStatus struct {
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:resource:shortName=udn
+//+kubebuilder:subresource:status
+
+// UserDefinedNetwork describes network request for a Namespace. Its spec is immutable.
+type UserDefinedNetwork struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   UserDefinedNetworkSpec   `json:"spec"`
+	Status UserDefinedNetworkStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// UserDefinedNetworkList contains a list of UserDefinedNetwork.
+type UserDefinedNetworkList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []UserDefinedNetwork `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&UserDefinedNetwork{}, &UserDefinedNetworkList{})
+}
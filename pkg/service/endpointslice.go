@@ -0,0 +1,60 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/golang/glog"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ListReadyEndpoints returns the ready endpoints from all EndpointSlices of the service. Endpoints without a ready
+// condition are considered ready, as defined by the EndpointSlice API.
+func (builder *Builder) ListReadyEndpoints() ([]discoveryv1.Endpoint, error) {
+	if valid, err := builder.validate(); !valid {
+		return nil, err
+	}
+
+	glog.V(100).Infof("Listing ready endpoints of service %s in namespace %s",
+		builder.Definition.Name, builder.Definition.Namespace)
+
+	endpointSlices, err := builder.apiClient.K8sClient.DiscoveryV1().EndpointSlices(builder.Definition.Namespace).List(
+		context.TODO(), metav1.ListOptions{
+			LabelSelector: fmt.Sprintf("%s=%s", discoveryv1.LabelServiceName, builder.Definition.Name),
+		})
+	if err != nil {
+		glog.V(100).Infof("Failed to list EndpointSlices of service %s in namespace %s: %v",
+			builder.Definition.Name, builder.Definition.Namespace, err)
+
+		return nil, err
+	}
+
+	var readyEndpoints []discoveryv1.Endpoint
+
+	for _, endpointSlice := range endpointSlices.Items {
+		for _, endpoint := range endpointSlice.Endpoints {
+			if endpoint.Conditions.Ready == nil || *endpoint.Conditions.Ready {
+				readyEndpoints = append(readyEndpoints, endpoint)
+			}
+		}
+	}
+
+	return readyEndpoints, nil
+}
+
+// GetReadyEndpointAddresses returns the addresses of the ready endpoints of the service.
+func (builder *Builder) GetReadyEndpointAddresses() ([]string, error) {
+	readyEndpoints, err := builder.ListReadyEndpoints()
+	if err != nil {
+		return nil, err
+	}
+
+	var addresses []string
+
+	for _, endpoint := range readyEndpoints {
+		addresses = append(addresses, endpoint.Addresses...)
+	}
+
+	return addresses, nil
+}
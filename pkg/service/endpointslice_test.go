@@ -0,0 +1,73 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/openshift-kni/eco-goinfra/pkg/clients"
+	"github.com/stretchr/testify/assert"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+)
+
+func TestServiceListReadyEndpoints(t *testing.T) {
+	testCases := []struct {
+		endpointSlices    []runtime.Object
+		expectedAddresses []string
+	}{
+		{
+			endpointSlices: []runtime.Object{
+				buildDummyEndpointSlice("slice-a", defaultServiceName, discoveryv1.Endpoint{
+					Addresses:  []string{"10.128.0.10"},
+					Conditions: discoveryv1.EndpointConditions{Ready: ptr.To(true)},
+				}, discoveryv1.Endpoint{
+					Addresses:  []string{"10.128.0.11"},
+					Conditions: discoveryv1.EndpointConditions{Ready: ptr.To(false)},
+				}),
+				buildDummyEndpointSlice("slice-b", defaultServiceName, discoveryv1.Endpoint{
+					Addresses: []string{"10.128.0.12"},
+				}),
+				buildDummyEndpointSlice("slice-c", "other-service", discoveryv1.Endpoint{
+					Addresses: []string{"10.128.0.13"},
+				}),
+			},
+			expectedAddresses: []string{"10.128.0.10", "10.128.0.12"},
+		},
+		{
+			endpointSlices:    nil,
+			expectedAddresses: nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testBuilder := buildValidServiceBuilder(clients.GetTestClients(clients.TestClientParams{
+			K8sMockObjects: append(buildDummyService(), testCase.endpointSlices...),
+		}))
+
+		readyEndpoints, err := testBuilder.ListReadyEndpoints()
+		assert.Nil(t, err)
+		assert.Len(t, readyEndpoints, len(testCase.expectedAddresses))
+
+		addresses, err := testBuilder.GetReadyEndpointAddresses()
+		assert.Nil(t, err)
+		assert.ElementsMatch(t, testCase.expectedAddresses, addresses)
+	}
+
+	testBuilder := buildInValidServiceBuilder(buildServiceClientWithDummyObject())
+
+	_, err := testBuilder.ListReadyEndpoints()
+	assert.NotNil(t, err)
+}
+
+func buildDummyEndpointSlice(name, serviceName string, endpoints ...discoveryv1.Endpoint) *discoveryv1.EndpointSlice {
+	return &discoveryv1.EndpointSlice{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: defaultServiceNamespace,
+			Labels:    map[string]string{discoveryv1.LabelServiceName: serviceName},
+		},
+		AddressType: discoveryv1.AddressTypeIPv4,
+		Endpoints:   endpoints,
+	}
+}
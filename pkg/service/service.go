@@ -3,6 +3,9 @@ package service
 import (
 	"context"
 	"fmt"
+	"net"
+	"strings"
+	"time"
 
 	"github.com/openshift-kni/eco-goinfra/pkg/msg"
 
@@ -13,6 +16,16 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+const (
+	// MetalLBLoadBalancerIPsAnnotation is the annotation requesting specific IPs from MetalLB for a LoadBalancer
+	// service.
+	MetalLBLoadBalancerIPsAnnotation = "metallb.universe.tf/loadBalancerIPs"
+	// MetalLBAddressPoolAnnotation is the annotation requesting the LoadBalancer service IPs to be allocated from a
+	// given MetalLB IPAddressPool.
+	MetalLBAddressPoolAnnotation = "metallb.universe.tf/address-pool"
 )
 
 // Builder provides struct for service object containing connection to the cluster and the service definitions.
@@ -384,6 +397,212 @@ func (builder *Builder) WithIPFamily(ipFamily []corev1.IPFamily, ipStackPolicy c
 	return builder
 }
 
+// WithLoadBalancer redefines the service with LoadBalancer service type.
+func (builder *Builder) WithLoadBalancer() *Builder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Defining service %s in namespace %s with LoadBalancer type",
+		builder.Definition.Name, builder.Definition.Namespace)
+
+	builder.Definition.Spec.Type = corev1.ServiceTypeLoadBalancer
+
+	return builder
+}
+
+// WithLoadBalancerClass redefines the service with LoadBalancer service type implemented by the given load balancer
+// class, for example metallb.universe.tf/metallb.
+func (builder *Builder) WithLoadBalancerClass(loadBalancerClass string) *Builder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Defining service's loadBalancerClass: %s", loadBalancerClass)
+
+	if loadBalancerClass == "" {
+		glog.V(100).Infof("The loadBalancerClass can not be empty")
+
+		builder.errorMsg = "loadBalancerClass can not be empty"
+
+		return builder
+	}
+
+	builder.Definition.Spec.Type = corev1.ServiceTypeLoadBalancer
+	builder.Definition.Spec.LoadBalancerClass = &loadBalancerClass
+
+	return builder
+}
+
+// WithAllocateLoadBalancerNodePorts redefines the service with LoadBalancer service type and sets whether node ports
+// are allocated for it.
+func (builder *Builder) WithAllocateLoadBalancerNodePorts(allocate bool) *Builder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Defining service's allocateLoadBalancerNodePorts: %t", allocate)
+
+	builder.Definition.Spec.Type = corev1.ServiceTypeLoadBalancer
+	builder.Definition.Spec.AllocateLoadBalancerNodePorts = &allocate
+
+	return builder
+}
+
+// WithLoadBalancerIPs redefines the service with LoadBalancer service type and requests the given IPs from MetalLB,
+// at most one per IP family.
+func (builder *Builder) WithLoadBalancerIPs(ips ...string) *Builder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Defining service's requested load balancer IPs: %v", ips)
+
+	if len(ips) == 0 {
+		glog.V(100).Infof("The load balancer IPs can not be empty")
+
+		builder.errorMsg = "load balancer IPs can not be empty"
+
+		return builder
+	}
+
+	ipv4Count, ipv6Count := 0, 0
+
+	for _, ip := range ips {
+		parsedIP := net.ParseIP(ip)
+		if parsedIP == nil {
+			glog.V(100).Infof("The load balancer IP %s is not a valid IP address", ip)
+
+			builder.errorMsg = fmt.Sprintf("load balancer IP %s is not a valid IP address", ip)
+
+			return builder
+		}
+
+		if parsedIP.To4() != nil {
+			ipv4Count++
+		} else {
+			ipv6Count++
+		}
+	}
+
+	if ipv4Count > 1 || ipv6Count > 1 {
+		glog.V(100).Infof("The load balancer IPs %v contain more than one IP of the same family", ips)
+
+		builder.errorMsg = "load balancer IPs can contain at most one IPv4 and one IPv6 address"
+
+		return builder
+	}
+
+	builder.Definition.Spec.Type = corev1.ServiceTypeLoadBalancer
+	builder.setAnnotation(MetalLBLoadBalancerIPsAnnotation, strings.Join(ips, ","))
+
+	return builder
+}
+
+// WithAddressPool redefines the service with LoadBalancer service type and requests its IPs to be allocated from the
+// given MetalLB IPAddressPool.
+func (builder *Builder) WithAddressPool(addressPool string) *Builder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Defining service's MetalLB address pool: %s", addressPool)
+
+	if addressPool == "" {
+		glog.V(100).Infof("The address pool can not be empty")
+
+		builder.errorMsg = "address pool can not be empty"
+
+		return builder
+	}
+
+	builder.Definition.Spec.Type = corev1.ServiceTypeLoadBalancer
+	builder.setAnnotation(MetalLBAddressPoolAnnotation, addressPool)
+
+	return builder
+}
+
+// WithExternalName redefines the service with ExternalName service type, returning a CNAME record for the given
+// external DNS name. The selector of the service is removed since ExternalName services have no endpoints.
+func (builder *Builder) WithExternalName(externalName string) *Builder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Defining service's externalName: %s", externalName)
+
+	if externalName == "" {
+		glog.V(100).Infof("The externalName can not be empty")
+
+		builder.errorMsg = "externalName can not be empty"
+
+		return builder
+	}
+
+	builder.Definition.Spec.Type = corev1.ServiceTypeExternalName
+	builder.Definition.Spec.ExternalName = externalName
+	builder.Definition.Spec.Selector = nil
+
+	return builder
+}
+
+// WithHeadless redefines the service as a headless ClusterIP service, resolving directly to the IPs of its
+// endpoints instead of a virtual IP.
+func (builder *Builder) WithHeadless() *Builder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Defining service %s in namespace %s as headless",
+		builder.Definition.Name, builder.Definition.Namespace)
+
+	builder.Definition.Spec.Type = corev1.ServiceTypeClusterIP
+	builder.Definition.Spec.ClusterIP = corev1.ClusterIPNone
+
+	return builder
+}
+
+// WaitUntilLoadBalancerIP waits for the duration of the defined timeout until the LoadBalancer service has at least
+// one ingress IP assigned in its status.
+func (builder *Builder) WaitUntilLoadBalancerIP(timeout time.Duration) error {
+	if valid, err := builder.validate(); !valid {
+		return err
+	}
+
+	glog.V(100).Infof("Waiting until service %s in namespace %s has a load balancer IP",
+		builder.Definition.Name, builder.Definition.Namespace)
+
+	return wait.PollUntilContextTimeout(
+		context.TODO(), time.Second, timeout, true, func(ctx context.Context) (bool, error) {
+			if !builder.Exists() {
+				glog.V(100).Infof("The service %s in namespace %s does not exist yet",
+					builder.Definition.Name, builder.Definition.Namespace)
+
+				return false, nil
+			}
+
+			return len(builder.GetLoadBalancerIPs()) > 0, nil
+		})
+}
+
+// GetLoadBalancerIPs returns the ingress IPs assigned to the LoadBalancer service, as of the last time the service
+// object was fetched.
+func (builder *Builder) GetLoadBalancerIPs() []string {
+	if valid, _ := builder.validate(); !valid || builder.Object == nil {
+		return nil
+	}
+
+	var ips []string
+
+	for _, ingress := range builder.Object.Status.LoadBalancer.Ingress {
+		if ingress.IP != "" {
+			ips = append(ips, ingress.IP)
+		}
+	}
+
+	return ips
+}
+
 // DefineServicePort helper for creating a Service with a ServicePort.
 func DefineServicePort(port, targetPort int32, protocol corev1.Protocol) (*corev1.ServicePort, error) {
 	glog.V(100).Infof(
@@ -414,6 +633,15 @@ func GetServiceGVR() schema.GroupVersionResource {
 	}
 }
 
+// setAnnotation adds the annotation to the service definition, keeping the existing ones.
+func (builder *Builder) setAnnotation(key, value string) {
+	if builder.Definition.Annotations == nil {
+		builder.Definition.Annotations = make(map[string]string)
+	}
+
+	builder.Definition.Annotations[key] = value
+}
+
 // isValidPort checks if a port is valid.
 func isValidPort(port int32) bool {
 	if (port > 0) && (port < 65535) {
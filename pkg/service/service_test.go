@@ -1,8 +1,10 @@
 package service
 
 import (
+	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/openshift-kni/eco-goinfra/pkg/clients"
 	"github.com/stretchr/testify/assert"
@@ -582,6 +584,196 @@ func TestServiceWithIPFamily(t *testing.T) {
 	}
 }
 
+func TestServiceWithLoadBalancerClass(t *testing.T) {
+	testCases := []struct {
+		loadBalancerClass string
+		expectedErrorText string
+	}{
+		{
+			loadBalancerClass: "metallb.universe.tf/metallb",
+			expectedErrorText: "",
+		},
+		{
+			loadBalancerClass: "",
+			expectedErrorText: "loadBalancerClass can not be empty",
+		},
+	}
+
+	for _, testCase := range testCases {
+		testBuilder := buildValidServiceBuilder(buildServiceClientWithDummyObject())
+
+		result := testBuilder.WithLoadBalancerClass(testCase.loadBalancerClass)
+		assert.Equal(t, testCase.expectedErrorText, result.errorMsg)
+
+		if testCase.expectedErrorText == "" {
+			assert.Equal(t, corev1.ServiceTypeLoadBalancer, result.Definition.Spec.Type)
+			assert.Equal(t, &testCase.loadBalancerClass, result.Definition.Spec.LoadBalancerClass)
+		}
+	}
+}
+
+func TestServiceWithAllocateLoadBalancerNodePorts(t *testing.T) {
+	for _, allocate := range []bool{true, false} {
+		testBuilder := buildValidServiceBuilder(buildServiceClientWithDummyObject())
+
+		result := testBuilder.WithAllocateLoadBalancerNodePorts(allocate)
+		assert.Empty(t, result.errorMsg)
+		assert.Equal(t, corev1.ServiceTypeLoadBalancer, result.Definition.Spec.Type)
+		assert.Equal(t, &allocate, result.Definition.Spec.AllocateLoadBalancerNodePorts)
+	}
+}
+
+func TestServiceWithLoadBalancerIPs(t *testing.T) {
+	testCases := []struct {
+		ips                []string
+		expectedAnnotation string
+		expectedErrorText  string
+	}{
+		{
+			ips:                []string{"192.168.100.10"},
+			expectedAnnotation: "192.168.100.10",
+			expectedErrorText:  "",
+		},
+		{
+			ips:                []string{"192.168.100.10", "2001:db8::10"},
+			expectedAnnotation: "192.168.100.10,2001:db8::10",
+			expectedErrorText:  "",
+		},
+		{
+			ips:               []string{"192.168.100"},
+			expectedErrorText: "load balancer IP 192.168.100 is not a valid IP address",
+		},
+		{
+			ips:               []string{},
+			expectedErrorText: "load balancer IPs can not be empty",
+		},
+		{
+			ips:               []string{"192.168.100.10", "192.168.100.11"},
+			expectedErrorText: "load balancer IPs can contain at most one IPv4 and one IPv6 address",
+		},
+		{
+			ips:               []string{"2001:db8::10", "192.168.100.10", "2001:db8::11"},
+			expectedErrorText: "load balancer IPs can contain at most one IPv4 and one IPv6 address",
+		},
+	}
+
+	for _, testCase := range testCases {
+		testBuilder := buildValidServiceBuilder(buildServiceClientWithDummyObject()).
+			WithAnnotation(defaultServiceAnnotation)
+
+		result := testBuilder.WithLoadBalancerIPs(testCase.ips...)
+		assert.Equal(t, testCase.expectedErrorText, result.errorMsg)
+
+		if testCase.expectedErrorText == "" {
+			assert.Equal(t, corev1.ServiceTypeLoadBalancer, result.Definition.Spec.Type)
+			assert.Equal(t, testCase.expectedAnnotation, result.Definition.Annotations[MetalLBLoadBalancerIPsAnnotation])
+			assert.Equal(t, "true", result.Definition.Annotations["service-test/annotation"])
+		}
+	}
+}
+
+func TestServiceWithAddressPool(t *testing.T) {
+	testCases := []struct {
+		addressPool       string
+		expectedErrorText string
+	}{
+		{
+			addressPool:       "test-pool",
+			expectedErrorText: "",
+		},
+		{
+			addressPool:       "",
+			expectedErrorText: "address pool can not be empty",
+		},
+	}
+
+	for _, testCase := range testCases {
+		testBuilder := buildValidServiceBuilder(buildServiceClientWithDummyObject())
+
+		result := testBuilder.WithAddressPool(testCase.addressPool)
+		assert.Equal(t, testCase.expectedErrorText, result.errorMsg)
+
+		if testCase.expectedErrorText == "" {
+			assert.Equal(t, corev1.ServiceTypeLoadBalancer, result.Definition.Spec.Type)
+			assert.Equal(t, testCase.addressPool, result.Definition.Annotations[MetalLBAddressPoolAnnotation])
+		}
+	}
+}
+
+func TestServiceWithExternalName(t *testing.T) {
+	testCases := []struct {
+		externalName      string
+		expectedErrorText string
+	}{
+		{
+			externalName:      "my.database.example.com",
+			expectedErrorText: "",
+		},
+		{
+			externalName:      "",
+			expectedErrorText: "externalName can not be empty",
+		},
+	}
+
+	for _, testCase := range testCases {
+		testBuilder := buildValidServiceBuilder(buildServiceClientWithDummyObject())
+
+		result := testBuilder.WithExternalName(testCase.externalName)
+		assert.Equal(t, testCase.expectedErrorText, result.errorMsg)
+
+		if testCase.expectedErrorText == "" {
+			assert.Equal(t, corev1.ServiceTypeExternalName, result.Definition.Spec.Type)
+			assert.Equal(t, testCase.externalName, result.Definition.Spec.ExternalName)
+			assert.Nil(t, result.Definition.Spec.Selector)
+		}
+	}
+}
+
+func TestServiceWithHeadless(t *testing.T) {
+	testBuilder := buildValidServiceBuilder(buildServiceClientWithDummyObject())
+
+	result := testBuilder.WithHeadless()
+	assert.Empty(t, result.errorMsg)
+	assert.Equal(t, corev1.ServiceTypeClusterIP, result.Definition.Spec.Type)
+	assert.Equal(t, corev1.ClusterIPNone, result.Definition.Spec.ClusterIP)
+}
+
+func TestServiceWaitUntilLoadBalancerIP(t *testing.T) {
+	testCases := []struct {
+		ingress       []corev1.LoadBalancerIngress
+		expectedError error
+	}{
+		{
+			ingress:       []corev1.LoadBalancerIngress{{IP: "192.168.100.10"}},
+			expectedError: nil,
+		},
+		{
+			ingress:       []corev1.LoadBalancerIngress{{Hostname: "lb.example.com"}},
+			expectedError: context.DeadlineExceeded,
+		},
+		{
+			ingress:       nil,
+			expectedError: context.DeadlineExceeded,
+		},
+	}
+
+	for _, testCase := range testCases {
+		dummyService := buildDummyService()
+		dummyService[0].(*corev1.Service).Status.LoadBalancer.Ingress = testCase.ingress
+
+		testBuilder := buildValidServiceBuilder(clients.GetTestClients(clients.TestClientParams{
+			K8sMockObjects: dummyService,
+		}))
+
+		err := testBuilder.WaitUntilLoadBalancerIP(time.Second)
+		assert.Equal(t, testCase.expectedError, err)
+
+		if testCase.expectedError == nil {
+			assert.Equal(t, []string{"192.168.100.10"}, testBuilder.GetLoadBalancerIPs())
+		}
+	}
+}
+
 func TestServiceDefineServicePort(t *testing.T) {
 	testCases := []struct {
 		testPort       int32
@@ -0,0 +1,352 @@
+package sriov
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/golang/glog"
+	"github.com/openshift-kni/eco-goinfra/pkg/msg"
+
+	srIovV1 "github.com/k8snetworkplumbingwg/sriov-network-operator/api/v1"
+	"github.com/openshift-kni/eco-goinfra/pkg/clients"
+	"golang.org/x/exp/slices"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	goclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// IBNetworkBuilder provides struct for SriovIBNetwork object which contains connection to cluster and
+// SriovIBNetwork definition.
+type IBNetworkBuilder struct {
+	// SriovIBNetwork definition. Used to create SriovIBNetwork object.
+	Definition *srIovV1.SriovIBNetwork
+	// Created SriovIBNetwork object.
+	Object *srIovV1.SriovIBNetwork
+	// Used in functions that define or mutate SriovIBNetwork definitions. errorMsg is processed before
+	// SriovIBNetwork object is created.
+	errorMsg string
+	// apiClient opens api connection to the cluster.
+	apiClient *clients.Settings
+}
+
+// IBNetworkAdditionalOptions additional options for SriovIBNetwork object.
+type IBNetworkAdditionalOptions func(builder *IBNetworkBuilder) (*IBNetworkBuilder, error)
+
+// NewIBNetworkBuilder creates new instance of IBNetworkBuilder.
+func NewIBNetworkBuilder(
+	apiClient *clients.Settings, name, nsname, targetNsname, resName string) *IBNetworkBuilder {
+	glog.V(100).Infof(
+		"Initializing new SriovIBNetwork structure with the name %s in the namespace %s", name, nsname)
+
+	builder := IBNetworkBuilder{
+		apiClient: apiClient,
+		Definition: &srIovV1.SriovIBNetwork{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: nsname,
+			},
+			Spec: srIovV1.SriovIBNetworkSpec{
+				ResourceName:     resName,
+				NetworkNamespace: targetNsname,
+			},
+		},
+	}
+
+	if name == "" {
+		builder.errorMsg = "SriovIBNetwork 'name' cannot be empty"
+	}
+
+	if nsname == "" {
+		builder.errorMsg = "SriovIBNetwork 'nsname' cannot be empty"
+	}
+
+	if targetNsname == "" {
+		builder.errorMsg = "SriovIBNetwork 'targetNsname' cannot be empty"
+	}
+
+	if resName == "" {
+		builder.errorMsg = "SriovIBNetwork 'resName' cannot be empty"
+	}
+
+	return &builder
+}
+
+// WithLinkState sets linkState parameters in the SriovIBNetwork definition spec.
+func (builder *IBNetworkBuilder) WithLinkState(linkState string) *IBNetworkBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Setting SriovIBNetwork %s linkState: %s", builder.Definition.Name, linkState)
+
+	allowedLinkStates := []string{"enable", "disable", "auto"}
+
+	if !slices.Contains(allowedLinkStates, linkState) {
+		builder.errorMsg = "invalid 'linkState' parameters"
+
+		return builder
+	}
+
+	builder.Definition.Spec.LinkState = linkState
+
+	return builder
+}
+
+// WithInfinibandGUIDSupport sets infinibandGUID capabilities in the SriovIBNetwork definition spec.
+func (builder *IBNetworkBuilder) WithInfinibandGUIDSupport() *IBNetworkBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Setting SriovIBNetwork %s infinibandGUID capability", builder.Definition.Name)
+
+	builder.Definition.Spec.Capabilities = `{ "infinibandGUID": true }`
+
+	return builder
+}
+
+// WithIPAddressSupport sets ips capabilities in the SriovIBNetwork definition spec.
+func (builder *IBNetworkBuilder) WithIPAddressSupport() *IBNetworkBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Setting SriovIBNetwork %s ips capability", builder.Definition.Name)
+
+	builder.Definition.Spec.Capabilities = `{ "ips": true }`
+
+	return builder
+}
+
+// WithStaticIpam sets static IPAM in the SriovIBNetwork definition spec.
+func (builder *IBNetworkBuilder) WithStaticIpam() *IBNetworkBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Setting SriovIBNetwork %s static IPAM", builder.Definition.Name)
+
+	builder.Definition.Spec.IPAM = `{ "type": "static" }`
+
+	return builder
+}
+
+// WithOptions creates SriovIBNetwork with generic mutation options.
+func (builder *IBNetworkBuilder) WithOptions(options ...IBNetworkAdditionalOptions) *IBNetworkBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Setting SriovIBNetwork additional options")
+
+	for _, option := range options {
+		if option != nil {
+			builder, err := option(builder)
+
+			if err != nil {
+				glog.V(100).Infof("Error occurred in mutation function")
+
+				builder.errorMsg = err.Error()
+
+				return builder
+			}
+		}
+	}
+
+	return builder
+}
+
+// PullIBNetwork pulls existing SriovIBNetwork from cluster.
+func PullIBNetwork(apiClient *clients.Settings, name, nsname string) (*IBNetworkBuilder, error) {
+	glog.V(100).Infof("Pulling existing SriovIBNetwork name %s under namespace %s from cluster", name, nsname)
+
+	if apiClient == nil {
+		glog.V(100).Infof("The apiClient is empty")
+
+		return nil, fmt.Errorf("SriovIBNetwork 'apiClient' cannot be empty")
+	}
+
+	builder := IBNetworkBuilder{
+		apiClient: apiClient,
+		Definition: &srIovV1.SriovIBNetwork{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: nsname,
+			},
+		},
+	}
+
+	if name == "" {
+		glog.V(100).Infof("The name of the SriovIBNetwork is empty")
+
+		return nil, fmt.Errorf("SriovIBNetwork 'name' cannot be empty")
+	}
+
+	if nsname == "" {
+		glog.V(100).Infof("The namespace of the SriovIBNetwork is empty")
+
+		return nil, fmt.Errorf("SriovIBNetwork 'namespace' cannot be empty")
+	}
+
+	if !builder.Exists() {
+		return nil, fmt.Errorf("SriovIBNetwork object %s does not exist in namespace %s", name, nsname)
+	}
+
+	builder.Definition = builder.Object
+
+	return &builder, nil
+}
+
+// Get returns SriovIBNetwork object if found.
+func (builder *IBNetworkBuilder) Get() (*srIovV1.SriovIBNetwork, error) {
+	if valid, err := builder.validate(); !valid {
+		return nil, err
+	}
+
+	glog.V(100).Infof("Collecting SriovIBNetwork object %s in namespace %s",
+		builder.Definition.Name, builder.Definition.Namespace)
+
+	ibNetwork := &srIovV1.SriovIBNetwork{}
+	err := builder.apiClient.Get(context.TODO(), goclient.ObjectKey{
+		Name:      builder.Definition.Name,
+		Namespace: builder.Definition.Namespace,
+	}, ibNetwork)
+
+	if err != nil {
+		glog.V(100).Infof("Failed to get SriovIBNetwork %s in namespace %s",
+			builder.Definition.Name, builder.Definition.Namespace)
+
+		return nil, err
+	}
+
+	return ibNetwork, nil
+}
+
+// Exists checks whether the given SriovIBNetwork object exists in a cluster.
+func (builder *IBNetworkBuilder) Exists() bool {
+	if valid, _ := builder.validate(); !valid {
+		return false
+	}
+
+	glog.V(100).Infof("Checking if SriovIBNetwork %s exists in namespace %s",
+		builder.Definition.Name, builder.Definition.Namespace)
+
+	var err error
+	builder.Object, err = builder.Get()
+
+	return err == nil || !k8serrors.IsNotFound(err)
+}
+
+// Create generates SriovIBNetwork in a cluster and stores the created object in struct.
+func (builder *IBNetworkBuilder) Create() (*IBNetworkBuilder, error) {
+	if valid, err := builder.validate(); !valid {
+		return builder, err
+	}
+
+	glog.V(100).Infof("Creating the SriovIBNetwork %s in namespace %s",
+		builder.Definition.Name, builder.Definition.Namespace)
+
+	if !builder.Exists() {
+		err := builder.apiClient.Create(context.TODO(), builder.Definition)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	builder.Object = builder.Definition
+
+	return builder, nil
+}
+
+// Delete removes SriovIBNetwork object.
+func (builder *IBNetworkBuilder) Delete() error {
+	if valid, err := builder.validate(); !valid {
+		return err
+	}
+
+	glog.V(100).Infof("Deleting the SriovIBNetwork object %s in namespace %s",
+		builder.Definition.Name, builder.Definition.Namespace)
+
+	if !builder.Exists() {
+		return nil
+	}
+
+	err := builder.apiClient.Delete(context.TODO(), builder.Object)
+
+	if err != nil {
+		return err
+	}
+
+	builder.Object = nil
+
+	return nil
+}
+
+// Update renovates the existing SriovIBNetwork object with the SriovIBNetwork definition in builder.
+func (builder *IBNetworkBuilder) Update(force bool) (*IBNetworkBuilder, error) {
+	if valid, err := builder.validate(); !valid {
+		return builder, err
+	}
+
+	glog.V(100).Infof("Updating the SriovIBNetwork object %s in namespace %s",
+		builder.Definition.Name, builder.Definition.Namespace)
+
+	err := builder.apiClient.Update(context.TODO(), builder.Definition)
+
+	if err != nil {
+		if force {
+			glog.V(100).Infof(
+				msg.FailToUpdateNotification("SriovIBNetwork", builder.Definition.Name, builder.Definition.Namespace))
+
+			err = builder.Delete()
+
+			if err != nil {
+				glog.V(100).Infof(
+					msg.FailToUpdateError("SriovIBNetwork", builder.Definition.Name, builder.Definition.Namespace))
+
+				return nil, err
+			}
+
+			return builder.Create()
+		}
+
+		return nil, err
+	}
+
+	builder.Object = builder.Definition
+
+	return builder, nil
+}
+
+// validate will check that the builder and builder definition are properly initialized before
+// accessing any member fields.
+func (builder *IBNetworkBuilder) validate() (bool, error) {
+	resourceCRD := "SriovIBNetwork"
+
+	if builder == nil {
+		glog.V(100).Infof("The %s builder is uninitialized", resourceCRD)
+
+		return false, fmt.Errorf("error: received nil %s builder", resourceCRD)
+	}
+
+	if builder.Definition == nil {
+		glog.V(100).Infof("The %s is undefined", resourceCRD)
+
+		builder.errorMsg = msg.UndefinedCrdObjectErrString(resourceCRD)
+	}
+
+	if builder.apiClient == nil {
+		glog.V(100).Infof("The %s builder apiclient is nil", resourceCRD)
+
+		builder.errorMsg = fmt.Sprintf("%s builder cannot have nil apiClient", resourceCRD)
+	}
+
+	if builder.errorMsg != "" {
+		glog.V(100).Infof("The %s builder has error message: %s", resourceCRD, builder.errorMsg)
+
+		return false, fmt.Errorf(builder.errorMsg)
+	}
+
+	return true, nil
+}
@@ -0,0 +1,295 @@
+package sriov
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/openshift-kni/eco-goinfra/pkg/clients"
+	"github.com/stretchr/testify/assert"
+
+	srIovV1 "github.com/k8snetworkplumbingwg/sriov-network-operator/api/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+var (
+	defaultIBNetworkName       = "ibnetwork"
+	defaultIBNetworkNsName     = "testnamespace"
+	defaultIBNetworkTargetNs   = "targetnamespace"
+	defaultIBNetworkResName    = "ibresource"
+	defaultIBNetworkLinkStates = []string{"enable", "disable", "auto"}
+)
+
+func TestNewIBNetworkBuilder(t *testing.T) {
+	testCases := []struct {
+		name              string
+		nsname            string
+		targetNsname      string
+		resName           string
+		expectedErrorText string
+	}{
+		{
+			name:         defaultIBNetworkName,
+			nsname:       defaultIBNetworkNsName,
+			targetNsname: defaultIBNetworkTargetNs,
+			resName:      defaultIBNetworkResName,
+		},
+		{
+			name:              "",
+			nsname:            defaultIBNetworkNsName,
+			targetNsname:      defaultIBNetworkTargetNs,
+			resName:           defaultIBNetworkResName,
+			expectedErrorText: "SriovIBNetwork 'name' cannot be empty",
+		},
+		{
+			name:              defaultIBNetworkName,
+			nsname:            "",
+			targetNsname:      defaultIBNetworkTargetNs,
+			resName:           defaultIBNetworkResName,
+			expectedErrorText: "SriovIBNetwork 'nsname' cannot be empty",
+		},
+		{
+			name:              defaultIBNetworkName,
+			nsname:            defaultIBNetworkNsName,
+			targetNsname:      "",
+			resName:           defaultIBNetworkResName,
+			expectedErrorText: "SriovIBNetwork 'targetNsname' cannot be empty",
+		},
+		{
+			name:              defaultIBNetworkName,
+			nsname:            defaultIBNetworkNsName,
+			targetNsname:      defaultIBNetworkTargetNs,
+			resName:           "",
+			expectedErrorText: "SriovIBNetwork 'resName' cannot be empty",
+		},
+	}
+
+	for _, testCase := range testCases {
+		testBuilder := NewIBNetworkBuilder(
+			clients.GetTestClients(clients.TestClientParams{}),
+			testCase.name, testCase.nsname, testCase.targetNsname, testCase.resName)
+		assert.Equal(t, testCase.expectedErrorText, testBuilder.errorMsg)
+
+		if testCase.expectedErrorText == "" {
+			assert.Equal(t, testCase.name, testBuilder.Definition.Name)
+			assert.Equal(t, testCase.nsname, testBuilder.Definition.Namespace)
+			assert.Equal(t, testCase.targetNsname, testBuilder.Definition.Spec.NetworkNamespace)
+			assert.Equal(t, testCase.resName, testBuilder.Definition.Spec.ResourceName)
+		}
+	}
+}
+
+func TestPullIBNetwork(t *testing.T) {
+	testCases := []struct {
+		name                string
+		nsname              string
+		addToRuntimeObjects bool
+		client              bool
+		expectedError       error
+	}{
+		{
+			name:                defaultIBNetworkName,
+			nsname:              defaultIBNetworkNsName,
+			addToRuntimeObjects: true,
+			client:              true,
+			expectedError:       nil,
+		},
+		{
+			name:                "",
+			nsname:              defaultIBNetworkNsName,
+			addToRuntimeObjects: true,
+			client:              true,
+			expectedError:       fmt.Errorf("SriovIBNetwork 'name' cannot be empty"),
+		},
+		{
+			name:                defaultIBNetworkName,
+			nsname:              "",
+			addToRuntimeObjects: true,
+			client:              true,
+			expectedError:       fmt.Errorf("SriovIBNetwork 'namespace' cannot be empty"),
+		},
+		{
+			name:                defaultIBNetworkName,
+			nsname:              defaultIBNetworkNsName,
+			addToRuntimeObjects: false,
+			client:              true,
+			expectedError: fmt.Errorf(
+				"SriovIBNetwork object %s does not exist in namespace %s", defaultIBNetworkName, defaultIBNetworkNsName),
+		},
+		{
+			name:                defaultIBNetworkName,
+			nsname:              defaultIBNetworkNsName,
+			addToRuntimeObjects: true,
+			client:              false,
+			expectedError:       fmt.Errorf("SriovIBNetwork 'apiClient' cannot be empty"),
+		},
+	}
+
+	for _, testCase := range testCases {
+		var (
+			runtimeObjects []runtime.Object
+			testSettings   *clients.Settings
+		)
+
+		if testCase.addToRuntimeObjects {
+			runtimeObjects = buildDummyIBNetworkObject()
+		}
+
+		if testCase.client {
+			testSettings = clients.GetTestClients(clients.TestClientParams{K8sMockObjects: runtimeObjects})
+		}
+
+		testBuilder, err := PullIBNetwork(testSettings, testCase.name, testCase.nsname)
+		assert.Equal(t, testCase.expectedError, err)
+
+		if testCase.expectedError == nil {
+			assert.Equal(t, testCase.name, testBuilder.Object.Name)
+			assert.Equal(t, testCase.nsname, testBuilder.Object.Namespace)
+		}
+	}
+}
+
+func TestIBNetworkWithLinkState(t *testing.T) {
+	for _, linkState := range defaultIBNetworkLinkStates {
+		testBuilder := buildValidIBNetworkTestBuilder(buildTestIBNetworkClientWithDummyObject()).WithLinkState(linkState)
+		assert.Empty(t, testBuilder.errorMsg)
+		assert.Equal(t, linkState, testBuilder.Definition.Spec.LinkState)
+	}
+
+	testBuilder := buildValidIBNetworkTestBuilder(buildTestIBNetworkClientWithDummyObject()).WithLinkState("up")
+	assert.Equal(t, "invalid 'linkState' parameters", testBuilder.errorMsg)
+	assert.Empty(t, testBuilder.Definition.Spec.LinkState)
+}
+
+func TestIBNetworkWithCapabilitiesAndIpam(t *testing.T) {
+	testBuilder := buildValidIBNetworkTestBuilder(buildTestIBNetworkClientWithDummyObject()).WithInfinibandGUIDSupport()
+	assert.Equal(t, `{ "infinibandGUID": true }`, testBuilder.Definition.Spec.Capabilities)
+
+	testBuilder = buildValidIBNetworkTestBuilder(buildTestIBNetworkClientWithDummyObject()).WithIPAddressSupport()
+	assert.Equal(t, `{ "ips": true }`, testBuilder.Definition.Spec.Capabilities)
+
+	testBuilder = buildValidIBNetworkTestBuilder(buildTestIBNetworkClientWithDummyObject()).WithStaticIpam()
+	assert.Equal(t, `{ "type": "static" }`, testBuilder.Definition.Spec.IPAM)
+}
+
+func TestIBNetworkWithOptions(t *testing.T) {
+	testBuilder := buildValidIBNetworkTestBuilder(buildTestIBNetworkClientWithDummyObject()).WithOptions(
+		func(builder *IBNetworkBuilder) (*IBNetworkBuilder, error) {
+			return builder, nil
+		})
+	assert.Equal(t, "", testBuilder.errorMsg)
+
+	testBuilder = buildValidIBNetworkTestBuilder(buildTestIBNetworkClientWithDummyObject()).WithOptions(
+		func(builder *IBNetworkBuilder) (*IBNetworkBuilder, error) {
+			return builder, fmt.Errorf("error")
+		})
+	assert.Equal(t, "error", testBuilder.errorMsg)
+}
+
+func TestIBNetworkCreate(t *testing.T) {
+	testCases := []struct {
+		testIBNetwork *IBNetworkBuilder
+		expectedError error
+	}{
+		{
+			testIBNetwork: buildValidIBNetworkTestBuilder(clients.GetTestClients(clients.TestClientParams{})),
+			expectedError: nil,
+		},
+		{
+			testIBNetwork: buildValidIBNetworkTestBuilder(buildTestIBNetworkClientWithDummyObject()),
+			expectedError: nil,
+		},
+		{
+			testIBNetwork: buildInvalidIBNetworkTestBuilder(buildTestIBNetworkClientWithDummyObject()),
+			expectedError: fmt.Errorf("SriovIBNetwork 'nsname' cannot be empty"),
+		},
+	}
+
+	for _, testCase := range testCases {
+		testBuilder, err := testCase.testIBNetwork.Create()
+		assert.Equal(t, testCase.expectedError, err)
+
+		if testCase.expectedError == nil {
+			assert.Equal(t, testBuilder.Definition.Name, testBuilder.Object.Name)
+			assert.True(t, testBuilder.Exists())
+		}
+	}
+}
+
+func TestIBNetworkDelete(t *testing.T) {
+	testCases := []struct {
+		testIBNetwork *IBNetworkBuilder
+		expectedError error
+	}{
+		{
+			testIBNetwork: buildValidIBNetworkTestBuilder(buildTestIBNetworkClientWithDummyObject()),
+			expectedError: nil,
+		},
+		{
+			testIBNetwork: buildValidIBNetworkTestBuilder(clients.GetTestClients(clients.TestClientParams{})),
+			expectedError: nil,
+		},
+		{
+			testIBNetwork: buildInvalidIBNetworkTestBuilder(buildTestIBNetworkClientWithDummyObject()),
+			expectedError: fmt.Errorf("SriovIBNetwork 'nsname' cannot be empty"),
+		},
+	}
+
+	for _, testCase := range testCases {
+		err := testCase.testIBNetwork.Delete()
+		assert.Equal(t, testCase.expectedError, err)
+
+		if testCase.expectedError == nil {
+			assert.Nil(t, testCase.testIBNetwork.Object)
+			assert.False(t, testCase.testIBNetwork.Exists())
+		}
+	}
+}
+
+func TestIBNetworkUpdate(t *testing.T) {
+	testBuilder := buildValidIBNetworkTestBuilder(buildTestIBNetworkClientWithDummyObject())
+	assert.True(t, testBuilder.Exists())
+
+	testBuilder.Definition.ResourceVersion = testBuilder.Object.ResourceVersion
+	testBuilder.WithLinkState("enable")
+
+	testBuilder, err := testBuilder.Update(false)
+	assert.Nil(t, err)
+	assert.Equal(t, "enable", testBuilder.Object.Spec.LinkState)
+
+	testBuilder = buildValidIBNetworkTestBuilder(clients.GetTestClients(clients.TestClientParams{}))
+	_, err = testBuilder.Update(false)
+	assert.NotNil(t, err)
+
+	testBuilder, err = testBuilder.Update(true)
+	assert.Nil(t, err)
+	assert.True(t, testBuilder.Exists())
+}
+
+func buildValidIBNetworkTestBuilder(apiClient *clients.Settings) *IBNetworkBuilder {
+	return NewIBNetworkBuilder(
+		apiClient, defaultIBNetworkName, defaultIBNetworkNsName, defaultIBNetworkTargetNs, defaultIBNetworkResName)
+}
+
+func buildInvalidIBNetworkTestBuilder(apiClient *clients.Settings) *IBNetworkBuilder {
+	return NewIBNetworkBuilder(apiClient, defaultIBNetworkName, "", defaultIBNetworkTargetNs, defaultIBNetworkResName)
+}
+
+func buildTestIBNetworkClientWithDummyObject() *clients.Settings {
+	return clients.GetTestClients(clients.TestClientParams{
+		K8sMockObjects: buildDummyIBNetworkObject(),
+	})
+}
+
+func buildDummyIBNetworkObject() []runtime.Object {
+	return append([]runtime.Object{}, &srIovV1.SriovIBNetwork{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      defaultIBNetworkName,
+			Namespace: defaultIBNetworkNsName,
+		},
+		Spec: srIovV1.SriovIBNetworkSpec{
+			ResourceName:     defaultIBNetworkResName,
+			NetworkNamespace: defaultIBNetworkTargetNs,
+		},
+	})
+}
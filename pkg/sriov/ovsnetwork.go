@@ -0,0 +1,425 @@
+package sriov
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/golang/glog"
+	"github.com/openshift-kni/eco-goinfra/pkg/msg"
+
+	"github.com/openshift-kni/eco-goinfra/pkg/clients"
+	"github.com/openshift-kni/eco-goinfra/pkg/schemes/sriov/sriovtypes"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	goclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// maxOVSVlanID is the highest vlan id accepted by the OVSNetwork vlan and trunk fields.
+const maxOVSVlanID = 4095
+
+// OVSNetworkBuilder provides struct for OVSNetwork object which contains connection to cluster and
+// OVSNetwork definition.
+type OVSNetworkBuilder struct {
+	// OVSNetwork definition. Used to create OVSNetwork object.
+	Definition *sriovtypes.OVSNetwork
+	// Created OVSNetwork object.
+	Object *sriovtypes.OVSNetwork
+	// Used in functions that define or mutate OVSNetwork definitions. errorMsg is processed before
+	// OVSNetwork object is created.
+	errorMsg string
+	// apiClient opens api connection to the cluster.
+	apiClient *clients.Settings
+}
+
+// OVSNetworkAdditionalOptions additional options for OVSNetwork object.
+type OVSNetworkAdditionalOptions func(builder *OVSNetworkBuilder) (*OVSNetworkBuilder, error)
+
+// NewOVSNetworkBuilder creates new instance of OVSNetworkBuilder.
+func NewOVSNetworkBuilder(
+	apiClient *clients.Settings, name, nsname, targetNsname, resName string) *OVSNetworkBuilder {
+	glog.V(100).Infof(
+		"Initializing new OVSNetwork structure with the name %s in the namespace %s", name, nsname)
+
+	builder := OVSNetworkBuilder{
+		apiClient: apiClient,
+		Definition: &sriovtypes.OVSNetwork{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: nsname,
+			},
+			Spec: sriovtypes.OVSNetworkSpec{
+				ResourceName:     resName,
+				NetworkNamespace: targetNsname,
+			},
+		},
+	}
+
+	if name == "" {
+		builder.errorMsg = "OVSNetwork 'name' cannot be empty"
+	}
+
+	if nsname == "" {
+		builder.errorMsg = "OVSNetwork 'nsname' cannot be empty"
+	}
+
+	if targetNsname == "" {
+		builder.errorMsg = "OVSNetwork 'targetNsname' cannot be empty"
+	}
+
+	if resName == "" {
+		builder.errorMsg = "OVSNetwork 'resName' cannot be empty"
+	}
+
+	return &builder
+}
+
+// WithBridge sets the name of the OVS bridge the VF representors are attached to in the OVSNetwork definition spec.
+func (builder *OVSNetworkBuilder) WithBridge(bridge string) *OVSNetworkBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Setting OVSNetwork %s bridge: %s", builder.Definition.Name, bridge)
+
+	if bridge == "" {
+		builder.errorMsg = "OVSNetwork 'bridge' cannot be empty"
+
+		return builder
+	}
+
+	builder.Definition.Spec.Bridge = bridge
+
+	return builder
+}
+
+// WithVLAN sets vlan id in the OVSNetwork definition spec. Allowed vlanId range is between 0-4095.
+func (builder *OVSNetworkBuilder) WithVLAN(vlanID uint) *OVSNetworkBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Setting OVSNetwork %s vlan: %d", builder.Definition.Name, vlanID)
+
+	if vlanID > maxOVSVlanID {
+		builder.errorMsg = "invalid vlanID, allowed vlanID values are between 0-4095"
+
+		return builder
+	}
+
+	builder.Definition.Spec.Vlan = vlanID
+
+	return builder
+}
+
+// WithTrunk appends a trunk vlan range to the OVSNetwork definition spec. Allowed vlanId range is between 0-4095.
+func (builder *OVSNetworkBuilder) WithTrunk(minID, maxID uint) *OVSNetworkBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Setting OVSNetwork %s trunk: %d-%d", builder.Definition.Name, minID, maxID)
+
+	if minID > maxOVSVlanID || maxID > maxOVSVlanID {
+		builder.errorMsg = "invalid trunk, allowed vlanID values are between 0-4095"
+
+		return builder
+	}
+
+	if minID > maxID {
+		builder.errorMsg = "invalid trunk, 'minID' cannot be greater than 'maxID'"
+
+		return builder
+	}
+
+	builder.Definition.Spec.Trunk = append(builder.Definition.Spec.Trunk, &sriovtypes.TrunkConfig{
+		MinID: ptr.To(minID),
+		MaxID: ptr.To(maxID),
+	})
+
+	return builder
+}
+
+// WithMTU sets the MTU of the OVS port in the OVSNetwork definition spec.
+func (builder *OVSNetworkBuilder) WithMTU(mtu uint) *OVSNetworkBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Setting OVSNetwork %s mtu: %d", builder.Definition.Name, mtu)
+
+	if mtu < 1 || mtu > 9192 {
+		builder.errorMsg = fmt.Sprintf("invalid mtu size %d allowed mtu should be in range 1...9192", mtu)
+
+		return builder
+	}
+
+	builder.Definition.Spec.MTU = mtu
+
+	return builder
+}
+
+// WithInterfaceType sets the OVS interface type in the OVSNetwork definition spec, e.g. dpdk.
+func (builder *OVSNetworkBuilder) WithInterfaceType(interfaceType string) *OVSNetworkBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Setting OVSNetwork %s interfaceType: %s", builder.Definition.Name, interfaceType)
+
+	if interfaceType == "" {
+		builder.errorMsg = "OVSNetwork 'interfaceType' cannot be empty"
+
+		return builder
+	}
+
+	builder.Definition.Spec.InterfaceType = interfaceType
+
+	return builder
+}
+
+// WithIPAddressSupport sets ips capabilities in the OVSNetwork definition spec.
+func (builder *OVSNetworkBuilder) WithIPAddressSupport() *OVSNetworkBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Setting OVSNetwork %s ips capability", builder.Definition.Name)
+
+	builder.Definition.Spec.Capabilities = `{ "ips": true }`
+
+	return builder
+}
+
+// WithStaticIpam sets static IPAM in the OVSNetwork definition spec.
+func (builder *OVSNetworkBuilder) WithStaticIpam() *OVSNetworkBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Setting OVSNetwork %s static IPAM", builder.Definition.Name)
+
+	builder.Definition.Spec.IPAM = `{ "type": "static" }`
+
+	return builder
+}
+
+// WithOptions creates OVSNetwork with generic mutation options.
+func (builder *OVSNetworkBuilder) WithOptions(options ...OVSNetworkAdditionalOptions) *OVSNetworkBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Setting OVSNetwork additional options")
+
+	for _, option := range options {
+		if option != nil {
+			builder, err := option(builder)
+
+			if err != nil {
+				glog.V(100).Infof("Error occurred in mutation function")
+
+				builder.errorMsg = err.Error()
+
+				return builder
+			}
+		}
+	}
+
+	return builder
+}
+
+// PullOVSNetwork pulls existing OVSNetwork from cluster.
+func PullOVSNetwork(apiClient *clients.Settings, name, nsname string) (*OVSNetworkBuilder, error) {
+	glog.V(100).Infof("Pulling existing OVSNetwork name %s under namespace %s from cluster", name, nsname)
+
+	if apiClient == nil {
+		glog.V(100).Infof("The apiClient is empty")
+
+		return nil, fmt.Errorf("OVSNetwork 'apiClient' cannot be empty")
+	}
+
+	builder := OVSNetworkBuilder{
+		apiClient: apiClient,
+		Definition: &sriovtypes.OVSNetwork{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: nsname,
+			},
+		},
+	}
+
+	if name == "" {
+		glog.V(100).Infof("The name of the OVSNetwork is empty")
+
+		return nil, fmt.Errorf("OVSNetwork 'name' cannot be empty")
+	}
+
+	if nsname == "" {
+		glog.V(100).Infof("The namespace of the OVSNetwork is empty")
+
+		return nil, fmt.Errorf("OVSNetwork 'namespace' cannot be empty")
+	}
+
+	if !builder.Exists() {
+		return nil, fmt.Errorf("OVSNetwork object %s does not exist in namespace %s", name, nsname)
+	}
+
+	builder.Definition = builder.Object
+
+	return &builder, nil
+}
+
+// Get returns OVSNetwork object if found.
+func (builder *OVSNetworkBuilder) Get() (*sriovtypes.OVSNetwork, error) {
+	if valid, err := builder.validate(); !valid {
+		return nil, err
+	}
+
+	glog.V(100).Infof("Collecting OVSNetwork object %s in namespace %s",
+		builder.Definition.Name, builder.Definition.Namespace)
+
+	ovsNetwork := &sriovtypes.OVSNetwork{}
+	err := builder.apiClient.Get(context.TODO(), goclient.ObjectKey{
+		Name:      builder.Definition.Name,
+		Namespace: builder.Definition.Namespace,
+	}, ovsNetwork)
+
+	if err != nil {
+		glog.V(100).Infof("Failed to get OVSNetwork %s in namespace %s",
+			builder.Definition.Name, builder.Definition.Namespace)
+
+		return nil, err
+	}
+
+	return ovsNetwork, nil
+}
+
+// Exists checks whether the given OVSNetwork object exists in a cluster.
+func (builder *OVSNetworkBuilder) Exists() bool {
+	if valid, _ := builder.validate(); !valid {
+		return false
+	}
+
+	glog.V(100).Infof("Checking if OVSNetwork %s exists in namespace %s",
+		builder.Definition.Name, builder.Definition.Namespace)
+
+	var err error
+	builder.Object, err = builder.Get()
+
+	return err == nil || !k8serrors.IsNotFound(err)
+}
+
+// Create generates OVSNetwork in a cluster and stores the created object in struct.
+func (builder *OVSNetworkBuilder) Create() (*OVSNetworkBuilder, error) {
+	if valid, err := builder.validate(); !valid {
+		return builder, err
+	}
+
+	glog.V(100).Infof("Creating the OVSNetwork %s in namespace %s",
+		builder.Definition.Name, builder.Definition.Namespace)
+
+	if !builder.Exists() {
+		err := builder.apiClient.Create(context.TODO(), builder.Definition)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	builder.Object = builder.Definition
+
+	return builder, nil
+}
+
+// Delete removes OVSNetwork object.
+func (builder *OVSNetworkBuilder) Delete() error {
+	if valid, err := builder.validate(); !valid {
+		return err
+	}
+
+	glog.V(100).Infof("Deleting the OVSNetwork object %s in namespace %s",
+		builder.Definition.Name, builder.Definition.Namespace)
+
+	if !builder.Exists() {
+		return nil
+	}
+
+	err := builder.apiClient.Delete(context.TODO(), builder.Object)
+
+	if err != nil {
+		return err
+	}
+
+	builder.Object = nil
+
+	return nil
+}
+
+// Update renovates the existing OVSNetwork object with the OVSNetwork definition in builder.
+func (builder *OVSNetworkBuilder) Update(force bool) (*OVSNetworkBuilder, error) {
+	if valid, err := builder.validate(); !valid {
+		return builder, err
+	}
+
+	glog.V(100).Infof("Updating the OVSNetwork object %s in namespace %s",
+		builder.Definition.Name, builder.Definition.Namespace)
+
+	err := builder.apiClient.Update(context.TODO(), builder.Definition)
+
+	if err != nil {
+		if force {
+			glog.V(100).Infof(
+				msg.FailToUpdateNotification("OVSNetwork", builder.Definition.Name, builder.Definition.Namespace))
+
+			err = builder.Delete()
+
+			if err != nil {
+				glog.V(100).Infof(
+					msg.FailToUpdateError("OVSNetwork", builder.Definition.Name, builder.Definition.Namespace))
+
+				return nil, err
+			}
+
+			return builder.Create()
+		}
+
+		return nil, err
+	}
+
+	builder.Object = builder.Definition
+
+	return builder, nil
+}
+
+// validate will check that the builder and builder definition are properly initialized before
+// accessing any member fields.
+func (builder *OVSNetworkBuilder) validate() (bool, error) {
+	resourceCRD := "OVSNetwork"
+
+	if builder == nil {
+		glog.V(100).Infof("The %s builder is uninitialized", resourceCRD)
+
+		return false, fmt.Errorf("error: received nil %s builder", resourceCRD)
+	}
+
+	if builder.Definition == nil {
+		glog.V(100).Infof("The %s is undefined", resourceCRD)
+
+		builder.errorMsg = msg.UndefinedCrdObjectErrString(resourceCRD)
+	}
+
+	if builder.apiClient == nil {
+		glog.V(100).Infof("The %s builder apiclient is nil", resourceCRD)
+
+		builder.errorMsg = fmt.Sprintf("%s builder cannot have nil apiClient", resourceCRD)
+	}
+
+	if builder.errorMsg != "" {
+		glog.V(100).Infof("The %s builder has error message: %s", resourceCRD, builder.errorMsg)
+
+		return false, fmt.Errorf(builder.errorMsg)
+	}
+
+	return true, nil
+}
@@ -0,0 +1,373 @@
+package sriov
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/openshift-kni/eco-goinfra/pkg/clients"
+	"github.com/openshift-kni/eco-goinfra/pkg/schemes/sriov/sriovtypes"
+	"github.com/stretchr/testify/assert"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+)
+
+var (
+	defaultOVSNetworkName     = "ovsnetwork"
+	defaultOVSNetworkNsName   = "testnamespace"
+	defaultOVSNetworkTargetNs = "targetnamespace"
+	defaultOVSNetworkResName  = "ovsresource"
+)
+
+func TestNewOVSNetworkBuilder(t *testing.T) {
+	testCases := []struct {
+		name              string
+		nsname            string
+		targetNsname      string
+		resName           string
+		expectedErrorText string
+	}{
+		{
+			name:         defaultOVSNetworkName,
+			nsname:       defaultOVSNetworkNsName,
+			targetNsname: defaultOVSNetworkTargetNs,
+			resName:      defaultOVSNetworkResName,
+		},
+		{
+			name:              "",
+			nsname:            defaultOVSNetworkNsName,
+			targetNsname:      defaultOVSNetworkTargetNs,
+			resName:           defaultOVSNetworkResName,
+			expectedErrorText: "OVSNetwork 'name' cannot be empty",
+		},
+		{
+			name:              defaultOVSNetworkName,
+			nsname:            "",
+			targetNsname:      defaultOVSNetworkTargetNs,
+			resName:           defaultOVSNetworkResName,
+			expectedErrorText: "OVSNetwork 'nsname' cannot be empty",
+		},
+		{
+			name:              defaultOVSNetworkName,
+			nsname:            defaultOVSNetworkNsName,
+			targetNsname:      "",
+			resName:           defaultOVSNetworkResName,
+			expectedErrorText: "OVSNetwork 'targetNsname' cannot be empty",
+		},
+		{
+			name:              defaultOVSNetworkName,
+			nsname:            defaultOVSNetworkNsName,
+			targetNsname:      defaultOVSNetworkTargetNs,
+			resName:           "",
+			expectedErrorText: "OVSNetwork 'resName' cannot be empty",
+		},
+	}
+
+	for _, testCase := range testCases {
+		testBuilder := NewOVSNetworkBuilder(
+			clients.GetTestClients(clients.TestClientParams{}),
+			testCase.name, testCase.nsname, testCase.targetNsname, testCase.resName)
+		assert.Equal(t, testCase.expectedErrorText, testBuilder.errorMsg)
+
+		if testCase.expectedErrorText == "" {
+			assert.Equal(t, testCase.name, testBuilder.Definition.Name)
+			assert.Equal(t, testCase.nsname, testBuilder.Definition.Namespace)
+			assert.Equal(t, testCase.targetNsname, testBuilder.Definition.Spec.NetworkNamespace)
+			assert.Equal(t, testCase.resName, testBuilder.Definition.Spec.ResourceName)
+		}
+	}
+}
+
+func TestPullOVSNetwork(t *testing.T) {
+	testCases := []struct {
+		name                string
+		nsname              string
+		addToRuntimeObjects bool
+		client              bool
+		expectedError       error
+	}{
+		{
+			name:                defaultOVSNetworkName,
+			nsname:              defaultOVSNetworkNsName,
+			addToRuntimeObjects: true,
+			client:              true,
+			expectedError:       nil,
+		},
+		{
+			name:                "",
+			nsname:              defaultOVSNetworkNsName,
+			addToRuntimeObjects: true,
+			client:              true,
+			expectedError:       fmt.Errorf("OVSNetwork 'name' cannot be empty"),
+		},
+		{
+			name:                defaultOVSNetworkName,
+			nsname:              "",
+			addToRuntimeObjects: true,
+			client:              true,
+			expectedError:       fmt.Errorf("OVSNetwork 'namespace' cannot be empty"),
+		},
+		{
+			name:                defaultOVSNetworkName,
+			nsname:              defaultOVSNetworkNsName,
+			addToRuntimeObjects: false,
+			client:              true,
+			expectedError: fmt.Errorf(
+				"OVSNetwork object %s does not exist in namespace %s", defaultOVSNetworkName, defaultOVSNetworkNsName),
+		},
+		{
+			name:                defaultOVSNetworkName,
+			nsname:              defaultOVSNetworkNsName,
+			addToRuntimeObjects: true,
+			client:              false,
+			expectedError:       fmt.Errorf("OVSNetwork 'apiClient' cannot be empty"),
+		},
+	}
+
+	for _, testCase := range testCases {
+		var (
+			runtimeObjects []runtime.Object
+			testSettings   *clients.Settings
+		)
+
+		if testCase.addToRuntimeObjects {
+			runtimeObjects = buildDummyOVSNetworkObject()
+		}
+
+		if testCase.client {
+			testSettings = clients.GetTestClients(clients.TestClientParams{K8sMockObjects: runtimeObjects})
+		}
+
+		testBuilder, err := PullOVSNetwork(testSettings, testCase.name, testCase.nsname)
+		assert.Equal(t, testCase.expectedError, err)
+
+		if testCase.expectedError == nil {
+			assert.Equal(t, testCase.name, testBuilder.Object.Name)
+			assert.Equal(t, testCase.nsname, testBuilder.Object.Namespace)
+		}
+	}
+}
+
+func TestOVSNetworkWithBridgeAndInterfaceType(t *testing.T) {
+	testBuilder := buildValidOVSNetworkTestBuilder(buildTestOVSNetworkClientWithDummyObject()).
+		WithBridge("br-phy").WithInterfaceType("dpdk")
+	assert.Empty(t, testBuilder.errorMsg)
+	assert.Equal(t, "br-phy", testBuilder.Definition.Spec.Bridge)
+	assert.Equal(t, "dpdk", testBuilder.Definition.Spec.InterfaceType)
+
+	testBuilder = buildValidOVSNetworkTestBuilder(buildTestOVSNetworkClientWithDummyObject()).WithBridge("")
+	assert.Equal(t, "OVSNetwork 'bridge' cannot be empty", testBuilder.errorMsg)
+
+	testBuilder = buildValidOVSNetworkTestBuilder(buildTestOVSNetworkClientWithDummyObject()).WithInterfaceType("")
+	assert.Equal(t, "OVSNetwork 'interfaceType' cannot be empty", testBuilder.errorMsg)
+}
+
+func TestOVSNetworkWithVLAN(t *testing.T) {
+	testCases := []struct {
+		vlanID            uint
+		expectedErrorText string
+	}{
+		{
+			vlanID: 100,
+		},
+		{
+			vlanID: 4095,
+		},
+		{
+			vlanID:            4096,
+			expectedErrorText: "invalid vlanID, allowed vlanID values are between 0-4095",
+		},
+	}
+
+	for _, testCase := range testCases {
+		testBuilder := buildValidOVSNetworkTestBuilder(buildTestOVSNetworkClientWithDummyObject()).
+			WithVLAN(testCase.vlanID)
+		assert.Equal(t, testCase.expectedErrorText, testBuilder.errorMsg)
+
+		if testCase.expectedErrorText == "" {
+			assert.Equal(t, testCase.vlanID, testBuilder.Definition.Spec.Vlan)
+		}
+	}
+}
+
+func TestOVSNetworkWithTrunk(t *testing.T) {
+	testCases := []struct {
+		minID             uint
+		maxID             uint
+		expectedErrorText string
+	}{
+		{
+			minID: 100,
+			maxID: 200,
+		},
+		{
+			minID: 300,
+			maxID: 300,
+		},
+		{
+			minID:             200,
+			maxID:             100,
+			expectedErrorText: "invalid trunk, 'minID' cannot be greater than 'maxID'",
+		},
+		{
+			minID:             100,
+			maxID:             5000,
+			expectedErrorText: "invalid trunk, allowed vlanID values are between 0-4095",
+		},
+	}
+
+	for _, testCase := range testCases {
+		testBuilder := buildValidOVSNetworkTestBuilder(buildTestOVSNetworkClientWithDummyObject()).
+			WithTrunk(testCase.minID, testCase.maxID)
+		assert.Equal(t, testCase.expectedErrorText, testBuilder.errorMsg)
+
+		if testCase.expectedErrorText == "" {
+			assert.Equal(t, []*sriovtypes.TrunkConfig{{MinID: ptr.To(testCase.minID), MaxID: ptr.To(testCase.maxID)}},
+				testBuilder.Definition.Spec.Trunk)
+		}
+	}
+
+	testBuilder := buildValidOVSNetworkTestBuilder(buildTestOVSNetworkClientWithDummyObject()).
+		WithTrunk(10, 20).WithTrunk(30, 40)
+	assert.Len(t, testBuilder.Definition.Spec.Trunk, 2)
+}
+
+func TestOVSNetworkWithMTU(t *testing.T) {
+	testBuilder := buildValidOVSNetworkTestBuilder(buildTestOVSNetworkClientWithDummyObject()).WithMTU(9000)
+	assert.Empty(t, testBuilder.errorMsg)
+	assert.Equal(t, uint(9000), testBuilder.Definition.Spec.MTU)
+
+	testBuilder = buildValidOVSNetworkTestBuilder(buildTestOVSNetworkClientWithDummyObject()).WithMTU(0)
+	assert.Equal(t, "invalid mtu size 0 allowed mtu should be in range 1...9192", testBuilder.errorMsg)
+}
+
+func TestOVSNetworkWithCapabilitiesAndIpam(t *testing.T) {
+	testBuilder := buildValidOVSNetworkTestBuilder(buildTestOVSNetworkClientWithDummyObject()).WithIPAddressSupport()
+	assert.Equal(t, `{ "ips": true }`, testBuilder.Definition.Spec.Capabilities)
+
+	testBuilder = buildValidOVSNetworkTestBuilder(buildTestOVSNetworkClientWithDummyObject()).WithStaticIpam()
+	assert.Equal(t, `{ "type": "static" }`, testBuilder.Definition.Spec.IPAM)
+}
+
+func TestOVSNetworkWithOptions(t *testing.T) {
+	testBuilder := buildValidOVSNetworkTestBuilder(buildTestOVSNetworkClientWithDummyObject()).WithOptions(
+		func(builder *OVSNetworkBuilder) (*OVSNetworkBuilder, error) {
+			return builder, nil
+		})
+	assert.Equal(t, "", testBuilder.errorMsg)
+
+	testBuilder = buildValidOVSNetworkTestBuilder(buildTestOVSNetworkClientWithDummyObject()).WithOptions(
+		func(builder *OVSNetworkBuilder) (*OVSNetworkBuilder, error) {
+			return builder, fmt.Errorf("error")
+		})
+	assert.Equal(t, "error", testBuilder.errorMsg)
+}
+
+func TestOVSNetworkCreate(t *testing.T) {
+	testCases := []struct {
+		testOVSNetwork *OVSNetworkBuilder
+		expectedError  error
+	}{
+		{
+			testOVSNetwork: buildValidOVSNetworkTestBuilder(clients.GetTestClients(clients.TestClientParams{})),
+			expectedError:  nil,
+		},
+		{
+			testOVSNetwork: buildValidOVSNetworkTestBuilder(buildTestOVSNetworkClientWithDummyObject()),
+			expectedError:  nil,
+		},
+		{
+			testOVSNetwork: buildInvalidOVSNetworkTestBuilder(buildTestOVSNetworkClientWithDummyObject()),
+			expectedError:  fmt.Errorf("OVSNetwork 'nsname' cannot be empty"),
+		},
+	}
+
+	for _, testCase := range testCases {
+		testBuilder, err := testCase.testOVSNetwork.Create()
+		assert.Equal(t, testCase.expectedError, err)
+
+		if testCase.expectedError == nil {
+			assert.Equal(t, testBuilder.Definition.Name, testBuilder.Object.Name)
+			assert.True(t, testBuilder.Exists())
+		}
+	}
+}
+
+func TestOVSNetworkDelete(t *testing.T) {
+	testCases := []struct {
+		testOVSNetwork *OVSNetworkBuilder
+		expectedError  error
+	}{
+		{
+			testOVSNetwork: buildValidOVSNetworkTestBuilder(buildTestOVSNetworkClientWithDummyObject()),
+			expectedError:  nil,
+		},
+		{
+			testOVSNetwork: buildValidOVSNetworkTestBuilder(clients.GetTestClients(clients.TestClientParams{})),
+			expectedError:  nil,
+		},
+		{
+			testOVSNetwork: buildInvalidOVSNetworkTestBuilder(buildTestOVSNetworkClientWithDummyObject()),
+			expectedError:  fmt.Errorf("OVSNetwork 'nsname' cannot be empty"),
+		},
+	}
+
+	for _, testCase := range testCases {
+		err := testCase.testOVSNetwork.Delete()
+		assert.Equal(t, testCase.expectedError, err)
+
+		if testCase.expectedError == nil {
+			assert.Nil(t, testCase.testOVSNetwork.Object)
+			assert.False(t, testCase.testOVSNetwork.Exists())
+		}
+	}
+}
+
+func TestOVSNetworkUpdate(t *testing.T) {
+	testBuilder := buildValidOVSNetworkTestBuilder(buildTestOVSNetworkClientWithDummyObject())
+	assert.True(t, testBuilder.Exists())
+
+	testBuilder.Definition.ResourceVersion = testBuilder.Object.ResourceVersion
+	testBuilder.WithBridge("br-phy")
+
+	testBuilder, err := testBuilder.Update(false)
+	assert.Nil(t, err)
+	assert.Equal(t, "br-phy", testBuilder.Object.Spec.Bridge)
+
+	testBuilder = buildValidOVSNetworkTestBuilder(clients.GetTestClients(clients.TestClientParams{}))
+	_, err = testBuilder.Update(false)
+	assert.NotNil(t, err)
+
+	testBuilder, err = testBuilder.Update(true)
+	assert.Nil(t, err)
+	assert.True(t, testBuilder.Exists())
+}
+
+func buildValidOVSNetworkTestBuilder(apiClient *clients.Settings) *OVSNetworkBuilder {
+	return NewOVSNetworkBuilder(
+		apiClient, defaultOVSNetworkName, defaultOVSNetworkNsName, defaultOVSNetworkTargetNs, defaultOVSNetworkResName)
+}
+
+func buildInvalidOVSNetworkTestBuilder(apiClient *clients.Settings) *OVSNetworkBuilder {
+	return NewOVSNetworkBuilder(apiClient, defaultOVSNetworkName, "", defaultOVSNetworkTargetNs, defaultOVSNetworkResName)
+}
+
+func buildTestOVSNetworkClientWithDummyObject() *clients.Settings {
+	return clients.GetTestClients(clients.TestClientParams{
+		K8sMockObjects: buildDummyOVSNetworkObject(),
+	})
+}
+
+func buildDummyOVSNetworkObject() []runtime.Object {
+	return append([]runtime.Object{}, &sriovtypes.OVSNetwork{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      defaultOVSNetworkName,
+			Namespace: defaultOVSNetworkNsName,
+		},
+		Spec: sriovtypes.OVSNetworkSpec{
+			ResourceName:     defaultOVSNetworkResName,
+			NetworkNamespace: defaultOVSNetworkTargetNs,
+		},
+	})
+}
@@ -0,0 +1,227 @@
+package sriov
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/golang/glog"
+	nadV1 "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1"
+	srIovV1 "github.com/k8snetworkplumbingwg/sriov-network-operator/api/v1"
+	"github.com/openshift-kni/eco-goinfra/pkg/clients"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// PodVFAllocation describes a single SR-IOV VF attached to a pod as reported by the network-status annotation
+// and resolved against the SriovNetworkNodeState of the node running the pod.
+type PodVFAllocation struct {
+	// NetworkName is the namespaced name of the NetworkAttachmentDefinition the interface belongs to.
+	NetworkName string
+	// Interface is the name of the interface inside the pod.
+	Interface string
+	// PciAddress is the PCI address of the VF.
+	PciAddress string
+	// VFIndex is the index of the VF on its PF.
+	VFIndex int
+	// PfName is the name of the PF the VF belongs to.
+	PfName string
+	// PfPciAddress is the PCI address of the PF the VF belongs to.
+	PfPciAddress string
+	// NodeName is the name of the node running the pod.
+	NodeName string
+}
+
+// NodeResourceAllocation holds the allocatable and allocated quantity of an extended resource on a node.
+type NodeResourceAllocation struct {
+	// Allocatable is the quantity of the resource the node advertises as allocatable.
+	Allocatable int64
+	// Allocated is the sum of the requests for the resource of all non-terminated pods on the node.
+	Allocated int64
+}
+
+// GetPodVFAllocations returns the SR-IOV VFs attached to the given pod. The PCI addresses are read from the
+// pod's network-status annotation and mapped to VF index and PF using the SriovNetworkNodeState of the node
+// in the SR-IOV operator namespace.
+func GetPodVFAllocations(
+	apiClient *clients.Settings, podName, podNsName, sriovOpNsName string) ([]PodVFAllocation, error) {
+	glog.V(100).Infof("Getting SR-IOV VF allocations of pod %s in namespace %s", podName, podNsName)
+
+	if apiClient == nil {
+		glog.V(100).Infof("The apiClient is empty")
+
+		return nil, fmt.Errorf("failed to get pod VF allocations, 'apiClient' parameter is empty")
+	}
+
+	if podName == "" {
+		glog.V(100).Infof("The pod name is empty")
+
+		return nil, fmt.Errorf("failed to get pod VF allocations, 'podName' parameter is empty")
+	}
+
+	if podNsName == "" {
+		glog.V(100).Infof("The pod namespace is empty")
+
+		return nil, fmt.Errorf("failed to get pod VF allocations, 'podNsName' parameter is empty")
+	}
+
+	pod, err := apiClient.CoreV1Interface.Pods(podNsName).Get(context.TODO(), podName, metav1.GetOptions{})
+	if err != nil {
+		glog.V(100).Infof("Failed to get pod %s in namespace %s: %v", podName, podNsName, err)
+
+		return nil, err
+	}
+
+	networkStatuses, err := getPodNetworkStatuses(pod)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		vfAllocations []PodVFAllocation
+		nodeState     *NetworkNodeStateBuilder
+	)
+
+	for _, networkStatus := range networkStatuses {
+		if networkStatus.DeviceInfo == nil || networkStatus.DeviceInfo.Pci == nil ||
+			networkStatus.DeviceInfo.Pci.PciAddress == "" {
+			continue
+		}
+
+		if nodeState == nil {
+			nodeState = NewNetworkNodeStateBuilder(apiClient, pod.Spec.NodeName, sriovOpNsName)
+
+			if err := nodeState.Discover(); err != nil {
+				glog.V(100).Infof("Failed to discover SriovNetworkNodeState of node %s: %v", pod.Spec.NodeName, err)
+
+				return nil, err
+			}
+		}
+
+		vfAllocation, err := findVFAllocation(nodeState.Objects.Status.Interfaces, networkStatus.DeviceInfo.Pci)
+		if err != nil {
+			return nil, err
+		}
+
+		vfAllocation.NetworkName = networkStatus.Name
+		vfAllocation.Interface = networkStatus.Interface
+		vfAllocation.NodeName = pod.Spec.NodeName
+
+		vfAllocations = append(vfAllocations, *vfAllocation)
+	}
+
+	return vfAllocations, nil
+}
+
+// GetNodeSriovResourceAllocation returns the allocatable and allocated quantities of all the extended resources
+// with the given prefix, e.g. openshift.io/, on the given node keyed by resource name.
+func GetNodeSriovResourceAllocation(
+	apiClient *clients.Settings, nodeName, resourcePrefix string) (map[string]NodeResourceAllocation, error) {
+	glog.V(100).Infof("Getting SR-IOV resources with prefix %s allocation on node %s", resourcePrefix, nodeName)
+
+	if apiClient == nil {
+		glog.V(100).Infof("The apiClient is empty")
+
+		return nil, fmt.Errorf("failed to get node resource allocation, 'apiClient' parameter is empty")
+	}
+
+	if nodeName == "" {
+		glog.V(100).Infof("The node name is empty")
+
+		return nil, fmt.Errorf("failed to get node resource allocation, 'nodeName' parameter is empty")
+	}
+
+	if resourcePrefix == "" {
+		glog.V(100).Infof("The resource prefix is empty")
+
+		return nil, fmt.Errorf("failed to get node resource allocation, 'resourcePrefix' parameter is empty")
+	}
+
+	node, err := apiClient.CoreV1Interface.Nodes().Get(context.TODO(), nodeName, metav1.GetOptions{})
+	if err != nil {
+		glog.V(100).Infof("Failed to get node %s: %v", nodeName, err)
+
+		return nil, err
+	}
+
+	resourceAllocations := make(map[string]NodeResourceAllocation)
+
+	for resourceName, quantity := range node.Status.Allocatable {
+		if strings.HasPrefix(string(resourceName), resourcePrefix) {
+			resourceAllocations[string(resourceName)] = NodeResourceAllocation{Allocatable: quantity.Value()}
+		}
+	}
+
+	podList, err := apiClient.CoreV1Interface.Pods("").List(context.TODO(), metav1.ListOptions{
+		FieldSelector: fmt.Sprintf("spec.nodeName=%s", nodeName),
+	})
+	if err != nil {
+		glog.V(100).Infof("Failed to list pods on node %s: %v", nodeName, err)
+
+		return nil, err
+	}
+
+	for _, pod := range podList.Items {
+		if pod.Spec.NodeName != nodeName ||
+			pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+
+		for _, container := range pod.Spec.Containers {
+			for resourceName, quantity := range container.Resources.Requests {
+				if !strings.HasPrefix(string(resourceName), resourcePrefix) {
+					continue
+				}
+
+				resourceAllocation := resourceAllocations[string(resourceName)]
+				resourceAllocation.Allocated += quantity.Value()
+				resourceAllocations[string(resourceName)] = resourceAllocation
+			}
+		}
+	}
+
+	return resourceAllocations, nil
+}
+
+func getPodNetworkStatuses(pod *corev1.Pod) ([]nadV1.NetworkStatus, error) {
+	networkStatusAnnotation, ok := pod.Annotations[nadV1.NetworkStatusAnnot]
+	if !ok {
+		glog.V(100).Infof("Pod %s in namespace %s has no %s annotation",
+			pod.Name, pod.Namespace, nadV1.NetworkStatusAnnot)
+
+		return nil, fmt.Errorf("pod %s in namespace %s has no %s annotation",
+			pod.Name, pod.Namespace, nadV1.NetworkStatusAnnot)
+	}
+
+	var networkStatuses []nadV1.NetworkStatus
+
+	err := json.Unmarshal([]byte(networkStatusAnnotation), &networkStatuses)
+	if err != nil {
+		glog.V(100).Infof("Failed to unmarshal %s annotation of pod %s: %v", nadV1.NetworkStatusAnnot, pod.Name, err)
+
+		return nil, fmt.Errorf("failed to unmarshal %s annotation of pod %s in namespace %s: %w",
+			nadV1.NetworkStatusAnnot, pod.Name, pod.Namespace, err)
+	}
+
+	return networkStatuses, nil
+}
+
+func findVFAllocation(interfaces srIovV1.InterfaceExts, pciDevice *nadV1.PciDevice) (*PodVFAllocation, error) {
+	for _, pfInterface := range interfaces {
+		for _, virtualFunction := range pfInterface.VFs {
+			if virtualFunction.PciAddress != pciDevice.PciAddress {
+				continue
+			}
+
+			return &PodVFAllocation{
+				PciAddress:   virtualFunction.PciAddress,
+				VFIndex:      virtualFunction.VfID,
+				PfName:       pfInterface.Name,
+				PfPciAddress: pfInterface.PciAddress,
+			}, nil
+		}
+	}
+
+	return nil, fmt.Errorf("failed to find VF with PCI address %s in SriovNetworkNodeState", pciDevice.PciAddress)
+}
@@ -0,0 +1,179 @@
+package sriov
+
+import (
+	"fmt"
+	"testing"
+
+	nadV1 "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1"
+	srIovV1 "github.com/k8snetworkplumbingwg/sriov-network-operator/api/v1"
+	"github.com/openshift-kni/eco-goinfra/pkg/clients"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const (
+	defaultAllocationPodName   = "sriov-pod"
+	defaultAllocationPodNsName = "test-ns"
+	defaultAllocationResource  = "openshift.io/sriovnic"
+	defaultAllocationNetStatus = `[{"name":"ovn-kubernetes","interface":"eth0","default":true},` +
+		`{"name":"test-ns/sriovnet","interface":"net1","device-info":{"type":"pci","version":"1.1.0",` +
+		`"pci":{"pci-address":"0000:3b:02.1"}}}]`
+)
+
+func TestGetPodVFAllocations(t *testing.T) {
+	testCases := []struct {
+		annotations   map[string]string
+		podName       string
+		client        bool
+		expected      []PodVFAllocation
+		expectedError error
+	}{
+		{
+			annotations: map[string]string{nadV1.NetworkStatusAnnot: defaultAllocationNetStatus},
+			podName:     defaultAllocationPodName,
+			client:      true,
+			expected: []PodVFAllocation{{
+				NetworkName:  "test-ns/sriovnet",
+				Interface:    "net1",
+				PciAddress:   "0000:3b:02.1",
+				VFIndex:      1,
+				PfName:       "ens1f0",
+				PfPciAddress: "0000:3b:00.0",
+				NodeName:     defaultNodeName,
+			}},
+		},
+		{
+			annotations: map[string]string{nadV1.NetworkStatusAnnot: `[{"name":"ovn-kubernetes","default":true}]`},
+			podName:     defaultAllocationPodName,
+			client:      true,
+			expected:    nil,
+		},
+		{
+			annotations: map[string]string{nadV1.NetworkStatusAnnot: `[{"name":"test-ns/sriovnet",` +
+				`"device-info":{"pci":{"pci-address":"0000:af:02.0"}}}]`},
+			podName:       defaultAllocationPodName,
+			client:        true,
+			expectedError: fmt.Errorf("failed to find VF with PCI address 0000:af:02.0 in SriovNetworkNodeState"),
+		},
+		{
+			annotations: nil,
+			podName:     defaultAllocationPodName,
+			client:      true,
+			expectedError: fmt.Errorf("pod %s in namespace %s has no %s annotation",
+				defaultAllocationPodName, defaultAllocationPodNsName, nadV1.NetworkStatusAnnot),
+		},
+		{
+			annotations:   nil,
+			podName:       "",
+			client:        true,
+			expectedError: fmt.Errorf("failed to get pod VF allocations, 'podName' parameter is empty"),
+		},
+		{
+			annotations:   nil,
+			podName:       defaultAllocationPodName,
+			client:        false,
+			expectedError: fmt.Errorf("failed to get pod VF allocations, 'apiClient' parameter is empty"),
+		},
+	}
+
+	for _, testCase := range testCases {
+		var testSettings *clients.Settings
+
+		if testCase.client {
+			pod := buildDummyAllocationPod(defaultAllocationPodName, defaultNodeName, 1)
+			pod.Annotations = testCase.annotations
+
+			testSettings = clients.GetTestClients(clients.TestClientParams{K8sMockObjects: []runtime.Object{
+				pod, buildDummyAllocationNodeState(),
+			}})
+		}
+
+		vfAllocations, err := GetPodVFAllocations(
+			testSettings, testCase.podName, defaultAllocationPodNsName, defaultNodeNsName)
+		assert.Equal(t, testCase.expectedError, err)
+		assert.Equal(t, testCase.expected, vfAllocations)
+	}
+}
+
+func TestGetNodeSriovResourceAllocation(t *testing.T) {
+	completedPod := buildDummyAllocationPod("completed-pod", defaultNodeName, 2)
+	completedPod.Status.Phase = corev1.PodSucceeded
+
+	testSettings := clients.GetTestClients(clients.TestClientParams{K8sMockObjects: []runtime.Object{
+		&corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: defaultNodeName},
+			Status: corev1.NodeStatus{
+				Allocatable: corev1.ResourceList{
+					defaultAllocationResource: resource.MustParse("8"),
+					"openshift.io/sriovdpdk":  resource.MustParse("4"),
+					corev1.ResourceCPU:        resource.MustParse("32"),
+				},
+			},
+		},
+		buildDummyAllocationPod(defaultAllocationPodName, defaultNodeName, 1),
+		buildDummyAllocationPod("second-pod", defaultNodeName, 2),
+		buildDummyAllocationPod("other-node-pod", "test2", 3),
+		completedPod,
+	}})
+
+	resourceAllocations, err := GetNodeSriovResourceAllocation(testSettings, defaultNodeName, "openshift.io/")
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]NodeResourceAllocation{
+		defaultAllocationResource: {Allocatable: 8, Allocated: 3},
+		"openshift.io/sriovdpdk":  {Allocatable: 4, Allocated: 0},
+	}, resourceAllocations)
+
+	_, err = GetNodeSriovResourceAllocation(testSettings, "", "openshift.io/")
+	assert.Equal(t, fmt.Errorf("failed to get node resource allocation, 'nodeName' parameter is empty"), err)
+
+	_, err = GetNodeSriovResourceAllocation(testSettings, defaultNodeName, "")
+	assert.Equal(t, fmt.Errorf("failed to get node resource allocation, 'resourcePrefix' parameter is empty"), err)
+
+	_, err = GetNodeSriovResourceAllocation(nil, defaultNodeName, "openshift.io/")
+	assert.Equal(t, fmt.Errorf("failed to get node resource allocation, 'apiClient' parameter is empty"), err)
+
+	_, err = GetNodeSriovResourceAllocation(testSettings, "test3", "openshift.io/")
+	assert.NotNil(t, err)
+}
+
+func buildDummyAllocationPod(name, nodeName string, vfs int64) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: defaultAllocationPodNsName,
+		},
+		Spec: corev1.PodSpec{
+			NodeName: nodeName,
+			Containers: []corev1.Container{{
+				Name: "test",
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{
+						defaultAllocationResource: *resource.NewQuantity(vfs, resource.DecimalSI),
+					},
+				},
+			}},
+		},
+		Status: corev1.PodStatus{Phase: corev1.PodRunning},
+	}
+}
+
+func buildDummyAllocationNodeState() *srIovV1.SriovNetworkNodeState {
+	return buildNodeNetworkStateWithNics(srIovV1.InterfaceExts{
+		{
+			Name:       "ens1f0",
+			PciAddress: "0000:3b:00.0",
+			NumVfs:     2,
+			VFs: []srIovV1.VirtualFunction{
+				{PciAddress: "0000:3b:02.0", VfID: 0},
+				{PciAddress: "0000:3b:02.1", VfID: 1},
+			},
+		},
+		{
+			Name:       "ens1f1",
+			PciAddress: "0000:3b:00.1",
+		},
+	})
+}
@@ -3,16 +3,24 @@ package sriov
 import (
 	"context"
 	"fmt"
+	"regexp"
+	"time"
 
 	"github.com/golang/glog"
 	"github.com/openshift-kni/eco-goinfra/pkg/msg"
 
 	srIovV1 "github.com/k8snetworkplumbingwg/sriov-network-operator/api/v1"
-	clientSrIov "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/client/clientset/versioned"
 	"github.com/openshift-kni/eco-goinfra/pkg/clients"
 	"golang.org/x/exp/slices"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+var (
+	nicIDRegex      = regexp.MustCompile(`^[0-9a-fA-F]{4}$`)
+	pciAddressRegex = regexp.MustCompile(`^[0-9a-fA-F]{4}:[0-9a-fA-F]{2}:[0-9a-fA-F]{2}\.[0-7]$`)
 )
 
 // PolicyBuilder provides struct for srIovPolicy object containing connection to the cluster and the srIovPolicy
@@ -26,7 +34,10 @@ type PolicyBuilder struct {
 	// object is created.
 	errorMsg string
 	// apiClient opens api connection to the cluster.
-	apiClient clientSrIov.Interface
+	apiClient *clients.Settings
+	// nodeStateGenerations holds the generation of every SriovNetworkNodeState, keyed by node name, from before the
+	// srIovPolicy was created. WaitUntilApplied uses it to ignore the sync status left by the previous configuration.
+	nodeStateGenerations map[string]int64
 }
 
 // PolicyAdditionalOptions additional options for SriovNetworkNodePolicy object.
@@ -42,7 +53,7 @@ func NewPolicyBuilder(
 	nicNames []string,
 	nodeSelector map[string]string) *PolicyBuilder {
 	builder := PolicyBuilder{
-		apiClient: apiClient.ClientSrIov,
+		apiClient: apiClient,
 		Definition: &srIovV1.SriovNetworkNodePolicy{
 			ObjectMeta: metav1.ObjectMeta{
 				Name:      name,
@@ -101,6 +112,12 @@ func (builder *PolicyBuilder) WithDevType(devType string) *PolicyBuilder {
 		return builder
 	}
 
+	if devType == "vfio-pci" && builder.Definition.Spec.IsRdma {
+		builder.errorMsg = "RDMA mode is not supported with vfio-pci device type"
+
+		return builder
+	}
+
 	builder.Definition.Spec.DeviceType = devType
 
 	return builder
@@ -163,6 +180,12 @@ func (builder *PolicyBuilder) WithRDMA(rdma bool) *PolicyBuilder {
 		return builder
 	}
 
+	if rdma && builder.Definition.Spec.DeviceType == "vfio-pci" {
+		builder.errorMsg = "RDMA mode is not supported with vfio-pci device type"
+
+		return builder
+	}
+
 	builder.Definition.Spec.IsRdma = rdma
 
 	return builder
@@ -196,6 +219,156 @@ func (builder *PolicyBuilder) WithExternallyManaged(externallyManaged bool) *Pol
 	return builder
 }
 
+// WithNicVendor sets the NIC vendor id used to select PFs in the SriovNetworkNodePolicy, e.g. 15b3.
+func (builder *PolicyBuilder) WithNicVendor(vendor string) *PolicyBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Redefining SriovNetworkNodePolicy %s with nicSelector vendor: %s",
+		builder.Definition.Name, vendor)
+
+	if !nicIDRegex.MatchString(vendor) {
+		builder.errorMsg = fmt.Sprintf("invalid vendor %q, vendor should be a 4 digit hex number", vendor)
+
+		return builder
+	}
+
+	builder.Definition.Spec.NicSelector.Vendor = vendor
+
+	return builder
+}
+
+// WithNicDeviceID sets the NIC device id used to select PFs in the SriovNetworkNodePolicy, e.g. 1017.
+func (builder *PolicyBuilder) WithNicDeviceID(deviceID string) *PolicyBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Redefining SriovNetworkNodePolicy %s with nicSelector deviceID: %s",
+		builder.Definition.Name, deviceID)
+
+	if !nicIDRegex.MatchString(deviceID) {
+		builder.errorMsg = fmt.Sprintf("invalid deviceID %q, deviceID should be a 4 digit hex number", deviceID)
+
+		return builder
+	}
+
+	builder.Definition.Spec.NicSelector.DeviceID = deviceID
+
+	return builder
+}
+
+// WithRootDevices sets the PF PCI addresses used to select PFs in the SriovNetworkNodePolicy,
+// e.g. 0000:3b:00.0.
+func (builder *PolicyBuilder) WithRootDevices(rootDevices []string) *PolicyBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Redefining SriovNetworkNodePolicy %s with nicSelector rootDevices: %v",
+		builder.Definition.Name, rootDevices)
+
+	if len(rootDevices) == 0 {
+		builder.errorMsg = "SriovNetworkNodePolicy 'rootDevices' cannot be empty list"
+
+		return builder
+	}
+
+	for _, rootDevice := range rootDevices {
+		if !pciAddressRegex.MatchString(rootDevice) {
+			builder.errorMsg = fmt.Sprintf("invalid rootDevice %q, rootDevice should be a PCI address", rootDevice)
+
+			return builder
+		}
+	}
+
+	builder.Definition.Spec.NicSelector.RootDevices = rootDevices
+
+	return builder
+}
+
+// WithEswitchMode sets the eSwitchMode in the SriovNetworkNodePolicy. Allowed modes are legacy and switchdev.
+func (builder *PolicyBuilder) WithEswitchMode(eSwitchMode string) *PolicyBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Redefining SriovNetworkNodePolicy %s with eSwitchMode: %s",
+		builder.Definition.Name, eSwitchMode)
+
+	allowedEswitchModes := []string{"legacy", "switchdev"}
+
+	if !slices.Contains(allowedEswitchModes, eSwitchMode) {
+		builder.errorMsg = "invalid eSwitchMode, allowed eSwitchMode values are: legacy or switchdev"
+
+		return builder
+	}
+
+	builder.Definition.Spec.EswitchMode = eSwitchMode
+
+	return builder
+}
+
+// WithLinkType sets the PF link type in the SriovNetworkNodePolicy. Allowed link types are eth and ib.
+func (builder *PolicyBuilder) WithLinkType(linkType string) *PolicyBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Redefining SriovNetworkNodePolicy %s with linkType: %s",
+		builder.Definition.Name, linkType)
+
+	allowedLinkTypes := []string{"eth", "ETH", "ib", "IB"}
+
+	if !slices.Contains(allowedLinkTypes, linkType) {
+		builder.errorMsg = "invalid linkType, allowed linkType values are: eth or ib"
+
+		return builder
+	}
+
+	builder.Definition.Spec.LinkType = linkType
+
+	return builder
+}
+
+// WithVdpaType sets the vDPA device type in the SriovNetworkNodePolicy. Allowed types are virtio and vhost.
+func (builder *PolicyBuilder) WithVdpaType(vdpaType string) *PolicyBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Redefining SriovNetworkNodePolicy %s with vdpaType: %s",
+		builder.Definition.Name, vdpaType)
+
+	allowedVdpaTypes := []string{"virtio", "vhost"}
+
+	if !slices.Contains(allowedVdpaTypes, vdpaType) {
+		builder.errorMsg = "invalid vdpaType, allowed vdpaType values are: virtio or vhost"
+
+		return builder
+	}
+
+	builder.Definition.Spec.VdpaType = vdpaType
+
+	return builder
+}
+
+// WithExcludeTopology sets the excludeTopology option in the SriovNetworkNodePolicy, excluding the
+// NUMA node of the VFs from the advertised device plugin topology.
+func (builder *PolicyBuilder) WithExcludeTopology(excludeTopology bool) *PolicyBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Redefining SriovNetworkNodePolicy %s with"+
+		" excludeTopology: %t", builder.Definition.Name, excludeTopology)
+
+	builder.Definition.Spec.ExcludeTopology = excludeTopology
+
+	return builder
+}
+
 // WithOptions creates SriovNetworkNodePolicy with generic mutation options.
 func (builder *PolicyBuilder) WithOptions(options ...PolicyAdditionalOptions) *PolicyBuilder {
 	if valid, _ := builder.validate(); !valid {
@@ -232,7 +405,7 @@ func PullPolicy(apiClient *clients.Settings, name, nsname string) (*PolicyBuilde
 	}
 
 	builder := PolicyBuilder{
-		apiClient: apiClient.ClientSrIov,
+		apiClient: apiClient,
 		Definition: &srIovV1.SriovNetworkNodePolicy{
 			ObjectMeta: metav1.ObjectMeta{
 				Name:      name,
@@ -269,8 +442,10 @@ func (builder *PolicyBuilder) Create() (*PolicyBuilder, error) {
 	}
 
 	if !builder.Exists() {
+		builder.nodeStateGenerations = builder.getNodeStateGenerations()
+
 		var err error
-		builder.Object, err = builder.apiClient.SriovnetworkV1().
+		builder.Object, err = builder.apiClient.ClientSrIov.SriovnetworkV1().
 			SriovNetworkNodePolicies(builder.Definition.Namespace).
 			Create(context.TODO(), builder.Definition, metav1.CreateOptions{})
 
@@ -292,7 +467,8 @@ func (builder *PolicyBuilder) Delete() error {
 		return nil
 	}
 
-	err := builder.apiClient.SriovnetworkV1().SriovNetworkNodePolicies(builder.Definition.Namespace).Delete(
+	err := builder.apiClient.ClientSrIov.SriovnetworkV1().
+		SriovNetworkNodePolicies(builder.Definition.Namespace).Delete(
 		context.TODO(), builder.Definition.Name, metav1.DeleteOptions{})
 
 	if err != nil {
@@ -311,12 +487,151 @@ func (builder *PolicyBuilder) Exists() bool {
 	}
 
 	var err error
-	builder.Object, err = builder.apiClient.SriovnetworkV1().SriovNetworkNodePolicies(builder.Definition.Namespace).Get(
+	builder.Object, err = builder.apiClient.ClientSrIov.SriovnetworkV1().
+		SriovNetworkNodePolicies(builder.Definition.Namespace).Get(
 		context.TODO(), builder.Definition.Name, metav1.GetOptions{})
 
 	return err == nil || !k8serrors.IsNotFound(err)
 }
 
+// WaitUntilApplied waits for the duration of the defined timeout or until the SriovNetworkNodeState of every node
+// selected by the SriovNetworkNodePolicy reports Succeeded sync status and all the PFs matching the nicSelector
+// have at least the requested number of VFs. When the SriovNetworkNodePolicy was created by this builder, the
+// SriovNetworkNodeState must first report InProgress or a newer generation than before the creation, so that the
+// Succeeded status of the previous configuration is not taken into account.
+func (builder *PolicyBuilder) WaitUntilApplied(timeout time.Duration) error {
+	if valid, err := builder.validate(); !valid {
+		return err
+	}
+
+	glog.V(100).Infof("Waiting for the defined period until SriovNetworkNodePolicy %s in namespace %s is applied",
+		builder.Definition.Name, builder.Definition.Namespace)
+
+	if !builder.Exists() {
+		return fmt.Errorf("sriovnetworknodepolicy object %s does not exist in namespace %s",
+			builder.Definition.Name, builder.Definition.Namespace)
+	}
+
+	syncStarted := make(map[string]bool)
+
+	return wait.PollUntilContextTimeout(
+		context.TODO(), time.Second, timeout, true, func(ctx context.Context) (bool, error) {
+			nodeList, err := builder.apiClient.CoreV1Interface.Nodes().List(ctx, metav1.ListOptions{
+				LabelSelector: labels.Set(builder.Definition.Spec.NodeSelector).String(),
+			})
+
+			if err != nil {
+				glog.V(100).Infof("Failed to list nodes selected by SriovNetworkNodePolicy %s: %v",
+					builder.Definition.Name, err)
+
+				return false, nil
+			}
+
+			if len(nodeList.Items) == 0 {
+				glog.V(100).Infof("No nodes are selected by SriovNetworkNodePolicy %s", builder.Definition.Name)
+
+				return false, nil
+			}
+
+			for _, node := range nodeList.Items {
+				if !builder.isAppliedOnNode(ctx, node.Name, syncStarted) {
+					return false, nil
+				}
+			}
+
+			return true, nil
+		})
+}
+
+func (builder *PolicyBuilder) isAppliedOnNode(ctx context.Context, nodeName string, syncStarted map[string]bool) bool {
+	nodeState, err := builder.apiClient.ClientSrIov.SriovnetworkV1().
+		SriovNetworkNodeStates(builder.Definition.Namespace).Get(ctx, nodeName, metav1.GetOptions{})
+
+	if err != nil {
+		glog.V(100).Infof("Failed to get SriovNetworkNodeState %s: %v", nodeName, err)
+
+		return false
+	}
+
+	if !builder.hasSyncStarted(nodeState, syncStarted) {
+		glog.V(100).Infof("SriovNetworkNodeState %s has not started syncing SriovNetworkNodePolicy %s",
+			nodeName, builder.Definition.Name)
+
+		return false
+	}
+
+	if nodeState.Status.SyncStatus != "Succeeded" {
+		glog.V(100).Infof("SriovNetworkNodeState %s has sync status %s", nodeName, nodeState.Status.SyncStatus)
+
+		return false
+	}
+
+	var selectedInterfaces int
+
+	for index := range nodeState.Status.Interfaces {
+		nodeInterface := &nodeState.Status.Interfaces[index]
+
+		if !builder.Definition.Spec.NicSelector.Selected(nodeInterface) {
+			continue
+		}
+
+		selectedInterfaces++
+
+		if nodeInterface.NumVfs < builder.Definition.Spec.NumVfs {
+			glog.V(100).Infof("Interface %s on node %s has %d VFs, expected %d", nodeInterface.Name, nodeName,
+				nodeInterface.NumVfs, builder.Definition.Spec.NumVfs)
+
+			return false
+		}
+	}
+
+	if selectedInterfaces == 0 {
+		glog.V(100).Infof("No interfaces on node %s match SriovNetworkNodePolicy %s nicSelector",
+			nodeName, builder.Definition.Name)
+
+		return false
+	}
+
+	return true
+}
+
+// hasSyncStarted checks whether the SriovNetworkNodeState started syncing the configuration including the
+// SriovNetworkNodePolicy, which is the case once it reports InProgress or has a newer generation than before the
+// SriovNetworkNodePolicy was created. syncStarted records the nodes on which the sync was seen starting.
+func (builder *PolicyBuilder) hasSyncStarted(
+	nodeState *srIovV1.SriovNetworkNodeState, syncStarted map[string]bool) bool {
+	if builder.nodeStateGenerations == nil || syncStarted[nodeState.Name] {
+		return true
+	}
+
+	generation, found := builder.nodeStateGenerations[nodeState.Name]
+	if !found || nodeState.Status.SyncStatus == "InProgress" || nodeState.Generation > generation {
+		syncStarted[nodeState.Name] = true
+	}
+
+	return syncStarted[nodeState.Name]
+}
+
+// getNodeStateGenerations returns the generation of every SriovNetworkNodeState in the policy namespace, keyed by
+// node name. If they cannot be listed nil is returned and WaitUntilApplied does not wait for the sync to start.
+func (builder *PolicyBuilder) getNodeStateGenerations() map[string]int64 {
+	nodeStateList, err := builder.apiClient.ClientSrIov.SriovnetworkV1().
+		SriovNetworkNodeStates(builder.Definition.Namespace).List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		glog.V(100).Infof("Failed to list SriovNetworkNodeStates in namespace %s: %v", builder.Definition.Namespace, err)
+
+		return nil
+	}
+
+	nodeStateGenerations := make(map[string]int64, len(nodeStateList.Items))
+
+	for _, nodeState := range nodeStateList.Items {
+		nodeStateGenerations[nodeState.Name] = nodeState.Generation
+	}
+
+	return nodeStateGenerations
+}
+
 // validate will check that the builder and builder definition are properly initialized before
 // accessing any member fields.
 func (builder *PolicyBuilder) validate() (bool, error) {
@@ -1,12 +1,15 @@
 package sriov
 
 import (
+	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	srIovV1 "github.com/k8snetworkplumbingwg/sriov-network-operator/api/v1"
 	"github.com/openshift-kni/eco-goinfra/pkg/clients"
 	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
@@ -467,6 +470,231 @@ func TestPolicyExist(t *testing.T) {
 	}
 }
 
+func TestPolicyWithRDMAAndDevTypeConflict(t *testing.T) {
+	testSettings := buildTestClientWithDummyPolicyObject()
+
+	testBuilder := buildValidSriovPolicyTestBuilder(testSettings).WithDevType("vfio-pci").WithRDMA(true)
+	assert.Equal(t, "RDMA mode is not supported with vfio-pci device type", testBuilder.errorMsg)
+
+	testBuilder = buildValidSriovPolicyTestBuilder(testSettings).WithRDMA(true).WithDevType("vfio-pci")
+	assert.Equal(t, "RDMA mode is not supported with vfio-pci device type", testBuilder.errorMsg)
+
+	testBuilder = buildValidSriovPolicyTestBuilder(testSettings).WithRDMA(true).WithDevType("netdevice")
+	assert.Empty(t, testBuilder.errorMsg)
+	assert.True(t, testBuilder.Definition.Spec.IsRdma)
+}
+
+func TestPolicyWithNicVendorAndDeviceID(t *testing.T) {
+	testCases := []struct {
+		nicID             string
+		expectedErrorText string
+	}{
+		{
+			nicID: "15b3",
+		},
+		{
+			nicID: "8086",
+		},
+		{
+			nicID:             "15b",
+			expectedErrorText: "should be a 4 digit hex number",
+		},
+		{
+			nicID:             "zzzz",
+			expectedErrorText: "should be a 4 digit hex number",
+		},
+	}
+
+	for _, testCase := range testCases {
+		testSettings := buildTestClientWithDummyPolicyObject()
+
+		testBuilder := buildValidSriovPolicyTestBuilder(testSettings).WithNicVendor(testCase.nicID)
+		assert.Contains(t, testBuilder.errorMsg, testCase.expectedErrorText)
+
+		if testCase.expectedErrorText == "" {
+			assert.Equal(t, testCase.nicID, testBuilder.Definition.Spec.NicSelector.Vendor)
+		}
+
+		testBuilder = buildValidSriovPolicyTestBuilder(testSettings).WithNicDeviceID(testCase.nicID)
+		assert.Contains(t, testBuilder.errorMsg, testCase.expectedErrorText)
+
+		if testCase.expectedErrorText == "" {
+			assert.Equal(t, testCase.nicID, testBuilder.Definition.Spec.NicSelector.DeviceID)
+		}
+	}
+}
+
+func TestPolicyWithRootDevices(t *testing.T) {
+	testCases := []struct {
+		rootDevices       []string
+		expectedErrorText string
+	}{
+		{
+			rootDevices: []string{"0000:3b:00.0", "0000:3b:00.1"},
+		},
+		{
+			rootDevices:       []string{},
+			expectedErrorText: "SriovNetworkNodePolicy 'rootDevices' cannot be empty list",
+		},
+		{
+			rootDevices:       []string{"0000:3b:00.0", "3b:00.1"},
+			expectedErrorText: "invalid rootDevice \"3b:00.1\", rootDevice should be a PCI address",
+		},
+	}
+
+	for _, testCase := range testCases {
+		testSettings := buildTestClientWithDummyPolicyObject()
+		testBuilder := buildValidSriovPolicyTestBuilder(testSettings).WithRootDevices(testCase.rootDevices)
+		assert.Equal(t, testCase.expectedErrorText, testBuilder.errorMsg)
+
+		if testCase.expectedErrorText == "" {
+			assert.Equal(t, testCase.rootDevices, testBuilder.Definition.Spec.NicSelector.RootDevices)
+		}
+	}
+}
+
+func TestPolicyWithEswitchModeLinkTypeAndVdpaType(t *testing.T) {
+	testSettings := buildTestClientWithDummyPolicyObject()
+
+	testBuilder := buildValidSriovPolicyTestBuilder(testSettings).
+		WithEswitchMode("switchdev").WithLinkType("ib").WithVdpaType("virtio")
+	assert.Empty(t, testBuilder.errorMsg)
+	assert.Equal(t, "switchdev", testBuilder.Definition.Spec.EswitchMode)
+	assert.Equal(t, "ib", testBuilder.Definition.Spec.LinkType)
+	assert.Equal(t, "virtio", testBuilder.Definition.Spec.VdpaType)
+
+	testBuilder = buildValidSriovPolicyTestBuilder(testSettings).WithEswitchMode("offload")
+	assert.Equal(t, "invalid eSwitchMode, allowed eSwitchMode values are: legacy or switchdev", testBuilder.errorMsg)
+
+	testBuilder = buildValidSriovPolicyTestBuilder(testSettings).WithLinkType("roce")
+	assert.Equal(t, "invalid linkType, allowed linkType values are: eth or ib", testBuilder.errorMsg)
+
+	testBuilder = buildValidSriovPolicyTestBuilder(testSettings).WithVdpaType("vdpa")
+	assert.Equal(t, "invalid vdpaType, allowed vdpaType values are: virtio or vhost", testBuilder.errorMsg)
+}
+
+func TestPolicyWithExcludeTopology(t *testing.T) {
+	for _, excludeTopology := range []bool{true, false} {
+		testSettings := buildTestClientWithDummyPolicyObject()
+		testBuilder := buildValidSriovPolicyTestBuilder(testSettings).WithExcludeTopology(excludeTopology)
+		assert.Empty(t, testBuilder.errorMsg)
+		assert.Equal(t, excludeTopology, testBuilder.Definition.Spec.ExcludeTopology)
+	}
+
+	var nilBuilder *PolicyBuilder
+
+	assert.NotPanics(t, func() { nilBuilder.WithExcludeTopology(true) })
+}
+
+func TestPolicyWaitUntilApplied(t *testing.T) {
+	testCases := []struct {
+		syncStatus    string
+		numVfs        int
+		interfaceName string
+		addNode       bool
+		expectedError error
+	}{
+		{
+			syncStatus:    "Succeeded",
+			numVfs:        defaultPolicyVFNum,
+			interfaceName: defaultPolicyNICs[0],
+			addNode:       true,
+			expectedError: nil,
+		},
+		{
+			syncStatus:    "InProgress",
+			numVfs:        defaultPolicyVFNum,
+			interfaceName: defaultPolicyNICs[0],
+			addNode:       true,
+			expectedError: context.DeadlineExceeded,
+		},
+		{
+			syncStatus:    "Succeeded",
+			numVfs:        0,
+			interfaceName: defaultPolicyNICs[0],
+			addNode:       true,
+			expectedError: context.DeadlineExceeded,
+		},
+		{
+			syncStatus:    "Succeeded",
+			numVfs:        defaultPolicyVFNum,
+			interfaceName: "eth2",
+			addNode:       true,
+			expectedError: context.DeadlineExceeded,
+		},
+		{
+			syncStatus:    "Succeeded",
+			numVfs:        defaultPolicyVFNum,
+			interfaceName: defaultPolicyNICs[0],
+			addNode:       false,
+			expectedError: context.DeadlineExceeded,
+		},
+	}
+
+	for _, testCase := range testCases {
+		nodeState := buildNodeNetworkStateSyncStatus(defaultNodeName, defaultPolicyNsName, testCase.syncStatus)
+		nodeState.Status.Interfaces = srIovV1.InterfaceExts{{
+			Name:       testCase.interfaceName,
+			PciAddress: "0000:3b:00.0",
+			NumVfs:     testCase.numVfs,
+		}}
+
+		runtimeObjects := []runtime.Object{
+			buildDummySrIovPolicy(defaultPolicyName, defaultPolicyNsName),
+			nodeState,
+		}
+
+		if testCase.addNode {
+			runtimeObjects = append(runtimeObjects, &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:   defaultNodeName,
+					Labels: defaultPolicyNodeSelector,
+				},
+			})
+		}
+
+		testSettings := clients.GetTestClients(clients.TestClientParams{K8sMockObjects: runtimeObjects})
+
+		err := buildValidSriovPolicyTestBuilder(testSettings).WaitUntilApplied(time.Second)
+		assert.Equal(t, testCase.expectedError, err)
+	}
+
+	err := buildValidSriovPolicyTestBuilder(clients.GetTestClients(clients.TestClientParams{})).
+		WaitUntilApplied(time.Second)
+	assert.Equal(t, fmt.Errorf("sriovnetworknodepolicy object %s does not exist in namespace %s",
+		defaultPolicyName, defaultPolicyNsName), err)
+}
+
+func TestPolicyWaitUntilAppliedAfterCreate(t *testing.T) {
+	nodeState := buildNodeNetworkStateSyncStatus(defaultNodeName, defaultPolicyNsName, "Succeeded")
+	nodeState.Generation = 1
+	nodeState.Status.Interfaces = srIovV1.InterfaceExts{{
+		Name:       defaultPolicyNICs[0],
+		PciAddress: "0000:3b:00.0",
+		NumVfs:     defaultPolicyVFNum,
+	}}
+
+	testSettings := clients.GetTestClients(clients.TestClientParams{K8sMockObjects: []runtime.Object{
+		nodeState,
+		&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: defaultNodeName, Labels: defaultPolicyNodeSelector}},
+	}})
+
+	testBuilder, err := buildValidSriovPolicyTestBuilder(testSettings).Create()
+	assert.Nil(t, err)
+
+	// The Succeeded status is left by the previous configuration, so the policy is not applied yet.
+	err = testBuilder.WaitUntilApplied(time.Second)
+	assert.Equal(t, context.DeadlineExceeded, err)
+
+	nodeState.Generation = 2
+	_, err = testSettings.ClientSrIov.SriovnetworkV1().SriovNetworkNodeStates(defaultPolicyNsName).Update(
+		context.TODO(), nodeState, metav1.UpdateOptions{})
+	assert.Nil(t, err)
+
+	err = testBuilder.WaitUntilApplied(time.Second)
+	assert.Nil(t, err)
+}
+
 // buildValidSriovPolicyTestBuilder returns a valid PolicyBuilder for testing purposes.
 func buildValidSriovPolicyTestBuilder(apiClient *clients.Settings) *PolicyBuilder {
 	return NewPolicyBuilder(
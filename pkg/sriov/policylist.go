@@ -54,7 +54,7 @@ func ListPolicy(apiClient *clients.Settings, nsname string, options ...metav1.Li
 	for _, policy := range networkNodePoliciesList.Items {
 		copiedNetworkNodePolicy := policy
 		policyBuilder := &PolicyBuilder{
-			apiClient:  apiClient.ClientSrIov,
+			apiClient:  apiClient,
 			Object:     &copiedNetworkNodePolicy,
 			Definition: &copiedNetworkNodePolicy}
 